@@ -1,17 +1,23 @@
 package main
 
 import (
+	"context"
 	"flag"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
-	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/config"
+	"github.com/PsionicAlch/course-platform/web/pages"
 )
 
 func main() {
 	loggers := utils.CreateLoggers("ADMIN USER COMMAND")
 
-	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", "/db/migrations")
+	if err := config.SetupDatabaseConfig(); err != nil {
+		loggers.ErrorLog.Fatalln("Failed to load database config: ", err)
+	}
+
+	db, err := pages.SetupDatabase()
 	if err != nil {
 		loggers.ErrorLog.Fatalf("Failed to open database connection: %s\n", err)
 	}
@@ -33,7 +39,7 @@ func main() {
 		loggers.ErrorLog.Println("To add a new admin user you need to specifically call:\n\tmake new-admin name=\"FIRST_NAME\" surname=\"LAST_NAME\" email=\"EMAIL_ADDRESS\" password=\"PASSWORD\"")
 	}
 
-	if err := auth.NewAdminUser(*name, *surname, *email, *password); err != nil {
+	if err := auth.NewAdminUser(context.Background(), *name, *surname, *email, *password); err != nil {
 		loggers.ErrorLog.Fatalf("Failed to add new admin user: %s\n", err)
 	} else {
 		loggers.InfoLog.Printf("%s %s has been added as an admin user!", *name, *surname)
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/config"
+	"github.com/PsionicAlch/course-platform/web/pages"
+)
+
+func main() {
+	// Construct loggers.
+	loggers := utils.CreateLoggers("BACKUP")
+
+	// Check if a subcommand is provided
+	if len(os.Args) < 2 {
+		loggers.ErrorLog.Fatal("expected 'snapshot', 'list' or 'restore' subcommand")
+	}
+
+	// Get the subcommand (e.g., "snapshot", "list", "restore")
+	subcommand := os.Args[1]
+
+	if err := config.SetupBackupConfig(); err != nil {
+		loggers.ErrorLog.Fatalln("Failed to load backup config: ", err)
+	}
+
+	backups, err := pages.SetupBackup()
+	if err != nil {
+		loggers.ErrorLog.Fatalln(err)
+	}
+
+	ctx := context.Background()
+
+	// Run command.
+	switch subcommand {
+	case "snapshot":
+		loggers.InfoLog.Println("Creating database snapshot.")
+
+		snapshot, err := backups.Snapshot(ctx)
+		if err != nil {
+			loggers.ErrorLog.Fatalln(err)
+		}
+
+		loggers.InfoLog.Printf("Snapshot saved to \"%s\"!\n", snapshot)
+
+	case "list":
+		snapshots, err := backups.Snapshots()
+		if err != nil {
+			loggers.ErrorLog.Fatalln(err)
+		}
+
+		if len(snapshots) == 0 {
+			loggers.InfoLog.Printf("No snapshots found in \"%s\"\n", backups.Directory)
+		}
+
+		for _, snapshot := range snapshots {
+			loggers.InfoLog.Println(snapshot)
+		}
+
+	case "restore":
+		// Restore the given snapshot or fall back to the newest one.
+		var snapshot string
+
+		if len(os.Args) > 2 {
+			snapshot = os.Args[2]
+		} else {
+			snapshots, err := backups.Snapshots()
+			if err != nil {
+				loggers.ErrorLog.Fatalln(err)
+			}
+
+			if len(snapshots) == 0 {
+				loggers.ErrorLog.Fatalf("No snapshots found in \"%s\"\n", backups.Directory)
+			}
+
+			snapshot = snapshots[len(snapshots)-1]
+		}
+
+		loggers.InfoLog.Printf("Restoring database from \"%s\". Make sure the web server isn't running.\n", snapshot)
+
+		if err := backups.Restore(ctx, snapshot); err != nil {
+			loggers.ErrorLog.Fatalln(err)
+		}
+
+		loggers.InfoLog.Println("Database restored successfully!")
+
+	default:
+		loggers.ErrorLog.Fatalf("unknown subcommand: %s", subcommand)
+	}
+}
@@ -1,21 +1,48 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"os"
 	"time"
 
-	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/config"
 	"github.com/PsionicAlch/course-platform/web/content"
+	"github.com/PsionicAlch/course-platform/web/pages"
 )
 
 func main() {
 	startTimer := time.Now()
 
+	// Linting only reads the markdown files so it doesn't need a database connection.
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(lint(os.Args[2:]))
+	}
+
 	loggers := utils.CreateLoggers("CONTENT LOADER")
 
+	orphans := flag.String("orphans", string(content.UnpublishOrphans), "What to do with content whose markdown file was removed: unpublish, archive or delete")
+	dryRun := flag.Bool("dry-run", false, "Print what would change, along with a diff of the changed content, without writing to the database")
+	codeTheme := flag.String("code-theme", utils.DefaultCodeTheme, "The chroma style that code blocks get highlighted with")
+	flag.Parse()
+
+	policy, err := content.ParseOrphanPolicy(*orphans)
+	if err != nil {
+		loggers.ErrorLog.Fatalln(err)
+	}
+
+	if err := utils.SetCodeTheme(*codeTheme); err != nil {
+		loggers.ErrorLog.Fatalln(err)
+	}
+
 	loggers.InfoLog.Println("Creating database connection!")
 
-	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", "/db/migrations")
+	if err := config.SetupDatabaseConfig(); err != nil {
+		loggers.ErrorLog.Fatalln("Failed to load database config: ", err)
+	}
+
+	db, err := pages.SetupDatabase()
 	if err != nil {
 		loggers.ErrorLog.Fatalln("Failed to open database connection: ", err)
 	}
@@ -23,9 +50,57 @@ func main() {
 
 	loggers.InfoLog.Println("Registering content!")
 
-	content.RegisterContent(db)
+	report := content.RegisterContent(context.Background(), db, policy, *dryRun)
+
+	if len(report.Changes) == 0 {
+		loggers.InfoLog.Println("Content is already up to date!")
+	} else if *dryRun {
+		loggers.InfoLog.Printf("Dry run, nothing was written. %d change(s) would be made:\n", len(report.Changes))
+
+		if err := report.WritePlan(os.Stdout); err != nil {
+			loggers.ErrorLog.Fatalln("Failed to write plan: ", err)
+		}
+	}
+
+	if !*dryRun {
+		for _, change := range report.Changes {
+			loggers.InfoLog.Println(change)
+		}
+	}
 
 	endTimer := time.Since(startTimer)
 
 	loggers.InfoLog.Printf("Finished loading content in %s!", endTimer)
 }
+
+// lint checks the markdown files on disk and returns the status code to exit with, which isn't zero if there are any
+// problems.
+func lint(args []string) int {
+	loggers := utils.CreateLoggers("CONTENT LINTER")
+
+	flags := flag.NewFlagSet("lint", flag.ExitOnError)
+	dir := flags.String("dir", "./web/content", "Directory that contains the tutorials and courses folders")
+	offline := flags.Bool("offline", false, "Skip requesting remote image links")
+
+	// The flag set was created with flag.ExitOnError so Parse never returns an error.
+	_ = flags.Parse(args)
+
+	problems, err := content.NewLinter(os.DirFS(*dir), !*offline).Lint()
+	if err != nil {
+		loggers.ErrorLog.Println(err)
+		return 1
+	}
+
+	for _, problem := range problems {
+		loggers.ErrorLog.Println(problem)
+	}
+
+	if len(problems) > 0 {
+		loggers.ErrorLog.Printf("Found %d problem(s)\n", len(problems))
+		return 1
+	}
+
+	loggers.InfoLog.Println("No problems found!")
+
+	return 0
+}
@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+		code int
+	}{
+		{name: "Valid content", dir: "../../web/content/testdata/lint/valid", code: 0},
+		{name: "Invalid content", dir: "../../web/content/testdata/lint/invalid", code: 1},
+		{name: "Missing directory", dir: "does-not-exist", code: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := lint([]string{"-dir", tt.dir, "-offline"}); code != tt.code {
+				t.Errorf("Expected exit code %d. Got %d", tt.code, code)
+			}
+		})
+	}
+}
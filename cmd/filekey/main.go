@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"log"
 
+	migrations "github.com/PsionicAlch/course-platform/db"
 	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/content"
@@ -11,29 +13,31 @@ import (
 func main() {
 	loggers := utils.CreateLoggers("FILE KEY GENERATOR")
 
-	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", "/db/migrations")
+	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", migrations.SQLiteMigrations())
 	if err != nil {
 		loggers.ErrorLog.Fatalln("Failed to open database connection: ", err)
 	}
 	defer db.Close()
 
+	ctx := context.Background()
+
 	for {
 		key, err := content.GenerateFileKey()
 		if err != nil {
 			log.Fatalln(err)
 		}
 
-		tutorial, err := db.GetTutorialByFileKey(key)
+		tutorial, err := db.GetTutorialByFileKey(ctx, key)
 		if err != nil {
 			loggers.ErrorLog.Fatalf("Failed to try and get tutorial by file key: %s\n", err)
 		}
 
-		course, err := db.GetCourseByFileKey(key)
+		course, err := db.GetCourseByFileKey(ctx, key)
 		if err != nil {
 			loggers.ErrorLog.Fatalf("Failed to try and get course by file key: %s\n", err)
 		}
 
-		chapter, err := db.GetChapterByFileKey(key)
+		chapter, err := db.GetChapterByFileKey(ctx, key)
 		if err != nil {
 			loggers.ErrorLog.Fatalf("Failed to try and get chapter by file key: %s\n", err)
 		}
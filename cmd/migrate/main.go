@@ -1,36 +1,102 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 
-	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "modernc.org/sqlite"
 
-	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database"
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/config"
+	"github.com/PsionicAlch/course-platform/web/pages"
 )
 
+// migrationDirs are the directories that new migrations get created in, relative to the root of the project.
+var migrationDirs = []string{"./db/migrations", "./db/postgres_migrations"}
+
 func main() {
 	// Construct loggers.
 	loggers := utils.CreateLoggers("MIGRATIONS")
 
 	// Check if a subcommand is provided
 	if len(os.Args) < 2 {
-		loggers.ErrorLog.Fatal("expected 'up', 'down' or 'rollback' subcommand")
+		loggers.ErrorLog.Fatal("expected 'up', 'down', 'rollback', 'status', 'goto', 'force' or 'new' subcommand")
 	}
 
 	// Get the subcommand (e.g., "up", "down", "rollback")
 	subcommand := os.Args[1]
 
-	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", "/db/migrations")
+	// Parse the flags that come after the subcommand.
+	flags := flag.NewFlagSet(subcommand, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "Print the migrations that would run without applying them")
+	steps := flags.Int("step", 1, "Number of migrations to roll back")
+	args := parseArgs(flags, os.Args[2:])
+
+	// New migrations are only written to disk so there's no need to connect to the database.
+	if subcommand == "new" {
+		if len(args) < 1 {
+			loggers.ErrorLog.Fatal("expected a name for the new migration")
+		}
+
+		files, err := newMigration(args[0])
+		if err != nil {
+			loggers.ErrorLog.Fatalln(err)
+		}
+
+		for _, file := range files {
+			loggers.InfoLog.Printf("Created \"%s\"\n", file)
+		}
+
+		return
+	}
+
+	if err := config.SetupDatabaseConfig(); err != nil {
+		loggers.ErrorLog.Fatalln("Failed to load database config: ", err)
+	}
+
+	db, err := pages.SetupDatabase()
+	if err != nil {
+		loggers.ErrorLog.Fatalln(err)
+	}
+
+	migrations, err := db.Migrations()
+	if err != nil {
+		loggers.ErrorLog.Fatalln(err)
+	}
+
+	current, dirty, err := db.MigrationVersion()
 	if err != nil {
 		loggers.ErrorLog.Fatalln(err)
 	}
 
 	// Run command.
 	switch subcommand {
+	case "status":
+		loggers.InfoLog.Printf("Schema version: %d (expected %d)\n", current, database.LatestMigration(migrations))
+
+		if dirty {
+			loggers.InfoLog.Printf("Version %d is dirty. Fix the failed migration and run 'force <version>'.\n", current)
+		}
+
+		for _, migration := range migrations {
+			state := "pending"
+			if migration.Version <= current {
+				state = "applied"
+			}
+
+			loggers.InfoLog.Printf("%s %s\n", migrationName(migration), state)
+		}
+
 	case "up":
+		if *dryRun {
+			printPlan(loggers, migrations, current, database.LatestMigration(migrations))
+			return
+		}
+
 		loggers.InfoLog.Println("Running migrations up.")
 
 		err = db.MigrateUp()
@@ -41,6 +107,11 @@ func main() {
 		loggers.InfoLog.Println("Migrations applied successfully!")
 
 	case "down":
+		if *dryRun {
+			printPlan(loggers, migrations, current, 0)
+			return
+		}
+
 		loggers.InfoLog.Println("Running migrations down.")
 
 		err = db.MigrateDown()
@@ -51,23 +122,188 @@ func main() {
 		loggers.InfoLog.Println("Migrations removed successfully!")
 
 	case "rollback":
-		// Parse the optional `-step` flag
-		steps := 1
+		if *dryRun {
+			printPlan(loggers, migrations, current, rollbackTarget(migrations, current, *steps))
+			return
+		}
+
+		loggers.InfoLog.Printf("Rolling back %d step(s)\n", *steps)
 
-		if len(os.Args) > 2 && os.Args[2] == "-step" && len(os.Args) > 3 {
-			steps, _ = strconv.Atoi(os.Args[3]) // Convert string to int
+		err = db.Rollback(*steps)
+		if err != nil {
+			loggers.ErrorLog.Fatalln(err)
 		}
 
-		loggers.InfoLog.Printf("Rolling back %d step(s)\n", steps)
+		loggers.InfoLog.Printf("Successfully rolled back %d migration(s)\n", *steps)
+
+	case "goto":
+		version := parseVersion(loggers, args)
+
+		if !hasMigration(migrations, version) {
+			loggers.ErrorLog.Fatalf("there is no migration with version %d\n", version)
+		}
+
+		if *dryRun {
+			printPlan(loggers, migrations, current, version)
+			return
+		}
 
-		err = db.Rollback(steps)
+		loggers.InfoLog.Printf("Migrating to version %d.\n", version)
+
+		err = db.MigrateTo(version)
 		if err != nil {
 			loggers.ErrorLog.Fatalln(err)
 		}
 
-		loggers.InfoLog.Printf("Successfully rolled back %d migration(s)\n", steps)
+		loggers.InfoLog.Printf("Successfully migrated to version %d!\n", version)
+
+	case "force":
+		version := parseVersion(loggers, args)
+
+		if version != 0 && !hasMigration(migrations, version) {
+			loggers.ErrorLog.Fatalf("there is no migration with version %d\n", version)
+		}
+
+		if *dryRun {
+			loggers.InfoLog.Printf("Would mark the schema as version %d without running any migrations.\n", version)
+			return
+		}
+
+		err = db.ForceMigrationVersion(int(version))
+		if err != nil {
+			loggers.ErrorLog.Fatalln(err)
+		}
+
+		loggers.InfoLog.Printf("Schema version forced to %d!\n", version)
 
 	default:
 		loggers.ErrorLog.Fatalf("unknown subcommand: %s", subcommand)
 	}
 }
+
+// parseArgs parses the flags and returns the positional arguments. Flags are allowed both before and after the
+// positional arguments, so "goto 3 --dry-run" works the same as "goto --dry-run 3".
+func parseArgs(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+
+	for {
+		// The flag set was created with flag.ExitOnError so Parse never returns an error.
+		_ = flags.Parse(args)
+
+		if flags.NArg() == 0 {
+			return positional
+		}
+
+		positional = append(positional, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// parseVersion reads the migration version from the first positional argument.
+func parseVersion(loggers utils.Loggers, args []string) uint {
+	if len(args) < 1 {
+		loggers.ErrorLog.Fatal("expected a migration version")
+	}
+
+	version, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		loggers.ErrorLog.Fatalf("invalid migration version \"%s\"\n", args[0])
+	}
+
+	return uint(version)
+}
+
+func hasMigration(migrations []database.Migration, version uint) bool {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+func migrationName(migration database.Migration) string {
+	return fmt.Sprintf("%06d_%s", migration.Version, migration.Name)
+}
+
+// rollbackTarget works out which version the schema ends up at after rolling back the given number of steps.
+func rollbackTarget(migrations []database.Migration, current uint, steps int) uint {
+	var applied []database.Migration
+
+	for _, migration := range migrations {
+		if migration.Version <= current {
+			applied = append(applied, migration)
+		}
+	}
+
+	if steps >= len(applied) {
+		return 0
+	}
+
+	return applied[len(applied)-1-steps].Version
+}
+
+// printPlan prints the migrations that would run to move the schema from the current version to the target
+// version, in the order that they would run.
+func printPlan(loggers utils.Loggers, migrations []database.Migration, current, target uint) {
+	var plan []string
+
+	if target >= current {
+		for _, migration := range migrations {
+			if migration.Version > current && migration.Version <= target {
+				plan = append(plan, fmt.Sprintf("%s up", migrationName(migration)))
+			}
+		}
+	} else {
+		for i := len(migrations) - 1; i >= 0; i-- {
+			if migrations[i].Version <= current && migrations[i].Version > target {
+				plan = append(plan, fmt.Sprintf("%s down", migrationName(migrations[i])))
+			}
+		}
+	}
+
+	if len(plan) == 0 {
+		loggers.InfoLog.Printf("Schema is already at version %d. Nothing to do.\n", current)
+		return
+	}
+
+	loggers.InfoLog.Printf("Would migrate from version %d to version %d:\n", current, target)
+
+	for _, step := range plan {
+		loggers.InfoLog.Println(step)
+	}
+}
+
+// newMigration creates empty up and down files for the next migration in every migrations directory and returns
+// their paths.
+func newMigration(name string) ([]string, error) {
+	var version uint
+
+	for _, dir := range migrationDirs {
+		migrations, err := database.ReadMigrations(os.DirFS(dir))
+		if err != nil {
+			return nil, err
+		}
+
+		version = max(version, database.LatestMigration(migrations))
+	}
+
+	version++
+
+	var files []string
+
+	for _, dir := range migrationDirs {
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, fmt.Sprintf("%06d_%s.%s.sql", version, name, direction))
+
+			if err := os.WriteFile(file, nil, 0644); err != nil {
+				return files, err
+			}
+
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
@@ -269,3 +269,54 @@ var Discounts = []struct {
 	{"Fall Clearance Sale", "Enjoy 40% off all fall items during our clearance event.", 40, 250},
 	{"Flash 60% Off Deal", "Grab the best deals with up to 60% off select categories.", 60, 150},
 }
+
+// The lists below are used by the generator to put together titles, keywords and comments.
+
+var Topics = []string{
+	"Concurrency", "Generics", "Error Handling", "Interfaces", "Goroutines", "Channels", "Testing", "Benchmarking",
+	"Profiling", "HTTP Servers", "Middleware", "Templates", "SQLite", "PostgreSQL", "Migrations", "Authentication",
+	"Web Sockets", "gRPC", "JSON Encoding", "Reflection", "Modules", "Embedding Files", "Logging", "Context",
+	"Rate Limiting", "Caching", "Full-Text Search", "Docker", "Deployments", "Command Line Tools",
+}
+
+var TitlePatterns = []string{
+	"Getting Started with %s",
+	"A Practical Guide to %s",
+	"%s in Go: The Good Parts",
+	"Mastering %s",
+	"Common Mistakes with %s",
+	"%s from Scratch",
+	"Understanding %s",
+	"Advanced %s Patterns",
+}
+
+var Keywords = []string{
+	"go", "golang", "backend", "web development", "databases", "performance", "testing", "security", "devops",
+	"concurrency", "beginner", "intermediate", "advanced", "tooling", "architecture", "sql", "http", "cli",
+}
+
+var Sentences = []string{
+	"Lorem ipsum dolor sit amet, consectetur adipiscing elit.",
+	"Integer placerat ante ut sodales venenatis.",
+	"Donec non aliquam ligula, sit amet sollicitudin ligula.",
+	"Mauris ornare neque consequat, viverra quam non, fermentum leo.",
+	"Nullam feugiat ullamcorper ipsum, vel dapibus elit porttitor eget.",
+	"Cras rutrum, lacus at laoreet convallis, erat libero gravida ex, id accumsan augue magna id nunc.",
+	"Proin semper elit eu arcu elementum, non aliquet massa scelerisque.",
+	"Duis porta commodo dapibus.",
+	"Suspendisse pulvinar tortor purus, sit amet varius urna vehicula sit amet.",
+	"Aenean congue mi mi, a blandit lectus maximus efficitur.",
+}
+
+var Comments = []string{
+	"This was exactly what I was looking for, thank you!",
+	"Great explanation. The examples made it click for me.",
+	"Could you do a follow up on how this works in production?",
+	"I ran into an issue with the second example, the code doesn't compile for me.",
+	"Bookmarked! Coming back to this one for sure.",
+	"Clear and to the point. More of these please.",
+	"I've been doing this wrong for years.",
+	"Is there a reason you didn't use the standard library for this?",
+	"Nice write up, but I think the benchmark section could use more detail.",
+	"Shared this with my whole team.",
+}
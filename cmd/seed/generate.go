@@ -0,0 +1,529 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/payments"
+	"github.com/PsionicAlch/course-platform/web/content"
+)
+
+// paymentStatuses and refundStatuses list every status so that the generator can make sure each one shows up at
+// least once.
+var (
+	paymentStatuses = []database.PaymentStatus{
+		database.Pending,
+		database.RequiresAction,
+		database.Processing,
+		database.Succeeded,
+		database.Failed,
+		database.Cancelled,
+		database.Refunded,
+		database.Disputed,
+	}
+
+	refundStatuses = []database.RefundStatus{
+		database.RefundPending,
+		database.RefundRequiresAction,
+		database.RefundSucceeded,
+		database.RefundFailed,
+		database.RefundCancelled,
+		database.DisputeWarningNeedsResponse,
+		database.DisputeWarningUnderReview,
+		database.DisputeWarningClosed,
+		database.DisputeNeedsResponse,
+		database.DisputeUnderReview,
+		database.DisputeWon,
+		database.DisputeLost,
+	}
+)
+
+// GeneratorOptions controls how much data the generator creates.
+type GeneratorOptions struct {
+	Seed      int64
+	Users     int
+	Tutorials int
+	Courses   int
+}
+
+// DataGenerator fills the database with a large dataset. Every decision is made using a random number generator that
+// was seeded with GeneratorOptions.Seed so running it twice with the same seed against an empty database produces the
+// same users, content and relationships. Only the IDs and timestamps, which the database generates, will differ.
+type DataGenerator struct {
+	*DatabaseSeeder
+	Options GeneratorOptions
+
+	rng       *rand.Rand
+	users     []*models.UserModel
+	authors   []*models.UserModel
+	tutorials []*models.TutorialModel
+	courses   []*models.CourseModel
+	chapters  map[string][]*models.ChapterModel
+	discounts []*models.DiscountModel
+	points    map[string]int
+}
+
+func NewDataGenerator(ds *DatabaseSeeder, options GeneratorOptions) *DataGenerator {
+	return &DataGenerator{
+		DatabaseSeeder: ds,
+		Options:        options,
+		rng:            rand.New(rand.NewSource(options.Seed)),
+		chapters:       make(map[string][]*models.ChapterModel),
+		points:         make(map[string]int),
+	}
+}
+
+// Generate runs every step of the generator. The steps have to run one after the other because later steps pick
+// from the rows that earlier steps created.
+func (gen *DataGenerator) Generate(ctx context.Context) {
+	gen.InfoLog.Printf("Generating dataset with seed %d...\n", gen.Options.Seed)
+
+	gen.GenerateUsers(ctx)
+	gen.GenerateDiscounts(ctx)
+	gen.GenerateTutorials(ctx)
+	gen.GenerateCourses(ctx)
+	gen.GenerateEngagement(ctx)
+	gen.GeneratePurchases(ctx)
+
+	gen.InfoLog.Println("Finished generating dataset!")
+}
+
+func (gen *DataGenerator) GenerateUsers(ctx context.Context) {
+	gen.InfoLog.Printf("Generating %d users...\n", gen.Options.Users)
+
+	// Hashing is slow on purpose so every generated user shares the same password hash.
+	password, err := gen.Authentication.PasswordParameters.HashPassword("SuperSecurePassword123")
+	if err != nil {
+		gen.ErrorLog.Fatalf("Failed to hash password: %s\n", err)
+	}
+
+	for i := 0; i < gen.Options.Users; i++ {
+		name := Users[gen.rng.Intn(len(Users))].Name
+		surname := Users[gen.rng.Intn(len(Users))].Surname
+		email := fmt.Sprintf("%s.%s.%d@example.com", strings.ToLower(name), strings.ToLower(surname), i+1)
+
+		// Roughly 1 in 100 users are admins.
+		if gen.rng.Intn(100) == 0 {
+			err = gen.Database.NewAdminUser(ctx, name, surname, email, password)
+		} else {
+			err = gen.Database.NewUser(ctx, name, surname, email, password)
+		}
+
+		if err == database.ErrUserAlreadyExists {
+			gen.ErrorLog.Fatalf("User \"%s\" already exists. The generator needs an empty database.\n", email)
+		}
+
+		if err != nil {
+			gen.ErrorLog.Fatalf("Failed to add new user to the database: %s\n", err)
+		}
+
+		user, err := gen.Database.GetUserByEmail(ctx, email, database.All)
+		if err != nil || user == nil {
+			gen.ErrorLog.Fatalf("Failed to get new user (\"%s\") from the database: %v\n", email, err)
+		}
+
+		// Roughly 1 in 50 users are authors.
+		if gen.rng.Intn(50) == 0 {
+			if err := gen.Database.AddAuthorStatus(ctx, user.ID); err != nil {
+				gen.ErrorLog.Fatalf("Failed to give user (\"%s\") author status: %s\n", user.ID, err)
+			}
+
+			gen.authors = append(gen.authors, user)
+		}
+
+		gen.users = append(gen.users, user)
+	}
+
+	// Content needs at least one author.
+	if len(gen.authors) == 0 && len(gen.users) > 0 {
+		if err := gen.Database.AddAuthorStatus(ctx, gen.users[0].ID); err != nil {
+			gen.ErrorLog.Fatalf("Failed to give user (\"%s\") author status: %s\n", gen.users[0].ID, err)
+		}
+
+		gen.authors = append(gen.authors, gen.users[0])
+	}
+
+	gen.InfoLog.Printf("Finished generating users (%d authors)!\n", len(gen.authors))
+}
+
+func (gen *DataGenerator) GenerateDiscounts(ctx context.Context) {
+	gen.InfoLog.Println("Generating discounts...")
+
+	for _, discount := range Discounts {
+		if _, err := gen.Database.AddDiscount(ctx, discount.Title, discount.Description, uint64(discount.Amount), uint64(discount.Uses)); err != nil {
+			gen.ErrorLog.Fatalf("Failed to add new discount to the database: %s\n", err)
+		}
+	}
+
+	discounts, err := gen.Database.GetAllDiscounts(ctx)
+	if err != nil {
+		gen.ErrorLog.Fatalf("Failed to get all discounts: %s\n", err)
+	}
+
+	// Discounts come back in whatever order the database likes so sort them to keep the picks below stable.
+	sort.Slice(discounts, func(i, j int) bool {
+		return discounts[i].Title < discounts[j].Title
+	})
+
+	gen.discounts = discounts
+
+	gen.InfoLog.Println("Finished generating discounts!")
+}
+
+func (gen *DataGenerator) GenerateTutorials(ctx context.Context) {
+	gen.InfoLog.Printf("Generating %d tutorials...\n", gen.Options.Tutorials)
+
+	slugs := make([]string, 0, gen.Options.Tutorials)
+
+	gen.Database.PrepareBulkTutorials()
+
+	for i := 0; i < gen.Options.Tutorials; i++ {
+		title := fmt.Sprintf("%s Part %d", gen.title(), i+1)
+		slug := content.TitleToSlug(title)
+		body := gen.content(3 + gen.rng.Intn(6))
+
+		gen.Database.InsertTutorial(title, slug, gen.sentence(), "", "", body.HTML, body.TableOfContents, body.WordCount, body.ReadingTime, checksum(body.HTML), gen.fileKey(), gen.keywords(), "", "", 0, sql.NullTime{}, sql.NullTime{})
+
+		slugs = append(slugs, slug)
+	}
+
+	if err := gen.Database.RunBulkTutorials(ctx); err != nil {
+		gen.ErrorLog.Fatalf("Failed to insert tutorials: %s\n", err)
+	}
+
+	for _, slug := range slugs {
+		tutorial, err := gen.Database.GetTutorialBySlug(ctx, slug)
+		if err != nil || tutorial == nil {
+			gen.ErrorLog.Fatalf("Failed to get tutorial (\"%s\") from the database: %v\n", slug, err)
+		}
+
+		author := gen.authors[gen.rng.Intn(len(gen.authors))]
+		if err := gen.Database.UpdateTutorialAuthor(ctx, tutorial.ID, author.ID); err != nil {
+			gen.ErrorLog.Fatalf("Failed to update tutorial (\"%s\") author: %s\n", tutorial.ID, err)
+		}
+
+		// Leave about 1 in 10 tutorials unpublished.
+		if gen.rng.Intn(10) != 0 {
+			if err := gen.Database.PublishTutorial(ctx, tutorial.ID); err != nil {
+				gen.ErrorLog.Fatalf("Failed to publish tutorial (\"%s\"): %s\n", tutorial.ID, err)
+			}
+		}
+
+		gen.tutorials = append(gen.tutorials, tutorial)
+	}
+
+	gen.InfoLog.Println("Finished generating tutorials!")
+}
+
+func (gen *DataGenerator) GenerateCourses(ctx context.Context) {
+	gen.InfoLog.Printf("Generating %d courses...\n", gen.Options.Courses)
+
+	slugs := make([]string, 0, gen.Options.Courses)
+
+	gen.Database.PrepareBulkCourses()
+
+	for i := 0; i < gen.Options.Courses; i++ {
+		title := fmt.Sprintf("%s Course %d", gen.title(), i+1)
+		slug := content.TitleToSlug(title)
+		body := gen.content(2 + gen.rng.Intn(3))
+		courseKey := gen.fileKey()
+
+		gen.Database.InsertCourse(title, slug, gen.sentence(), "", "", body.HTML, checksum(body.HTML), courseKey, gen.keywords(), sql.NullTime{}, sql.NullTime{})
+
+		chapters := 3 + gen.rng.Intn(10)
+		for chapter := 1; chapter <= chapters; chapter++ {
+			chapterTitle := fmt.Sprintf("%s Chapter %d", title, chapter)
+			chapterBody := gen.content(3 + gen.rng.Intn(6))
+
+			gen.Database.InsertChapter(chapterTitle, content.TitleToSlug(chapterTitle), chapter, chapterBody.HTML, chapterBody.TableOfContents, chapterBody.WordCount, chapterBody.ReadingTime, checksum(chapterBody.HTML), gen.fileKey(), courseKey, sql.NullInt32{}, sql.NullTime{}, nil)
+		}
+
+		slugs = append(slugs, slug)
+	}
+
+	if err := gen.Database.RunBulkCourses(ctx); err != nil {
+		gen.ErrorLog.Fatalf("Failed to insert courses: %s\n", err)
+	}
+
+	for _, slug := range slugs {
+		course, err := gen.Database.GetCourseBySlug(ctx, slug)
+		if err != nil || course == nil {
+			gen.ErrorLog.Fatalf("Failed to get course (\"%s\") from the database: %v\n", slug, err)
+		}
+
+		author := gen.authors[gen.rng.Intn(len(gen.authors))]
+		if err := gen.Database.UpdateCourseAuthor(ctx, course.ID, author.ID); err != nil {
+			gen.ErrorLog.Fatalf("Failed to update course (\"%s\") author: %s\n", course.ID, err)
+		}
+
+		if gen.rng.Intn(10) != 0 {
+			if err := gen.Database.PublishCourse(ctx, course.ID); err != nil {
+				gen.ErrorLog.Fatalf("Failed to publish course (\"%s\"): %s\n", course.ID, err)
+			}
+		}
+
+		chapters, err := gen.Database.GetCourseChapters(ctx, course.ID)
+		if err != nil {
+			gen.ErrorLog.Fatalf("Failed to get chapters for course (\"%s\"): %s\n", course.ID, err)
+		}
+
+		gen.courses = append(gen.courses, course)
+		gen.chapters[course.ID] = chapters
+	}
+
+	gen.InfoLog.Println("Finished generating courses!")
+}
+
+// GenerateEngagement adds likes, bookmarks and comments to the tutorials.
+func (gen *DataGenerator) GenerateEngagement(ctx context.Context) {
+	if len(gen.tutorials) == 0 {
+		return
+	}
+
+	gen.InfoLog.Println("Generating likes, bookmarks and comments...")
+
+	var likes, bookmarks, comments int
+
+	for _, user := range gen.users {
+		for _, tutorial := range gen.pickTutorials(gen.rng.Intn(11)) {
+			if err := gen.Database.UserLikeTutorial(ctx, user.ID, tutorial.Slug); err != nil {
+				gen.ErrorLog.Fatalf("Failed to like tutorial (\"%s\"): %s\n", tutorial.ID, err)
+			}
+
+			likes++
+		}
+
+		for _, tutorial := range gen.pickTutorials(gen.rng.Intn(6)) {
+			if err := gen.Database.UserBookmarkTutorial(ctx, user.ID, tutorial.Slug); err != nil {
+				gen.ErrorLog.Fatalf("Failed to bookmark tutorial (\"%s\"): %s\n", tutorial.ID, err)
+			}
+
+			bookmarks++
+		}
+	}
+
+	for _, tutorial := range gen.tutorials {
+		for i := gen.rng.Intn(31); i > 0; i-- {
+			user := gen.users[gen.rng.Intn(len(gen.users))]
+
+			if _, err := gen.Database.AddCommentBySlug(ctx, Comments[gen.rng.Intn(len(Comments))], user.ID, tutorial.Slug); err != nil {
+				gen.ErrorLog.Fatalf("Failed to comment on tutorial (\"%s\"): %s\n", tutorial.ID, err)
+			}
+
+			comments++
+		}
+	}
+
+	gen.InfoLog.Printf("Finished generating %d likes, %d bookmarks and %d comments!\n", likes, bookmarks, comments)
+}
+
+// GeneratePurchases adds course purchases along with the refunds, affiliate points, chapter completions and
+// certificates that go with them.
+func (gen *DataGenerator) GeneratePurchases(ctx context.Context) {
+	if len(gen.courses) == 0 {
+		return
+	}
+
+	gen.InfoLog.Println("Generating course purchases...")
+
+	var purchases, refunds, certificates int
+
+	for _, user := range gen.users {
+		// Most users never buy anything.
+		if gen.rng.Intn(10) >= 3 {
+			continue
+		}
+
+		for _, index := range gen.rng.Perm(len(gen.courses))[:min(1+gen.rng.Intn(3), len(gen.courses))] {
+			course := gen.courses[index]
+
+			// The first few purchases go through every status so that each one shows up at least once.
+			status := database.Succeeded
+			if purchases < len(paymentStatuses) {
+				status = paymentStatuses[purchases]
+			} else if gen.rng.Intn(10) >= 7 {
+				status = paymentStatuses[gen.rng.Intn(len(paymentStatuses))]
+			}
+
+			coursePurchase := gen.purchase(ctx, user, course, status)
+			purchases++
+
+			// Refunds and disputes only happen to purchases that actually went through.
+			if status == database.Succeeded || status == database.Refunded || status == database.Disputed {
+				if refunds < len(refundStatuses) || gen.rng.Intn(10) == 0 {
+					refundStatus := refundStatuses[refunds%len(refundStatuses)]
+					if refunds >= len(refundStatuses) {
+						refundStatus = refundStatuses[gen.rng.Intn(len(refundStatuses))]
+					}
+
+					if err := gen.Database.RegisterRefund(ctx, user.ID, coursePurchase.ID, refundStatus); err != nil {
+						gen.ErrorLog.Fatalf("Failed to register refund for course purchase (\"%s\"): %s\n", coursePurchase.ID, err)
+					}
+
+					refunds++
+				}
+			}
+
+			if status != database.Succeeded {
+				continue
+			}
+
+			chapters := gen.chapters[course.ID]
+			completed := gen.rng.Intn(len(chapters) + 1)
+
+			for _, chapter := range chapters[:completed] {
+				if err := gen.Database.FinishChapter(ctx, user.ID, chapter.ID, course.ID); err != nil {
+					gen.ErrorLog.Fatalf("Failed to finish chapter (\"%s\"): %s\n", chapter.ID, err)
+				}
+			}
+
+			if completed == len(chapters) {
+				if err := gen.Database.AddCertificate(ctx, user.ID, course.ID); err != nil {
+					gen.ErrorLog.Fatalf("Failed to add certificate for course (\"%s\"): %s\n", course.ID, err)
+				}
+
+				certificates++
+			}
+		}
+	}
+
+	gen.InfoLog.Printf("Finished generating %d purchases, %d refunds and %d certificates!\n", purchases, refunds, certificates)
+}
+
+// purchase registers a course purchase with the given status. Some purchases are made with an affiliate code, a
+// discount code or the buyer's affiliate points, the same way a real checkout would.
+func (gen *DataGenerator) purchase(ctx context.Context, user *models.UserModel, course *models.CourseModel, status database.PaymentStatus) *models.CoursePurchaseModel {
+	var affiliate *models.UserModel
+	var affiliateCode, discountCode sql.NullString
+	var pointsUsed uint
+
+	amountPaid := payments.CoursePrice
+
+	if gen.rng.Intn(4) == 0 {
+		affiliate = gen.users[gen.rng.Intn(len(gen.users))]
+		if affiliate.ID != user.ID {
+			affiliateCode = database.NewNullString(affiliate.AffiliateCode)
+		} else {
+			affiliate = nil
+		}
+	}
+
+	if len(gen.discounts) > 0 && gen.rng.Intn(10) == 0 {
+		discount := gen.discounts[gen.rng.Intn(len(gen.discounts))]
+		discountCode = database.NewNullString(discount.Code)
+		amountPaid = amountPaid * float64(100-discount.Discount) / 100
+	}
+
+	if points := gen.points[user.ID]; points > 0 && gen.rng.Intn(2) == 0 {
+		pointsUsed = uint(points)
+		amountPaid = max(amountPaid-float64(points), 0)
+		gen.points[user.ID] = 0
+	}
+
+	paymentKey := fmt.Sprintf("%016x", gen.rng.Uint64())
+	checkoutSessionId := fmt.Sprintf("cs_test_%016x", gen.rng.Uint64())
+
+	if err := gen.Database.RegisterCoursePurchase(ctx, user.ID, course.ID, paymentKey, checkoutSessionId, affiliateCode, discountCode, pointsUsed, amountPaid, "", payments.PaymentToken, time.Now().Add(time.Hour)); err != nil {
+		gen.ErrorLog.Fatalf("Failed to register course purchase: %s\n", err)
+	}
+
+	coursePurchase, err := gen.Database.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+	if err != nil || coursePurchase == nil {
+		gen.ErrorLog.Fatalf("Failed to get course purchase by payment key (\"%s\"): %v\n", paymentKey, err)
+	}
+
+	if status != database.Pending {
+		if err := gen.Database.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, status); err != nil {
+			gen.ErrorLog.Fatalf("Failed to update course purchase (\"%s\") payment status: %s\n", coursePurchase.ID, err)
+		}
+	}
+
+	if affiliate != nil && status == database.Succeeded {
+		if err := gen.Database.RegisterAffiliatePointsChange(ctx, affiliate.ID, course.ID, payments.AffiliateReward, "Affiliate reward received"); err != nil {
+			gen.ErrorLog.Fatalf("Failed to reward user (\"%s\") with affiliate points: %s\n", affiliate.ID, err)
+		}
+
+		gen.points[affiliate.ID] += payments.AffiliateReward
+	}
+
+	return coursePurchase
+}
+
+// pickTutorials picks n different tutorials at random.
+func (gen *DataGenerator) pickTutorials(n int) []*models.TutorialModel {
+	n = min(n, len(gen.tutorials))
+	tutorials := make([]*models.TutorialModel, 0, n)
+
+	for _, index := range gen.rng.Perm(len(gen.tutorials))[:n] {
+		tutorials = append(tutorials, gen.tutorials[index])
+	}
+
+	return tutorials
+}
+
+func (gen *DataGenerator) title() string {
+	pattern := TitlePatterns[gen.rng.Intn(len(TitlePatterns))]
+	topic := Topics[gen.rng.Intn(len(Topics))]
+
+	return fmt.Sprintf(pattern, topic)
+}
+
+func (gen *DataGenerator) sentence() string {
+	return Sentences[gen.rng.Intn(len(Sentences))]
+}
+
+// content builds a markdown document with the given number of sections and renders it.
+func (gen *DataGenerator) content(sections int) *content.RenderedMarkdown {
+	var markdown strings.Builder
+
+	for i := 0; i < sections; i++ {
+		fmt.Fprintf(&markdown, "## %s\n\n", Topics[gen.rng.Intn(len(Topics))])
+
+		for j := 3 + gen.rng.Intn(6); j > 0; j-- {
+			markdown.WriteString(gen.sentence())
+			markdown.WriteString(" ")
+		}
+
+		markdown.WriteString("\n\n")
+	}
+
+	rendered, err := content.RenderMarkdown([]byte(markdown.String()))
+	if err != nil {
+		gen.ErrorLog.Fatalf("Failed to render generated content: %s\n", err)
+	}
+
+	return rendered
+}
+
+func (gen *DataGenerator) keywords() []string {
+	var keywords []string
+
+	for _, index := range gen.rng.Perm(len(Keywords))[:1+gen.rng.Intn(4)] {
+		keywords = append(keywords, Keywords[index])
+	}
+
+	return keywords
+}
+
+// fileKey mimics content.GenerateFileKey but uses the seeded random number generator.
+func (gen *DataGenerator) fileKey() string {
+	b := make([]byte, 64)
+	gen.rng.Read(b)
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func checksum(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
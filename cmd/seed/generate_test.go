@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	options := GeneratorOptions{Seed: 42, Users: 150, Tutorials: 20, Courses: 5}
+
+	first := generate(t, options)
+	second := generate(t, options)
+
+	if len(first) == 0 {
+		t.Fatal("Expected the generator to create data")
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		for i := range min(len(first), len(second)) {
+			if first[i] != second[i] {
+				t.Fatalf("Expected the same seed to generate the same data. First difference:\n%s\n%s", first[i], second[i])
+			}
+		}
+
+		t.Fatalf("Expected the same seed to generate the same data. Got %d and %d rows", len(first), len(second))
+	}
+
+	options.Seed = 43
+	if other := generate(t, options); reflect.DeepEqual(first, other) {
+		t.Error("Expected a different seed to generate different data")
+	}
+}
+
+// generate runs the generator against an empty fake database and describes everything it created. IDs, affiliate
+// codes, discount codes and timestamps are made by the database so rows are described by their email or slug instead.
+func generate(t *testing.T, options GeneratorOptions) []string {
+	t.Helper()
+
+	ctx := context.Background()
+	db := databasetest.NewDatabase()
+	loggers := utils.CreateLoggers("DATABASE SEEDER")
+
+	ds := &DatabaseSeeder{
+		Loggers:  loggers,
+		Database: db,
+		Authentication: &authentication.Authentication{
+			Loggers:            loggers,
+			Database:           db,
+			PasswordParameters: &authentication.PasswordParameters{SaltLength: 16, Iterations: 1, Memory: 1024, Threads: 1, KeyLength: 16},
+		},
+	}
+
+	NewDataGenerator(ds, options).Generate(ctx)
+
+	var rows []string
+
+	users, err := db.GetAllUsers(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get users: %s", err)
+	}
+
+	emails := make(map[string]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+
+		likes, err := db.CountTutorialsLikedByUser(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("Failed to count likes: %s", err)
+		}
+
+		bookmarks, err := db.CountTutorialsBookmarkedByUser(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("Failed to count bookmarks: %s", err)
+		}
+
+		rows = append(rows, fmt.Sprintf("user %s %s %s admin=%t author=%t points=%d likes=%d bookmarks=%d", user.Email, user.Name, user.Surname, user.IsAdmin, user.IsAuthor, user.AffiliatePoints, likes, bookmarks))
+	}
+
+	tutorials, err := db.GetAllTutorials(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to get tutorials: %s", err)
+	}
+
+	slugs := make(map[string]string, len(tutorials))
+	for _, tutorial := range tutorials {
+		slugs[tutorial.ID] = tutorial.Slug
+
+		rows = append(rows, fmt.Sprintf("tutorial %s %s author=%s published=%t file=%s checksum=%s", tutorial.Slug, tutorial.Title, emails[tutorial.AuthorID.String], tutorial.Published, tutorial.FileKey, tutorial.FileChecksum))
+	}
+
+	courses, err := db.GetAllCourses(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to get courses: %s", err)
+	}
+
+	for _, course := range courses {
+		slugs[course.ID] = course.Slug
+
+		chapters, err := db.GetCourseChapters(ctx, course.ID)
+		if err != nil {
+			t.Fatalf("Failed to get chapters: %s", err)
+		}
+
+		rows = append(rows, fmt.Sprintf("course %s %s author=%s published=%t file=%s chapters=%d", course.Slug, course.Title, emails[course.AuthorID.String], course.Published, course.FileKey, len(chapters)))
+	}
+
+	purchases, _, err := db.AdminGetCoursePurchases(ctx, "", "", "", "", nil, 1<<20)
+	if err != nil {
+		t.Fatalf("Failed to get course purchases: %s", err)
+	}
+
+	for _, purchase := range purchases {
+		rows = append(rows, fmt.Sprintf("purchase %s user=%s course=%s status=%s paid=%.2f points=%d affiliate=%t discount=%t", purchase.PaymentKey, emails[purchase.UserID], slugs[purchase.CourseID], purchase.PaymentStatus, purchase.AmountPaid, purchase.AffiliatePointsUsed, purchase.AffiliateCode.Valid, purchase.DiscountCode.Valid))
+	}
+
+	comments, _, err := db.AdminGetComments(ctx, "", "", "", false, nil, 1<<20)
+	if err != nil {
+		t.Fatalf("Failed to get comments: %s", err)
+	}
+
+	for _, comment := range comments {
+		rows = append(rows, fmt.Sprintf("comment user=%s tutorial=%s %s", emails[comment.UserID], slugs[comment.TutorialID], comment.Content))
+	}
+
+	// The database hands rows back in whatever order it likes.
+	sort.Strings(rows)
+
+	return rows
+}
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"sync"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
-	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/config"
+	"github.com/PsionicAlch/course-platform/web/pages"
 )
 
 type DatabaseSeeder struct {
@@ -16,7 +19,7 @@ type DatabaseSeeder struct {
 	Authentication *authentication.Authentication
 }
 
-func (ds *DatabaseSeeder) SeedUsers(wg *sync.WaitGroup) {
+func (ds *DatabaseSeeder) SeedUsers(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	ds.InfoLog.Println("Seeding users...")
@@ -27,7 +30,7 @@ func (ds *DatabaseSeeder) SeedUsers(wg *sync.WaitGroup) {
 		email := fmt.Sprintf("%s.%s@gmail.com", name, surname)
 		password := "SuperSecurePassword123"
 
-		if err := ds.Authentication.NewUser(name, surname, email, password); err != nil {
+		if err := ds.Authentication.NewUser(ctx, name, surname, email, password); err != nil {
 			ds.ErrorLog.Fatalf("Failed to add new user to the database: %s\n", err)
 		}
 	}
@@ -35,7 +38,7 @@ func (ds *DatabaseSeeder) SeedUsers(wg *sync.WaitGroup) {
 	ds.InfoLog.Println("Finished seeding users!")
 }
 
-func (ds *DatabaseSeeder) SeedAdmins(wg *sync.WaitGroup) {
+func (ds *DatabaseSeeder) SeedAdmins(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	ds.InfoLog.Println("Seeding admins...")
@@ -46,7 +49,7 @@ func (ds *DatabaseSeeder) SeedAdmins(wg *sync.WaitGroup) {
 		email := fmt.Sprintf("%s.%s@gmail.com", name, surname)
 		password := "SuperSecurePassword123"
 
-		if err := ds.Authentication.NewAdminUser(name, surname, email, password); err != nil {
+		if err := ds.Authentication.NewAdminUser(ctx, name, surname, email, password); err != nil {
 			ds.ErrorLog.Fatalf("Failed to add new admin user to the database: %s\n", err)
 		}
 	}
@@ -54,13 +57,13 @@ func (ds *DatabaseSeeder) SeedAdmins(wg *sync.WaitGroup) {
 	ds.InfoLog.Println("Finished seeding admins!")
 }
 
-func (ds *DatabaseSeeder) SeedDiscounts(wg *sync.WaitGroup) {
+func (ds *DatabaseSeeder) SeedDiscounts(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	ds.InfoLog.Printf("Seeding discounts...")
 
 	for _, discount := range Discounts {
-		if _, err := ds.Database.AddDiscount(discount.Title, discount.Description, uint64(discount.Amount), uint64(discount.Uses)); err != nil {
+		if _, err := ds.Database.AddDiscount(ctx, discount.Title, discount.Description, uint64(discount.Amount), uint64(discount.Uses)); err != nil {
 			ds.ErrorLog.Fatalf("Failed to add new discount to the database: %s\n", err)
 		}
 	}
@@ -69,9 +72,20 @@ func (ds *DatabaseSeeder) SeedDiscounts(wg *sync.WaitGroup) {
 }
 
 func main() {
+	generate := flag.Bool("generate", false, "Generate a large dataset instead of the handful of fixed users, admins and discounts")
+	seed := flag.Int64("seed", 1, "Seed for the generated dataset. The same seed always generates the same data")
+	users := flag.Int("users", 5000, "Number of users to generate")
+	tutorials := flag.Int("tutorials", 500, "Number of tutorials to generate")
+	courses := flag.Int("courses", 40, "Number of courses to generate")
+	flag.Parse()
+
 	loggers := utils.CreateLoggers("DATABASE SEEDER")
 
-	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", "/db/migrations")
+	if err := config.SetupDatabaseConfig(); err != nil {
+		loggers.ErrorLog.Fatalln("Failed to load database config: ", err)
+	}
+
+	db, err := pages.SetupDatabase()
 	if err != nil {
 		loggers.ErrorLog.Fatalln("Failed to open database connection: ", err)
 	}
@@ -88,13 +102,28 @@ func main() {
 		Authentication: auth,
 	}
 
+	ctx := context.Background()
+
+	if *generate {
+		options := GeneratorOptions{
+			Seed:      *seed,
+			Users:     *users,
+			Tutorials: *tutorials,
+			Courses:   *courses,
+		}
+
+		NewDataGenerator(ds, options).Generate(ctx)
+
+		return
+	}
+
 	wg := new(sync.WaitGroup)
 
 	wg.Add(3)
 
-	go ds.SeedUsers(wg)
-	go ds.SeedAdmins(wg)
-	go ds.SeedDiscounts(wg)
+	go ds.SeedUsers(ctx, wg)
+	go ds.SeedAdmins(ctx, wg)
+	go ds.SeedDiscounts(ctx, wg)
 
 	wg.Wait()
 }
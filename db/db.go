@@ -0,0 +1,25 @@
+// Package db embeds the SQL migrations so that they ship with every binary instead of being read from disk.
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+//go:embed postgres_migrations/*.sql
+var postgresMigrationFiles embed.FS
+
+// SQLiteMigrations returns the migrations used by the SQLite database driver.
+func SQLiteMigrations() fs.FS {
+	migrations, _ := fs.Sub(migrationFiles, "migrations")
+	return migrations
+}
+
+// PostgresMigrations returns the migrations used by the PostgreSQL database driver.
+func PostgresMigrations() fs.FS {
+	migrations, _ := fs.Sub(postgresMigrationFiles, "postgres_migrations")
+	return migrations
+}
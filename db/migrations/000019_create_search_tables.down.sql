@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_delete_courses_fts;
+
+DROP TRIGGER IF EXISTS trigger_delete_tutorials_fts;
+
+DROP TABLE IF EXISTS courses_fts;
+
+DROP TABLE IF EXISTS tutorials_fts;
@@ -0,0 +1,42 @@
+-- Full text search index for tutorials. The content column holds the rendered tutorial with all the HTML stripped out
+-- and the keywords column holds all the tutorial's keywords separated by spaces. The index gets rebuilt by the bulk
+-- import so the content column starts out empty for tutorials that were already in the database.
+CREATE VIRTUAL TABLE IF NOT EXISTS tutorials_fts USING fts5(
+    id UNINDEXED,                                                                               -- The ID of the tutorial.
+    title,                                                                                      -- The title of the tutorial.
+    description,                                                                                -- A short description of the tutorial.
+    keywords,                                                                                   -- The tutorial's keywords.
+    content,                                                                                    -- The text of the tutorial without any HTML.
+    tokenize = 'porter unicode61'
+);
+
+-- Full text search index for courses. Works the same way as the tutorials index.
+CREATE VIRTUAL TABLE IF NOT EXISTS courses_fts USING fts5(
+    id UNINDEXED,                                                                               -- The ID of the course.
+    title,                                                                                      -- The title of the course.
+    description,                                                                                -- A short description of the course.
+    keywords,                                                                                   -- The course's keywords.
+    content,                                                                                    -- The text of the course without any HTML.
+    tokenize = 'porter unicode61'
+);
+
+INSERT INTO tutorials_fts (id, title, description, keywords, content)
+SELECT t.id, t.title, t.description, COALESCE((SELECT GROUP_CONCAT(k.keyword, ' ') FROM tutorials_keywords AS tk JOIN keywords AS k ON tk.keyword_id = k.id WHERE tk.tutorial_id = t.id), ''), '' FROM tutorials AS t;
+
+INSERT INTO courses_fts (id, title, description, keywords, content)
+SELECT c.id, c.title, c.description, COALESCE((SELECT GROUP_CONCAT(k.keyword, ' ') FROM courses_keywords AS ck JOIN keywords AS k ON ck.keyword_id = k.id WHERE ck.course_id = c.id), ''), '' FROM courses AS c;
+
+-- Remove rows from the search index when the content they point to gets deleted.
+CREATE TRIGGER IF NOT EXISTS trigger_delete_tutorials_fts
+AFTER DELETE ON tutorials
+FOR EACH ROW
+BEGIN
+    DELETE FROM tutorials_fts WHERE id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS trigger_delete_courses_fts
+AFTER DELETE ON courses
+FOR EACH ROW
+BEGIN
+    DELETE FROM courses_fts WHERE id = OLD.id;
+END;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_audit_log_action;
+
+DROP INDEX IF EXISTS idx_audit_log_actor_id;
+
+DROP INDEX IF EXISTS idx_audit_log_created_at_id;
+
+DROP TABLE IF EXISTS audit_log;
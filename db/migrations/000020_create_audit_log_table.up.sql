@@ -0,0 +1,20 @@
+-- Audit log keeps a record of every privileged action taken through the admin panel. Rows are never updated or
+-- deleted, which is also why there are no foreign keys: the record has to outlive the users and content it mentions.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+
+    actor_id TEXT NOT NULL,                         -- The ID of the admin who took the action.
+    action TEXT NOT NULL,                           -- What the admin did (eg "Publish Course").
+    target_id TEXT NOT NULL,                        -- The ID of the user, tutorial, course, comment or discount that was acted on.
+    before_value TEXT NOT NULL DEFAULT '',          -- The value before the action was taken.
+    after_value TEXT NOT NULL DEFAULT '',           -- The value after the action was taken.
+    ip_address TEXT NOT NULL DEFAULT '',            -- The IP address the admin made the request from.
+
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at_id ON audit_log(created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log(action);
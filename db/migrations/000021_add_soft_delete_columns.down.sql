@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+
+DROP INDEX IF EXISTS idx_courses_deleted_at;
+
+DROP INDEX IF EXISTS idx_tutorials_deleted_at;
+
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE comments DROP COLUMN deleted_at;
+
+ALTER TABLE courses DROP COLUMN deleted_at;
+
+ALTER TABLE tutorials DROP COLUMN deleted_at;
+
+ALTER TABLE users DROP COLUMN deleted_at;
@@ -0,0 +1,17 @@
+-- Users, tutorials, courses and comments get soft deleted so that deleting them doesn't cascade into purchases, refunds
+-- and certificates. The maintenance worker permanently removes rows once they have been deleted for long enough.
+ALTER TABLE users ADD COLUMN deleted_at DATETIME DEFAULT NULL;
+
+ALTER TABLE tutorials ADD COLUMN deleted_at DATETIME DEFAULT NULL;
+
+ALTER TABLE courses ADD COLUMN deleted_at DATETIME DEFAULT NULL;
+
+ALTER TABLE comments ADD COLUMN deleted_at DATETIME DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_tutorials_deleted_at ON tutorials(deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_courses_deleted_at ON courses(deleted_at);
+
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments(deleted_at);
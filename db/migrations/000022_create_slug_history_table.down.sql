@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_slug_history_content_id;
+
+DROP INDEX IF EXISTS idx_slug_history_kind_slug;
+
+DROP TABLE IF EXISTS slug_history;
@@ -0,0 +1,13 @@
+ALTER TABLE courses DROP COLUMN reading_time;
+
+ALTER TABLE course_chapters DROP COLUMN reading_time;
+
+ALTER TABLE course_chapters DROP COLUMN word_count;
+
+ALTER TABLE course_chapters DROP COLUMN table_of_contents;
+
+ALTER TABLE tutorials DROP COLUMN reading_time;
+
+ALTER TABLE tutorials DROP COLUMN word_count;
+
+ALTER TABLE tutorials DROP COLUMN table_of_contents;
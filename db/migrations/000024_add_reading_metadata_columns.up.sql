@@ -0,0 +1,16 @@
+-- The table of contents is stored as a JSON list of headings. Tutorials and chapters that were loaded before these
+-- columns existed get filled in the next time their markdown file changes.
+ALTER TABLE tutorials ADD COLUMN table_of_contents TEXT NOT NULL DEFAULT '[]';
+
+ALTER TABLE tutorials ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE tutorials ADD COLUMN reading_time INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE course_chapters ADD COLUMN table_of_contents TEXT NOT NULL DEFAULT '[]';
+
+ALTER TABLE course_chapters ADD COLUMN word_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE course_chapters ADD COLUMN reading_time INTEGER NOT NULL DEFAULT 0;
+
+-- A course's reading time is the total reading time of its chapters. It gets updated whenever the courses are loaded.
+ALTER TABLE courses ADD COLUMN reading_time INTEGER NOT NULL DEFAULT 0;
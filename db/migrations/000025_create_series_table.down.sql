@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_tutorials_series_id;
+
+ALTER TABLE tutorials DROP COLUMN series_order;
+
+ALTER TABLE tutorials DROP COLUMN series_id;
+
+DROP INDEX IF EXISTS idx_series_slug;
+
+DROP TABLE IF EXISTS series;
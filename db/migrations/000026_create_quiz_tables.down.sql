@@ -0,0 +1,17 @@
+DROP INDEX IF EXISTS idx_quiz_attempt_answers_question_id;
+
+DROP INDEX IF EXISTS idx_quiz_attempt_answers_attempt_id;
+
+DROP TABLE IF EXISTS quiz_attempt_answers;
+
+DROP INDEX IF EXISTS idx_quiz_attempts_user_id_quiz_id;
+
+DROP TABLE IF EXISTS quiz_attempts;
+
+DROP INDEX IF EXISTS idx_quiz_questions_quiz_id_position;
+
+DROP TABLE IF EXISTS quiz_questions;
+
+DROP INDEX IF EXISTS idx_quizzes_chapter_id;
+
+DROP TABLE IF EXISTS quizzes;
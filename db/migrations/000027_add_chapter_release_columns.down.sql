@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_chapter_release_notifications_chapter_id;
+
+DROP INDEX IF EXISTS idx_chapter_release_notifications_user_id_chapter_id;
+
+DROP TABLE IF EXISTS chapter_release_notifications;
+
+ALTER TABLE course_chapters DROP COLUMN release_at;
+
+ALTER TABLE course_chapters DROP COLUMN release_after_days;
@@ -0,0 +1,5 @@
+ALTER TABLE users DROP COLUMN anonymized_at;
+
+DROP INDEX IF EXISTS idx_users_email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email);
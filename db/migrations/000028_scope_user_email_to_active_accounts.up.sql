@@ -0,0 +1,8 @@
+-- Deleted accounts no longer hold on to their email address so that the person can sign up again with it.
+DROP INDEX IF EXISTS idx_users_email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users(email) WHERE deleted_at IS NULL;
+
+-- Deleted accounts that have to be kept for the financial records get their personal details scrubbed once the
+-- retention period has passed. anonymized_at marks the accounts that have been scrubbed and can't be restored anymore.
+ALTER TABLE users ADD COLUMN anonymized_at DATETIME DEFAULT NULL;
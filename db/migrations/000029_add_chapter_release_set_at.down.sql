@@ -0,0 +1 @@
+ALTER TABLE course_chapters DROP COLUMN release_set_at;
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_update_users_updated_at ON users;
+
+DROP INDEX IF EXISTS idx_users_email;
+
+DROP TABLE IF EXISTS users;
+
+DROP FUNCTION IF EXISTS set_updated_at;
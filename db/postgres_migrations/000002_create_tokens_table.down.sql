@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tokens_token_type;
+
+DROP TABLE IF EXISTS tokens;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS whitelisted_ips (
+    id TEXT PRIMARY KEY,
+
+    user_id TEXT NOT NULL,
+    ip_address TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_whitelisted_ips_user_ip ON whitelisted_ips(user_id, ip_address);
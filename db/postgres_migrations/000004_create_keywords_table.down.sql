@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_keywords_keyword;
+
+DROP TABLE IF EXISTS keywords;
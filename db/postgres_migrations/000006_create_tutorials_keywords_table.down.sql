@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tutorials_keywords;
+
+DROP TABLE IF EXISTS tutorials_keywords;
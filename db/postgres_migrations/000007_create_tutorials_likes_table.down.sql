@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tutorials_likes_user_id;
+
+DROP TABLE IF EXISTS tutorials_likes;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tutorial_bookmarks_user_id;
+
+DROP TABLE IF EXISTS tutorials_bookmarks;
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trigger_update_courses_updated_at ON courses;
+
+DROP INDEX IF EXISTS idx_courses_author_id;
+
+DROP INDEX IF EXISTS idx_courses_published;
+
+DROP INDEX IF EXISTS idx_courses_slug;
+
+DROP INDEX IF EXISTS idx_courses_file_key;
+
+DROP TABLE IF EXISTS courses;
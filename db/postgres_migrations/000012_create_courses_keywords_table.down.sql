@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_courses_keywords;
+
+DROP TABLE IF EXISTS courses_keywords;
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trigger_update_discounts_updated_at ON discounts;
+
+DROP INDEX IF EXISTS discounts_title_code;
+
+DROP INDEX IF EXISTS discounts_title_idx;
+
+DROP TABLE IF EXISTS discounts;
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trigger_recalculate_user_affiliate_points_after_delete ON affiliate_points_history;
+
+DROP TRIGGER IF EXISTS trigger_recalculate_user_affiliate_points_after_update ON affiliate_points_history;
+
+DROP TRIGGER IF EXISTS trigger_recalculate_user_affiliate_points_after_insert ON affiliate_points_history;
+
+DROP FUNCTION IF EXISTS recalculate_user_affiliate_points;
+
+DROP INDEX IF EXISTS idx_affiliate_points_user_id;
+
+DROP TABLE IF EXISTS affiliate_points_history;
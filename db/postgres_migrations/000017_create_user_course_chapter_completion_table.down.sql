@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_user_course_chapter_completion;
+
+DROP TABLE IF EXISTS user_course_chapter_completion;
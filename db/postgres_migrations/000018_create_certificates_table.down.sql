@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_certificates_user_id_course_id;
+
+DROP TABLE IF EXISTS certificates;
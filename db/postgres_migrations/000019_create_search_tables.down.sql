@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_courses_fts_document;
+
+DROP TABLE IF EXISTS courses_fts;
+
+DROP INDEX IF EXISTS idx_tutorials_fts_document;
+
+DROP TABLE IF EXISTS tutorials_fts;
@@ -0,0 +1,49 @@
+-- Full text search index for tutorials. The content column holds the rendered tutorial with all the HTML stripped out
+-- and the keywords column holds all the tutorial's keywords separated by spaces. The index gets rebuilt by the bulk
+-- import so the content column starts out empty for tutorials that were already in the database.
+CREATE TABLE IF NOT EXISTS tutorials_fts (
+    id TEXT PRIMARY KEY,                                                                        -- The ID of the tutorial.
+
+    title TEXT NOT NULL DEFAULT '',                                                             -- The title of the tutorial.
+    description TEXT NOT NULL DEFAULT '',                                                       -- A short description of the tutorial.
+    keywords TEXT NOT NULL DEFAULT '',                                                          -- The tutorial's keywords.
+    content TEXT NOT NULL DEFAULT '',                                                           -- The text of the tutorial without any HTML.
+
+    document TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('english', keywords), 'B') ||
+        setweight(to_tsvector('english', description), 'C') ||
+        setweight(to_tsvector('english', content), 'D')
+    ) STORED,                                                                                   -- The weighted search document.
+
+    FOREIGN KEY (id) REFERENCES tutorials(id) ON DELETE CASCADE                                 -- Remove the row when the tutorial gets deleted.
+);
+
+CREATE INDEX IF NOT EXISTS idx_tutorials_fts_document ON tutorials_fts USING GIN (document);
+
+-- Full text search index for courses. Works the same way as the tutorials index.
+CREATE TABLE IF NOT EXISTS courses_fts (
+    id TEXT PRIMARY KEY,                                                                        -- The ID of the course.
+
+    title TEXT NOT NULL DEFAULT '',                                                             -- The title of the course.
+    description TEXT NOT NULL DEFAULT '',                                                       -- A short description of the course.
+    keywords TEXT NOT NULL DEFAULT '',                                                          -- The course's keywords.
+    content TEXT NOT NULL DEFAULT '',                                                           -- The text of the course without any HTML.
+
+    document TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', title), 'A') ||
+        setweight(to_tsvector('english', keywords), 'B') ||
+        setweight(to_tsvector('english', description), 'C') ||
+        setweight(to_tsvector('english', content), 'D')
+    ) STORED,                                                                                   -- The weighted search document.
+
+    FOREIGN KEY (id) REFERENCES courses(id) ON DELETE CASCADE                                   -- Remove the row when the course gets deleted.
+);
+
+CREATE INDEX IF NOT EXISTS idx_courses_fts_document ON courses_fts USING GIN (document);
+
+INSERT INTO tutorials_fts (id, title, description, keywords)
+SELECT t.id, t.title, t.description, COALESCE((SELECT STRING_AGG(k.keyword, ' ') FROM tutorials_keywords AS tk JOIN keywords AS k ON tk.keyword_id = k.id WHERE tk.tutorial_id = t.id), '') FROM tutorials AS t;
+
+INSERT INTO courses_fts (id, title, description, keywords)
+SELECT c.id, c.title, c.description, COALESCE((SELECT STRING_AGG(k.keyword, ' ') FROM courses_keywords AS ck JOIN keywords AS k ON ck.keyword_id = k.id WHERE ck.course_id = c.id), '') FROM courses AS c;
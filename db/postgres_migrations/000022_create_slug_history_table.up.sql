@@ -0,0 +1,16 @@
+-- Slug history keeps the slugs that tutorials, courses and chapters used to have so that links to an old slug can be
+-- redirected to the current one. A slug belongs to at most one piece of content of each kind. There are no foreign keys
+-- because content_id points at a different table depending on the kind.
+CREATE TABLE IF NOT EXISTS slug_history (
+    id TEXT PRIMARY KEY,
+
+    kind TEXT NOT NULL,                             -- The kind of content the slug belonged to ("tutorial", "course" or "chapter").
+    content_id TEXT NOT NULL,                       -- The ID of the tutorial, course or chapter.
+    slug TEXT NOT NULL,                             -- The old slug.
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_slug_history_kind_slug ON slug_history(kind, slug);
+
+CREATE INDEX IF NOT EXISTS idx_slug_history_content_id ON slug_history(content_id);
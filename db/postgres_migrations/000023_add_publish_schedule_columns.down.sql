@@ -0,0 +1,15 @@
+DROP INDEX IF EXISTS idx_courses_unpublish_at;
+
+DROP INDEX IF EXISTS idx_courses_publish_at;
+
+DROP INDEX IF EXISTS idx_tutorials_unpublish_at;
+
+DROP INDEX IF EXISTS idx_tutorials_publish_at;
+
+ALTER TABLE courses DROP COLUMN unpublish_at;
+
+ALTER TABLE courses DROP COLUMN publish_at;
+
+ALTER TABLE tutorials DROP COLUMN unpublish_at;
+
+ALTER TABLE tutorials DROP COLUMN publish_at;
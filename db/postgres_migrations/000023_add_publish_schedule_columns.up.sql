@@ -0,0 +1,17 @@
+-- Tutorials and courses can be scheduled to be published and unpublished from their frontmatter. The scheduler clears
+-- each column once it has acted on it so that changes made from the admin panel afterwards are left alone.
+ALTER TABLE tutorials ADD COLUMN publish_at TIMESTAMPTZ DEFAULT NULL;
+
+ALTER TABLE tutorials ADD COLUMN unpublish_at TIMESTAMPTZ DEFAULT NULL;
+
+ALTER TABLE courses ADD COLUMN publish_at TIMESTAMPTZ DEFAULT NULL;
+
+ALTER TABLE courses ADD COLUMN unpublish_at TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tutorials_publish_at ON tutorials(publish_at);
+
+CREATE INDEX IF NOT EXISTS idx_tutorials_unpublish_at ON tutorials(unpublish_at);
+
+CREATE INDEX IF NOT EXISTS idx_courses_publish_at ON courses(publish_at);
+
+CREATE INDEX IF NOT EXISTS idx_courses_unpublish_at ON courses(unpublish_at);
@@ -0,0 +1,20 @@
+-- Series link tutorials that are parts of a bigger whole, like "Advanced Concurrency Patterns in Go Part 1" and
+-- "Part 2". Series are created and removed by the content loader based on the tutorials' frontmatter.
+CREATE TABLE IF NOT EXISTS series (
+    id TEXT PRIMARY KEY,
+
+    title TEXT NOT NULL,                            -- The title of the series.
+    slug TEXT NOT NULL,                             -- URL friendly slug for the series.
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_series_slug ON series(slug);
+
+-- series_id isn't a foreign key so that SQLite is able to drop the column again when the migration gets rolled back.
+-- The content loader clears it before a series gets deleted.
+ALTER TABLE tutorials ADD COLUMN series_id TEXT DEFAULT NULL;
+
+ALTER TABLE tutorials ADD COLUMN series_order INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tutorials_series_id ON tutorials(series_id);
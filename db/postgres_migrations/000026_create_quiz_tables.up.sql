@@ -0,0 +1,63 @@
+-- A chapter can have a quiz that has to be passed before the chapter can be finished. Quizzes and their questions are
+-- created, updated and removed by the content loader based on the chapters' frontmatter.
+CREATE TABLE IF NOT EXISTS quizzes (
+    id TEXT PRIMARY KEY,
+
+    chapter_id TEXT NOT NULL,                       -- The chapter that the quiz belongs to.
+    pass_mark INTEGER NOT NULL,                     -- The percentage of questions that have to be answered correctly.
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (chapter_id) REFERENCES course_chapters(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quizzes_chapter_id ON quizzes(chapter_id);
+
+-- Questions are identified by their position in the quiz so that their statistics survive small edits to the wording.
+CREATE TABLE IF NOT EXISTS quiz_questions (
+    id TEXT PRIMARY KEY,
+
+    quiz_id TEXT NOT NULL,
+    position INTEGER NOT NULL,                      -- The position of the question in the quiz, starting at 1.
+    kind TEXT NOT NULL,                             -- "multiple_choice", "multiple_answers" or "short_text".
+    question TEXT NOT NULL,
+    options TEXT NOT NULL DEFAULT '[]',             -- JSON list of the options to pick from.
+    answers TEXT NOT NULL DEFAULT '[]',             -- JSON list of the correct options or accepted short text answers.
+
+    FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quiz_questions_quiz_id_position ON quiz_questions(quiz_id, position);
+
+CREATE TABLE IF NOT EXISTS quiz_attempts (
+    id TEXT PRIMARY KEY,
+
+    quiz_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    score INTEGER NOT NULL,                         -- The percentage of questions that were answered correctly.
+    passed INTEGER NOT NULL DEFAULT 0 CHECK (passed >= 0 AND passed <= 1),
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (quiz_id) REFERENCES quizzes(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempts_user_id_quiz_id ON quiz_attempts(user_id, quiz_id);
+
+CREATE TABLE IF NOT EXISTS quiz_attempt_answers (
+    id TEXT PRIMARY KEY,
+
+    attempt_id TEXT NOT NULL,
+    question_id TEXT NOT NULL,
+    answer TEXT NOT NULL DEFAULT '[]',              -- JSON list of the options that were picked or the text that was typed.
+    correct INTEGER NOT NULL DEFAULT 0 CHECK (correct >= 0 AND correct <= 1),
+
+    FOREIGN KEY (attempt_id) REFERENCES quiz_attempts(id) ON DELETE CASCADE,
+    FOREIGN KEY (question_id) REFERENCES quiz_questions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempt_answers_attempt_id ON quiz_attempt_answers(attempt_id);
+
+CREATE INDEX IF NOT EXISTS idx_quiz_attempt_answers_question_id ON quiz_attempt_answers(question_id);
@@ -0,0 +1,23 @@
+-- Chapters can be released some time after a course was bought. release_after_days is counted from the created_at of
+-- the learner's purchase while release_at is a fixed date that applies to every learner. Only one of them gets set.
+ALTER TABLE course_chapters ADD COLUMN release_after_days INTEGER DEFAULT NULL;
+
+ALTER TABLE course_chapters ADD COLUMN release_at TIMESTAMPTZ DEFAULT NULL;
+
+-- Learners get emailed once when a chapter unlocks for them. A row is added before the email gets sent so that a
+-- learner never gets the same email twice.
+CREATE TABLE IF NOT EXISTS chapter_release_notifications (
+    id TEXT PRIMARY KEY,
+
+    user_id TEXT NOT NULL,
+    chapter_id TEXT NOT NULL,
+
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (chapter_id) REFERENCES course_chapters(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_chapter_release_notifications_user_id_chapter_id ON chapter_release_notifications(user_id, chapter_id);
+
+CREATE INDEX IF NOT EXISTS idx_chapter_release_notifications_chapter_id ON chapter_release_notifications(chapter_id);
@@ -0,0 +1,6 @@
+-- release_set_at records when a chapter's release_after_days or release_at last changed. Learners only get emailed
+-- about releases that happen after it so that adding a release rule to a chapter doesn't email everyone whose release
+-- date has already passed. Chapters that already have a release rule count it as set now for the same reason.
+ALTER TABLE course_chapters ADD COLUMN release_set_at TIMESTAMPTZ DEFAULT NULL;
+
+UPDATE course_chapters SET release_set_at = CURRENT_TIMESTAMP WHERE release_after_days IS NOT NULL OR release_at IS NOT NULL;
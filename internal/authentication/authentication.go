@@ -1,6 +1,7 @@
 package authentication
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -44,7 +45,7 @@ func SetupAuthentication(db database.Database, ses *session.Session, authLifetim
 	return auth, nil
 }
 
-func (auth *Authentication) SignUserUp(name, surname, email, password, ipAddr string) (*models.UserModel, *http.Cookie, error) {
+func (auth *Authentication) SignUserUp(ctx context.Context, name, surname, email, password, ipAddr string) (*models.UserModel, *http.Cookie, error) {
 	hashedPassword, err := auth.PasswordParameters.HashPassword(password)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to hash user's password: %s\n", err)
@@ -59,7 +60,7 @@ func (auth *Authentication) SignUserUp(name, surname, email, password, ipAddr st
 
 	validUntil := time.Now().Add(auth.AuthenticationLifetime)
 
-	user, err := auth.Database.AddNewUser(name, surname, email, hashedPassword, token, AuthenticationToken, ipAddr, validUntil)
+	user, err := auth.Database.AddNewUser(ctx, name, surname, email, hashedPassword, token, AuthenticationToken, ipAddr, validUntil)
 	if err != nil {
 		if err == database.ErrUserAlreadyExists {
 			return nil, nil, ErrUserExists
@@ -78,8 +79,8 @@ func (auth *Authentication) SignUserUp(name, surname, email, password, ipAddr st
 	return user, cookie, nil
 }
 
-func (auth *Authentication) LogUserIn(email, password string) (*models.UserModel, *http.Cookie, error) {
-	user, err := auth.Database.GetUserByEmail(email, database.All)
+func (auth *Authentication) LogUserIn(ctx context.Context, email, password string) (*models.UserModel, *http.Cookie, error) {
+	user, err := auth.Database.GetUserByEmail(ctx, email, database.All)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to find user (\"%s\") in database: %s\n", email, err)
 		return nil, nil, err
@@ -107,7 +108,7 @@ func (auth *Authentication) LogUserIn(email, password string) (*models.UserModel
 
 	validUntil := time.Now().Add(auth.AuthenticationLifetime)
 
-	err = auth.Database.AddToken(token, AuthenticationToken, user.ID, validUntil)
+	err = auth.Database.AddToken(ctx, token, AuthenticationToken, user.ID, validUntil)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to add %s token to the database: %s\n", AuthenticationToken, err)
 		return nil, nil, err
@@ -122,7 +123,7 @@ func (auth *Authentication) LogUserIn(email, password string) (*models.UserModel
 	return user, cookie, nil
 }
 
-func (auth *Authentication) LogUserOut(cookies []*http.Cookie) (*http.Cookie, error) {
+func (auth *Authentication) LogUserOut(ctx context.Context, cookies []*http.Cookie) (*http.Cookie, error) {
 	emptyCookie := auth.CookiesManager.EmptyCookie()
 
 	for _, cookie := range cookies {
@@ -133,7 +134,7 @@ func (auth *Authentication) LogUserOut(cookies []*http.Cookie) (*http.Cookie, er
 				return emptyCookie, err
 			}
 
-			err = auth.Database.DeleteToken(authToken, AuthenticationToken)
+			err = auth.Database.DeleteToken(ctx, authToken, AuthenticationToken)
 			if err != nil {
 				if err != database.ErrNoRowsAffected {
 					auth.ErrorLog.Printf("Failed to delete authentication token: %s\n", err)
@@ -148,7 +149,7 @@ func (auth *Authentication) LogUserOut(cookies []*http.Cookie) (*http.Cookie, er
 	return emptyCookie, nil
 }
 
-func (auth *Authentication) GetUserFromAuthCookie(cookies []*http.Cookie) (*models.UserModel, error) {
+func (auth *Authentication) GetUserFromAuthCookie(ctx context.Context, cookies []*http.Cookie) (*models.UserModel, error) {
 	for _, cookie := range cookies {
 		if cookie.Name == auth.CookiesManager.CookieParams.Name {
 			authToken, err := auth.CookiesManager.Decode(cookie.Value)
@@ -157,7 +158,7 @@ func (auth *Authentication) GetUserFromAuthCookie(cookies []*http.Cookie) (*mode
 				return nil, err
 			}
 
-			token, err := auth.Database.GetToken(authToken, AuthenticationToken)
+			token, err := auth.Database.GetToken(ctx, authToken, AuthenticationToken)
 			if err != nil {
 				auth.ErrorLog.Printf("Failed to get authentication token from database: %s\n", err)
 				return nil, err
@@ -169,7 +170,7 @@ func (auth *Authentication) GetUserFromAuthCookie(cookies []*http.Cookie) (*mode
 				continue
 			}
 
-			user, err := auth.Database.GetUserByID(token.UserID, database.All)
+			user, err := auth.Database.GetUserByID(ctx, token.UserID, database.All)
 			if err != nil {
 				auth.ErrorLog.Printf("Failed to get user (\"%s\") from database: %s\n", token.UserID, err)
 				return nil, err
@@ -182,8 +183,8 @@ func (auth *Authentication) GetUserFromAuthCookie(cookies []*http.Cookie) (*mode
 	return nil, nil
 }
 
-func (auth *Authentication) GeneratePasswordResetToken(email string) (*models.UserModel, string, error) {
-	user, err := auth.Database.GetUserByEmail(email, database.All)
+func (auth *Authentication) GeneratePasswordResetToken(ctx context.Context, email string) (*models.UserModel, string, error) {
+	user, err := auth.Database.GetUserByEmail(ctx, email, database.All)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to find user (\"%s\") in database: %s\n", email, err)
 		return nil, "", err
@@ -201,7 +202,7 @@ func (auth *Authentication) GeneratePasswordResetToken(email string) (*models.Us
 
 	validUntil := time.Now().Add(auth.PasswordResetLifetime)
 
-	err = auth.Database.AddToken(token, EmailToken, user.ID, validUntil)
+	err = auth.Database.AddToken(ctx, token, EmailToken, user.ID, validUntil)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to add %s token to the database: %s\n", EmailToken, err)
 		return nil, "", err
@@ -210,8 +211,8 @@ func (auth *Authentication) GeneratePasswordResetToken(email string) (*models.Us
 	return user, token, nil
 }
 
-func (auth *Authentication) ValidateEmailToken(emailToken string) (bool, error) {
-	token, err := auth.Database.GetToken(emailToken, EmailToken)
+func (auth *Authentication) ValidateEmailToken(ctx context.Context, emailToken string) (bool, error) {
+	token, err := auth.Database.GetToken(ctx, emailToken, EmailToken)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to get email token from database: %s\n", err)
 		return false, err
@@ -220,8 +221,8 @@ func (auth *Authentication) ValidateEmailToken(emailToken string) (bool, error)
 	return ValidateToken(token, EmailToken), nil
 }
 
-func (auth *Authentication) GetUserFromEmailToken(emailToken string) (*models.UserModel, error) {
-	user, err := auth.Database.GetUserByToken(emailToken, EmailToken, database.All)
+func (auth *Authentication) GetUserFromEmailToken(ctx context.Context, emailToken string) (*models.UserModel, error) {
+	user, err := auth.Database.GetUserByToken(ctx, emailToken, EmailToken, database.All)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to get user using password reset token from database: %s\n", err)
 		return nil, err
@@ -230,26 +231,57 @@ func (auth *Authentication) GetUserFromEmailToken(emailToken string) (*models.Us
 	return user, nil
 }
 
-func (auth *Authentication) ChangeUserPassword(user *models.UserModel, password string) error {
+// GenerateDataExportToken creates a token that lets the user download a copy of their data for the next
+// DataExportLifetime.
+func (auth *Authentication) GenerateDataExportToken(ctx context.Context, user *models.UserModel) (string, time.Time, error) {
+	token, err := database.GenerateToken()
+	if err != nil {
+		auth.ErrorLog.Printf("Failed to generate new data export token for user (\"%s\"): %s\n", user.ID, err)
+		return "", time.Time{}, err
+	}
+
+	validUntil := time.Now().Add(DataExportLifetime)
+
+	err = auth.Database.AddToken(ctx, token, DataExportToken, user.ID, validUntil)
+	if err != nil {
+		auth.ErrorLog.Printf("Failed to add %s token to the database: %s\n", DataExportToken, err)
+		return "", time.Time{}, err
+	}
+
+	return token, validUntil, nil
+}
+
+// ValidateDataExportToken checks that the data export token is still valid and that it belongs to the given user.
+func (auth *Authentication) ValidateDataExportToken(ctx context.Context, dataExportToken string, user *models.UserModel) (bool, error) {
+	token, err := auth.Database.GetToken(ctx, dataExportToken, DataExportToken)
+	if err != nil {
+		auth.ErrorLog.Printf("Failed to get data export token from database: %s\n", err)
+		return false, err
+	}
+
+	return ValidateToken(token, DataExportToken) && token.UserID == user.ID, nil
+}
+
+func (auth *Authentication) ChangeUserPassword(ctx context.Context, user *models.UserModel, password string) error {
 	hashedPassword, err := auth.PasswordParameters.HashPassword(password)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to hash user's password: %s\n", err)
 		return err
 	}
 
-	err = auth.Database.UpdateUserPassword(user.ID, hashedPassword)
+	err = auth.Database.UpdateUserPassword(ctx, user.ID, hashedPassword)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to update user's password in the database: %s\n", err)
 		return err
 	}
 
-	err = auth.Database.DeleteAllTokens(user.Email, AuthenticationToken)
+	err = auth.Database.DeleteAllTokens(ctx, user.Email, AuthenticationToken)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to delete all of the user's (\"%s\") %s tokens: %s\n", user.Email, AuthenticationToken, err)
 		return err
 	}
 
-	err = auth.Database.DeleteAllTokens(user.Email, EmailToken)
+	err = auth.Database.DeleteAllTokens(ctx, user.Email, EmailToken)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to delete all of the user's (\"%s\") %s tokens: %s\n", user.Email, EmailToken, err)
 		return err
@@ -258,8 +290,8 @@ func (auth *Authentication) ChangeUserPassword(user *models.UserModel, password
 	return nil
 }
 
-func (auth *Authentication) DeleteEmailToken(token string) error {
-	err := auth.Database.DeleteToken(token, EmailToken)
+func (auth *Authentication) DeleteEmailToken(ctx context.Context, token string) error {
+	err := auth.Database.DeleteToken(ctx, token, EmailToken)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to email token from the database: %s\n", err)
 		return err
@@ -268,14 +300,14 @@ func (auth *Authentication) DeleteEmailToken(token string) error {
 	return nil
 }
 
-func (auth *Authentication) NewAdminUser(name, surname, email, password string) error {
+func (auth *Authentication) NewAdminUser(ctx context.Context, name, surname, email, password string) error {
 	hashedPassword, err := auth.PasswordParameters.HashPassword(password)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to hash user's password: %s\n", err)
 		return err
 	}
 
-	if err := auth.Database.NewAdminUser(name, surname, email, hashedPassword); err != nil {
+	if err := auth.Database.NewAdminUser(ctx, name, surname, email, hashedPassword); err != nil {
 		auth.ErrorLog.Printf("Failed to add new admin user: %s\n", err)
 		return err
 	}
@@ -283,14 +315,14 @@ func (auth *Authentication) NewAdminUser(name, surname, email, password string)
 	return nil
 }
 
-func (auth *Authentication) NewUser(name, surname, email, password string) error {
+func (auth *Authentication) NewUser(ctx context.Context, name, surname, email, password string) error {
 	hashedPassword, err := auth.PasswordParameters.HashPassword(password)
 	if err != nil {
 		auth.ErrorLog.Printf("Failed to hash user's password: %s\n", err)
 		return err
 	}
 
-	if err := auth.Database.NewUser(name, surname, email, hashedPassword); err != nil {
+	if err := auth.Database.NewUser(ctx, name, surname, email, hashedPassword); err != nil {
 		auth.ErrorLog.Printf("Failed to add new user: %s\n", err)
 		return err
 	}
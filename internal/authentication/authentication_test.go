@@ -34,6 +34,14 @@ func TestGetUserFromEmailToken(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestGenerateDataExportToken(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestValidateDataExportToken(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestChangeUserPassword(t *testing.T) {
 	// TODO: Implement.
 }
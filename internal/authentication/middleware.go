@@ -16,7 +16,7 @@ type AuthenticationEmail interface {
 func (auth *Authentication) SetUserWithEmail(email AuthenticationEmail) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			user, err := auth.GetUserFromAuthCookie(r.Cookies())
+			user, err := auth.GetUserFromAuthCookie(r.Context(), r.Cookies())
 			if err != nil {
 				auth.ErrorLog.Printf("Failed to get user from cookies: %s\n", err)
 				user = nil
@@ -30,7 +30,7 @@ func (auth *Authentication) SetUserWithEmail(email AuthenticationEmail) func(nex
 					auth.ErrorLog.Printf("Failed to get ip address from r.RemoteAddr: %s\n", err)
 				}
 
-				userIpAddresses, err := auth.Database.GetUserIpAddresses(user.ID)
+				userIpAddresses, err := auth.Database.GetUserIpAddresses(r.Context(), user.ID)
 				if err != nil {
 					auth.ErrorLog.Printf("Failed to get user's (\"%s\") whitelisted IP addresses: %s\n", user.Email, err)
 				} else {
@@ -53,7 +53,7 @@ func (auth *Authentication) SetUserWithEmail(email AuthenticationEmail) func(nex
 
 func (auth *Authentication) SetUser(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		user, err := auth.GetUserFromAuthCookie(r.Cookies())
+		user, err := auth.GetUserFromAuthCookie(r.Context(), r.Cookies())
 		if err != nil {
 			auth.ErrorLog.Printf("Failed to get user from cookies: %s\n", err)
 			user = nil
@@ -128,3 +128,22 @@ func (auth *Authentication) AllowAdmin(redirectURL string) func(next http.Handle
 		})
 	}
 }
+
+// AllowAuthor only lets authors and admins through. Everyone else gets sent to the redirect URL.
+func (auth *Authentication) AllowAuthor(redirectURL string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUserFromRequest(r)
+			if user == nil || (!user.IsAuthor && !user.IsAdmin) {
+				if redirectURL != "" {
+					auth.Session.SetRedirectURL(r.Context(), r.URL.Path)
+					utils.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+				}
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
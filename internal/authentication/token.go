@@ -9,8 +9,12 @@ import (
 const (
 	AuthenticationToken = "authentication"
 	EmailToken          = "email"
+	DataExportToken     = "data-export"
 )
 
+// DataExportLifetime is how long the link to download a copy of a user's data stays valid.
+const DataExportLifetime = 24 * time.Hour
+
 func ValidateToken(token *models.TokenModel, tokenType string) bool {
 	if token == nil {
 		return false
@@ -0,0 +1,365 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/bucket"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	// SnapshotPrefix is the prefix of every snapshot's file name.
+	SnapshotPrefix = "backup-"
+
+	// SnapshotExtension is the file extension of every snapshot.
+	SnapshotExtension = ".sqlite"
+
+	// BucketDirectory is the directory inside the bucket that snapshots get uploaded to.
+	BucketDirectory = "backups/"
+
+	// snapshotTimeFormat is used in the snapshot file names. It sorts in the same order as the time it represents.
+	snapshotTimeFormat = "20060102T150405Z"
+)
+
+// ErrIntegrityCheckFailed indicates that a SQLite database file didn't pass "PRAGMA integrity_check".
+var ErrIntegrityCheckFailed = errors.New("database failed the integrity check")
+
+// Backup makes snapshots of the SQLite database and restores the database from those snapshots.
+type Backup struct {
+	utils.Loggers
+	DatabasePath string
+	Directory    string
+	Keep         int
+	Bucket       bucket.Bucket
+}
+
+// SetupBackup creates a new instance of Backup. The database path is the path to the live SQLite database file, the
+// directory is where the snapshots get stored and keep is how many snapshots to hold on to before the oldest ones get
+// removed. The bucket is optional. When it isn't nil every snapshot gets uploaded to it as well.
+func SetupBackup(databasePath, directory string, keep int, b bucket.Bucket) *Backup {
+	loggers := utils.CreateLoggers("BACKUP")
+
+	return &Backup{
+		Loggers:      loggers,
+		DatabasePath: databasePath,
+		Directory:    directory,
+		Keep:         keep,
+		Bucket:       b,
+	}
+}
+
+// Start makes a snapshot once every interval in a background goroutine. The goroutine stops when the given context
+// gets cancelled and is tracked by the wait group so that the caller can wait for a snapshot that is still being made
+// to finish.
+func (b *Backup) Start(ctx context.Context, wg *sync.WaitGroup, interval time.Duration) {
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				b.InfoLog.Println("Stopping scheduled backups")
+				return
+			case <-ticker.C:
+				b.Snapshot(ctx)
+			}
+		}
+	}()
+}
+
+// Snapshot makes a consistent copy of the live database with "VACUUM INTO", uploads it to the bucket (if there is one)
+// and removes the oldest snapshots so that only the configured amount is kept. It's safe to run while the web server
+// is using the database. The path to the new snapshot gets returned.
+func (b *Backup) Snapshot(ctx context.Context) (string, error) {
+	start := time.Now()
+
+	if err := os.MkdirAll(b.Directory, 0755); err != nil {
+		b.ErrorLog.Printf("Failed to create backup directory \"%s\": %s\n", b.Directory, err)
+		return "", err
+	}
+
+	fileName := SnapshotPrefix + start.UTC().Format(snapshotTimeFormat) + SnapshotExtension
+	snapshotPath := filepath.Join(b.Directory, fileName)
+
+	conn, err := sql.Open("sqlite", b.DatabasePath)
+	if err != nil {
+		b.ErrorLog.Printf("Failed to open database \"%s\": %s\n", b.DatabasePath, err)
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `VACUUM INTO ?;`, snapshotPath); err != nil {
+		b.ErrorLog.Printf("Failed to create snapshot \"%s\": %s\n", snapshotPath, err)
+		return "", err
+	}
+
+	if b.Bucket != nil {
+		if err := b.upload(snapshotPath, fileName); err != nil {
+			return snapshotPath, err
+		}
+	}
+
+	if err := b.Rotate(); err != nil {
+		return snapshotPath, err
+	}
+
+	b.InfoLog.Printf("Created snapshot \"%s\" in %s\n", snapshotPath, time.Since(start))
+
+	return snapshotPath, nil
+}
+
+// Snapshots returns the paths of all the snapshots in the backup directory from oldest to newest.
+func (b *Backup) Snapshots() ([]string, error) {
+	entries, err := os.ReadDir(b.Directory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		b.ErrorLog.Printf("Failed to read backup directory \"%s\": %s\n", b.Directory, err)
+		return nil, err
+	}
+
+	var snapshots []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() && isSnapshot(entry.Name()) {
+			snapshots = append(snapshots, filepath.Join(b.Directory, entry.Name()))
+		}
+	}
+
+	sort.Strings(snapshots)
+
+	return snapshots, nil
+}
+
+// Rotate removes the oldest snapshots, both locally and in the bucket, so that only the configured amount is kept.
+func (b *Backup) Rotate() error {
+	snapshots, err := b.Snapshots()
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range expired(snapshots, b.Keep) {
+		if err := os.Remove(snapshot); err != nil {
+			b.ErrorLog.Printf("Failed to remove old snapshot \"%s\": %s\n", snapshot, err)
+			return err
+		}
+	}
+
+	if b.Bucket == nil {
+		return nil
+	}
+
+	files, err := b.Bucket.GetAllFiles()
+	if err != nil {
+		return err
+	}
+
+	var uploaded []string
+
+	for _, file := range files {
+		if strings.HasPrefix(file.Name, BucketDirectory) && isSnapshot(strings.TrimPrefix(file.Name, BucketDirectory)) {
+			uploaded = append(uploaded, file.Name)
+		}
+	}
+
+	sort.Strings(uploaded)
+
+	for _, fileName := range expired(uploaded, b.Keep) {
+		if err := b.Bucket.DeleteFile(fileName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restore replaces the live database with the given snapshot. The snapshot has to pass "PRAGMA integrity_check"
+// before anything gets touched. The web server should be stopped while the database gets restored.
+func (b *Backup) Restore(ctx context.Context, snapshotPath string) error {
+	if err := CheckIntegrity(ctx, snapshotPath); err != nil {
+		b.ErrorLog.Printf("Refusing to restore \"%s\": %s\n", snapshotPath, err)
+		return err
+	}
+
+	// Copy the snapshot next to the database first so that the final swap is a single rename.
+	tempPath := b.DatabasePath + ".restore"
+
+	if err := copyFile(snapshotPath, tempPath); err != nil {
+		b.ErrorLog.Printf("Failed to copy snapshot \"%s\" to \"%s\": %s\n", snapshotPath, tempPath, err)
+		return err
+	}
+
+	// The write-ahead log belongs to the old database so it can't be allowed to be replayed on top of the snapshot. It
+	// only gets moved aside until the swap went through because it still holds the old database's latest transactions.
+	var movedAside []string
+
+	for _, suffix := range []string{"-wal", "-shm"} {
+		path := b.DatabasePath + suffix
+
+		if err := os.Rename(path, path+".old"); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			b.ErrorLog.Printf("Failed to move \"%s\" aside: %s\n", path, err)
+			b.putBack(movedAside, tempPath)
+			return err
+		}
+
+		movedAside = append(movedAside, path)
+	}
+
+	if err := os.Rename(tempPath, b.DatabasePath); err != nil {
+		b.ErrorLog.Printf("Failed to move \"%s\" to \"%s\": %s\n", tempPath, b.DatabasePath, err)
+		b.putBack(movedAside, tempPath)
+		return err
+	}
+
+	for _, path := range movedAside {
+		if err := os.Remove(path + ".old"); err != nil {
+			b.WarningLog.Printf("Failed to remove \"%s\": %s\n", path+".old", err)
+		}
+	}
+
+	b.InfoLog.Printf("Restored \"%s\" from \"%s\"\n", b.DatabasePath, snapshotPath)
+
+	return nil
+}
+
+// putBack moves the write-ahead log files that Restore moved aside back into place and removes the copy of the
+// snapshot so that a failed restore leaves the old database the way it was.
+func (b *Backup) putBack(movedAside []string, tempPath string) {
+	for _, path := range movedAside {
+		if err := os.Rename(path+".old", path); err != nil {
+			b.ErrorLog.Printf("Failed to put \"%s\" back. It's been left at \"%s\": %s\n", path, path+".old", err)
+		}
+	}
+
+	if err := os.Remove(tempPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		b.WarningLog.Printf("Failed to remove \"%s\": %s\n", tempPath, err)
+	}
+}
+
+// CheckIntegrity runs "PRAGMA integrity_check" against the SQLite database file at the given path. It returns
+// ErrIntegrityCheckFailed, along with the problems that SQLite found, if the database is damaged.
+func CheckIntegrity(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	conn, err := sql.Open("sqlite", fmt.Sprintf("file:%s?mode=ro", path))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	rows, err := conn.QueryContext(ctx, `PRAGMA integrity_check;`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var problems []string
+
+	for rows.Next() {
+		var result string
+
+		if err := rows.Scan(&result); err != nil {
+			return err
+		}
+
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrIntegrityCheckFailed, strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+func (b *Backup) upload(snapshotPath, fileName string) error {
+	file, err := os.Open(snapshotPath)
+	if err != nil {
+		b.ErrorLog.Printf("Failed to open snapshot \"%s\": %s\n", snapshotPath, err)
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		b.ErrorLog.Printf("Failed to generate checksum for snapshot \"%s\": %s\n", snapshotPath, err)
+		return err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		b.ErrorLog.Printf("Failed to rewind snapshot \"%s\": %s\n", snapshotPath, err)
+		return err
+	}
+
+	return b.Bucket.UploadFile(BucketDirectory+fileName, file, hex.EncodeToString(hash.Sum(nil)))
+}
+
+func isSnapshot(fileName string) bool {
+	return strings.HasPrefix(fileName, SnapshotPrefix) && strings.HasSuffix(fileName, SnapshotExtension)
+}
+
+// expired returns the oldest entries of a sorted list of snapshots that go over the amount to keep.
+func expired(snapshots []string, keep int) []string {
+	if keep < 1 || len(snapshots) <= keep {
+		return nil
+	}
+
+	return snapshots[:len(snapshots)-keep]
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,158 @@
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStart(t *testing.T) {
+	dir := t.TempDir()
+	backup := SetupBackup(filepath.Join(dir, "db.sqlite"), filepath.Join(dir, "backups"), 1, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var wg sync.WaitGroup
+	backup.Start(ctx, &wg, time.Hour)
+
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the scheduled backups to stop once the context was cancelled")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot func(t *testing.T, path string)
+		live     func(t *testing.T, path string)
+		wantErr  bool
+	}{
+		{
+			name:     "Valid snapshot",
+			snapshot: func(t *testing.T, path string) { createDatabase(t, path, "snapshot") },
+			live:     func(t *testing.T, path string) { createDatabase(t, path, "live") },
+		},
+		{
+			name: "Damaged snapshot",
+			snapshot: func(t *testing.T, path string) {
+				writeFile(t, path, "not a database")
+			},
+			live:    func(t *testing.T, path string) { createDatabase(t, path, "live") },
+			wantErr: true,
+		},
+		{
+			name:     "Swap fails",
+			snapshot: func(t *testing.T, path string) { createDatabase(t, path, "snapshot") },
+			live: func(t *testing.T, path string) {
+				// A directory that isn't empty can't be replaced by a rename.
+				if err := os.MkdirAll(filepath.Join(path, "in-the-way"), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %s", err)
+				}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			databasePath := filepath.Join(dir, "db.sqlite")
+			snapshotPath := filepath.Join(dir, "snapshot.sqlite")
+
+			tt.snapshot(t, snapshotPath)
+			tt.live(t, databasePath)
+
+			// The write-ahead log holds transactions that haven't been checkpointed into the database yet.
+			writeFile(t, databasePath+"-wal", "committed transactions")
+			writeFile(t, databasePath+"-shm", "shared memory")
+
+			backup := SetupBackup(databasePath, filepath.Join(dir, "backups"), 1, nil)
+
+			err := backup.Restore(context.Background(), snapshotPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error to be %t. Got %v", tt.wantErr, err)
+			}
+
+			for _, leftover := range []string{databasePath + ".restore", databasePath + "-wal.old", databasePath + "-shm.old"} {
+				if _, err := os.Stat(leftover); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("Expected \"%s\" to be cleaned up", leftover)
+				}
+			}
+
+			if tt.wantErr {
+				// A failed restore has to leave the write-ahead log of the old database untouched.
+				if content, err := os.ReadFile(databasePath + "-wal"); err != nil || string(content) != "committed transactions" {
+					t.Errorf("Expected the write-ahead log to be put back. Got %q (%v)", content, err)
+				}
+
+				return
+			}
+
+			for _, suffix := range []string{"-wal", "-shm"} {
+				if _, err := os.Stat(databasePath + suffix); !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("Expected the old database's \"%s\" file to be removed", suffix)
+				}
+			}
+
+			if !hasTable(t, databasePath, "snapshot") {
+				t.Error("Expected the database to be replaced by the snapshot")
+			}
+		})
+	}
+}
+
+// createDatabase creates a SQLite database at the given path with a single empty table.
+func createDatabase(t *testing.T, path, table string) {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %s", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Exec(`CREATE TABLE ` + table + ` (id TEXT PRIMARY KEY);`); err != nil {
+		t.Fatalf("Failed to create table: %s", err)
+	}
+}
+
+// hasTable reports whether the SQLite database at the given path has a table with the given name.
+func hasTable(t *testing.T, path, table string) bool {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %s", err)
+	}
+	defer conn.Close()
+
+	var count int
+	if err := conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?;`, table).Scan(&count); err != nil {
+		t.Fatalf("Failed to look up table: %s", err)
+	}
+
+	return count == 1
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write \"%s\": %s", path, err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"io"
 	"mime"
 	"path/filepath"
 	"strings"
@@ -158,6 +159,30 @@ func (b *S3Bucket) UploadFileFS(files embed.FS, fileName, checksum string) error
 	return nil
 }
 
+func (b *S3Bucket) UploadFile(fileName string, file io.Reader, checksum string) error {
+	contentType := mime.TypeByExtension(filepath.Ext(fileName))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_, err := b.Client.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:            aws.String(b.BucketName),
+		Key:               aws.String(fileName),
+		Body:              file,
+		ContentType:       aws.String(contentType),
+		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+		Metadata: map[string]string{
+			"checksum": checksum,
+		},
+	})
+	if err != nil {
+		b.ErrorLog.Printf("Failed to upload \"%s\" to \"%s\" bucket: %s\n", fileName, b.BucketName, err)
+		return err
+	}
+
+	return nil
+}
+
 func (b *S3Bucket) DeleteFile(fileName string) error {
 	_, err := b.Client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
 		Bucket: aws.String(b.BucketName),
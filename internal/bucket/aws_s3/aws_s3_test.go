@@ -22,6 +22,10 @@ func TestUploadFileFS(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestUploadFile(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestDeleteFile(t *testing.T) {
 	// TODO: Implement.
 }
@@ -1,6 +1,9 @@
 package bucket
 
-import "embed"
+import (
+	"embed"
+	"io"
+)
 
 type File struct {
 	Name     string
@@ -10,5 +13,6 @@ type File struct {
 type Bucket interface {
 	GetAllFiles() ([]*File, error)
 	UploadFileFS(files embed.FS, fileName, checksum string) error
+	UploadFile(fileName string, file io.Reader, checksum string) error
 	DeleteFile(fileName string) error
 }
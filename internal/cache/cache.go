@@ -11,4 +11,5 @@ type Cache interface {
 	GetCoursesRSSFeed() string
 	GetAuthorTutorialsRSSFeed(authorSlug string) string
 	GetAuthorCoursesRSSFeed(authorSlug string) string
+	GetSeriesRSSFeed(seriesSlug string) string
 }
@@ -13,6 +13,7 @@ type Generators struct {
 	CoursesRSSFeed         func() (string, error)
 	AuthorTutorialsRSSFeed func(authorSlug string) (string, error)
 	AuthorCoursesRSSFeed   func(authorSlug string) (string, error)
+	SeriesRSSFeed          func(seriesSlug string) (string, error)
 }
 
 type GoCache struct {
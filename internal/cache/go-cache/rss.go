@@ -119,3 +119,22 @@ func (c *GoCache) GetAuthorCoursesRSSFeed(authorSlug string) string {
 
 	return feed
 }
+
+func (c *GoCache) GetSeriesRSSFeed(seriesSlug string) string {
+	var key = fmt.Sprintf("%s-series-rss-feed", seriesSlug)
+
+	rssFeed, found := c.Cache.Get(key)
+	if found {
+		feed, ok := rssFeed.(string)
+		if ok {
+			return feed
+		}
+	}
+
+	feed, err := c.Generator.SeriesRSSFeed(seriesSlug)
+	if err == nil {
+		c.Cache.Set(key, feed, cache.NoExpiration)
+	}
+
+	return feed
+}
@@ -8,11 +8,11 @@ import (
 	"strings"
 )
 
-const _AuthorizationLevelName = "AllUserAdminAuthor"
+const _AuthorizationLevelName = "AllUserAdminAuthorDeletedIncludeDeleted"
 
-var _AuthorizationLevelIndex = [...]uint8{0, 3, 7, 12, 18}
+var _AuthorizationLevelIndex = [...]uint8{0, 3, 7, 12, 18, 25, 39}
 
-const _AuthorizationLevelLowerName = "alluseradminauthor"
+const _AuthorizationLevelLowerName = "alluseradminauthordeletedincludedeleted"
 
 func (i AuthorizationLevel) String() string {
 	if i < 0 || i >= AuthorizationLevel(len(_AuthorizationLevelIndex)-1) {
@@ -29,9 +29,11 @@ func _AuthorizationLevelNoOp() {
 	_ = x[User-(1)]
 	_ = x[Admin-(2)]
 	_ = x[Author-(3)]
+	_ = x[Deleted-(4)]
+	_ = x[IncludeDeleted-(5)]
 }
 
-var _AuthorizationLevelValues = []AuthorizationLevel{All, User, Admin, Author}
+var _AuthorizationLevelValues = []AuthorizationLevel{All, User, Admin, Author, Deleted, IncludeDeleted}
 
 var _AuthorizationLevelNameToValueMap = map[string]AuthorizationLevel{
 	_AuthorizationLevelName[0:3]:        All,
@@ -42,6 +44,10 @@ var _AuthorizationLevelNameToValueMap = map[string]AuthorizationLevel{
 	_AuthorizationLevelLowerName[7:12]:  Admin,
 	_AuthorizationLevelName[12:18]:      Author,
 	_AuthorizationLevelLowerName[12:18]: Author,
+	_AuthorizationLevelName[18:25]:      Deleted,
+	_AuthorizationLevelLowerName[18:25]: Deleted,
+	_AuthorizationLevelName[25:39]:      IncludeDeleted,
+	_AuthorizationLevelLowerName[25:39]: IncludeDeleted,
 }
 
 var _AuthorizationLevelNames = []string{
@@ -49,6 +55,8 @@ var _AuthorizationLevelNames = []string{
 	_AuthorizationLevelName[3:7],
 	_AuthorizationLevelName[7:12],
 	_AuthorizationLevelName[12:18],
+	_AuthorizationLevelName[18:25],
+	_AuthorizationLevelName[25:39],
 }
 
 // AuthorizationLevelString retrieves an enum value from the enum constants string name.
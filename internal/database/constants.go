@@ -8,6 +8,13 @@ const (
 	User
 	Admin
 	Author
+
+	// Deleted only matches accounts that have been soft deleted. Every other level leaves them out.
+	Deleted
+
+	// IncludeDeleted matches every account, including the soft deleted and anonymized ones. It is only meant for
+	// tracing purchases, refunds and other records that outlive an account back to it.
+	IncludeDeleted
 )
 
 type PaymentStatus int
@@ -23,6 +30,10 @@ const (
 	Disputed
 )
 
+// OpenPaymentStatuses are the payment statuses of a course purchase whose payment hasn't been settled yet. Only these
+// purchases may still be moved to another status by Stripe or by the maintenance job.
+var OpenPaymentStatuses = []PaymentStatus{Pending, RequiresAction, Processing}
+
 // String converts PaymentStatus to a string.
 func (p PaymentStatus) String() string {
 	switch p {
@@ -149,3 +160,124 @@ func RefundStatusFromString(s string) RefundStatus {
 		return RefundPending
 	}
 }
+
+// AuditAction is a privileged action that gets recorded in the audit log.
+type AuditAction int
+
+const (
+	AuditAddAdminStatus AuditAction = iota
+	AuditRemoveAdminStatus
+	AuditAddAuthorStatus
+	AuditRemoveAuthorStatus
+	AuditRestoreUser
+	AuditPublishTutorial
+	AuditUnpublishTutorial
+	AuditUpdateTutorialAuthor
+	AuditDeleteTutorial
+	AuditRestoreTutorial
+	AuditPublishCourse
+	AuditUnpublishCourse
+	AuditUpdateCourseAuthor
+	AuditDeleteCourse
+	AuditRestoreCourse
+	AuditDeleteComment
+	AuditRestoreComment
+	AuditAddDiscount
+	AuditActivateDiscount
+	AuditDeactivateDiscount
+)
+
+// AuditActions lists every AuditAction so that they can be used as filter options.
+var AuditActions = []AuditAction{
+	AuditAddAdminStatus,
+	AuditRemoveAdminStatus,
+	AuditAddAuthorStatus,
+	AuditRemoveAuthorStatus,
+	AuditRestoreUser,
+	AuditPublishTutorial,
+	AuditUnpublishTutorial,
+	AuditUpdateTutorialAuthor,
+	AuditDeleteTutorial,
+	AuditRestoreTutorial,
+	AuditPublishCourse,
+	AuditUnpublishCourse,
+	AuditUpdateCourseAuthor,
+	AuditDeleteCourse,
+	AuditRestoreCourse,
+	AuditDeleteComment,
+	AuditRestoreComment,
+	AuditAddDiscount,
+	AuditActivateDiscount,
+	AuditDeactivateDiscount,
+}
+
+// String converts an AuditAction to a string.
+func (a AuditAction) String() string {
+	switch a {
+	case AuditAddAdminStatus:
+		return "Add Admin Status"
+	case AuditRemoveAdminStatus:
+		return "Remove Admin Status"
+	case AuditAddAuthorStatus:
+		return "Add Author Status"
+	case AuditRemoveAuthorStatus:
+		return "Remove Author Status"
+	case AuditRestoreUser:
+		return "Restore User"
+	case AuditPublishTutorial:
+		return "Publish Tutorial"
+	case AuditUnpublishTutorial:
+		return "Unpublish Tutorial"
+	case AuditUpdateTutorialAuthor:
+		return "Update Tutorial Author"
+	case AuditDeleteTutorial:
+		return "Delete Tutorial"
+	case AuditRestoreTutorial:
+		return "Restore Tutorial"
+	case AuditPublishCourse:
+		return "Publish Course"
+	case AuditUnpublishCourse:
+		return "Unpublish Course"
+	case AuditUpdateCourseAuthor:
+		return "Update Course Author"
+	case AuditDeleteCourse:
+		return "Delete Course"
+	case AuditRestoreCourse:
+		return "Restore Course"
+	case AuditDeleteComment:
+		return "Delete Comment"
+	case AuditRestoreComment:
+		return "Restore Comment"
+	case AuditAddDiscount:
+		return "Add Discount"
+	case AuditActivateDiscount:
+		return "Activate Discount"
+	case AuditDeactivateDiscount:
+		return "Deactivate Discount"
+	default:
+		return ""
+	}
+}
+
+// SlugKind is the kind of content that a slug in the slug history belongs to.
+type SlugKind int
+
+const (
+	TutorialSlug SlugKind = iota
+	CourseSlug
+	ChapterSlug
+)
+
+// String converts a SlugKind to a string.
+func (s SlugKind) String() string {
+	switch s {
+	case TutorialSlug:
+		return "tutorial"
+	case CourseSlug:
+		return "course"
+	case ChapterSlug:
+		return "chapter"
+	default:
+		return ""
+	}
+}
@@ -17,3 +17,11 @@ func TestRefundStatusString(t *testing.T) {
 func TestRefundStatusFromString(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestAuditActionString(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestSlugKindString(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cursor marks the last row of a page of results so that the next page can carry on right after it. Listings are
+// ordered from newest to oldest by (created_at, id), which is unique because IDs are ULIDs, so rows that get inserted
+// while someone is paging through a listing don't shift the rows on later pages. Full-text search results are ordered
+// by relevance instead, which is what Rank is used for.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+	Rank      float64
+}
+
+// NewCursor creates a cursor that points at the row with the given creation time and ID.
+func NewCursor(createdAt time.Time, id string) *Cursor {
+	return &Cursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}
+}
+
+// NewRankedCursor creates a cursor that points at a full-text search result with the given rank.
+func NewRankedCursor(rank float64, createdAt time.Time, id string) *Cursor {
+	return &Cursor{
+		CreatedAt: createdAt,
+		ID:        id,
+		Rank:      rank,
+	}
+}
+
+// String encodes the cursor into a URL safe string that can be decoded again with ParseCursor.
+func (cursor *Cursor) String() string {
+	raw := fmt.Sprintf("%d|%s|%s", cursor.CreatedAt.UnixNano(), strconv.FormatFloat(cursor.Rank, 'g', -1, 64), cursor.ID)
+
+	return BytesToURLString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor that was encoded with Cursor.String. An empty string means that there is no cursor so
+// both the cursor and the error will be nil.
+func ParseCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := URLStringToBytes(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	nanoseconds, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	rank, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{
+		CreatedAt: time.Unix(0, nanoseconds).UTC(),
+		ID:        parts[2],
+		Rank:      rank,
+	}, nil
+}
+
+// Paginate trims the extra row that gets fetched to find out whether there is another page after this one. It returns
+// the rows on the page along with the cursor for the next page, which is nil when this is the last page. cursorAt
+// creates the cursor for the row at the given index.
+func Paginate[T any](rows []T, elements int, cursorAt func(i int) *Cursor) ([]T, *Cursor) {
+	if elements < 1 || len(rows) <= elements {
+		return rows, nil
+	}
+
+	return rows[:elements], cursorAt(elements - 1)
+}
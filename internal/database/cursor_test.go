@@ -0,0 +1,23 @@
+package database
+
+import "testing"
+
+func TestNewCursor(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestNewRankedCursor(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestCursorString(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestParseCursor(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestPaginate(t *testing.T) {
+	// TODO: Implement.
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -10,173 +11,264 @@ import (
 type Database interface {
 	// General database functions.
 	Close() error
+	WithTx(ctx context.Context, fn func(tx Tx) error) error
 
 	// Migration functions.
 	MigrateUp() error
 	MigrateDown() error
 	Rollback(steps int) error
+	MigrateTo(version uint) error
+	ForceMigrationVersion(version int) error
+	MigrationVersion() (uint, bool, error)
+	Migrations() ([]Migration, error)
 
 	// Users functions.
-	GetUsers(term string, level AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error)
-	GetUsersPaginated(term string, level AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, page, elements uint) ([]*models.UserModel, error)
-	GetAllUsers() ([]*models.UserModel, error)
-	AddNewUser(name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error)
-	NewUser(name, surname, email, password string) error
-	NewAdminUser(name, surname, email, password string) error
-	GetUserByEmail(email string, level AuthorizationLevel) (*models.UserModel, error)
-	GetUserByID(id string, level AuthorizationLevel) (*models.UserModel, error)
-	GetUserByToken(token, tokenType string, level AuthorizationLevel) (*models.UserModel, error)
-	GetUserByAffiliateCode(affiliateCode string, level AuthorizationLevel) (*models.UserModel, error)
-	GetUserBySlug(userSlug string, level AuthorizationLevel) (*models.UserModel, error)
-	UpdateUserName(userId, name, surname string) error
-	UpdateUserEmail(userId, email string) error
-	UpdateUserPassword(userId, password string) error
-	CountUsers() (uint, error)
-	AddAuthorStatus(userId string) error
-	RemoveAuthorStatus(userId string) error
-	AddAdminStatus(userId string) error
-	RemoveAdminStatus(userId string) error
-	DeleteUser(userId string) error
+	GetUsers(ctx context.Context, term string, level AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error)
+	GetUsersPaginated(ctx context.Context, term string, level AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, cursor *Cursor, elements uint) ([]*models.UserModel, *Cursor, error)
+	GetAllUsers(ctx context.Context) ([]*models.UserModel, error)
+	AddNewUser(ctx context.Context, name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error)
+	NewUser(ctx context.Context, name, surname, email, password string) error
+	NewAdminUser(ctx context.Context, name, surname, email, password string) error
+	GetUserByEmail(ctx context.Context, email string, level AuthorizationLevel) (*models.UserModel, error)
+	GetUserByID(ctx context.Context, id string, level AuthorizationLevel) (*models.UserModel, error)
+	GetUserByToken(ctx context.Context, token, tokenType string, level AuthorizationLevel) (*models.UserModel, error)
+	GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level AuthorizationLevel) (*models.UserModel, error)
+	GetUserBySlug(ctx context.Context, userSlug string, level AuthorizationLevel) (*models.UserModel, error)
+	UpdateUserName(ctx context.Context, userId, name, surname string) error
+	UpdateUserEmail(ctx context.Context, userId, email string) error
+	UpdateUserPassword(ctx context.Context, userId, password string) error
+	CountUsers(ctx context.Context) (uint, error)
+	AddAuthorStatus(ctx context.Context, userId string) error
+	RemoveAuthorStatus(ctx context.Context, userId string) error
+	AddAdminStatus(ctx context.Context, userId string) error
+	RemoveAdminStatus(ctx context.Context, userId string) error
+	DeleteUser(ctx context.Context, userId string) error
+	RestoreUser(ctx context.Context, userId string) error
 
 	// Tokens functions.
-	AddToken(token, tokenType, userId string, validUntil time.Time) error
-	GetToken(token, tokenType string) (*models.TokenModel, error)
-	DeleteToken(token, tokenType string) error
-	DeleteAllTokens(email, tokenType string) error
+	AddToken(ctx context.Context, token, tokenType, userId string, validUntil time.Time) error
+	GetToken(ctx context.Context, token, tokenType string) (*models.TokenModel, error)
+	DeleteToken(ctx context.Context, token, tokenType string) error
+	DeleteAllTokens(ctx context.Context, email, tokenType string) error
 
 	// IP Addresses functions.
-	AddIPAddress(userId, ipAddr string) error
-	GetUserIpAddresses(userId string) ([]*models.WhitelistedIPModel, error)
-	DeleteIPAddress(ipAddrId, userId string) error
+	AddIPAddress(ctx context.Context, userId, ipAddr string) error
+	GetUserIpAddresses(ctx context.Context, userId string) ([]*models.WhitelistedIPModel, error)
+	DeleteIPAddress(ctx context.Context, ipAddrId, userId string) error
 
 	// Tutorials functions.
-	AdminGetTutorials(term string, published *bool, authorId *string, likedByUser string, bookmarkedByUser string, keyword string, page, elements uint) ([]*models.TutorialModel, error)
-	GetAllTutorials(authorId string, published *bool) ([]*models.TutorialModel, error)
-	GetTutorials(term string, authorId string, page, elements int) ([]*models.TutorialModel, error)
-	GetTutorialByID(id string) (*models.TutorialModel, error)
-	GetTutorialBySlug(slug string) (*models.TutorialModel, error)
-	CountTutorials() (uint, error)
-	CountTutorialsWrittenBy(authorId string) (uint, error)
-	PublishTutorial(tutorialId string) error
-	UnpublishTutorial(tutorialId string) error
-	UpdateTutorialAuthor(tutorialId, authorId string) error
+	AdminGetTutorials(ctx context.Context, term string, published *bool, authorId *string, likedByUser string, bookmarkedByUser string, keyword string, deleted bool, cursor *Cursor, elements uint) ([]*models.TutorialModel, *Cursor, error)
+	GetAllTutorials(ctx context.Context, authorId string, published *bool) ([]*models.TutorialModel, error)
+	GetTutorials(ctx context.Context, term string, authorId string, cursor *Cursor, elements int) ([]*models.TutorialModel, *Cursor, error)
+	GetTutorialByID(ctx context.Context, id string) (*models.TutorialModel, error)
+	GetTutorialBySlug(ctx context.Context, slug string) (*models.TutorialModel, error)
+	CountTutorials(ctx context.Context) (uint, error)
+	CountTutorialsWrittenBy(ctx context.Context, authorId string) (uint, error)
+	PublishTutorial(ctx context.Context, tutorialId string) error
+	UnpublishTutorial(ctx context.Context, tutorialId string) error
+	UpdateTutorialAuthor(ctx context.Context, tutorialId, authorId string) error
+	DeleteTutorial(ctx context.Context, tutorialId string) error
+	RestoreTutorial(ctx context.Context, tutorialId string) error
+	PurgeTutorial(ctx context.Context, tutorialId string) error
 
 	// Keywords functions.
-	GetKeywords() ([]string, error)
-	DeleteAllKeywords() error
+	GetKeywords(ctx context.Context) ([]string, error)
+	DeleteAllKeywords(ctx context.Context) error
 
 	// Tutorials-Keywords functions.
-	GetAllKeywordsForTutorial(tutorialId string) ([]string, error)
+	GetAllKeywordsForTutorial(ctx context.Context, tutorialId string) ([]string, error)
 
 	// Tutorials-Likes functions.
-	GetTutorialsLikedByUser(term, userId string, page, elements uint) ([]*models.TutorialModel, error)
-	UserLikedTutorial(userId, slug string) (bool, error)
-	UserLikeTutorial(userId, slug string) error
-	UserDislikeTutorial(userId, slug string) error
-	CountTutorialsLikedByUser(userId string) (uint, error)
-	CountTutorialLikes(tutorialId string) (uint, error)
+	GetTutorialsLikedByUser(ctx context.Context, term, userId string, cursor *Cursor, elements uint) ([]*models.TutorialModel, *Cursor, error)
+	UserLikedTutorial(ctx context.Context, userId, slug string) (bool, error)
+	UserLikeTutorial(ctx context.Context, userId, slug string) error
+	UserDislikeTutorial(ctx context.Context, userId, slug string) error
+	CountTutorialsLikedByUser(ctx context.Context, userId string) (uint, error)
+	CountTutorialLikes(ctx context.Context, tutorialId string) (uint, error)
 
 	// Tutorials-Bookmarks functions.
-	GetTutorialsBookmarkedByUser(term, userId string, page, elements uint) ([]*models.TutorialModel, error)
-	UserBookmarkedTutorial(userId, slug string) (bool, error)
-	UserBookmarkTutorial(userId, slug string) error
-	UserUnbookmarkTutorial(userId, slug string) error
-	CountTutorialsBookmarkedByUser(userId string) (uint, error)
-	CountTutorialBookmarks(tutorialId string) (uint, error)
+	GetTutorialsBookmarkedByUser(ctx context.Context, term, userId string, cursor *Cursor, elements uint) ([]*models.TutorialModel, *Cursor, error)
+	UserBookmarkedTutorial(ctx context.Context, userId, slug string) (bool, error)
+	UserBookmarkTutorial(ctx context.Context, userId, slug string) error
+	UserUnbookmarkTutorial(ctx context.Context, userId, slug string) error
+	CountTutorialsBookmarkedByUser(ctx context.Context, userId string) (uint, error)
+	CountTutorialBookmarks(ctx context.Context, tutorialId string) (uint, error)
 
 	// Comments functions.
-	AdminGetComments(term, tutorialId, userId string, page, elements uint) ([]*models.CommentModel, error)
-	GetAllCommentsPaginated(tutorialId string, page, elements int) ([]*models.CommentModel, error)
-	GetAllCommentsBySlugPaginated(slug string, page, elements int) ([]*models.CommentModel, error)
-	CountCommentsForTutorial(tutorialId string) (uint, error)
-	AddCommentBySlug(content, userId, slug string) (*models.CommentModel, error)
-	CountComments() (uint, error)
-	DeleteComment(commentId string) error
+	AdminGetComments(ctx context.Context, term, tutorialId, userId string, deleted bool, cursor *Cursor, elements uint) ([]*models.CommentModel, *Cursor, error)
+	GetAllCommentsPaginated(ctx context.Context, tutorialId string, cursor *Cursor, elements int) ([]*models.CommentModel, *Cursor, error)
+	GetAllCommentsBySlugPaginated(ctx context.Context, slug string, cursor *Cursor, elements int) ([]*models.CommentModel, *Cursor, error)
+	CountCommentsForTutorial(ctx context.Context, tutorialId string) (uint, error)
+	AddCommentBySlug(ctx context.Context, content, userId, slug string) (*models.CommentModel, error)
+	CountComments(ctx context.Context) (uint, error)
+	DeleteComment(ctx context.Context, commentId string) error
+	RestoreComment(ctx context.Context, commentId string) error
+	GetCommentByID(ctx context.Context, commentId string) (*models.CommentModel, error)
 
 	// Courses functions.
-	AdminGetCourses(term string, published *bool, authorId *string, boughtBy, keyword string, page, elements uint) ([]*models.CourseModel, error)
-	GetAllCourses(authorId string, published *bool) ([]*models.CourseModel, error)
-	GetCourses(term string, authorId string, page, elements int) ([]*models.CourseModel, error)
-	GetCourseByFileKey(fileKey string) (*models.CourseModel, error)
-	GetCourseBySlug(slug string) (*models.CourseModel, error)
-	GetCourseByID(courseId string) (*models.CourseModel, error)
-	CountCourses() (uint, error)
-	CountCoursesWrittenBy(authorId string) (uint, error)
-	PublishCourse(courseId string) error
-	UnpublishCourse(courseId string) error
-	UpdateCourseAuthor(tutorialId, authorId string) error
+	AdminGetCourses(ctx context.Context, term string, published *bool, authorId *string, boughtBy, keyword string, deleted bool, cursor *Cursor, elements uint) ([]*models.CourseModel, *Cursor, error)
+	GetAllCourses(ctx context.Context, authorId string, published *bool) ([]*models.CourseModel, error)
+	GetCourses(ctx context.Context, term string, authorId string, cursor *Cursor, elements int) ([]*models.CourseModel, *Cursor, error)
+	GetCourseByFileKey(ctx context.Context, fileKey string) (*models.CourseModel, error)
+	GetCourseBySlug(ctx context.Context, slug string) (*models.CourseModel, error)
+	GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error)
+	CountCourses(ctx context.Context) (uint, error)
+	CountCoursesWrittenBy(ctx context.Context, authorId string) (uint, error)
+	PublishCourse(ctx context.Context, courseId string) error
+	UnpublishCourse(ctx context.Context, courseId string) error
+	UpdateCourseAuthor(ctx context.Context, tutorialId, authorId string) error
+	DeleteCourse(ctx context.Context, courseId string) error
+	RestoreCourse(ctx context.Context, courseId string) error
+	PurgeCourse(ctx context.Context, courseId string) error
 
 	// Courses Keywords functions.
-	GetAllKeywordsForCourse(courseId string) ([]string, error)
+	GetAllKeywordsForCourse(ctx context.Context, courseId string) ([]string, error)
 
 	// Chapters functions.
-	GetAllChapters() ([]*models.ChapterModel, error)
-	GetChapterBySlug(chapterSlug string) (*models.ChapterModel, error)
-	GetChapterByFileKey(fileKey string) (*models.ChapterModel, error)
-	CountChapters(courseId string) (int, error)
-	GetCourseChapters(courseId string) ([]*models.ChapterModel, error)
+	GetAllChapters(ctx context.Context) ([]*models.ChapterModel, error)
+	GetChapterBySlug(ctx context.Context, chapterSlug string) (*models.ChapterModel, error)
+	GetChapterByFileKey(ctx context.Context, fileKey string) (*models.ChapterModel, error)
+	CountChapters(ctx context.Context, courseId string) (int, error)
+	GetCourseChapters(ctx context.Context, courseId string) ([]*models.ChapterModel, error)
 
 	// Discounts functions.
-	GetDiscountsPaginated(term string, active *bool, page, elements uint) ([]*models.DiscountModel, error)
-	GetAllDiscounts() ([]*models.DiscountModel, error)
-	CountDiscounts() (uint, error)
-	AddDiscount(title, description string, discount, uses uint64) (string, error)
-	GetDiscountByID(discountId string) (*models.DiscountModel, error)
-	GetDiscountByCode(discountCode string) (*models.DiscountModel, error)
-	ActivateDiscount(discountId string) error
-	DeactivateDiscount(discountId string) error
+	GetDiscountsPaginated(ctx context.Context, term string, active *bool, cursor *Cursor, elements uint) ([]*models.DiscountModel, *Cursor, error)
+	GetAllDiscounts(ctx context.Context) ([]*models.DiscountModel, error)
+	CountDiscounts(ctx context.Context) (uint, error)
+	AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error)
+	GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error)
+	GetDiscountByCode(ctx context.Context, discountCode string) (*models.DiscountModel, error)
+	ActivateDiscount(ctx context.Context, discountId string) error
+	DeactivateDiscount(ctx context.Context, discountId string) error
 
 	// Course Purchases functions.
-	AdminGetCoursePurchases(term string, courseId string, authorId string, status string, page, elements uint) ([]*models.CoursePurchaseModel, error)
-	HasUserPurchasedCourse(userId, courseId string) (bool, error)
-	RegisterCoursePurchase(userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error
-	CountAllPurchases() (uint, error)
-	CountCoursesWhereDiscountWasUsed(discountCode string) (uint, error)
-	CountUsersWhoBoughtCourse(courseId string) (uint, error)
-	GetCoursePurchaseByPaymentKey(paymentKey string) (*models.CoursePurchaseModel, error)
-	GetCoursePurchaseByID(coursePurchaseId string) (*models.CoursePurchaseModel, error)
-	GetCoursePurchaseByCheckoutSession(checkoutSessionId string) (*models.CoursePurchaseModel, error)
-	GetCourseByCoursePurchaseID(coursePurchaseId string) (*models.CourseModel, error)
-	UpdateCoursePurchasePaymentStatus(coursePurchaseId string, status PaymentStatus) error
-	GetCoursesBoughtByUser(term, userId string, page, elements uint) ([]*models.CourseModel, error)
-	GetAllCoursesBoughtByUser(userId string) ([]*models.CourseModel, error)
-	GetCoursePurchasesByUserAndCourse(userId, courseId string) ([]*models.CoursePurchaseModel, error)
+	AdminGetCoursePurchases(ctx context.Context, term string, courseId string, authorId string, status string, cursor *Cursor, elements uint) ([]*models.CoursePurchaseModel, *Cursor, error)
+	HasUserPurchasedCourse(ctx context.Context, userId, courseId string) (bool, error)
+	RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error
+	CountAllPurchases(ctx context.Context) (uint, error)
+	CountCoursesWhereDiscountWasUsed(ctx context.Context, discountCode string) (uint, error)
+	CountUsersWhoBoughtCourse(ctx context.Context, courseId string) (uint, error)
+	GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error)
+	GetCoursePurchaseByID(ctx context.Context, coursePurchaseId string) (*models.CoursePurchaseModel, error)
+	GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error)
+	GetCourseByCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.CourseModel, error)
+	UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status PaymentStatus) error
+	TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []PaymentStatus, to PaymentStatus) (bool, error)
+	GetCoursesBoughtByUser(ctx context.Context, term, userId string, cursor *Cursor, elements uint) ([]*models.CourseModel, *Cursor, error)
+	GetAllCoursesBoughtByUser(ctx context.Context, userId string) ([]*models.CourseModel, error)
+	GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error)
+	GetCoursePurchaseDate(ctx context.Context, userId, courseId string) (sql.NullTime, error)
 
 	// Affiliate Points History functions.
-	RegisterAffiliatePointsChange(userId, courseId string, pointsChange int, reason string) error
-	CountUserAffiliateHistory(userId string) (uint, error)
-	GetUserAffiliatePointsHistory(userId string, page, elements uint) ([]*models.AffiliatePointsHistoryModel, error)
+	RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error
+	CountUserAffiliateHistory(ctx context.Context, userId string) (uint, error)
+	GetUserAffiliatePointsHistory(ctx context.Context, userId string, cursor *Cursor, elements uint) ([]*models.AffiliatePointsHistoryModel, *Cursor, error)
 
 	// User Course Chapter Completion functions.
-	HasUserCompletedChapter(userId, courseId, chapterId string) (bool, error)
-	GetAllChaptersCompleted(userId, courseId string) ([]*models.ChapterModel, error)
-	GetAllChaptersNotCompleted(userId, courseId string) ([]*models.ChapterModel, error)
-	FinishChapter(userId, chapterId, courseId string) error
+	HasUserCompletedChapter(ctx context.Context, userId, courseId, chapterId string) (bool, error)
+	GetAllChaptersCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error)
+	GetAllChaptersNotCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error)
+	FinishChapter(ctx context.Context, userId, chapterId, courseId string) error
 
 	// Certificates functions.
-	AddCertificate(userId, courseId string) error
-	GetCertificateFromID(certificateId string) (*models.CertificateModel, error)
-	GetCertificateFromUserAndCourse(userId, courseId string) (*models.CertificateModel, error)
-	GetUserFromCertificate(certificateId string) (*models.UserModel, error)
-	GetCourseFromCertificate(certificateId string) (*models.CourseModel, error)
+	AddCertificate(ctx context.Context, userId, courseId string) error
+	GetCertificateFromID(ctx context.Context, certificateId string) (*models.CertificateModel, error)
+	GetCertificateFromUserAndCourse(ctx context.Context, userId, courseId string) (*models.CertificateModel, error)
+	GetUserFromCertificate(ctx context.Context, certificateId string) (*models.UserModel, error)
+	GetCourseFromCertificate(ctx context.Context, certificateId string) (*models.CourseModel, error)
 
 	// Refunds functions.
-	AdminGetRefunds(term string, status string, page, elements uint) ([]*models.RefundModel, error)
-	RegisterRefund(userId, coursePurchaseId string, status RefundStatus) error
-	GetRefundWithCoursePurchaseID(coursePurchaseId string) (*models.RefundModel, error)
-	UpdateRefundStatus(refundId string, status RefundStatus) error
-	CountRefunds() (uint, error)
+	AdminGetRefunds(ctx context.Context, term string, status string, cursor *Cursor, elements uint) ([]*models.RefundModel, *Cursor, error)
+	RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status RefundStatus) error
+	GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error)
+	UpdateRefundStatus(ctx context.Context, refundId string, status RefundStatus) error
+	CountRefunds(ctx context.Context) (uint, error)
+
+	// Audit Log functions.
+	AddAuditLog(ctx context.Context, actorId string, action AuditAction, targetId, before, after, ipAddr string) error
+	AdminGetAuditLogs(ctx context.Context, term, action, actorId string, cursor *Cursor, elements uint) ([]*models.AuditLogModel, *Cursor, error)
+	CountAuditLogs(ctx context.Context) (uint, error)
+
+	// Slug History functions.
+	GetCurrentSlug(ctx context.Context, kind SlugKind, oldSlug string) (string, error)
+
+	// Series functions.
+	GetAllSeries(ctx context.Context) ([]*models.SeriesModel, error)
+	GetSeriesBySlug(ctx context.Context, slug string) (*models.SeriesModel, error)
+	GetSeriesByTutorialID(ctx context.Context, tutorialId string) (*models.SeriesModel, error)
+	GetSeriesTutorials(ctx context.Context, seriesId string) ([]*models.TutorialModel, error)
+
+	// Quiz functions.
+	GetQuizByChapterID(ctx context.Context, chapterId string) (*models.QuizModel, error)
+	AddQuizAttempt(ctx context.Context, userId, quizId string, score int, passed bool, answers []*models.QuizAttemptAnswerModel) error
+	GetQuizAttempts(ctx context.Context, userId, quizId string) ([]*models.QuizAttemptModel, error)
+	GetQuizAttemptAnswers(ctx context.Context, attemptId string) ([]*models.QuizAttemptAnswerModel, error)
+	HasUserPassedQuiz(ctx context.Context, userId, quizId string) (bool, error)
+	GetQuizQuestionStats(ctx context.Context, quizId string) ([]*models.QuizQuestionStatsModel, error)
+
+	// Publish Schedule functions.
+	GetTutorialPublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error)
+	GetCoursePublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error)
+	RunPublishSchedules(ctx context.Context) (uint, error)
+
+	// Chapter Release functions.
+	GetDueChapterReleases(ctx context.Context) ([]*models.ChapterReleaseModel, error)
+	AddChapterReleaseNotification(ctx context.Context, userId, chapterId string) error
+	GetUserChapterReleaseNotifications(ctx context.Context, userId string) ([]*models.ChapterReleaseNotificationModel, error)
+
+	// Search functions.
+	GetTutorialSearchSnippets(ctx context.Context, term string, tutorialIds []string) (map[string]string, error)
+	GetCourseSearchSnippets(ctx context.Context, term string, courseIds []string) (map[string]string, error)
+
+	// Maintenance functions.
+	DeleteExpiredTokens(ctx context.Context) (uint, error)
+	CancelStalePurchases(ctx context.Context, olderThan time.Duration) (uint, error)
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (uint, error)
+	Optimize(ctx context.Context) error
 
 	// Bulk functions.
 	PrepareBulkTutorials()
-	InsertTutorial(title, slug, description, thumbnailUrl, bannerUrl, content, checksum, fileKey string, keywords []string)
-	UpdateTutorial(id, title, slug, description, thumbnailUrl, bannerUrl, content, checksum, fileKey string, keywords []string, authorId sql.NullString)
-	RunBulkTutorials() error
+	InsertTutorial(title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, publishAt, unpublishAt sql.NullTime)
+	UpdateTutorial(id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, authorId sql.NullString, publishAt, unpublishAt sql.NullTime)
+	RunBulkTutorials(ctx context.Context) error
 
 	PrepareBulkCourses()
-	InsertCourse(title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string)
-	UpdateCourse(id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, authorId sql.NullString)
-	InsertChapter(title, slug string, chapter int, content, fileChecksum, fileKey, courseKey string)
-	UpdateChapter(id, title, slug string, chapter int, content, fileChecksum, fileKey, courseKey string)
-	RunBulkCourses() error
+	InsertCourse(title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, publishAt, unpublishAt sql.NullTime)
+	UpdateCourse(id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, authorId sql.NullString, publishAt, unpublishAt sql.NullTime)
+	InsertChapter(title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel)
+	UpdateChapter(id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel)
+	DeleteChapter(id string)
+	RunBulkCourses(ctx context.Context) error
+}
+
+// Tx is the set of database functions that can be run as part of a single unit of work using Database.WithTx. Every
+// call made through Tx is committed together once the unit of work returns without an error and rolled back otherwise.
+type Tx interface {
+	// Users functions.
+	GetUserByID(ctx context.Context, id string, level AuthorizationLevel) (*models.UserModel, error)
+	GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level AuthorizationLevel) (*models.UserModel, error)
+
+	// Courses functions.
+	GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error)
+
+	// Discounts functions.
+	AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error)
+	GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error)
+	ActivateDiscount(ctx context.Context, discountId string) error
+
+	// Course Purchases functions.
+	RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error
+	GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error)
+	GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error)
+	UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status PaymentStatus) error
+	TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []PaymentStatus, to PaymentStatus) (bool, error)
+	GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error)
+
+	// Affiliate Points History functions.
+	RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error
+
+	// Refunds functions.
+	RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status RefundStatus) error
+	GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error)
+	UpdateRefundStatus(ctx context.Context, refundId string, status RefundStatus) error
 }
@@ -0,0 +1,76 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RegisterAffiliatePointsChange"); err != nil {
+		return err
+	}
+
+	return db.insertAffiliatePointsChange(userId, courseId, pointsChange, reason)
+}
+
+func (db *Database) CountUserAffiliateHistory(ctx context.Context, userId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountUserAffiliateHistory"); err != nil {
+		return 0, err
+	}
+
+	return count(db.affiliatePointsHistory, func(history *models.AffiliatePointsHistoryModel) bool {
+		return history.UserID == userId
+	}), nil
+}
+
+func (db *Database) GetUserAffiliatePointsHistory(ctx context.Context, userId string, cursor *database.Cursor, elements uint) ([]*models.AffiliatePointsHistoryModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserAffiliatePointsHistory"); err != nil {
+		return nil, nil, err
+	}
+
+	history := rows(db.affiliatePointsHistory, func(history *models.AffiliatePointsHistoryModel) bool {
+		return history.UserID == userId
+	})
+	history, next := paginate(history, cursor, int(elements), func(row *models.AffiliatePointsHistoryModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return history, next, nil
+}
+
+// insertAffiliatePointsChange adds a new row to the affiliate_points_history table and updates the user's affiliate
+// points, which the real implementations do with a trigger. The points get added to the user's current points so that
+// users seeded with affiliate points keep them. The caller needs to hold the lock.
+func (db *Database) insertAffiliatePointsChange(userId, courseId string, pointsChange int, reason string) error {
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.affiliatePointsHistory[id] = &models.AffiliatePointsHistoryModel{
+		ID:           id,
+		UserID:       userId,
+		CourseID:     courseId,
+		PointsChange: pointsChange,
+		Reason:       reason,
+		CreatedAt:    time.Now(),
+	}
+
+	if user, has := db.users[userId]; has {
+		user.AffiliatePoints += pointsChange
+	}
+
+	return nil
+}
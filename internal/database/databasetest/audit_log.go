@@ -0,0 +1,81 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AddAuditLog(ctx context.Context, actorId string, action database.AuditAction, targetId, before, after, ipAddr string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddAuditLog"); err != nil {
+		return err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.auditLogs[id] = &models.AuditLogModel{
+		ID:          id,
+		ActorID:     actorId,
+		Action:      action.String(),
+		TargetID:    targetId,
+		BeforeValue: before,
+		AfterValue:  after,
+		IPAddress:   ipAddr,
+		CreatedAt:   time.Now(),
+	}
+
+	return nil
+}
+
+func (db *Database) AdminGetAuditLogs(ctx context.Context, term, action, actorId string, cursor *database.Cursor, elements uint) ([]*models.AuditLogModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("AdminGetAuditLogs"); err != nil {
+		return nil, nil, err
+	}
+
+	entries := rows(db.auditLogs, func(entry *models.AuditLogModel) bool {
+		if term != "" {
+			values := []string{entry.ID, entry.TargetID, entry.BeforeValue, entry.AfterValue, entry.IPAddress}
+
+			if actor, has := db.users[entry.ActorID]; has {
+				values = append(values, actor.Name+" "+actor.Surname, actor.Email)
+			}
+
+			if !contains(term, values...) {
+				return false
+			}
+		}
+
+		if action != "" && entry.Action != action {
+			return false
+		}
+
+		return actorId == "" || entry.ActorID == actorId
+	})
+	entries, next := paginate(entries, cursor, int(elements), func(row *models.AuditLogModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return entries, next, nil
+}
+
+func (db *Database) CountAuditLogs(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountAuditLogs"); err != nil {
+		return 0, err
+	}
+
+	return count(db.auditLogs, nil), nil
+}
@@ -0,0 +1,317 @@
+package databasetest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// DefaultPassword is the password every user built with UserBuilder has unless WithPassword gets called.
+const DefaultPassword = "password123"
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// UserBuilder builds users that can be passed to Database.SeedUsers.
+type UserBuilder struct {
+	user     models.UserModel
+	password string
+}
+
+// NewUserBuilder creates a UserBuilder for a regular user with a unique ID, email and affiliate code.
+func NewUserBuilder() *UserBuilder {
+	id := mustID()
+	now := time.Now()
+
+	return &UserBuilder{
+		user: models.UserModel{
+			ID:            id,
+			Name:          "Test",
+			Surname:       "User",
+			Slug:          database.NameSurnameToSlug("Test", "User"),
+			Email:         fmt.Sprintf("%s@example.com", strings.ToLower(id)),
+			AffiliateCode: mustID(),
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		},
+		password: DefaultPassword,
+	}
+}
+
+func (builder *UserBuilder) WithID(id string) *UserBuilder {
+	builder.user.ID = id
+	return builder
+}
+
+func (builder *UserBuilder) WithName(name, surname string) *UserBuilder {
+	builder.user.Name = name
+	builder.user.Surname = surname
+	builder.user.Slug = database.NameSurnameToSlug(name, surname)
+	return builder
+}
+
+func (builder *UserBuilder) WithEmail(email string) *UserBuilder {
+	builder.user.Email = email
+	return builder
+}
+
+// WithPassword sets the plain text password of the user. It gets hashed when the user is built.
+func (builder *UserBuilder) WithPassword(password string) *UserBuilder {
+	builder.password = password
+	return builder
+}
+
+func (builder *UserBuilder) WithAffiliateCode(affiliateCode string) *UserBuilder {
+	builder.user.AffiliateCode = affiliateCode
+	return builder
+}
+
+func (builder *UserBuilder) WithAffiliatePoints(points int) *UserBuilder {
+	builder.user.AffiliatePoints = points
+	return builder
+}
+
+func (builder *UserBuilder) AsAdmin() *UserBuilder {
+	builder.user.IsAdmin = true
+	return builder
+}
+
+func (builder *UserBuilder) AsAuthor() *UserBuilder {
+	builder.user.IsAuthor = true
+	return builder
+}
+
+func (builder *UserBuilder) CreatedAt(createdAt time.Time) *UserBuilder {
+	builder.user.CreatedAt = createdAt
+	builder.user.UpdatedAt = createdAt
+	return builder
+}
+
+// Build hashes the user's password and returns the user. It panics if the password can't be hashed.
+func (builder *UserBuilder) Build() *models.UserModel {
+	user := builder.user
+
+	hash, err := authentication.DefaultPasswordParameters().HashPassword(builder.password)
+	if err != nil {
+		panic(fmt.Sprintf("failed to hash password: %s", err))
+	}
+
+	user.Password = hash
+
+	return &user
+}
+
+// CourseBuilder builds courses that can be passed to Database.SeedCourses.
+type CourseBuilder struct {
+	course models.CourseModel
+}
+
+// NewCourseBuilder creates a CourseBuilder for an unpublished course without an author.
+func NewCourseBuilder() *CourseBuilder {
+	id := mustID()
+	now := time.Now()
+
+	return &CourseBuilder{
+		course: models.CourseModel{
+			ID:           id,
+			Title:        "Test Course",
+			Slug:         "test-course-" + strings.ToLower(id),
+			Description:  "A course used for testing.",
+			ThumbnailURL: "https://example.com/thumbnail.png",
+			BannerURL:    "https://example.com/banner.png",
+			Content:      "<p>A course used for testing.</p>",
+			FileChecksum: id,
+			FileKey:      id,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		},
+	}
+}
+
+func (builder *CourseBuilder) WithID(id string) *CourseBuilder {
+	builder.course.ID = id
+	return builder
+}
+
+// WithTitle sets the title of the course along with a slug based on the title.
+func (builder *CourseBuilder) WithTitle(title string) *CourseBuilder {
+	builder.course.Title = title
+	builder.course.Slug = strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ReplaceAll(strings.ToLower(title), " ", "-"), ""), "-")
+	return builder
+}
+
+func (builder *CourseBuilder) WithSlug(slug string) *CourseBuilder {
+	builder.course.Slug = slug
+	return builder
+}
+
+func (builder *CourseBuilder) WithDescription(description string) *CourseBuilder {
+	builder.course.Description = description
+	return builder
+}
+
+func (builder *CourseBuilder) WithContent(content string) *CourseBuilder {
+	builder.course.Content = content
+	return builder
+}
+
+func (builder *CourseBuilder) WithFileKey(fileKey string) *CourseBuilder {
+	builder.course.FileKey = fileKey
+	return builder
+}
+
+func (builder *CourseBuilder) WithAuthor(authorId string) *CourseBuilder {
+	builder.course.AuthorID = database.NewNullString(authorId)
+	return builder
+}
+
+func (builder *CourseBuilder) Published() *CourseBuilder {
+	builder.course.Published = true
+	return builder
+}
+
+func (builder *CourseBuilder) UpdatedAt(updatedAt time.Time) *CourseBuilder {
+	builder.course.UpdatedAt = updatedAt
+	return builder
+}
+
+func (builder *CourseBuilder) Build() *models.CourseModel {
+	course := builder.course
+	return &course
+}
+
+// CoursePurchaseBuilder builds course purchases that can be passed to Database.SeedCoursePurchases.
+type CoursePurchaseBuilder struct {
+	purchase models.CoursePurchaseModel
+}
+
+// NewCoursePurchaseBuilder creates a CoursePurchaseBuilder for a successful purchase of the course by the user.
+func NewCoursePurchaseBuilder(userId, courseId string) *CoursePurchaseBuilder {
+	now := time.Now()
+
+	return &CoursePurchaseBuilder{
+		purchase: models.CoursePurchaseModel{
+			ID:                      mustID(),
+			UserID:                  userId,
+			CourseID:                courseId,
+			PaymentKey:              mustID(),
+			StripeCheckoutSessionID: "cs_test_" + mustID(),
+			AmountPaid:              0,
+			PaymentStatus:           database.Succeeded.String(),
+			CreatedAt:               now,
+			UpdatedAt:               now,
+		},
+	}
+}
+
+func (builder *CoursePurchaseBuilder) WithID(id string) *CoursePurchaseBuilder {
+	builder.purchase.ID = id
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithStatus(status database.PaymentStatus) *CoursePurchaseBuilder {
+	builder.purchase.PaymentStatus = status.String()
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithPaymentKey(paymentKey string) *CoursePurchaseBuilder {
+	builder.purchase.PaymentKey = paymentKey
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithCheckoutSession(checkoutSessionId string) *CoursePurchaseBuilder {
+	builder.purchase.StripeCheckoutSessionID = checkoutSessionId
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithAffiliateCode(affiliateCode string) *CoursePurchaseBuilder {
+	builder.purchase.AffiliateCode = database.NewNullString(affiliateCode)
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithDiscountCode(discountCode string) *CoursePurchaseBuilder {
+	builder.purchase.DiscountCode = database.NewNullString(discountCode)
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithAffiliatePointsUsed(points uint) *CoursePurchaseBuilder {
+	builder.purchase.AffiliatePointsUsed = points
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) WithAmountPaid(amountPaid float64) *CoursePurchaseBuilder {
+	builder.purchase.AmountPaid = amountPaid
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) CreatedAt(createdAt time.Time) *CoursePurchaseBuilder {
+	builder.purchase.CreatedAt = createdAt
+	builder.purchase.UpdatedAt = createdAt
+	return builder
+}
+
+func (builder *CoursePurchaseBuilder) Build() *models.CoursePurchaseModel {
+	purchase := builder.purchase
+	return &purchase
+}
+
+// TokenBuilder builds tokens that can be passed to Database.SeedTokens.
+type TokenBuilder struct {
+	token models.TokenModel
+}
+
+// NewTokenBuilder creates a TokenBuilder for a random token of the given type that is valid for an hour.
+func NewTokenBuilder(userId, tokenType string) *TokenBuilder {
+	token, err := database.GenerateToken()
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate token: %s", err))
+	}
+
+	now := time.Now()
+
+	return &TokenBuilder{
+		token: models.TokenModel{
+			ID:         mustID(),
+			Token:      token,
+			TokenType:  tokenType,
+			ValidUntil: now.Add(time.Hour),
+			UserID:     userId,
+			CreatedAt:  now,
+		},
+	}
+}
+
+func (builder *TokenBuilder) WithToken(token string) *TokenBuilder {
+	builder.token.Token = token
+	return builder
+}
+
+func (builder *TokenBuilder) ValidUntil(validUntil time.Time) *TokenBuilder {
+	builder.token.ValidUntil = validUntil
+	return builder
+}
+
+// Expired makes the token expire an hour ago.
+func (builder *TokenBuilder) Expired() *TokenBuilder {
+	builder.token.ValidUntil = time.Now().Add(-time.Hour)
+	return builder
+}
+
+func (builder *TokenBuilder) Build() *models.TokenModel {
+	token := builder.token
+	return &token
+}
+
+// mustID generates a new ID and panics if it can't. It is only meant to be used by the builders.
+func mustID() string {
+	id, err := newID()
+	if err != nil {
+		panic(fmt.Sprintf("failed to generate ID: %s", err))
+	}
+
+	return id
+}
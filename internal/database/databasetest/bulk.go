@@ -0,0 +1,416 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// bulkContent is a tutorial or course that has been staged with one of the bulk functions.
+type bulkContent struct {
+	ID              string
+	Title           string
+	Slug            string
+	Description     string
+	ThumbnailURL    string
+	BannerURL       string
+	Content         string
+	TableOfContents models.TableOfContents
+	WordCount       int
+	ReadingTime     int
+	FileChecksum    string
+	FileKey         string
+	Keywords        []string
+	Series          string
+	SeriesSlug      string
+	SeriesOrder     int
+	AuthorID        sql.NullString
+	PublishAt       sql.NullTime
+	UnpublishAt     sql.NullTime
+}
+
+// bulkChapter is a chapter that has been staged with one of the bulk functions.
+type bulkChapter struct {
+	ID               string
+	Title            string
+	Slug             string
+	Chapter          int
+	Content          string
+	TableOfContents  models.TableOfContents
+	WordCount        int
+	ReadingTime      int
+	FileChecksum     string
+	FileKey          string
+	CourseKey        string
+	ReleaseAfterDays sql.NullInt32
+	ReleaseAt        sql.NullTime
+	Quiz             *models.QuizModel
+}
+
+// bulkState holds everything that has been staged since the last call to PrepareBulkTutorials or PrepareBulkCourses.
+type bulkState struct {
+	tutorialsToInsert []*bulkContent
+	tutorialsToUpdate []*bulkContent
+	coursesToInsert   []*bulkContent
+	coursesToUpdate   []*bulkContent
+	chaptersToInsert  []*bulkChapter
+	chaptersToUpdate  []*bulkChapter
+	chaptersToDelete  []string
+}
+
+func (db *Database) PrepareBulkTutorials() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.tutorialsToInsert = nil
+	db.bulk.tutorialsToUpdate = nil
+}
+
+func (db *Database) InsertTutorial(title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, publishAt, unpublishAt sql.NullTime) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.tutorialsToInsert = append(db.bulk.tutorialsToInsert, &bulkContent{
+		Title:           title,
+		Slug:            slug,
+		Description:     description,
+		ThumbnailURL:    thumbnailUrl,
+		BannerURL:       bannerUrl,
+		Content:         content,
+		TableOfContents: toc,
+		WordCount:       wordCount,
+		ReadingTime:     readingTime,
+		FileChecksum:    checksum,
+		FileKey:         fileKey,
+		Keywords:        keywords,
+		Series:          series,
+		SeriesSlug:      seriesSlug,
+		SeriesOrder:     seriesOrder,
+		PublishAt:       publishAt,
+		UnpublishAt:     unpublishAt,
+	})
+}
+
+func (db *Database) UpdateTutorial(id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.tutorialsToUpdate = append(db.bulk.tutorialsToUpdate, &bulkContent{
+		ID:              id,
+		Title:           title,
+		Slug:            slug,
+		Description:     description,
+		ThumbnailURL:    thumbnailUrl,
+		BannerURL:       bannerUrl,
+		Content:         content,
+		TableOfContents: toc,
+		WordCount:       wordCount,
+		ReadingTime:     readingTime,
+		FileChecksum:    checksum,
+		FileKey:         fileKey,
+		Keywords:        keywords,
+		Series:          series,
+		SeriesSlug:      seriesSlug,
+		SeriesOrder:     seriesOrder,
+		AuthorID:        authorId,
+		PublishAt:       publishAt,
+		UnpublishAt:     unpublishAt,
+	})
+}
+
+func (db *Database) RunBulkTutorials(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RunBulkTutorials"); err != nil {
+		return err
+	}
+
+	snapshot := db.snapshot()
+	now := time.Now()
+
+	for _, staged := range db.bulk.tutorialsToInsert {
+		id, err := newID()
+		if err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+
+		db.tutorials[id] = &models.TutorialModel{
+			ID:              id,
+			Title:           staged.Title,
+			Slug:            staged.Slug,
+			Description:     staged.Description,
+			ThumbnailURL:    staged.ThumbnailURL,
+			BannerURL:       staged.BannerURL,
+			Content:         staged.Content,
+			TableOfContents: staged.TableOfContents,
+			WordCount:       staged.WordCount,
+			ReadingTime:     staged.ReadingTime,
+			FileChecksum:    staged.FileChecksum,
+			FileKey:         staged.FileKey,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		db.tutorialKeywords[id] = append([]string(nil), staged.Keywords...)
+		db.setPublishSchedule(id, staged.PublishAt, staged.UnpublishAt)
+
+		if err := db.setTutorialSeries(id, staged.Series, staged.SeriesSlug, staged.SeriesOrder); err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+	}
+
+	for _, staged := range db.bulk.tutorialsToUpdate {
+		tutorial, has := db.tutorials[staged.ID]
+		if !has {
+			continue
+		}
+
+		db.addSlugHistory(database.TutorialSlug, tutorial.ID, tutorial.Slug, staged.Slug)
+		tutorial.Title = staged.Title
+		tutorial.Slug = staged.Slug
+		tutorial.Description = staged.Description
+		tutorial.ThumbnailURL = staged.ThumbnailURL
+		tutorial.BannerURL = staged.BannerURL
+		tutorial.Content = staged.Content
+		tutorial.TableOfContents = staged.TableOfContents
+		tutorial.WordCount = staged.WordCount
+		tutorial.ReadingTime = staged.ReadingTime
+		tutorial.FileChecksum = staged.FileChecksum
+		tutorial.FileKey = staged.FileKey
+		tutorial.AuthorID = staged.AuthorID
+		tutorial.UpdatedAt = now
+		db.tutorialKeywords[tutorial.ID] = append([]string(nil), staged.Keywords...)
+		db.setPublishSchedule(tutorial.ID, staged.PublishAt, staged.UnpublishAt)
+
+		if err := db.setTutorialSeries(tutorial.ID, staged.Series, staged.SeriesSlug, staged.SeriesOrder); err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+	}
+
+	db.deleteEmptySeries()
+
+	return nil
+}
+
+func (db *Database) PrepareBulkCourses() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.coursesToInsert = nil
+	db.bulk.coursesToUpdate = nil
+	db.bulk.chaptersToInsert = nil
+	db.bulk.chaptersToUpdate = nil
+	db.bulk.chaptersToDelete = nil
+}
+
+func (db *Database) InsertCourse(title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, publishAt, unpublishAt sql.NullTime) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.coursesToInsert = append(db.bulk.coursesToInsert, &bulkContent{
+		Title:        title,
+		Slug:         slug,
+		Description:  description,
+		ThumbnailURL: thumbnailUrl,
+		BannerURL:    bannerUrl,
+		Content:      content,
+		FileChecksum: fileChecksum,
+		FileKey:      fileKey,
+		Keywords:     keywords,
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+	})
+}
+
+func (db *Database) UpdateCourse(id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.coursesToUpdate = append(db.bulk.coursesToUpdate, &bulkContent{
+		ID:           id,
+		Title:        title,
+		Slug:         slug,
+		Description:  description,
+		ThumbnailURL: thumbnailUrl,
+		BannerURL:    bannerUrl,
+		Content:      content,
+		FileChecksum: fileChecksum,
+		FileKey:      fileKey,
+		Keywords:     keywords,
+		AuthorID:     authorId,
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+	})
+}
+
+func (db *Database) InsertChapter(title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.chaptersToInsert = append(db.bulk.chaptersToInsert, &bulkChapter{
+		Title:            title,
+		Slug:             slug,
+		Chapter:          chapter,
+		Content:          content,
+		TableOfContents:  toc,
+		WordCount:        wordCount,
+		ReadingTime:      readingTime,
+		FileChecksum:     fileChecksum,
+		FileKey:          fileKey,
+		CourseKey:        courseKey,
+		ReleaseAfterDays: releaseAfterDays,
+		ReleaseAt:        releaseAt,
+		Quiz:             quiz,
+	})
+}
+
+func (db *Database) UpdateChapter(id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.chaptersToUpdate = append(db.bulk.chaptersToUpdate, &bulkChapter{
+		ID:               id,
+		Title:            title,
+		Slug:             slug,
+		Chapter:          chapter,
+		Content:          content,
+		TableOfContents:  toc,
+		WordCount:        wordCount,
+		ReadingTime:      readingTime,
+		FileChecksum:     fileChecksum,
+		FileKey:          fileKey,
+		CourseKey:        courseKey,
+		ReleaseAfterDays: releaseAfterDays,
+		ReleaseAt:        releaseAt,
+		Quiz:             quiz,
+	})
+}
+
+func (db *Database) DeleteChapter(id string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.bulk.chaptersToDelete = append(db.bulk.chaptersToDelete, id)
+}
+
+// RunBulkCourses applies the staged courses and chapters. Just like the real implementations the author of an updated
+// course is left as is.
+func (db *Database) RunBulkCourses(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RunBulkCourses"); err != nil {
+		return err
+	}
+
+	snapshot := db.snapshot()
+	now := time.Now()
+
+	for _, staged := range db.bulk.coursesToInsert {
+		id, err := newID()
+		if err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+
+		db.courses[id] = &models.CourseModel{
+			ID:           id,
+			Title:        staged.Title,
+			Slug:         staged.Slug,
+			Description:  staged.Description,
+			ThumbnailURL: staged.ThumbnailURL,
+			BannerURL:    staged.BannerURL,
+			Content:      staged.Content,
+			FileChecksum: staged.FileChecksum,
+			FileKey:      staged.FileKey,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		db.courseKeywords[id] = append([]string(nil), staged.Keywords...)
+		db.setPublishSchedule(id, staged.PublishAt, staged.UnpublishAt)
+	}
+
+	for _, staged := range db.bulk.coursesToUpdate {
+		course, has := db.courses[staged.ID]
+		if !has {
+			continue
+		}
+
+		db.addSlugHistory(database.CourseSlug, course.ID, course.Slug, staged.Slug)
+		course.Title = staged.Title
+		course.Slug = staged.Slug
+		course.Description = staged.Description
+		course.ThumbnailURL = staged.ThumbnailURL
+		course.BannerURL = staged.BannerURL
+		course.Content = staged.Content
+		course.FileChecksum = staged.FileChecksum
+		course.FileKey = staged.FileKey
+		course.UpdatedAt = now
+		db.courseKeywords[course.ID] = append([]string(nil), staged.Keywords...)
+		db.setPublishSchedule(course.ID, staged.PublishAt, staged.UnpublishAt)
+	}
+
+	for _, id := range db.bulk.chaptersToDelete {
+		delete(db.chapters, id)
+		delete(db.releaseSetAt, id)
+		deleteWhere(db.chapterCompletions, func(completion *chapterCompletion) bool { return completion.ChapterID == id })
+		db.deleteChapterQuiz(id)
+		deleteWhere(db.releaseNotifications, func(notification *models.ChapterReleaseNotificationModel) bool { return notification.ChapterID == id })
+	}
+
+	for _, staged := range db.bulk.chaptersToInsert {
+		id, err := newID()
+		if err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+
+		db.insertChapter(id, staged.Title, staged.Slug, staged.Chapter, staged.Content, staged.TableOfContents, staged.WordCount, staged.ReadingTime, staged.FileChecksum, staged.FileKey, staged.CourseKey, staged.ReleaseAfterDays, staged.ReleaseAt)
+
+		if err := db.setChapterQuiz(id, staged.Quiz); err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+	}
+
+	for _, staged := range db.bulk.chaptersToUpdate {
+		chapter, has := db.chapters[staged.ID]
+		if !has {
+			continue
+		}
+
+		db.addSlugHistory(database.ChapterSlug, chapter.ID, chapter.Slug, staged.Slug)
+		chapter.Title = staged.Title
+		chapter.Slug = staged.Slug
+		chapter.Chapter = staged.Chapter
+		chapter.Content = staged.Content
+		chapter.TableOfContents = staged.TableOfContents
+		chapter.WordCount = staged.WordCount
+		chapter.ReadingTime = staged.ReadingTime
+		chapter.FileChecksum = staged.FileChecksum
+		chapter.FileKey = staged.FileKey
+		if chapter.ReleaseAfterDays != staged.ReleaseAfterDays || !chapter.ReleaseAt.Time.Equal(staged.ReleaseAt.Time) || chapter.ReleaseAt.Valid != staged.ReleaseAt.Valid {
+			db.releaseSetAt[chapter.ID] = now
+		}
+
+		chapter.ReleaseAfterDays = staged.ReleaseAfterDays
+		chapter.ReleaseAt = staged.ReleaseAt
+		chapter.CourseID = db.courseIDByFileKey(staged.CourseKey)
+		chapter.UpdatedAt = now
+
+		if err := db.setChapterQuiz(chapter.ID, staged.Quiz); err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+	}
+
+	db.updateCourseReadingTimes()
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AddCertificate(ctx context.Context, userId, courseId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddCertificate"); err != nil {
+		return err
+	}
+
+	if db.certificateFor(userId, courseId) != nil {
+		return nil
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.certificates[id] = &models.CertificateModel{
+		ID:        id,
+		UserID:    userId,
+		CourseID:  courseId,
+		CreatedAt: time.Now(),
+	}
+
+	return nil
+}
+
+func (db *Database) GetCertificateFromID(ctx context.Context, certificateId string) (*models.CertificateModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCertificateFromID"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.certificates[certificateId]), nil
+}
+
+func (db *Database) GetCertificateFromUserAndCourse(ctx context.Context, userId, courseId string) (*models.CertificateModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCertificateFromUserAndCourse"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.certificateFor(userId, courseId)), nil
+}
+
+func (db *Database) GetUserFromCertificate(ctx context.Context, certificateId string) (*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserFromCertificate"); err != nil {
+		return nil, err
+	}
+
+	certificate, has := db.certificates[certificateId]
+	if !has {
+		return nil, nil
+	}
+
+	return copyOf(db.users[certificate.UserID]), nil
+}
+
+func (db *Database) GetCourseFromCertificate(ctx context.Context, certificateId string) (*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseFromCertificate"); err != nil {
+		return nil, err
+	}
+
+	certificate, has := db.certificates[certificateId]
+	if !has {
+		return nil, nil
+	}
+
+	return copyOf(db.courses[certificate.CourseID]), nil
+}
+
+func (db *Database) certificateFor(userId, courseId string) *models.CertificateModel {
+	for _, certificate := range db.certificates {
+		if certificate.UserID == userId && certificate.CourseID == courseId {
+			return certificate
+		}
+	}
+
+	return nil
+}
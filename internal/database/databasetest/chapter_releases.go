@@ -0,0 +1,149 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetDueChapterReleases(ctx context.Context) ([]*models.ChapterReleaseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetDueChapterReleases"); err != nil {
+		return nil, err
+	}
+
+	type dueRelease struct {
+		release    *models.ChapterReleaseModel
+		chapter    int
+		releasedAt time.Time
+	}
+
+	now := time.Now()
+	var due []*dueRelease
+
+	for _, chapter := range db.chapters {
+		if !chapter.ReleaseAfterDays.Valid && !chapter.ReleaseAt.Valid {
+			continue
+		}
+
+		course, has := db.courses[chapter.CourseID]
+		if !has || !course.Published || db.isDeleted(course.ID) {
+			continue
+		}
+
+		for _, user := range db.users {
+			if db.isDeleted(user.ID) || db.hasReleaseNotification(user.ID, chapter.ID) {
+				continue
+			}
+
+			purchasedAt, has := db.purchaseDate(user.ID, course.ID)
+			if !has {
+				continue
+			}
+
+			releasedAt := chapter.ReleaseTime(purchasedAt)
+			if !releasedAt.After(purchasedAt) || releasedAt.After(now) {
+				continue
+			}
+
+			if setAt, has := db.releaseSetAt[chapter.ID]; has && releasedAt.Before(setAt) {
+				continue
+			}
+
+			due = append(due, &dueRelease{
+				release: &models.ChapterReleaseModel{
+					UserID:       user.ID,
+					Name:         user.Name,
+					Email:        user.Email,
+					CourseTitle:  course.Title,
+					CourseSlug:   course.Slug,
+					ChapterID:    chapter.ID,
+					ChapterTitle: chapter.Title,
+					ChapterSlug:  chapter.Slug,
+				},
+				chapter:    chapter.Chapter,
+				releasedAt: releasedAt,
+			})
+		}
+	}
+
+	sortBy(due, func(a, b *dueRelease) bool {
+		if !a.releasedAt.Equal(b.releasedAt) {
+			return a.releasedAt.Before(b.releasedAt)
+		}
+
+		if a.release.UserID != b.release.UserID {
+			return a.release.UserID < b.release.UserID
+		}
+
+		return a.chapter < b.chapter
+	})
+
+	var releases []*models.ChapterReleaseModel
+	for _, d := range due {
+		releases = append(releases, d.release)
+	}
+
+	return releases, nil
+}
+
+func (db *Database) AddChapterReleaseNotification(ctx context.Context, userId, chapterId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddChapterReleaseNotification"); err != nil {
+		return err
+	}
+
+	if db.hasReleaseNotification(userId, chapterId) {
+		return database.ErrChapterReleaseAlreadyNotified
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.releaseNotifications[id] = &models.ChapterReleaseNotificationModel{
+		ID:        id,
+		UserID:    userId,
+		ChapterID: chapterId,
+		CreatedAt: time.Now(),
+	}
+
+	return nil
+}
+
+func (db *Database) GetUserChapterReleaseNotifications(ctx context.Context, userId string) ([]*models.ChapterReleaseNotificationModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserChapterReleaseNotifications"); err != nil {
+		return nil, err
+	}
+
+	notifications := rows(db.releaseNotifications, func(notification *models.ChapterReleaseNotificationModel) bool {
+		return notification.UserID == userId
+	})
+	sortBy(notifications, func(a, b *models.ChapterReleaseNotificationModel) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+
+		return a.ID > b.ID
+	})
+
+	return notifications, nil
+}
+
+// hasReleaseNotification reports whether the user has already been told that the chapter unlocked for them. The caller
+// needs to hold the lock.
+func (db *Database) hasReleaseNotification(userId, chapterId string) bool {
+	return find(db.releaseNotifications, func(notification *models.ChapterReleaseNotificationModel) bool {
+		return notification.UserID == userId && notification.ChapterID == chapterId
+	}) != nil
+}
@@ -0,0 +1,242 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetAllChapters(ctx context.Context) ([]*models.ChapterModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllChapters"); err != nil {
+		return nil, err
+	}
+
+	chapters := rows(db.chapters, nil)
+	sortChapters(chapters)
+
+	return chapters, nil
+}
+
+func (db *Database) GetChapterBySlug(ctx context.Context, chapterSlug string) (*models.ChapterModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetChapterBySlug"); err != nil {
+		return nil, err
+	}
+
+	return find(db.chapters, func(chapter *models.ChapterModel) bool {
+		return chapter.Slug == chapterSlug
+	}), nil
+}
+
+func (db *Database) GetChapterByFileKey(ctx context.Context, fileKey string) (*models.ChapterModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetChapterByFileKey"); err != nil {
+		return nil, err
+	}
+
+	return find(db.chapters, func(chapter *models.ChapterModel) bool {
+		return chapter.FileKey == fileKey
+	}), nil
+}
+
+func (db *Database) CountChapters(ctx context.Context, courseId string) (int, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountChapters"); err != nil {
+		return 0, err
+	}
+
+	return int(count(db.chapters, func(chapter *models.ChapterModel) bool {
+		return chapter.CourseID == courseId
+	})), nil
+}
+
+func (db *Database) GetCourseChapters(ctx context.Context, courseId string) ([]*models.ChapterModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseChapters"); err != nil {
+		return nil, err
+	}
+
+	return db.courseChapters(courseId), nil
+}
+
+func (db *Database) HasUserCompletedChapter(ctx context.Context, userId, courseId, chapterId string) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("HasUserCompletedChapter"); err != nil {
+		return false, err
+	}
+
+	return db.completedChapter(userId, courseId, chapterId), nil
+}
+
+func (db *Database) GetAllChaptersCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllChaptersCompleted"); err != nil {
+		return nil, err
+	}
+
+	var chapters []*models.ChapterModel
+
+	for _, chapter := range db.courseChapters(courseId) {
+		if db.completedChapter(userId, courseId, chapter.ID) {
+			chapters = append(chapters, chapter)
+		}
+	}
+
+	return chapters, nil
+}
+
+func (db *Database) GetAllChaptersNotCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllChaptersNotCompleted"); err != nil {
+		return nil, err
+	}
+
+	var chapters []*models.ChapterModel
+
+	for _, chapter := range db.courseChapters(courseId) {
+		if !db.completedChapter(userId, courseId, chapter.ID) {
+			chapters = append(chapters, chapter)
+		}
+	}
+
+	return chapters, nil
+}
+
+func (db *Database) FinishChapter(ctx context.Context, userId, chapterId, courseId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("FinishChapter"); err != nil {
+		return err
+	}
+
+	finished := find(db.chapterCompletions, func(completion *chapterCompletion) bool {
+		return completion.UserID == userId && completion.CourseID == courseId && completion.ChapterID == chapterId
+	})
+	if finished != nil {
+		return nil
+	}
+
+	quiz := find(db.quizzes, func(quiz *models.QuizModel) bool {
+		return quiz.ChapterID == chapterId
+	})
+	if quiz != nil && !db.hasPassedQuiz(userId, quiz.ID) {
+		return database.ErrQuizNotPassed
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.chapterCompletions[id] = &chapterCompletion{
+		UserID:    userId,
+		CourseID:  courseId,
+		ChapterID: chapterId,
+	}
+
+	return nil
+}
+
+// SeedChapters adds the given chapters to the database as is.
+func (db *Database) SeedChapters(chapters ...*models.ChapterModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, chapter := range chapters {
+		db.chapters[chapter.ID] = copyOf(chapter)
+	}
+}
+
+func (db *Database) courseChapters(courseId string) []*models.ChapterModel {
+	chapters := rows(db.chapters, func(chapter *models.ChapterModel) bool {
+		return chapter.CourseID == courseId
+	})
+	sortChapters(chapters)
+
+	return chapters
+}
+
+func (db *Database) completedChapter(userId, courseId, chapterId string) bool {
+	for _, completion := range db.chapterCompletions {
+		if completion.UserID == userId && completion.CourseID == courseId && completion.ChapterID == chapterId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// insertChapter adds a new chapter to the course with the given file key. The caller needs to hold the lock.
+func (db *Database) insertChapter(id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime) {
+	now := time.Now()
+
+	db.chapters[id] = &models.ChapterModel{
+		ID:               id,
+		Title:            title,
+		Slug:             slug,
+		Chapter:          chapter,
+		Content:          content,
+		TableOfContents:  toc,
+		WordCount:        wordCount,
+		ReadingTime:      readingTime,
+		CourseID:         db.courseIDByFileKey(courseKey),
+		FileChecksum:     fileChecksum,
+		FileKey:          fileKey,
+		ReleaseAfterDays: releaseAfterDays,
+		ReleaseAt:        releaseAt,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	db.releaseSetAt[id] = now
+}
+
+// updateCourseReadingTimes sets the reading time of every course to the total reading time of its chapters.
+func (db *Database) updateCourseReadingTimes() {
+	for _, course := range db.courses {
+		course.ReadingTime = 0
+	}
+
+	for _, chapter := range db.chapters {
+		if course, has := db.courses[chapter.CourseID]; has {
+			course.ReadingTime += chapter.ReadingTime
+		}
+	}
+}
+
+func (db *Database) courseIDByFileKey(fileKey string) string {
+	for _, course := range db.courses {
+		if course.FileKey == fileKey {
+			return course.ID
+		}
+	}
+
+	return ""
+}
+
+func sortChapters(chapters []*models.ChapterModel) {
+	sortBy(chapters, func(a, b *models.ChapterModel) bool {
+		return a.Chapter < b.Chapter
+	})
+}
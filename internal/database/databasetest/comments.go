@@ -0,0 +1,189 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AdminGetComments(ctx context.Context, term, tutorialId, userId string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CommentModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("AdminGetComments"); err != nil {
+		return nil, nil, err
+	}
+
+	comments := rows(db.comments, func(comment *models.CommentModel) bool {
+		if db.isDeleted(comment.ID) != deleted || !contains(term, comment.ID, comment.Content) {
+			return false
+		}
+
+		if tutorialId != "" && comment.TutorialID != tutorialId {
+			return false
+		}
+
+		return userId == "" || comment.UserID == userId
+	})
+	comments, next := paginate(comments, cursor, int(elements), func(row *models.CommentModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return comments, next, nil
+}
+
+func (db *Database) GetAllCommentsPaginated(ctx context.Context, tutorialId string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllCommentsPaginated"); err != nil {
+		return nil, nil, err
+	}
+
+	comments, next := paginate(db.tutorialComments(tutorialId), cursor, elements, func(row *models.CommentModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return comments, next, nil
+}
+
+func (db *Database) GetAllCommentsBySlugPaginated(ctx context.Context, slug string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllCommentsBySlugPaginated"); err != nil {
+		return nil, nil, err
+	}
+
+	tutorial := db.tutorialBySlug(slug)
+	if tutorial == nil {
+		return nil, nil, nil
+	}
+
+	comments, next := paginate(db.tutorialComments(tutorial.ID), cursor, elements, func(row *models.CommentModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return comments, next, nil
+}
+
+func (db *Database) CountCommentsForTutorial(ctx context.Context, tutorialId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountCommentsForTutorial"); err != nil {
+		return 0, err
+	}
+
+	return count(db.comments, func(comment *models.CommentModel) bool {
+		return comment.TutorialID == tutorialId && !db.isDeleted(comment.ID)
+	}), nil
+}
+
+// AddCommentBySlug adds a comment to the tutorial with the given slug. Just like the real implementations the returned
+// comment doesn't have its TutorialID set.
+func (db *Database) AddCommentBySlug(ctx context.Context, content, userId, slug string) (*models.CommentModel, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddCommentBySlug"); err != nil {
+		return nil, err
+	}
+
+	tutorial := db.tutorialBySlug(slug)
+	if tutorial == nil {
+		return nil, database.ErrNoRowsAffected
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &models.CommentModel{
+		ID:        id,
+		Content:   content,
+		UserID:    userId,
+		CreatedAt: time.Now(),
+	}
+
+	db.comments[id] = copyOf(comment)
+	db.comments[id].TutorialID = tutorial.ID
+
+	return comment, nil
+}
+
+func (db *Database) CountComments(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountComments"); err != nil {
+		return 0, err
+	}
+
+	return count(db.comments, func(comment *models.CommentModel) bool {
+		return !db.isDeleted(comment.ID)
+	}), nil
+}
+
+func (db *Database) DeleteComment(ctx context.Context, commentId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteComment"); err != nil {
+		return err
+	}
+
+	_, has := db.comments[commentId]
+
+	return db.softDelete(has, commentId)
+}
+
+func (db *Database) GetCommentByID(ctx context.Context, commentId string) (*models.CommentModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCommentByID"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.comments[commentId]), nil
+}
+
+func (db *Database) RestoreComment(ctx context.Context, commentId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RestoreComment"); err != nil {
+		return err
+	}
+
+	return db.undelete(commentId)
+}
+
+// SeedComments adds the given comments to the database as is.
+func (db *Database) SeedComments(comments ...*models.CommentModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, comment := range comments {
+		db.comments[comment.ID] = copyOf(comment)
+	}
+}
+
+func (db *Database) tutorialComments(tutorialId string) []*models.CommentModel {
+	comments := rows(db.comments, func(comment *models.CommentModel) bool {
+		return comment.TutorialID == tutorialId && !db.isDeleted(comment.ID)
+	})
+	sortCommentsByCreatedAt(comments)
+
+	return comments
+}
+
+func sortCommentsByCreatedAt(comments []*models.CommentModel) {
+	sortBy(comments, func(a, b *models.CommentModel) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+}
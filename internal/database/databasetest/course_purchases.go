@@ -0,0 +1,396 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AdminGetCoursePurchases(ctx context.Context, term string, courseId string, authorId string, status string, cursor *database.Cursor, elements uint) ([]*models.CoursePurchaseModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("AdminGetCoursePurchases"); err != nil {
+		return nil, nil, err
+	}
+
+	purchases := rows(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		user := db.users[purchase.UserID]
+		course := db.courses[purchase.CourseID]
+
+		if term != "" {
+			values := []string{purchase.ID, purchase.PaymentKey, purchase.StripeCheckoutSessionID, nullString(purchase.AffiliateCode), nullString(purchase.DiscountCode)}
+
+			if user != nil {
+				values = append(values, user.Name, user.Surname)
+			}
+
+			if course != nil {
+				values = append(values, course.Title)
+			}
+
+			if !contains(term, values...) {
+				return false
+			}
+		}
+
+		if courseId != "" && purchase.CourseID != courseId {
+			return false
+		}
+
+		if authorId != "" && (course == nil || nullString(course.AuthorID) != authorId) {
+			return false
+		}
+
+		return status == "" || purchase.PaymentStatus == status
+	})
+	purchases, next := paginate(purchases, cursor, int(elements), func(row *models.CoursePurchaseModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return purchases, next, nil
+}
+
+func (db *Database) HasUserPurchasedCourse(ctx context.Context, userId, courseId string) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("HasUserPurchasedCourse"); err != nil {
+		return false, err
+	}
+
+	return db.findPurchase(userId, courseId, database.Succeeded.String()) != nil, nil
+}
+
+func (db *Database) RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RegisterCoursePurchase"); err != nil {
+		return err
+	}
+
+	user, has := db.users[userId]
+	if !has {
+		return sql.ErrNoRows
+	}
+
+	course, has := db.courses[courseId]
+	if !has {
+		return sql.ErrNoRows
+	}
+
+	if db.findPurchase(user.ID, course.ID, database.Succeeded.String()) != nil {
+		return database.ErrCourseAlreadyOwned
+	}
+
+	if user.AffiliatePoints < int(affiliatePointsUsed) {
+		return database.ErrInsufficientAffiliatePoints
+	}
+
+	// Work on a snapshot so that a failure halfway through leaves the database untouched, the same way the real
+	// implementations roll back their transaction.
+	snapshot := db.snapshot()
+
+	if affiliatePointsUsed > 0 {
+		if err := db.insertAffiliatePointsChange(user.ID, course.ID, -1*int(affiliatePointsUsed), fmt.Sprintf("Purchased \"%s\"", course.Title)); err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+	}
+
+	id, err := newID()
+	if err != nil {
+		db.restoreLocked(snapshot)
+		return err
+	}
+
+	now := time.Now()
+
+	db.coursePurchases[id] = &models.CoursePurchaseModel{
+		ID:                      id,
+		UserID:                  user.ID,
+		CourseID:                course.ID,
+		PaymentKey:              paymentKey,
+		StripeCheckoutSessionID: stripeCheckoutSessionId,
+		AffiliateCode:           affiliateCode,
+		DiscountCode:            discountCode,
+		AffiliatePointsUsed:     affiliatePointsUsed,
+		AmountPaid:              amountPaid,
+		PaymentStatus:           database.Pending.String(),
+		CreatedAt:               now,
+		UpdatedAt:               now,
+	}
+
+	if token != "" {
+		if err := db.insertToken(token, tokenType, user.ID, validUntil); err != nil {
+			db.restoreLocked(snapshot)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) CountAllPurchases(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountAllPurchases"); err != nil {
+		return 0, err
+	}
+
+	return count(db.coursePurchases, nil), nil
+}
+
+func (db *Database) CountCoursesWhereDiscountWasUsed(ctx context.Context, discountCode string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountCoursesWhereDiscountWasUsed"); err != nil {
+		return 0, err
+	}
+
+	return count(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.DiscountCode.Valid && purchase.DiscountCode.String == discountCode
+	}), nil
+}
+
+func (db *Database) CountUsersWhoBoughtCourse(ctx context.Context, courseId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountUsersWhoBoughtCourse"); err != nil {
+		return 0, err
+	}
+
+	return count(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.CourseID == courseId
+	}), nil
+}
+
+func (db *Database) GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursePurchaseByPaymentKey"); err != nil {
+		return nil, err
+	}
+
+	return find(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.PaymentKey == paymentKey
+	}), nil
+}
+
+func (db *Database) GetCoursePurchaseByID(ctx context.Context, coursePurchaseId string) (*models.CoursePurchaseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursePurchaseByID"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.coursePurchases[coursePurchaseId]), nil
+}
+
+func (db *Database) GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursePurchaseByCheckoutSession"); err != nil {
+		return nil, err
+	}
+
+	return find(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.StripeCheckoutSessionID == checkoutSessionId
+	}), nil
+}
+
+func (db *Database) GetCourseByCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseByCoursePurchaseID"); err != nil {
+		return nil, err
+	}
+
+	purchase, has := db.coursePurchases[coursePurchaseId]
+	if !has {
+		return nil, nil
+	}
+
+	return copyOf(db.courses[purchase.CourseID]), nil
+}
+
+func (db *Database) UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status database.PaymentStatus) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("UpdateCoursePurchasePaymentStatus"); err != nil {
+		return err
+	}
+
+	purchase, has := db.coursePurchases[coursePurchaseId]
+	if !has {
+		return database.ErrNoRowsAffected
+	}
+
+	purchase.PaymentStatus = status.String()
+	purchase.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (db *Database) TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("TransitionCoursePurchasePaymentStatus"); err != nil {
+		return false, err
+	}
+
+	return db.transitionPurchase(coursePurchaseId, from, to), nil
+}
+
+func (db *Database) GetCoursesBoughtByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursesBoughtByUser"); err != nil {
+		return nil, nil, err
+	}
+
+	// The courses are ordered by when they were bought so the cursor points at the purchase rather than the course.
+	purchases := rows(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		course, has := db.courses[purchase.CourseID]
+
+		return purchase.UserID == userId && purchase.PaymentStatus == database.Succeeded.String() && has && course.Published && !db.isDeleted(course.ID) && contains(term, course.Title, course.Slug, course.Description)
+	})
+
+	purchases, next := paginate(purchases, cursor, int(elements), func(row *models.CoursePurchaseModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	var courses []*models.CourseModel
+
+	for _, purchase := range purchases {
+		courses = append(courses, copyOf(db.courses[purchase.CourseID]))
+	}
+
+	return courses, next, nil
+}
+
+func (db *Database) GetAllCoursesBoughtByUser(ctx context.Context, userId string) ([]*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllCoursesBoughtByUser"); err != nil {
+		return nil, err
+	}
+
+	var courses []*models.CourseModel
+
+	for _, purchase := range db.succeededPurchases(userId) {
+		if course, has := db.courses[purchase.CourseID]; has {
+			courses = append(courses, copyOf(course))
+		}
+	}
+
+	return courses, nil
+}
+
+func (db *Database) GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursePurchasesByUserAndCourse"); err != nil {
+		return nil, err
+	}
+
+	return rows(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.UserID == userId && purchase.CourseID == courseId
+	}), nil
+}
+
+// SeedCoursePurchases adds the given course purchases to the database as is. Use CoursePurchaseBuilder to create them.
+func (db *Database) SeedCoursePurchases(purchases ...*models.CoursePurchaseModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, purchase := range purchases {
+		db.coursePurchases[purchase.ID] = copyOf(purchase)
+	}
+}
+
+func (db *Database) GetCoursePurchaseDate(ctx context.Context, userId, courseId string) (sql.NullTime, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursePurchaseDate"); err != nil {
+		return sql.NullTime{}, err
+	}
+
+	purchasedAt, has := db.purchaseDate(userId, courseId)
+
+	return sql.NullTime{Time: purchasedAt, Valid: has}, nil
+}
+
+// findPurchase returns the first purchase of the course by the user with the given payment status. An empty status
+// matches every purchase. The caller needs to hold the lock.
+func (db *Database) findPurchase(userId, courseId, status string) *models.CoursePurchaseModel {
+	return find(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.UserID == userId && purchase.CourseID == courseId && (status == "" || purchase.PaymentStatus == status)
+	})
+}
+
+// purchaseDate returns when the user first bought the course and whether they bought it at all. The caller needs to hold
+// the lock.
+func (db *Database) purchaseDate(userId, courseId string) (time.Time, bool) {
+	var purchasedAt time.Time
+	var has bool
+
+	for _, purchase := range db.coursePurchases {
+		if purchase.UserID != userId || purchase.CourseID != courseId || purchase.PaymentStatus != database.Succeeded.String() {
+			continue
+		}
+
+		if !has || purchase.CreatedAt.Before(purchasedAt) {
+			purchasedAt = purchase.CreatedAt
+			has = true
+		}
+	}
+
+	return purchasedAt, has
+}
+
+// succeededPurchases returns the user's successful purchases, most recently updated first. The caller needs to hold the
+// lock.
+func (db *Database) succeededPurchases(userId string) []*models.CoursePurchaseModel {
+	purchases := rows(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool {
+		return purchase.UserID == userId && purchase.PaymentStatus == database.Succeeded.String()
+	})
+	sortBy(purchases, func(a, b *models.CoursePurchaseModel) bool {
+		return a.UpdatedAt.After(b.UpdatedAt)
+	})
+
+	return purchases
+}
+
+// transitionPurchase moves the purchase to the new payment status if its current status is one of the given statuses
+// and reports whether it did. The caller needs to hold the lock.
+func (db *Database) transitionPurchase(coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) bool {
+	purchase, has := db.coursePurchases[coursePurchaseId]
+	if !has || !slices.ContainsFunc(from, func(status database.PaymentStatus) bool { return status.String() == purchase.PaymentStatus }) {
+		return false
+	}
+
+	purchase.PaymentStatus = to.String()
+	purchase.UpdatedAt = time.Now()
+
+	return true
+}
@@ -0,0 +1,295 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AdminGetCourses(ctx context.Context, term string, published *bool, authorId *string, boughtBy, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("AdminGetCourses"); err != nil {
+		return nil, nil, err
+	}
+
+	courses := rows(db.courses, func(course *models.CourseModel) bool {
+		if db.isDeleted(course.ID) != deleted {
+			return false
+		}
+
+		if term != "" && course.ID != term && !db.matchesCourse(term, course) {
+			return false
+		}
+
+		if published != nil && course.Published != *published {
+			return false
+		}
+
+		if !matchesAuthor(course.AuthorID, authorId) {
+			return false
+		}
+
+		if boughtBy != "" && db.findPurchase(boughtBy, course.ID, "") == nil {
+			return false
+		}
+
+		return keyword == "" || hasKeyword(db.courseKeywords[course.ID], keyword)
+	})
+	courses, next := paginate(courses, cursor, int(elements), func(row *models.CourseModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return courses, next, nil
+}
+
+func (db *Database) GetAllCourses(ctx context.Context, authorId string, published *bool) ([]*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllCourses"); err != nil {
+		return nil, err
+	}
+
+	courses := rows(db.courses, func(course *models.CourseModel) bool {
+		if authorId != "" && nullString(course.AuthorID) != authorId {
+			return false
+		}
+
+		if published != nil {
+			if db.isDeleted(course.ID) {
+				return false
+			}
+
+			if course.Published != *published || (*published && !db.hasActiveAuthor(course.AuthorID)) {
+				return false
+			}
+		}
+
+		return true
+	})
+	sortCoursesByUpdatedAt(courses)
+
+	return courses, nil
+}
+
+func (db *Database) GetCourses(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.CourseModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourses"); err != nil {
+		return nil, nil, err
+	}
+
+	courses := rows(db.courses, func(course *models.CourseModel) bool {
+		if !course.Published || !db.hasActiveAuthor(course.AuthorID) || db.isDeleted(course.ID) {
+			return false
+		}
+
+		if authorId != "" && course.AuthorID.String != authorId {
+			return false
+		}
+
+		return db.matchesCourse(term, course)
+	})
+
+	// Search results don't get ranked so they are ordered the same way as the rest of the courses.
+	courses, next := paginate(courses, cursor, elements, func(row *models.CourseModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return courses, next, nil
+}
+
+func (db *Database) GetCourseByFileKey(ctx context.Context, fileKey string) (*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseByFileKey"); err != nil {
+		return nil, err
+	}
+
+	return find(db.courses, func(course *models.CourseModel) bool {
+		return course.FileKey == fileKey
+	}), nil
+}
+
+func (db *Database) GetCourseBySlug(ctx context.Context, slug string) (*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseBySlug"); err != nil {
+		return nil, err
+	}
+
+	return find(db.courses, func(course *models.CourseModel) bool {
+		return course.Slug == slug && !db.isDeleted(course.ID)
+	}), nil
+}
+
+func (db *Database) GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseByID"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.courses[courseId]), nil
+}
+
+func (db *Database) CountCourses(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountCourses"); err != nil {
+		return 0, err
+	}
+
+	return count(db.courses, func(course *models.CourseModel) bool {
+		return !db.isDeleted(course.ID)
+	}), nil
+}
+
+func (db *Database) CountCoursesWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountCoursesWrittenBy"); err != nil {
+		return 0, err
+	}
+
+	return count(db.courses, func(course *models.CourseModel) bool {
+		return nullString(course.AuthorID) == authorId && !db.isDeleted(course.ID)
+	}), nil
+}
+
+func (db *Database) PublishCourse(ctx context.Context, courseId string) error {
+	return db.updateCourse("PublishCourse", courseId, func(course *models.CourseModel) {
+		course.Published = true
+	})
+}
+
+func (db *Database) UnpublishCourse(ctx context.Context, courseId string) error {
+	return db.updateCourse("UnpublishCourse", courseId, func(course *models.CourseModel) {
+		course.Published = false
+	})
+}
+
+func (db *Database) UpdateCourseAuthor(ctx context.Context, courseId, authorId string) error {
+	return db.updateCourse("UpdateCourseAuthor", courseId, func(course *models.CourseModel) {
+		course.AuthorID = sql.NullString{String: authorId, Valid: true}
+	})
+}
+
+func (db *Database) DeleteCourse(ctx context.Context, courseId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteCourse"); err != nil {
+		return err
+	}
+
+	_, has := db.courses[courseId]
+
+	return db.softDelete(has, courseId)
+}
+
+func (db *Database) RestoreCourse(ctx context.Context, courseId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RestoreCourse"); err != nil {
+		return err
+	}
+
+	return db.undelete(courseId)
+}
+
+func (db *Database) PurgeCourse(ctx context.Context, courseId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("PurgeCourse"); err != nil {
+		return err
+	}
+
+	if _, has := db.courses[courseId]; !has {
+		return database.ErrNoRowsAffected
+	}
+
+	if count(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool { return purchase.CourseID == courseId }) > 0 ||
+		count(db.affiliatePointsHistory, func(history *models.AffiliatePointsHistoryModel) bool { return history.CourseID == courseId }) > 0 {
+		return database.ErrCourseHasPurchases
+	}
+
+	db.purgeCourse(courseId)
+	delete(db.deletedAt, courseId)
+
+	return nil
+}
+
+func (db *Database) GetAllKeywordsForCourse(ctx context.Context, courseId string) ([]string, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllKeywordsForCourse"); err != nil {
+		return nil, err
+	}
+
+	return append([]string(nil), db.courseKeywords[courseId]...), nil
+}
+
+// SeedCourses adds the given courses to the database as is. Use CourseBuilder to create them.
+func (db *Database) SeedCourses(courses ...*models.CourseModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, course := range courses {
+		db.courses[course.ID] = copyOf(course)
+	}
+}
+
+// SeedCourseKeywords sets the keywords of the course with the given ID.
+func (db *Database) SeedCourseKeywords(courseId string, keywords ...string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.courseKeywords[courseId] = append([]string(nil), keywords...)
+}
+
+// matchesCourse stands in for the full-text search done by the real implementations.
+func (db *Database) matchesCourse(term string, course *models.CourseModel) bool {
+	return contains(term, course.Title, course.Slug, course.Description, course.Content, strings.Join(db.courseKeywords[course.ID], " "))
+}
+
+func (db *Database) updateCourse(method, courseId string, update func(course *models.CourseModel)) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail(method); err != nil {
+		return err
+	}
+
+	if course, has := db.courses[courseId]; has {
+		update(course)
+		course.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+func sortCoursesByUpdatedAt(courses []*models.CourseModel) {
+	sortBy(courses, func(a, b *models.CourseModel) bool {
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+
+		return a.Title < b.Title
+	})
+}
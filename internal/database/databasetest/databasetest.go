@@ -0,0 +1,612 @@
+// Package databasetest provides an in-memory implementation of database.Database along with builders for the most
+// commonly used models. It is meant for tests that need a database but shouldn't have to set up a SQLite file.
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// Database is an in-memory implementation of database.Database that is backed by maps. It tries to behave the same
+// way as the SQLite implementation, except that search terms are matched with a simple case insensitive substring
+// search instead of full-text search. It is safe for concurrent use.
+type Database struct {
+	mu sync.RWMutex
+
+	users                  map[string]*models.UserModel
+	tokens                 map[string]*models.TokenModel
+	ipAddresses            map[string]*models.WhitelistedIPModel
+	tutorials              map[string]*models.TutorialModel
+	tutorialKeywords       map[string][]string
+	tutorialLikes          map[string]*relation
+	tutorialBookmarks      map[string]*relation
+	comments               map[string]*models.CommentModel
+	courses                map[string]*models.CourseModel
+	courseKeywords         map[string][]string
+	chapters               map[string]*models.ChapterModel
+	discounts              map[string]*models.DiscountModel
+	coursePurchases        map[string]*models.CoursePurchaseModel
+	affiliatePointsHistory map[string]*models.AffiliatePointsHistoryModel
+	chapterCompletions     map[string]*chapterCompletion
+	certificates           map[string]*models.CertificateModel
+	refunds                map[string]*models.RefundModel
+	auditLogs              map[string]*models.AuditLogModel
+	slugHistory            map[string]*slugHistory
+	series                 map[string]*models.SeriesModel
+	quizzes                map[string]*models.QuizModel
+	quizQuestions          map[string]*models.QuizQuestionModel
+	quizAttempts           map[string]*models.QuizAttemptModel
+	quizAttemptAnswers     map[string]*models.QuizAttemptAnswerModel
+	releaseNotifications   map[string]*models.ChapterReleaseNotificationModel
+
+	// tutorialSeries stands in for the series_id and series_order columns of the tutorials table. It is keyed by
+	// tutorial ID.
+	tutorialSeries map[string]*seriesMembership
+
+	// publishSchedules stands in for the publish_at and unpublish_at columns of the tutorials and courses tables. It is
+	// keyed by row ID, which is unique across tables.
+	publishSchedules map[string]*publishSchedule
+
+	// deletedAt stands in for the deleted_at column of the users, tutorials, courses and comments tables. It is keyed
+	// by row ID, which is unique across tables.
+	deletedAt map[string]time.Time
+
+	// anonymizedAt stands in for the anonymized_at column of the users table. It is keyed by user ID.
+	anonymizedAt map[string]time.Time
+
+	// releaseSetAt stands in for the release_set_at column of the course_chapters table. It is keyed by chapter ID.
+	// Seeded chapters don't have one so their release rule counts as having always been there.
+	releaseSetAt map[string]time.Time
+
+	errors map[string]error
+	bulk   bulkState
+}
+
+var (
+	_ database.Database = (*Database)(nil)
+	_ database.Tx       = (*Database)(nil)
+)
+
+// relation is a row in one of the tables that link a user to a tutorial (likes and bookmarks).
+type relation struct {
+	ID         string
+	UserID     string
+	TutorialID string
+	CreatedAt  time.Time
+}
+
+// chapterCompletion is a row in the user_course_chapter_completion table.
+type chapterCompletion struct {
+	UserID    string
+	CourseID  string
+	ChapterID string
+}
+
+// slugHistory is a row in the slug_history table. Rows are keyed by their kind and slug, which are unique together.
+type slugHistory struct {
+	Kind      database.SlugKind
+	ContentID string
+	Slug      string
+}
+
+// seriesMembership holds the series_id and series_order columns of a tutorial.
+type seriesMembership struct {
+	SeriesID string
+	Order    int
+}
+
+// publishSchedule holds the publish_at and unpublish_at columns of a tutorial or course.
+type publishSchedule struct {
+	PublishAt   sql.NullTime
+	UnpublishAt sql.NullTime
+}
+
+// NewDatabase creates a new empty in-memory database.
+func NewDatabase() *Database {
+	return &Database{
+		users:                  make(map[string]*models.UserModel),
+		tokens:                 make(map[string]*models.TokenModel),
+		ipAddresses:            make(map[string]*models.WhitelistedIPModel),
+		tutorials:              make(map[string]*models.TutorialModel),
+		tutorialKeywords:       make(map[string][]string),
+		tutorialLikes:          make(map[string]*relation),
+		tutorialBookmarks:      make(map[string]*relation),
+		comments:               make(map[string]*models.CommentModel),
+		courses:                make(map[string]*models.CourseModel),
+		courseKeywords:         make(map[string][]string),
+		chapters:               make(map[string]*models.ChapterModel),
+		discounts:              make(map[string]*models.DiscountModel),
+		coursePurchases:        make(map[string]*models.CoursePurchaseModel),
+		affiliatePointsHistory: make(map[string]*models.AffiliatePointsHistoryModel),
+		chapterCompletions:     make(map[string]*chapterCompletion),
+		certificates:           make(map[string]*models.CertificateModel),
+		refunds:                make(map[string]*models.RefundModel),
+		auditLogs:              make(map[string]*models.AuditLogModel),
+		slugHistory:            make(map[string]*slugHistory),
+		series:                 make(map[string]*models.SeriesModel),
+		quizzes:                make(map[string]*models.QuizModel),
+		quizQuestions:          make(map[string]*models.QuizQuestionModel),
+		quizAttempts:           make(map[string]*models.QuizAttemptModel),
+		quizAttemptAnswers:     make(map[string]*models.QuizAttemptAnswerModel),
+		releaseNotifications:   make(map[string]*models.ChapterReleaseNotificationModel),
+		tutorialSeries:         make(map[string]*seriesMembership),
+		publishSchedules:       make(map[string]*publishSchedule),
+		deletedAt:              make(map[string]time.Time),
+		anonymizedAt:           make(map[string]time.Time),
+		releaseSetAt:           make(map[string]time.Time),
+		errors:                 make(map[string]error),
+	}
+}
+
+// FailOn makes every call to the named method (eg "GetCourses") return the given error until ClearErrors gets called.
+// This makes it possible to test how handlers deal with database errors.
+func (db *Database) FailOn(method string, err error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.errors[method] = err
+}
+
+// ClearErrors removes every error that was registered with FailOn.
+func (db *Database) ClearErrors() {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.errors = make(map[string]error)
+}
+
+// fail returns the error registered for the given method. The caller needs to hold the lock.
+func (db *Database) fail(method string) error {
+	return db.errors[method]
+}
+
+func (db *Database) Close() error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.fail("Close")
+}
+
+// WithTx runs fn against the in-memory database. If fn returns an error or panics every change it made gets thrown
+// away, the same way a real transaction would be rolled back.
+func (db *Database) WithTx(ctx context.Context, fn func(tx database.Tx) error) (err error) {
+	db.mu.Lock()
+	if err := db.fail("WithTx"); err != nil {
+		db.mu.Unlock()
+		return err
+	}
+	snapshot := db.snapshot()
+	db.mu.Unlock()
+
+	defer func() {
+		if p := recover(); p != nil {
+			db.restore(snapshot)
+			panic(p)
+		}
+
+		if err != nil {
+			db.restore(snapshot)
+		}
+	}()
+
+	return fn(db)
+}
+
+func (db *Database) MigrateUp() error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.fail("MigrateUp")
+}
+
+func (db *Database) MigrateDown() error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.fail("MigrateDown")
+}
+
+func (db *Database) Rollback(steps int) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.fail("Rollback")
+}
+
+func (db *Database) MigrateTo(version uint) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.fail("MigrateTo")
+}
+
+func (db *Database) ForceMigrationVersion(version int) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.fail("ForceMigrationVersion")
+}
+
+// MigrationVersion reports an empty schema, which matches the empty list returned by Migrations, so the fake always
+// passes the startup schema check.
+func (db *Database) MigrationVersion() (uint, bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("MigrationVersion"); err != nil {
+		return 0, false, err
+	}
+
+	return 0, false, nil
+}
+
+func (db *Database) Migrations() ([]database.Migration, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("Migrations"); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// snapshot makes a copy of every table. The caller needs to hold the lock.
+func (db *Database) snapshot() *Database {
+	return &Database{
+		users:                  cloneTable(db.users),
+		tokens:                 cloneTable(db.tokens),
+		ipAddresses:            cloneTable(db.ipAddresses),
+		tutorials:              cloneTable(db.tutorials),
+		tutorialKeywords:       cloneKeywords(db.tutorialKeywords),
+		tutorialLikes:          cloneTable(db.tutorialLikes),
+		tutorialBookmarks:      cloneTable(db.tutorialBookmarks),
+		comments:               cloneTable(db.comments),
+		courses:                cloneTable(db.courses),
+		courseKeywords:         cloneKeywords(db.courseKeywords),
+		chapters:               cloneTable(db.chapters),
+		discounts:              cloneTable(db.discounts),
+		coursePurchases:        cloneTable(db.coursePurchases),
+		affiliatePointsHistory: cloneTable(db.affiliatePointsHistory),
+		chapterCompletions:     cloneTable(db.chapterCompletions),
+		certificates:           cloneTable(db.certificates),
+		refunds:                cloneTable(db.refunds),
+		auditLogs:              cloneTable(db.auditLogs),
+		slugHistory:            cloneTable(db.slugHistory),
+		series:                 cloneTable(db.series),
+		quizzes:                cloneTable(db.quizzes),
+		quizQuestions:          cloneTable(db.quizQuestions),
+		quizAttempts:           cloneTable(db.quizAttempts),
+		quizAttemptAnswers:     cloneTable(db.quizAttemptAnswers),
+		releaseNotifications:   cloneTable(db.releaseNotifications),
+		tutorialSeries:         cloneTable(db.tutorialSeries),
+		publishSchedules:       cloneTable(db.publishSchedules),
+		deletedAt:              cloneDeletedAt(db.deletedAt),
+		anonymizedAt:           cloneDeletedAt(db.anonymizedAt),
+		releaseSetAt:           cloneDeletedAt(db.releaseSetAt),
+	}
+}
+
+// restore replaces every table with the tables from a snapshot.
+func (db *Database) restore(snapshot *Database) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.restoreLocked(snapshot)
+}
+
+// restoreLocked does the same as restore except that the caller needs to hold the lock.
+func (db *Database) restoreLocked(snapshot *Database) {
+	db.users = snapshot.users
+	db.tokens = snapshot.tokens
+	db.ipAddresses = snapshot.ipAddresses
+	db.tutorials = snapshot.tutorials
+	db.tutorialKeywords = snapshot.tutorialKeywords
+	db.tutorialLikes = snapshot.tutorialLikes
+	db.tutorialBookmarks = snapshot.tutorialBookmarks
+	db.comments = snapshot.comments
+	db.courses = snapshot.courses
+	db.courseKeywords = snapshot.courseKeywords
+	db.chapters = snapshot.chapters
+	db.discounts = snapshot.discounts
+	db.coursePurchases = snapshot.coursePurchases
+	db.affiliatePointsHistory = snapshot.affiliatePointsHistory
+	db.chapterCompletions = snapshot.chapterCompletions
+	db.certificates = snapshot.certificates
+	db.refunds = snapshot.refunds
+	db.auditLogs = snapshot.auditLogs
+	db.slugHistory = snapshot.slugHistory
+	db.series = snapshot.series
+	db.quizzes = snapshot.quizzes
+	db.quizQuestions = snapshot.quizQuestions
+	db.quizAttempts = snapshot.quizAttempts
+	db.quizAttemptAnswers = snapshot.quizAttemptAnswers
+	db.releaseNotifications = snapshot.releaseNotifications
+	db.tutorialSeries = snapshot.tutorialSeries
+	db.publishSchedules = snapshot.publishSchedules
+	db.deletedAt = snapshot.deletedAt
+	db.anonymizedAt = snapshot.anonymizedAt
+	db.releaseSetAt = snapshot.releaseSetAt
+}
+
+// newID generates a new ID the same way the real database implementations do.
+func newID() (string, error) {
+	return database.GenerateID()
+}
+
+// copyOf returns a pointer to a copy of the given row so that callers can't change the stored row.
+func copyOf[T any](row *T) *T {
+	if row == nil {
+		return nil
+	}
+
+	c := *row
+	return &c
+}
+
+func cloneTable[T any](table map[string]*T) map[string]*T {
+	c := make(map[string]*T, len(table))
+
+	for key, row := range table {
+		c[key] = copyOf(row)
+	}
+
+	return c
+}
+
+func cloneKeywords(table map[string][]string) map[string][]string {
+	c := make(map[string][]string, len(table))
+
+	for key, keywords := range table {
+		c[key] = append([]string(nil), keywords...)
+	}
+
+	return c
+}
+
+func cloneDeletedAt(table map[string]time.Time) map[string]time.Time {
+	c := make(map[string]time.Time, len(table))
+
+	for id, deletedAt := range table {
+		c[id] = deletedAt
+	}
+
+	return c
+}
+
+// rows returns a copy of every row in the table that matches the filter.
+func rows[T any](table map[string]*T, filter func(row *T) bool) []*T {
+	var result []*T
+
+	for _, row := range table {
+		if filter == nil || filter(row) {
+			result = append(result, copyOf(row))
+		}
+	}
+
+	return result
+}
+
+// find returns a copy of the first row in the table that matches the filter or nil if there isn't one.
+func find[T any](table map[string]*T, filter func(row *T) bool) *T {
+	for _, row := range table {
+		if filter(row) {
+			return copyOf(row)
+		}
+	}
+
+	return nil
+}
+
+// count returns the number of rows in the table that match the filter.
+func count[T any](table map[string]*T, filter func(row *T) bool) uint {
+	var total uint
+
+	for _, row := range table {
+		if filter == nil || filter(row) {
+			total++
+		}
+	}
+
+	return total
+}
+
+// paginate orders the rows the same way the real implementations order their listings, newest first, and returns the
+// rows that come after the cursor along with the cursor for the next page.
+func paginate[T any](rows []T, cursor *database.Cursor, elements int, cursorOf func(row T) *database.Cursor) ([]T, *database.Cursor) {
+	sortBy(rows, func(a, b T) bool {
+		return newer(cursorOf(a), cursorOf(b))
+	})
+
+	start := 0
+	if cursor != nil {
+		for start < len(rows) && !newer(cursor, cursorOf(rows[start])) {
+			start++
+		}
+	}
+
+	rows = rows[start:]
+	if len(rows) > elements+1 {
+		rows = rows[:elements+1]
+	}
+
+	return database.Paginate(rows, elements, func(i int) *database.Cursor {
+		return cursorOf(rows[i])
+	})
+}
+
+// newer reports whether the row at cursor a comes before the row at cursor b when ordering by created_at DESC and
+// id DESC.
+func newer(a, b *database.Cursor) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+
+	return a.ID > b.ID
+}
+
+// sortBy sorts the rows with the given less function while keeping rows that are equal in a predictable order.
+func sortBy[T any](rows []T, less func(a, b T) bool) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return less(rows[i], rows[j])
+	})
+}
+
+// contains reports whether any of the values contain the search term, ignoring case. An empty term matches
+// everything.
+func contains(term string, values ...string) bool {
+	if term == "" {
+		return true
+	}
+
+	term = strings.ToLower(term)
+
+	for _, value := range values {
+		if strings.Contains(strings.ToLower(value), term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesLevel reports whether the user has the given authorization level. Soft deleted users only match the Deleted
+// and IncludeDeleted levels. The caller needs to hold the lock.
+func (db *Database) matchesLevel(user *models.UserModel, level database.AuthorizationLevel) bool {
+	deleted := db.isDeleted(user.ID)
+
+	switch level {
+	case database.User:
+		return !deleted && !user.IsAdmin && !user.IsAuthor
+	case database.Admin:
+		return !deleted && user.IsAdmin
+	case database.Author:
+		return !deleted && user.IsAuthor
+	case database.Deleted:
+		return deleted
+	case database.IncludeDeleted:
+		return true
+	default:
+		return !deleted
+	}
+}
+
+// isDeleted reports whether the row with the given ID has been soft deleted. The caller needs to hold the lock.
+func (db *Database) isDeleted(id string) bool {
+	_, deleted := db.deletedAt[id]
+	return deleted
+}
+
+// softDelete marks the row with the given ID as deleted. Just like the real implementations it returns
+// ErrNoRowsAffected when the row doesn't exist or has already been deleted. The caller needs to hold the lock.
+func (db *Database) softDelete(exists bool, id string) error {
+	if !exists || db.isDeleted(id) {
+		return database.ErrNoRowsAffected
+	}
+
+	db.deletedAt[id] = time.Now()
+
+	return nil
+}
+
+// undelete brings back a soft deleted row. It returns ErrNoRowsAffected when the row isn't deleted. The caller needs
+// to hold the lock.
+func (db *Database) undelete(id string) error {
+	if !db.isDeleted(id) {
+		return database.ErrNoRowsAffected
+	}
+
+	delete(db.deletedAt, id)
+
+	return nil
+}
+
+func nullString(s sql.NullString) string {
+	if !s.Valid {
+		return ""
+	}
+
+	return s.String
+}
+
+// hasRelation reports whether the user is linked to the tutorial in the given table. The caller needs to hold the lock.
+func (db *Database) hasRelation(table map[string]*relation, userId, tutorialId string) bool {
+	for _, r := range table {
+		if r.UserID == userId && r.TutorialID == tutorialId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addRelation links the user to the tutorial with the given slug. Just like the real implementations it returns
+// ErrNoRowsAffected when the tutorial doesn't exist or the user is already linked to it. The caller needs to hold the
+// lock.
+func (db *Database) addRelation(table map[string]*relation, userId, slug string) error {
+	tutorial := db.tutorialBySlug(slug)
+	if tutorial == nil || db.hasRelation(table, userId, tutorial.ID) {
+		return database.ErrNoRowsAffected
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	table[id] = &relation{
+		ID:         id,
+		UserID:     userId,
+		TutorialID: tutorial.ID,
+		CreatedAt:  time.Now(),
+	}
+
+	return nil
+}
+
+// removeRelation unlinks the user from the tutorial with the given slug. The caller needs to hold the lock.
+func (db *Database) removeRelation(table map[string]*relation, userId, slug string) error {
+	tutorial := db.tutorialBySlug(slug)
+	if tutorial == nil {
+		return database.ErrNoRowsAffected
+	}
+
+	for id, r := range table {
+		if r.UserID == userId && r.TutorialID == tutorial.ID {
+			delete(table, id)
+			return nil
+		}
+	}
+
+	return database.ErrNoRowsAffected
+}
+
+// tutorialsWithRelation returns the page of published tutorials, that come after the cursor, that the user is linked to
+// in the given table. The tutorials are ordered by when they were linked, most recent first, so the cursor points at
+// the relation rather than the tutorial. The caller needs to hold the lock.
+func (db *Database) tutorialsWithRelation(table map[string]*relation, term, userId string, cursor *database.Cursor, elements int) ([]*models.TutorialModel, *database.Cursor) {
+	relations := rows(table, func(r *relation) bool {
+		tutorial, has := db.tutorials[r.TutorialID]
+
+		return r.UserID == userId && has && tutorial.Published && !db.isDeleted(tutorial.ID) && contains(term, tutorial.Title, tutorial.Slug, tutorial.Description)
+	})
+
+	relations, next := paginate(relations, cursor, elements, func(r *relation) *database.Cursor {
+		return database.NewCursor(r.CreatedAt, r.ID)
+	})
+
+	var tutorials []*models.TutorialModel
+
+	for _, r := range relations {
+		tutorials = append(tutorials, copyOf(db.tutorials[r.TutorialID]))
+	}
+
+	return tutorials, next
+}
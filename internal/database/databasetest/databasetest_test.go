@@ -0,0 +1,39 @@
+package databasetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func TestFailOn(t *testing.T) {
+	ctx := context.Background()
+	db := NewDatabase()
+	user := NewUserBuilder().Build()
+	db.SeedUsers(user)
+
+	failure := errors.New("database is down")
+	db.FailOn("GetUserByID", failure)
+
+	if _, err := db.GetUserByID(ctx, user.ID, database.All); !errors.Is(err, failure) {
+		t.Errorf("Expected the registered error. Got %v", err)
+	}
+
+	// Other methods keep working.
+	if found, err := db.GetUserByEmail(ctx, user.Email, database.All); err != nil || found == nil {
+		t.Errorf("Expected GetUserByEmail to find the user. Got %v", err)
+	}
+
+	db.ClearErrors()
+
+	found, err := db.GetUserByID(ctx, user.ID, database.All)
+	if err != nil || found == nil {
+		t.Fatalf("Expected GetUserByID to find the user once the errors were cleared. Got %v", err)
+	}
+
+	if found.Email != user.Email || found.AffiliateCode != user.AffiliateCode {
+		t.Errorf("Expected the seeded user to be returned as is. Got %+v", found)
+	}
+}
@@ -0,0 +1,145 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetDiscountsPaginated(ctx context.Context, term string, active *bool, cursor *database.Cursor, elements uint) ([]*models.DiscountModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetDiscountsPaginated"); err != nil {
+		return nil, nil, err
+	}
+
+	discounts := rows(db.discounts, func(discount *models.DiscountModel) bool {
+		if !contains(term, discount.ID, discount.Title, discount.Description, discount.Code) {
+			return false
+		}
+
+		return active == nil || discount.Active == *active
+	})
+	discounts, next := paginate(discounts, cursor, int(elements), func(row *models.DiscountModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return discounts, next, nil
+}
+
+func (db *Database) GetAllDiscounts(ctx context.Context) ([]*models.DiscountModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllDiscounts"); err != nil {
+		return nil, err
+	}
+
+	return rows(db.discounts, nil), nil
+}
+
+func (db *Database) CountDiscounts(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountDiscounts"); err != nil {
+		return 0, err
+	}
+
+	return count(db.discounts, nil), nil
+}
+
+func (db *Database) AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddDiscount"); err != nil {
+		return "", err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	code, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	db.discounts[id] = &models.DiscountModel{
+		ID:          id,
+		Title:       title,
+		Description: description,
+		Code:        code,
+		Discount:    uint(discount),
+		Uses:        uint(uses),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	return id, nil
+}
+
+func (db *Database) GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetDiscountByID"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.discounts[discountId]), nil
+}
+
+func (db *Database) GetDiscountByCode(ctx context.Context, discountCode string) (*models.DiscountModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetDiscountByCode"); err != nil {
+		return nil, err
+	}
+
+	return find(db.discounts, func(discount *models.DiscountModel) bool {
+		return discount.Code == discountCode
+	}), nil
+}
+
+func (db *Database) ActivateDiscount(ctx context.Context, discountId string) error {
+	return db.setDiscountActive("ActivateDiscount", discountId, true)
+}
+
+func (db *Database) DeactivateDiscount(ctx context.Context, discountId string) error {
+	return db.setDiscountActive("DeactivateDiscount", discountId, false)
+}
+
+// SeedDiscounts adds the given discounts to the database as is.
+func (db *Database) SeedDiscounts(discounts ...*models.DiscountModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, discount := range discounts {
+		db.discounts[discount.ID] = copyOf(discount)
+	}
+}
+
+func (db *Database) setDiscountActive(method, discountId string, active bool) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail(method); err != nil {
+		return err
+	}
+
+	if discount, has := db.discounts[discountId]; has {
+		discount.Active = active
+		discount.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AddIPAddress(ctx context.Context, userId, ipAddr string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddIPAddress"); err != nil {
+		return err
+	}
+
+	return db.insertIPAddress(userId, ipAddr)
+}
+
+func (db *Database) GetUserIpAddresses(ctx context.Context, userId string) ([]*models.WhitelistedIPModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserIpAddresses"); err != nil {
+		return nil, err
+	}
+
+	ipAddresses := rows(db.ipAddresses, func(ipAddress *models.WhitelistedIPModel) bool {
+		return ipAddress.UserID == userId
+	})
+	sortBy(ipAddresses, func(a, b *models.WhitelistedIPModel) bool {
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	return ipAddresses, nil
+}
+
+func (db *Database) DeleteIPAddress(ctx context.Context, ipAddrId, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteIPAddress"); err != nil {
+		return err
+	}
+
+	if ipAddress, has := db.ipAddresses[ipAddrId]; has && ipAddress.UserID == userId {
+		delete(db.ipAddresses, ipAddrId)
+	}
+
+	return nil
+}
+
+// insertIPAddress adds a new row to the whitelisted_ips table unless the user already whitelisted the IP address. The
+// caller needs to hold the lock.
+func (db *Database) insertIPAddress(userId, ipAddr string) error {
+	for _, ipAddress := range db.ipAddresses {
+		if ipAddress.UserID == userId && ipAddress.IPAddress == ipAddr {
+			return nil
+		}
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.ipAddresses[id] = &models.WhitelistedIPModel{
+		ID:        id,
+		UserID:    userId,
+		IPAddress: ipAddr,
+		CreatedAt: time.Now(),
+	}
+
+	return nil
+}
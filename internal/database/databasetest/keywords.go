@@ -0,0 +1,49 @@
+package databasetest
+
+import (
+	"context"
+	"sort"
+)
+
+func (db *Database) GetKeywords(ctx context.Context) ([]string, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetKeywords"); err != nil {
+		return nil, err
+	}
+
+	unique := make(map[string]struct{})
+
+	for _, table := range []map[string][]string{db.tutorialKeywords, db.courseKeywords} {
+		for _, keywords := range table {
+			for _, keyword := range keywords {
+				unique[keyword] = struct{}{}
+			}
+		}
+	}
+
+	var keywords []string
+
+	for keyword := range unique {
+		keywords = append(keywords, keyword)
+	}
+
+	sort.Strings(keywords)
+
+	return keywords, nil
+}
+
+func (db *Database) DeleteAllKeywords(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteAllKeywords"); err != nil {
+		return err
+	}
+
+	db.tutorialKeywords = make(map[string][]string)
+	db.courseKeywords = make(map[string][]string)
+
+	return nil
+}
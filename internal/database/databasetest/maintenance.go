@@ -0,0 +1,236 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) DeleteExpiredTokens(ctx context.Context) (uint, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteExpiredTokens"); err != nil {
+		return 0, err
+	}
+
+	var deleted uint
+	now := time.Now()
+
+	for id, token := range db.tokens {
+		if !token.ValidUntil.After(now) {
+			delete(db.tokens, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func (db *Database) CancelStalePurchases(ctx context.Context, olderThan time.Duration) (uint, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("CancelStalePurchases"); err != nil {
+		return 0, err
+	}
+
+	var cancelled uint
+	cutoff := time.Now().Add(-olderThan)
+
+	for _, purchase := range db.coursePurchases {
+		if purchase.PaymentStatus != database.Pending.String() || purchase.CreatedAt.After(cutoff) {
+			continue
+		}
+
+		if !db.transitionPurchase(purchase.ID, []database.PaymentStatus{database.Pending}, database.Cancelled) {
+			continue
+		}
+
+		if purchase.AffiliatePointsUsed > 0 {
+			if err := db.insertAffiliatePointsChange(purchase.UserID, purchase.CourseID, int(purchase.AffiliatePointsUsed), "Payment expired"); err != nil {
+				return 0, err
+			}
+		}
+
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+// PurgeDeleted permanently removes the rows that were soft deleted before the cutoff. It mirrors the ON DELETE
+// foreign keys of the real tables and, like the real implementations, keeps users and courses that are still needed by
+// the financial records. Those users get anonymized instead.
+func (db *Database) PurgeDeleted(ctx context.Context, olderThan time.Duration) (uint, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("PurgeDeleted"); err != nil {
+		return 0, err
+	}
+
+	var purged uint
+	cutoff := time.Now().Add(-olderThan)
+
+	expired := func(id string) bool {
+		deletedAt, has := db.deletedAt[id]
+		return has && !deletedAt.After(cutoff)
+	}
+
+	purge := func(id string) {
+		delete(db.deletedAt, id)
+		purged++
+	}
+
+	for id := range db.comments {
+		if expired(id) {
+			delete(db.comments, id)
+			purge(id)
+		}
+	}
+
+	for id := range db.tutorials {
+		if expired(id) {
+			db.purgeTutorial(id)
+			purge(id)
+		}
+	}
+
+	for id := range db.courses {
+		if !expired(id) {
+			continue
+		}
+
+		if count(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool { return purchase.CourseID == id }) > 0 ||
+			count(db.affiliatePointsHistory, func(history *models.AffiliatePointsHistoryModel) bool { return history.CourseID == id }) > 0 {
+			continue
+		}
+
+		db.purgeCourse(id)
+		purge(id)
+	}
+
+	for id := range db.users {
+		if !expired(id) {
+			continue
+		}
+
+		if count(db.coursePurchases, func(purchase *models.CoursePurchaseModel) bool { return purchase.UserID == id }) > 0 ||
+			count(db.refunds, func(refund *models.RefundModel) bool { return refund.UserID == id }) > 0 ||
+			count(db.affiliatePointsHistory, func(history *models.AffiliatePointsHistoryModel) bool { return history.UserID == id }) > 0 {
+			if _, anonymized := db.anonymizedAt[id]; !anonymized {
+				db.anonymizeUser(id)
+				purged++
+			}
+
+			continue
+		}
+
+		db.purgeUser(id)
+		purge(id)
+	}
+
+	return purged, nil
+}
+
+func (db *Database) Optimize(ctx context.Context) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.fail("Optimize")
+}
+
+// purgeTutorial removes the tutorial along with every row that references it. The caller needs to hold the lock.
+func (db *Database) purgeTutorial(tutorialId string) {
+	delete(db.tutorials, tutorialId)
+	delete(db.tutorialKeywords, tutorialId)
+	delete(db.tutorialSeries, tutorialId)
+
+	deleteWhere(db.tutorialLikes, func(like *relation) bool { return like.TutorialID == tutorialId })
+	deleteWhere(db.tutorialBookmarks, func(bookmark *relation) bool { return bookmark.TutorialID == tutorialId })
+	deleteWhere(db.comments, func(comment *models.CommentModel) bool { return comment.TutorialID == tutorialId })
+}
+
+// purgeCourse removes the course along with every row that references it. The caller needs to hold the lock.
+func (db *Database) purgeCourse(courseId string) {
+	delete(db.courses, courseId)
+	delete(db.courseKeywords, courseId)
+
+	for _, chapter := range db.chapters {
+		if chapter.CourseID == courseId {
+			db.deleteChapterQuiz(chapter.ID)
+		}
+	}
+
+	deleteWhere(db.chapters, func(chapter *models.ChapterModel) bool { return chapter.CourseID == courseId })
+	deleteWhere(db.chapterCompletions, func(completion *chapterCompletion) bool { return completion.CourseID == courseId })
+	deleteWhere(db.certificates, func(certificate *models.CertificateModel) bool { return certificate.CourseID == courseId })
+}
+
+// purgeUser removes the user along with every row that references them. Tutorials and courses they wrote lose their
+// author instead. The caller needs to hold the lock.
+func (db *Database) purgeUser(userId string) {
+	delete(db.users, userId)
+	delete(db.anonymizedAt, userId)
+
+	deleteWhere(db.tokens, func(token *models.TokenModel) bool { return token.UserID == userId })
+	deleteWhere(db.ipAddresses, func(ipAddress *models.WhitelistedIPModel) bool { return ipAddress.UserID == userId })
+	deleteWhere(db.tutorialLikes, func(like *relation) bool { return like.UserID == userId })
+	deleteWhere(db.tutorialBookmarks, func(bookmark *relation) bool { return bookmark.UserID == userId })
+	deleteWhere(db.comments, func(comment *models.CommentModel) bool { return comment.UserID == userId })
+	deleteWhere(db.chapterCompletions, func(completion *chapterCompletion) bool { return completion.UserID == userId })
+
+	attempts := make(map[string]bool)
+	for _, attempt := range db.quizAttempts {
+		if attempt.UserID == userId {
+			attempts[attempt.ID] = true
+		}
+	}
+
+	deleteWhere(db.quizAttemptAnswers, func(answer *models.QuizAttemptAnswerModel) bool { return attempts[answer.AttemptID] })
+	deleteWhere(db.quizAttempts, func(attempt *models.QuizAttemptModel) bool { return attempt.UserID == userId })
+	deleteWhere(db.certificates, func(certificate *models.CertificateModel) bool { return certificate.UserID == userId })
+	deleteWhere(db.releaseNotifications, func(notification *models.ChapterReleaseNotificationModel) bool { return notification.UserID == userId })
+
+	for _, tutorial := range db.tutorials {
+		if nullString(tutorial.AuthorID) == userId {
+			tutorial.AuthorID = sql.NullString{}
+		}
+	}
+
+	for _, course := range db.courses {
+		if nullString(course.AuthorID) == userId {
+			course.AuthorID = sql.NullString{}
+		}
+	}
+}
+
+// anonymizeUser scrubs the personal details of a user that has to be kept for the financial records and removes their
+// tokens and whitelisted IP addresses. The caller needs to hold the lock.
+func (db *Database) anonymizeUser(userId string) {
+	user := db.users[userId]
+	user.Name = "Deleted"
+	user.Surname = "User"
+	user.Slug = userId
+	user.Email = userId + "@deleted.invalid"
+	user.Password = ""
+	user.UpdatedAt = time.Now()
+
+	db.anonymizedAt[userId] = time.Now()
+
+	deleteWhere(db.tokens, func(token *models.TokenModel) bool { return token.UserID == userId })
+	deleteWhere(db.ipAddresses, func(ipAddress *models.WhitelistedIPModel) bool { return ipAddress.UserID == userId })
+}
+
+// deleteWhere removes every row in the table that matches the filter.
+func deleteWhere[T any](table map[string]*T, filter func(row *T) bool) {
+	for id, row := range table {
+		if filter(row) {
+			delete(table, id)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetTutorialPublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorialPublishSchedules"); err != nil {
+		return nil, err
+	}
+
+	var schedules []*models.PublishScheduleModel
+
+	for _, tutorial := range db.tutorials {
+		if schedule := db.publishScheduleModel(tutorial.ID, tutorial.Title, tutorial.Slug, tutorial.Published, tutorial.UpdatedAt); schedule != nil {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	sortPublishSchedules(schedules)
+
+	return schedules, nil
+}
+
+func (db *Database) GetCoursePublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCoursePublishSchedules"); err != nil {
+		return nil, err
+	}
+
+	var schedules []*models.PublishScheduleModel
+
+	for _, course := range db.courses {
+		if schedule := db.publishScheduleModel(course.ID, course.Title, course.Slug, course.Published, course.UpdatedAt); schedule != nil {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	sortPublishSchedules(schedules)
+
+	return schedules, nil
+}
+
+func (db *Database) RunPublishSchedules(ctx context.Context) (uint, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RunPublishSchedules"); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+
+	var changed uint
+
+	for _, tutorial := range db.tutorials {
+		if db.runPublishSchedule(tutorial.ID, &tutorial.Published, now) {
+			changed++
+		}
+	}
+
+	for _, course := range db.courses {
+		if db.runPublishSchedule(course.ID, &course.Published, now) {
+			changed++
+		}
+	}
+
+	return changed, nil
+}
+
+// setPublishSchedule replaces the publish schedule of a tutorial or course. The caller needs to hold the lock.
+func (db *Database) setPublishSchedule(id string, publishAt, unpublishAt sql.NullTime) {
+	if !publishAt.Valid && !unpublishAt.Valid {
+		delete(db.publishSchedules, id)
+		return
+	}
+
+	db.publishSchedules[id] = &publishSchedule{
+		PublishAt:   publishAt,
+		UnpublishAt: unpublishAt,
+	}
+}
+
+// runPublishSchedule works the same way as the real implementations: the publish status only changes when the row
+// hasn't been deleted and every schedule that is due gets cleared. It returns whether the publish status changed. The
+// caller needs to hold the lock.
+func (db *Database) runPublishSchedule(id string, published *bool, now time.Time) bool {
+	schedule, has := db.publishSchedules[id]
+	if !has {
+		return false
+	}
+
+	publishDue := schedule.PublishAt.Valid && !schedule.PublishAt.Time.After(now)
+	unpublishDue := schedule.UnpublishAt.Valid && !schedule.UnpublishAt.Time.After(now)
+	changed := false
+
+	if !db.isDeleted(id) {
+		if publishDue && !unpublishDue && !*published {
+			*published = true
+			changed = true
+		} else if unpublishDue && *published {
+			*published = false
+			changed = true
+		}
+	}
+
+	if publishDue {
+		schedule.PublishAt = sql.NullTime{}
+	}
+
+	if unpublishDue {
+		schedule.UnpublishAt = sql.NullTime{}
+	}
+
+	if !schedule.PublishAt.Valid && !schedule.UnpublishAt.Valid {
+		delete(db.publishSchedules, id)
+	}
+
+	return changed
+}
+
+// publishScheduleModel returns the publish schedule of a tutorial or course, or nil if it has been deleted or has
+// nothing scheduled. The caller needs to hold the lock.
+func (db *Database) publishScheduleModel(id, title, slug string, published bool, updatedAt time.Time) *models.PublishScheduleModel {
+	schedule, has := db.publishSchedules[id]
+	if !has || db.isDeleted(id) {
+		return nil
+	}
+
+	return &models.PublishScheduleModel{
+		ID:          id,
+		Title:       title,
+		Slug:        slug,
+		Published:   published,
+		PublishAt:   schedule.PublishAt,
+		UnpublishAt: schedule.UnpublishAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+// sortPublishSchedules orders the schedules by whichever of their two times comes first.
+func sortPublishSchedules(schedules []*models.PublishScheduleModel) {
+	sortBy(schedules, func(a, b *models.PublishScheduleModel) bool {
+		if !nextScheduled(a).Equal(nextScheduled(b)) {
+			return nextScheduled(a).Before(nextScheduled(b))
+		}
+
+		return a.ID < b.ID
+	})
+}
+
+func nextScheduled(schedule *models.PublishScheduleModel) time.Time {
+	if !schedule.UnpublishAt.Valid || (schedule.PublishAt.Valid && schedule.PublishAt.Time.Before(schedule.UnpublishAt.Time)) {
+		return schedule.PublishAt.Time
+	}
+
+	return schedule.UnpublishAt.Time
+}
@@ -0,0 +1,290 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetQuizByChapterID(ctx context.Context, chapterId string) (*models.QuizModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetQuizByChapterID"); err != nil {
+		return nil, err
+	}
+
+	quiz := find(db.quizzes, func(quiz *models.QuizModel) bool {
+		return quiz.ChapterID == chapterId
+	})
+	if quiz == nil {
+		return nil, nil
+	}
+
+	quiz.Questions = db.questionsOfQuiz(quiz.ID)
+
+	return quiz, nil
+}
+
+func (db *Database) AddQuizAttempt(ctx context.Context, userId, quizId string, score int, passed bool, answers []*models.QuizAttemptAnswerModel) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddQuizAttempt"); err != nil {
+		return err
+	}
+
+	attemptId, err := newID()
+	if err != nil {
+		return err
+	}
+
+	// Every ID gets generated up front so that a failure doesn't leave half an attempt behind.
+	answerIds := make([]string, len(answers))
+	for i := range answers {
+		if answerIds[i], err = newID(); err != nil {
+			return err
+		}
+	}
+
+	db.quizAttempts[attemptId] = &models.QuizAttemptModel{
+		ID:        attemptId,
+		QuizID:    quizId,
+		UserID:    userId,
+		Score:     score,
+		Passed:    passed,
+		CreatedAt: time.Now(),
+	}
+
+	for i, answer := range answers {
+		db.quizAttemptAnswers[answerIds[i]] = &models.QuizAttemptAnswerModel{
+			ID:         answerIds[i],
+			AttemptID:  attemptId,
+			QuestionID: answer.QuestionID,
+			Answer:     append(models.QuizAnswers(nil), answer.Answer...),
+			Correct:    answer.Correct,
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) GetQuizAttempts(ctx context.Context, userId, quizId string) ([]*models.QuizAttemptModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetQuizAttempts"); err != nil {
+		return nil, err
+	}
+
+	attempts := rows(db.quizAttempts, func(attempt *models.QuizAttemptModel) bool {
+		return attempt.UserID == userId && attempt.QuizID == quizId
+	})
+	sortBy(attempts, func(a, b *models.QuizAttemptModel) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+
+		return a.ID > b.ID
+	})
+
+	return attempts, nil
+}
+
+func (db *Database) GetQuizAttemptAnswers(ctx context.Context, attemptId string) ([]*models.QuizAttemptAnswerModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetQuizAttemptAnswers"); err != nil {
+		return nil, err
+	}
+
+	// Answers are stored in the order of the questions that they answer.
+	positions := make(map[string]int)
+	for _, question := range db.quizQuestions {
+		positions[question.ID] = question.Position
+	}
+
+	answers := rows(db.quizAttemptAnswers, func(answer *models.QuizAttemptAnswerModel) bool {
+		return answer.AttemptID == attemptId
+	})
+	sortBy(answers, func(a, b *models.QuizAttemptAnswerModel) bool {
+		return positions[a.QuestionID] < positions[b.QuestionID]
+	})
+
+	return answers, nil
+}
+
+func (db *Database) HasUserPassedQuiz(ctx context.Context, userId, quizId string) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("HasUserPassedQuiz"); err != nil {
+		return false, err
+	}
+
+	return db.hasPassedQuiz(userId, quizId), nil
+}
+
+func (db *Database) GetQuizQuestionStats(ctx context.Context, quizId string) ([]*models.QuizQuestionStatsModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetQuizQuestionStats"); err != nil {
+		return nil, err
+	}
+
+	var stats []*models.QuizQuestionStatsModel
+
+	for _, question := range db.questionsOfQuiz(quizId) {
+		stat := &models.QuizQuestionStatsModel{
+			QuestionID: question.ID,
+			Position:   question.Position,
+			Kind:       question.Kind,
+			Question:   question.Question,
+		}
+
+		for _, answer := range db.quizAttemptAnswers {
+			if answer.QuestionID != question.ID {
+				continue
+			}
+
+			stat.Answers++
+
+			if answer.Correct {
+				stat.Correct++
+			}
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// SeedQuizzes adds the given quizzes along with their questions to the database as is.
+func (db *Database) SeedQuizzes(quizzes ...*models.QuizModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, quiz := range quizzes {
+		stored := copyOf(quiz)
+		stored.Questions = nil
+		db.quizzes[quiz.ID] = stored
+
+		for _, question := range quiz.Questions {
+			db.quizQuestions[question.ID] = copyOf(question)
+		}
+	}
+}
+
+// questionsOfQuiz returns the questions of a quiz in the order that they should be asked. The caller needs to hold the
+// lock.
+func (db *Database) questionsOfQuiz(quizId string) []*models.QuizQuestionModel {
+	questions := rows(db.quizQuestions, func(question *models.QuizQuestionModel) bool {
+		return question.QuizID == quizId
+	})
+	sortBy(questions, func(a, b *models.QuizQuestionModel) bool {
+		return a.Position < b.Position
+	})
+
+	return questions
+}
+
+// hasPassedQuiz reports whether any of the user's attempts at the quiz reached its pass mark. The caller needs to hold
+// the lock.
+func (db *Database) hasPassedQuiz(userId, quizId string) bool {
+	return find(db.quizAttempts, func(attempt *models.QuizAttemptModel) bool {
+		return attempt.UserID == userId && attempt.QuizID == quizId && attempt.Passed
+	}) != nil
+}
+
+// setChapterQuiz replaces the quiz of a chapter with the given one. Questions are matched by their position so that the
+// attempts at questions which stay in place are kept. A nil quiz removes the chapter's quiz. The caller needs to hold
+// the lock.
+func (db *Database) setChapterQuiz(chapterId string, quiz *models.QuizModel) error {
+	if quiz == nil {
+		db.deleteChapterQuiz(chapterId)
+		return nil
+	}
+
+	now := time.Now()
+
+	stored := find(db.quizzes, func(stored *models.QuizModel) bool {
+		return stored.ChapterID == chapterId
+	})
+
+	if stored == nil {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+
+		stored = &models.QuizModel{
+			ID:        id,
+			ChapterID: chapterId,
+			CreatedAt: now,
+		}
+	}
+
+	stored.PassMark = quiz.PassMark
+	stored.UpdatedAt = now
+	db.quizzes[stored.ID] = stored
+
+	existing := make(map[int]*models.QuizQuestionModel)
+	for _, question := range db.questionsOfQuiz(stored.ID) {
+		existing[question.Position] = question
+	}
+
+	for i, question := range quiz.Questions {
+		position := i + 1
+
+		id := ""
+		if previous, has := existing[position]; has {
+			id = previous.ID
+		} else {
+			var err error
+			if id, err = newID(); err != nil {
+				return err
+			}
+		}
+
+		db.quizQuestions[id] = &models.QuizQuestionModel{
+			ID:       id,
+			QuizID:   stored.ID,
+			Position: position,
+			Kind:     question.Kind,
+			Question: question.Question,
+			Options:  append(models.QuizAnswers(nil), question.Options...),
+			Answers:  append(models.QuizAnswers(nil), question.Answers...),
+		}
+	}
+
+	for position, question := range existing {
+		if position > len(quiz.Questions) {
+			delete(db.quizQuestions, question.ID)
+			deleteWhere(db.quizAttemptAnswers, func(answer *models.QuizAttemptAnswerModel) bool { return answer.QuestionID == question.ID })
+		}
+	}
+
+	return nil
+}
+
+// deleteChapterQuiz removes the quiz of a chapter along with its questions and every attempt at it. The caller needs
+// to hold the lock.
+func (db *Database) deleteChapterQuiz(chapterId string) {
+	for _, quiz := range rows(db.quizzes, func(quiz *models.QuizModel) bool { return quiz.ChapterID == chapterId }) {
+		attempts := make(map[string]bool)
+		for _, attempt := range db.quizAttempts {
+			if attempt.QuizID == quiz.ID {
+				attempts[attempt.ID] = true
+			}
+		}
+
+		deleteWhere(db.quizAttemptAnswers, func(answer *models.QuizAttemptAnswerModel) bool { return attempts[answer.AttemptID] })
+		deleteWhere(db.quizAttempts, func(attempt *models.QuizAttemptModel) bool { return attempt.QuizID == quiz.ID })
+		deleteWhere(db.quizQuestions, func(question *models.QuizQuestionModel) bool { return question.QuizID == quiz.ID })
+		delete(db.quizzes, quiz.ID)
+	}
+}
@@ -0,0 +1,112 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AdminGetRefunds(ctx context.Context, term string, status string, cursor *database.Cursor, elements uint) ([]*models.RefundModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("AdminGetRefunds"); err != nil {
+		return nil, nil, err
+	}
+
+	refunds := rows(db.refunds, func(refund *models.RefundModel) bool {
+		if term != "" {
+			values := []string{refund.ID}
+
+			if user, has := db.users[refund.UserID]; has {
+				values = append(values, user.Name, user.Surname)
+			}
+
+			if purchase, has := db.coursePurchases[refund.CoursePurchaseID]; has {
+				if course, has := db.courses[purchase.CourseID]; has {
+					values = append(values, course.Title)
+				}
+			}
+
+			if !contains(term, values...) {
+				return false
+			}
+		}
+
+		return status == "" || refund.RefundStatus == status
+	})
+	refunds, next := paginate(refunds, cursor, int(elements), func(row *models.RefundModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return refunds, next, nil
+}
+
+func (db *Database) RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status database.RefundStatus) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RegisterRefund"); err != nil {
+		return err
+	}
+
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	db.refunds[id] = &models.RefundModel{
+		ID:               id,
+		UserID:           userId,
+		CoursePurchaseID: coursePurchaseId,
+		RefundStatus:     status.String(),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	return nil
+}
+
+func (db *Database) GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetRefundWithCoursePurchaseID"); err != nil {
+		return nil, err
+	}
+
+	return find(db.refunds, func(refund *models.RefundModel) bool {
+		return refund.CoursePurchaseID == coursePurchaseId
+	}), nil
+}
+
+func (db *Database) UpdateRefundStatus(ctx context.Context, refundId string, status database.RefundStatus) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("UpdateRefundStatus"); err != nil {
+		return err
+	}
+
+	if refund, has := db.refunds[refundId]; has {
+		refund.RefundStatus = status.String()
+		refund.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+func (db *Database) CountRefunds(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountRefunds"); err != nil {
+		return 0, err
+	}
+
+	return count(db.refunds, nil), nil
+}
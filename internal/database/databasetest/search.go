@@ -0,0 +1,85 @@
+package databasetest
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/searchmark"
+)
+
+// snippetContext is the number of characters that gets kept on either side of the match in a search snippet.
+const snippetContext = 60
+
+func (db *Database) GetTutorialSearchSnippets(ctx context.Context, term string, tutorialIds []string) (map[string]string, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorialSearchSnippets"); err != nil {
+		return nil, err
+	}
+
+	snippets := make(map[string]string)
+
+	for _, id := range tutorialIds {
+		if tutorial, has := db.tutorials[id]; has {
+			if snippet := searchSnippet(term, tutorial.Content); snippet != "" {
+				snippets[id] = snippet
+			}
+		}
+	}
+
+	return snippets, nil
+}
+
+func (db *Database) GetCourseSearchSnippets(ctx context.Context, term string, courseIds []string) (map[string]string, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCourseSearchSnippets"); err != nil {
+		return nil, err
+	}
+
+	snippets := make(map[string]string)
+
+	for _, id := range courseIds {
+		if course, has := db.courses[id]; has {
+			if snippet := searchSnippet(term, course.Content); snippet != "" {
+				snippets[id] = snippet
+			}
+		}
+	}
+
+	return snippets, nil
+}
+
+// searchSnippet returns the text around the first occurrence of the term in the content with the term wrapped in
+// searchmark.Start and searchmark.End. It returns an empty string if the content doesn't contain the term.
+func searchSnippet(term, content string) string {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return ""
+	}
+
+	text := database.HTMLToText(content)
+
+	index := strings.Index(strings.ToLower(text), strings.ToLower(term))
+	if index < 0 {
+		return ""
+	}
+
+	start := max(0, index-snippetContext)
+	end := min(len(text), index+len(term)+snippetContext)
+
+	snippet := text[start:index] + searchmark.Start + text[index:index+len(term)] + searchmark.End + text[index+len(term):end]
+
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+
+	if end < len(text) {
+		snippet += "…"
+	}
+
+	return strings.ToValidUTF8(snippet, "")
+}
@@ -0,0 +1,137 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetAllSeries(ctx context.Context) ([]*models.SeriesModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllSeries"); err != nil {
+		return nil, err
+	}
+
+	series := rows(db.series, func(series *models.SeriesModel) bool {
+		return len(db.seriesTutorials(series.ID)) > 0
+	})
+	sortBy(series, func(a, b *models.SeriesModel) bool {
+		return a.Title < b.Title
+	})
+
+	return series, nil
+}
+
+func (db *Database) GetSeriesBySlug(ctx context.Context, slug string) (*models.SeriesModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetSeriesBySlug"); err != nil {
+		return nil, err
+	}
+
+	return find(db.series, func(series *models.SeriesModel) bool {
+		return series.Slug == slug
+	}), nil
+}
+
+func (db *Database) GetSeriesByTutorialID(ctx context.Context, tutorialId string) (*models.SeriesModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetSeriesByTutorialID"); err != nil {
+		return nil, err
+	}
+
+	membership, has := db.tutorialSeries[tutorialId]
+	if !has {
+		return nil, nil
+	}
+
+	return copyOf(db.series[membership.SeriesID]), nil
+}
+
+func (db *Database) GetSeriesTutorials(ctx context.Context, seriesId string) ([]*models.TutorialModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetSeriesTutorials"); err != nil {
+		return nil, err
+	}
+
+	return db.seriesTutorials(seriesId), nil
+}
+
+// seriesTutorials returns the published tutorials of a series in the order that they should be read. The caller needs
+// to hold the lock.
+func (db *Database) seriesTutorials(seriesId string) []*models.TutorialModel {
+	tutorials := rows(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		membership, has := db.tutorialSeries[tutorial.ID]
+		if !has || membership.SeriesID != seriesId {
+			return false
+		}
+
+		return tutorial.Published && db.hasActiveAuthor(tutorial.AuthorID) && !db.isDeleted(tutorial.ID)
+	})
+	sortBy(tutorials, func(a, b *models.TutorialModel) bool {
+		orderA, orderB := db.tutorialSeries[a.ID].Order, db.tutorialSeries[b.ID].Order
+		if orderA != orderB {
+			return orderA < orderB
+		}
+
+		return a.Title < b.Title
+	})
+
+	return tutorials
+}
+
+// setTutorialSeries adds a tutorial to the series with the given slug, creating the series if it doesn't exist yet. An
+// empty series removes the tutorial from its series. The caller needs to hold the lock.
+func (db *Database) setTutorialSeries(tutorialId, title, slug string, order int) error {
+	if title == "" {
+		delete(db.tutorialSeries, tutorialId)
+		return nil
+	}
+
+	series := find(db.series, func(series *models.SeriesModel) bool {
+		return series.Slug == slug
+	})
+
+	if series == nil {
+		id, err := newID()
+		if err != nil {
+			return err
+		}
+
+		series = &models.SeriesModel{
+			ID:        id,
+			Slug:      slug,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	series.Title = title
+	db.series[series.ID] = series
+
+	db.tutorialSeries[tutorialId] = &seriesMembership{
+		SeriesID: series.ID,
+		Order:    order,
+	}
+
+	return nil
+}
+
+// deleteEmptySeries removes every series that no longer has any tutorials. The caller needs to hold the lock.
+func (db *Database) deleteEmptySeries() {
+	used := make(map[string]bool)
+	for _, membership := range db.tutorialSeries {
+		used[membership.SeriesID] = true
+	}
+
+	deleteWhere(db.series, func(series *models.SeriesModel) bool {
+		return !used[series.ID]
+	})
+}
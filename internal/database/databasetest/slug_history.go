@@ -0,0 +1,55 @@
+package databasetest
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func (db *Database) GetCurrentSlug(ctx context.Context, kind database.SlugKind, oldSlug string) (string, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetCurrentSlug"); err != nil {
+		return "", err
+	}
+
+	history, has := db.slugHistory[slugHistoryKey(kind, oldSlug)]
+	if !has {
+		return "", nil
+	}
+
+	switch kind {
+	case database.TutorialSlug:
+		if tutorial, has := db.tutorials[history.ContentID]; has && !db.isDeleted(tutorial.ID) {
+			return tutorial.Slug, nil
+		}
+	case database.CourseSlug:
+		if course, has := db.courses[history.ContentID]; has && !db.isDeleted(course.ID) {
+			return course.Slug, nil
+		}
+	case database.ChapterSlug:
+		if chapter, has := db.chapters[history.ContentID]; has {
+			return chapter.Slug, nil
+		}
+	}
+
+	return "", nil
+}
+
+// addSlugHistory records the old slug of a piece of content when it changes. The caller needs to hold the lock.
+func (db *Database) addSlugHistory(kind database.SlugKind, contentId, oldSlug, newSlug string) {
+	if oldSlug == newSlug {
+		return
+	}
+
+	db.slugHistory[slugHistoryKey(kind, oldSlug)] = &slugHistory{
+		Kind:      kind,
+		ContentID: contentId,
+		Slug:      oldSlug,
+	}
+}
+
+func slugHistoryKey(kind database.SlugKind, slug string) string {
+	return kind.String() + "/" + slug
+}
@@ -0,0 +1,96 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AddToken(ctx context.Context, token, tokenType, userId string, validUntil time.Time) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddToken"); err != nil {
+		return err
+	}
+
+	return db.insertToken(token, tokenType, userId, validUntil)
+}
+
+func (db *Database) GetToken(ctx context.Context, token, tokenType string) (*models.TokenModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetToken"); err != nil {
+		return nil, err
+	}
+
+	return find(db.tokens, func(t *models.TokenModel) bool {
+		return t.Token == token && t.TokenType == tokenType
+	}), nil
+}
+
+func (db *Database) DeleteToken(ctx context.Context, token, tokenType string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteToken"); err != nil {
+		return err
+	}
+
+	for id, t := range db.tokens {
+		if t.Token == token && t.TokenType == tokenType {
+			delete(db.tokens, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) DeleteAllTokens(ctx context.Context, email, tokenType string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteAllTokens"); err != nil {
+		return err
+	}
+
+	for id, t := range db.tokens {
+		user, has := db.users[t.UserID]
+		if has && user.Email == email && t.TokenType == tokenType {
+			delete(db.tokens, id)
+		}
+	}
+
+	return nil
+}
+
+// SeedTokens adds the given tokens to the database as is. Use TokenBuilder to create them.
+func (db *Database) SeedTokens(tokens ...*models.TokenModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, token := range tokens {
+		db.tokens[token.ID] = copyOf(token)
+	}
+}
+
+// insertToken adds a new row to the tokens table. The caller needs to hold the lock.
+func (db *Database) insertToken(token, tokenType, userId string, validUntil time.Time) error {
+	id, err := newID()
+	if err != nil {
+		return err
+	}
+
+	db.tokens[id] = &models.TokenModel{
+		ID:         id,
+		Token:      token,
+		TokenType:  tokenType,
+		ValidUntil: validUntil,
+		UserID:     userId,
+		CreatedAt:  time.Now(),
+	}
+
+	return nil
+}
@@ -0,0 +1,318 @@
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) AdminGetTutorials(ctx context.Context, term string, published *bool, authorId *string, likedByUser string, bookmarkedByUser string, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("AdminGetTutorials"); err != nil {
+		return nil, nil, err
+	}
+
+	tutorials := rows(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		if db.isDeleted(tutorial.ID) != deleted {
+			return false
+		}
+
+		if term != "" && tutorial.ID != term && !db.matchesTutorial(term, tutorial) {
+			return false
+		}
+
+		if published != nil && tutorial.Published != *published {
+			return false
+		}
+
+		if !matchesAuthor(tutorial.AuthorID, authorId) {
+			return false
+		}
+
+		if likedByUser != "" && !db.hasRelation(db.tutorialLikes, likedByUser, tutorial.ID) {
+			return false
+		}
+
+		if bookmarkedByUser != "" && !db.hasRelation(db.tutorialBookmarks, bookmarkedByUser, tutorial.ID) {
+			return false
+		}
+
+		return keyword == "" || hasKeyword(db.tutorialKeywords[tutorial.ID], keyword)
+	})
+	tutorials, next := paginate(tutorials, cursor, int(elements), func(row *models.TutorialModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return tutorials, next, nil
+}
+
+func (db *Database) GetAllTutorials(ctx context.Context, authorId string, published *bool) ([]*models.TutorialModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllTutorials"); err != nil {
+		return nil, err
+	}
+
+	tutorials := rows(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		if authorId != "" && nullString(tutorial.AuthorID) != authorId {
+			return false
+		}
+
+		if published != nil {
+			if db.isDeleted(tutorial.ID) {
+				return false
+			}
+
+			if tutorial.Published != *published || (*published && !db.hasActiveAuthor(tutorial.AuthorID)) {
+				return false
+			}
+		}
+
+		return true
+	})
+	sortTutorialsByUpdatedAt(tutorials)
+
+	return tutorials, nil
+}
+
+func (db *Database) GetTutorials(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.TutorialModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorials"); err != nil {
+		return nil, nil, err
+	}
+
+	tutorials := rows(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		if !tutorial.Published || !db.hasActiveAuthor(tutorial.AuthorID) || db.isDeleted(tutorial.ID) {
+			return false
+		}
+
+		if authorId != "" && tutorial.AuthorID.String != authorId {
+			return false
+		}
+
+		return db.matchesTutorial(term, tutorial)
+	})
+
+	// Search results don't get ranked so they are ordered the same way as the rest of the tutorials.
+	tutorials, next := paginate(tutorials, cursor, elements, func(row *models.TutorialModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return tutorials, next, nil
+}
+
+func (db *Database) GetTutorialByID(ctx context.Context, id string) (*models.TutorialModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorialByID"); err != nil {
+		return nil, err
+	}
+
+	return copyOf(db.tutorials[id]), nil
+}
+
+func (db *Database) GetTutorialBySlug(ctx context.Context, slug string) (*models.TutorialModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorialBySlug"); err != nil {
+		return nil, err
+	}
+
+	return find(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		return tutorial.Slug == slug && !db.isDeleted(tutorial.ID)
+	}), nil
+}
+
+func (db *Database) CountTutorials(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountTutorials"); err != nil {
+		return 0, err
+	}
+
+	return count(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		return !db.isDeleted(tutorial.ID)
+	}), nil
+}
+
+func (db *Database) CountTutorialsWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountTutorialsWrittenBy"); err != nil {
+		return 0, err
+	}
+
+	return count(db.tutorials, func(tutorial *models.TutorialModel) bool {
+		return nullString(tutorial.AuthorID) == authorId && !db.isDeleted(tutorial.ID)
+	}), nil
+}
+
+func (db *Database) PublishTutorial(ctx context.Context, tutorialId string) error {
+	return db.updateTutorial("PublishTutorial", tutorialId, func(tutorial *models.TutorialModel) {
+		tutorial.Published = true
+	})
+}
+
+func (db *Database) UnpublishTutorial(ctx context.Context, tutorialId string) error {
+	return db.updateTutorial("UnpublishTutorial", tutorialId, func(tutorial *models.TutorialModel) {
+		tutorial.Published = false
+	})
+}
+
+func (db *Database) UpdateTutorialAuthor(ctx context.Context, tutorialId, authorId string) error {
+	return db.updateTutorial("UpdateTutorialAuthor", tutorialId, func(tutorial *models.TutorialModel) {
+		tutorial.AuthorID = sql.NullString{String: authorId, Valid: true}
+	})
+}
+
+func (db *Database) DeleteTutorial(ctx context.Context, tutorialId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteTutorial"); err != nil {
+		return err
+	}
+
+	_, has := db.tutorials[tutorialId]
+
+	return db.softDelete(has, tutorialId)
+}
+
+func (db *Database) RestoreTutorial(ctx context.Context, tutorialId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RestoreTutorial"); err != nil {
+		return err
+	}
+
+	return db.undelete(tutorialId)
+}
+
+func (db *Database) PurgeTutorial(ctx context.Context, tutorialId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("PurgeTutorial"); err != nil {
+		return err
+	}
+
+	if _, has := db.tutorials[tutorialId]; !has {
+		return database.ErrNoRowsAffected
+	}
+
+	db.purgeTutorial(tutorialId)
+	delete(db.deletedAt, tutorialId)
+
+	return nil
+}
+
+func (db *Database) GetAllKeywordsForTutorial(ctx context.Context, tutorialId string) ([]string, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllKeywordsForTutorial"); err != nil {
+		return nil, err
+	}
+
+	return append([]string(nil), db.tutorialKeywords[tutorialId]...), nil
+}
+
+// SeedTutorials adds the given tutorials to the database as is.
+func (db *Database) SeedTutorials(tutorials ...*models.TutorialModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, tutorial := range tutorials {
+		db.tutorials[tutorial.ID] = copyOf(tutorial)
+	}
+}
+
+// SeedTutorialKeywords sets the keywords of the tutorial with the given ID.
+func (db *Database) SeedTutorialKeywords(tutorialId string, keywords ...string) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.tutorialKeywords[tutorialId] = append([]string(nil), keywords...)
+}
+
+// matchesTutorial stands in for the full-text search done by the real implementations.
+func (db *Database) matchesTutorial(term string, tutorial *models.TutorialModel) bool {
+	return contains(term, tutorial.Title, tutorial.Slug, tutorial.Description, tutorial.Content, strings.Join(db.tutorialKeywords[tutorial.ID], " "))
+}
+
+func (db *Database) tutorialBySlug(slug string) *models.TutorialModel {
+	for _, tutorial := range db.tutorials {
+		if tutorial.Slug == slug {
+			return tutorial
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) updateTutorial(method, tutorialId string, update func(tutorial *models.TutorialModel)) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail(method); err != nil {
+		return err
+	}
+
+	if tutorial, has := db.tutorials[tutorialId]; has {
+		update(tutorial)
+		tutorial.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+func sortTutorialsByUpdatedAt(tutorials []*models.TutorialModel) {
+	sortBy(tutorials, func(a, b *models.TutorialModel) bool {
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+
+		return a.Title < b.Title
+	})
+}
+
+// matchesAuthor mirrors how the admin queries filter on the author: a nil authorId only matches content without an
+// author while an empty authorId matches everything.
+func matchesAuthor(contentAuthorId sql.NullString, authorId *string) bool {
+	if authorId == nil {
+		return !contentAuthorId.Valid
+	}
+
+	return *authorId == "" || nullString(contentAuthorId) == *authorId
+}
+
+// hasActiveAuthor reports whether the content has an author whose account hasn't been deleted. The caller needs to
+// hold the lock.
+func (db *Database) hasActiveAuthor(authorId sql.NullString) bool {
+	_, has := db.users[authorId.String]
+	return authorId.Valid && has && !db.isDeleted(authorId.String)
+}
+
+func hasKeyword(keywords []string, keyword string) bool {
+	for _, k := range keywords {
+		if strings.Contains(k, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+package databasetest
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetTutorialsBookmarkedByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorialsBookmarkedByUser"); err != nil {
+		return nil, nil, err
+	}
+
+	tutorials, next := db.tutorialsWithRelation(db.tutorialBookmarks, term, userId, cursor, int(elements))
+
+	return tutorials, next, nil
+}
+
+func (db *Database) UserBookmarkedTutorial(ctx context.Context, userId, slug string) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("UserBookmarkedTutorial"); err != nil {
+		return false, err
+	}
+
+	tutorial := db.tutorialBySlug(slug)
+
+	return tutorial != nil && db.hasRelation(db.tutorialBookmarks, userId, tutorial.ID), nil
+}
+
+func (db *Database) UserBookmarkTutorial(ctx context.Context, userId, slug string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("UserBookmarkTutorial"); err != nil {
+		return err
+	}
+
+	return db.addRelation(db.tutorialBookmarks, userId, slug)
+}
+
+func (db *Database) UserUnbookmarkTutorial(ctx context.Context, userId, slug string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("UserUnbookmarkTutorial"); err != nil {
+		return err
+	}
+
+	return db.removeRelation(db.tutorialBookmarks, userId, slug)
+}
+
+func (db *Database) CountTutorialsBookmarkedByUser(ctx context.Context, userId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountTutorialsBookmarkedByUser"); err != nil {
+		return 0, err
+	}
+
+	return count(db.tutorialBookmarks, func(r *relation) bool {
+		return r.UserID == userId
+	}), nil
+}
+
+func (db *Database) CountTutorialBookmarks(ctx context.Context, tutorialId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountTutorialBookmarks"); err != nil {
+		return 0, err
+	}
+
+	return count(db.tutorialBookmarks, func(r *relation) bool {
+		return r.TutorialID == tutorialId
+	}), nil
+}
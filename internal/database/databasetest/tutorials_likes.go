@@ -0,0 +1,82 @@
+package databasetest
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetTutorialsLikedByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetTutorialsLikedByUser"); err != nil {
+		return nil, nil, err
+	}
+
+	tutorials, next := db.tutorialsWithRelation(db.tutorialLikes, term, userId, cursor, int(elements))
+
+	return tutorials, next, nil
+}
+
+func (db *Database) UserLikedTutorial(ctx context.Context, userId, slug string) (bool, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("UserLikedTutorial"); err != nil {
+		return false, err
+	}
+
+	tutorial := db.tutorialBySlug(slug)
+
+	return tutorial != nil && db.hasRelation(db.tutorialLikes, userId, tutorial.ID), nil
+}
+
+func (db *Database) UserLikeTutorial(ctx context.Context, userId, slug string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("UserLikeTutorial"); err != nil {
+		return err
+	}
+
+	return db.addRelation(db.tutorialLikes, userId, slug)
+}
+
+func (db *Database) UserDislikeTutorial(ctx context.Context, userId, slug string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("UserDislikeTutorial"); err != nil {
+		return err
+	}
+
+	return db.removeRelation(db.tutorialLikes, userId, slug)
+}
+
+func (db *Database) CountTutorialsLikedByUser(ctx context.Context, userId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountTutorialsLikedByUser"); err != nil {
+		return 0, err
+	}
+
+	return count(db.tutorialLikes, func(r *relation) bool {
+		return r.UserID == userId
+	}), nil
+}
+
+func (db *Database) CountTutorialLikes(ctx context.Context, tutorialId string) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountTutorialLikes"); err != nil {
+		return 0, err
+	}
+
+	return count(db.tutorialLikes, func(r *relation) bool {
+		return r.TutorialID == tutorialId
+	}), nil
+}
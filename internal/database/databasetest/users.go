@@ -0,0 +1,396 @@
+package databasetest
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *Database) GetUsers(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUsers"); err != nil {
+		return nil, err
+	}
+
+	return db.filterUsers(term, level, likedTutorialID, bookmarkedTutorialID), nil
+}
+
+func (db *Database) GetUsersPaginated(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, cursor *database.Cursor, elements uint) ([]*models.UserModel, *database.Cursor, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUsersPaginated"); err != nil {
+		return nil, nil, err
+	}
+
+	users := db.filterUsers(term, level, likedTutorialID, bookmarkedTutorialID)
+	users, next := paginate(users, cursor, int(elements), func(row *models.UserModel) *database.Cursor {
+		return database.NewCursor(row.CreatedAt, row.ID)
+	})
+
+	return users, next, nil
+}
+
+func (db *Database) GetAllUsers(ctx context.Context) ([]*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetAllUsers"); err != nil {
+		return nil, err
+	}
+
+	return rows(db.users, func(user *models.UserModel) bool {
+		return !db.isDeleted(user.ID)
+	}), nil
+}
+
+func (db *Database) AddNewUser(ctx context.Context, name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("AddNewUser"); err != nil {
+		return nil, err
+	}
+
+	user, err := db.insertUser(name, surname, email, password, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.insertToken(token, tokenType, user.ID, validUntil); err != nil {
+		return nil, err
+	}
+
+	if err := db.insertIPAddress(user.ID, ipAddr); err != nil {
+		return nil, err
+	}
+
+	return copyOf(user), nil
+}
+
+func (db *Database) NewUser(ctx context.Context, name, surname, email, password string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("NewUser"); err != nil {
+		return err
+	}
+
+	_, err := db.insertUser(name, surname, email, password, false)
+	return err
+}
+
+func (db *Database) NewAdminUser(ctx context.Context, name, surname, email, password string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("NewAdminUser"); err != nil {
+		return err
+	}
+
+	_, err := db.insertUser(name, surname, email, password, true)
+	return err
+}
+
+func (db *Database) GetUserByEmail(ctx context.Context, email string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserByEmail"); err != nil {
+		return nil, err
+	}
+
+	return find(db.users, func(user *models.UserModel) bool {
+		return user.Email == email && db.matchesLevel(user, level)
+	}), nil
+}
+
+func (db *Database) GetUserByID(ctx context.Context, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserByID"); err != nil {
+		return nil, err
+	}
+
+	return db.userByID(id, level), nil
+}
+
+func (db *Database) GetUserByToken(ctx context.Context, token, tokenType string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserByToken"); err != nil {
+		return nil, err
+	}
+
+	tokenModel := find(db.tokens, func(t *models.TokenModel) bool {
+		return t.Token == token && t.TokenType == tokenType && t.ValidUntil.After(time.Now())
+	})
+	if tokenModel == nil {
+		return nil, nil
+	}
+
+	return db.userByID(tokenModel.UserID, level), nil
+}
+
+func (db *Database) GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserByAffiliateCode"); err != nil {
+		return nil, err
+	}
+
+	return find(db.users, func(user *models.UserModel) bool {
+		return user.AffiliateCode == affiliateCode && db.matchesLevel(user, level)
+	}), nil
+}
+
+func (db *Database) GetUserBySlug(ctx context.Context, userSlug string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("GetUserBySlug"); err != nil {
+		return nil, err
+	}
+
+	return find(db.users, func(user *models.UserModel) bool {
+		return user.Slug == userSlug && db.matchesLevel(user, level)
+	}), nil
+}
+
+func (db *Database) UpdateUserName(ctx context.Context, userId, name, surname string) error {
+	return db.updateUser("UpdateUserName", true, userId, func(user *models.UserModel) {
+		user.Name = name
+		user.Surname = surname
+		user.Slug = database.NameSurnameToSlug(name, surname)
+	})
+}
+
+func (db *Database) UpdateUserEmail(ctx context.Context, userId, email string) error {
+	db.mu.RLock()
+	failure := db.fail("UpdateUserEmail")
+	taken := find(db.users, func(user *models.UserModel) bool {
+		return user.Email == email && user.ID != userId && !db.isDeleted(user.ID)
+	})
+	db.mu.RUnlock()
+
+	if failure != nil {
+		return failure
+	}
+
+	if taken != nil {
+		return database.ErrUserAlreadyExists
+	}
+
+	return db.updateUser("UpdateUserEmail", true, userId, func(user *models.UserModel) {
+		user.Email = email
+	})
+}
+
+func (db *Database) UpdateUserPassword(ctx context.Context, userId, password string) error {
+	return db.updateUser("UpdateUserPassword", true, userId, func(user *models.UserModel) {
+		user.Password = password
+	})
+}
+
+func (db *Database) CountUsers(ctx context.Context) (uint, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if err := db.fail("CountUsers"); err != nil {
+		return 0, err
+	}
+
+	return count(db.users, func(user *models.UserModel) bool {
+		return !db.isDeleted(user.ID)
+	}), nil
+}
+
+func (db *Database) AddAuthorStatus(ctx context.Context, userId string) error {
+	return db.updateUser("AddAuthorStatus", false, userId, func(user *models.UserModel) {
+		user.IsAuthor = true
+	})
+}
+
+func (db *Database) RemoveAuthorStatus(ctx context.Context, userId string) error {
+	return db.updateUser("RemoveAuthorStatus", false, userId, func(user *models.UserModel) {
+		user.IsAuthor = false
+	})
+}
+
+func (db *Database) AddAdminStatus(ctx context.Context, userId string) error {
+	return db.updateUser("AddAdminStatus", false, userId, func(user *models.UserModel) {
+		user.IsAdmin = true
+	})
+}
+
+func (db *Database) RemoveAdminStatus(ctx context.Context, userId string) error {
+	return db.updateUser("RemoveAdminStatus", false, userId, func(user *models.UserModel) {
+		user.IsAdmin = false
+	})
+}
+
+func (db *Database) DeleteUser(ctx context.Context, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("DeleteUser"); err != nil {
+		return err
+	}
+
+	_, has := db.users[userId]
+	if err := db.softDelete(has, userId); err != nil {
+		return err
+	}
+
+	// The user's comments get deleted along with them so that they disappear from the site too.
+	for id, comment := range db.comments {
+		if comment.UserID == userId && !db.isDeleted(id) {
+			db.deletedAt[id] = db.deletedAt[userId]
+		}
+	}
+
+	for id, token := range db.tokens {
+		if token.UserID == userId {
+			delete(db.tokens, id)
+		}
+	}
+
+	return nil
+}
+
+func (db *Database) RestoreUser(ctx context.Context, userId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail("RestoreUser"); err != nil {
+		return err
+	}
+
+	deletedAt, has := db.deletedAt[userId]
+	if _, anonymized := db.anonymizedAt[userId]; !has || anonymized {
+		return database.ErrNoRowsAffected
+	}
+
+	user := db.users[userId]
+	for id, other := range db.users {
+		if id != userId && other.Email == user.Email && !db.isDeleted(id) {
+			return database.ErrUserAlreadyExists
+		}
+	}
+
+	// Only bring back the comments that were deleted along with the user.
+	for id, comment := range db.comments {
+		if comment.UserID == userId && db.isDeleted(id) && db.deletedAt[id].Equal(deletedAt) {
+			delete(db.deletedAt, id)
+		}
+	}
+
+	return db.undelete(userId)
+}
+
+// SeedUsers adds the given users to the database as is. Use UserBuilder to create them.
+func (db *Database) SeedUsers(users ...*models.UserModel) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, user := range users {
+		db.users[user.ID] = copyOf(user)
+	}
+}
+
+func (db *Database) filterUsers(term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) []*models.UserModel {
+	return rows(db.users, func(user *models.UserModel) bool {
+		if !contains(term, user.ID, user.Name, user.Surname, user.Email, user.AffiliateCode) || !db.matchesLevel(user, level) {
+			return false
+		}
+
+		if likedTutorialID != "" && !db.hasRelation(db.tutorialLikes, user.ID, likedTutorialID) {
+			return false
+		}
+
+		if bookmarkedTutorialID != "" && !db.hasRelation(db.tutorialBookmarks, user.ID, bookmarkedTutorialID) {
+			return false
+		}
+
+		return true
+	})
+}
+
+func (db *Database) userByID(id string, level database.AuthorizationLevel) *models.UserModel {
+	user, has := db.users[id]
+	if !has || !db.matchesLevel(user, level) {
+		return nil
+	}
+
+	return copyOf(user)
+}
+
+func (db *Database) insertUser(name, surname, email, password string, isAdmin bool) (*models.UserModel, error) {
+	// Like the partial unique index on users.email, deleted accounts don't hold on to their email address.
+	for _, user := range db.users {
+		if user.Email == email && !db.isDeleted(user.ID) {
+			return nil, database.ErrUserAlreadyExists
+		}
+	}
+
+	id, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	affiliateCode, err := newID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	user := &models.UserModel{
+		ID:            id,
+		Name:          name,
+		Surname:       surname,
+		Slug:          database.NameSurnameToSlug(name, surname),
+		Email:         email,
+		Password:      password,
+		AffiliateCode: affiliateCode,
+		IsAdmin:       isAdmin,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	db.users[id] = user
+
+	return user, nil
+}
+
+// updateUser applies the update to the user's row. When mustExist is true ErrNoRowsAffected gets returned if there is
+// no user with that ID, matching the real implementations which only check the rows affected for some updates.
+func (db *Database) updateUser(method string, mustExist bool, userId string, update func(user *models.UserModel)) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if err := db.fail(method); err != nil {
+		return err
+	}
+
+	user, has := db.users[userId]
+	if !has {
+		if mustExist {
+			return database.ErrNoRowsAffected
+		}
+
+		return nil
+	}
+
+	update(user)
+	user.UpdatedAt = time.Now()
+
+	return nil
+}
@@ -26,4 +26,16 @@ var (
 
 	// ErrInsufficientAffiliatePoints indicates that there isn't enough affiliate points in the user's profile.
 	ErrInsufficientAffiliatePoints = errors.New("user does not have enough affiliate points")
+
+	// ErrInvalidCursor indicates that a pagination cursor couldn't be decoded.
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+	// ErrCourseHasPurchases indicates that a course can't be permanently deleted because purchases still refer to it.
+	ErrCourseHasPurchases = errors.New("course has purchases")
+
+	// ErrQuizNotPassed indicates that a chapter can't be finished because the user hasn't passed its quiz yet.
+	ErrQuizNotPassed = errors.New("quiz has not been passed")
+
+	// ErrChapterReleaseAlreadyNotified indicates that the user has already been told that the chapter was released.
+	ErrChapterReleaseAlreadyNotified = errors.New("chapter release has already been notified")
 )
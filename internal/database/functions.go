@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/base64"
 	"fmt"
+	"html"
 	mathRand "math/rand"
 	"regexp"
 	"strings"
@@ -49,6 +50,11 @@ func BytesToURLString(src []byte) string {
 	return base64.RawURLEncoding.EncodeToString(src)
 }
 
+// URLStringToBytes converts a string created with BytesToURLString back into a slice of bytes.
+func URLStringToBytes(src string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(src)
+}
+
 // NewNullString creates a new sql.NullString based off a given string. If the string is empty the sql.NullString
 // generated won't be valid.
 func NewNullString(s string) sql.NullString {
@@ -80,3 +86,16 @@ func NameSurnameToSlug(name, surname string) string {
 
 	return safeSlug
 }
+
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+var whitespaceRegex = regexp.MustCompile(`\s+`)
+
+// HTMLToText strips all the tags from rendered HTML so that only the readable text is left. This is used to keep
+// markup out of the search index.
+func HTMLToText(s string) string {
+	text := htmlTagRegex.ReplaceAllString(s, " ")
+	text = html.UnescapeString(text)
+	text = whitespaceRegex.ReplaceAllString(text, " ")
+
+	return strings.TrimSpace(text)
+}
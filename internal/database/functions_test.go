@@ -18,6 +18,10 @@ func TestBytesToURLString(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestURLStringToBytes(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestNewNullString(t *testing.T) {
 	// TODO: Implement.
 }
@@ -25,3 +29,7 @@ func TestNewNullString(t *testing.T) {
 func TestNameSurnameToSlug(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestHTMLToText(t *testing.T) {
+	// TODO: Implement.
+}
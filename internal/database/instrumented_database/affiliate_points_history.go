@@ -0,0 +1,33 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error {
+	start := time.Now()
+	err := db.database.RegisterAffiliatePointsChange(ctx, userId, courseId, pointsChange, reason)
+	db.observe("RegisterAffiliatePointsChange", start, err, userId, courseId, pointsChange, reason)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) CountUserAffiliateHistory(ctx context.Context, userId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountUserAffiliateHistory(ctx, userId)
+	db.observe("CountUserAffiliateHistory", start, err, userId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) GetUserAffiliatePointsHistory(ctx context.Context, userId string, cursor *database.Cursor, elements uint) ([]*models.AffiliatePointsHistoryModel, *database.Cursor, error) {
+	start := time.Now()
+	affiliatePointsHistories, next, err := db.database.GetUserAffiliatePointsHistory(ctx, userId, cursor, elements)
+	db.observe("GetUserAffiliatePointsHistory", start, err, userId, cursor, elements)
+
+	return affiliatePointsHistories, next, err
+}
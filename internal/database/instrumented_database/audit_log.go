@@ -0,0 +1,33 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AddAuditLog(ctx context.Context, actorId string, action database.AuditAction, targetId, before, after, ipAddr string) error {
+	start := time.Now()
+	err := db.database.AddAuditLog(ctx, actorId, action, targetId, before, after, ipAddr)
+	db.observe("AddAuditLog", start, err, actorId, action, targetId, before, after, ipAddr)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) AdminGetAuditLogs(ctx context.Context, term, action, actorId string, cursor *database.Cursor, elements uint) ([]*models.AuditLogModel, *database.Cursor, error) {
+	start := time.Now()
+	auditLogs, next, err := db.database.AdminGetAuditLogs(ctx, term, action, actorId, cursor, elements)
+	db.observe("AdminGetAuditLogs", start, err, term, action, actorId, cursor, elements)
+
+	return auditLogs, next, err
+}
+
+func (db *InstrumentedDatabase) CountAuditLogs(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountAuditLogs(ctx)
+	db.observe("CountAuditLogs", start, err)
+
+	return count, err
+}
@@ -0,0 +1,48 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AddCertificate(ctx context.Context, userId, courseId string) error {
+	start := time.Now()
+	err := db.database.AddCertificate(ctx, userId, courseId)
+	db.observe("AddCertificate", start, err, userId, courseId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetCertificateFromID(ctx context.Context, certificateId string) (*models.CertificateModel, error) {
+	start := time.Now()
+	certificate, err := db.database.GetCertificateFromID(ctx, certificateId)
+	db.observe("GetCertificateFromID", start, err, certificateId)
+
+	return certificate, err
+}
+
+func (db *InstrumentedDatabase) GetCertificateFromUserAndCourse(ctx context.Context, userId, courseId string) (*models.CertificateModel, error) {
+	start := time.Now()
+	certificate, err := db.database.GetCertificateFromUserAndCourse(ctx, userId, courseId)
+	db.observe("GetCertificateFromUserAndCourse", start, err, userId, courseId)
+
+	return certificate, err
+}
+
+func (db *InstrumentedDatabase) GetUserFromCertificate(ctx context.Context, certificateId string) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.GetUserFromCertificate(ctx, certificateId)
+	db.observe("GetUserFromCertificate", start, err, certificateId)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) GetCourseFromCertificate(ctx context.Context, certificateId string) (*models.CourseModel, error) {
+	start := time.Now()
+	course, err := db.database.GetCourseFromCertificate(ctx, certificateId)
+	db.observe("GetCourseFromCertificate", start, err, certificateId)
+
+	return course, err
+}
@@ -0,0 +1,32 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetDueChapterReleases(ctx context.Context) ([]*models.ChapterReleaseModel, error) {
+	start := time.Now()
+	releases, err := db.database.GetDueChapterReleases(ctx)
+	db.observe("GetDueChapterReleases", start, err)
+
+	return releases, err
+}
+
+func (db *InstrumentedDatabase) AddChapterReleaseNotification(ctx context.Context, userId, chapterId string) error {
+	start := time.Now()
+	err := db.database.AddChapterReleaseNotification(ctx, userId, chapterId)
+	db.observe("AddChapterReleaseNotification", start, err, userId, chapterId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetUserChapterReleaseNotifications(ctx context.Context, userId string) ([]*models.ChapterReleaseNotificationModel, error) {
+	start := time.Now()
+	notifications, err := db.database.GetUserChapterReleaseNotifications(ctx, userId)
+	db.observe("GetUserChapterReleaseNotifications", start, err, userId)
+
+	return notifications, err
+}
@@ -0,0 +1,81 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AdminGetComments(ctx context.Context, term, tutorialId, userId string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CommentModel, *database.Cursor, error) {
+	start := time.Now()
+	comments, next, err := db.database.AdminGetComments(ctx, term, tutorialId, userId, deleted, cursor, elements)
+	db.observe("AdminGetComments", start, err, term, tutorialId, userId, deleted, cursor, elements)
+
+	return comments, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllCommentsPaginated(ctx context.Context, tutorialId string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	start := time.Now()
+	comments, next, err := db.database.GetAllCommentsPaginated(ctx, tutorialId, cursor, elements)
+	db.observe("GetAllCommentsPaginated", start, err, tutorialId, cursor, elements)
+
+	return comments, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllCommentsBySlugPaginated(ctx context.Context, slug string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	start := time.Now()
+	comments, next, err := db.database.GetAllCommentsBySlugPaginated(ctx, slug, cursor, elements)
+	db.observe("GetAllCommentsBySlugPaginated", start, err, slug, cursor, elements)
+
+	return comments, next, err
+}
+
+func (db *InstrumentedDatabase) CountCommentsForTutorial(ctx context.Context, tutorialId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountCommentsForTutorial(ctx, tutorialId)
+	db.observe("CountCommentsForTutorial", start, err, tutorialId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) AddCommentBySlug(ctx context.Context, content, userId, slug string) (*models.CommentModel, error) {
+	start := time.Now()
+	comment, err := db.database.AddCommentBySlug(ctx, content, userId, slug)
+	db.observe("AddCommentBySlug", start, err, content, userId, slug)
+
+	return comment, err
+}
+
+func (db *InstrumentedDatabase) CountComments(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountComments(ctx)
+	db.observe("CountComments", start, err)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) DeleteComment(ctx context.Context, commentId string) error {
+	start := time.Now()
+	err := db.database.DeleteComment(ctx, commentId)
+	db.observe("DeleteComment", start, err, commentId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetCommentByID(ctx context.Context, commentId string) (*models.CommentModel, error) {
+	start := time.Now()
+	comment, err := db.database.GetCommentByID(ctx, commentId)
+	db.observe("GetCommentByID", start, err, commentId)
+
+	return comment, err
+}
+
+func (db *InstrumentedDatabase) RestoreComment(ctx context.Context, commentId string) error {
+	start := time.Now()
+	err := db.database.RestoreComment(ctx, commentId)
+	db.observe("RestoreComment", start, err, commentId)
+
+	return err
+}
@@ -0,0 +1,138 @@
+package instrumented_database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AdminGetCoursePurchases(ctx context.Context, term string, courseId string, authorId string, status string, cursor *database.Cursor, elements uint) ([]*models.CoursePurchaseModel, *database.Cursor, error) {
+	start := time.Now()
+	coursePurchases, next, err := db.database.AdminGetCoursePurchases(ctx, term, courseId, authorId, status, cursor, elements)
+	db.observe("AdminGetCoursePurchases", start, err, term, courseId, authorId, status, cursor, elements)
+
+	return coursePurchases, next, err
+}
+
+func (db *InstrumentedDatabase) HasUserPurchasedCourse(ctx context.Context, userId, courseId string) (bool, error) {
+	start := time.Now()
+	purchased, err := db.database.HasUserPurchasedCourse(ctx, userId, courseId)
+	db.observe("HasUserPurchasedCourse", start, err, userId, courseId)
+
+	return purchased, err
+}
+
+func (db *InstrumentedDatabase) RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+	start := time.Now()
+	err := db.database.RegisterCoursePurchase(ctx, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil)
+	db.observe("RegisterCoursePurchase", start, err, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) CountAllPurchases(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountAllPurchases(ctx)
+	db.observe("CountAllPurchases", start, err)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) CountCoursesWhereDiscountWasUsed(ctx context.Context, discountCode string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountCoursesWhereDiscountWasUsed(ctx, discountCode)
+	db.observe("CountCoursesWhereDiscountWasUsed", start, err, discountCode)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) CountUsersWhoBoughtCourse(ctx context.Context, courseId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountUsersWhoBoughtCourse(ctx, courseId)
+	db.observe("CountUsersWhoBoughtCourse", start, err, courseId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchase, err := db.database.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+	db.observe("GetCoursePurchaseByPaymentKey", start, err, paymentKey)
+
+	return coursePurchase, err
+}
+
+func (db *InstrumentedDatabase) GetCoursePurchaseByID(ctx context.Context, coursePurchaseId string) (*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchase, err := db.database.GetCoursePurchaseByID(ctx, coursePurchaseId)
+	db.observe("GetCoursePurchaseByID", start, err, coursePurchaseId)
+
+	return coursePurchase, err
+}
+
+func (db *InstrumentedDatabase) GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchase, err := db.database.GetCoursePurchaseByCheckoutSession(ctx, checkoutSessionId)
+	db.observe("GetCoursePurchaseByCheckoutSession", start, err, checkoutSessionId)
+
+	return coursePurchase, err
+}
+
+func (db *InstrumentedDatabase) GetCourseByCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.CourseModel, error) {
+	start := time.Now()
+	course, err := db.database.GetCourseByCoursePurchaseID(ctx, coursePurchaseId)
+	db.observe("GetCourseByCoursePurchaseID", start, err, coursePurchaseId)
+
+	return course, err
+}
+
+func (db *InstrumentedDatabase) UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status database.PaymentStatus) error {
+	start := time.Now()
+	err := db.database.UpdateCoursePurchasePaymentStatus(ctx, coursePurchaseId, status)
+	db.observe("UpdateCoursePurchasePaymentStatus", start, err, coursePurchaseId, status)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	start := time.Now()
+	changed, err := db.database.TransitionCoursePurchasePaymentStatus(ctx, coursePurchaseId, from, to)
+	db.observe("TransitionCoursePurchasePaymentStatus", start, err, coursePurchaseId, from, to)
+
+	return changed, err
+}
+
+func (db *InstrumentedDatabase) GetCoursesBoughtByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	start := time.Now()
+	courses, next, err := db.database.GetCoursesBoughtByUser(ctx, term, userId, cursor, elements)
+	db.observe("GetCoursesBoughtByUser", start, err, term, userId, cursor, elements)
+
+	return courses, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllCoursesBoughtByUser(ctx context.Context, userId string) ([]*models.CourseModel, error) {
+	start := time.Now()
+	courses, err := db.database.GetAllCoursesBoughtByUser(ctx, userId)
+	db.observe("GetAllCoursesBoughtByUser", start, err, userId)
+
+	return courses, err
+}
+
+func (db *InstrumentedDatabase) GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchases, err := db.database.GetCoursePurchasesByUserAndCourse(ctx, userId, courseId)
+	db.observe("GetCoursePurchasesByUserAndCourse", start, err, userId, courseId)
+
+	return coursePurchases, err
+}
+
+func (db *InstrumentedDatabase) GetCoursePurchaseDate(ctx context.Context, userId, courseId string) (sql.NullTime, error) {
+	start := time.Now()
+	purchasedAt, err := db.database.GetCoursePurchaseDate(ctx, userId, courseId)
+	db.observe("GetCoursePurchaseDate", start, err, userId, courseId)
+
+	return purchasedAt, err
+}
@@ -0,0 +1,121 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AdminGetCourses(ctx context.Context, term string, published *bool, authorId *string, boughtBy, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	start := time.Now()
+	courses, next, err := db.database.AdminGetCourses(ctx, term, published, authorId, boughtBy, keyword, deleted, cursor, elements)
+	db.observe("AdminGetCourses", start, err, term, published, authorId, boughtBy, keyword, deleted, cursor, elements)
+
+	return courses, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllCourses(ctx context.Context, authorId string, published *bool) ([]*models.CourseModel, error) {
+	start := time.Now()
+	courses, err := db.database.GetAllCourses(ctx, authorId, published)
+	db.observe("GetAllCourses", start, err, authorId, published)
+
+	return courses, err
+}
+
+func (db *InstrumentedDatabase) GetCourses(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.CourseModel, *database.Cursor, error) {
+	start := time.Now()
+	courses, next, err := db.database.GetCourses(ctx, term, authorId, cursor, elements)
+	db.observe("GetCourses", start, err, term, authorId, cursor, elements)
+
+	return courses, next, err
+}
+
+func (db *InstrumentedDatabase) GetCourseByFileKey(ctx context.Context, fileKey string) (*models.CourseModel, error) {
+	start := time.Now()
+	course, err := db.database.GetCourseByFileKey(ctx, fileKey)
+	db.observe("GetCourseByFileKey", start, err, fileKey)
+
+	return course, err
+}
+
+func (db *InstrumentedDatabase) GetCourseBySlug(ctx context.Context, slug string) (*models.CourseModel, error) {
+	start := time.Now()
+	course, err := db.database.GetCourseBySlug(ctx, slug)
+	db.observe("GetCourseBySlug", start, err, slug)
+
+	return course, err
+}
+
+func (db *InstrumentedDatabase) GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error) {
+	start := time.Now()
+	course, err := db.database.GetCourseByID(ctx, courseId)
+	db.observe("GetCourseByID", start, err, courseId)
+
+	return course, err
+}
+
+func (db *InstrumentedDatabase) CountCourses(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountCourses(ctx)
+	db.observe("CountCourses", start, err)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) CountCoursesWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountCoursesWrittenBy(ctx, authorId)
+	db.observe("CountCoursesWrittenBy", start, err, authorId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) PublishCourse(ctx context.Context, courseId string) error {
+	start := time.Now()
+	err := db.database.PublishCourse(ctx, courseId)
+	db.observe("PublishCourse", start, err, courseId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UnpublishCourse(ctx context.Context, courseId string) error {
+	start := time.Now()
+	err := db.database.UnpublishCourse(ctx, courseId)
+	db.observe("UnpublishCourse", start, err, courseId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UpdateCourseAuthor(ctx context.Context, tutorialId, authorId string) error {
+	start := time.Now()
+	err := db.database.UpdateCourseAuthor(ctx, tutorialId, authorId)
+	db.observe("UpdateCourseAuthor", start, err, tutorialId, authorId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) DeleteCourse(ctx context.Context, courseId string) error {
+	start := time.Now()
+	err := db.database.DeleteCourse(ctx, courseId)
+	db.observe("DeleteCourse", start, err, courseId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) RestoreCourse(ctx context.Context, courseId string) error {
+	start := time.Now()
+	err := db.database.RestoreCourse(ctx, courseId)
+	db.observe("RestoreCourse", start, err, courseId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) PurgeCourse(ctx context.Context, courseId string) error {
+	start := time.Now()
+	err := db.database.PurgeCourse(ctx, courseId)
+	db.observe("PurgeCourse", start, err, courseId)
+
+	return err
+}
@@ -0,0 +1,53 @@
+package instrumented_database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) PrepareBulkCourses() {
+	start := time.Now()
+	db.database.PrepareBulkCourses()
+	db.observe("PrepareBulkCourses", start, nil)
+}
+
+func (db *InstrumentedDatabase) InsertCourse(title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, publishAt, unpublishAt sql.NullTime) {
+	start := time.Now()
+	db.database.InsertCourse(title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, keywords, publishAt, unpublishAt)
+	db.observe("InsertCourse", start, nil, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, keywords, publishAt, unpublishAt)
+}
+
+func (db *InstrumentedDatabase) UpdateCourse(id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) {
+	start := time.Now()
+	db.database.UpdateCourse(id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, keywords, authorId, publishAt, unpublishAt)
+	db.observe("UpdateCourse", start, nil, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, keywords, authorId, publishAt, unpublishAt)
+}
+
+func (db *InstrumentedDatabase) InsertChapter(title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel) {
+	start := time.Now()
+	db.database.InsertChapter(title, slug, chapter, content, toc, wordCount, readingTime, fileChecksum, fileKey, courseKey, releaseAfterDays, releaseAt, quiz)
+	db.observe("InsertChapter", start, nil, title, slug, chapter, content, toc, wordCount, readingTime, fileChecksum, fileKey, courseKey, releaseAfterDays, releaseAt, quiz)
+}
+
+func (db *InstrumentedDatabase) UpdateChapter(id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel) {
+	start := time.Now()
+	db.database.UpdateChapter(id, title, slug, chapter, content, toc, wordCount, readingTime, fileChecksum, fileKey, courseKey, releaseAfterDays, releaseAt, quiz)
+	db.observe("UpdateChapter", start, nil, id, title, slug, chapter, content, toc, wordCount, readingTime, fileChecksum, fileKey, courseKey, releaseAfterDays, releaseAt, quiz)
+}
+
+func (db *InstrumentedDatabase) DeleteChapter(id string) {
+	start := time.Now()
+	db.database.DeleteChapter(id)
+	db.observe("DeleteChapter", start, nil, id)
+}
+
+func (db *InstrumentedDatabase) RunBulkCourses(ctx context.Context) error {
+	start := time.Now()
+	err := db.database.RunBulkCourses(ctx)
+	db.observe("RunBulkCourses", start, err)
+
+	return err
+}
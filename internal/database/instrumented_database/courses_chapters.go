@@ -0,0 +1,48 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetAllChapters(ctx context.Context) ([]*models.ChapterModel, error) {
+	start := time.Now()
+	chapters, err := db.database.GetAllChapters(ctx)
+	db.observe("GetAllChapters", start, err)
+
+	return chapters, err
+}
+
+func (db *InstrumentedDatabase) GetChapterBySlug(ctx context.Context, chapterSlug string) (*models.ChapterModel, error) {
+	start := time.Now()
+	chapter, err := db.database.GetChapterBySlug(ctx, chapterSlug)
+	db.observe("GetChapterBySlug", start, err, chapterSlug)
+
+	return chapter, err
+}
+
+func (db *InstrumentedDatabase) GetChapterByFileKey(ctx context.Context, fileKey string) (*models.ChapterModel, error) {
+	start := time.Now()
+	chapter, err := db.database.GetChapterByFileKey(ctx, fileKey)
+	db.observe("GetChapterByFileKey", start, err, fileKey)
+
+	return chapter, err
+}
+
+func (db *InstrumentedDatabase) CountChapters(ctx context.Context, courseId string) (int, error) {
+	start := time.Now()
+	count, err := db.database.CountChapters(ctx, courseId)
+	db.observe("CountChapters", start, err, courseId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) GetCourseChapters(ctx context.Context, courseId string) ([]*models.ChapterModel, error) {
+	start := time.Now()
+	chapters, err := db.database.GetCourseChapters(ctx, courseId)
+	db.observe("GetCourseChapters", start, err, courseId)
+
+	return chapters, err
+}
@@ -0,0 +1,14 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+)
+
+func (db *InstrumentedDatabase) GetAllKeywordsForCourse(ctx context.Context, courseId string) ([]string, error) {
+	start := time.Now()
+	keywords, err := db.database.GetAllKeywordsForCourse(ctx, courseId)
+	db.observe("GetAllKeywordsForCourse", start, err, courseId)
+
+	return keywords, err
+}
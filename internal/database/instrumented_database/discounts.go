@@ -0,0 +1,73 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetDiscountsPaginated(ctx context.Context, term string, active *bool, cursor *database.Cursor, elements uint) ([]*models.DiscountModel, *database.Cursor, error) {
+	start := time.Now()
+	discounts, next, err := db.database.GetDiscountsPaginated(ctx, term, active, cursor, elements)
+	db.observe("GetDiscountsPaginated", start, err, term, active, cursor, elements)
+
+	return discounts, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllDiscounts(ctx context.Context) ([]*models.DiscountModel, error) {
+	start := time.Now()
+	discounts, err := db.database.GetAllDiscounts(ctx)
+	db.observe("GetAllDiscounts", start, err)
+
+	return discounts, err
+}
+
+func (db *InstrumentedDatabase) CountDiscounts(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountDiscounts(ctx)
+	db.observe("CountDiscounts", start, err)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error) {
+	start := time.Now()
+	id, err := db.database.AddDiscount(ctx, title, description, discount, uses)
+	db.observe("AddDiscount", start, err, title, description, discount, uses)
+
+	return id, err
+}
+
+func (db *InstrumentedDatabase) GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error) {
+	start := time.Now()
+	discount, err := db.database.GetDiscountByID(ctx, discountId)
+	db.observe("GetDiscountByID", start, err, discountId)
+
+	return discount, err
+}
+
+func (db *InstrumentedDatabase) GetDiscountByCode(ctx context.Context, discountCode string) (*models.DiscountModel, error) {
+	start := time.Now()
+	discount, err := db.database.GetDiscountByCode(ctx, discountCode)
+	db.observe("GetDiscountByCode", start, err, discountCode)
+
+	return discount, err
+}
+
+func (db *InstrumentedDatabase) ActivateDiscount(ctx context.Context, discountId string) error {
+	start := time.Now()
+	err := db.database.ActivateDiscount(ctx, discountId)
+	db.observe("ActivateDiscount", start, err, discountId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) DeactivateDiscount(ctx context.Context, discountId string) error {
+	start := time.Now()
+	err := db.database.DeactivateDiscount(ctx, discountId)
+	db.observe("DeactivateDiscount", start, err, discountId)
+
+	return err
+}
@@ -0,0 +1,71 @@
+package instrumented_database
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+// redactArguments describes the arguments of a database call without giving away their values. Strings can contain
+// anything from email addresses to passwords and tokens so only their length gets logged. Values that only control how
+// the query runs, like limits and authorization levels, are safe to keep.
+func redactArguments(args []any) string {
+	redacted := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		redacted = append(redacted, redact(arg))
+	}
+
+	return strings.Join(redacted, ", ")
+}
+
+func redact(arg any) string {
+	switch value := arg.(type) {
+	case nil:
+		return "nil"
+	case string:
+		return fmt.Sprintf("string(%d)", len(value))
+	case []string:
+		return fmt.Sprintf("[]string(%d)", len(value))
+	case sql.NullString:
+		if !value.Valid {
+			return "NULL"
+		}
+
+		return fmt.Sprintf("string(%d)", len(value.String))
+	case *string:
+		if value == nil {
+			return "nil"
+		}
+
+		return fmt.Sprintf("*string(%d)", len(*value))
+	case *bool:
+		if value == nil {
+			return "nil"
+		}
+
+		return fmt.Sprintf("*%t", *value)
+	case *database.Cursor:
+		if value == nil {
+			return "nil"
+		}
+
+		return "cursor"
+	case bool, int, uint, time.Duration, database.AuthorizationLevel, database.PaymentStatus, database.RefundStatus, database.AuditAction:
+		return fmt.Sprint(value)
+	default:
+		// Numbers like prices, amounts of points and page sizes don't give anything away.
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			return fmt.Sprint(value)
+		}
+
+		return fmt.Sprintf("%T", value)
+	}
+}
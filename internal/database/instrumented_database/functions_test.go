@@ -0,0 +1,29 @@
+package instrumented_database
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func TestRedactArguments(t *testing.T) {
+	email := "jane@example.com"
+
+	tests := []struct {
+		args     []any
+		expected string
+	}{
+		{[]any{"password", &email, sql.NullString{}}, "string(8), *string(16), NULL"},
+		{[]any{uint(25), int32(-3), uint64(100), float64(49.99), float32(1.5)}, "25, -3, 100, 49.99, 1.5"},
+		{[]any{database.Admin, 2 * time.Second, true}, "Admin, 2s, true"},
+		{[]any{time.Time{}, []int{1, 2}}, "time.Time, []int"},
+	}
+
+	for _, test := range tests {
+		if redacted := redactArguments(test.args); redacted != test.expected {
+			t.Errorf("Expected %q. Got %q", test.expected, redacted)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package instrumented_database
+
+import (
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+)
+
+// InstrumentedDatabase wraps another database.Database and keeps track of how many times each method gets called, how
+// many of those calls returned an error and how long they took. Calls that take longer than the slow call threshold get
+// logged along with a redacted version of their arguments.
+type InstrumentedDatabase struct {
+	utils.Loggers
+	database  database.Database
+	metrics   *Metrics
+	threshold time.Duration
+}
+
+// CreateInstrumentedDatabase wraps the given database. A threshold of 0 turns the slow call logging off.
+func CreateInstrumentedDatabase(db database.Database, threshold time.Duration) *InstrumentedDatabase {
+	loggers := utils.CreateLoggers("INSTRUMENTED DATABASE")
+
+	return &InstrumentedDatabase{
+		Loggers:   loggers,
+		database:  db,
+		metrics:   NewMetrics(),
+		threshold: threshold,
+	}
+}
+
+// Metrics returns the numbers that have been recorded for the wrapped database so far.
+func (db *InstrumentedDatabase) Metrics() *Metrics {
+	return db.metrics
+}
+
+// GetMetrics returns the metrics of the given database when it is an InstrumentedDatabase and nil otherwise. This lets
+// the rest of the app get to the numbers without needing to know whether instrumentation was turned on.
+func GetMetrics(db database.Database) *Metrics {
+	if instrumented, ok := db.(*InstrumentedDatabase); ok {
+		return instrumented.Metrics()
+	}
+
+	return nil
+}
+
+func (db *InstrumentedDatabase) Close() error {
+	start := time.Now()
+	err := db.database.Close()
+	db.observe("Close", start, err)
+
+	return err
+}
+
+// observe records a single call to the wrapped database and logs it when it took longer than the threshold.
+func (db *InstrumentedDatabase) observe(method string, start time.Time, err error, args ...any) {
+	duration := time.Since(start)
+	db.metrics.record(method, duration, err)
+
+	if db.threshold > 0 && duration >= db.threshold {
+		db.WarningLog.Printf("Slow database call to %s took %s: (%s)\n", method, duration, redactArguments(args))
+	}
+}
@@ -0,0 +1,153 @@
+package instrumented_database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+)
+
+// secret is passed as every string argument to make sure that it never ends up in the logs.
+const secret = "secret@example.com"
+
+var errorType = reflect.TypeFor[error]()
+
+// TestInstrumentedDatabase calls every method of database.Database and database.Tx through an InstrumentedDatabase
+// that wraps the fake database. Every call needs to be timed, logged without giving away its arguments and hand back
+// the errors of the wrapped database.
+func TestInstrumentedDatabase(t *testing.T) {
+	ctx := context.Background()
+	fake := databasetest.NewDatabase()
+
+	var logs bytes.Buffer
+
+	// A threshold of 1ns makes every call slow enough to be logged.
+	instrumented := CreateInstrumentedDatabase(fake, time.Nanosecond)
+	instrumented.WarningLog = log.New(&logs, "", 0)
+
+	failure := errors.New("database is down")
+
+	check := func(t *testing.T, method string, wrapped reflect.Value, name string, recorded string) {
+		t.Helper()
+
+		instrumented.Metrics().Reset()
+		logs.Reset()
+		fake.ClearErrors()
+
+		wrapped.Call(arguments(ctx, wrapped.Type()))
+
+		snapshot := instrumented.Metrics().Snapshot()
+		if len(snapshot) != 1 || snapshot[0].Method != recorded || snapshot[0].Calls != 1 {
+			t.Fatalf("Expected a single call to %s to be recorded. Got %+v", recorded, snapshot)
+		}
+
+		if !strings.Contains(logs.String(), "Slow database call to "+recorded+" ") {
+			t.Errorf("Expected the call to be logged. Got %q", logs.String())
+		}
+
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("Expected the arguments to be redacted. Got %q", logs.String())
+		}
+
+		results := wrapped.Type().NumOut()
+		if results == 0 || wrapped.Type().Out(results-1) != errorType {
+			return
+		}
+
+		instrumented.Metrics().Reset()
+		fake.FailOn(name, failure)
+		defer fake.ClearErrors()
+
+		out := wrapped.Call(arguments(ctx, wrapped.Type()))
+
+		if err, _ := out[results-1].Interface().(error); !errors.Is(err, failure) {
+			t.Errorf("Expected the error of the wrapped database to be passed through. Got %v", err)
+		}
+
+		if snapshot := instrumented.Metrics().Snapshot(); len(snapshot) != 1 || snapshot[0].Errors != 1 {
+			t.Errorf("Expected the error to be recorded. Got %+v", snapshot)
+		}
+	}
+
+	databaseType := reflect.TypeFor[database.Database]()
+
+	for i := range databaseType.NumMethod() {
+		name := databaseType.Method(i).Name
+
+		t.Run(name, func(t *testing.T) {
+			if name == "WithTx" {
+				// WithTx only fails when the unit of work does, which is covered by the Tx methods below.
+				instrumented.Metrics().Reset()
+
+				if err := instrumented.WithTx(ctx, func(tx database.Tx) error { return failure }); !errors.Is(err, failure) {
+					t.Errorf("Expected the error of the unit of work to be passed through. Got %v", err)
+				}
+
+				if snapshot := instrumented.Metrics().Snapshot(); len(snapshot) != 1 || snapshot[0].Method != "WithTx" || snapshot[0].Errors != 1 {
+					t.Errorf("Expected the failed unit of work to be recorded. Got %+v", snapshot)
+				}
+
+				return
+			}
+
+			check(t, name, reflect.ValueOf(instrumented).MethodByName(name), name, name)
+		})
+	}
+
+	txType := reflect.TypeFor[database.Tx]()
+
+	for i := range txType.NumMethod() {
+		name := txType.Method(i).Name
+
+		t.Run("Tx."+name, func(t *testing.T) {
+			var tx database.Tx
+
+			// The Tx handed out by WithTx gets captured so that its methods can be called one at a time.
+			if err := instrumented.WithTx(ctx, func(unitOfWork database.Tx) error {
+				tx = unitOfWork
+				return nil
+			}); err != nil {
+				t.Fatalf("Failed to start unit of work: %s", err)
+			}
+
+			check(t, name, reflect.ValueOf(tx).MethodByName(name), name, "Tx."+name)
+		})
+	}
+}
+
+// arguments builds arguments for a call to a method of the given type. Contexts get a real context, strings get
+// secret, functions do nothing and everything else gets its zero value.
+func arguments(ctx context.Context, method reflect.Type) []reflect.Value {
+	args := make([]reflect.Value, method.NumIn())
+
+	for i := range args {
+		in := method.In(i)
+
+		switch {
+		case in == reflect.TypeFor[context.Context]():
+			args[i] = reflect.ValueOf(ctx)
+		case in.Kind() == reflect.String:
+			args[i] = reflect.ValueOf(secret).Convert(in)
+		case in.Kind() == reflect.Func:
+			args[i] = reflect.MakeFunc(in, func([]reflect.Value) []reflect.Value {
+				out := make([]reflect.Value, in.NumOut())
+				for j := range out {
+					out[j] = reflect.Zero(in.Out(j))
+				}
+
+				return out
+			})
+		default:
+			args[i] = reflect.Zero(in)
+		}
+	}
+
+	return args
+}
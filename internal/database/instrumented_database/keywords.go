@@ -0,0 +1,22 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+)
+
+func (db *InstrumentedDatabase) GetKeywords(ctx context.Context) ([]string, error) {
+	start := time.Now()
+	keywords, err := db.database.GetKeywords(ctx)
+	db.observe("GetKeywords", start, err)
+
+	return keywords, err
+}
+
+func (db *InstrumentedDatabase) DeleteAllKeywords(ctx context.Context) error {
+	start := time.Now()
+	err := db.database.DeleteAllKeywords(ctx)
+	db.observe("DeleteAllKeywords", start, err)
+
+	return err
+}
@@ -0,0 +1,38 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+)
+
+func (db *InstrumentedDatabase) DeleteExpiredTokens(ctx context.Context) (uint, error) {
+	start := time.Now()
+	deleted, err := db.database.DeleteExpiredTokens(ctx)
+	db.observe("DeleteExpiredTokens", start, err)
+
+	return deleted, err
+}
+
+func (db *InstrumentedDatabase) CancelStalePurchases(ctx context.Context, olderThan time.Duration) (uint, error) {
+	start := time.Now()
+	cancelled, err := db.database.CancelStalePurchases(ctx, olderThan)
+	db.observe("CancelStalePurchases", start, err, olderThan)
+
+	return cancelled, err
+}
+
+func (db *InstrumentedDatabase) PurgeDeleted(ctx context.Context, olderThan time.Duration) (uint, error) {
+	start := time.Now()
+	purged, err := db.database.PurgeDeleted(ctx, olderThan)
+	db.observe("PurgeDeleted", start, err, olderThan)
+
+	return purged, err
+}
+
+func (db *InstrumentedDatabase) Optimize(ctx context.Context) error {
+	start := time.Now()
+	err := db.database.Optimize(ctx)
+	db.observe("Optimize", start, err)
+
+	return err
+}
@@ -0,0 +1,111 @@
+package instrumented_database
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the buckets in each method's latency histogram. Calls that take longer than
+// the last bound get counted in one extra bucket at the end of the histogram.
+var LatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Metrics holds the numbers recorded for every method of an InstrumentedDatabase. It is safe for concurrent use.
+type Metrics struct {
+	mu      sync.Mutex
+	methods map[string]*MethodMetrics
+}
+
+// MethodMetrics holds the numbers recorded for a single method. Histogram[i] counts the calls that took at most
+// LatencyBuckets[i] (and longer than the bound before it) while the last element counts the calls that took longer than
+// every bound.
+type MethodMetrics struct {
+	Method        string
+	Calls         uint64
+	Errors        uint64
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	Histogram     []uint64
+}
+
+// NewMetrics creates an empty set of metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		methods: make(map[string]*MethodMetrics),
+	}
+}
+
+// Snapshot returns a copy of the numbers recorded so far, sorted by method name.
+func (metrics *Metrics) Snapshot() []MethodMetrics {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	snapshot := make([]MethodMetrics, 0, len(metrics.methods))
+
+	for _, method := range metrics.methods {
+		methodCopy := *method
+		methodCopy.Histogram = slices.Clone(method.Histogram)
+		snapshot = append(snapshot, methodCopy)
+	}
+
+	slices.SortFunc(snapshot, func(a, b MethodMetrics) int {
+		return strings.Compare(a.Method, b.Method)
+	})
+
+	return snapshot
+}
+
+// Reset throws away everything that has been recorded so far.
+func (metrics *Metrics) Reset() {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	metrics.methods = make(map[string]*MethodMetrics)
+}
+
+// AverageDuration returns how long a call to the method took on average.
+func (method MethodMetrics) AverageDuration() time.Duration {
+	if method.Calls == 0 {
+		return 0
+	}
+
+	return method.TotalDuration / time.Duration(method.Calls)
+}
+
+func (metrics *Metrics) record(methodName string, duration time.Duration, err error) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	method, has := metrics.methods[methodName]
+	if !has {
+		method = &MethodMetrics{
+			Method:    methodName,
+			Histogram: make([]uint64, len(LatencyBuckets)+1),
+		}
+
+		metrics.methods[methodName] = method
+	}
+
+	method.Calls++
+	method.TotalDuration += duration
+	method.MaxDuration = max(method.MaxDuration, duration)
+
+	if err != nil {
+		method.Errors++
+	}
+
+	bucket, _ := slices.BinarySearch(LatencyBuckets, duration)
+	method.Histogram[bucket]++
+}
@@ -0,0 +1,63 @@
+package instrumented_database
+
+import (
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func (db *InstrumentedDatabase) MigrateUp() error {
+	start := time.Now()
+	err := db.database.MigrateUp()
+	db.observe("MigrateUp", start, err)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) MigrateDown() error {
+	start := time.Now()
+	err := db.database.MigrateDown()
+	db.observe("MigrateDown", start, err)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) Rollback(steps int) error {
+	start := time.Now()
+	err := db.database.Rollback(steps)
+	db.observe("Rollback", start, err, steps)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) MigrateTo(version uint) error {
+	start := time.Now()
+	err := db.database.MigrateTo(version)
+	db.observe("MigrateTo", start, err, version)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) ForceMigrationVersion(version int) error {
+	start := time.Now()
+	err := db.database.ForceMigrationVersion(version)
+	db.observe("ForceMigrationVersion", start, err, version)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) MigrationVersion() (uint, bool, error) {
+	start := time.Now()
+	version, dirty, err := db.database.MigrationVersion()
+	db.observe("MigrationVersion", start, err)
+
+	return version, dirty, err
+}
+
+func (db *InstrumentedDatabase) Migrations() ([]database.Migration, error) {
+	start := time.Now()
+	migrations, err := db.database.Migrations()
+	db.observe("Migrations", start, err)
+
+	return migrations, err
+}
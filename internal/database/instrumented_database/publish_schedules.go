@@ -0,0 +1,32 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetTutorialPublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error) {
+	start := time.Now()
+	schedules, err := db.database.GetTutorialPublishSchedules(ctx)
+	db.observe("GetTutorialPublishSchedules", start, err)
+
+	return schedules, err
+}
+
+func (db *InstrumentedDatabase) GetCoursePublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error) {
+	start := time.Now()
+	schedules, err := db.database.GetCoursePublishSchedules(ctx)
+	db.observe("GetCoursePublishSchedules", start, err)
+
+	return schedules, err
+}
+
+func (db *InstrumentedDatabase) RunPublishSchedules(ctx context.Context) (uint, error) {
+	start := time.Now()
+	changed, err := db.database.RunPublishSchedules(ctx)
+	db.observe("RunPublishSchedules", start, err)
+
+	return changed, err
+}
@@ -0,0 +1,56 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetQuizByChapterID(ctx context.Context, chapterId string) (*models.QuizModel, error) {
+	start := time.Now()
+	quiz, err := db.database.GetQuizByChapterID(ctx, chapterId)
+	db.observe("GetQuizByChapterID", start, err, chapterId)
+
+	return quiz, err
+}
+
+func (db *InstrumentedDatabase) AddQuizAttempt(ctx context.Context, userId, quizId string, score int, passed bool, answers []*models.QuizAttemptAnswerModel) error {
+	start := time.Now()
+	err := db.database.AddQuizAttempt(ctx, userId, quizId, score, passed, answers)
+	db.observe("AddQuizAttempt", start, err, userId, quizId, score, passed, answers)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetQuizAttempts(ctx context.Context, userId, quizId string) ([]*models.QuizAttemptModel, error) {
+	start := time.Now()
+	attempts, err := db.database.GetQuizAttempts(ctx, userId, quizId)
+	db.observe("GetQuizAttempts", start, err, userId, quizId)
+
+	return attempts, err
+}
+
+func (db *InstrumentedDatabase) GetQuizAttemptAnswers(ctx context.Context, attemptId string) ([]*models.QuizAttemptAnswerModel, error) {
+	start := time.Now()
+	answers, err := db.database.GetQuizAttemptAnswers(ctx, attemptId)
+	db.observe("GetQuizAttemptAnswers", start, err, attemptId)
+
+	return answers, err
+}
+
+func (db *InstrumentedDatabase) HasUserPassedQuiz(ctx context.Context, userId, quizId string) (bool, error) {
+	start := time.Now()
+	passed, err := db.database.HasUserPassedQuiz(ctx, userId, quizId)
+	db.observe("HasUserPassedQuiz", start, err, userId, quizId)
+
+	return passed, err
+}
+
+func (db *InstrumentedDatabase) GetQuizQuestionStats(ctx context.Context, quizId string) ([]*models.QuizQuestionStatsModel, error) {
+	start := time.Now()
+	stats, err := db.database.GetQuizQuestionStats(ctx, quizId)
+	db.observe("GetQuizQuestionStats", start, err, quizId)
+
+	return stats, err
+}
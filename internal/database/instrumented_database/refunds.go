@@ -0,0 +1,49 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AdminGetRefunds(ctx context.Context, term string, status string, cursor *database.Cursor, elements uint) ([]*models.RefundModel, *database.Cursor, error) {
+	start := time.Now()
+	refunds, next, err := db.database.AdminGetRefunds(ctx, term, status, cursor, elements)
+	db.observe("AdminGetRefunds", start, err, term, status, cursor, elements)
+
+	return refunds, next, err
+}
+
+func (db *InstrumentedDatabase) RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status database.RefundStatus) error {
+	start := time.Now()
+	err := db.database.RegisterRefund(ctx, userId, coursePurchaseId, status)
+	db.observe("RegisterRefund", start, err, userId, coursePurchaseId, status)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error) {
+	start := time.Now()
+	refund, err := db.database.GetRefundWithCoursePurchaseID(ctx, coursePurchaseId)
+	db.observe("GetRefundWithCoursePurchaseID", start, err, coursePurchaseId)
+
+	return refund, err
+}
+
+func (db *InstrumentedDatabase) UpdateRefundStatus(ctx context.Context, refundId string, status database.RefundStatus) error {
+	start := time.Now()
+	err := db.database.UpdateRefundStatus(ctx, refundId, status)
+	db.observe("UpdateRefundStatus", start, err, refundId, status)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) CountRefunds(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountRefunds(ctx)
+	db.observe("CountRefunds", start, err)
+
+	return count, err
+}
@@ -0,0 +1,22 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+)
+
+func (db *InstrumentedDatabase) GetTutorialSearchSnippets(ctx context.Context, term string, tutorialIds []string) (map[string]string, error) {
+	start := time.Now()
+	snippets, err := db.database.GetTutorialSearchSnippets(ctx, term, tutorialIds)
+	db.observe("GetTutorialSearchSnippets", start, err, term, tutorialIds)
+
+	return snippets, err
+}
+
+func (db *InstrumentedDatabase) GetCourseSearchSnippets(ctx context.Context, term string, courseIds []string) (map[string]string, error) {
+	start := time.Now()
+	snippets, err := db.database.GetCourseSearchSnippets(ctx, term, courseIds)
+	db.observe("GetCourseSearchSnippets", start, err, term, courseIds)
+
+	return snippets, err
+}
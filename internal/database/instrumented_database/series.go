@@ -0,0 +1,40 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetAllSeries(ctx context.Context) ([]*models.SeriesModel, error) {
+	start := time.Now()
+	series, err := db.database.GetAllSeries(ctx)
+	db.observe("GetAllSeries", start, err)
+
+	return series, err
+}
+
+func (db *InstrumentedDatabase) GetSeriesBySlug(ctx context.Context, slug string) (*models.SeriesModel, error) {
+	start := time.Now()
+	series, err := db.database.GetSeriesBySlug(ctx, slug)
+	db.observe("GetSeriesBySlug", start, err, slug)
+
+	return series, err
+}
+
+func (db *InstrumentedDatabase) GetSeriesByTutorialID(ctx context.Context, tutorialId string) (*models.SeriesModel, error) {
+	start := time.Now()
+	series, err := db.database.GetSeriesByTutorialID(ctx, tutorialId)
+	db.observe("GetSeriesByTutorialID", start, err, tutorialId)
+
+	return series, err
+}
+
+func (db *InstrumentedDatabase) GetSeriesTutorials(ctx context.Context, seriesId string) ([]*models.TutorialModel, error) {
+	start := time.Now()
+	tutorials, err := db.database.GetSeriesTutorials(ctx, seriesId)
+	db.observe("GetSeriesTutorials", start, err, seriesId)
+
+	return tutorials, err
+}
@@ -0,0 +1,16 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func (db *InstrumentedDatabase) GetCurrentSlug(ctx context.Context, kind database.SlugKind, oldSlug string) (string, error) {
+	start := time.Now()
+	slug, err := db.database.GetCurrentSlug(ctx, kind, oldSlug)
+	db.observe("GetCurrentSlug", start, err, kind, oldSlug)
+
+	return slug, err
+}
@@ -0,0 +1,40 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AddToken(ctx context.Context, token, tokenType, userId string, validUntil time.Time) error {
+	start := time.Now()
+	err := db.database.AddToken(ctx, token, tokenType, userId, validUntil)
+	db.observe("AddToken", start, err, token, tokenType, userId, validUntil)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetToken(ctx context.Context, token, tokenType string) (*models.TokenModel, error) {
+	start := time.Now()
+	tokenModel, err := db.database.GetToken(ctx, token, tokenType)
+	db.observe("GetToken", start, err, token, tokenType)
+
+	return tokenModel, err
+}
+
+func (db *InstrumentedDatabase) DeleteToken(ctx context.Context, token, tokenType string) error {
+	start := time.Now()
+	err := db.database.DeleteToken(ctx, token, tokenType)
+	db.observe("DeleteToken", start, err, token, tokenType)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) DeleteAllTokens(ctx context.Context, email, tokenType string) error {
+	start := time.Now()
+	err := db.database.DeleteAllTokens(ctx, email, tokenType)
+	db.observe("DeleteAllTokens", start, err, email, tokenType)
+
+	return err
+}
@@ -0,0 +1,159 @@
+package instrumented_database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) WithTx(ctx context.Context, fn func(tx database.Tx) error) error {
+	start := time.Now()
+	err := db.database.WithTx(ctx, func(tx database.Tx) error {
+		return fn(&InstrumentedTx{database: db, tx: tx})
+	})
+	db.observe("WithTx", start, err)
+
+	return err
+}
+
+// InstrumentedTx wraps the database.Tx handed out by the underlying database so that calls made as part of a
+// transaction get recorded too. They are recorded under their own names with a "Tx." prefix.
+type InstrumentedTx struct {
+	database *InstrumentedDatabase
+	tx       database.Tx
+}
+
+func (db *InstrumentedTx) observe(method string, start time.Time, err error, args ...any) {
+	db.database.observe(method, start, err, args...)
+}
+
+func (db *InstrumentedTx) GetUserByID(ctx context.Context, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.tx.GetUserByID(ctx, id, level)
+	db.observe("Tx.GetUserByID", start, err, id, level)
+
+	return user, err
+}
+
+func (db *InstrumentedTx) GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.tx.GetUserByAffiliateCode(ctx, affiliateCode, level)
+	db.observe("Tx.GetUserByAffiliateCode", start, err, affiliateCode, level)
+
+	return user, err
+}
+
+func (db *InstrumentedTx) GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error) {
+	start := time.Now()
+	course, err := db.tx.GetCourseByID(ctx, courseId)
+	db.observe("Tx.GetCourseByID", start, err, courseId)
+
+	return course, err
+}
+
+func (db *InstrumentedTx) AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error) {
+	start := time.Now()
+	id, err := db.tx.AddDiscount(ctx, title, description, discount, uses)
+	db.observe("Tx.AddDiscount", start, err, title, description, discount, uses)
+
+	return id, err
+}
+
+func (db *InstrumentedTx) GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error) {
+	start := time.Now()
+	discount, err := db.tx.GetDiscountByID(ctx, discountId)
+	db.observe("Tx.GetDiscountByID", start, err, discountId)
+
+	return discount, err
+}
+
+func (db *InstrumentedTx) ActivateDiscount(ctx context.Context, discountId string) error {
+	start := time.Now()
+	err := db.tx.ActivateDiscount(ctx, discountId)
+	db.observe("Tx.ActivateDiscount", start, err, discountId)
+
+	return err
+}
+
+func (db *InstrumentedTx) RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+	start := time.Now()
+	err := db.tx.RegisterCoursePurchase(ctx, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil)
+	db.observe("Tx.RegisterCoursePurchase", start, err, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil)
+
+	return err
+}
+
+func (db *InstrumentedTx) GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchase, err := db.tx.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+	db.observe("Tx.GetCoursePurchaseByPaymentKey", start, err, paymentKey)
+
+	return coursePurchase, err
+}
+
+func (db *InstrumentedTx) GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchase, err := db.tx.GetCoursePurchaseByCheckoutSession(ctx, checkoutSessionId)
+	db.observe("Tx.GetCoursePurchaseByCheckoutSession", start, err, checkoutSessionId)
+
+	return coursePurchase, err
+}
+
+func (db *InstrumentedTx) UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status database.PaymentStatus) error {
+	start := time.Now()
+	err := db.tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchaseId, status)
+	db.observe("Tx.UpdateCoursePurchasePaymentStatus", start, err, coursePurchaseId, status)
+
+	return err
+}
+
+func (db *InstrumentedTx) TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	start := time.Now()
+	changed, err := db.tx.TransitionCoursePurchasePaymentStatus(ctx, coursePurchaseId, from, to)
+	db.observe("Tx.TransitionCoursePurchasePaymentStatus", start, err, coursePurchaseId, from, to)
+
+	return changed, err
+}
+
+func (db *InstrumentedTx) GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	start := time.Now()
+	coursePurchases, err := db.tx.GetCoursePurchasesByUserAndCourse(ctx, userId, courseId)
+	db.observe("Tx.GetCoursePurchasesByUserAndCourse", start, err, userId, courseId)
+
+	return coursePurchases, err
+}
+
+func (db *InstrumentedTx) RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error {
+	start := time.Now()
+	err := db.tx.RegisterAffiliatePointsChange(ctx, userId, courseId, pointsChange, reason)
+	db.observe("Tx.RegisterAffiliatePointsChange", start, err, userId, courseId, pointsChange, reason)
+
+	return err
+}
+
+func (db *InstrumentedTx) RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status database.RefundStatus) error {
+	start := time.Now()
+	err := db.tx.RegisterRefund(ctx, userId, coursePurchaseId, status)
+	db.observe("Tx.RegisterRefund", start, err, userId, coursePurchaseId, status)
+
+	return err
+}
+
+func (db *InstrumentedTx) GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error) {
+	start := time.Now()
+	refund, err := db.tx.GetRefundWithCoursePurchaseID(ctx, coursePurchaseId)
+	db.observe("Tx.GetRefundWithCoursePurchaseID", start, err, coursePurchaseId)
+
+	return refund, err
+}
+
+func (db *InstrumentedTx) UpdateRefundStatus(ctx context.Context, refundId string, status database.RefundStatus) error {
+	start := time.Now()
+	err := db.tx.UpdateRefundStatus(ctx, refundId, status)
+	db.observe("Tx.UpdateRefundStatus", start, err, refundId, status)
+
+	return err
+}
@@ -0,0 +1,113 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AdminGetTutorials(ctx context.Context, term string, published *bool, authorId *string, likedByUser string, bookmarkedByUser string, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	start := time.Now()
+	tutorials, next, err := db.database.AdminGetTutorials(ctx, term, published, authorId, likedByUser, bookmarkedByUser, keyword, deleted, cursor, elements)
+	db.observe("AdminGetTutorials", start, err, term, published, authorId, likedByUser, bookmarkedByUser, keyword, deleted, cursor, elements)
+
+	return tutorials, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllTutorials(ctx context.Context, authorId string, published *bool) ([]*models.TutorialModel, error) {
+	start := time.Now()
+	tutorials, err := db.database.GetAllTutorials(ctx, authorId, published)
+	db.observe("GetAllTutorials", start, err, authorId, published)
+
+	return tutorials, err
+}
+
+func (db *InstrumentedDatabase) GetTutorials(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.TutorialModel, *database.Cursor, error) {
+	start := time.Now()
+	tutorials, next, err := db.database.GetTutorials(ctx, term, authorId, cursor, elements)
+	db.observe("GetTutorials", start, err, term, authorId, cursor, elements)
+
+	return tutorials, next, err
+}
+
+func (db *InstrumentedDatabase) GetTutorialByID(ctx context.Context, id string) (*models.TutorialModel, error) {
+	start := time.Now()
+	tutorial, err := db.database.GetTutorialByID(ctx, id)
+	db.observe("GetTutorialByID", start, err, id)
+
+	return tutorial, err
+}
+
+func (db *InstrumentedDatabase) GetTutorialBySlug(ctx context.Context, slug string) (*models.TutorialModel, error) {
+	start := time.Now()
+	tutorial, err := db.database.GetTutorialBySlug(ctx, slug)
+	db.observe("GetTutorialBySlug", start, err, slug)
+
+	return tutorial, err
+}
+
+func (db *InstrumentedDatabase) CountTutorials(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountTutorials(ctx)
+	db.observe("CountTutorials", start, err)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) CountTutorialsWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountTutorialsWrittenBy(ctx, authorId)
+	db.observe("CountTutorialsWrittenBy", start, err, authorId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) PublishTutorial(ctx context.Context, tutorialId string) error {
+	start := time.Now()
+	err := db.database.PublishTutorial(ctx, tutorialId)
+	db.observe("PublishTutorial", start, err, tutorialId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UnpublishTutorial(ctx context.Context, tutorialId string) error {
+	start := time.Now()
+	err := db.database.UnpublishTutorial(ctx, tutorialId)
+	db.observe("UnpublishTutorial", start, err, tutorialId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UpdateTutorialAuthor(ctx context.Context, tutorialId, authorId string) error {
+	start := time.Now()
+	err := db.database.UpdateTutorialAuthor(ctx, tutorialId, authorId)
+	db.observe("UpdateTutorialAuthor", start, err, tutorialId, authorId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) DeleteTutorial(ctx context.Context, tutorialId string) error {
+	start := time.Now()
+	err := db.database.DeleteTutorial(ctx, tutorialId)
+	db.observe("DeleteTutorial", start, err, tutorialId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) RestoreTutorial(ctx context.Context, tutorialId string) error {
+	start := time.Now()
+	err := db.database.RestoreTutorial(ctx, tutorialId)
+	db.observe("RestoreTutorial", start, err, tutorialId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) PurgeTutorial(ctx context.Context, tutorialId string) error {
+	start := time.Now()
+	err := db.database.PurgeTutorial(ctx, tutorialId)
+	db.observe("PurgeTutorial", start, err, tutorialId)
+
+	return err
+}
@@ -0,0 +1,57 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetTutorialsBookmarkedByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	start := time.Now()
+	tutorials, next, err := db.database.GetTutorialsBookmarkedByUser(ctx, term, userId, cursor, elements)
+	db.observe("GetTutorialsBookmarkedByUser", start, err, term, userId, cursor, elements)
+
+	return tutorials, next, err
+}
+
+func (db *InstrumentedDatabase) UserBookmarkedTutorial(ctx context.Context, userId, slug string) (bool, error) {
+	start := time.Now()
+	bookmarked, err := db.database.UserBookmarkedTutorial(ctx, userId, slug)
+	db.observe("UserBookmarkedTutorial", start, err, userId, slug)
+
+	return bookmarked, err
+}
+
+func (db *InstrumentedDatabase) UserBookmarkTutorial(ctx context.Context, userId, slug string) error {
+	start := time.Now()
+	err := db.database.UserBookmarkTutorial(ctx, userId, slug)
+	db.observe("UserBookmarkTutorial", start, err, userId, slug)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UserUnbookmarkTutorial(ctx context.Context, userId, slug string) error {
+	start := time.Now()
+	err := db.database.UserUnbookmarkTutorial(ctx, userId, slug)
+	db.observe("UserUnbookmarkTutorial", start, err, userId, slug)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) CountTutorialsBookmarkedByUser(ctx context.Context, userId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountTutorialsBookmarkedByUser(ctx, userId)
+	db.observe("CountTutorialsBookmarkedByUser", start, err, userId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) CountTutorialBookmarks(ctx context.Context, tutorialId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountTutorialBookmarks(ctx, tutorialId)
+	db.observe("CountTutorialBookmarks", start, err, tutorialId)
+
+	return count, err
+}
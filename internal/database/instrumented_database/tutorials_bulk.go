@@ -0,0 +1,35 @@
+package instrumented_database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) PrepareBulkTutorials() {
+	start := time.Now()
+	db.database.PrepareBulkTutorials()
+	db.observe("PrepareBulkTutorials", start, nil)
+}
+
+func (db *InstrumentedDatabase) InsertTutorial(title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, publishAt, unpublishAt sql.NullTime) {
+	start := time.Now()
+	db.database.InsertTutorial(title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, checksum, fileKey, keywords, series, seriesSlug, seriesOrder, publishAt, unpublishAt)
+	db.observe("InsertTutorial", start, nil, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, checksum, fileKey, keywords, series, seriesSlug, seriesOrder, publishAt, unpublishAt)
+}
+
+func (db *InstrumentedDatabase) UpdateTutorial(id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) {
+	start := time.Now()
+	db.database.UpdateTutorial(id, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, checksum, fileKey, keywords, series, seriesSlug, seriesOrder, authorId, publishAt, unpublishAt)
+	db.observe("UpdateTutorial", start, nil, id, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, checksum, fileKey, keywords, series, seriesSlug, seriesOrder, authorId, publishAt, unpublishAt)
+}
+
+func (db *InstrumentedDatabase) RunBulkTutorials(ctx context.Context) error {
+	start := time.Now()
+	err := db.database.RunBulkTutorials(ctx)
+	db.observe("RunBulkTutorials", start, err)
+
+	return err
+}
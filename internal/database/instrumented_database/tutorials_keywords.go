@@ -0,0 +1,14 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+)
+
+func (db *InstrumentedDatabase) GetAllKeywordsForTutorial(ctx context.Context, tutorialId string) ([]string, error) {
+	start := time.Now()
+	keywords, err := db.database.GetAllKeywordsForTutorial(ctx, tutorialId)
+	db.observe("GetAllKeywordsForTutorial", start, err, tutorialId)
+
+	return keywords, err
+}
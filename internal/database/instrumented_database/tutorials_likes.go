@@ -0,0 +1,57 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetTutorialsLikedByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	start := time.Now()
+	tutorials, next, err := db.database.GetTutorialsLikedByUser(ctx, term, userId, cursor, elements)
+	db.observe("GetTutorialsLikedByUser", start, err, term, userId, cursor, elements)
+
+	return tutorials, next, err
+}
+
+func (db *InstrumentedDatabase) UserLikedTutorial(ctx context.Context, userId, slug string) (bool, error) {
+	start := time.Now()
+	liked, err := db.database.UserLikedTutorial(ctx, userId, slug)
+	db.observe("UserLikedTutorial", start, err, userId, slug)
+
+	return liked, err
+}
+
+func (db *InstrumentedDatabase) UserLikeTutorial(ctx context.Context, userId, slug string) error {
+	start := time.Now()
+	err := db.database.UserLikeTutorial(ctx, userId, slug)
+	db.observe("UserLikeTutorial", start, err, userId, slug)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UserDislikeTutorial(ctx context.Context, userId, slug string) error {
+	start := time.Now()
+	err := db.database.UserDislikeTutorial(ctx, userId, slug)
+	db.observe("UserDislikeTutorial", start, err, userId, slug)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) CountTutorialsLikedByUser(ctx context.Context, userId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountTutorialsLikedByUser(ctx, userId)
+	db.observe("CountTutorialsLikedByUser", start, err, userId)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) CountTutorialLikes(ctx context.Context, tutorialId string) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountTutorialLikes(ctx, tutorialId)
+	db.observe("CountTutorialLikes", start, err, tutorialId)
+
+	return count, err
+}
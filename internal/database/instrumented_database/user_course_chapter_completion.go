@@ -0,0 +1,40 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) HasUserCompletedChapter(ctx context.Context, userId, courseId, chapterId string) (bool, error) {
+	start := time.Now()
+	completed, err := db.database.HasUserCompletedChapter(ctx, userId, courseId, chapterId)
+	db.observe("HasUserCompletedChapter", start, err, userId, courseId, chapterId)
+
+	return completed, err
+}
+
+func (db *InstrumentedDatabase) GetAllChaptersCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	start := time.Now()
+	chapters, err := db.database.GetAllChaptersCompleted(ctx, userId, courseId)
+	db.observe("GetAllChaptersCompleted", start, err, userId, courseId)
+
+	return chapters, err
+}
+
+func (db *InstrumentedDatabase) GetAllChaptersNotCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	start := time.Now()
+	chapters, err := db.database.GetAllChaptersNotCompleted(ctx, userId, courseId)
+	db.observe("GetAllChaptersNotCompleted", start, err, userId, courseId)
+
+	return chapters, err
+}
+
+func (db *InstrumentedDatabase) FinishChapter(ctx context.Context, userId, chapterId, courseId string) error {
+	start := time.Now()
+	err := db.database.FinishChapter(ctx, userId, chapterId, courseId)
+	db.observe("FinishChapter", start, err, userId, chapterId, courseId)
+
+	return err
+}
@@ -0,0 +1,177 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) GetUsers(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error) {
+	start := time.Now()
+	users, err := db.database.GetUsers(ctx, term, level, likedTutorialID, bookmarkedTutorialID)
+	db.observe("GetUsers", start, err, term, level, likedTutorialID, bookmarkedTutorialID)
+
+	return users, err
+}
+
+func (db *InstrumentedDatabase) GetUsersPaginated(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, cursor *database.Cursor, elements uint) ([]*models.UserModel, *database.Cursor, error) {
+	start := time.Now()
+	users, next, err := db.database.GetUsersPaginated(ctx, term, level, likedTutorialID, bookmarkedTutorialID, cursor, elements)
+	db.observe("GetUsersPaginated", start, err, term, level, likedTutorialID, bookmarkedTutorialID, cursor, elements)
+
+	return users, next, err
+}
+
+func (db *InstrumentedDatabase) GetAllUsers(ctx context.Context) ([]*models.UserModel, error) {
+	start := time.Now()
+	users, err := db.database.GetAllUsers(ctx)
+	db.observe("GetAllUsers", start, err)
+
+	return users, err
+}
+
+func (db *InstrumentedDatabase) AddNewUser(ctx context.Context, name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.AddNewUser(ctx, name, surname, email, password, token, tokenType, ipAddr, validUntil)
+	db.observe("AddNewUser", start, err, name, surname, email, password, token, tokenType, ipAddr, validUntil)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) NewUser(ctx context.Context, name, surname, email, password string) error {
+	start := time.Now()
+	err := db.database.NewUser(ctx, name, surname, email, password)
+	db.observe("NewUser", start, err, name, surname, email, password)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) NewAdminUser(ctx context.Context, name, surname, email, password string) error {
+	start := time.Now()
+	err := db.database.NewAdminUser(ctx, name, surname, email, password)
+	db.observe("NewAdminUser", start, err, name, surname, email, password)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetUserByEmail(ctx context.Context, email string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.GetUserByEmail(ctx, email, level)
+	db.observe("GetUserByEmail", start, err, email, level)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) GetUserByID(ctx context.Context, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.GetUserByID(ctx, id, level)
+	db.observe("GetUserByID", start, err, id, level)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) GetUserByToken(ctx context.Context, token, tokenType string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.GetUserByToken(ctx, token, tokenType, level)
+	db.observe("GetUserByToken", start, err, token, tokenType, level)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.GetUserByAffiliateCode(ctx, affiliateCode, level)
+	db.observe("GetUserByAffiliateCode", start, err, affiliateCode, level)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) GetUserBySlug(ctx context.Context, userSlug string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	start := time.Now()
+	user, err := db.database.GetUserBySlug(ctx, userSlug, level)
+	db.observe("GetUserBySlug", start, err, userSlug, level)
+
+	return user, err
+}
+
+func (db *InstrumentedDatabase) UpdateUserName(ctx context.Context, userId, name, surname string) error {
+	start := time.Now()
+	err := db.database.UpdateUserName(ctx, userId, name, surname)
+	db.observe("UpdateUserName", start, err, userId, name, surname)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UpdateUserEmail(ctx context.Context, userId, email string) error {
+	start := time.Now()
+	err := db.database.UpdateUserEmail(ctx, userId, email)
+	db.observe("UpdateUserEmail", start, err, userId, email)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) UpdateUserPassword(ctx context.Context, userId, password string) error {
+	start := time.Now()
+	err := db.database.UpdateUserPassword(ctx, userId, password)
+	db.observe("UpdateUserPassword", start, err, userId, password)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) CountUsers(ctx context.Context) (uint, error) {
+	start := time.Now()
+	count, err := db.database.CountUsers(ctx)
+	db.observe("CountUsers", start, err)
+
+	return count, err
+}
+
+func (db *InstrumentedDatabase) AddAuthorStatus(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := db.database.AddAuthorStatus(ctx, userId)
+	db.observe("AddAuthorStatus", start, err, userId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) RemoveAuthorStatus(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := db.database.RemoveAuthorStatus(ctx, userId)
+	db.observe("RemoveAuthorStatus", start, err, userId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) AddAdminStatus(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := db.database.AddAdminStatus(ctx, userId)
+	db.observe("AddAdminStatus", start, err, userId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) RemoveAdminStatus(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := db.database.RemoveAdminStatus(ctx, userId)
+	db.observe("RemoveAdminStatus", start, err, userId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) DeleteUser(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := db.database.DeleteUser(ctx, userId)
+	db.observe("DeleteUser", start, err, userId)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) RestoreUser(ctx context.Context, userId string) error {
+	start := time.Now()
+	err := db.database.RestoreUser(ctx, userId)
+	db.observe("RestoreUser", start, err, userId)
+
+	return err
+}
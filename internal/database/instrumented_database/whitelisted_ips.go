@@ -0,0 +1,32 @@
+package instrumented_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *InstrumentedDatabase) AddIPAddress(ctx context.Context, userId, ipAddr string) error {
+	start := time.Now()
+	err := db.database.AddIPAddress(ctx, userId, ipAddr)
+	db.observe("AddIPAddress", start, err, userId, ipAddr)
+
+	return err
+}
+
+func (db *InstrumentedDatabase) GetUserIpAddresses(ctx context.Context, userId string) ([]*models.WhitelistedIPModel, error) {
+	start := time.Now()
+	whitelistedIPs, err := db.database.GetUserIpAddresses(ctx, userId)
+	db.observe("GetUserIpAddresses", start, err, userId)
+
+	return whitelistedIPs, err
+}
+
+func (db *InstrumentedDatabase) DeleteIPAddress(ctx context.Context, ipAddrId, userId string) error {
+	start := time.Now()
+	err := db.database.DeleteIPAddress(ctx, ipAddrId, userId)
+	db.observe("DeleteIPAddress", start, err, ipAddrId, userId)
+
+	return err
+}
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"io/fs"
+	"sort"
+
+	"github.com/golang-migrate/migrate/v4/source"
+)
+
+// Migration describes a single schema migration that was bundled with the binary.
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// ReadMigrations lists the migrations in the given file system ordered from oldest to newest. Only the up files are
+// looked at so every version shows up once.
+func ReadMigrations(migrations fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrations, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Migration
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		migration, err := source.Parse(entry.Name())
+		if err != nil || migration.Direction != source.Up {
+			continue
+		}
+
+		list = append(list, Migration{
+			Version: migration.Version,
+			Name:    migration.Identifier,
+		})
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Version < list[j].Version
+	})
+
+	return list, nil
+}
+
+// LatestMigration returns the version of the newest migration in the list or 0 if the list is empty.
+func LatestMigration(migrations []Migration) uint {
+	if len(migrations) == 0 {
+		return 0
+	}
+
+	return migrations[len(migrations)-1].Version
+}
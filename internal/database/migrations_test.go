@@ -0,0 +1,11 @@
+package database
+
+import "testing"
+
+func TestReadMigrations(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestLatestMigration(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// AuditLogModel is a struct representation of the audit_log table.
+type AuditLogModel struct {
+	ID          string
+	ActorID     string
+	Action      string
+	TargetID    string
+	BeforeValue string
+	AfterValue  string
+	IPAddress   string
+	CreatedAt   time.Time
+}
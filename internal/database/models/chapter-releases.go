@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// ChapterReleaseModel is a struct representation of a chapter that has unlocked for a learner who hasn't been told
+// about it yet.
+type ChapterReleaseModel struct {
+	UserID       string
+	Name         string
+	Email        string
+	CourseTitle  string
+	CourseSlug   string
+	ChapterID    string
+	ChapterTitle string
+	ChapterSlug  string
+	ReleasedAt   time.Time
+}
+
+// ChapterReleaseNotificationModel is a struct representation of the chapter_release_notifications table.
+type ChapterReleaseNotificationModel struct {
+	ID        string
+	UserID    string
+	ChapterID string
+	CreatedAt time.Time
+}
@@ -1,17 +1,43 @@
 package models
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 // ChapterModel is a struct representation of the course_chapters table.
 type ChapterModel struct {
-	ID           string
-	Title        string
-	Slug         string
-	Chapter      int
-	Content      string
-	CourseID     string
-	FileChecksum string
-	FileKey      string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID               string
+	Title            string
+	Slug             string
+	Chapter          int
+	Content          string
+	TableOfContents  TableOfContents
+	WordCount        int
+	ReadingTime      int
+	CourseID         string
+	FileChecksum     string
+	FileKey          string
+	ReleaseAfterDays sql.NullInt32
+	ReleaseAt        sql.NullTime
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// ReleaseTime returns when the chapter becomes available to a learner who bought the course at the given time. A
+// chapter without a release schedule is available straight away.
+func (chapter *ChapterModel) ReleaseTime(purchasedAt time.Time) time.Time {
+	switch {
+	case chapter.ReleaseAt.Valid:
+		return chapter.ReleaseAt.Time
+	case chapter.ReleaseAfterDays.Valid:
+		return purchasedAt.AddDate(0, 0, int(chapter.ReleaseAfterDays.Int32))
+	default:
+		return purchasedAt
+	}
+}
+
+// IsReleased checks whether the chapter is available to a learner who bought the course at the given time.
+func (chapter *ChapterModel) IsReleased(purchasedAt, now time.Time) bool {
+	return !now.Before(chapter.ReleaseTime(purchasedAt))
 }
@@ -14,6 +14,7 @@ type CourseModel struct {
 	ThumbnailURL string
 	BannerURL    string
 	Content      string
+	ReadingTime  int
 	Published    bool
 	AuthorID     sql.NullString
 	FileChecksum string
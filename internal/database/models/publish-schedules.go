@@ -0,0 +1,18 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// PublishScheduleModel is a struct representation of a tutorial or course that is scheduled to be published or
+// unpublished.
+type PublishScheduleModel struct {
+	ID          string
+	Title       string
+	Slug        string
+	Published   bool
+	PublishAt   sql.NullTime
+	UnpublishAt sql.NullTime
+	UpdatedAt   time.Time
+}
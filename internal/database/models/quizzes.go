@@ -0,0 +1,179 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MultipleChoiceQuestion has a list of options of which exactly one is correct.
+	MultipleChoiceQuestion = "multiple_choice"
+
+	// MultipleAnswersQuestion has a list of options of which one or more are correct. All of them need to be picked.
+	MultipleAnswersQuestion = "multiple_answers"
+
+	// ShortTextQuestion gets answered by typing. The answer is compared to the accepted answers without paying
+	// attention to case or surrounding whitespace.
+	ShortTextQuestion = "short_text"
+)
+
+// QuizModel is a struct representation of the quizzes table along with its questions.
+type QuizModel struct {
+	ID        string
+	ChapterID string
+	PassMark  int
+	Questions []*QuizQuestionModel
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// QuizQuestionModel is a struct representation of the quiz_questions table.
+type QuizQuestionModel struct {
+	ID       string
+	QuizID   string
+	Position int
+	Kind     string
+	Question string
+	Options  QuizAnswers
+	Answers  QuizAnswers
+}
+
+// QuizAttemptModel is a struct representation of the quiz_attempts table.
+type QuizAttemptModel struct {
+	ID        string
+	QuizID    string
+	UserID    string
+	Score     int
+	Passed    bool
+	CreatedAt time.Time
+}
+
+// QuizAttemptAnswerModel is a struct representation of the quiz_attempt_answers table.
+type QuizAttemptAnswerModel struct {
+	ID         string
+	AttemptID  string
+	QuestionID string
+	Answer     QuizAnswers
+	Correct    bool
+}
+
+// QuizQuestionStatsModel holds how often a question has been answered and how many of those answers were correct.
+type QuizQuestionStatsModel struct {
+	QuestionID string
+	Position   int
+	Kind       string
+	Question   string
+	Answers    uint
+	Correct    uint
+}
+
+// QuizAnswers is a list of options or answers of a quiz question. It gets stored as JSON.
+type QuizAnswers []string
+
+// Scan implements the sql.Scanner interface.
+func (answers *QuizAnswers) Scan(src any) error {
+	var data []byte
+
+	switch value := src.(type) {
+	case nil:
+		*answers = nil
+		return nil
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		return fmt.Errorf("cannot scan %T into QuizAnswers", src)
+	}
+
+	return json.Unmarshal(data, answers)
+}
+
+// Value implements the driver.Valuer interface.
+func (answers QuizAnswers) Value() (driver.Value, error) {
+	if answers == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// IsCorrect reports whether the given answer to the question is correct. Options have to match exactly while short
+// text answers are compared without paying attention to case or surrounding whitespace.
+func (question *QuizQuestionModel) IsCorrect(answer []string) bool {
+	switch question.Kind {
+	case ShortTextQuestion:
+		if len(answer) != 1 {
+			return false
+		}
+
+		return slices.ContainsFunc(question.Answers, func(accepted string) bool {
+			return strings.EqualFold(strings.TrimSpace(accepted), strings.TrimSpace(answer[0]))
+		})
+
+	case MultipleChoiceQuestion, MultipleAnswersQuestion:
+		if len(answer) != len(question.Answers) {
+			return false
+		}
+
+		for _, option := range answer {
+			if !slices.Contains(question.Answers, option) {
+				return false
+			}
+		}
+
+		// Picking the same correct option twice doesn't make up for a missing one.
+		return len(slices.Compact(slices.Sorted(slices.Values(answer)))) == len(answer)
+	}
+
+	return false
+}
+
+// Grade marks the answers that were given to each question, keyed by question ID. It returns the percentage of
+// questions that were answered correctly, rounded down, whether that reaches the pass mark and the marked answers.
+func (quiz *QuizModel) Grade(answers map[string][]string) (int, bool, []*QuizAttemptAnswerModel) {
+	if len(quiz.Questions) == 0 {
+		return 0, false, nil
+	}
+
+	graded := make([]*QuizAttemptAnswerModel, 0, len(quiz.Questions))
+	correct := 0
+
+	for _, question := range quiz.Questions {
+		answer := answers[question.ID]
+		isCorrect := question.IsCorrect(answer)
+
+		if isCorrect {
+			correct++
+		}
+
+		graded = append(graded, &QuizAttemptAnswerModel{
+			QuestionID: question.ID,
+			Answer:     answer,
+			Correct:    isCorrect,
+		})
+	}
+
+	score := correct * 100 / len(quiz.Questions)
+
+	return score, score >= quiz.PassMark, graded
+}
+
+// CorrectPercentage returns the percentage of answers to the question that were correct, rounded down. A question that
+// hasn't been answered yet has a percentage of 0.
+func (stats *QuizQuestionStatsModel) CorrectPercentage() uint {
+	if stats.Answers == 0 {
+		return 0
+	}
+
+	return stats.Correct * 100 / stats.Answers
+}
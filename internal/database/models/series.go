@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// SeriesModel is a struct representation of the series table.
+type SeriesModel struct {
+	ID        string
+	Title     string
+	Slug      string
+	CreatedAt time.Time
+}
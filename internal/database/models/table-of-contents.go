@@ -0,0 +1,51 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Heading is a single entry in the table of contents of a tutorial or chapter.
+type Heading struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Level int    `json:"level"`
+}
+
+// TableOfContents is the list of headings in a tutorial or chapter, in the order that they appear. It gets stored as
+// JSON.
+type TableOfContents []Heading
+
+// Scan implements the sql.Scanner interface.
+func (toc *TableOfContents) Scan(src any) error {
+	var data []byte
+
+	switch value := src.(type) {
+	case nil:
+		*toc = nil
+		return nil
+	case string:
+		data = []byte(value)
+	case []byte:
+		data = value
+	default:
+		return fmt.Errorf("cannot scan %T into TableOfContents", src)
+	}
+
+	return json.Unmarshal(data, toc)
+}
+
+// Value implements the driver.Valuer interface.
+func (toc TableOfContents) Value() (driver.Value, error) {
+	if toc == nil {
+		return "[]", nil
+	}
+
+	data, err := json.Marshal(toc)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
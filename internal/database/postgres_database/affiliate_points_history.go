@@ -0,0 +1,84 @@
+package postgres_database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// RegisterAffiliatePointsChange adds a new row to the affiliate_points_history table.
+func (db *PostgresDatabase) RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for affiliate points history: %s\n", err)
+		return err
+	}
+
+	if err := internal.RegisterAffiliatePointsChange(ctx, db.connection, id, userId, courseId, pointsChange, reason); err != nil {
+		db.ErrorLog.Printf("Failed to save affiliate point change to database: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// CountUserAffiliateHistory counts all the times the given user's affiliate code was used.
+func (db *PostgresDatabase) CountUserAffiliateHistory(ctx context.Context, userId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM affiliate_points_history WHERE user_id = $1;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, userId)
+	if err := row.Scan(&count); err != nil {
+		db.ErrorLog.Printf("Failed to count the number of affiliate_points_history rows connected to user (\"%s\"): %s\n", userId, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetUserAffiliatePointsHistory gets a slice of AffiliatePointsHistoryModel for a given user.
+func (db *PostgresDatabase) GetUserAffiliatePointsHistory(ctx context.Context, userId string, cursor *database.Cursor, elements uint) ([]*models.AffiliatePointsHistoryModel, *database.Cursor, error) {
+	query := `SELECT h.id, h.user_id, h.course_id, h.points_change, h.reason, h.created_at FROM affiliate_points_history AS h WHERE h.user_id = $1`
+	args := []any{userId}
+
+	condition, cursorArgs := internal.AfterCursor("h", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY h.created_at DESC, h.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var history []*models.AffiliatePointsHistoryModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get user's (\"%s\") affiliate points history: %s\n", userId, err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var h models.AffiliatePointsHistoryModel
+
+		if err := rows.Scan(&h.ID, &h.UserID, &h.CourseID, &h.PointsChange, &h.Reason, &h.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from affiliate_points_history table: %s\n", err)
+			return nil, nil, err
+		}
+
+		history = append(history, &h)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get user's (\"%s\") affiliate points history: %s\n", userId, err)
+		return nil, nil, err
+	}
+
+	history, next := database.Paginate(history, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(history[i].CreatedAt, history[i].ID)
+	})
+
+	return history, next, nil
+}
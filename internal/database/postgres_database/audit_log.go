@@ -0,0 +1,114 @@
+package postgres_database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// AddAuditLog records a privileged action in the audit log.
+func (db *PostgresDatabase) AddAuditLog(ctx context.Context, actorId string, action database.AuditAction, targetId, before, after, ipAddr string) error {
+	query := `INSERT INTO audit_log (id, actor_id, action, target_id, before_value, after_value, ip_address) VALUES ($1, $2, $3, $4, $5, $6, $7);`
+
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new audit log entry: %s\n", err)
+		return err
+	}
+
+	result, err := db.connection.ExecContext(ctx, query, id, actorId, action.String(), targetId, before, after, ipAddr)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to insert new audit log entry into the database: %s\n", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after inserting new audit log entry: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Println("No rows were affected after inserting new audit log entry")
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// AdminGetAuditLogs gets a paginated list of audit log entries for the admin panel. The search term gets matched
+// against the entry's ID, target, values and IP address as well as the name and email of the admin who took the action.
+func (db *PostgresDatabase) AdminGetAuditLogs(ctx context.Context, term, action, actorId string, cursor *database.Cursor, elements uint) ([]*models.AuditLogModel, *database.Cursor, error) {
+	query := `SELECT a.id, a.actor_id, a.action, a.target_id, a.before_value, a.after_value, a.ip_address, a.created_at FROM audit_log AS a LEFT JOIN users AS u ON u.id = a.actor_id WHERE (a.id ILIKE '%' || $1 || '%' OR a.target_id ILIKE '%' || $2 || '%' OR a.before_value ILIKE '%' || $3 || '%' OR a.after_value ILIKE '%' || $4 || '%' OR a.ip_address ILIKE '%' || $5 || '%' OR (u.name || ' ' || u.surname) ILIKE '%' || $6 || '%' OR u.email ILIKE '%' || $7 || '%')`
+
+	args := []any{term, term, term, term, term, term, term}
+
+	if action != "" {
+		query += fmt.Sprintf(" AND a.action = $%d", len(args)+1)
+		args = append(args, action)
+	}
+
+	if actorId != "" {
+		query += fmt.Sprintf(" AND a.actor_id = $%d", len(args)+1)
+		args = append(args, actorId)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("a", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY a.created_at DESC, a.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var entries []*models.AuditLogModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get audit log entries from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var entry models.AuditLogModel
+
+		if err := rows.Scan(&entry.ID, &entry.ActorID, &entry.Action, &entry.TargetID, &entry.BeforeValue, &entry.AfterValue, &entry.IPAddress, &entry.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from audit_log table: %s\n", err)
+			return nil, nil, err
+		}
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get audit log entries from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	entries, next := database.Paginate(entries, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(entries[i].CreatedAt, entries[i].ID)
+	})
+
+	return entries, next, nil
+}
+
+// CountAuditLogs counts the number of entries in the audit log.
+func (db *PostgresDatabase) CountAuditLogs(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM audit_log;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		db.ErrorLog.Printf("Failed to count all audit log entries in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,99 @@
+package postgres_database
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// GetDueChapterReleases gets every chapter that has unlocked for a learner who hasn't been told about it yet, oldest
+// release first. Chapters that were already available when the course was bought are left out because there is
+// nothing for the learner to wait for. So are releases from before the chapter's release rule was set, otherwise adding
+// a rule to a chapter would email every learner whose release date has already passed.
+func (db *PostgresDatabase) GetDueChapterReleases(ctx context.Context) ([]*models.ChapterReleaseModel, error) {
+	query := `SELECT u.id, u.name, u.email, c.title, c.slug, cc.id, cc.title, cc.slug FROM (SELECT p.user_id, cc.id AS chapter_id, MIN(p.created_at) AS purchased_at, COALESCE(cc.release_at, MIN(p.created_at) + cc.release_after_days * INTERVAL '1 day') AS released_at FROM course_purchases AS p JOIN course_chapters AS cc ON cc.course_id = p.course_id WHERE p.payment_status = $1 AND (cc.release_at IS NOT NULL OR cc.release_after_days IS NOT NULL) GROUP BY p.user_id, cc.id) AS r JOIN users AS u ON u.id = r.user_id JOIN course_chapters AS cc ON cc.id = r.chapter_id JOIN courses AS c ON c.id = cc.course_id WHERE r.released_at > r.purchased_at AND r.released_at >= cc.release_set_at AND r.released_at <= CURRENT_TIMESTAMP AND u.deleted_at IS NULL AND c.published = 1 AND c.deleted_at IS NULL AND NOT EXISTS (SELECT 1 FROM chapter_release_notifications AS n WHERE n.user_id = r.user_id AND n.chapter_id = r.chapter_id) ORDER BY r.released_at ASC, u.id ASC, cc.chapter ASC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, database.Succeeded.String())
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for due chapter releases: %s\n", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var releases []*models.ChapterReleaseModel
+
+	for rows.Next() {
+		var release models.ChapterReleaseModel
+
+		if err := rows.Scan(&release.UserID, &release.Name, &release.Email, &release.CourseTitle, &release.CourseSlug, &release.ChapterID, &release.ChapterTitle, &release.ChapterSlug); err != nil {
+			db.ErrorLog.Printf("Failed to scan chapter release row from the database: %s\n", err)
+			return nil, err
+		}
+
+		releases = append(releases, &release)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all chapter release rows: %s\n", err)
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// AddChapterReleaseNotification records that a user has been told that a chapter unlocked for them.
+// database.ErrChapterReleaseAlreadyNotified is returned if the user has already been told.
+func (db *PostgresDatabase) AddChapterReleaseNotification(ctx context.Context, userId, chapterId string) error {
+	query := `INSERT INTO chapter_release_notifications (id, user_id, chapter_id) VALUES ($1, $2, $3);`
+
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new chapter release notification: %s\n", err)
+		return err
+	}
+
+	if _, err := db.connection.ExecContext(ctx, query, id, userId, chapterId); err != nil {
+		if internal.IsUniqueViolation(err) {
+			return database.ErrChapterReleaseAlreadyNotified
+		}
+
+		db.ErrorLog.Printf("Failed to record that user (\"%s\") was notified about the release of chapter (\"%s\"): %s\n", userId, chapterId, err)
+		return err
+	}
+
+	return nil
+}
+
+// GetUserChapterReleaseNotifications gets every chapter release that a user has been told about, newest first.
+func (db *PostgresDatabase) GetUserChapterReleaseNotifications(ctx context.Context, userId string) ([]*models.ChapterReleaseNotificationModel, error) {
+	query := `SELECT id, user_id, chapter_id, created_at FROM chapter_release_notifications WHERE user_id = $1 ORDER BY created_at DESC, id DESC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for user's (\"%s\") chapter release notifications: %s\n", userId, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*models.ChapterReleaseNotificationModel
+
+	for rows.Next() {
+		var notification models.ChapterReleaseNotificationModel
+
+		if err := rows.Scan(&notification.ID, &notification.UserID, &notification.ChapterID, &notification.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to scan chapter_release_notifications row from the database: %s\n", err)
+			return nil, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all chapter_release_notifications rows: %s\n", err)
+		return nil, err
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,309 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// AdminGetComments gets a paginated list of all comments for the admin panel.
+func (db *PostgresDatabase) AdminGetComments(ctx context.Context, term, tutorialId, userId string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CommentModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.content, c.user_id, c.tutorial_id, c.created_at FROM comments AS c WHERE (LOWER(c.id) ILIKE '%' || $1 || '%' OR LOWER(c.content) ILIKE '%' || $2 || '%')`
+
+	args := []any{term, term}
+
+	if deleted {
+		query += " AND c.deleted_at IS NOT NULL"
+	} else {
+		query += " AND c.deleted_at IS NULL"
+	}
+
+	if tutorialId != "" {
+		query += fmt.Sprintf(" AND c.tutorial_id = $%d", len(args)+1)
+		args = append(args, tutorialId)
+	}
+
+	if userId != "" {
+		query += fmt.Sprintf(" AND c.user_id = $%d", len(args)+1)
+		args = append(args, userId)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("c", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY c.created_at DESC, c.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var comments []*models.CommentModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all comments from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var comment models.CommentModel
+
+		if err := rows.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from comments table: %s\n", err)
+			return nil, nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all comments from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	comments, next := database.Paginate(comments, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(comments[i].CreatedAt, comments[i].ID)
+	})
+
+	return comments, next, nil
+}
+
+// GetAllCommentsPaginated gets a paginated list of comments for a given tutorial by ID.
+func (db *PostgresDatabase) GetAllCommentsPaginated(ctx context.Context, tutorialId string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.content, c.user_id, c.tutorial_id, c.created_at FROM comments AS c WHERE c.tutorial_id = $1 AND c.deleted_at IS NULL`
+	args := []any{tutorialId}
+
+	condition, cursorArgs := internal.AfterCursor("c", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY c.created_at DESC, c.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var comments []*models.CommentModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return comments, nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get comments from the database: %s\n", err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var comment models.CommentModel
+
+		if err := rows.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read individual row from comments table: %s\n", err)
+			return nil, nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	if rows.Err() != nil {
+		db.ErrorLog.Printf("Got an error after reading rows from comments table: %s\n", err)
+		return nil, nil, err
+	}
+
+	comments, next := database.Paginate(comments, elements, func(i int) *database.Cursor {
+		return database.NewCursor(comments[i].CreatedAt, comments[i].ID)
+	})
+
+	return comments, next, nil
+}
+
+// GetAllCommentsBySlugPaginated gets a paginated list of comments for a given tutorial by slug.
+func (db *PostgresDatabase) GetAllCommentsBySlugPaginated(ctx context.Context, slug string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.content, c.user_id, c.tutorial_id, c.created_at FROM comments AS c WHERE c.tutorial_id = (SELECT id FROM tutorials WHERE slug = $1) AND c.deleted_at IS NULL`
+	args := []any{slug}
+
+	condition, cursorArgs := internal.AfterCursor("c", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY c.created_at DESC, c.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var comments []*models.CommentModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return comments, nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get comments from the database: %s\n", err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var comment models.CommentModel
+
+		if err := rows.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read individual row from comments table: %s\n", err)
+			return nil, nil, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	if rows.Err() != nil {
+		db.ErrorLog.Printf("Got an error after reading rows from comments table: %s\n", err)
+		return nil, nil, err
+	}
+
+	comments, next := database.Paginate(comments, elements, func(i int) *database.Cursor {
+		return database.NewCursor(comments[i].CreatedAt, comments[i].ID)
+	})
+
+	return comments, next, nil
+}
+
+// CountCommentsForTutorial counts the number of comments a given tutorial has.
+func (db *PostgresDatabase) CountCommentsForTutorial(ctx context.Context, tutorialId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM comments WHERE tutorial_id = $1 AND deleted_at IS NULL;`
+
+	var comments uint
+
+	row := db.connection.QueryRowContext(ctx, query, tutorialId)
+	if err := row.Scan(&comments); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count all comments related to tutorial \"%s\": %s\n", tutorialId, err)
+		return 0, err
+	}
+
+	return comments, nil
+}
+
+// AddCommentBySlug adds a comment to a tutorial by the tutorial slug.
+func (db *PostgresDatabase) AddCommentBySlug(ctx context.Context, content, userId, slug string) (*models.CommentModel, error) {
+	query := `INSERT INTO comments (id, content, user_id, tutorial_id, created_at) VALUES ($1, $2, $3, (SELECT id FROM tutorials WHERE slug = $4), $5);`
+
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new comment: %s\n", err)
+		return nil, err
+	}
+
+	comment := models.CommentModel{
+		ID:         id,
+		Content:    content,
+		UserID:     userId,
+		TutorialID: "",
+		CreatedAt:  time.Now(),
+	}
+
+	result, err := db.connection.ExecContext(ctx, query, comment.ID, comment.Content, comment.UserID, slug, comment.CreatedAt)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to insert new comment in comments table: %s\n", err)
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to read the rows affected after inserting new comment in comments table: %s\n", err)
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Println("No rows were affected after inserting new comment into comments table")
+		return nil, database.ErrNoRowsAffected
+	}
+
+	return &comment, nil
+}
+
+// CountComments counts the number of comments in the database.
+func (db *PostgresDatabase) CountComments(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM comments WHERE deleted_at IS NULL;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		db.ErrorLog.Printf("Failed to count all comments in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// DeleteComment soft deletes a comment by it's ID.
+func (db *PostgresDatabase) DeleteComment(ctx context.Context, commentId string) error {
+	query := `UPDATE comments SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, commentId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete comment (\"%s\"): %s\n", commentId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get the rows affected after deleting comment: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("No rows were affected after deleting comment (\"%s\")\n", commentId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// GetCommentByID gets a comment by it's ID. Soft deleted comments are included so that they can be restored.
+func (db *PostgresDatabase) GetCommentByID(ctx context.Context, commentId string) (*models.CommentModel, error) {
+	query := `SELECT id, content, user_id, tutorial_id, created_at FROM comments WHERE id = $1;`
+
+	var comment models.CommentModel
+
+	row := db.connection.QueryRowContext(ctx, query, commentId)
+	if err := row.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get comment (\"%s\") from the database: %s\n", commentId, err)
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// RestoreComment brings back a soft deleted comment.
+func (db *PostgresDatabase) RestoreComment(ctx context.Context, commentId string) error {
+	query := `UPDATE comments SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, commentId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to restore comment (\"%s\"): %s\n", commentId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get the rows affected after restoring comment: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("No rows were affected after restoring comment (\"%s\")\n", commentId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
@@ -0,0 +1,388 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// AdminGetCoursePurchases retrieves all course purchases according to the search parameters in a paginated fashion.
+func (db *PostgresDatabase) AdminGetCoursePurchases(ctx context.Context, term string, courseId string, authorId string, status string, cursor *database.Cursor, elements uint) ([]*models.CoursePurchaseModel, *database.Cursor, error) {
+	query := "SELECT cp.id, cp.user_id, cp.course_id, cp.payment_key, cp.stripe_checkout_session_id, cp.affiliate_code, cp.discount_code, cp.affiliate_points_used, cp.amount_paid, cp.payment_status, cp.created_at, cp.updated_at FROM course_purchases AS cp LEFT JOIN users AS u ON cp.user_id = u.id LEFT JOIN courses AS c ON cp.course_id = c.id WHERE 1=1"
+	var args []any
+
+	if term != "" {
+		query += fmt.Sprintf(" AND (LOWER(cp.id) ILIKE '%%' || $%d || '%%' OR LOWER(cp.payment_key) ILIKE '%%' || $%d || '%%' OR LOWER(cp.stripe_checkout_session_id) ILIKE '%%' || $%d || '%%' OR LOWER(cp.affiliate_code) ILIKE '%%' || $%d || '%%' OR LOWER(cp.discount_code) ILIKE '%%' || $%d || '%%' OR LOWER(u.name) ILIKE '%%' || $%d || '%%' OR LOWER(u.surname) ILIKE '%%' || $%d || '%%' OR LOWER(c.title) ILIKE '%%' || $%d || '%%')", len(args)+1, len(args)+2, len(args)+3, len(args)+4, len(args)+5, len(args)+6, len(args)+7, len(args)+8)
+		args = append(args, term, term, term, term, term, term, term, term)
+	}
+
+	if courseId != "" {
+		query += fmt.Sprintf(" AND cp.course_id = $%d", len(args)+1)
+		args = append(args, courseId)
+	}
+
+	if authorId != "" {
+		query += fmt.Sprintf(" AND c.author_id = $%d", len(args)+1)
+		args = append(args, authorId)
+	}
+
+	if status != "" {
+		query += fmt.Sprintf(" AND cp.payment_status = $%d", len(args)+1)
+		args = append(args, status)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("cp", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY cp.created_at DESC, cp.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var coursePurchases []*models.CoursePurchaseModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all course purchases from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var coursePurchase models.CoursePurchaseModel
+
+		if err := rows.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from course purchases table: %s\n", err)
+			return nil, nil, err
+		}
+
+		coursePurchases = append(coursePurchases, &coursePurchase)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all course purchases from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	coursePurchases, next := database.Paginate(coursePurchases, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(coursePurchases[i].CreatedAt, coursePurchases[i].ID)
+	})
+
+	return coursePurchases, next, nil
+}
+
+// HasUserPurchasedCourse checks to see if a course has been purchased by a user.
+func (db *PostgresDatabase) HasUserPurchasedCourse(ctx context.Context, userId, courseId string) (bool, error) {
+	b, err := internal.HasUserPurchasedCourse(ctx, db.connection, userId, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to check if user (\"%s\") has purchased course (\"%s\"): %s\n", userId, courseId, err)
+
+		return false, err
+	}
+
+	return b, nil
+}
+
+func (db *PostgresDatabase) RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+	purchaseId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new course purchase: %s\n", err)
+		return err
+	}
+
+	affiliateHistoryId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new affiliate points history: %s\n", err)
+		return err
+	}
+
+	paymentTokenId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new payment token: %s\n", err)
+		return err
+	}
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	if err := internal.RegisterCoursePurchase(ctx, tx, purchaseId, affiliateHistoryId, paymentTokenId, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		if err != database.ErrCourseAlreadyOwned && err != database.ErrInsufficientAffiliatePoints {
+			db.ErrorLog.Printf("Failed to register course purchase: %s\n", err)
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after registering course purchase: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) CountAllPurchases(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM course_purchases;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count all course purchases: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) CountCoursesWhereDiscountWasUsed(ctx context.Context, discountCode string) (uint, error) {
+	query := `SELECT COUNT(id) FROM course_purchases WHERE discount_code = $1;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, discountCode)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number of courses bought using discount code (\"%s\"): %s\n", discountCode, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) CountUsersWhoBoughtCourse(ctx context.Context, courseId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM course_purchases WHERE course_id = $1;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, courseId)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number times course \"%s\" was purchased: %s\n", courseId, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error) {
+	coursePurchase, err := internal.GetCoursePurchaseByPaymentKey(ctx, db.connection, paymentKey)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to find course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+		return nil, err
+	}
+
+	return coursePurchase, nil
+}
+
+func (db *PostgresDatabase) GetCoursePurchaseByID(ctx context.Context, coursePurchaseId string) (*models.CoursePurchaseModel, error) {
+	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE id = $1;`
+
+	var coursePurchase models.CoursePurchaseModel
+
+	row := db.connection.QueryRowContext(ctx, query, coursePurchaseId)
+	if err := row.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to find course purchase by ID (\"%s\"): %s\n", coursePurchaseId, err)
+		return nil, err
+	}
+
+	return &coursePurchase, nil
+}
+
+func (db *PostgresDatabase) GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	coursePurchase, err := internal.GetCoursePurchaseByCheckoutSession(ctx, db.connection, checkoutSessionId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to find course purchase by stripe checkout session ID (\"%s\"): %s\n", checkoutSessionId, err)
+		return nil, err
+	}
+
+	return coursePurchase, nil
+}
+
+func (db *PostgresDatabase) GetCourseByCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.CourseModel, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp LEFT JOIN courses AS c ON cp.course_id = c.id WHERE cp.id = $1;`
+
+	var course models.CourseModel
+	var published int
+
+	row := db.connection.QueryRowContext(ctx, query, coursePurchaseId)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get course from course purchase model (\"%s\"): %s\n", coursePurchaseId, err)
+		return nil, err
+	}
+
+	course.Published = published == 1
+
+	return &course, nil
+}
+
+func (db *PostgresDatabase) UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status database.PaymentStatus) error {
+	if err := internal.UpdateCoursePurchasePaymentStatus(ctx, db.connection, coursePurchaseId, status); err != nil {
+		db.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	changed, err := internal.TransitionCoursePurchasePaymentStatus(ctx, db.connection, coursePurchaseId, from, to)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
+		return false, err
+	}
+
+	return changed, nil
+}
+
+func (db *PostgresDatabase) GetCoursesBoughtByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at, cp.created_at, cp.id FROM course_purchases AS cp JOIN courses AS c ON cp.course_id = c.id WHERE cp.user_id = $1 AND cp.payment_status = $2 AND c.published = 1 AND c.deleted_at IS NULL`
+	args := []any{userId, database.Succeeded.String()}
+
+	if term != "" {
+		query += fmt.Sprintf(" AND (LOWER(c.title) ILIKE '%%' || $%d || '%%' OR LOWER(c.slug) ILIKE '%%' || $%d || '%%' OR LOWER(c.description) ILIKE '%%' || $%d || '%%')", len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, term, term, term)
+	}
+
+	// The courses are ordered by when they were bought so the cursor points at the purchase rather than the course.
+	condition, cursorArgs := internal.AfterCursor("cp", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY cp.created_at DESC, cp.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var courses []*models.CourseModel
+	var purchases []*database.Cursor
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var course models.CourseModel
+		var published int
+		var purchase database.Cursor
+
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt, &purchase.CreatedAt, &purchase.ID); err != nil {
+			db.ErrorLog.Printf("Failed to read course from the database: %s\n", err)
+			return nil, nil, err
+		}
+
+		course.Published = published == 1
+
+		courses = append(courses, &course)
+		purchases = append(purchases, &purchase)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
+		return nil, nil, err
+	}
+
+	courses, next := database.Paginate(courses, int(elements), func(i int) *database.Cursor {
+		return purchases[i]
+	})
+
+	return courses, next, nil
+}
+
+func (db *PostgresDatabase) GetAllCoursesBoughtByUser(ctx context.Context, userId string) ([]*models.CourseModel, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp LEFT JOIN courses AS c ON cp.course_id = c.id WHERE cp.user_id = $1 AND cp.payment_status = 'Succeeded' ORDER BY cp.updated_at DESC;`
+
+	var courses []*models.CourseModel
+
+	rows, err := db.connection.QueryContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var course models.CourseModel
+		var published int
+
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read course from the database: %s\n", err)
+			return nil, err
+		}
+
+		course.Published = published == 1
+
+		courses = append(courses, &course)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
+		return nil, err
+	}
+
+	return courses, nil
+}
+
+func (db *PostgresDatabase) GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	coursePurchases, err := internal.GetCoursePurchasesByUserAndCourse(ctx, db.connection, userId, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get course purchases for user (\"%s\") and course (\"%s\"): %s\n", userId, courseId, err)
+		return nil, err
+	}
+
+	return coursePurchases, nil
+}
+
+// GetCoursePurchaseDate gets when the user first bought the course. The returned time is invalid if the user hasn't
+// bought the course.
+func (db *PostgresDatabase) GetCoursePurchaseDate(ctx context.Context, userId, courseId string) (sql.NullTime, error) {
+	query := `SELECT created_at FROM course_purchases WHERE user_id = $1 AND course_id = $2 AND payment_status = $3 ORDER BY created_at ASC LIMIT 1;`
+
+	var purchasedAt sql.NullTime
+
+	if err := db.connection.QueryRowContext(ctx, query, userId, courseId, database.Succeeded.String()).Scan(&purchasedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return sql.NullTime{}, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get the date that user (\"%s\") bought course (\"%s\"): %s\n", userId, courseId, err)
+		return sql.NullTime{}, err
+	}
+
+	return purchasedAt, nil
+}
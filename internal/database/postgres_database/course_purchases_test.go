@@ -0,0 +1,160 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func TestRegisterCoursePurchase(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+	addTestCourse(t, postgresDatabase, "course")
+
+	if err := postgresDatabase.RegisterCoursePurchase(ctx, user.ID, "course", "key", "session", sql.NullString{}, sql.NullString{}, 0, 10, "token", "payment", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to register course purchase: %s", err)
+	}
+
+	purchase, err := postgresDatabase.GetCoursePurchaseByPaymentKey(ctx, "key")
+	if err != nil || purchase == nil {
+		t.Fatalf("Failed to get course purchase by payment key: %v", err)
+	}
+
+	if purchase.PaymentStatus != database.Pending.String() {
+		t.Errorf("Expected a new course purchase to be %q. Got %q", database.Pending, purchase.PaymentStatus)
+	}
+
+	if purchased, err := postgresDatabase.HasUserPurchasedCourse(ctx, user.ID, "course"); err != nil || purchased {
+		t.Errorf("Expected a pending purchase to not count as owning the course. Got %t (%v)", purchased, err)
+	}
+
+	if _, err := postgresDatabase.TransitionCoursePurchasePaymentStatus(ctx, purchase.ID, database.OpenPaymentStatuses, database.Succeeded); err != nil {
+		t.Fatalf("Failed to update course purchase payment status: %s", err)
+	}
+
+	if purchased, err := postgresDatabase.HasUserPurchasedCourse(ctx, user.ID, "course"); err != nil || !purchased {
+		t.Errorf("Expected a successful purchase to count as owning the course. Got %t (%v)", purchased, err)
+	}
+
+	if err := postgresDatabase.RegisterCoursePurchase(ctx, user.ID, "course", "other-key", "other-session", sql.NullString{}, sql.NullString{}, 0, 10, "", "payment", time.Now().Add(time.Hour)); !errors.Is(err, database.ErrCourseAlreadyOwned) {
+		t.Errorf("Expected buying an owned course to be rejected. Got %v", err)
+	}
+
+	if err := postgresDatabase.RegisterCoursePurchase(ctx, user.ID, "missing", "missing-key", "missing-session", sql.NullString{}, sql.NullString{}, 0, 10, "", "payment", time.Now().Add(time.Hour)); err == nil {
+		t.Error("Expected buying a course that doesn't exist to fail")
+	}
+}
+
+func TestTransitionCoursePurchasePaymentStatus(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+	addTestCourse(t, postgresDatabase, "course")
+
+	if err := postgresDatabase.RegisterCoursePurchase(ctx, user.ID, "course", "key", "session", sql.NullString{}, sql.NullString{}, 0, 10, "", "payment", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to register course purchase: %s", err)
+	}
+
+	purchase, err := postgresDatabase.GetCoursePurchaseByPaymentKey(ctx, "key")
+	if err != nil || purchase == nil {
+		t.Fatalf("Failed to get course purchase by payment key: %v", err)
+	}
+
+	changed, err := postgresDatabase.TransitionCoursePurchasePaymentStatus(ctx, purchase.ID, database.OpenPaymentStatuses, database.Succeeded)
+	if err != nil {
+		t.Fatalf("Failed to transition course purchase payment status: %s", err)
+	}
+
+	if !changed {
+		t.Error("Expected the pending purchase to move to succeeded")
+	}
+
+	changed, err = postgresDatabase.TransitionCoursePurchasePaymentStatus(ctx, purchase.ID, database.OpenPaymentStatuses, database.Cancelled)
+	if err != nil {
+		t.Fatalf("Failed to transition course purchase payment status: %s", err)
+	}
+
+	if changed {
+		t.Error("Expected the settled purchase to be left alone")
+	}
+
+	purchase, err = postgresDatabase.GetCoursePurchaseByID(ctx, purchase.ID)
+	if err != nil || purchase == nil {
+		t.Fatalf("Failed to get course purchase by ID: %v", err)
+	}
+
+	if purchase.PaymentStatus != database.Succeeded.String() {
+		t.Errorf("Expected the course purchase to be %q. Got %q", database.Succeeded, purchase.PaymentStatus)
+	}
+}
+
+func TestGetCoursesBoughtByUser(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+
+	for _, id := range []string{"first", "second", "third"} {
+		addTestCourse(t, postgresDatabase, id)
+
+		if err := postgresDatabase.RegisterCoursePurchase(ctx, user.ID, id, id, id, sql.NullString{}, sql.NullString{}, 0, 10, "", "payment", time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("Failed to register course purchase: %s", err)
+		}
+
+		purchase, err := postgresDatabase.GetCoursePurchaseByPaymentKey(ctx, id)
+		if err != nil || purchase == nil {
+			t.Fatalf("Failed to get course purchase by payment key: %v", err)
+		}
+
+		if _, err := postgresDatabase.TransitionCoursePurchasePaymentStatus(ctx, purchase.ID, database.OpenPaymentStatuses, database.Succeeded); err != nil {
+			t.Fatalf("Failed to update course purchase payment status: %s", err)
+		}
+	}
+
+	if _, err := postgresDatabase.connection.ExecContext(ctx, `UPDATE courses SET published = 1;`); err != nil {
+		t.Fatalf("Failed to publish courses: %s", err)
+	}
+
+	courses, next, err := postgresDatabase.GetCoursesBoughtByUser(ctx, "", user.ID, nil, 2)
+	if err != nil {
+		t.Fatalf("Failed to get first page of courses bought by user: %s", err)
+	}
+
+	if len(courses) != 2 || next == nil {
+		t.Fatalf("Expected a full first page with a cursor to the next one. Got %d courses", len(courses))
+	}
+
+	rest, next, err := postgresDatabase.GetCoursesBoughtByUser(ctx, "", user.ID, next, 2)
+	if err != nil {
+		t.Fatalf("Failed to get second page of courses bought by user: %s", err)
+	}
+
+	if len(rest) != 1 || next != nil {
+		t.Fatalf("Expected the last course on the second page. Got %d courses", len(rest))
+	}
+
+	seen := make(map[string]bool)
+	for _, course := range append(courses, rest...) {
+		seen[course.ID] = true
+	}
+
+	if len(seen) != 3 {
+		t.Errorf("Expected every course to show up once. Got %d distinct courses", len(seen))
+	}
+
+	courses, _, err = postgresDatabase.GetCoursesBoughtByUser(ctx, "second", user.ID, nil, 2)
+	if err != nil {
+		t.Fatalf("Failed to search courses bought by user: %s", err)
+	}
+
+	if len(courses) != 1 || courses[0].ID != "second" {
+		t.Errorf("Expected the search to only match the second course. Got %d courses", len(courses))
+	}
+}
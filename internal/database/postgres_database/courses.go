@@ -0,0 +1,506 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) AdminGetCourses(ctx context.Context, term string, published *bool, authorId *string, boughtBy, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	query := `SELECT DISTINCT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM courses AS c LEFT JOIN course_purchases AS cp ON cp.course_id = c.id LEFT JOIN courses_keywords AS ck ON ck.course_id = c.id LEFT JOIN keywords AS k ON k.id = ck.keyword_id WHERE 1=1`
+	args := []any{}
+
+	if deleted {
+		query += " AND c.deleted_at IS NOT NULL"
+	} else {
+		query += " AND c.deleted_at IS NULL"
+	}
+
+	if term != "" {
+		if match := internal.FullTextQuery(term); match != "" {
+			query += fmt.Sprintf(" AND (c.id = $%d OR c.id IN (SELECT id FROM courses_fts WHERE document @@ to_tsquery('english', $%d)))", len(args)+1, len(args)+2)
+			args = append(args, term, match)
+		} else {
+			query += fmt.Sprintf(" AND c.id = $%d", len(args)+1)
+			args = append(args, term)
+		}
+	}
+
+	if published != nil {
+		query += fmt.Sprintf(" AND c.published = $%d", len(args)+1)
+
+		var pubInt int
+		if *published {
+			pubInt = 1
+		} else {
+			pubInt = 0
+		}
+
+		args = append(args, pubInt)
+	}
+
+	if authorId != nil {
+		if *authorId != "" {
+			query += fmt.Sprintf(" AND c.author_id = $%d", len(args)+1)
+			args = append(args, *authorId)
+		}
+	} else {
+		query += " AND c.author_id IS NULL"
+	}
+
+	if boughtBy != "" {
+		query += fmt.Sprintf(" AND cp.user_id = $%d", len(args)+1)
+		args = append(args, boughtBy)
+	}
+
+	if keyword != "" {
+		query += fmt.Sprintf(" AND k.keyword ILIKE '%%' || $%d || '%%'", len(args)+1)
+		args = append(args, keyword)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("c", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY c.created_at DESC, c.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var courses []*models.CourseModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all courses from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var course models.CourseModel
+		var published int
+
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from courses table: %s\n", err)
+			return nil, nil, err
+		}
+
+		course.Published = published == 1
+
+		courses = append(courses, &course)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all courses from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	courses, next := database.Paginate(courses, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(courses[i].CreatedAt, courses[i].ID)
+	})
+
+	return courses, next, nil
+}
+
+func (db *PostgresDatabase) GetAllCourses(ctx context.Context, authorId string, published *bool) ([]*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE 1=1`
+	args := []any{}
+
+	if authorId != "" {
+		query += fmt.Sprintf(" AND author_id = $%d", len(args)+1)
+		args = append(args, authorId)
+	}
+
+	// Soft deleted courses are only left out when filtering on their publish status because the content command
+	// still needs to find them by file key.
+	if published != nil {
+		query += fmt.Sprintf(" AND deleted_at IS NULL AND published = $%d", len(args)+1)
+
+		if *published {
+			query += " AND author_id IN (SELECT id FROM users WHERE deleted_at IS NULL)"
+			args = append(args, 1)
+		} else {
+			args = append(args, 0)
+		}
+	}
+
+	query += " ORDER BY updated_at DESC, title ASC;"
+
+	var courses []*models.CourseModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for all courses: %s\n", err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var course models.CourseModel
+		var published int
+
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from courses table: %s\n", err)
+			return nil, err
+		}
+
+		course.Published = published == 1
+
+		courses = append(courses, &course)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to query database for all courses: %s\n", err)
+		return nil, err
+	}
+
+	return courses, nil
+}
+
+func (db *PostgresDatabase) GetCourses(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.CourseModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at`
+	args := []any{}
+
+	match := internal.FullTextQuery(term)
+	rank := "ts_rank(courses_fts.document, to_tsquery('english', $1))"
+	if match != "" {
+		query += ", " + rank + " FROM courses AS c JOIN courses_fts ON courses_fts.id = c.id WHERE courses_fts.document @@ to_tsquery('english', $1) AND"
+		args = append(args, match)
+	} else {
+		query += ", 0.0 FROM courses AS c WHERE"
+	}
+
+	query += " c.published = 1 AND c.author_id IN (SELECT id FROM users WHERE deleted_at IS NULL) AND c.deleted_at IS NULL"
+
+	if authorId != "" {
+		query += fmt.Sprintf(" AND c.author_id = $%d", len(args)+1)
+		args = append(args, authorId)
+	}
+
+	// Search results are ordered by relevance so the cursor needs to keep track of the rank of the last result.
+	if match != "" {
+		condition, cursorArgs := internal.AfterRankedCursor(rank, "c", cursor, len(args)+1)
+		query += condition + " ORDER BY " + rank + " DESC, c.id DESC"
+		args = append(args, cursorArgs...)
+	} else {
+		condition, cursorArgs := internal.AfterCursor("c", cursor, len(args)+1)
+		query += condition + " ORDER BY c.created_at DESC, c.id DESC"
+		args = append(args, cursorArgs...)
+	}
+
+	query += fmt.Sprintf(" LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var courses []*models.CourseModel
+	var ranks []float64
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for the next page of courses: %s\n", err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var course models.CourseModel
+		var published int
+		var rank float64
+
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt, &rank); err != nil {
+			db.ErrorLog.Printf("Failed to read row from courses table: %s\n", err)
+			return nil, nil, err
+		}
+
+		course.Published = published == 1
+
+		courses = append(courses, &course)
+		ranks = append(ranks, rank)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to query database for the next page of courses: %s\n", err)
+		return nil, nil, err
+	}
+
+	courses, next := database.Paginate(courses, elements, func(i int) *database.Cursor {
+		if match != "" {
+			return database.NewRankedCursor(ranks[i], courses[i].CreatedAt, courses[i].ID)
+		}
+
+		return database.NewCursor(courses[i].CreatedAt, courses[i].ID)
+	})
+
+	return courses, next, nil
+}
+
+func (db *PostgresDatabase) GetCourseByFileKey(ctx context.Context, fileKey string) (*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE file_key = $1;`
+
+	var course models.CourseModel
+	var published int
+
+	row := db.connection.QueryRowContext(ctx, query, fileKey)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get course by file key from the database: %s\n", err)
+		return nil, err
+	}
+
+	course.Published = published == 1
+
+	return &course, nil
+}
+
+func (db *PostgresDatabase) GetCourseBySlug(ctx context.Context, slug string) (*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE slug = $1 AND deleted_at IS NULL;`
+
+	var course models.CourseModel
+	var published int
+
+	row := db.connection.QueryRowContext(ctx, query, slug)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get course by slug (\"%s\") from the database: %s\n", slug, err)
+		return nil, err
+	}
+
+	course.Published = published == 1
+
+	return &course, nil
+}
+
+func (db *PostgresDatabase) GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error) {
+	course, err := internal.GetCourseByID(ctx, db.connection, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", courseId, err)
+		return nil, err
+	}
+
+	return course, nil
+}
+
+func (db *PostgresDatabase) CountCourses(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM courses WHERE deleted_at IS NULL;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count all the courses in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) CountCoursesWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM courses WHERE author_id = $1 AND deleted_at IS NULL;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, authorId)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count all the courses written by \"%s\": %s\n", authorId, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) PublishCourse(ctx context.Context, courseId string) error {
+	query := `UPDATE courses SET published = 1 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to publish course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UnpublishCourse(ctx context.Context, courseId string) error {
+	query := `UPDATE courses SET published = 0 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to unpublish course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UpdateCourseAuthor(ctx context.Context, courseId, authorId string) error {
+	query := `UPDATE courses SET author_id = $1 WHERE id = $2;`
+
+	// Foreign keys are enforced so an empty author ID has to be stored as a proper NULL value.
+	_, err := db.connection.ExecContext(ctx, query, database.NewNullString(authorId), courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update course's (\"%s\") author (\"%s\"): %s\n", courseId, authorId, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCourse soft deletes the course so that it no longer shows up anywhere on the site.
+func (db *PostgresDatabase) DeleteCourse(ctx context.Context, courseId string) error {
+	query := `UPDATE courses SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after deleting course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after deleting course \"%s\"\n", courseId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// RestoreCourse brings back a soft deleted course.
+func (db *PostgresDatabase) RestoreCourse(ctx context.Context, courseId string) error {
+	query := `UPDATE courses SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to restore course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after restoring course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after restoring course \"%s\"\n", courseId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// PurgeCourse permanently removes the course along with its keywords, chapters, quizzes, chapter completions and
+// certificates.
+// Courses that have been bought are kept so that the financial records stay intact, in which case
+// database.ErrCourseHasPurchases gets returned.
+func (db *PostgresDatabase) PurgeCourse(ctx context.Context, courseId string) error {
+	queries := []string{
+		`DELETE FROM courses_keywords WHERE course_id = $1;`,
+		`DELETE FROM user_course_chapter_completion WHERE course_id = $1;`,
+		`DELETE FROM quiz_attempt_answers WHERE attempt_id IN (SELECT id FROM quiz_attempts WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = $1)));`,
+		`DELETE FROM quiz_attempts WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = $1));`,
+		`DELETE FROM quiz_questions WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = $1));`,
+		`DELETE FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = $1);`,
+		`DELETE FROM certificates WHERE course_id = $1;`,
+		`DELETE FROM course_chapters WHERE course_id = $1;`,
+	}
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	var purchased bool
+
+	row := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM course_purchases WHERE course_id = $1) OR EXISTS (SELECT 1 FROM affiliate_points_history WHERE course_id = $1);`, courseId)
+	if err := row.Scan(&purchased); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to check if course \"%s\" has been purchased: %s\n", courseId, err)
+		return err
+	}
+
+	if purchased {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		return database.ErrCourseHasPurchases
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, courseId); err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to purge course \"%s\": %s\n", courseId, err)
+			db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM courses WHERE id = $1;`, courseId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to purge course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after purging course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after purging course \"%s\"\n", courseId)
+		return database.ErrNoRowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after purging course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	return nil
+}
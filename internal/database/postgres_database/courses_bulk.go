@@ -0,0 +1,399 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+type intermediate_course struct {
+	ID           string
+	Title        string
+	Slug         string
+	Description  string
+	ThumbnailURL string
+	BannerURL    string
+	Content      string
+	FileChecksum string
+	FileKey      string
+	Keywords     []string
+	AuthorID     sql.NullString
+	PublishAt    sql.NullTime
+	UnpublishAt  sql.NullTime
+}
+
+type intermediate_chapter struct {
+	ID               string
+	Title            string
+	Slug             string
+	Chapter          int
+	Content          string
+	TableOfContents  models.TableOfContents
+	WordCount        int
+	ReadingTime      int
+	FileChecksum     string
+	FileKey          string
+	CourseKey        string
+	ReleaseAfterDays sql.NullInt32
+	ReleaseAt        sql.NullTime
+	Quiz             *models.QuizModel
+}
+
+var coursesToInsert []*intermediate_course
+var coursesToUpdate []*intermediate_course
+var chaptersToInsert []*intermediate_chapter
+var chaptersToUpdate []*intermediate_chapter
+var chaptersToDelete []string
+
+func (db *PostgresDatabase) PrepareBulkCourses() {
+	coursesToInsert = []*intermediate_course{}
+	coursesToUpdate = []*intermediate_course{}
+	chaptersToInsert = []*intermediate_chapter{}
+	chaptersToUpdate = []*intermediate_chapter{}
+	chaptersToDelete = []string{}
+}
+
+func (db *PostgresDatabase) InsertCourse(title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, publishAt, unpublishAt sql.NullTime) {
+	coursesToInsert = append(coursesToInsert, &intermediate_course{
+		Title:        title,
+		Slug:         slug,
+		Description:  description,
+		ThumbnailURL: thumbnailUrl,
+		BannerURL:    bannerUrl,
+		Content:      content,
+		FileChecksum: fileChecksum,
+		FileKey:      fileKey,
+		Keywords:     keywords,
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+	})
+}
+
+func (db *PostgresDatabase) UpdateCourse(id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, keywords []string, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) {
+	coursesToUpdate = append(coursesToUpdate, &intermediate_course{
+		ID:           id,
+		Title:        title,
+		Slug:         slug,
+		Description:  description,
+		ThumbnailURL: thumbnailUrl,
+		BannerURL:    bannerUrl,
+		Content:      content,
+		FileChecksum: fileChecksum,
+		FileKey:      fileKey,
+		Keywords:     keywords,
+		AuthorID:     authorId,
+		PublishAt:    publishAt,
+		UnpublishAt:  unpublishAt,
+	})
+}
+
+func (db *PostgresDatabase) InsertChapter(title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel) {
+	chaptersToInsert = append(chaptersToInsert, &intermediate_chapter{
+		Title:            title,
+		Slug:             slug,
+		Chapter:          chapter,
+		Content:          content,
+		TableOfContents:  toc,
+		WordCount:        wordCount,
+		ReadingTime:      readingTime,
+		FileChecksum:     fileChecksum,
+		FileKey:          fileKey,
+		CourseKey:        courseKey,
+		ReleaseAfterDays: releaseAfterDays,
+		ReleaseAt:        releaseAt,
+		Quiz:             quiz,
+	})
+}
+
+func (db *PostgresDatabase) UpdateChapter(id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, quiz *models.QuizModel) {
+	chaptersToUpdate = append(chaptersToUpdate, &intermediate_chapter{
+		ID:               id,
+		Title:            title,
+		Slug:             slug,
+		Chapter:          chapter,
+		Content:          content,
+		TableOfContents:  toc,
+		WordCount:        wordCount,
+		ReadingTime:      readingTime,
+		FileChecksum:     fileChecksum,
+		FileKey:          fileKey,
+		CourseKey:        courseKey,
+		ReleaseAfterDays: releaseAfterDays,
+		ReleaseAt:        releaseAt,
+		Quiz:             quiz,
+	})
+}
+
+func (db *PostgresDatabase) DeleteChapter(id string) {
+	chaptersToDelete = append(chaptersToDelete, id)
+}
+
+func (db *PostgresDatabase) RunBulkCourses(ctx context.Context) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction for bulk parsing courses: %s\n", err)
+		return err
+	}
+
+	if err := AddCourses(ctx, tx, coursesToInsert); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk insert courses: %s\n", err)
+		return err
+	}
+
+	if err := UpdateCourses(ctx, tx, coursesToUpdate); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk update courses: %s\n", err)
+		return err
+	}
+
+	if err := internal.RebuildCoursesIndex(ctx, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to rebuild courses search index: %s\n", err)
+		return err
+	}
+
+	if err := DeleteChapters(ctx, tx, chaptersToDelete); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk delete chapters: %s\n", err)
+		return err
+	}
+
+	// Chapters that are about to be updated get moved out of the way first so that renumbering or renaming them
+	// doesn't trip over the unique chapter number, title and slug indexes.
+	if err := ParkChapters(ctx, tx, chaptersToUpdate); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to park chapters before updating them: %s\n", err)
+		return err
+	}
+
+	if err := AddChapters(ctx, tx, chaptersToInsert); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk insert chapters: %s\n", err)
+		return err
+	}
+
+	if err := UpdateChapters(ctx, tx, chaptersToUpdate); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk update chapters: %s\n", err)
+		return err
+	}
+
+	if err := internal.UpdateCourseReadingTimes(ctx, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to update course reading times: %s\n", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after an error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit bulk courses changes to the database: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func AddCourses(ctx context.Context, tx *sql.Tx, courses []*intermediate_course) error {
+	for _, course := range courses {
+		id, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddCourse(ctx, tx, id, course.Title, course.Slug, course.Description, course.ThumbnailURL, course.BannerURL, course.Content, course.FileChecksum, course.FileKey, course.PublishAt, course.UnpublishAt); err != nil {
+			return err
+		}
+
+		if err := AddKeywordsToCourse(ctx, tx, id, course.Keywords); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddChapters(ctx context.Context, tx *sql.Tx, chapters []*intermediate_chapter) error {
+	for _, chapter := range chapters {
+		id, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddChapter(ctx, tx, id, chapter.Title, chapter.Slug, chapter.Chapter, chapter.Content, chapter.TableOfContents, chapter.WordCount, chapter.ReadingTime, chapter.FileChecksum, chapter.FileKey, chapter.CourseKey, chapter.ReleaseAfterDays, chapter.ReleaseAt); err != nil {
+			return err
+		}
+
+		if err := SetChapterQuiz(ctx, tx, id, chapter.Quiz); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func UpdateCourses(ctx context.Context, tx *sql.Tx, courses []*intermediate_course) error {
+	for _, course := range courses {
+		historyId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddSlugHistory(ctx, tx, historyId, database.CourseSlug, course.ID, course.Slug); err != nil {
+			return err
+		}
+
+		if err := internal.UpdateCourse(ctx, tx, course.ID, course.Title, course.Slug, course.Description, course.ThumbnailURL, course.BannerURL, course.Content, course.FileChecksum, course.FileKey, course.PublishAt, course.UnpublishAt); err != nil {
+			return err
+		}
+
+		if err := internal.DeleteAllKeywordsFromCourses(ctx, tx, course.ID); err != nil {
+			return err
+		}
+
+		if err := AddKeywordsToCourse(ctx, tx, course.ID, course.Keywords); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func UpdateChapters(ctx context.Context, tx *sql.Tx, chapters []*intermediate_chapter) error {
+	for _, chapter := range chapters {
+		if err := internal.UpdateChapter(ctx, tx, chapter.ID, chapter.Title, chapter.Slug, chapter.Chapter, chapter.Content, chapter.TableOfContents, chapter.WordCount, chapter.ReadingTime, chapter.FileChecksum, chapter.FileKey, chapter.CourseKey, chapter.ReleaseAfterDays, chapter.ReleaseAt); err != nil {
+			return err
+		}
+
+		if err := SetChapterQuiz(ctx, tx, chapter.ID, chapter.Quiz); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func DeleteChapters(ctx context.Context, tx *sql.Tx, ids []string) error {
+	for _, id := range ids {
+		if err := internal.DeleteChapter(ctx, tx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParkChapters moves the chapters that are about to be updated out of the way. Their current slugs get recorded in
+// the slug history first because parking overwrites them.
+func ParkChapters(ctx context.Context, tx *sql.Tx, chapters []*intermediate_chapter) error {
+	for _, chapter := range chapters {
+		historyId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddSlugHistory(ctx, tx, historyId, database.ChapterSlug, chapter.ID, chapter.Slug); err != nil {
+			return err
+		}
+
+		if err := internal.ParkChapter(ctx, tx, chapter.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetChapterQuiz replaces the quiz of a chapter with the given one. Questions are matched by their position so the
+// statistics of questions that stay in place are kept. A nil quiz removes the chapter's quiz.
+func SetChapterQuiz(ctx context.Context, tx *sql.Tx, chapterId string, quiz *models.QuizModel) error {
+	if quiz == nil {
+		return internal.DeleteChapterQuiz(ctx, tx, chapterId)
+	}
+
+	quizId, err := database.GenerateID()
+	if err != nil {
+		return err
+	}
+
+	quizId, err = internal.AddQuiz(ctx, tx, quizId, chapterId, quiz.PassMark)
+	if err != nil {
+		return err
+	}
+
+	for i, question := range quiz.Questions {
+		questionId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddQuizQuestion(ctx, tx, questionId, quizId, i+1, question.Kind, question.Question, question.Options, question.Answers); err != nil {
+			return err
+		}
+	}
+
+	return internal.DeleteQuizQuestionsAfter(ctx, tx, quizId, len(quiz.Questions))
+}
+
+func AddKeywordsToCourse(ctx context.Context, tx *sql.Tx, courseId string, keywords []string) error {
+	for _, keyword := range keywords {
+		keywordId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddKeyword(ctx, tx, keywordId, keyword); err != nil {
+			if err == database.ErrKeywordAlreadyExists {
+				keywordModel, err := internal.GetKeywordByKeyword(ctx, tx, keyword)
+				if err != nil {
+					return err
+				}
+
+				keywordId = keywordModel.ID
+			} else {
+				return err
+			}
+		}
+
+		courseKeywordId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddKeywordToCourse(ctx, tx, courseKeywordId, keywordId, courseId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
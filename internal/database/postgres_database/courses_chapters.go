@@ -0,0 +1,122 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func (db *PostgresDatabase) GetAllChapters(ctx context.Context) ([]*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters ORDER BY chapter ASC;`
+
+	var chapters []*models.ChapterModel
+
+	rows, err := db.connection.QueryContext(ctx, query)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all chapters from the database: %s\n", err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var chapter models.ChapterModel
+
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from chapters table: %s\n", err)
+			return nil, err
+		}
+
+		chapters = append(chapters, &chapter)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all chapters from the database: %s\n", err)
+		return nil, err
+	}
+
+	return chapters, nil
+}
+
+func (db *PostgresDatabase) GetChapterBySlug(ctx context.Context, chapterSlug string) (*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE slug = $1;`
+
+	var chapter models.ChapterModel
+
+	row := db.connection.QueryRowContext(ctx, query, chapterSlug)
+	if err := row.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get chapter using slug (\"%s\") from the database: %s\n", chapterSlug, err)
+		return nil, err
+	}
+
+	return &chapter, nil
+}
+
+func (db *PostgresDatabase) GetChapterByFileKey(ctx context.Context, fileKey string) (*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE file_key = $1;`
+
+	var chapter models.ChapterModel
+
+	row := db.connection.QueryRowContext(ctx, query, fileKey)
+	if err := row.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get chapter using file key from the database: %s\n", err)
+		return nil, err
+	}
+
+	return &chapter, nil
+}
+
+func (db *PostgresDatabase) CountChapters(ctx context.Context, courseId string) (int, error) {
+	query := `SELECT COUNT(id) FROM course_chapters WHERE course_id = $1;`
+
+	var chapters int
+
+	row := db.connection.QueryRowContext(ctx, query, courseId)
+	if err := row.Scan(&chapters); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count all the chapters for course with ID \"%s\": %s\n", courseId, err)
+		return -1, err
+	}
+
+	return chapters, nil
+}
+
+func (db *PostgresDatabase) GetCourseChapters(ctx context.Context, courseId string) ([]*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE course_id = $1 ORDER BY chapter ASC;`
+
+	var chapters []*models.ChapterModel
+
+	rows, err := db.connection.QueryContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all chapters for course (\"%s\") from the database: %s\n", courseId, err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var chapter models.ChapterModel
+
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from chapters table: %s\n", err)
+			return nil, err
+		}
+
+		chapters = append(chapters, &chapter)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all chapters for course (\"%s\") from the database: %s\n", courseId, err)
+		return nil, err
+	}
+
+	return chapters, nil
+}
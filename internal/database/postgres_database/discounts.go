@@ -0,0 +1,195 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) GetDiscountsPaginated(ctx context.Context, term string, active *bool, cursor *database.Cursor, elements uint) ([]*models.DiscountModel, *database.Cursor, error) {
+	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts WHERE (LOWER(id) ILIKE '%' || $1 ||'%' OR LOWER(title) ILIKE '%' || $2 || '%' OR LOWER(description) ILIKE '%' || $3 || '%' OR LOWER(code) ILIKE '%' || $4 || '%')`
+
+	args := []any{term, term, term, term}
+
+	if active != nil {
+		query += fmt.Sprintf(" AND active = $%d", len(args)+1)
+
+		if *active {
+			args = append(args, 1)
+		} else {
+			args = append(args, 0)
+		}
+	}
+
+	condition, cursorArgs := internal.AfterCursor("discounts", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY discounts.created_at DESC, discounts.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var discounts []*models.DiscountModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var discount models.DiscountModel
+		var active uint
+
+		if err := rows.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from discounts table: %s\n", err)
+			return nil, nil, err
+		}
+
+		if active == 1 {
+			discount.Active = true
+		}
+
+		discounts = append(discounts, &discount)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
+		return nil, nil, err
+	}
+
+	discounts, next := database.Paginate(discounts, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(discounts[i].CreatedAt, discounts[i].ID)
+	})
+
+	return discounts, next, nil
+}
+
+func (db *PostgresDatabase) GetAllDiscounts(ctx context.Context) ([]*models.DiscountModel, error) {
+	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts;`
+
+	var discounts []*models.DiscountModel
+
+	rows, err := db.connection.QueryContext(ctx, query)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var discount models.DiscountModel
+		var active uint
+
+		if err := rows.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from discounts table: %s\n", err)
+			return nil, err
+		}
+
+		if active == 1 {
+			discount.Active = true
+		}
+
+		discounts = append(discounts, &discount)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
+		return nil, err
+	}
+
+	return discounts, nil
+}
+
+func (db *PostgresDatabase) CountDiscounts(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM discounts;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number of discounts in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error) {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new discount: %s\n", err)
+		return "", err
+	}
+
+	code, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate code for new discount: %s\n", err)
+		return "", err
+	}
+
+	if err := internal.AddDiscount(ctx, db.connection, id, title, description, code, discount, uses); err != nil {
+		db.ErrorLog.Printf("Failed to add new discount \"%s\" to the database: %s\n", title, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (db *PostgresDatabase) GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error) {
+	discount, err := internal.GetDiscountByID(ctx, db.connection, discountId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get discount \"%s\" from the database: %s\n", discountId, err)
+		return nil, err
+	}
+
+	return discount, nil
+}
+
+func (db *PostgresDatabase) GetDiscountByCode(ctx context.Context, discountCode string) (*models.DiscountModel, error) {
+	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts WHERE code = $1;`
+
+	discount := new(models.DiscountModel)
+
+	var active int
+
+	row := db.connection.QueryRowContext(ctx, query, discountCode)
+	if err := row.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get discount by code \"%s\" from the database: %s\n", discountCode, err)
+		return nil, err
+	}
+
+	discount.Active = active == 1
+
+	return discount, nil
+}
+
+func (db *PostgresDatabase) ActivateDiscount(ctx context.Context, discountId string) error {
+	if err := internal.ActivateDiscount(ctx, db.connection, discountId); err != nil {
+		db.ErrorLog.Printf("Failed to update discount \"%s\" active status: %s\n", discountId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) DeactivateDiscount(ctx context.Context, discountId string) error {
+	query := `UPDATE discounts SET active = 0, updated_at = CURRENT_TIMESTAMP WHERE id = $1;`
+
+	if _, err := db.connection.ExecContext(ctx, query, discountId); err != nil {
+		db.ErrorLog.Printf("Failed to update discount \"%s\" active status: %s\n", discountId, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package postgres_database
+
+import (
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+func (db *PostgresDatabase) SetupMigrations() (*migrate.Migrate, error) {
+	// Configure database driver for migrate.
+	driver, err := pgx.WithInstance(db.connection, &pgx.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the migrations that were embedded into the binary.
+	src, err := iofs.New(db.migrations, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "pgx5", driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
@@ -0,0 +1,230 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// HasUserPurchasedCourse checks if there is a database row that indicates the user has purchased
+// the provided course. This function works with normal database connections or database transactions.
+func HasUserPurchasedCourse(ctx context.Context, dbFacade SqlDbFacade, userId, courseId string) (bool, error) {
+	query := `SELECT id FROM course_purchases WHERE user_id = $1 AND course_id = $2 AND payment_status = $3;`
+
+	var id string
+
+	row := dbFacade.QueryRowContext(ctx, query, userId, courseId, database.Succeeded.String())
+	if err := row.Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return id != "", nil
+}
+
+// AddNewCoursePurchase adds a new course purchase row in the database. This function works with normal database
+// connections or database transactions.
+func AddNewCoursePurchase(ctx context.Context, dbFacade SqlDbFacade, purchaseId, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64) error {
+	query := `INSERT INTO course_purchases (id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+
+	result, err := dbFacade.ExecContext(ctx, query, purchaseId, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// RegisterCoursePurchase checks that the user is allowed to buy the course, deducts any affiliate points used and then
+// saves the course purchase along with the payment token (if one was given). This function works with normal database
+// connections or database transactions but it should be run inside of a transaction so that the steps either all
+// succeed or all fail.
+func RegisterCoursePurchase(ctx context.Context, dbFacade SqlDbFacade, purchaseId, affiliateHistoryId, paymentTokenId, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+	user, err := GetUserByID(ctx, dbFacade, userId, database.All)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return sql.ErrNoRows
+	}
+
+	course, err := GetCourseByID(ctx, dbFacade, courseId)
+	if err != nil {
+		return err
+	}
+
+	if course == nil {
+		return sql.ErrNoRows
+	}
+
+	purchased, err := HasUserPurchasedCourse(ctx, dbFacade, user.ID, course.ID)
+	if err != nil {
+		return err
+	}
+
+	if purchased {
+		return database.ErrCourseAlreadyOwned
+	}
+
+	if user.AffiliatePoints < int(affiliatePointsUsed) {
+		return database.ErrInsufficientAffiliatePoints
+	}
+
+	if affiliatePointsUsed > 0 {
+		if err := RegisterAffiliatePointsChange(ctx, dbFacade, affiliateHistoryId, user.ID, course.ID, -1*int(affiliatePointsUsed), fmt.Sprintf("Purchased \"%s\"", course.Title)); err != nil {
+			return err
+		}
+	}
+
+	if err := AddNewCoursePurchase(ctx, dbFacade, purchaseId, user.ID, course.ID, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid); err != nil {
+		return err
+	}
+
+	if token != "" {
+		if err := AddToken(ctx, dbFacade, paymentTokenId, token, tokenType, user.ID, validUntil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetCoursePurchaseByPaymentKey finds a course purchase row based on the given payment key. This function works with
+// normal database connections or database transactions.
+func GetCoursePurchaseByPaymentKey(ctx context.Context, dbFacade SqlDbFacade, paymentKey string) (*models.CoursePurchaseModel, error) {
+	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE payment_key = $1;`
+
+	var coursePurchase models.CoursePurchaseModel
+
+	row := dbFacade.QueryRowContext(ctx, query, paymentKey)
+	if err := row.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &coursePurchase, nil
+}
+
+// GetCoursePurchaseByCheckoutSession finds a course purchase row based on the given Stripe Checkout Session ID. This
+// function works with normal database connections or database transactions.
+func GetCoursePurchaseByCheckoutSession(ctx context.Context, dbFacade SqlDbFacade, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE stripe_checkout_session_id = $1;`
+
+	var coursePurchase models.CoursePurchaseModel
+
+	row := dbFacade.QueryRowContext(ctx, query, checkoutSessionId)
+	if err := row.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &coursePurchase, nil
+}
+
+// GetCoursePurchasesByUserAndCourse finds all the course purchase rows for the given user and course. This function
+// works with normal database connections or database transactions.
+func GetCoursePurchasesByUserAndCourse(ctx context.Context, dbFacade SqlDbFacade, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE user_id = $1 AND course_id = $2;`
+
+	coursePurchases := []*models.CoursePurchaseModel{}
+
+	rows, err := dbFacade.QueryContext(ctx, query, userId, courseId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var coursePurchase models.CoursePurchaseModel
+
+		if err := rows.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
+			return nil, err
+		}
+
+		coursePurchases = append(coursePurchases, &coursePurchase)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return coursePurchases, nil
+}
+
+// UpdateCoursePurchasePaymentStatus updates the payment status of the given course purchase row. This function works
+// with normal database connections or database transactions.
+func UpdateCoursePurchasePaymentStatus(ctx context.Context, dbFacade SqlDbFacade, coursePurchaseId string, status database.PaymentStatus) error {
+	query := `UPDATE course_purchases SET payment_status = $1 WHERE id = $2;`
+
+	result, err := dbFacade.ExecContext(ctx, query, status.String(), coursePurchaseId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// TransitionCoursePurchasePaymentStatus moves the given course purchase row to the new payment status, but only if its
+// current payment status is one of the given statuses. It reports whether the row was changed so that callers can tell
+// if someone else got to the purchase first. This function works with normal database connections or database
+// transactions.
+func TransitionCoursePurchasePaymentStatus(ctx context.Context, dbFacade SqlDbFacade, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	if len(from) == 0 {
+		return false, nil
+	}
+
+	args := []any{to.String(), coursePurchaseId}
+
+	placeholders := make([]string, 0, len(from))
+	for _, status := range from {
+		args = append(args, status.String())
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`UPDATE course_purchases SET payment_status = $1 WHERE id = $2 AND payment_status IN (%s);`, strings.Join(placeholders, ", "))
+
+	result, err := dbFacade.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
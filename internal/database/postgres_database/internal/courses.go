@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddCourse adds a new course row to the database. This function works with either a database connection or a database
+// transaction.
+func AddCourse(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, publishAt, unpublishAt sql.NullTime) error {
+	query := `INSERT INTO courses (id, title, slug, description, thumbnail_url, banner_url, content, file_checksum, file_key, publish_at, unpublish_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`
+
+	results, err := dbFacade.ExecContext(ctx, query, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, publishAt, unpublishAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// UpdateCourse updates the course row based on the provided ID. This function works with either a database connection
+// or a database transaction.
+func UpdateCourse(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, publishAt, unpublishAt sql.NullTime) error {
+	query := `UPDATE courses SET title = $1, slug = $2, description = $3, thumbnail_url = $4, banner_url = $5, content = $6, published = 0, file_checksum = $7, file_key = $8, publish_at = $9, unpublish_at = $10, updated_at = CURRENT_TIMESTAMP WHERE id = $11;`
+	results, err := dbFacade.ExecContext(ctx, query, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, publishAt, unpublishAt, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := results.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// GetCourseByID retrieves a CourseModel based on the provided course ID. This function works with either a database
+// connection or a database transaction.
+func GetCourseByID(ctx context.Context, dbFacade SqlDbFacade, courseId string) (*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE id = $1;`
+
+	var course models.CourseModel
+	var published int
+
+	row := dbFacade.QueryRowContext(ctx, query, courseId)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	course.Published = published == 1
+
+	return &course, nil
+}
+
+// UpdateCourseReadingTimes sets the reading time of every course to the total reading time of its chapters. This
+// function works with either a database connection or a database transaction.
+func UpdateCourseReadingTimes(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `UPDATE courses SET reading_time = (SELECT COALESCE(SUM(reading_time), 0) FROM course_chapters WHERE course_chapters.course_id = courses.id);`
+
+	_, err := dbFacade.ExecContext(ctx, query)
+
+	return err
+}
@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddChapter adds a new chapter row to the database. This function works with either a database connection or a
+// database transaction. This function will NOT throw an error upon a unique constraint violation.
+func AddChapter(ctx context.Context, dbFacade SqlDbFacade, id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime) error {
+	query := `INSERT INTO course_chapters (id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, release_set_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT id FROM courses WHERE file_key = $9), $10, $11, $12, $13, CURRENT_TIMESTAMP);`
+
+	result, err := dbFacade.ExecContext(ctx, query, id, title, slug, chapter, content, toc, wordCount, readingTime, courseKey, fileChecksum, fileKey, releaseAfterDays, releaseAt)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return database.ErrChapterAlreadyExists
+		}
+
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// UpdateChapter updates a chapter in the database based off the provided ID. release_set_at only moves when the
+// chapter's release rule changes. This function works with either a database connection or a database transaction.
+func UpdateChapter(ctx context.Context, dbFacade SqlDbFacade, id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime) error {
+	query := `UPDATE course_chapters SET title = $1, slug = $2, chapter = $3, content = $4, table_of_contents = $5, word_count = $6, reading_time = $7, course_id = (SELECT id FROM courses WHERE file_key = $8), file_checksum = $9, file_key = $10, release_after_days = $11, release_at = $12, release_set_at = CASE WHEN release_after_days IS NOT DISTINCT FROM $11 AND release_at IS NOT DISTINCT FROM $12 THEN release_set_at ELSE CURRENT_TIMESTAMP END WHERE id = $13;`
+
+	result, err := dbFacade.ExecContext(ctx, query, title, slug, chapter, content, toc, wordCount, readingTime, courseKey, fileChecksum, fileKey, releaseAfterDays, releaseAt, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// DeleteChapter removes a chapter along with its quiz, every user's completion of that chapter and the record of who was
+// told about its release. This function works with either a database connection or a database transaction.
+func DeleteChapter(ctx context.Context, dbFacade SqlDbFacade, id string) error {
+	if err := DeleteChapterQuiz(ctx, dbFacade, id); err != nil {
+		return err
+	}
+
+	if _, err := dbFacade.ExecContext(ctx, `DELETE FROM user_course_chapter_completion WHERE chapter_id = $1;`, id); err != nil {
+		return err
+	}
+
+	if _, err := dbFacade.ExecContext(ctx, `DELETE FROM chapter_release_notifications WHERE chapter_id = $1;`, id); err != nil {
+		return err
+	}
+
+	result, err := dbFacade.ExecContext(ctx, `DELETE FROM course_chapters WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// ParkChapter temporarily gives a chapter a negative chapter number and uses its ID as its title and slug so that
+// other chapters in the same course can take over its number, title or slug before it gets updated. The chapter
+// numbers of a course are unique so the negative numbers are too. This function works with either a database
+// connection or a database transaction.
+func ParkChapter(ctx context.Context, dbFacade SqlDbFacade, id string) error {
+	result, err := dbFacade.ExecContext(ctx, `UPDATE course_chapters SET chapter = -chapter, title = id, slug = id WHERE id = $1;`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
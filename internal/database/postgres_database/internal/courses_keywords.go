@@ -0,0 +1,31 @@
+package internal
+
+import "context"
+
+// AddKeywordToCourse associates a keyword row to a course row. This function works with either a database connection
+// or a database transaction. This function will NOT throw an error upon a unique constraint violation.
+func AddKeywordToCourse(ctx context.Context, dbFacade SqlDbFacade, id, keywordId, courseId string) error {
+	// A unique constraint violation aborts the surrounding transaction in PostgreSQL so the conflict is ignored
+	// by the query itself instead of being checked for afterwards.
+	query := `INSERT INTO courses_keywords (id, course_id, keyword_id) VALUES ($1, $2, $3) ON CONFLICT (course_id, keyword_id) DO NOTHING;`
+
+	_, err := dbFacade.ExecContext(ctx, query, id, courseId, keywordId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAllKeywordsFromCourses removes all database associations between courses and keywords. This function works
+// with either a database connection or a database transaction.
+func DeleteAllKeywordsFromCourses(ctx context.Context, dbFacade SqlDbFacade, courseId string) error {
+	query := `DELETE FROM courses_keywords WHERE course_id = $1;`
+
+	_, err := dbFacade.ExecContext(ctx, query, courseId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddDiscount adds a new discount row to the database. This function works with either a database connection or a
+// database transaction.
+func AddDiscount(ctx context.Context, dbFacade SqlDbFacade, id, title, description, code string, discount, uses uint64) error {
+	query := `INSERT INTO discounts (id, title, description, code, discount, uses) VALUES ($1, $2, $3, $4, $5, $6);`
+
+	result, err := dbFacade.ExecContext(ctx, query, id, title, description, code, discount, uses)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// GetDiscountByID finds a discount row based on the given ID. This function works with either a database connection or
+// a database transaction.
+func GetDiscountByID(ctx context.Context, dbFacade SqlDbFacade, discountId string) (*models.DiscountModel, error) {
+	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts WHERE id = $1;`
+
+	discount := new(models.DiscountModel)
+
+	var active int
+
+	row := dbFacade.QueryRowContext(ctx, query, discountId)
+	if err := row.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	discount.Active = active == 1
+
+	return discount, nil
+}
+
+// ActivateDiscount marks a discount row as active. This function works with either a database connection or a database
+// transaction.
+func ActivateDiscount(ctx context.Context, dbFacade SqlDbFacade, discountId string) error {
+	query := `UPDATE discounts SET active = 1, updated_at = CURRENT_TIMESTAMP WHERE id = $1;`
+
+	if _, err := dbFacade.ExecContext(ctx, query, discountId); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode is the PostgreSQL error code for a unique constraint violation.
+const uniqueViolationCode = "23505"
+
+// IsUniqueViolation checks whether the given error was caused by a unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == uniqueViolationCode
+	}
+
+	return false
+}
@@ -0,0 +1,18 @@
+package internal
+
+import "context"
+
+// AddIPAddress adds a new IP address to a user's whitelist of IP addresses. This function works with either a database
+// connection or a database transaction. This function will NOT throw an error upon a unique constraint violation.
+func AddIPAddress(ctx context.Context, dbFacade SqlDbFacade, id, userId, ipAddr string) error {
+	// A unique constraint violation aborts the surrounding transaction in PostgreSQL so the conflict is ignored
+	// by the query itself instead of being checked for afterwards.
+	query := `INSERT INTO whitelisted_ips (id, user_id, ip_address) VALUES ($1, $2, $3) ON CONFLICT (user_id, ip_address) DO NOTHING;`
+
+	_, err := dbFacade.ExecContext(ctx, query, id, userId, ipAddr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
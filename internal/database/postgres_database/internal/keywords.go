@@ -0,0 +1,52 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddKeywords adds a new keyword row to the database. This function works with either a database connection or a
+// database transaction. This function will NOT throw an error upon a unique constraint violation.
+func AddKeyword(ctx context.Context, dbFacade SqlDbFacade, id, keyword string) error {
+	// A unique constraint violation aborts the surrounding transaction in PostgreSQL so conflicting keywords are
+	// skipped by the query itself and reported through the number of affected rows instead.
+	query := `INSERT INTO keywords (id, keyword) VALUES ($1, $2) ON CONFLICT (keyword) DO NOTHING;`
+
+	result, err := dbFacade.ExecContext(ctx, query, id, keyword)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrKeywordAlreadyExists
+	}
+
+	return nil
+}
+
+// GetKeywordByKeyword will retrieve a KeywordModel from the database using a given keyword. This function works with
+// either a database connection or a database transaction.
+func GetKeywordByKeyword(ctx context.Context, dbFacade SqlDbFacade, keyword string) (*models.KeywordModel, error) {
+	query := `SELECT id, keyword FROM keywords WHERE keyword = $1;`
+
+	keywordModel := new(models.KeywordModel)
+
+	row := dbFacade.QueryRowContext(ctx, query, keyword)
+	if err := row.Scan(&keywordModel.ID, &keywordModel.Keyword); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return keywordModel, nil
+}
@@ -0,0 +1,29 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+// AfterCursor returns the condition that limits a listing, ordered by created_at DESC and id DESC, to the rows that
+// come after the cursor. The alias is the name the table goes by in the query and argIndex is the number of the first
+// placeholder the condition may use. An empty condition gets returned when there is no cursor.
+func AfterCursor(alias string, cursor *database.Cursor, argIndex int) (string, []any) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" AND (%[1]s.created_at, %[1]s.id) < ($%[2]d, $%[3]d)", alias, argIndex, argIndex+1), []any{cursor.CreatedAt, cursor.ID}
+}
+
+// AfterRankedCursor returns the condition that limits full-text search results, ordered by the given rank expression
+// DESC and then by id DESC, to the results that come after the cursor. An empty condition gets returned when there is
+// no cursor.
+func AfterRankedCursor(rank, alias string, cursor *database.Cursor, argIndex int) (string, []any) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" AND (%s, %s.id) < ($%d, $%d)", rank, alias, argIndex, argIndex+1), []any{cursor.Rank, cursor.ID}
+}
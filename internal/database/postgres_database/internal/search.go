@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/searchmark"
+)
+
+// FullTextQuery turns a user's search term into a tsquery string for to_tsquery. Every word only keeps its letters and
+// numbers so that tsquery syntax in the search term can't cause errors and gets turned into a prefix search so that
+// results show up while the user is still typing. An empty string gets returned if the search term doesn't contain
+// any words.
+func FullTextQuery(term string) string {
+	words := strings.FieldsFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+// RebuildTutorialsIndex replaces every row in the tutorials_fts table with the current tutorials and their keywords.
+// This function works with either a database connection or a database transaction.
+func RebuildTutorialsIndex(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `SELECT t.id, t.title, t.description, COALESCE((SELECT STRING_AGG(k.keyword, ' ') FROM tutorials_keywords AS tk JOIN keywords AS k ON tk.keyword_id = k.id WHERE tk.tutorial_id = t.id), ''), t.content FROM tutorials AS t;`
+
+	return rebuildIndex(ctx, dbFacade, "tutorials_fts", query)
+}
+
+// RebuildCoursesIndex replaces every row in the courses_fts table with the current courses and their keywords. This
+// function works with either a database connection or a database transaction.
+func RebuildCoursesIndex(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `SELECT c.id, c.title, c.description, COALESCE((SELECT STRING_AGG(k.keyword, ' ') FROM courses_keywords AS ck JOIN keywords AS k ON ck.keyword_id = k.id WHERE ck.course_id = c.id), ''), c.content FROM courses AS c;`
+
+	return rebuildIndex(ctx, dbFacade, "courses_fts", query)
+}
+
+// GetSearchSnippets returns a highlighted snippet of the content column for each of the given IDs that matches the
+// tsquery. Matched terms are wrapped in searchmark.Start and searchmark.End. IDs whose content didn't
+// match are left out of the map.
+func GetSearchSnippets(ctx context.Context, dbFacade SqlDbFacade, table, match string, ids []string) (map[string]string, error) {
+	snippets := make(map[string]string, len(ids))
+
+	if match == "" || len(ids) == 0 {
+		return snippets, nil
+	}
+
+	options := fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=24, MinWords=12, MaxFragments=1, FragmentDelimiter=…", searchmark.Start, searchmark.End)
+	args := []any{match, options}
+
+	placeholders := make([]string, 0, len(ids))
+	for _, id := range ids {
+		args = append(args, id)
+		placeholders = append(placeholders, fmt.Sprintf("$%d", len(args)))
+	}
+
+	query := fmt.Sprintf(`SELECT id, ts_headline('english', content, to_tsquery('english', $1), $2) FROM %s WHERE document @@ to_tsquery('english', $1) AND id IN (%s);`, table, strings.Join(placeholders, ", "))
+
+	rows, err := dbFacade.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, snippet string
+
+		if err := rows.Scan(&id, &snippet); err != nil {
+			return nil, err
+		}
+
+		if strings.Contains(snippet, searchmark.Start) {
+			snippets[id] = snippet
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snippets, nil
+}
+
+type indexRow struct {
+	id          string
+	title       string
+	description string
+	keywords    string
+	content     string
+}
+
+func rebuildIndex(ctx context.Context, dbFacade SqlDbFacade, table, query string) error {
+	var indexRows []indexRow
+
+	rows, err := dbFacade.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row indexRow
+
+		if err := rows.Scan(&row.id, &row.title, &row.description, &row.keywords, &row.content); err != nil {
+			return err
+		}
+
+		indexRows = append(indexRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := dbFacade.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s;`, table)); err != nil {
+		return err
+	}
+
+	insert := fmt.Sprintf(`INSERT INTO %s (id, title, description, keywords, content) VALUES ($1, $2, $3, $4, $5);`, table)
+
+	for _, row := range indexRows {
+		if _, err := dbFacade.ExecContext(ctx, insert, row.id, row.title, row.description, row.keywords, database.HTMLToText(row.content)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+)
+
+// AddSeries adds a new series row to the database and returns its ID. If a series with the same slug already exists
+// its title gets updated instead and its ID is returned. This function works with either a database connection or a
+// database transaction.
+func AddSeries(ctx context.Context, dbFacade SqlDbFacade, id, title, slug string) (string, error) {
+	query := `INSERT INTO series (id, title, slug) VALUES ($1, $2, $3) ON CONFLICT (slug) DO UPDATE SET title = excluded.title RETURNING id;`
+
+	var seriesId string
+
+	if err := dbFacade.QueryRowContext(ctx, query, id, title, slug).Scan(&seriesId); err != nil {
+		return "", err
+	}
+
+	return seriesId, nil
+}
+
+// SetTutorialSeries puts a tutorial at the given position in a series. An invalid seriesId takes the tutorial out of
+// its series. This function works with either a database connection or a database transaction.
+func SetTutorialSeries(ctx context.Context, dbFacade SqlDbFacade, tutorialId string, seriesId sql.NullString, order int) error {
+	query := `UPDATE tutorials SET series_id = $1, series_order = $2 WHERE id = $3;`
+
+	_, err := dbFacade.ExecContext(ctx, query, seriesId, order, tutorialId)
+
+	return err
+}
+
+// DeleteEmptySeries deletes every series that no longer has any tutorials. This function works with either a database
+// connection or a database transaction.
+func DeleteEmptySeries(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `DELETE FROM series WHERE id NOT IN (SELECT series_id FROM tutorials WHERE series_id IS NOT NULL);`
+
+	_, err := dbFacade.ExecContext(ctx, query)
+
+	return err
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+// AddToken adds a new token to the database. This function works with either a database connection or a database
+// transaction. This function WILL throw a ErrTokenAlreadyExists error upon a unique constraint violation.
+func AddToken(ctx context.Context, dbFacade SqlDbFacade, id, token, tokenType, userId string, validUntil time.Time) error {
+	query := `INSERT INTO tokens (id, token, token_type, valid_until, user_id) VALUES ($1, $2, $3, $4, $5);`
+
+	result, err := dbFacade.ExecContext(ctx, query, id, token, tokenType, validUntil, userId)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return database.ErrTokenAlreadyExists
+		}
+
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
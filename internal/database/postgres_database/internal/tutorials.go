@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddTutorial adds a new tutorial row to the database. This function works with either a database connection or a
+// database transaction. This function WILL throw a ErrTutorialAlreadyExist error upon a unique constraint violation.
+func AddTutorial(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey string, publishAt, unpublishAt sql.NullTime) error {
+	query := `INSERT INTO tutorials (id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, file_checksum, file_key, publish_at, unpublish_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14);`
+
+	result, err := dbFacade.ExecContext(ctx, query, id, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, fileChecksum, fileKey, publishAt, unpublishAt)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return database.ErrTutorialAlreadyExists
+		}
+
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// UpdateTutorial updates a tutorial database row based on the provided ID. This function works with either a database
+// connection or a database transaction.
+func UpdateTutorial(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey string, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) error {
+	query := `UPDATE tutorials SET title = $1, slug = $2, description = $3, thumbnail_url = $4, banner_url = $5, content = $6, table_of_contents = $7, word_count = $8, reading_time = $9, published = 0, author_id = $10, file_checksum = $11, file_key = $12, publish_at = $13, unpublish_at = $14, updated_at = CURRENT_TIMESTAMP WHERE id = $15;`
+
+	result, err := dbFacade.ExecContext(ctx, query, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, authorId, fileChecksum, fileKey, publishAt, unpublishAt, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
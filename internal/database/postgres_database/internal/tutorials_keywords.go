@@ -0,0 +1,32 @@
+package internal
+
+import "context"
+
+// AddKeywordToTutorial adds a new keyword-tutorial association in the database. This function works with either a
+// database connection or a database transaction. This function wil NOT throw an error upon a unique constraint
+// violation.
+func AddKeywordToTutorial(ctx context.Context, dbFacade SqlDbFacade, id, keywordId, tutorialId string) error {
+	// A unique constraint violation aborts the surrounding transaction in PostgreSQL so the conflict is ignored
+	// by the query itself instead of being checked for afterwards.
+	query := `INSERT INTO tutorials_keywords (id, tutorial_id, keyword_id) VALUES ($1, $2, $3) ON CONFLICT (tutorial_id, keyword_id) DO NOTHING;`
+
+	_, err := dbFacade.ExecContext(ctx, query, id, tutorialId, keywordId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAllKeywordsFromTutorial removes all keyword associations from the tutorial with the provided ID. This function
+// works with either a database connection or a database transaction.
+func DeleteAllKeywordsFromTutorial(ctx context.Context, dbFacade SqlDbFacade, tutorialId string) error {
+	query := `DELETE FROM tutorials_keywords WHERE tutorial_id = $1;`
+
+	_, err := dbFacade.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
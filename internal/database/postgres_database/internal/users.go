@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddUser adds a new user row to the database. This function will work with either a database connection or a database
+// transaction.
+func AddUser(ctx context.Context, dbFacade SqlDbFacade, id, name, surname, slug, email, password, affiliateCode string) (*models.UserModel, error) {
+	query := `INSERT INTO users (id, name, surname, slug, email, password, affiliate_code, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+
+	user := new(models.UserModel)
+	user.ID = id
+	user.Name = name
+	user.Surname = surname
+	user.Slug = slug
+	user.Email = email
+	user.Password = password
+	user.AffiliateCode = affiliateCode
+	user.IsAdmin = false
+	user.IsAuthor = false
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = time.Now()
+	user.AffiliatePoints = 0
+
+	result, err := dbFacade.ExecContext(ctx, query, user.ID, user.Name, user.Surname, user.Slug, user.Email, user.Password, user.AffiliateCode, user.CreatedAt, user.UpdatedAt)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return nil, database.ErrUserAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		return nil, database.ErrNoRowsAffected
+	}
+
+	return user, nil
+}
+
+// GetUserByID retrieves a UserModel from the database depending on the provided ID and authorization level of the user.
+// Soft deleted accounts are only found when using Deleted or IncludeDeleted so that purchases and refunds can still be
+// traced back to them. This function will work with either a database connection or a database transaction.
+func GetUserByID(ctx context.Context, dbFacade SqlDbFacade, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE id = $1`
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	var isAdmin int
+	var isAuthor int
+	var user models.UserModel
+
+	row := dbFacade.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	user.IsAdmin = isAdmin == 1
+	user.IsAuthor = isAuthor == 1
+
+	return &user, nil
+}
+
+// GetUserByAffiliateCode finds a user row based on the given affiliate code. This function will work with either a
+// database connection or a database transaction.
+func GetUserByAffiliateCode(ctx context.Context, dbFacade SqlDbFacade, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE affiliate_code = $1`
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	user := new(models.UserModel)
+	isAdmin := 0
+	isAuthor := 0
+
+	row := dbFacade.QueryRowContext(ctx, query, affiliateCode)
+	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	user.IsAdmin = isAdmin == 1
+	user.IsAuthor = isAuthor == 1
+
+	return user, nil
+}
@@ -0,0 +1,213 @@
+package postgres_database
+
+import (
+	"context"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// DeleteExpiredTokens removes every token whose validity period has passed and returns how many tokens were removed.
+func (db *PostgresDatabase) DeleteExpiredTokens(ctx context.Context) (uint, error) {
+	query := `DELETE FROM tokens WHERE valid_until <= NOW();`
+
+	result, err := db.connection.ExecContext(ctx, query)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete expired tokens: %s\n", err)
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after deleting expired tokens: %s\n", err)
+		return 0, err
+	}
+
+	return uint(rowsAffected), nil
+}
+
+// CancelStalePurchases moves every "Pending" course purchase that is older than the given duration to "Cancelled" and
+// gives back any affiliate points that were used for the purchase. It returns how many purchases were cancelled.
+func (db *PostgresDatabase) CancelStalePurchases(ctx context.Context, olderThan time.Duration) (uint, error) {
+	query := `SELECT id, user_id, course_id, affiliate_points_used FROM course_purchases WHERE payment_status = $1 AND created_at <= NOW() - make_interval(secs => $2);`
+
+	type stalePurchase struct {
+		id                  string
+		userId              string
+		courseId            string
+		affiliatePointsUsed uint
+	}
+
+	var purchases []stalePurchase
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return 0, err
+	}
+
+	rows, err := tx.QueryContext(ctx, query, database.Pending.String(), olderThan.Seconds())
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get stale course purchases: %s\n", err)
+		return 0, err
+	}
+
+	for rows.Next() {
+		var purchase stalePurchase
+
+		if err := rows.Scan(&purchase.id, &purchase.userId, &purchase.courseId, &purchase.affiliatePointsUsed); err != nil {
+			rows.Close()
+
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to read row from course_purchases table: %s\n", err)
+			return 0, err
+		}
+
+		purchases = append(purchases, purchase)
+	}
+
+	if err := rows.Err(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get stale course purchases: %s\n", err)
+		return 0, err
+	}
+
+	var cancelled uint
+
+	for _, purchase := range purchases {
+		// A Stripe webhook might have settled the purchase since it was selected. Only the caller that actually moves
+		// the purchase out of "Pending" gets to give the affiliate points back.
+		changed, err := internal.TransitionCoursePurchasePaymentStatus(ctx, tx, purchase.id, []database.PaymentStatus{database.Pending}, database.Cancelled)
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to cancel stale course purchase (\"%s\"): %s\n", purchase.id, err)
+			return 0, err
+		}
+
+		if !changed {
+			continue
+		}
+
+		cancelled++
+
+		if purchase.affiliatePointsUsed > 0 {
+			id, err := database.GenerateID()
+			if err != nil {
+				if err := tx.Rollback(); err != nil {
+					db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+				}
+
+				db.ErrorLog.Printf("Failed to generate ID for affiliate points history: %s\n", err)
+				return 0, err
+			}
+
+			if err := internal.RegisterAffiliatePointsChange(ctx, tx, id, purchase.userId, purchase.courseId, int(purchase.affiliatePointsUsed), "Payment expired"); err != nil {
+				if err := tx.Rollback(); err != nil {
+					db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+				}
+
+				db.ErrorLog.Printf("Failed to refund affiliate points after payment expired: %s\n", err)
+				return 0, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after cancelling stale course purchases: %s\n", err)
+		return 0, err
+	}
+
+	return cancelled, nil
+}
+
+// Optimize refreshes the query planner statistics. PostgreSQL's autovacuum daemon already takes care of reclaiming
+// space so there is nothing else to do here.
+func (db *PostgresDatabase) Optimize(ctx context.Context) error {
+	if _, err := db.connection.ExecContext(ctx, `ANALYZE;`); err != nil {
+		db.ErrorLog.Printf("Failed to run database housekeeping: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// PurgeDeleted permanently removes every comment, tutorial, course and user that was soft deleted more than the given
+// duration ago and returns how many rows were removed or anonymized. Users and courses that are still referenced by
+// purchases, refunds or affiliate points history are kept so that the financial records stay intact. Those users have
+// their personal details, tokens and whitelisted IP addresses removed instead.
+func (db *PostgresDatabase) PurgeDeleted(ctx context.Context, olderThan time.Duration) (uint, error) {
+	queries := []string{
+		`DELETE FROM comments WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1);`,
+		`DELETE FROM tutorials WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1);`,
+		`DELETE FROM courses WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1) AND id NOT IN (SELECT course_id FROM course_purchases) AND id NOT IN (SELECT course_id FROM affiliate_points_history);`,
+		`DELETE FROM users WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1) AND id NOT IN (SELECT user_id FROM course_purchases) AND id NOT IN (SELECT user_id FROM refunds) AND id NOT IN (SELECT user_id FROM affiliate_points_history);`,
+		`DELETE FROM tokens WHERE user_id IN (SELECT id FROM users WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1) AND anonymized_at IS NULL);`,
+		`DELETE FROM whitelisted_ips WHERE user_id IN (SELECT id FROM users WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1) AND anonymized_at IS NULL);`,
+		`UPDATE users SET name = 'Deleted', surname = 'User', slug = id, email = id || '@deleted.invalid', password = '', anonymized_at = NOW() WHERE deleted_at IS NOT NULL AND deleted_at <= NOW() - make_interval(secs => $1) AND anonymized_at IS NULL;`,
+	}
+
+	seconds := olderThan.Seconds()
+
+	var purged uint
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return 0, err
+	}
+
+	for _, query := range queries {
+		result, err := tx.ExecContext(ctx, query, seconds)
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to purge soft deleted rows: %s\n", err)
+			db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+			return 0, err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to get rows affected after purging soft deleted rows: %s\n", err)
+			return 0, err
+		}
+
+		purged += uint(rowsAffected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after purging soft deleted rows: %s\n", err)
+		return 0, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,155 @@
+package postgres_database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func TestCancelStalePurchases(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+
+	query := `INSERT INTO course_purchases (id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_points_used, amount_paid, payment_status, created_at) VALUES ($1, $2, $3, $4, $5, $6, 0, $7, NOW() - $8::INTERVAL);`
+
+	purchases := []struct {
+		id     string
+		points uint
+		status database.PaymentStatus
+		age    string
+	}{
+		{"stale", 50, database.Pending, "2 days"},
+		{"fresh", 50, database.Pending, "1 hour"},
+		{"processing", 50, database.Processing, "2 days"},
+	}
+
+	for _, purchase := range purchases {
+		addTestCourse(t, postgresDatabase, purchase.id)
+
+		if _, err := postgresDatabase.connection.ExecContext(ctx, query, purchase.id, user.ID, purchase.id, purchase.id, purchase.id, purchase.points, purchase.status.String(), purchase.age); err != nil {
+			t.Fatalf("Failed to insert course purchase: %s", err)
+		}
+	}
+
+	cancelled, err := postgresDatabase.CancelStalePurchases(ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to cancel stale purchases: %s", err)
+	}
+
+	if cancelled != 1 {
+		t.Errorf("Expected 1 purchase to be cancelled. Got %d", cancelled)
+	}
+
+	cancelled, err = postgresDatabase.CancelStalePurchases(ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to cancel stale purchases: %s", err)
+	}
+
+	if cancelled != 0 {
+		t.Errorf("Expected a second run to cancel nothing. Got %d", cancelled)
+	}
+
+	// A late "payment_intent.canceled" webhook must not be able to close the purchase a second time.
+	err = postgresDatabase.WithTx(ctx, func(tx database.Tx) error {
+		changed, err := tx.TransitionCoursePurchasePaymentStatus(ctx, "stale", database.OpenPaymentStatuses, database.Cancelled)
+		if err != nil {
+			return err
+		}
+
+		if changed {
+			t.Error("Expected the expired purchase to be left alone by the webhook")
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to run webhook transaction: %s", err)
+	}
+
+	statuses := map[string]database.PaymentStatus{
+		"stale":      database.Cancelled,
+		"fresh":      database.Pending,
+		"processing": database.Processing,
+	}
+
+	for id, expected := range statuses {
+		purchase, err := postgresDatabase.GetCoursePurchaseByID(ctx, id)
+		if err != nil {
+			t.Fatalf("Failed to get course purchase (\"%s\"): %s", id, err)
+		}
+
+		if purchase.PaymentStatus != expected.String() {
+			t.Errorf("Expected course purchase (\"%s\") to be %q. Got %q", id, expected, purchase.PaymentStatus)
+		}
+	}
+
+	history, _, err := postgresDatabase.GetUserAffiliatePointsHistory(ctx, user.ID, nil, 10)
+	if err != nil {
+		t.Fatalf("Failed to get affiliate points history: %s", err)
+	}
+
+	if len(history) != 1 || history[0].PointsChange != 50 {
+		t.Errorf("Expected the affiliate points to be given back exactly once. Got %d history rows", len(history))
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	buyer := addTestUser(t, postgresDatabase, "buyer@example.com")
+	visitor := addTestUser(t, postgresDatabase, "visitor@example.com")
+
+	addTestCourse(t, postgresDatabase, "course")
+
+	query := `INSERT INTO course_purchases (id, user_id, course_id, payment_key, stripe_checkout_session_id, amount_paid, payment_status) VALUES ('purchase', $1, 'course', 'key', 'session', 10, $2);`
+	if _, err := postgresDatabase.connection.ExecContext(ctx, query, buyer.ID, database.Succeeded.String()); err != nil {
+		t.Fatalf("Failed to insert course purchase: %s", err)
+	}
+
+	for _, user := range []string{buyer.ID, visitor.ID} {
+		if err := postgresDatabase.DeleteUser(ctx, user); err != nil {
+			t.Fatalf("Failed to delete user: %s", err)
+		}
+	}
+
+	purged, err := postgresDatabase.PurgeDeleted(ctx, 0)
+	if err != nil {
+		t.Fatalf("Failed to purge deleted rows: %s", err)
+	}
+
+	if purged != 2 {
+		t.Errorf("Expected 1 user to be removed and 1 to be anonymized. Got %d", purged)
+	}
+
+	if user, err := postgresDatabase.GetUserByID(ctx, visitor.ID, database.IncludeDeleted); err != nil || user != nil {
+		t.Errorf("Expected the user without purchases to be removed. Got %v (%v)", user, err)
+	}
+
+	user, err := postgresDatabase.GetUserByID(ctx, buyer.ID, database.IncludeDeleted)
+	if err != nil || user == nil {
+		t.Fatalf("Expected the user with purchases to be kept. Got %v", err)
+	}
+
+	if user.Email == buyer.Email || user.Name == buyer.Name || user.Password != "" {
+		t.Errorf("Expected the kept user's personal details to be removed. Got %s %s <%s>", user.Name, user.Surname, user.Email)
+	}
+
+	if err := postgresDatabase.RestoreUser(ctx, buyer.ID); !errors.Is(err, database.ErrNoRowsAffected) {
+		t.Errorf("Expected an anonymized user to not be restorable. Got %v", err)
+	}
+
+	purged, err = postgresDatabase.PurgeDeleted(ctx, 0)
+	if err != nil {
+		t.Fatalf("Failed to purge deleted rows: %s", err)
+	}
+
+	if purged != 0 {
+		t.Errorf("Expected a second run to leave the anonymized user alone. Got %d", purged)
+	}
+}
@@ -0,0 +1,111 @@
+package postgres_database
+
+import (
+	"errors"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/golang-migrate/migrate/v4"
+)
+
+func (db *PostgresDatabase) MigrateUp() error {
+	m, err := db.SetupMigrations()
+	if err != nil {
+		return err
+	}
+
+	// Apply all up migrations.
+	if err = m.Up(); err != nil && err != migrate.ErrNoChange {
+		db.ErrorLog.Printf("Failed to run up migrations: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) MigrateDown() error {
+	m, err := db.SetupMigrations()
+	if err != nil {
+		return err
+	}
+
+	// Apply all down migrations.
+	if err = m.Down(); err != nil && err != migrate.ErrNoChange {
+		db.ErrorLog.Printf("Failed to run down migrations: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) Rollback(steps int) error {
+	m, err := db.SetupMigrations()
+	if err != nil {
+		return err
+	}
+
+	if err = m.Steps(-1 * steps); err != nil && err != migrate.ErrNoChange {
+		db.ErrorLog.Printf("Failed to rollback migrations: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) MigrateTo(version uint) error {
+	m, err := db.SetupMigrations()
+	if err != nil {
+		return err
+	}
+
+	if err = m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		db.ErrorLog.Printf("Failed to migrate to version %d: %s", version, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) ForceMigrationVersion(version int) error {
+	m, err := db.SetupMigrations()
+	if err != nil {
+		return err
+	}
+
+	if err = m.Force(version); err != nil {
+		db.ErrorLog.Printf("Failed to force migration version %d: %s", version, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// MigrationVersion returns the version that the schema is currently at and whether the last migration failed halfway
+// through. A database that hasn't been migrated yet is at version 0.
+func (db *PostgresDatabase) MigrationVersion() (uint, bool, error) {
+	m, err := db.SetupMigrations()
+	if err != nil {
+		return 0, false, err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return 0, false, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get migration version: %s", err.Error())
+		return 0, false, err
+	}
+
+	return version, dirty, nil
+}
+
+func (db *PostgresDatabase) Migrations() ([]database.Migration, error) {
+	migrations, err := database.ReadMigrations(db.migrations)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to read migrations: %s", err.Error())
+		return nil, err
+	}
+
+	return migrations, nil
+}
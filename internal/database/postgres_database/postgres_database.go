@@ -0,0 +1,49 @@
+package postgres_database
+
+import (
+	"database/sql"
+	"io/fs"
+
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type PostgresDatabase struct {
+	utils.Loggers
+	dataSourceName string
+	migrations     fs.FS
+	connection     *sql.DB
+}
+
+func CreatePostgresDatabase(dataSourceName string, migrations fs.FS) (*PostgresDatabase, error) {
+	loggers := utils.CreateLoggers("POSTGRES DATABASE")
+
+	// Open a connection to the database.
+	conn, err := sql.Open("pgx", dataSourceName)
+	if err != nil {
+		loggers.ErrorLog.Printf("Failed to connect to database: %s", err.Error())
+		return nil, err
+	}
+
+	// Verify that the connection was successful.
+	err = conn.Ping()
+	if err != nil {
+		loggers.ErrorLog.Printf("Failed to ping database: %s", err.Error())
+		return nil, err
+	}
+
+	postgresDatabase := &PostgresDatabase{
+		Loggers:        loggers,
+		dataSourceName: dataSourceName,
+		migrations:     migrations,
+		connection:     conn,
+	}
+
+	return postgresDatabase, nil
+}
+
+func (db *PostgresDatabase) Close() error {
+	db.InfoLog.Println("Closing database connection")
+
+	return db.connection.Close()
+}
@@ -0,0 +1,125 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/db"
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+	"github.com/jackc/pgx/v5"
+)
+
+func TestCreatePostgresDatabase(t *testing.T) {
+	postgresDatabase := newTestDatabase(t)
+
+	version, dirty, err := postgresDatabase.MigrationVersion()
+	if err != nil {
+		t.Fatalf("Failed to get migration version: %s", err)
+	}
+
+	if version == 0 || dirty {
+		t.Errorf("Expected the database to be fully migrated. Got version %d (dirty: %t)", version, dirty)
+	}
+
+	// Running the migrations a second time shouldn't change anything.
+	if err := postgresDatabase.MigrateUp(); err != nil {
+		t.Errorf("Failed to migrate an up to date database: %s", err)
+	}
+}
+
+// newTestDatabase creates a fully migrated Postgres database in its own schema that gets dropped once the test is
+// done. The tests need a running Postgres server so they get skipped unless POSTGRES_TEST_DSN holds the connection
+// string of a database that the tests are allowed to create schemas in.
+func newTestDatabase(t *testing.T) *PostgresDatabase {
+	t.Helper()
+
+	dataSourceName := os.Getenv("POSTGRES_TEST_DSN")
+	if dataSourceName == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	id, err := database.GenerateID()
+	if err != nil {
+		t.Fatalf("Failed to generate schema name: %s", err)
+	}
+
+	schema := "test_" + strings.ToLower(id)
+
+	admin, err := sql.Open("pgx", dataSourceName)
+	if err != nil {
+		t.Fatalf("Failed to connect to Postgres: %s", err)
+	}
+
+	t.Cleanup(func() {
+		admin.Close()
+	})
+
+	if _, err := admin.Exec(`CREATE SCHEMA ` + pgx.Identifier{schema}.Sanitize() + `;`); err != nil {
+		t.Fatalf("Failed to create schema: %s", err)
+	}
+
+	t.Cleanup(func() {
+		if _, err := admin.Exec(`DROP SCHEMA ` + pgx.Identifier{schema}.Sanitize() + ` CASCADE;`); err != nil {
+			t.Errorf("Failed to drop schema: %s", err)
+		}
+	})
+
+	// Every connection in the pool needs to use the new schema, so it gets set as a runtime parameter.
+	if strings.Contains(dataSourceName, "://") {
+		separator := "?"
+		if strings.Contains(dataSourceName, "?") {
+			separator = "&"
+		}
+
+		dataSourceName += separator + "search_path=" + schema
+	} else {
+		dataSourceName += " search_path=" + schema
+	}
+
+	postgresDatabase, err := CreatePostgresDatabase(dataSourceName, db.PostgresMigrations())
+	if err != nil {
+		t.Fatalf("Failed to create Postgres database: %s", err)
+	}
+
+	t.Cleanup(func() {
+		postgresDatabase.Close()
+	})
+
+	if err := postgresDatabase.MigrateUp(); err != nil {
+		t.Fatalf("Failed to migrate Postgres database: %s", err)
+	}
+
+	return postgresDatabase
+}
+
+// addTestUser signs up a new user with the given email address and returns them.
+func addTestUser(t *testing.T, postgresDatabase *PostgresDatabase, email string) *models.UserModel {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if err := postgresDatabase.NewUser(ctx, "Jane", "Doe", email, "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := postgresDatabase.GetUserByEmail(ctx, email, database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	return user
+}
+
+// addTestCourse adds an unpublished course whose ID, title, slug and file key are all the given ID.
+func addTestCourse(t *testing.T, postgresDatabase *PostgresDatabase, id string) {
+	t.Helper()
+
+	if err := internal.AddCourse(context.Background(), postgresDatabase.connection, id, id, id, "", "", "", "", "", id, sql.NullTime{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add course: %s", err)
+	}
+}
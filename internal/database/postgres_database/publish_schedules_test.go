@@ -0,0 +1,48 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func TestRunPublishSchedules(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	// Frontmatter times can have any offset. They need to be compared against the database's clock as the same
+	// instant.
+	courses := map[string]time.Time{
+		"due":     time.Now().Add(-30 * time.Minute).In(time.FixedZone("", 2*60*60)),
+		"not-due": time.Now().Add(30 * time.Minute).In(time.FixedZone("", -5*60*60)),
+	}
+
+	for id, publishAt := range courses {
+		if err := internal.AddCourse(ctx, postgresDatabase.connection, id, id, id, "", "", "", "", "", id, sql.NullTime{Time: publishAt, Valid: true}, sql.NullTime{}); err != nil {
+			t.Fatalf("Failed to add course: %s", err)
+		}
+	}
+
+	changed, err := postgresDatabase.RunPublishSchedules(ctx)
+	if err != nil {
+		t.Fatalf("Failed to run publish schedules: %s", err)
+	}
+
+	if changed != 1 {
+		t.Errorf("Expected 1 course to be published. Got %d", changed)
+	}
+
+	for id := range courses {
+		course, err := postgresDatabase.GetCourseByID(ctx, id)
+		if err != nil || course == nil {
+			t.Fatalf("Failed to get course (\"%s\"): %v", id, err)
+		}
+
+		if course.Published != (id == "due") {
+			t.Errorf("Expected course (\"%s\") published to be %t. Got %t", id, id == "due", course.Published)
+		}
+	}
+}
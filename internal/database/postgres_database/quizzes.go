@@ -0,0 +1,227 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// GetQuizByChapterID gets the quiz of a chapter along with its questions in the order that they should be asked. Nil
+// is returned if the chapter doesn't have a quiz.
+func (db *PostgresDatabase) GetQuizByChapterID(ctx context.Context, chapterId string) (*models.QuizModel, error) {
+	query := `SELECT id, chapter_id, pass_mark, created_at, updated_at FROM quizzes WHERE chapter_id = $1;`
+
+	quiz := new(models.QuizModel)
+
+	if err := db.connection.QueryRowContext(ctx, query, chapterId).Scan(&quiz.ID, &quiz.ChapterID, &quiz.PassMark, &quiz.CreatedAt, &quiz.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get quiz of chapter (\"%s\") from the database: %s\n", chapterId, err)
+		return nil, err
+	}
+
+	query = `SELECT id, quiz_id, position, kind, question, options, answers FROM quiz_questions WHERE quiz_id = $1 ORDER BY position ASC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, quiz.ID)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for the questions of quiz (\"%s\"): %s\n", quiz.ID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var question models.QuizQuestionModel
+
+		if err := rows.Scan(&question.ID, &question.QuizID, &question.Position, &question.Kind, &question.Question, &question.Options, &question.Answers); err != nil {
+			db.ErrorLog.Printf("Failed to scan quiz_questions row from the database: %s\n", err)
+			return nil, err
+		}
+
+		quiz.Questions = append(quiz.Questions, &question)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all quiz_questions rows: %s\n", err)
+		return nil, err
+	}
+
+	return quiz, nil
+}
+
+// AddQuizAttempt stores a user's attempt at a quiz along with the answers that they gave.
+func (db *PostgresDatabase) AddQuizAttempt(ctx context.Context, userId, quizId string, score int, passed bool, answers []*models.QuizAttemptAnswerModel) error {
+	attemptId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new quiz attempt: %s\n", err)
+		return err
+	}
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	if err := internal.AddQuizAttempt(ctx, tx, attemptId, quizId, userId, score, passed); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to insert new row to quiz_attempts table: %s\n", err)
+		return err
+	}
+
+	for _, answer := range answers {
+		answerId, err := database.GenerateID()
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to generate ID for new quiz attempt answer: %s\n", err)
+			return err
+		}
+
+		if err := internal.AddQuizAttemptAnswer(ctx, tx, answerId, attemptId, answer.QuestionID, answer.Answer, answer.Correct); err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to insert new row to quiz_attempt_answers table: %s\n", err)
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after adding quiz attempt: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetQuizAttempts gets a user's attempts at a quiz, newest first.
+func (db *PostgresDatabase) GetQuizAttempts(ctx context.Context, userId, quizId string) ([]*models.QuizAttemptModel, error) {
+	query := `SELECT id, quiz_id, user_id, score, passed, created_at FROM quiz_attempts WHERE user_id = $1 AND quiz_id = $2 ORDER BY created_at DESC, id DESC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, userId, quizId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for user's (\"%s\") attempts at quiz (\"%s\"): %s\n", userId, quizId, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []*models.QuizAttemptModel
+
+	for rows.Next() {
+		var attempt models.QuizAttemptModel
+		var passed int
+
+		if err := rows.Scan(&attempt.ID, &attempt.QuizID, &attempt.UserID, &attempt.Score, &passed, &attempt.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to scan quiz_attempts row from the database: %s\n", err)
+			return nil, err
+		}
+
+		attempt.Passed = passed == 1
+
+		attempts = append(attempts, &attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all quiz_attempts rows: %s\n", err)
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// GetQuizAttemptAnswers gets the answers that were given in a quiz attempt in the order of the questions.
+func (db *PostgresDatabase) GetQuizAttemptAnswers(ctx context.Context, attemptId string) ([]*models.QuizAttemptAnswerModel, error) {
+	query := `SELECT a.id, a.attempt_id, a.question_id, a.answer, a.correct FROM quiz_attempt_answers AS a JOIN quiz_questions AS q ON q.id = a.question_id WHERE a.attempt_id = $1 ORDER BY q.position ASC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, attemptId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for the answers of quiz attempt (\"%s\"): %s\n", attemptId, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []*models.QuizAttemptAnswerModel
+
+	for rows.Next() {
+		var answer models.QuizAttemptAnswerModel
+		var correct int
+
+		if err := rows.Scan(&answer.ID, &answer.AttemptID, &answer.QuestionID, &answer.Answer, &correct); err != nil {
+			db.ErrorLog.Printf("Failed to scan quiz_attempt_answers row from the database: %s\n", err)
+			return nil, err
+		}
+
+		answer.Correct = correct == 1
+
+		answers = append(answers, &answer)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all quiz_attempt_answers rows: %s\n", err)
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// HasUserPassedQuiz checks whether any of a user's attempts at a quiz reached its pass mark.
+func (db *PostgresDatabase) HasUserPassedQuiz(ctx context.Context, userId, quizId string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM quiz_attempts WHERE user_id = $1 AND quiz_id = $2 AND passed = 1);`
+
+	var passed bool
+
+	if err := db.connection.QueryRowContext(ctx, query, userId, quizId).Scan(&passed); err != nil {
+		db.ErrorLog.Printf("Failed to check if user (\"%s\") has passed quiz (\"%s\"): %s\n", userId, quizId, err)
+		return false, err
+	}
+
+	return passed, nil
+}
+
+// GetQuizQuestionStats gets how often each question of a quiz has been answered and how many of those answers were
+// correct, in the order that the questions are asked.
+func (db *PostgresDatabase) GetQuizQuestionStats(ctx context.Context, quizId string) ([]*models.QuizQuestionStatsModel, error) {
+	query := `SELECT q.id, q.position, q.kind, q.question, COUNT(a.id), COALESCE(SUM(a.correct), 0) FROM quiz_questions AS q LEFT JOIN quiz_attempt_answers AS a ON a.question_id = q.id WHERE q.quiz_id = $1 GROUP BY q.id, q.position, q.kind, q.question ORDER BY q.position ASC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, quizId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for the question statistics of quiz (\"%s\"): %s\n", quizId, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*models.QuizQuestionStatsModel
+
+	for rows.Next() {
+		var stat models.QuizQuestionStatsModel
+
+		if err := rows.Scan(&stat.QuestionID, &stat.Position, &stat.Kind, &stat.Question, &stat.Answers, &stat.Correct); err != nil {
+			db.ErrorLog.Printf("Failed to scan quiz question statistics row from the database: %s\n", err)
+			return nil, err
+		}
+
+		stats = append(stats, &stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all quiz question statistics rows: %s\n", err)
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,68 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func TestGetQuizAttemptAnswers(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+	addTestCourse(t, postgresDatabase, "course")
+
+	if err := internal.AddChapter(ctx, postgresDatabase.connection, "chapter", "Chapter", "chapter", 1, "", nil, 0, 0, "", "chapter", "course", sql.NullInt32{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add chapter: %s", err)
+	}
+
+	quizId, err := internal.AddQuiz(ctx, postgresDatabase.connection, "quiz", "chapter", 50)
+	if err != nil {
+		t.Fatalf("Failed to add quiz: %s", err)
+	}
+
+	if err := internal.AddQuizQuestion(ctx, postgresDatabase.connection, "first", quizId, 1, models.MultipleChoiceQuestion, "First?", models.QuizAnswers{"a", "b"}, models.QuizAnswers{"a"}); err != nil {
+		t.Fatalf("Failed to add quiz question: %s", err)
+	}
+
+	if err := internal.AddQuizQuestion(ctx, postgresDatabase.connection, "second", quizId, 2, models.ShortTextQuestion, "Second?", nil, models.QuizAnswers{"go"}); err != nil {
+		t.Fatalf("Failed to add quiz question: %s", err)
+	}
+
+	// The answers are given out of order to make sure that they come back in the order of the questions.
+	given := []*models.QuizAttemptAnswerModel{
+		{QuestionID: "second", Answer: models.QuizAnswers{"Go"}, Correct: true},
+		{QuestionID: "first", Answer: models.QuizAnswers{"b"}, Correct: false},
+	}
+
+	if err := postgresDatabase.AddQuizAttempt(ctx, user.ID, quizId, 50, true, given); err != nil {
+		t.Fatalf("Failed to add quiz attempt: %s", err)
+	}
+
+	attempts, err := postgresDatabase.GetQuizAttempts(ctx, user.ID, quizId)
+	if err != nil || len(attempts) != 1 {
+		t.Fatalf("Expected 1 quiz attempt. Got %d (%v)", len(attempts), err)
+	}
+
+	answers, err := postgresDatabase.GetQuizAttemptAnswers(ctx, attempts[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get quiz attempt answers: %s", err)
+	}
+
+	if len(answers) != 2 {
+		t.Fatalf("Expected 2 answers. Got %d", len(answers))
+	}
+
+	if answers[0].QuestionID != "first" || answers[0].Correct || !slices.Equal(answers[0].Answer, models.QuizAnswers{"b"}) {
+		t.Errorf("Expected the answer to the first question to come first. Got %+v", answers[0])
+	}
+
+	if answers[1].QuestionID != "second" || !answers[1].Correct || !slices.Equal(answers[1].Answer, models.QuizAnswers{"Go"}) {
+		t.Errorf("Expected the answer to the second question to come second. Got %+v", answers[1])
+	}
+}
@@ -0,0 +1,115 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) AdminGetRefunds(ctx context.Context, term string, status string, cursor *database.Cursor, elements uint) ([]*models.RefundModel, *database.Cursor, error) {
+	query := `SELECT r.id, r.user_id, r.course_purchase_id, r.refund_status, r.created_at, r.updated_at FROM refunds AS r LEFT JOIN users AS u ON r.user_id = u.id LEFT JOIN course_purchases AS cp ON r.course_purchase_id = cp.id LEFT JOIN courses AS c ON cp.course_id = c.id WHERE 1=1`
+	args := []any{}
+
+	if term != "" {
+		query += fmt.Sprintf(" AND (LOWER(r.id) ILIKE '%%' || $%d || '%%' OR LOWER(u.name) ILIKE '%%' || $%d || '%%' OR LOWER(u.surname) ILIKE '%%' || $%d || '%%' OR LOWER(c.title) ILIKE '%%' || $%d || '%%')", len(args)+1, len(args)+2, len(args)+3, len(args)+4)
+		args = append(args, term, term, term, term)
+	}
+
+	if status != "" {
+		query += fmt.Sprintf(" AND r.refund_status = $%d", len(args)+1)
+		args = append(args, status)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("r", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY r.created_at DESC, r.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var refunds []*models.RefundModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all refunds from the database: %s\n", err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var refund models.RefundModel
+
+		if err := rows.Scan(&refund.ID, &refund.UserID, &refund.CoursePurchaseID, &refund.RefundStatus, &refund.CreatedAt, &refund.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from refunds table: %s\n", err)
+			return nil, nil, err
+		}
+
+		refunds = append(refunds, &refund)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all refunds from the database: %s\n", err)
+		return nil, nil, err
+	}
+
+	refunds, next := database.Paginate(refunds, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(refunds[i].CreatedAt, refunds[i].ID)
+	})
+
+	return refunds, next, nil
+}
+
+func (db *PostgresDatabase) RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status database.RefundStatus) error {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new refund: %s\n", err)
+		return err
+	}
+
+	if err := internal.RegisterRefund(ctx, db.connection, id, userId, coursePurchaseId, status); err != nil {
+		db.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error) {
+	refund, err := internal.GetRefundWithCoursePurchaseID(ctx, db.connection, coursePurchaseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchaseId, err)
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (db *PostgresDatabase) UpdateRefundStatus(ctx context.Context, refundId string, status database.RefundStatus) error {
+	if err := internal.UpdateRefundStatus(ctx, db.connection, refundId, status); err != nil {
+		db.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) CountRefunds(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM refunds;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number of refunds in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,31 @@
+package postgres_database
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+// GetTutorialSearchSnippets returns a highlighted excerpt of each tutorial's content that matches the search term,
+// keyed by tutorial ID. Tutorials whose content didn't match the search term are left out.
+func (db *PostgresDatabase) GetTutorialSearchSnippets(ctx context.Context, term string, tutorialIds []string) (map[string]string, error) {
+	snippets, err := internal.GetSearchSnippets(ctx, db.connection, "tutorials_fts", internal.FullTextQuery(term), tutorialIds)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get search snippets for tutorials that match the search term \"%s\": %s\n", term, err)
+		return nil, err
+	}
+
+	return snippets, nil
+}
+
+// GetCourseSearchSnippets returns a highlighted excerpt of each course's content that matches the search term, keyed
+// by course ID. Courses whose content didn't match the search term are left out.
+func (db *PostgresDatabase) GetCourseSearchSnippets(ctx context.Context, term string, courseIds []string) (map[string]string, error) {
+	snippets, err := internal.GetSearchSnippets(ctx, db.connection, "courses_fts", internal.FullTextQuery(term), courseIds)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get search snippets for courses that match the search term \"%s\": %s\n", term, err)
+		return nil, err
+	}
+
+	return snippets, nil
+}
@@ -0,0 +1,127 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+	"github.com/PsionicAlch/course-platform/internal/searchmark"
+)
+
+func TestGetTutorialSearchSnippets(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	author := addTestUser(t, postgresDatabase, "author@example.com")
+
+	tutorials := map[string]string{
+		"channels":   "Channels let goroutines talk to each other without sharing memory.",
+		"goroutines": "Goroutines are cheap threads that the Go runtime schedules for you.",
+		"generics":   "Generics let you write functions that work with many types.",
+	}
+
+	for id, content := range tutorials {
+		if err := internal.AddTutorial(ctx, postgresDatabase.connection, id, id, id, "", "", "", content, nil, 0, 0, "", id, sql.NullTime{}, sql.NullTime{}); err != nil {
+			t.Fatalf("Failed to add tutorial: %s", err)
+		}
+	}
+
+	if _, err := postgresDatabase.connection.ExecContext(ctx, `UPDATE tutorials SET published = 1, author_id = $1;`, author.ID); err != nil {
+		t.Fatalf("Failed to publish tutorials: %s", err)
+	}
+
+	if err := internal.RebuildTutorialsIndex(ctx, postgresDatabase.connection); err != nil {
+		t.Fatalf("Failed to rebuild tutorials index: %s", err)
+	}
+
+	snippets, err := postgresDatabase.GetTutorialSearchSnippets(ctx, "goroutine", []string{"channels", "goroutines", "generics"})
+	if err != nil {
+		t.Fatalf("Failed to get tutorial search snippets: %s", err)
+	}
+
+	if len(snippets) != 2 {
+		t.Errorf("Expected only the tutorials that mention goroutines to have snippets. Got %d snippets", len(snippets))
+	}
+
+	if !strings.Contains(snippets["goroutines"], searchmark.Start) || !strings.Contains(snippets["goroutines"], searchmark.End) {
+		t.Errorf("Expected the matched term to be highlighted. Got %q", snippets["goroutines"])
+	}
+
+	// Search results are ordered by rank so paging through them needs to use the ranked cursor.
+	seen := make(map[string]bool)
+
+	var cursor *database.Cursor
+	for pages := 0; ; pages++ {
+		if pages > 2 {
+			t.Fatal("Expected pagination to stop once every search result was returned")
+		}
+
+		results, next, err := postgresDatabase.GetTutorials(ctx, "goroutine", "", cursor, 1)
+		if err != nil {
+			t.Fatalf("Failed to search tutorials: %s", err)
+		}
+
+		for _, tutorial := range results {
+			if seen[tutorial.ID] {
+				t.Errorf("Expected every search result to show up once. Got tutorial (\"%s\") twice", tutorial.ID)
+			}
+
+			seen[tutorial.ID] = true
+		}
+
+		if next == nil {
+			break
+		}
+
+		cursor = next
+	}
+
+	if len(seen) != 2 || seen["generics"] {
+		t.Errorf("Expected the search to return the 2 tutorials that mention goroutines. Got %v", seen)
+	}
+}
+
+func TestGetCourseSearchSnippets(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	courses := map[string]string{
+		"concurrency": "Learn how to use goroutines and channels to build concurrent programs.",
+		"web":         "Build a web application with templates and a database.",
+	}
+
+	for id, content := range courses {
+		if err := internal.AddCourse(ctx, postgresDatabase.connection, id, id, id, "", "", "", content, "", id, sql.NullTime{}, sql.NullTime{}); err != nil {
+			t.Fatalf("Failed to add course: %s", err)
+		}
+	}
+
+	if err := internal.RebuildCoursesIndex(ctx, postgresDatabase.connection); err != nil {
+		t.Fatalf("Failed to rebuild courses index: %s", err)
+	}
+
+	snippets, err := postgresDatabase.GetCourseSearchSnippets(ctx, "channels", []string{"concurrency", "web"})
+	if err != nil {
+		t.Fatalf("Failed to get course search snippets: %s", err)
+	}
+
+	if _, has := snippets["web"]; has || len(snippets) != 1 {
+		t.Errorf("Expected only the course that mentions channels to have a snippet. Got %v", snippets)
+	}
+
+	if !strings.Contains(snippets["concurrency"], searchmark.Start+"channels"+searchmark.End) {
+		t.Errorf("Expected the matched term to be highlighted. Got %q", snippets["concurrency"])
+	}
+
+	snippets, err = postgresDatabase.GetCourseSearchSnippets(ctx, "   ", []string{"concurrency", "web"})
+	if err != nil {
+		t.Fatalf("Failed to get course search snippets: %s", err)
+	}
+
+	if len(snippets) != 0 {
+		t.Errorf("Expected a blank search term to not match anything. Got %d snippets", len(snippets))
+	}
+}
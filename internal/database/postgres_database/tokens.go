@@ -0,0 +1,70 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) AddToken(ctx context.Context, token, tokenType, userId string, validUntil time.Time) error {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new ID for %s token: %s\n", tokenType, err)
+		return err
+	}
+
+	err = internal.AddToken(ctx, db.connection, id, token, tokenType, userId, validUntil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to save %s token to the database: %s\n", tokenType, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) GetToken(ctx context.Context, token, tokenType string) (*models.TokenModel, error) {
+	query := `SELECT id, token, token_type, valid_until, created_at, user_id FROM tokens WHERE token = $1 AND token_type = $2;`
+
+	tokenStruct := new(models.TokenModel)
+
+	row := db.connection.QueryRowContext(ctx, query, token, tokenType)
+
+	if err := row.Scan(&tokenStruct.ID, &tokenStruct.Token, &tokenStruct.TokenType, &tokenStruct.ValidUntil, &tokenStruct.CreatedAt, &tokenStruct.UserID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get %s token from the database: %s\n", tokenType, err)
+		return nil, err
+	}
+
+	return tokenStruct, nil
+}
+
+func (db *PostgresDatabase) DeleteToken(ctx context.Context, token, tokenType string) error {
+	query := `DELETE FROM tokens WHERE token = $1 AND token_type = $2;`
+
+	_, err := db.connection.ExecContext(ctx, query, token, tokenType)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete token from database: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) DeleteAllTokens(ctx context.Context, email, tokenType string) error {
+	query := `DELETE FROM tokens WHERE token_type = $1 AND user_id IN (SELECT id FROM users WHERE email = $2);`
+
+	_, err := db.connection.ExecContext(ctx, query, tokenType, email)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete all user's (\"%s\") %s tokens from database: %s\n", email, tokenType, err)
+		return err
+	}
+
+	return nil
+}
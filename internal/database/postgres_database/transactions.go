@@ -0,0 +1,251 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+)
+
+// WithTx runs fn as a single unit of work. Every database call made through the provided database.Tx gets committed
+// together when fn returns nil. If fn returns an error (or panics) all the changes get rolled back.
+func (db *PostgresDatabase) WithTx(ctx context.Context, fn func(tx database.Tx) error) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after panic occurred: %s\n", err)
+			}
+
+			panic(p)
+		}
+	}()
+
+	if err := fn(&PostgresTx{Loggers: db.Loggers, tx: tx}); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		db.ErrorLog.Printf("Failed to commit changes: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// PostgresTx is the implementation of database.Tx that gets handed to the function passed to WithTx.
+type PostgresTx struct {
+	utils.Loggers
+	tx *sql.Tx
+}
+
+func (db *PostgresTx) GetUserByID(ctx context.Context, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	user, err := internal.GetUserByID(ctx, db.tx, id, level)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get user by ID (\"%s\") from the database: %s\n", id, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (db *PostgresTx) GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	user, err := internal.GetUserByAffiliateCode(ctx, db.tx, affiliateCode, level)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query the database for user with affiliate code (\"%s\"): %s\n", affiliateCode, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (db *PostgresTx) GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error) {
+	course, err := internal.GetCourseByID(ctx, db.tx, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", courseId, err)
+		return nil, err
+	}
+
+	return course, nil
+}
+
+func (db *PostgresTx) AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error) {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new discount: %s\n", err)
+		return "", err
+	}
+
+	code, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate code for new discount: %s\n", err)
+		return "", err
+	}
+
+	if err := internal.AddDiscount(ctx, db.tx, id, title, description, code, discount, uses); err != nil {
+		db.ErrorLog.Printf("Failed to add new discount \"%s\" to the database: %s\n", title, err)
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (db *PostgresTx) GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error) {
+	discount, err := internal.GetDiscountByID(ctx, db.tx, discountId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get discount \"%s\" from the database: %s\n", discountId, err)
+		return nil, err
+	}
+
+	return discount, nil
+}
+
+func (db *PostgresTx) ActivateDiscount(ctx context.Context, discountId string) error {
+	if err := internal.ActivateDiscount(ctx, db.tx, discountId); err != nil {
+		db.ErrorLog.Printf("Failed to update discount \"%s\" active status: %s\n", discountId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresTx) RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+	purchaseId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new course purchase: %s\n", err)
+		return err
+	}
+
+	affiliateHistoryId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new affiliate points history: %s\n", err)
+		return err
+	}
+
+	paymentTokenId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new payment token: %s\n", err)
+		return err
+	}
+
+	if err := internal.RegisterCoursePurchase(ctx, db.tx, purchaseId, affiliateHistoryId, paymentTokenId, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil); err != nil {
+		if err != database.ErrCourseAlreadyOwned && err != database.ErrInsufficientAffiliatePoints {
+			db.ErrorLog.Printf("Failed to register course purchase: %s\n", err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresTx) GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error) {
+	coursePurchase, err := internal.GetCoursePurchaseByPaymentKey(ctx, db.tx, paymentKey)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to find course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+		return nil, err
+	}
+
+	return coursePurchase, nil
+}
+
+func (db *PostgresTx) GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	coursePurchase, err := internal.GetCoursePurchaseByCheckoutSession(ctx, db.tx, checkoutSessionId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to find course purchase by stripe checkout session ID (\"%s\"): %s\n", checkoutSessionId, err)
+		return nil, err
+	}
+
+	return coursePurchase, nil
+}
+
+func (db *PostgresTx) UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status database.PaymentStatus) error {
+	if err := internal.UpdateCoursePurchasePaymentStatus(ctx, db.tx, coursePurchaseId, status); err != nil {
+		db.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresTx) TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	changed, err := internal.TransitionCoursePurchasePaymentStatus(ctx, db.tx, coursePurchaseId, from, to)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
+		return false, err
+	}
+
+	return changed, nil
+}
+
+func (db *PostgresTx) GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	coursePurchases, err := internal.GetCoursePurchasesByUserAndCourse(ctx, db.tx, userId, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get course purchases for user (\"%s\") and course (\"%s\"): %s\n", userId, courseId, err)
+		return nil, err
+	}
+
+	return coursePurchases, nil
+}
+
+func (db *PostgresTx) RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for affiliate points history: %s\n", err)
+		return err
+	}
+
+	if err := internal.RegisterAffiliatePointsChange(ctx, db.tx, id, userId, courseId, pointsChange, reason); err != nil {
+		db.ErrorLog.Printf("Failed to save affiliate point change to database: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresTx) RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status database.RefundStatus) error {
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new refund: %s\n", err)
+		return err
+	}
+
+	if err := internal.RegisterRefund(ctx, db.tx, id, userId, coursePurchaseId, status); err != nil {
+		db.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresTx) GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error) {
+	refund, err := internal.GetRefundWithCoursePurchaseID(ctx, db.tx, coursePurchaseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchaseId, err)
+		return nil, err
+	}
+
+	return refund, nil
+}
+
+func (db *PostgresTx) UpdateRefundStatus(ctx context.Context, refundId string, status database.RefundStatus) error {
+	if err := internal.UpdateRefundStatus(ctx, db.tx, refundId, status); err != nil {
+		db.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundId, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package postgres_database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	var committed string
+
+	err := postgresDatabase.WithTx(ctx, func(tx database.Tx) error {
+		id, err := tx.AddDiscount(ctx, "Committed", "", 10, 1)
+		committed = id
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Failed to run transaction: %s", err)
+	}
+
+	if discount, err := postgresDatabase.GetDiscountByID(ctx, committed); err != nil || discount == nil {
+		t.Errorf("Expected the discount to be saved once the transaction was committed. Got %v", err)
+	}
+
+	var rolledBack string
+	expected := errors.New("something went wrong")
+
+	err = postgresDatabase.WithTx(ctx, func(tx database.Tx) error {
+		id, err := tx.AddDiscount(ctx, "Rolled back", "", 10, 1)
+		if err != nil {
+			return err
+		}
+
+		rolledBack = id
+
+		return expected
+	})
+	if !errors.Is(err, expected) {
+		t.Fatalf("Expected the transaction to return the error from the function. Got %v", err)
+	}
+
+	if discount, err := postgresDatabase.GetDiscountByID(ctx, rolledBack); err != nil || discount != nil {
+		t.Errorf("Expected the discount to be removed once the transaction was rolled back. Got %v (%v)", discount, err)
+	}
+}
@@ -0,0 +1,501 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) AdminGetTutorials(ctx context.Context, term string, published *bool, authorId *string, likedByUser, bookmarkedByUser, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	query := `SELECT DISTINCT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at FROM tutorials AS t LEFT JOIN tutorials_likes AS tl ON t.id = tl.tutorial_id LEFT JOIN tutorials_bookmarks AS tb ON t.id = tb.tutorial_id LEFT JOIN tutorials_keywords AS tk ON t.id = tk.tutorial_id LEFT JOIN keywords AS k ON tk.keyword_id = k.id WHERE 1=1`
+	args := []any{}
+
+	if deleted {
+		query += " AND t.deleted_at IS NOT NULL"
+	} else {
+		query += " AND t.deleted_at IS NULL"
+	}
+
+	if term != "" {
+		if match := internal.FullTextQuery(term); match != "" {
+			query += fmt.Sprintf(" AND (t.id = $%d OR t.id IN (SELECT id FROM tutorials_fts WHERE document @@ to_tsquery('english', $%d)))", len(args)+1, len(args)+2)
+			args = append(args, term, match)
+		} else {
+			query += fmt.Sprintf(" AND t.id = $%d", len(args)+1)
+			args = append(args, term)
+		}
+	}
+
+	if published != nil {
+		query += fmt.Sprintf(" AND t.published = $%d", len(args)+1)
+
+		var pubInt int
+		if *published {
+			pubInt = 1
+		} else {
+			pubInt = 0
+		}
+
+		args = append(args, pubInt)
+	}
+
+	if authorId != nil {
+		if *authorId != "" {
+			query += fmt.Sprintf(" AND t.author_id = $%d", len(args)+1)
+			args = append(args, *authorId)
+		}
+	} else {
+		query += " AND t.author_id IS NULL"
+	}
+
+	if likedByUser != "" {
+		query += fmt.Sprintf(" AND tl.user_id = $%d", len(args)+1)
+		args = append(args, likedByUser)
+	}
+
+	if bookmarkedByUser != "" {
+		query += fmt.Sprintf(" AND tb.user_id = $%d", len(args)+1)
+		args = append(args, bookmarkedByUser)
+	}
+
+	if keyword != "" {
+		query += fmt.Sprintf(" AND k.keyword ILIKE '%%' || $%d || '%%'", len(args)+1)
+		args = append(args, keyword)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("t", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY t.created_at DESC, t.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var tutorials []*models.TutorialModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all tutorials from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var tutorial models.TutorialModel
+		var published int
+
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from tutorials table: %s\n", err)
+			return nil, nil, err
+		}
+
+		tutorial.Published = published == 1
+
+		tutorials = append(tutorials, &tutorial)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all tutorials from the database: %s\n", err)
+		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+		return nil, nil, err
+	}
+
+	tutorials, next := database.Paginate(tutorials, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(tutorials[i].CreatedAt, tutorials[i].ID)
+	})
+
+	return tutorials, next, nil
+}
+
+func (db *PostgresDatabase) GetAllTutorials(ctx context.Context, authorId string, published *bool) ([]*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE 1=1`
+	args := []any{}
+
+	if authorId != "" {
+		query += fmt.Sprintf(" AND author_id = $%d", len(args)+1)
+		args = append(args, authorId)
+	}
+
+	// Soft deleted tutorials are only left out when filtering on their publish status because the content command
+	// still needs to find them by file key.
+	if published != nil {
+		query += fmt.Sprintf(" AND deleted_at IS NULL AND published = $%d", len(args)+1)
+
+		if *published {
+			query += " AND author_id IN (SELECT id FROM users WHERE deleted_at IS NULL)"
+			args = append(args, 1)
+		} else {
+			args = append(args, 0)
+		}
+	}
+
+	query += " ORDER BY updated_at DESC, title ASC;"
+
+	var tutorials []*models.TutorialModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for all tutorials: %s\n", err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var tutorial models.TutorialModel
+		var publishedInt int
+
+		published := false
+
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to scan tutorials row from the database: %s\n", err)
+			return nil, err
+		}
+
+		if publishedInt == 1 {
+			published = true
+		}
+
+		tutorial.Published = published
+
+		tutorials = append(tutorials, &tutorial)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all tutorials rows: %s\n", err)
+		return nil, err
+	}
+
+	return tutorials, err
+}
+
+func (db *PostgresDatabase) GetTutorials(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.TutorialModel, *database.Cursor, error) {
+	query := `SELECT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at`
+	args := []any{}
+
+	match := internal.FullTextQuery(term)
+	rank := "ts_rank(tutorials_fts.document, to_tsquery('english', $1))"
+	if match != "" {
+		query += ", " + rank + " FROM tutorials AS t JOIN tutorials_fts ON tutorials_fts.id = t.id WHERE tutorials_fts.document @@ to_tsquery('english', $1) AND"
+		args = append(args, match)
+	} else {
+		query += ", 0.0 FROM tutorials AS t WHERE"
+	}
+
+	query += " t.published = 1 AND t.author_id IN (SELECT id FROM users WHERE deleted_at IS NULL) AND t.deleted_at IS NULL"
+
+	if authorId != "" {
+		query += fmt.Sprintf(" AND t.author_id = $%d", len(args)+1)
+		args = append(args, authorId)
+	}
+
+	// Search results are ordered by relevance so the cursor needs to keep track of the rank of the last result.
+	if match != "" {
+		condition, cursorArgs := internal.AfterRankedCursor(rank, "t", cursor, len(args)+1)
+		query += condition + " ORDER BY " + rank + " DESC, t.id DESC"
+		args = append(args, cursorArgs...)
+	} else {
+		condition, cursorArgs := internal.AfterCursor("t", cursor, len(args)+1)
+		query += condition + " ORDER BY t.created_at DESC, t.id DESC"
+		args = append(args, cursorArgs...)
+	}
+
+	query += fmt.Sprintf(" LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var tutorials []*models.TutorialModel
+	var ranks []float64
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get the next page of tutorials, that match the search term \"%s\", from the database: %s\n", term, err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var tutorial models.TutorialModel
+		var published int
+		var rank float64
+
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt, &rank); err != nil {
+			db.ErrorLog.Printf("Failed to read row from tutorials table, that match the search term \"%s\": %s\n", term, err)
+			return nil, nil, err
+		}
+
+		if published == 1 {
+			tutorial.Published = true
+		}
+
+		tutorials = append(tutorials, &tutorial)
+		ranks = append(ranks, rank)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get the next page of tutorials, that match the search term \"%s\", from the database: %s\n", term, err)
+		return nil, nil, err
+	}
+
+	tutorials, next := database.Paginate(tutorials, elements, func(i int) *database.Cursor {
+		if match != "" {
+			return database.NewRankedCursor(ranks[i], tutorials[i].CreatedAt, tutorials[i].ID)
+		}
+
+		return database.NewCursor(tutorials[i].CreatedAt, tutorials[i].ID)
+	})
+
+	return tutorials, next, nil
+}
+
+func (db *PostgresDatabase) GetTutorialByID(ctx context.Context, id string) (*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE id = $1;`
+
+	var tutorial models.TutorialModel
+	var publishedInt int
+
+	row := db.connection.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get tutorial by ID: %s\n", err)
+		return nil, err
+	}
+
+	if publishedInt == 1 {
+		tutorial.Published = true
+	}
+
+	return &tutorial, nil
+}
+
+func (db *PostgresDatabase) GetTutorialBySlug(ctx context.Context, slug string) (*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE slug = $1 AND deleted_at IS NULL;`
+
+	var tutorial models.TutorialModel
+	var publishedInt int
+
+	row := db.connection.QueryRowContext(ctx, query, slug)
+	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get tutorial by slug: %s\n", err)
+		return nil, err
+	}
+
+	if publishedInt == 1 {
+		tutorial.Published = true
+	}
+
+	return &tutorial, nil
+}
+
+func (db *PostgresDatabase) GetTutorialByFileKey(ctx context.Context, fileKey string) (*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE file_key = $1;`
+
+	var tutorial models.TutorialModel
+	var publishedInt int
+
+	row := db.connection.QueryRowContext(ctx, query, fileKey)
+	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get tutorial by file key from the database: %s\n", err)
+		return nil, err
+	}
+
+	if publishedInt == 1 {
+		tutorial.Published = true
+	}
+
+	return &tutorial, nil
+}
+
+func (db *PostgresDatabase) CountTutorials(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM tutorials WHERE deleted_at IS NULL;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		db.ErrorLog.Printf("Failed to count the number of tutorials in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) CountTutorialsWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM tutorials WHERE author_id = $1 AND deleted_at IS NULL;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, authorId)
+	if err := row.Scan(&count); err != nil {
+		db.ErrorLog.Printf("Failed to count the number of tutorials written by \"%s\": %s\n", authorId, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) PublishTutorial(ctx context.Context, tutorialId string) error {
+	query := `UPDATE tutorials SET published = 1 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to publish tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UnpublishTutorial(ctx context.Context, tutorialId string) error {
+	query := `UPDATE tutorials SET published = 0 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to unpublish tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UpdateTutorialAuthor(ctx context.Context, tutorialId, authorId string) error {
+	query := `UPDATE tutorials SET author_id = $1 WHERE id = $2;`
+
+	// Foreign keys are enforced so an empty author ID has to be stored as a proper NULL value.
+	_, err := db.connection.ExecContext(ctx, query, database.NewNullString(authorId), tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update tutorial's (\"%s\") author (\"%s\"): %s\n", tutorialId, authorId, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTutorial soft deletes the tutorial so that it no longer shows up anywhere on the site.
+func (db *PostgresDatabase) DeleteTutorial(ctx context.Context, tutorialId string) error {
+	query := `UPDATE tutorials SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after deleting tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after deleting tutorial \"%s\"\n", tutorialId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// RestoreTutorial brings back a soft deleted tutorial.
+func (db *PostgresDatabase) RestoreTutorial(ctx context.Context, tutorialId string) error {
+	query := `UPDATE tutorials SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to restore tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after restoring tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after restoring tutorial \"%s\"\n", tutorialId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// PurgeTutorial permanently removes the tutorial along with its keywords, likes, bookmarks and comments.
+func (db *PostgresDatabase) PurgeTutorial(ctx context.Context, tutorialId string) error {
+	queries := []string{
+		`DELETE FROM tutorials_keywords WHERE tutorial_id = $1;`,
+		`DELETE FROM tutorials_likes WHERE tutorial_id = $1;`,
+		`DELETE FROM tutorials_bookmarks WHERE tutorial_id = $1;`,
+		`DELETE FROM comments WHERE tutorial_id = $1;`,
+	}
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, tutorialId); err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to purge tutorial \"%s\": %s\n", tutorialId, err)
+			db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM tutorials WHERE id = $1;`, tutorialId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to purge tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after purging tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after purging tutorial \"%s\"\n", tutorialId)
+		return database.ErrNoRowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after purging tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+type intermediate_tutorial struct {
+	ID              string
+	Title           string
+	Slug            string
+	Description     string
+	ThumbnailURL    string
+	BannerURL       string
+	Content         string
+	TableOfContents models.TableOfContents
+	WordCount       int
+	ReadingTime     int
+	Checksum        string
+	FileKey         string
+	Keywords        []string
+	Series          string
+	SeriesSlug      string
+	SeriesOrder     int
+	AuthorID        sql.NullString
+	PublishAt       sql.NullTime
+	UnpublishAt     sql.NullTime
+}
+
+var tutorialsToInsert []*intermediate_tutorial
+var tutorialsToUpdate []*intermediate_tutorial
+
+func (db *PostgresDatabase) PrepareBulkTutorials() {
+	tutorialsToInsert = []*intermediate_tutorial{}
+	tutorialsToUpdate = []*intermediate_tutorial{}
+}
+
+func (db *PostgresDatabase) InsertTutorial(title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, publishAt, unpublishAt sql.NullTime) {
+	tutorialsToInsert = append(tutorialsToInsert, &intermediate_tutorial{
+		ID:              "",
+		Title:           title,
+		Slug:            slug,
+		Description:     description,
+		ThumbnailURL:    thumbnailUrl,
+		BannerURL:       bannerUrl,
+		Content:         content,
+		TableOfContents: toc,
+		WordCount:       wordCount,
+		ReadingTime:     readingTime,
+		Checksum:        checksum,
+		FileKey:         fileKey,
+		Keywords:        keywords,
+		Series:          series,
+		SeriesSlug:      seriesSlug,
+		SeriesOrder:     seriesOrder,
+		PublishAt:       publishAt,
+		UnpublishAt:     unpublishAt,
+	})
+}
+
+func (db *PostgresDatabase) UpdateTutorial(id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, checksum, fileKey string, keywords []string, series, seriesSlug string, seriesOrder int, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) {
+	tutorialsToUpdate = append(tutorialsToUpdate, &intermediate_tutorial{
+		ID:              id,
+		Title:           title,
+		Slug:            slug,
+		Description:     description,
+		ThumbnailURL:    thumbnailUrl,
+		BannerURL:       bannerUrl,
+		Content:         content,
+		TableOfContents: toc,
+		WordCount:       wordCount,
+		ReadingTime:     readingTime,
+		Checksum:        checksum,
+		FileKey:         fileKey,
+		Keywords:        keywords,
+		Series:          series,
+		SeriesSlug:      seriesSlug,
+		SeriesOrder:     seriesOrder,
+		AuthorID:        authorId,
+		PublishAt:       publishAt,
+		UnpublishAt:     unpublishAt,
+	})
+}
+
+func (db *PostgresDatabase) RunBulkTutorials(ctx context.Context) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to create new database transaction: %s\n", err)
+		return err
+	}
+
+	if err := AddTutorials(ctx, tx, tutorialsToInsert); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk insert tutorials: %s\n", err)
+		return err
+	}
+
+	if err := UpdateTutorials(ctx, tx, tutorialsToUpdate); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to bulk update tutorials: %s\n", err)
+		return err
+	}
+
+	if err := internal.DeleteEmptySeries(ctx, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to delete empty series: %s\n", err)
+		return err
+	}
+
+	if err := internal.RebuildTutorialsIndex(ctx, tx); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to rebuild tutorials search index: %s\n", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit database transaction: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+func AddTutorials(ctx context.Context, tx *sql.Tx, tutorials []*intermediate_tutorial) error {
+	for _, tutorial := range tutorials {
+		id, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddTutorial(ctx, tx, id, tutorial.Title, tutorial.Slug, tutorial.Description, tutorial.ThumbnailURL, tutorial.BannerURL, tutorial.Content, tutorial.TableOfContents, tutorial.WordCount, tutorial.ReadingTime, tutorial.Checksum, tutorial.FileKey, tutorial.PublishAt, tutorial.UnpublishAt); err != nil {
+			return err
+		}
+
+		if err := AddKeywordsToTutorial(ctx, tx, id, tutorial.Keywords); err != nil {
+			return err
+		}
+
+		if err := AddSeriesToTutorial(ctx, tx, id, tutorial.Series, tutorial.SeriesSlug, tutorial.SeriesOrder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func UpdateTutorials(ctx context.Context, tx *sql.Tx, tutorials []*intermediate_tutorial) error {
+	for _, tutorial := range tutorials {
+		historyId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddSlugHistory(ctx, tx, historyId, database.TutorialSlug, tutorial.ID, tutorial.Slug); err != nil {
+			return err
+		}
+
+		if err := internal.UpdateTutorial(ctx, tx, tutorial.ID, tutorial.Title, tutorial.Slug, tutorial.Description, tutorial.ThumbnailURL, tutorial.BannerURL, tutorial.Content, tutorial.TableOfContents, tutorial.WordCount, tutorial.ReadingTime, tutorial.Checksum, tutorial.FileKey, tutorial.AuthorID, tutorial.PublishAt, tutorial.UnpublishAt); err != nil {
+			return err
+		}
+
+		if err := internal.DeleteAllKeywordsFromTutorial(ctx, tx, tutorial.ID); err != nil {
+			return err
+		}
+
+		if err := AddKeywordsToTutorial(ctx, tx, tutorial.ID, tutorial.Keywords); err != nil {
+			return err
+		}
+
+		if err := AddSeriesToTutorial(ctx, tx, tutorial.ID, tutorial.Series, tutorial.SeriesSlug, tutorial.SeriesOrder); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func AddKeywordsToTutorial(ctx context.Context, tx *sql.Tx, tutorialId string, keywords []string) error {
+	for _, keyword := range keywords {
+		keywordId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddKeyword(ctx, tx, keywordId, keyword); err != nil {
+			if err == database.ErrKeywordAlreadyExists {
+				keywordModel, err := internal.GetKeywordByKeyword(ctx, tx, keyword)
+				if err != nil {
+					return err
+				}
+
+				keywordId = keywordModel.ID
+			} else {
+				return err
+			}
+		}
+
+		tutorialKeywordId, err := database.GenerateID()
+		if err != nil {
+			return err
+		}
+
+		if err := internal.AddKeywordToTutorial(ctx, tx, tutorialKeywordId, keywordId, tutorialId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddSeriesToTutorial puts the tutorial in the series with the given slug, creating the series if it doesn't exist yet.
+// An empty series takes the tutorial out of the series it was in.
+func AddSeriesToTutorial(ctx context.Context, tx *sql.Tx, tutorialId, series, seriesSlug string, seriesOrder int) error {
+	if series == "" {
+		return internal.SetTutorialSeries(ctx, tx, tutorialId, sql.NullString{}, 0)
+	}
+
+	seriesId, err := database.GenerateID()
+	if err != nil {
+		return err
+	}
+
+	seriesId, err = internal.AddSeries(ctx, tx, seriesId, series, seriesSlug)
+	if err != nil {
+		return err
+	}
+
+	return internal.SetTutorialSeries(ctx, tx, tutorialId, sql.NullString{String: seriesId, Valid: true}, seriesOrder)
+}
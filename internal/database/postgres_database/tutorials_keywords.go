@@ -0,0 +1,30 @@
+package postgres_database
+
+import "context"
+
+func (db *PostgresDatabase) GetAllKeywordsForTutorial(ctx context.Context, tutorialId string) ([]string, error) {
+	query := `SELECT k.keyword FROM tutorials_keywords AS tk JOIN keywords AS k ON tk.keyword_id = k.id WHERE tk.tutorial_id = $1;`
+
+	var keywords []string
+
+	rows, err := db.connection.QueryContext(ctx, query, tutorialId)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var keyword string
+
+		if err := rows.Scan(&keyword); err != nil {
+			return nil, err
+		}
+
+		keywords = append(keywords, keyword)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keywords, nil
+}
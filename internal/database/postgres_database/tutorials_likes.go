@@ -0,0 +1,202 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) GetTutorialsLikedByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	query := `SELECT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at, tl.created_at, tl.id FROM tutorials_likes AS tl JOIN tutorials AS t ON tl.tutorial_id = t.id WHERE tl.user_id = $1 AND t.published = 1 AND t.deleted_at IS NULL`
+	args := []any{userId}
+
+	if term != "" {
+		query += fmt.Sprintf(" AND (LOWER(t.title) ILIKE '%%' || $%d || '%%' OR LOWER(t.slug) ILIKE '%%' || $%d || '%%' OR LOWER(t.description) ILIKE '%%' || $%d || '%%')", len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, term, term, term)
+	}
+
+	// The tutorials are ordered by when they were liked so the cursor points at the like rather than the tutorial.
+	condition, cursorArgs := internal.AfterCursor("tl", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY tl.created_at DESC, tl.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var tutorials []*models.TutorialModel
+	var relations []*database.Cursor
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all tutorials liked by user (\"%s\"): %s\n", userId, err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var tutorial models.TutorialModel
+		var published int
+		var relation database.Cursor
+
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt, &relation.CreatedAt, &relation.ID); err != nil {
+			db.ErrorLog.Printf("Failed to read tutorial from the database: %s\n", err)
+			return nil, nil, err
+		}
+
+		tutorial.Published = published == 1
+
+		tutorials = append(tutorials, &tutorial)
+		relations = append(relations, &relation)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all tutorials liked by user (\"%s\"): %s\n", userId, err)
+		return nil, nil, err
+	}
+
+	tutorials, next := database.Paginate(tutorials, int(elements), func(i int) *database.Cursor {
+		return relations[i]
+	})
+
+	return tutorials, next, nil
+}
+
+func (db *PostgresDatabase) UserLikedTutorial(ctx context.Context, userId, slug string) (bool, error) {
+	query := `SELECT t.id FROM tutorials_likes AS tl JOIN tutorials AS t ON tl.tutorial_id = t.id WHERE tl.user_id = $1 AND t.slug = $2;`
+
+	var id string
+
+	row := db.connection.QueryRowContext(ctx, query, userId, slug)
+	if err := row.Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		db.ErrorLog.Printf("Failed to query row from tutorials_likes table: %s\n", err)
+		return false, err
+	}
+
+	return id != "", nil
+}
+
+func (db *PostgresDatabase) UserLikeTutorial(ctx context.Context, userId, slug string) error {
+	query := `INSERT INTO tutorials_likes (id, user_id, tutorial_id) VALUES ($1, $2, (SELECT id FROM tutorials WHERE slug = $3));`
+
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new tutorials_likes database row: %s\n", err)
+		return err
+	}
+
+	result, err := db.connection.ExecContext(ctx, query, id, userId, slug)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to add new row to tutorials_likes table: %s\n", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query the database for the number of rows affected after adding new row to tutorials_likes table: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Println("No rows were affected after adding a new row to tutorials_likes table")
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UserDislikeTutorial(ctx context.Context, userId, slug string) error {
+	query := `DELETE FROM tutorials_likes WHERE user_id = $1 AND tutorial_id = (SELECT id FROM tutorials WHERE slug = $2);`
+
+	result, err := db.connection.ExecContext(ctx, query, userId, slug)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete row from tutorials_likes table: %s\n", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query the number of rows affected after deleting row from tutorials_likes table: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Println("No rows were affected after adding new row to tutorials_likes table")
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) TutorialsLikedByUser(ctx context.Context, userId string) ([]*models.TutorialModel, error) {
+	query := `SELECT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at FROM tutorials_likes AS tl JOIN tutorials AS t ON tl.tutorial_id = t.id WHERE tl.user_id = $1;`
+
+	var tutorials []*models.TutorialModel
+
+	rows, err := db.connection.QueryContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for all tutorials liked by user \"%s\": %s\n", userId, err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var tutorial models.TutorialModel
+		var published int
+
+		if err := rows.Scan(&tutorial.AuthorID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from tutorials_likes table joined with tutorials table: %s\n", err)
+			return nil, err
+		}
+
+		tutorials = append(tutorials, &tutorial)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to query database for all tutorials liked by user \"%s\": %s\n", userId, err)
+		return nil, err
+	}
+
+	return tutorials, nil
+}
+
+func (db *PostgresDatabase) CountTutorialsLikedByUser(ctx context.Context, userId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM tutorials_likes WHERE user_id = $1;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, userId)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number of tutorials liked by user \"%s\": %s\n", userId, err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) CountTutorialLikes(ctx context.Context, tutorialId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM tutorials_likes WHERE tutorial_id = $1;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query, tutorialId)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number of likes the tutorial \"%s\" has: %s\n", tutorialId, err)
+		return 0, err
+	}
+
+	return count, nil
+}
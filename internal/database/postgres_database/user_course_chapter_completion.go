@@ -0,0 +1,123 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) HasUserCompletedChapter(ctx context.Context, userId, courseId, chapterId string) (bool, error) {
+	query := `SELECT id FROM user_course_chapter_completion WHERE user_id = $1 AND course_id = $2 AND chapter_id = $3;`
+
+	var id string
+
+	row := db.connection.QueryRowContext(ctx, query, userId, courseId, chapterId)
+	if err := row.Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		db.ErrorLog.Printf("Failed to check if user (\"%s\") has finished course (\"%s\") chapter (\"%s\"): %s\n", userId, courseId, chapterId, err)
+		return false, err
+	}
+
+	return id != "", nil
+}
+
+func (db *PostgresDatabase) GetAllChaptersCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	query := `SELECT cc.id, cc.title, cc.slug, cc.chapter, cc.content, cc.table_of_contents, cc.word_count, cc.reading_time, cc.course_id, cc.file_checksum, cc.file_key, cc.release_after_days, cc.release_at, cc.created_at, cc.updated_at FROM user_course_chapter_completion AS uccc LEFT JOIN course_chapters AS cc ON uccc.chapter_id = cc.id WHERE uccc.user_id = $1 AND uccc.course_id = $2 ORDER BY cc.chapter ASC;`
+
+	var chapters []*models.ChapterModel
+
+	rows, err := db.connection.QueryContext(ctx, query, userId, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get course (\"%s\") chapters completed by user (\"%s\"): %s\n", courseId, userId, err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var chapter models.ChapterModel
+
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read chapter from database: %s\n", err)
+			return nil, err
+		}
+
+		chapters = append(chapters, &chapter)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get course (\"%s\") chapters completed by user (\"%s\"): %s\n", courseId, userId, err)
+		return nil, err
+	}
+
+	return chapters, nil
+}
+
+func (db *PostgresDatabase) GetAllChaptersNotCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE course_id = $1 EXCEPT SELECT cc.id, cc.title, cc.slug, cc.chapter, cc.content, cc.table_of_contents, cc.word_count, cc.reading_time, cc.course_id, cc.file_checksum, cc.file_key, cc.release_after_days, cc.release_at, cc.created_at, cc.updated_at FROM course_chapters AS cc LEFT JOIN user_course_chapter_completion AS uccc ON cc.id = uccc.chapter_id WHERE uccc.user_id = $2 AND cc.course_id = $3 ORDER BY chapter ASC;`
+
+	var chapters []*models.ChapterModel
+
+	rows, err := db.connection.QueryContext(ctx, query, courseId, userId, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get course (\"%s\") chapters not completed by user (\"%s\"): %s\n", courseId, userId, err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var chapter models.ChapterModel
+
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read chapter from database: %s\n", err)
+			return nil, err
+		}
+
+		chapters = append(chapters, &chapter)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get course (\"%s\") chapters not completed by user (\"%s\"): %s\n", courseId, userId, err)
+		return nil, err
+	}
+
+	return chapters, nil
+}
+
+// FinishChapter marks a chapter as completed by the user. If the chapter has a quiz the user needs to have passed it
+// first, otherwise database.ErrQuizNotPassed gets returned. Finishing a chapter twice isn't treated as an error.
+func (db *PostgresDatabase) FinishChapter(ctx context.Context, userId, chapterId, courseId string) error {
+	query := `INSERT INTO user_course_chapter_completion (id, user_id, course_id, chapter_id) SELECT $1, $2, $3, $4 WHERE NOT EXISTS (SELECT 1 FROM quizzes WHERE chapter_id = $4) OR EXISTS (SELECT 1 FROM quiz_attempts AS a JOIN quizzes AS q ON q.id = a.quiz_id WHERE q.chapter_id = $4 AND a.user_id = $2 AND a.passed = 1) OR EXISTS (SELECT 1 FROM user_course_chapter_completion WHERE user_id = $2 AND course_id = $3 AND chapter_id = $4);`
+
+	id, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate ID for new user_course_chapter_completion table row: %s\n", err)
+		return err
+	}
+
+	result, err := db.connection.ExecContext(ctx, query, id, userId, courseId, chapterId)
+	if err != nil {
+		if internal.IsUniqueViolation(err) {
+			return nil
+		}
+
+		db.ErrorLog.Printf("Failed to insert new row to user_course_chapter_completion table: %s\n", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after inserting new row to user_course_chapter_completion table: %s\n", err)
+		return err
+	}
+
+	// Nothing gets inserted when the chapter has a quiz that the user hasn't passed yet.
+	if rowsAffected == 0 {
+		return database.ErrQuizNotPassed
+	}
+
+	return nil
+}
@@ -0,0 +1,773 @@
+package postgres_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database/internal"
+)
+
+func (db *PostgresDatabase) GetUsers(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error) {
+	query := `SELECT DISTINCT u.id, u.name, u.surname, u.slug, u.email, u.password, u.affiliate_code, u.affiliate_points, u.is_admin, u.is_author, u.created_at, u.updated_at FROM users AS u LEFT JOIN tutorials_likes AS tl ON u.id = tl.user_id LEFT JOIN tutorials_bookmarks AS tb ON u.id = tb.user_id WHERE (LOWER(u.id) ILIKE '%' || $1 || '%' OR LOWER(u.name) ILIKE '%' || $2 || '%' OR LOWER(u.surname) ILIKE '%' || $3 || '%' OR LOWER(u.email) ILIKE '%' || $4 || '%' OR LOWER(u.affiliate_code) ILIKE '%' || $5 || '%')`
+
+	args := []any{term, term, term, term, term}
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	if likedTutorialID != "" {
+		query += fmt.Sprintf(" AND tl.tutorial_id = $%d", len(args)+1)
+		args = append(args, likedTutorialID)
+	}
+
+	if bookmarkedTutorialID != "" {
+		query += fmt.Sprintf(" AND tb.tutorial_id = $%d", len(args)+1)
+		args = append(args, bookmarkedTutorialID)
+	}
+
+	var users []*models.UserModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for all users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var user models.UserModel
+		var isAdmin int
+		var isAuthor int
+
+		if err := rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &user.AffiliateCode, &user.AffiliatePoints, &isAdmin, &isAuthor, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from users table: %s\n", err)
+			return nil, err
+		}
+
+		user.IsAdmin = isAdmin == 1
+		user.IsAuthor = isAuthor == 1
+
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to query database for all users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (db *PostgresDatabase) GetUsersPaginated(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, cursor *database.Cursor, elements uint) ([]*models.UserModel, *database.Cursor, error) {
+	query := `SELECT DISTINCT u.id, u.name, u.surname, u.slug, u.email, u.password, u.affiliate_code, u.affiliate_points, u.is_admin, u.is_author, u.created_at, u.updated_at FROM users AS u LEFT JOIN tutorials_likes AS tl ON u.id = tl.user_id LEFT JOIN tutorials_bookmarks AS tb ON u.id = tb.user_id WHERE (LOWER(u.id) ILIKE '%' || $1 || '%' OR LOWER(u.name) ILIKE '%' || $2 || '%' OR LOWER(u.surname) ILIKE '%' || $3 || '%' OR LOWER(u.email) ILIKE '%' || $4 || '%' OR LOWER(u.affiliate_code) ILIKE '%' || $5 || '%')`
+
+	args := []any{term, term, term, term, term}
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	if likedTutorialID != "" {
+		query += fmt.Sprintf(" AND tl.tutorial_id = $%d", len(args)+1)
+		args = append(args, likedTutorialID)
+	}
+
+	if bookmarkedTutorialID != "" {
+		query += fmt.Sprintf(" AND tb.tutorial_id = $%d", len(args)+1)
+		args = append(args, bookmarkedTutorialID)
+	}
+
+	condition, cursorArgs := internal.AfterCursor("u", cursor, len(args)+1)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += fmt.Sprintf(" ORDER BY u.created_at DESC, u.id DESC LIMIT $%d;", len(args)+1)
+	args = append(args, elements+1)
+
+	var users []*models.UserModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for the next page of users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
+		return nil, nil, err
+	}
+
+	for rows.Next() {
+		var user models.UserModel
+		var isAdmin int
+		var isAuthor int
+
+		if err := rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &user.AffiliateCode, &user.AffiliatePoints, &isAdmin, &isAuthor, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from users table: %s\n", err)
+			return nil, nil, err
+		}
+
+		user.IsAdmin = isAdmin == 1
+		user.IsAuthor = isAuthor == 1
+
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to query database for the next page of users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
+		return nil, nil, err
+	}
+
+	users, next := database.Paginate(users, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(users[i].CreatedAt, users[i].ID)
+	})
+
+	return users, next, nil
+}
+
+func (db *PostgresDatabase) GetAllUsers(ctx context.Context) ([]*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, affiliate_code, affiliate_points, is_admin, is_author, created_at, updated_at FROM users WHERE deleted_at IS NULL`
+
+	var users []*models.UserModel
+
+	rows, err := db.connection.QueryContext(ctx, query)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get all users from the database: %s\n", err)
+		return nil, err
+	}
+
+	for rows.Next() {
+		var user models.UserModel
+		var isAdmin int
+		var isAuthor int
+
+		if err := rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &user.AffiliateCode, &user.AffiliatePoints, &isAdmin, &isAuthor, &user.CreatedAt, &user.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to read row from users table: %s\n", err)
+			return nil, err
+		}
+
+		user.IsAdmin = isAdmin == 1
+		user.IsAuthor = isAuthor == 1
+
+		users = append(users, &user)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to get all users from the database: %s\n", err)
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// The only function that I believe has a viable reason to do transactions.
+func (db *PostgresDatabase) AddNewUser(ctx context.Context, name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error) {
+	// Generate all the IDs required for the database transaction first. I don't want the transaction to fail
+	// because I couldn't generate an ID.
+	userId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new ID for user (\"%s\"): %s\n", email, err)
+		return nil, err
+	}
+
+	affiliateCode, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new affiliate code for user (\"%s\"): %s\n", email, err)
+		return nil, err
+	}
+
+	tokenId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new token ID for user (\"%s\"): %s\n", email, err)
+		return nil, err
+	}
+
+	addressId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new IP address ID for user (\"%s\"): %s\n", email, err)
+		return nil, err
+	}
+
+	userSlug := database.NameSurnameToSlug(name, surname)
+
+	// With all the setup out the way, start a new transaction. The only thing that can fail now is database calls.
+	// The reason for the transaction is that I don't want the user saved to the database if the token or the IP
+	// address couldn't have been saved. I'd rather the user start again than have my database contain fractured data.
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to begin transaction to add user (\"%s\") to the database: %s\n", email, err)
+		return nil, err
+	}
+
+	user, err := internal.AddUser(ctx, tx, userId, name, surname, userSlug, email, password, affiliateCode)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to add user (\"%s\") to the database: %s\n", email, err)
+		return nil, err
+	}
+
+	err = internal.AddToken(ctx, tx, tokenId, token, tokenType, user.ID, validUntil)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to save user's (\"%s\") %s token to the database: %s\n", email, tokenType, err)
+		return nil, err
+	}
+
+	err = internal.AddIPAddress(ctx, tx, addressId, user.ID, ipAddr)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to save IP address to the database: %s\n", err)
+		return nil, err
+	}
+
+	// Now that all the database calls have been successfully made we can commit the changes as one big chunk. Hopefully
+	// committing the changes won't fail but if it does the user can just resubmit the signup form.
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit transaction to the database when adding a new user (\"%s\"): %s\n", email, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (db *PostgresDatabase) NewUser(ctx context.Context, name, surname, email, password string) error {
+	query := `INSERT INTO users (id, name, surname, slug, email, password, affiliate_code) VALUES ($1, $2, $3, $4, $5, $6, $7);`
+
+	userId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new ID for user (\"%s\"): %s\n", email, err)
+		return err
+	}
+
+	affiliateCode, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new affiliate code for user (\"%s\"): %s\n", email, err)
+		return err
+	}
+
+	userSlug := database.NameSurnameToSlug(name, surname)
+
+	result, err := db.connection.ExecContext(ctx, query, userId, name, surname, userSlug, email, password, affiliateCode)
+	if err != nil {
+		if internal.IsUniqueViolation(err) {
+			return database.ErrUserAlreadyExists
+		}
+
+		db.ErrorLog.Printf("Failed to add new user to the database: %s\n", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query the database for the rows affected after adding new user: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Println("0 rows were affected after adding new user to the database")
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// AddAdminUser adds a new admin user to the database. This function should not be made available to the application because
+// there should never be a reason for the actual application to use this function.
+func (db *PostgresDatabase) NewAdminUser(ctx context.Context, name, surname, email, password string) error {
+	query := `INSERT INTO users (id, name, surname, slug, email, password, affiliate_code, is_admin) VALUES ($1, $2, $3, $4, $5, $6, $7, 1);`
+
+	userId, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new ID for user (\"%s\"): %s\n", email, err)
+		return err
+	}
+
+	affiliateCode, err := database.GenerateID()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to generate new affiliate code for user (\"%s\"): %s\n", email, err)
+		return err
+	}
+
+	userSlug := database.NameSurnameToSlug(name, surname)
+
+	result, err := db.connection.ExecContext(ctx, query, userId, name, surname, userSlug, email, password, affiliateCode)
+	if err != nil {
+		if internal.IsUniqueViolation(err) {
+			return database.ErrUserAlreadyExists
+		}
+
+		db.ErrorLog.Printf("Failed to add new admin user to the database: %s\n", err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query the database for the rows affected after adding new admin user: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Println("0 rows were affected after adding new admin user to the database")
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) GetUserByEmail(ctx context.Context, email string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE email = $1`
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	var isAdminInt int
+	var isAuthorInt int
+	user := new(models.UserModel)
+	isAdmin := false
+	isAuthor := false
+
+	row := db.connection.QueryRowContext(ctx, query, email)
+	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdminInt, &isAuthorInt, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			// Nothing was found so we can just send back nothing and handle it at the caller
+			// end.
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to query the database for user (\"%s\"): %s\n", email, err)
+		return nil, err
+	}
+
+	if isAdminInt == 1 {
+		isAdmin = true
+	}
+
+	if isAuthorInt == 1 {
+		isAuthor = true
+	}
+
+	user.IsAdmin = isAdmin
+	user.IsAuthor = isAuthor
+
+	return user, nil
+}
+
+func (db *PostgresDatabase) GetUserByID(ctx context.Context, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	user, err := internal.GetUserByID(ctx, db.connection, id, level)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get user by ID (\"%s\") from the database: %s\n", id, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (db *PostgresDatabase) GetUserByToken(ctx context.Context, token, tokenType string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	query := `SELECT users.id, users.name, users.surname, users.slug, users.email, users.password, users.is_admin, users.is_author, users.affiliate_code, users.affiliate_points, users.created_at, users.updated_at FROM tokens JOIN users ON tokens.user_id = users.id WHERE tokens.token = $1 AND tokens.token_type = $2 AND tokens.valid_until > CURRENT_TIMESTAMP`
+
+	switch level {
+	case database.User:
+		query += ` AND users.is_admin = 0 AND users.is_author = 0 AND users.deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND users.is_admin = 1 AND users.deleted_at IS NULL`
+	case database.Author:
+		query += ` AND users.is_author = 1 AND users.deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND users.deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND users.deleted_at IS NULL`
+	}
+
+	var isAdminInt int
+	var isAuthorInt int
+	user := new(models.UserModel)
+	isAdmin := false
+	isAuthor := false
+
+	row := db.connection.QueryRowContext(ctx, query, token, tokenType)
+	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdminInt, &isAuthorInt, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			// Nothing was found so we can just send back nothing and handle it at the caller
+			// end.
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to query the database for user using token (\"%s\"): %s\n", token, err)
+		return nil, err
+	}
+
+	if isAdminInt == 1 {
+		isAdmin = true
+	}
+
+	if isAuthorInt == 1 {
+		isAuthor = true
+	}
+
+	user.IsAdmin = isAdmin
+	user.IsAuthor = isAuthor
+
+	return user, nil
+}
+
+func (db *PostgresDatabase) GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	user, err := internal.GetUserByAffiliateCode(ctx, db.connection, affiliateCode, level)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query the database for user with affiliate code (\"%s\"): %s\n", affiliateCode, err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (db *PostgresDatabase) GetUserBySlug(ctx context.Context, userSlug string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE slug = $1`
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	user := new(models.UserModel)
+	isAdmin := 0
+	isAuthor := 0
+
+	row := db.connection.QueryRowContext(ctx, query, userSlug)
+	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to query the database for user with slug (\"%s\"): %s\n", userSlug, err)
+		return nil, err
+	}
+
+	user.IsAdmin = isAdmin == 1
+	user.IsAuthor = isAuthor == 1
+
+	return user, nil
+}
+
+func (db *PostgresDatabase) UpdateUserName(ctx context.Context, userId, name, surname string) error {
+	query := `UPDATE users SET name = $1, surname = $2, slug = $3 WHERE id = $4;`
+
+	slug := database.NameSurnameToSlug(name, surname)
+
+	result, err := db.connection.ExecContext(ctx, query, name, surname, slug, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") name: %s\n", userId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after updating user's (\"%s\") name: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after updating user's (\"%s\") name\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UpdateUserEmail(ctx context.Context, userId, email string) error {
+	query := `UPDATE users SET email = $1 WHERE id = $2;`
+
+	result, err := db.connection.ExecContext(ctx, query, email, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") email: %s\n", userId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after updating user's (\"%s\") email: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after updating user's (\"%s\") email\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) UpdateUserPassword(ctx context.Context, userId, password string) error {
+	query := `UPDATE users SET password = $1 WHERE id = $2;`
+
+	result, err := db.connection.ExecContext(ctx, query, password, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") password: %s\n", userId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after updating user's (\"%s\") password: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after updating user's (\"%s\") password\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) CountUsers(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM users WHERE deleted_at IS NULL;`
+
+	var count uint
+
+	row := db.connection.QueryRowContext(ctx, query)
+	if err := row.Scan(&count); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+
+		db.ErrorLog.Printf("Failed to count the number of users in the database: %s\n", err)
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (db *PostgresDatabase) AddAuthorStatus(ctx context.Context, userId string) error {
+	query := `UPDATE users SET is_author = 1 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") author status: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) RemoveAuthorStatus(ctx context.Context, userId string) error {
+	query := `UPDATE users SET is_author = 0 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") author status: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) AddAdminStatus(ctx context.Context, userId string) error {
+	query := `UPDATE users SET is_admin = 1 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") admin status: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
+
+func (db *PostgresDatabase) RemoveAdminStatus(ctx context.Context, userId string) error {
+	query := `UPDATE users SET is_admin = 0 WHERE id = $1;`
+
+	_, err := db.connection.ExecContext(ctx, query, userId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update user's (\"%s\") admin status: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteUser soft deletes the user's account along with their comments and logs them out everywhere. Their purchases,
+// refunds and certificates are left untouched until the account gets purged.
+func (db *PostgresDatabase) DeleteUser(ctx context.Context, userId string) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL;`, userId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to delete user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after deleting user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after deleting user's (\"%s\") account\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	// The comments get the same timestamp as the account so that restoring the account only brings back the comments
+	// that were removed along with it.
+	if _, err := tx.ExecContext(ctx, `UPDATE comments SET deleted_at = (SELECT deleted_at FROM users WHERE id = $1) WHERE user_id = $2 AND deleted_at IS NULL;`, userId, userId); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to delete user's (\"%s\") comments: %s\n", userId, err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tokens WHERE user_id = $1;`, userId); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to delete user's (\"%s\") tokens: %s\n", userId, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after deleting user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
+
+// RestoreUser brings back a soft deleted account along with the comments that were deleted with it. Accounts that have
+// already been anonymized can't be restored.
+func (db *PostgresDatabase) RestoreUser(ctx context.Context, userId string) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE comments SET deleted_at = NULL WHERE user_id = $1 AND deleted_at = (SELECT deleted_at FROM users WHERE id = $2);`, userId, userId); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to restore user's (\"%s\") comments: %s\n", userId, err)
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL AND anonymized_at IS NULL;`, userId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		// Someone signed up with the account's email address after it was deleted.
+		if internal.IsUniqueViolation(err) {
+			return database.ErrUserAlreadyExists
+		}
+
+		db.ErrorLog.Printf("Failed to restore user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after restoring user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after restoring user's (\"%s\") account\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after restoring user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package postgres_database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func TestGetUsersPaginated(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	for _, email := range []string{"first@example.com", "second@example.com", "third@example.com"} {
+		addTestUser(t, postgresDatabase, email)
+	}
+
+	seen := make(map[string]bool)
+
+	var cursor *database.Cursor
+	pages := 0
+
+	for {
+		users, next, err := postgresDatabase.GetUsersPaginated(ctx, "", database.All, "", "", cursor, 2)
+		if err != nil {
+			t.Fatalf("Failed to get page of users: %s", err)
+		}
+
+		for _, user := range users {
+			if seen[user.ID] {
+				t.Errorf("Expected every user to show up once. Got user (\"%s\") twice", user.ID)
+			}
+
+			seen[user.ID] = true
+		}
+
+		pages++
+
+		if next == nil {
+			break
+		}
+
+		if pages > 3 {
+			t.Fatal("Expected pagination to stop once every user was returned")
+		}
+
+		cursor = next
+	}
+
+	if pages != 2 || len(seen) != 3 {
+		t.Errorf("Expected 3 users over 2 pages. Got %d users over %d pages", len(seen), pages)
+	}
+
+	users, _, err := postgresDatabase.GetUsersPaginated(ctx, "second", database.All, "", "", nil, 2)
+	if err != nil {
+		t.Fatalf("Failed to search users: %s", err)
+	}
+
+	if len(users) != 1 || users[0].Email != "second@example.com" {
+		t.Errorf("Expected the search to only match the second user. Got %d users", len(users))
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+
+	if err := postgresDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	// The deleted account mustn't stop the person from signing up again.
+	if err := postgresDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to sign up again with the email of a deleted account: %s", err)
+	}
+
+	if err := postgresDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); !errors.Is(err, database.ErrUserAlreadyExists) {
+		t.Errorf("Expected a second active account with the same email to be rejected. Got %v", err)
+	}
+}
+
+func TestRestoreUser(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+
+	if err := postgresDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	if err := postgresDatabase.RestoreUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to restore user: %s", err)
+	}
+
+	if err := postgresDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	if err := postgresDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to sign up again with the email of a deleted account: %s", err)
+	}
+
+	if err := postgresDatabase.RestoreUser(ctx, user.ID); !errors.Is(err, database.ErrUserAlreadyExists) {
+		t.Errorf("Expected restoring an account whose email was taken to fail. Got %v", err)
+	}
+}
+
+func TestDeletedUserLevels(t *testing.T) {
+	ctx := context.Background()
+	postgresDatabase := newTestDatabase(t)
+
+	user := addTestUser(t, postgresDatabase, "jane@example.com")
+
+	if err := postgresDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	lookups := map[string]func(level database.AuthorizationLevel) (*models.UserModel, error){
+		"ID": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return postgresDatabase.GetUserByID(ctx, user.ID, level)
+		},
+		"email": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return postgresDatabase.GetUserByEmail(ctx, user.Email, level)
+		},
+		"slug": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return postgresDatabase.GetUserBySlug(ctx, user.Slug, level)
+		},
+		"affiliate code": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return postgresDatabase.GetUserByAffiliateCode(ctx, user.AffiliateCode, level)
+		},
+	}
+
+	// Only Deleted and IncludeDeleted may hand back a deleted account, no matter how it is looked up.
+	for name, lookup := range lookups {
+		for _, level := range database.AuthorizationLevelValues() {
+			found, err := lookup(level)
+			if err != nil {
+				t.Fatalf("Failed to get user by %s: %s", name, err)
+			}
+
+			if want := level == database.Deleted || level == database.IncludeDeleted; (found != nil) != want {
+				t.Errorf("Expected looking up a deleted user by %s with level %s to find it: %t. Got %v", name, level, want, found)
+			}
+		}
+	}
+}
@@ -1,20 +1,22 @@
 package sqlite_database
 
 import (
+	"context"
+
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
 // RegisterAffiliatePointsChange adds a new row to the affiliate_points_history table.
-func (db *SQLiteDatabase) RegisterAffiliatePointsChange(userId, courseId string, pointsChange int, reason string) error {
+func (db *SQLiteDatabase) RegisterAffiliatePointsChange(ctx context.Context, userId, courseId string, pointsChange int, reason string) error {
 	id, err := database.GenerateID()
 	if err != nil {
 		db.ErrorLog.Printf("Failed to generate ID for affiliate points history: %s\n", err)
 		return err
 	}
 
-	if err := internal.RegisterAffiliatePointsChange(db.connection, id, userId, courseId, pointsChange, reason); err != nil {
+	if err := internal.RegisterAffiliatePointsChange(ctx, db.connection, id, userId, courseId, pointsChange, reason); err != nil {
 		db.ErrorLog.Printf("Failed to save affiliate point change to database: %s\n", err)
 		return err
 	}
@@ -23,12 +25,12 @@ func (db *SQLiteDatabase) RegisterAffiliatePointsChange(userId, courseId string,
 }
 
 // CountUserAffiliateHistory counts all the times the given user's affiliate code was used.
-func (db *SQLiteDatabase) CountUserAffiliateHistory(userId string) (uint, error) {
+func (db *SQLiteDatabase) CountUserAffiliateHistory(ctx context.Context, userId string) (uint, error) {
 	query := `SELECT COUNT(id) FROM affiliate_points_history WHERE user_id = ?;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, userId)
+	row := db.connection.QueryRowContext(ctx, query, userId)
 	if err := row.Scan(&count); err != nil {
 		db.ErrorLog.Printf("Failed to count the number of affiliate_points_history rows connected to user (\"%s\"): %s\n", userId, err)
 		return 0, err
@@ -38,17 +40,23 @@ func (db *SQLiteDatabase) CountUserAffiliateHistory(userId string) (uint, error)
 }
 
 // GetUserAffiliatePointsHistory gets a slice of AffiliatePointsHistoryModel for a given user.
-func (db *SQLiteDatabase) GetUserAffiliatePointsHistory(userId string, page, elements uint) ([]*models.AffiliatePointsHistoryModel, error) {
-	query := `SELECT id, user_id, course_id, points_change, reason, created_at FROM affiliate_points_history WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?;;`
+func (db *SQLiteDatabase) GetUserAffiliatePointsHistory(ctx context.Context, userId string, cursor *database.Cursor, elements uint) ([]*models.AffiliatePointsHistoryModel, *database.Cursor, error) {
+	query := `SELECT h.id, h.user_id, h.course_id, h.points_change, h.reason, h.created_at FROM affiliate_points_history AS h WHERE h.user_id = ?`
+	args := []any{userId}
 
-	var history []*models.AffiliatePointsHistoryModel
+	condition, cursorArgs := internal.AfterCursor("affiliate_points_history", "h", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
 
-	offset := (page - 1) * elements
+	query += " ORDER BY h.created_at DESC, h.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
-	rows, err := db.connection.Query(query, userId, elements, offset)
+	var history []*models.AffiliatePointsHistoryModel
+
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get user's (\"%s\") affiliate points history: %s\n", userId, err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -56,7 +64,7 @@ func (db *SQLiteDatabase) GetUserAffiliatePointsHistory(userId string, page, ele
 
 		if err := rows.Scan(&h.ID, &h.UserID, &h.CourseID, &h.PointsChange, &h.Reason, &h.CreatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from affiliate_points_history table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		history = append(history, &h)
@@ -64,8 +72,12 @@ func (db *SQLiteDatabase) GetUserAffiliatePointsHistory(userId string, page, ele
 
 	if err := rows.Err(); err != nil {
 		db.ErrorLog.Printf("Failed to get user's (\"%s\") affiliate points history: %s\n", userId, err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return history, nil
+	history, next := database.Paginate(history, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(history[i].CreatedAt, history[i].ID)
+	})
+
+	return history, next, nil
 }
@@ -0,0 +1,15 @@
+package sqlite_database
+
+import "testing"
+
+func TestAddAuditLog(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestAdminGetAuditLogs(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestCountAuditLogs(t *testing.T) {
+	// TODO: Implement.
+}
@@ -1,6 +1,7 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -10,7 +11,7 @@ import (
 )
 
 // AddCertificate adds a new certificate row the database.
-func (db *SQLiteDatabase) AddCertificate(userId, courseId string) error {
+func (db *SQLiteDatabase) AddCertificate(ctx context.Context, userId, courseId string) error {
 	query := `INSERT INTO certificates (id, user_id, course_id) VALUES (?, ?, ?);`
 
 	id, err := database.GenerateID()
@@ -19,7 +20,7 @@ func (db *SQLiteDatabase) AddCertificate(userId, courseId string) error {
 		return err
 	}
 
-	result, err := db.connection.Exec(query, id, userId, courseId)
+	result, err := db.connection.ExecContext(ctx, query, id, userId, courseId)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return nil
@@ -44,12 +45,12 @@ func (db *SQLiteDatabase) AddCertificate(userId, courseId string) error {
 }
 
 // GetCertificateFromID retrieves a CertificateModel from the database by the given ID.
-func (db *SQLiteDatabase) GetCertificateFromID(certificateId string) (*models.CertificateModel, error) {
+func (db *SQLiteDatabase) GetCertificateFromID(ctx context.Context, certificateId string) (*models.CertificateModel, error) {
 	query := `SELECT id, user_id, course_id, created_at FROM certificates WHERE id = ?;`
 
 	var certificate models.CertificateModel
 
-	row := db.connection.QueryRow(query, certificateId)
+	row := db.connection.QueryRowContext(ctx, query, certificateId)
 	if err := row.Scan(&certificate.ID, &certificate.UserID, &certificate.CourseID, &certificate.CreatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -63,12 +64,12 @@ func (db *SQLiteDatabase) GetCertificateFromID(certificateId string) (*models.Ce
 }
 
 // GetCertificateFromUserAndCourse retrieves a CertificateModel based on the user ID and course ID.
-func (db *SQLiteDatabase) GetCertificateFromUserAndCourse(userId, courseId string) (*models.CertificateModel, error) {
+func (db *SQLiteDatabase) GetCertificateFromUserAndCourse(ctx context.Context, userId, courseId string) (*models.CertificateModel, error) {
 	query := `SELECT id, user_id, course_id, created_at FROM certificates WHERE user_id = ? AND course_id = ?;`
 
 	var certificate models.CertificateModel
 
-	row := db.connection.QueryRow(query, userId, courseId)
+	row := db.connection.QueryRowContext(ctx, query, userId, courseId)
 	if err := row.Scan(&certificate.ID, &certificate.UserID, &certificate.CourseID, &certificate.CreatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -82,14 +83,14 @@ func (db *SQLiteDatabase) GetCertificateFromUserAndCourse(userId, courseId strin
 }
 
 // GetUserFromCertificate retrieves a UserModel from the given certificate ID.
-func (db *SQLiteDatabase) GetUserFromCertificate(certificateId string) (*models.UserModel, error) {
+func (db *SQLiteDatabase) GetUserFromCertificate(ctx context.Context, certificateId string) (*models.UserModel, error) {
 	query := `SELECT u.id, u.name, u.surname, u.slug, u.email, u.password, u.is_admin, u.is_author, u.affiliate_code, u.affiliate_points, u.created_at, u.updated_at FROM certificates AS c LEFT JOIN users AS u ON c.user_id = u.id WHERE c.id = ?;`
 
 	var user models.UserModel
 	var isAdmin int
 	var isAuthor int
 
-	row := db.connection.QueryRow(query, certificateId)
+	row := db.connection.QueryRowContext(ctx, query, certificateId)
 	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -106,14 +107,14 @@ func (db *SQLiteDatabase) GetUserFromCertificate(certificateId string) (*models.
 }
 
 // GetCourseFromCertificate retrieves a CourseModel from the given certificate ID.
-func (db *SQLiteDatabase) GetCourseFromCertificate(certificateId string) (*models.CourseModel, error) {
-	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM certificates AS cf LEFT JOIN courses AS c ON cf.course_id = c.id WHERE cf.id = ?;`
+func (db *SQLiteDatabase) GetCourseFromCertificate(ctx context.Context, certificateId string) (*models.CourseModel, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM certificates AS cf LEFT JOIN courses AS c ON cf.course_id = c.id WHERE cf.id = ?;`
 
 	var course models.CourseModel
 	var published int
 
-	row := db.connection.QueryRow(query, certificateId)
-	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, certificateId)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
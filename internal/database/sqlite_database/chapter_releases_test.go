@@ -0,0 +1,203 @@
+package sqlite_database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
+)
+
+func TestGetDueChapterReleases(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	if err := internal.AddCourse(ctx, sqliteDatabase.connection, "course", "Course", "course", "", "", "", "", "", "course", sql.NullTime{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add course: %s", err)
+	}
+
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, `UPDATE courses SET published = 1 WHERE id = 'course';`); err != nil {
+		t.Fatalf("Failed to publish course: %s", err)
+	}
+
+	// release_at can have any offset in the frontmatter. It needs to be compared against the database's clock as the
+	// same instant, not as text.
+	chapters := map[string]time.Time{
+		"released": time.Now().Add(-30 * time.Minute).In(time.FixedZone("", 2*60*60)),
+		"locked":   time.Now().Add(30 * time.Minute).In(time.FixedZone("", -5*60*60)),
+	}
+
+	number := 1
+	for id, releaseAt := range chapters {
+		if err := internal.AddChapter(ctx, sqliteDatabase.connection, id, id, id, number, "", nil, 0, 0, "", id, "course", sql.NullInt32{}, sql.NullTime{Time: releaseAt, Valid: true}); err != nil {
+			t.Fatalf("Failed to add chapter: %s", err)
+		}
+
+		number++
+	}
+
+	// The release dates were set before the course was bought.
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, `UPDATE course_chapters SET release_set_at = datetime('now', '-2 days');`); err != nil {
+		t.Fatalf("Failed to backdate release rules: %s", err)
+	}
+
+	query := `INSERT INTO course_purchases (id, user_id, course_id, payment_key, stripe_checkout_session_id, amount_paid, payment_status, created_at) VALUES ('purchase', ?, 'course', 'key', 'session', 10, ?, datetime('now', '-1 days'));`
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, query, user.ID, database.Succeeded.String()); err != nil {
+		t.Fatalf("Failed to insert course purchase: %s", err)
+	}
+
+	releases, err := sqliteDatabase.GetDueChapterReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get due chapter releases: %s", err)
+	}
+
+	if len(releases) != 1 || releases[0].ChapterID != "released" {
+		t.Fatalf("Expected only the released chapter to be due. Got %d releases", len(releases))
+	}
+
+	if err := sqliteDatabase.AddChapterReleaseNotification(ctx, user.ID, "released"); err != nil {
+		t.Fatalf("Failed to add chapter release notification: %s", err)
+	}
+
+	releases, err = sqliteDatabase.GetDueChapterReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get due chapter releases: %s", err)
+	}
+
+	if len(releases) != 0 {
+		t.Errorf("Expected no releases to be due after the learner was notified. Got %d", len(releases))
+	}
+}
+
+func TestGetDueChapterReleasesAfterReleaseRuleChanged(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	if err := internal.AddCourse(ctx, sqliteDatabase.connection, "course", "Course", "course", "", "", "", "", "", "course", sql.NullTime{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add course: %s", err)
+	}
+
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, `UPDATE courses SET published = 1 WHERE id = 'course';`); err != nil {
+		t.Fatalf("Failed to publish course: %s", err)
+	}
+
+	if err := internal.AddChapter(ctx, sqliteDatabase.connection, "chapter", "Chapter", "chapter", 1, "", nil, 0, 0, "", "chapter", "course", sql.NullInt32{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add chapter: %s", err)
+	}
+
+	query := `INSERT INTO course_purchases (id, user_id, course_id, payment_key, stripe_checkout_session_id, amount_paid, payment_status, created_at) VALUES ('purchase', ?, 'course', 'key', 'session', 10, ?, datetime('now', '-30 days'));`
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, query, user.ID, database.Succeeded.String()); err != nil {
+		t.Fatalf("Failed to insert course purchase: %s", err)
+	}
+
+	// The chapter only gets a release rule long after the course was bought, so the learner's release date has
+	// already passed.
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, `UPDATE course_chapters SET release_set_at = datetime('now', '-40 days');`); err != nil {
+		t.Fatalf("Failed to backdate chapter: %s", err)
+	}
+
+	if err := internal.UpdateChapter(ctx, sqliteDatabase.connection, "chapter", "Chapter", "chapter", 1, "", nil, 0, 0, "", "chapter", "course", sql.NullInt32{Int32: 7, Valid: true}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to update chapter: %s", err)
+	}
+
+	releases, err := sqliteDatabase.GetDueChapterReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get due chapter releases: %s", err)
+	}
+
+	if len(releases) != 0 {
+		t.Fatalf("Expected no releases from before the release rule was set. Got %d", len(releases))
+	}
+
+	// Updating the chapter without touching its release rule keeps the time that the rule was set.
+	if _, err := sqliteDatabase.connection.ExecContext(ctx, `UPDATE course_chapters SET release_set_at = datetime('now', '-40 days');`); err != nil {
+		t.Fatalf("Failed to backdate release rule: %s", err)
+	}
+
+	if err := internal.UpdateChapter(ctx, sqliteDatabase.connection, "chapter", "Renamed", "chapter", 1, "", nil, 0, 0, "", "chapter", "course", sql.NullInt32{Int32: 7, Valid: true}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to update chapter: %s", err)
+	}
+
+	releases, err = sqliteDatabase.GetDueChapterReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get due chapter releases: %s", err)
+	}
+
+	if len(releases) != 1 || releases[0].ChapterID != "chapter" {
+		t.Errorf("Expected the release to be due once the rule was in place before it. Got %d releases", len(releases))
+	}
+}
+
+func TestAddChapterReleaseNotification(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetUserChapterReleaseNotifications(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	var users []string
+
+	for _, email := range []string{"jane@example.com", "john@example.com"} {
+		if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", email, "password"); err != nil {
+			t.Fatalf("Failed to create user: %s", err)
+		}
+
+		user, err := sqliteDatabase.GetUserByEmail(ctx, email, database.All)
+		if err != nil || user == nil {
+			t.Fatalf("Failed to get user by email: %v", err)
+		}
+
+		users = append(users, user.ID)
+	}
+
+	if err := internal.AddCourse(ctx, sqliteDatabase.connection, "course", "Course", "course", "", "", "", "", "", "course", sql.NullTime{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add course: %s", err)
+	}
+
+	if err := internal.AddChapter(ctx, sqliteDatabase.connection, "chapter", "Chapter", "chapter", 1, "", nil, 0, 0, "", "chapter", "course", sql.NullInt32{Int32: 7, Valid: true}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add chapter: %s", err)
+	}
+
+	if err := sqliteDatabase.AddChapterReleaseNotification(ctx, users[0], "chapter"); err != nil {
+		t.Fatalf("Failed to add chapter release notification: %s", err)
+	}
+
+	notifications, err := sqliteDatabase.GetUserChapterReleaseNotifications(ctx, users[0])
+	if err != nil {
+		t.Fatalf("Failed to get chapter release notifications: %s", err)
+	}
+
+	if len(notifications) != 1 || notifications[0].ChapterID != "chapter" || notifications[0].UserID != users[0] || notifications[0].CreatedAt.IsZero() {
+		t.Fatalf("Expected 1 notification about the chapter. Got %d", len(notifications))
+	}
+
+	notifications, err = sqliteDatabase.GetUserChapterReleaseNotifications(ctx, users[1])
+	if err != nil {
+		t.Fatalf("Failed to get chapter release notifications: %s", err)
+	}
+
+	if len(notifications) != 0 {
+		t.Errorf("Expected no notifications for a user who wasn't notified. Got %d", len(notifications))
+	}
+}
@@ -1,19 +1,27 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
 // AdminGetComments gets a paginated list of all comments for the admin panel.
-func (db *SQLiteDatabase) AdminGetComments(term, tutorialId, userId string, page, elements uint) ([]*models.CommentModel, error) {
+func (db *SQLiteDatabase) AdminGetComments(ctx context.Context, term, tutorialId, userId string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CommentModel, *database.Cursor, error) {
 	query := `SELECT c.id, c.content, c.user_id, c.tutorial_id, c.created_at FROM comments AS c WHERE (LOWER(c.id) LIKE '%' || ? || '%' OR LOWER(c.content) LIKE '%' || ? || '%')`
 
 	args := []any{term, term}
 
+	if deleted {
+		query += " AND c.deleted_at IS NOT NULL"
+	} else {
+		query += " AND c.deleted_at IS NULL"
+	}
+
 	if tutorialId != "" {
 		query += " AND c.tutorial_id = ?"
 		args = append(args, tutorialId)
@@ -24,18 +32,21 @@ func (db *SQLiteDatabase) AdminGetComments(term, tutorialId, userId string, page
 		args = append(args, userId)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY c.created_at DESC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("comments", "c", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY c.created_at DESC, c.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var comments []*models.CommentModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all comments from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -43,7 +54,7 @@ func (db *SQLiteDatabase) AdminGetComments(term, tutorialId, userId string, page
 
 		if err := rows.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from comments table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		comments = append(comments, &comment)
@@ -53,28 +64,38 @@ func (db *SQLiteDatabase) AdminGetComments(term, tutorialId, userId string, page
 		db.ErrorLog.Printf("Failed to get all comments from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
-	return comments, nil
+	comments, next := database.Paginate(comments, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(comments[i].CreatedAt, comments[i].ID)
+	})
+
+	return comments, next, nil
 }
 
 // GetAllCommentsPaginated gets a paginated list of comments for a given tutorial by ID.
-func (db *SQLiteDatabase) GetAllCommentsPaginated(tutorialId string, page, elements int) ([]*models.CommentModel, error) {
-	query := `SELECT id, content, user_id, tutorial_id, created_at FROM comments WHERE tutorial_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?;`
+func (db *SQLiteDatabase) GetAllCommentsPaginated(ctx context.Context, tutorialId string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.content, c.user_id, c.tutorial_id, c.created_at FROM comments AS c WHERE c.tutorial_id = ? AND c.deleted_at IS NULL`
+	args := []any{tutorialId}
+
+	condition, cursorArgs := internal.AfterCursor("comments", "c", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
 
-	offset := (page - 1) * elements
+	query += " ORDER BY c.created_at DESC, c.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var comments []*models.CommentModel
 
-	rows, err := db.connection.Query(query, tutorialId, elements, offset)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return comments, nil
+			return comments, nil, nil
 		}
 
 		db.ErrorLog.Printf("Failed to get comments from the database: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -82,7 +103,7 @@ func (db *SQLiteDatabase) GetAllCommentsPaginated(tutorialId string, page, eleme
 
 		if err := rows.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read individual row from comments table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		comments = append(comments, &comment)
@@ -90,28 +111,38 @@ func (db *SQLiteDatabase) GetAllCommentsPaginated(tutorialId string, page, eleme
 
 	if rows.Err() != nil {
 		db.ErrorLog.Printf("Got an error after reading rows from comments table: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return comments, nil
+	comments, next := database.Paginate(comments, elements, func(i int) *database.Cursor {
+		return database.NewCursor(comments[i].CreatedAt, comments[i].ID)
+	})
+
+	return comments, next, nil
 }
 
 // GetAllCommentsBySlugPaginated gets a paginated list of comments for a given tutorial by slug.
-func (db *SQLiteDatabase) GetAllCommentsBySlugPaginated(slug string, page, elements int) ([]*models.CommentModel, error) {
-	query := `SELECT id, content, user_id, tutorial_id, created_at FROM comments WHERE tutorial_id = (SELECT id FROM tutorials WHERE slug = ?) ORDER BY created_at DESC LIMIT ? OFFSET ?;`
+func (db *SQLiteDatabase) GetAllCommentsBySlugPaginated(ctx context.Context, slug string, cursor *database.Cursor, elements int) ([]*models.CommentModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.content, c.user_id, c.tutorial_id, c.created_at FROM comments AS c WHERE c.tutorial_id = (SELECT id FROM tutorials WHERE slug = ?) AND c.deleted_at IS NULL`
+	args := []any{slug}
 
-	offset := (page - 1) * elements
+	condition, cursorArgs := internal.AfterCursor("comments", "c", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY c.created_at DESC, c.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var comments []*models.CommentModel
 
-	rows, err := db.connection.Query(query, slug, elements, offset)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return comments, nil
+			return comments, nil, nil
 		}
 
 		db.ErrorLog.Printf("Failed to get comments from the database: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -119,7 +150,7 @@ func (db *SQLiteDatabase) GetAllCommentsBySlugPaginated(slug string, page, eleme
 
 		if err := rows.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read individual row from comments table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		comments = append(comments, &comment)
@@ -127,19 +158,23 @@ func (db *SQLiteDatabase) GetAllCommentsBySlugPaginated(slug string, page, eleme
 
 	if rows.Err() != nil {
 		db.ErrorLog.Printf("Got an error after reading rows from comments table: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return comments, nil
+	comments, next := database.Paginate(comments, elements, func(i int) *database.Cursor {
+		return database.NewCursor(comments[i].CreatedAt, comments[i].ID)
+	})
+
+	return comments, next, nil
 }
 
 // CountCommentsForTutorial counts the number of comments a given tutorial has.
-func (db *SQLiteDatabase) CountCommentsForTutorial(tutorialId string) (uint, error) {
-	query := `SELECT COUNT(id) FROM comments WHERE tutorial_id = ?;`
+func (db *SQLiteDatabase) CountCommentsForTutorial(ctx context.Context, tutorialId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM comments WHERE tutorial_id = ? AND deleted_at IS NULL;`
 
 	var comments uint
 
-	row := db.connection.QueryRow(query, tutorialId)
+	row := db.connection.QueryRowContext(ctx, query, tutorialId)
 	if err := row.Scan(&comments); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -153,7 +188,7 @@ func (db *SQLiteDatabase) CountCommentsForTutorial(tutorialId string) (uint, err
 }
 
 // AddCommentBySlug adds a comment to a tutorial by the tutorial slug.
-func (db *SQLiteDatabase) AddCommentBySlug(content, userId, slug string) (*models.CommentModel, error) {
+func (db *SQLiteDatabase) AddCommentBySlug(ctx context.Context, content, userId, slug string) (*models.CommentModel, error) {
 	query := `INSERT INTO comments (id, content, user_id, tutorial_id, created_at) VALUES (?, ?, ?, (SELECT id FROM tutorials WHERE slug = ?), ?);`
 
 	id, err := database.GenerateID()
@@ -170,7 +205,7 @@ func (db *SQLiteDatabase) AddCommentBySlug(content, userId, slug string) (*model
 		CreatedAt:  time.Now(),
 	}
 
-	result, err := db.connection.Exec(query, comment.ID, comment.Content, comment.UserID, slug, comment.CreatedAt)
+	result, err := db.connection.ExecContext(ctx, query, comment.ID, comment.Content, comment.UserID, slug, comment.CreatedAt)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to insert new comment in comments table: %s\n", err)
 		return nil, err
@@ -191,12 +226,12 @@ func (db *SQLiteDatabase) AddCommentBySlug(content, userId, slug string) (*model
 }
 
 // CountComments counts the number of comments in the database.
-func (db *SQLiteDatabase) CountComments() (uint, error) {
-	query := `SELECT COUNT(id) FROM comments;`
+func (db *SQLiteDatabase) CountComments(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM comments WHERE deleted_at IS NULL;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		db.ErrorLog.Printf("Failed to count all comments in the database: %s\n", err)
 		return 0, err
@@ -205,11 +240,11 @@ func (db *SQLiteDatabase) CountComments() (uint, error) {
 	return count, nil
 }
 
-// DeleteComment deletes a comment by it's ID.
-func (db *SQLiteDatabase) DeleteComment(commentId string) error {
-	query := `DELETE FROM comments WHERE id = ?;`
+// DeleteComment soft deletes a comment by it's ID.
+func (db *SQLiteDatabase) DeleteComment(ctx context.Context, commentId string) error {
+	query := `UPDATE comments SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;`
 
-	result, err := db.connection.Exec(query, commentId)
+	result, err := db.connection.ExecContext(ctx, query, commentId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to delete comment (\"%s\"): %s\n", commentId, err)
 		return err
@@ -228,3 +263,46 @@ func (db *SQLiteDatabase) DeleteComment(commentId string) error {
 
 	return nil
 }
+
+// GetCommentByID gets a comment by it's ID. Soft deleted comments are included so that they can be restored.
+func (db *SQLiteDatabase) GetCommentByID(ctx context.Context, commentId string) (*models.CommentModel, error) {
+	query := `SELECT id, content, user_id, tutorial_id, created_at FROM comments WHERE id = ?;`
+
+	var comment models.CommentModel
+
+	row := db.connection.QueryRowContext(ctx, query, commentId)
+	if err := row.Scan(&comment.ID, &comment.Content, &comment.UserID, &comment.TutorialID, &comment.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get comment (\"%s\") from the database: %s\n", commentId, err)
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// RestoreComment brings back a soft deleted comment.
+func (db *SQLiteDatabase) RestoreComment(ctx context.Context, commentId string) error {
+	query := `UPDATE comments SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, commentId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to restore comment (\"%s\"): %s\n", commentId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get the rows affected after restoring comment: %s\n", err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("No rows were affected after restoring comment (\"%s\")\n", commentId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
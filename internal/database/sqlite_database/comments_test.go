@@ -29,3 +29,11 @@ func TestCountComments(t *testing.T) {
 func TestDeleteComment(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestGetCommentByID(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestRestoreComment(t *testing.T) {
+	// TODO: Implement.
+}
@@ -1,8 +1,8 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -11,7 +11,7 @@ import (
 )
 
 // AdminGetCoursePurchases retrieves all course purchases according to the search parameters in a paginated fashion.
-func (db *SQLiteDatabase) AdminGetCoursePurchases(term string, courseId string, authorId string, status string, page, elements uint) ([]*models.CoursePurchaseModel, error) {
+func (db *SQLiteDatabase) AdminGetCoursePurchases(ctx context.Context, term string, courseId string, authorId string, status string, cursor *database.Cursor, elements uint) ([]*models.CoursePurchaseModel, *database.Cursor, error) {
 	query := "SELECT cp.id, cp.user_id, cp.course_id, cp.payment_key, cp.stripe_checkout_session_id, cp.affiliate_code, cp.discount_code, cp.affiliate_points_used, cp.amount_paid, cp.payment_status, cp.created_at, cp.updated_at FROM course_purchases AS cp LEFT JOIN users AS u ON cp.user_id = u.id LEFT JOIN courses AS c ON cp.course_id = c.id WHERE 1=1"
 	var args []any
 
@@ -35,18 +35,21 @@ func (db *SQLiteDatabase) AdminGetCoursePurchases(term string, courseId string,
 		args = append(args, status)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY cp.updated_at DESC, cp.created_at DESC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("course_purchases", "cp", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY cp.created_at DESC, cp.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var coursePurchases []*models.CoursePurchaseModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all course purchases from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -54,7 +57,7 @@ func (db *SQLiteDatabase) AdminGetCoursePurchases(term string, courseId string,
 
 		if err := rows.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from course purchases table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		coursePurchases = append(coursePurchases, &coursePurchase)
@@ -64,15 +67,19 @@ func (db *SQLiteDatabase) AdminGetCoursePurchases(term string, courseId string,
 		db.ErrorLog.Printf("Failed to get all course purchases from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
-	return coursePurchases, nil
+	coursePurchases, next := database.Paginate(coursePurchases, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(coursePurchases[i].CreatedAt, coursePurchases[i].ID)
+	})
+
+	return coursePurchases, next, nil
 }
 
 // HasUserPurchasedCourse checks to see if a course has been purchased by a user.
-func (db *SQLiteDatabase) HasUserPurchasedCourse(userId, courseId string) (bool, error) {
-	b, err := internal.HasUserPurchasedCourse(db.connection, userId, courseId)
+func (db *SQLiteDatabase) HasUserPurchasedCourse(ctx context.Context, userId, courseId string) (bool, error) {
+	b, err := internal.HasUserPurchasedCourse(ctx, db.connection, userId, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to check if user (\"%s\") has purchased course (\"%s\"): %s\n", userId, courseId, err)
 
@@ -82,7 +89,7 @@ func (db *SQLiteDatabase) HasUserPurchasedCourse(userId, courseId string) (bool,
 	return b, nil
 }
 
-func (db *SQLiteDatabase) RegisterCoursePurchase(userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
+func (db *SQLiteDatabase) RegisterCoursePurchase(ctx context.Context, userId, courseId, paymentKey, stripeCheckoutSessionId string, affiliateCode, discountCode sql.NullString, affiliatePointsUsed uint, amountPaid float64, token, tokenType string, validUntil time.Time) error {
 	purchaseId, err := database.GenerateID()
 	if err != nil {
 		db.ErrorLog.Printf("Failed to generate ID for new course purchase: %s\n", err)
@@ -101,88 +108,24 @@ func (db *SQLiteDatabase) RegisterCoursePurchase(userId, courseId, paymentKey, s
 		return err
 	}
 
-	tx, err := db.connection.Begin()
+	tx, err := db.connection.BeginTx(ctx, nil)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
 		return err
 	}
 
-	user, err := internal.GetUserByID(tx, userId, database.All)
-	if err != nil {
-		if err := tx.Rollback(); err != nil {
-			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
-		}
-
-		db.ErrorLog.Printf("Failed to get user by ID (\"%s\"): %s\n", userId, err)
-		return err
-	}
-
-	course, err := internal.GetCourseByID(tx, courseId)
-	if err != nil {
+	if err := internal.RegisterCoursePurchase(ctx, tx, purchaseId, affiliateHistoryId, paymentTokenId, userId, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid, token, tokenType, validUntil); err != nil {
 		if err := tx.Rollback(); err != nil {
 			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
 		}
 
-		db.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", courseId, err)
-	}
-
-	purchased, err := internal.HasUserPurchasedCourse(tx, user.ID, course.ID)
-	if err != nil {
-		if err := tx.Rollback(); err != nil {
-			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		if err != database.ErrCourseAlreadyOwned && err != database.ErrInsufficientAffiliatePoints {
+			db.ErrorLog.Printf("Failed to register course purchase: %s\n", err)
 		}
 
-		db.ErrorLog.Printf("Failed to check if user (\"%s\") has already purchased this course (\"%s\"): %s\n", userId, courseId, err)
 		return err
 	}
 
-	if purchased {
-		if err := tx.Rollback(); err != nil {
-			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
-		}
-
-		return database.ErrCourseAlreadyOwned
-	}
-
-	if user.AffiliatePoints < int(affiliatePointsUsed) {
-		if err := tx.Rollback(); err != nil {
-			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
-		}
-
-		return database.ErrInsufficientAffiliatePoints
-	}
-
-	if affiliatePointsUsed > 0 {
-		if err := internal.RegisterAffiliatePointsChange(tx, affiliateHistoryId, user.ID, courseId, -1*int(affiliatePointsUsed), fmt.Sprintf("Purchased \"%s\"", course.Title)); err != nil {
-			if err := tx.Rollback(); err != nil {
-				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
-			}
-
-			db.ErrorLog.Printf("Failed to register affiliate point change: %s\n", err)
-			return err
-		}
-	}
-
-	if err := internal.AddNewCoursePurchase(tx, purchaseId, user.ID, courseId, paymentKey, stripeCheckoutSessionId, affiliateCode, discountCode, affiliatePointsUsed, amountPaid); err != nil {
-		if err := tx.Rollback(); err != nil {
-			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
-		}
-
-		db.ErrorLog.Printf("Failed to register course purchase: %s\n", err)
-		return err
-	}
-
-	if token != "" {
-		if err := internal.AddToken(tx, paymentTokenId, token, tokenType, user.ID, validUntil); err != nil {
-			if err := tx.Rollback(); err != nil {
-				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
-			}
-
-			db.ErrorLog.Printf("Failed to save the payment token: %s\n", err)
-			return err
-		}
-	}
-
 	if err := tx.Commit(); err != nil {
 		if err := tx.Rollback(); err != nil {
 			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
@@ -195,12 +138,12 @@ func (db *SQLiteDatabase) RegisterCoursePurchase(userId, courseId, paymentKey, s
 	return nil
 }
 
-func (db *SQLiteDatabase) CountAllPurchases() (uint, error) {
+func (db *SQLiteDatabase) CountAllPurchases(ctx context.Context) (uint, error) {
 	query := `SELECT COUNT(id) FROM course_purchases;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -213,12 +156,12 @@ func (db *SQLiteDatabase) CountAllPurchases() (uint, error) {
 	return count, nil
 }
 
-func (db *SQLiteDatabase) CountCoursesWhereDiscountWasUsed(discountCode string) (uint, error) {
+func (db *SQLiteDatabase) CountCoursesWhereDiscountWasUsed(ctx context.Context, discountCode string) (uint, error) {
 	query := `SELECT COUNT(id) FROM course_purchases WHERE discount_code = ?;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, discountCode)
+	row := db.connection.QueryRowContext(ctx, query, discountCode)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -231,12 +174,12 @@ func (db *SQLiteDatabase) CountCoursesWhereDiscountWasUsed(discountCode string)
 	return count, nil
 }
 
-func (db *SQLiteDatabase) CountUsersWhoBoughtCourse(courseId string) (uint, error) {
+func (db *SQLiteDatabase) CountUsersWhoBoughtCourse(ctx context.Context, courseId string) (uint, error) {
 	query := `SELECT COUNT(id) FROM course_purchases WHERE course_id = ?;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, courseId)
+	row := db.connection.QueryRowContext(ctx, query, courseId)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -249,30 +192,22 @@ func (db *SQLiteDatabase) CountUsersWhoBoughtCourse(courseId string) (uint, erro
 	return count, nil
 }
 
-func (db *SQLiteDatabase) GetCoursePurchaseByPaymentKey(paymentKey string) (*models.CoursePurchaseModel, error) {
-	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE payment_key = ?;`
-
-	var coursePurchase models.CoursePurchaseModel
-
-	row := db.connection.QueryRow(query, paymentKey)
-	if err := row.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-
+func (db *SQLiteDatabase) GetCoursePurchaseByPaymentKey(ctx context.Context, paymentKey string) (*models.CoursePurchaseModel, error) {
+	coursePurchase, err := internal.GetCoursePurchaseByPaymentKey(ctx, db.connection, paymentKey)
+	if err != nil {
 		db.ErrorLog.Printf("Failed to find course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
 		return nil, err
 	}
 
-	return &coursePurchase, nil
+	return coursePurchase, nil
 }
 
-func (db *SQLiteDatabase) GetCoursePurchaseByID(coursePurchaseId string) (*models.CoursePurchaseModel, error) {
+func (db *SQLiteDatabase) GetCoursePurchaseByID(ctx context.Context, coursePurchaseId string) (*models.CoursePurchaseModel, error) {
 	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE id = ?;`
 
 	var coursePurchase models.CoursePurchaseModel
 
-	row := db.connection.QueryRow(query, coursePurchaseId)
+	row := db.connection.QueryRowContext(ctx, query, coursePurchaseId)
 	if err := row.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -285,32 +220,24 @@ func (db *SQLiteDatabase) GetCoursePurchaseByID(coursePurchaseId string) (*model
 	return &coursePurchase, nil
 }
 
-func (db *SQLiteDatabase) GetCoursePurchaseByCheckoutSession(checkoutSessionId string) (*models.CoursePurchaseModel, error) {
-	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE stripe_checkout_session_id = ?;`
-
-	var coursePurchase models.CoursePurchaseModel
-
-	row := db.connection.QueryRow(query, checkoutSessionId)
-	if err := row.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-
+func (db *SQLiteDatabase) GetCoursePurchaseByCheckoutSession(ctx context.Context, checkoutSessionId string) (*models.CoursePurchaseModel, error) {
+	coursePurchase, err := internal.GetCoursePurchaseByCheckoutSession(ctx, db.connection, checkoutSessionId)
+	if err != nil {
 		db.ErrorLog.Printf("Failed to find course purchase by stripe checkout session ID (\"%s\"): %s\n", checkoutSessionId, err)
 		return nil, err
 	}
 
-	return &coursePurchase, nil
+	return coursePurchase, nil
 }
 
-func (db *SQLiteDatabase) GetCourseByCoursePurchaseID(coursePurchaseId string) (*models.CourseModel, error) {
-	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp LEFT JOIN courses AS c ON cp.course_id = c.id WHERE cp.id = ?;`
+func (db *SQLiteDatabase) GetCourseByCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.CourseModel, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp LEFT JOIN courses AS c ON cp.course_id = c.id WHERE cp.id = ?;`
 
 	var course models.CourseModel
 	var published int
 
-	row := db.connection.QueryRow(query, coursePurchaseId)
-	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, coursePurchaseId)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -324,31 +251,27 @@ func (db *SQLiteDatabase) GetCourseByCoursePurchaseID(coursePurchaseId string) (
 	return &course, nil
 }
 
-func (db *SQLiteDatabase) UpdateCoursePurchasePaymentStatus(coursePurchaseId string, status database.PaymentStatus) error {
-	query := `UPDATE course_purchases SET payment_status = ? WHERE id = ?;`
-
-	result, err := db.connection.Exec(query, status.String(), coursePurchaseId)
-	if err != nil {
+func (db *SQLiteDatabase) UpdateCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, status database.PaymentStatus) error {
+	if err := internal.UpdateCoursePurchasePaymentStatus(ctx, db.connection, coursePurchaseId, status); err != nil {
 		db.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		db.ErrorLog.Printf("Failed to query database for rows affected after updating course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
-		return err
-	}
+	return nil
+}
 
-	if rowsAffected == 0 {
-		db.ErrorLog.Printf("No rows were affected after updating course purchase's (\"%s\") payment status\n", coursePurchaseId)
-		return database.ErrNoRowsAffected
+func (db *SQLiteDatabase) TransitionCoursePurchasePaymentStatus(ctx context.Context, coursePurchaseId string, from []database.PaymentStatus, to database.PaymentStatus) (bool, error) {
+	changed, err := internal.TransitionCoursePurchasePaymentStatus(ctx, db.connection, coursePurchaseId, from, to)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchaseId, err)
+		return false, err
 	}
 
-	return nil
+	return changed, nil
 }
 
-func (db *SQLiteDatabase) GetCoursesBoughtByUser(term, userId string, page, elements uint) ([]*models.CourseModel, error) {
-	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp JOIN courses AS c ON cp.course_id = c.id WHERE cp.user_id = ? AND cp.payment_status = ? AND c.published = 1`
+func (db *SQLiteDatabase) GetCoursesBoughtByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at, cp.created_at, cp.id FROM course_purchases AS cp JOIN courses AS c ON cp.course_id = c.id WHERE cp.user_id = ? AND cp.payment_status = ? AND c.published = 1 AND c.deleted_at IS NULL`
 	args := []any{userId, database.Succeeded.String()}
 
 	if term != "" {
@@ -356,46 +279,57 @@ func (db *SQLiteDatabase) GetCoursesBoughtByUser(term, userId string, page, elem
 		args = append(args, term, term, term)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY cp.updated_at DESC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	// The courses are ordered by when they were bought so the cursor points at the purchase rather than the course.
+	condition, cursorArgs := internal.AfterCursor("course_purchases", "cp", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY cp.created_at DESC, cp.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var courses []*models.CourseModel
+	var purchases []*database.Cursor
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
 		var course models.CourseModel
 		var published int
+		var purchase database.Cursor
 
-		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt, &purchase.CreatedAt, &purchase.ID); err != nil {
 			db.ErrorLog.Printf("Failed to read course from the database: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		course.Published = published == 1
 
 		courses = append(courses, &course)
+		purchases = append(purchases, &purchase)
 	}
 
 	if err := rows.Err(); err != nil {
 		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return courses, nil
+	courses, next := database.Paginate(courses, int(elements), func(i int) *database.Cursor {
+		return purchases[i]
+	})
+
+	return courses, next, nil
 }
 
-func (db *SQLiteDatabase) GetAllCoursesBoughtByUser(userId string) ([]*models.CourseModel, error) {
-	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp LEFT JOIN courses AS c ON cp.course_id = c.id WHERE cp.user_id = ? AND cp.payment_status = 'Succeeded' ORDER BY cp.updated_at DESC;`
+func (db *SQLiteDatabase) GetAllCoursesBoughtByUser(ctx context.Context, userId string) ([]*models.CourseModel, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM course_purchases AS cp LEFT JOIN courses AS c ON cp.course_id = c.id WHERE cp.user_id = ? AND cp.payment_status = 'Succeeded' ORDER BY cp.updated_at DESC;`
 
 	var courses []*models.CourseModel
 
-	rows, err := db.connection.Query(query, userId)
+	rows, err := db.connection.QueryContext(ctx, query, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all courses purchased bought by user (\"%s\"): %s\n", userId, err)
 		return nil, err
@@ -405,7 +339,7 @@ func (db *SQLiteDatabase) GetAllCoursesBoughtByUser(userId string) ([]*models.Co
 		var course models.CourseModel
 		var published int
 
-		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read course from the database: %s\n", err)
 			return nil, err
 		}
@@ -423,32 +357,31 @@ func (db *SQLiteDatabase) GetAllCoursesBoughtByUser(userId string) ([]*models.Co
 	return courses, nil
 }
 
-func (db *SQLiteDatabase) GetCoursePurchasesByUserAndCourse(userId, courseId string) ([]*models.CoursePurchaseModel, error) {
-	query := `SELECT id, user_id, course_id, payment_key, stripe_checkout_session_id, affiliate_code, discount_code, affiliate_points_used, amount_paid, payment_status, created_at, updated_at FROM course_purchases WHERE user_id = ? AND course_id = ?;`
-
-	coursePurchases := []*models.CoursePurchaseModel{}
-
-	rows, err := db.connection.Query(query, userId, courseId, database.Succeeded.String())
+func (db *SQLiteDatabase) GetCoursePurchasesByUserAndCourse(ctx context.Context, userId, courseId string) ([]*models.CoursePurchaseModel, error) {
+	coursePurchases, err := internal.GetCoursePurchasesByUserAndCourse(ctx, db.connection, userId, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get course purchases for user (\"%s\") and course (\"%s\"): %s\n", userId, courseId, err)
 		return nil, err
 	}
 
-	for rows.Next() {
-		var coursePurchase models.CoursePurchaseModel
+	return coursePurchases, nil
+}
 
-		if err := rows.Scan(&coursePurchase.ID, &coursePurchase.UserID, &coursePurchase.CourseID, &coursePurchase.PaymentKey, &coursePurchase.StripeCheckoutSessionID, &coursePurchase.AffiliateCode, &coursePurchase.DiscountCode, &coursePurchase.AffiliatePointsUsed, &coursePurchase.AmountPaid, &coursePurchase.PaymentStatus, &coursePurchase.CreatedAt, &coursePurchase.UpdatedAt); err != nil {
-			db.ErrorLog.Printf("Failed to get course purchase information for user (\"%s\") and course (\"%s\"): %s\n", userId, courseId, err)
-			return nil, err
-		}
+// GetCoursePurchaseDate gets when the user first bought the course. The returned time is invalid if the user hasn't
+// bought the course.
+func (db *SQLiteDatabase) GetCoursePurchaseDate(ctx context.Context, userId, courseId string) (sql.NullTime, error) {
+	query := `SELECT created_at FROM course_purchases WHERE user_id = ? AND course_id = ? AND payment_status = ? ORDER BY created_at ASC LIMIT 1;`
 
-		coursePurchases = append(coursePurchases, &coursePurchase)
-	}
+	var purchasedAt sql.NullTime
 
-	if err := rows.Err(); err != nil {
-		db.ErrorLog.Printf("Failed to get course purchases for user (\"%s\") and course (\"%s\"): %s\n", userId, courseId, err)
-		return nil, err
+	if err := db.connection.QueryRowContext(ctx, query, userId, courseId, database.Succeeded.String()).Scan(&purchasedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return sql.NullTime{}, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get the date that user (\"%s\") bought course (\"%s\"): %s\n", userId, courseId, err)
+		return sql.NullTime{}, err
 	}
 
-	return coursePurchases, nil
+	return purchasedAt, nil
 }
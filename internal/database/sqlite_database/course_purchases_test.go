@@ -46,6 +46,10 @@ func TestUpdateCoursePurchasePaymentStatus(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestTransitionCoursePurchasePaymentStatus(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestGetCoursesBoughtByUser(t *testing.T) {
 	// TODO: Implement.
 }
@@ -57,3 +61,7 @@ func TestGetAllCoursesBoughtByUser(t *testing.T) {
 func TestGetCoursePurchasesByUserAndCourse(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestGetCoursePurchaseDate(t *testing.T) {
+	// TODO: Implement.
+}
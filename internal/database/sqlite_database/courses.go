@@ -1,16 +1,33 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) AdminGetCourses(term string, published *bool, authorId *string, boughtBy, keyword string, page, elements uint) ([]*models.CourseModel, error) {
-	query := `SELECT DISTINCT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM courses AS c LEFT JOIN course_purchases AS cp ON cp.course_id = c.id LEFT JOIN courses_keywords AS ck ON ck.course_id = c.id LEFT JOIN keywords AS k ON k.id = ck.keyword_id WHERE (LOWER(c.id) LIKE '%' || ? || '%' OR LOWER(c.title) LIKE '%' || ? || '%' OR LOWER(c.slug) LIKE '%' || ? || '%' OR LOWER(c.description) LIKE '%' || ? || '%' OR LOWER(k.keyword) LIKE '%' || ? || '%')`
+func (db *SQLiteDatabase) AdminGetCourses(ctx context.Context, term string, published *bool, authorId *string, boughtBy, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.CourseModel, *database.Cursor, error) {
+	query := `SELECT DISTINCT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at FROM courses AS c LEFT JOIN course_purchases AS cp ON cp.course_id = c.id LEFT JOIN courses_keywords AS ck ON ck.course_id = c.id LEFT JOIN keywords AS k ON k.id = ck.keyword_id WHERE 1=1`
+	args := []any{}
 
-	args := []any{term, term, term, term, term}
+	if deleted {
+		query += " AND c.deleted_at IS NOT NULL"
+	} else {
+		query += " AND c.deleted_at IS NULL"
+	}
+
+	if term != "" {
+		if match := internal.FullTextQuery(term); match != "" {
+			query += " AND (c.id = ? OR c.id IN (SELECT id FROM courses_fts WHERE courses_fts MATCH ?))"
+			args = append(args, term, match)
+		} else {
+			query += " AND c.id = ?"
+			args = append(args, term)
+		}
+	}
 
 	if published != nil {
 		query += " AND c.published = ?"
@@ -44,27 +61,30 @@ func (db *SQLiteDatabase) AdminGetCourses(term string, published *bool, authorId
 		args = append(args, keyword)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY c.created_at DESC, c.updated_at DESC, c.title ASC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("courses", "c", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY c.created_at DESC, c.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var courses []*models.CourseModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all courses from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
 		var course models.CourseModel
 		var published int
 
-		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from courses table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		course.Published = published == 1
@@ -76,14 +96,18 @@ func (db *SQLiteDatabase) AdminGetCourses(term string, published *bool, authorId
 		db.ErrorLog.Printf("Failed to get all courses from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used: \n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
-	return courses, nil
+	courses, next := database.Paginate(courses, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(courses[i].CreatedAt, courses[i].ID)
+	})
+
+	return courses, next, nil
 }
 
-func (db *SQLiteDatabase) GetAllCourses(authorId string, published *bool) ([]*models.CourseModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE 1=1`
+func (db *SQLiteDatabase) GetAllCourses(ctx context.Context, authorId string, published *bool) ([]*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE 1=1`
 	args := []any{}
 
 	if authorId != "" {
@@ -91,11 +115,13 @@ func (db *SQLiteDatabase) GetAllCourses(authorId string, published *bool) ([]*mo
 		args = append(args, authorId)
 	}
 
+	// Soft deleted courses are only left out when filtering on their publish status because the content command
+	// still needs to find them by file key.
 	if published != nil {
-		query += " AND published = ?"
+		query += " AND deleted_at IS NULL AND published = ?"
 
 		if *published {
-			query += " AND author_id IS NOT NULL"
+			query += " AND author_id IN (SELECT id FROM users WHERE deleted_at IS NULL)"
 			args = append(args, 1)
 		} else {
 			args = append(args, 0)
@@ -106,7 +132,7 @@ func (db *SQLiteDatabase) GetAllCourses(authorId string, published *bool) ([]*mo
 
 	var courses []*models.CourseModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to query database for all courses: %s\n", err)
 		return nil, err
@@ -116,7 +142,7 @@ func (db *SQLiteDatabase) GetAllCourses(authorId string, published *bool) ([]*mo
 		var course models.CourseModel
 		var published int
 
-		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from courses table: %s\n", err)
 			return nil, err
 		}
@@ -134,62 +160,88 @@ func (db *SQLiteDatabase) GetAllCourses(authorId string, published *bool) ([]*mo
 	return courses, nil
 }
 
-func (db *SQLiteDatabase) GetCourses(term string, authorId string, page, elements int) ([]*models.CourseModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE published = 1 AND author_id IS NOT NULL`
+func (db *SQLiteDatabase) GetCourses(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.CourseModel, *database.Cursor, error) {
+	query := `SELECT c.id, c.title, c.slug, c.description, c.thumbnail_url, c.banner_url, c.content, c.reading_time, c.published, c.author_id, c.file_checksum, c.file_key, c.created_at, c.updated_at`
 	args := []any{}
 
-	if term != "" {
-		query += " AND (LOWER(title) LIKE '%' || ? || '%' OR LOWER(description) LIKE '%' || ? || '%')"
-		args = append(args, term, term)
+	match := internal.FullTextQuery(term)
+	if match != "" {
+		query += ", " + internal.CoursesRank + " FROM courses AS c JOIN courses_fts ON courses_fts.id = c.id WHERE courses_fts MATCH ? AND"
+		args = append(args, match)
+	} else {
+		query += ", 0.0 FROM courses AS c WHERE"
 	}
 
+	query += " c.published = 1 AND c.author_id IN (SELECT id FROM users WHERE deleted_at IS NULL) AND c.deleted_at IS NULL"
+
 	if authorId != "" {
-		query += " AND author_id = ?"
+		query += " AND c.author_id = ?"
 		args = append(args, authorId)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY updated_at DESC, title ASC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	// Search results are ordered by relevance so the cursor needs to keep track of the rank of the last result.
+	if match != "" {
+		condition, cursorArgs := internal.AfterRankedCursor(internal.CoursesRank, "c", cursor)
+		query += condition + " ORDER BY " + internal.CoursesRank + " ASC, c.id ASC"
+		args = append(args, cursorArgs...)
+	} else {
+		condition, cursorArgs := internal.AfterCursor("courses", "c", cursor)
+		query += condition + " ORDER BY c.created_at DESC, c.id DESC"
+		args = append(args, cursorArgs...)
+	}
+
+	query += " LIMIT ?;"
+	args = append(args, elements+1)
 
 	var courses []*models.CourseModel
+	var ranks []float64
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
-		db.ErrorLog.Printf("Failed to query database for all courses on page %d: %s\n", page, err)
-		return nil, err
+		db.ErrorLog.Printf("Failed to query database for the next page of courses: %s\n", err)
+		return nil, nil, err
 	}
 
 	for rows.Next() {
 		var course models.CourseModel
 		var published int
+		var rank float64
 
-		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
-			db.ErrorLog.Printf("Failed to read row from courses table on page %d: %s\n", page, err)
-			return nil, err
+		if err := rows.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt, &rank); err != nil {
+			db.ErrorLog.Printf("Failed to read row from courses table: %s\n", err)
+			return nil, nil, err
 		}
 
 		course.Published = published == 1
 
 		courses = append(courses, &course)
+		ranks = append(ranks, rank)
 	}
 
 	if err := rows.Err(); err != nil {
-		db.ErrorLog.Printf("Failed to query database for all courses on page %d: %s\n", page, err)
-		return nil, err
+		db.ErrorLog.Printf("Failed to query database for the next page of courses: %s\n", err)
+		return nil, nil, err
 	}
 
-	return courses, nil
+	courses, next := database.Paginate(courses, elements, func(i int) *database.Cursor {
+		if match != "" {
+			return database.NewRankedCursor(ranks[i], courses[i].CreatedAt, courses[i].ID)
+		}
+
+		return database.NewCursor(courses[i].CreatedAt, courses[i].ID)
+	})
+
+	return courses, next, nil
 }
 
-func (db *SQLiteDatabase) GetCourseByFileKey(fileKey string) (*models.CourseModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE file_key = ?;`
+func (db *SQLiteDatabase) GetCourseByFileKey(ctx context.Context, fileKey string) (*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE file_key = ?;`
 
 	var course models.CourseModel
 	var published int
 
-	row := db.connection.QueryRow(query, fileKey)
-	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, fileKey)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -203,14 +255,14 @@ func (db *SQLiteDatabase) GetCourseByFileKey(fileKey string) (*models.CourseMode
 	return &course, nil
 }
 
-func (db *SQLiteDatabase) GetCourseBySlug(slug string) (*models.CourseModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE slug = ?;`
+func (db *SQLiteDatabase) GetCourseBySlug(ctx context.Context, slug string) (*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE slug = ? AND deleted_at IS NULL;`
 
 	var course models.CourseModel
 	var published int
 
-	row := db.connection.QueryRow(query, slug)
-	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, slug)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -224,8 +276,8 @@ func (db *SQLiteDatabase) GetCourseBySlug(slug string) (*models.CourseModel, err
 	return &course, nil
 }
 
-func (db *SQLiteDatabase) GetCourseByID(courseId string) (*models.CourseModel, error) {
-	course, err := internal.GetCourseByID(db.connection, courseId)
+func (db *SQLiteDatabase) GetCourseByID(ctx context.Context, courseId string) (*models.CourseModel, error) {
+	course, err := internal.GetCourseByID(ctx, db.connection, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", courseId, err)
 		return nil, err
@@ -234,12 +286,12 @@ func (db *SQLiteDatabase) GetCourseByID(courseId string) (*models.CourseModel, e
 	return course, nil
 }
 
-func (db *SQLiteDatabase) CountCourses() (uint, error) {
-	query := `SELECT COUNT(id) FROM courses;`
+func (db *SQLiteDatabase) CountCourses(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM courses WHERE deleted_at IS NULL;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -252,12 +304,12 @@ func (db *SQLiteDatabase) CountCourses() (uint, error) {
 	return count, nil
 }
 
-func (db *SQLiteDatabase) CountCoursesWrittenBy(authorId string) (uint, error) {
-	query := `SELECT COUNT(id) FROM courses WHERE author_id = ?;`
+func (db *SQLiteDatabase) CountCoursesWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM courses WHERE author_id = ? AND deleted_at IS NULL;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, authorId)
+	row := db.connection.QueryRowContext(ctx, query, authorId)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -270,10 +322,10 @@ func (db *SQLiteDatabase) CountCoursesWrittenBy(authorId string) (uint, error) {
 	return count, nil
 }
 
-func (db *SQLiteDatabase) PublishCourse(courseId string) error {
+func (db *SQLiteDatabase) PublishCourse(ctx context.Context, courseId string) error {
 	query := `UPDATE courses SET published = 1 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, courseId)
+	_, err := db.connection.ExecContext(ctx, query, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to publish course \"%s\": %s\n", courseId, err)
 		return err
@@ -282,10 +334,10 @@ func (db *SQLiteDatabase) PublishCourse(courseId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UnpublishCourse(courseId string) error {
+func (db *SQLiteDatabase) UnpublishCourse(ctx context.Context, courseId string) error {
 	query := `UPDATE courses SET published = 0 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, courseId)
+	_, err := db.connection.ExecContext(ctx, query, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to unpublish course \"%s\": %s\n", courseId, err)
 		return err
@@ -294,14 +346,14 @@ func (db *SQLiteDatabase) UnpublishCourse(courseId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UpdateCourseAuthor(courseId, authorId string) error {
+func (db *SQLiteDatabase) UpdateCourseAuthor(ctx context.Context, courseId, authorId string) error {
 	query := `UPDATE courses SET author_id = ? WHERE id = ?;`
 
 	if authorId == "" {
 		authorId = "NULL"
 	}
 
-	_, err := db.connection.Exec(query, authorId, courseId)
+	_, err := db.connection.ExecContext(ctx, query, authorId, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update course's (\"%s\") author (\"%s\"): %s\n", courseId, authorId, err)
 		return err
@@ -309,3 +361,147 @@ func (db *SQLiteDatabase) UpdateCourseAuthor(courseId, authorId string) error {
 
 	return nil
 }
+
+// DeleteCourse soft deletes the course so that it no longer shows up anywhere on the site.
+func (db *SQLiteDatabase) DeleteCourse(ctx context.Context, courseId string) error {
+	query := `UPDATE courses SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after deleting course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after deleting course \"%s\"\n", courseId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// RestoreCourse brings back a soft deleted course.
+func (db *SQLiteDatabase) RestoreCourse(ctx context.Context, courseId string) error {
+	query := `UPDATE courses SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, courseId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to restore course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after restoring course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after restoring course \"%s\"\n", courseId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// PurgeCourse permanently removes the course along with its keywords, chapters, quizzes, chapter completions and
+// certificates.
+// Courses that have been bought are kept so that the financial records stay intact, in which case
+// database.ErrCourseHasPurchases gets returned.
+func (db *SQLiteDatabase) PurgeCourse(ctx context.Context, courseId string) error {
+	queries := []string{
+		`DELETE FROM courses_keywords WHERE course_id = ?;`,
+		`DELETE FROM user_course_chapter_completion WHERE course_id = ?;`,
+		`DELETE FROM quiz_attempt_answers WHERE attempt_id IN (SELECT id FROM quiz_attempts WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = ?)));`,
+		`DELETE FROM quiz_attempts WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = ?));`,
+		`DELETE FROM quiz_questions WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = ?));`,
+		`DELETE FROM quizzes WHERE chapter_id IN (SELECT id FROM course_chapters WHERE course_id = ?);`,
+		`DELETE FROM certificates WHERE course_id = ?;`,
+		`DELETE FROM course_chapters WHERE course_id = ?;`,
+	}
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	var purchased bool
+
+	row := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM course_purchases WHERE course_id = ?) OR EXISTS (SELECT 1 FROM affiliate_points_history WHERE course_id = ?);`, courseId, courseId)
+	if err := row.Scan(&purchased); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to check if course \"%s\" has been purchased: %s\n", courseId, err)
+		return err
+	}
+
+	if purchased {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		return database.ErrCourseHasPurchases
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, courseId); err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to purge course \"%s\": %s\n", courseId, err)
+			db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM courses WHERE id = ?;`, courseId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to purge course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after purging course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after purging course \"%s\"\n", courseId)
+		return database.ErrNoRowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after purging course \"%s\": %s\n", courseId, err)
+		return err
+	}
+
+	return nil
+}
@@ -22,6 +22,10 @@ func TestUpdateChapter(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestDeleteChapter(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestRunBulkCourses(t *testing.T) {
 	// TODO: Implement.
 }
@@ -42,6 +46,14 @@ func TestUpdateChapters(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestDeleteChapters(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestParkChapters(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestAddKeywordsToCourse(t *testing.T) {
 	// TODO: Implement.
 }
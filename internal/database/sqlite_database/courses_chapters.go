@@ -1,17 +1,18 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 )
 
-func (db *SQLiteDatabase) GetAllChapters() ([]*models.ChapterModel, error) {
-	query := `SELECT id, title, slug, chapter, content, course_id, file_checksum, file_key, created_at, updated_at FROM course_chapters ORDER BY chapter ASC;`
+func (db *SQLiteDatabase) GetAllChapters(ctx context.Context) ([]*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters ORDER BY chapter ASC;`
 
 	var chapters []*models.ChapterModel
 
-	rows, err := db.connection.Query(query)
+	rows, err := db.connection.QueryContext(ctx, query)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all chapters from the database: %s\n", err)
 		return nil, err
@@ -20,7 +21,7 @@ func (db *SQLiteDatabase) GetAllChapters() ([]*models.ChapterModel, error) {
 	for rows.Next() {
 		var chapter models.ChapterModel
 
-		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from chapters table: %s\n", err)
 			return nil, err
 		}
@@ -36,13 +37,13 @@ func (db *SQLiteDatabase) GetAllChapters() ([]*models.ChapterModel, error) {
 	return chapters, nil
 }
 
-func (db *SQLiteDatabase) GetChapterBySlug(chapterSlug string) (*models.ChapterModel, error) {
-	query := `SELECT id, title, slug, chapter, content, course_id, file_checksum, file_key, created_at, updated_at FROM course_chapters WHERE slug = ?;`
+func (db *SQLiteDatabase) GetChapterBySlug(ctx context.Context, chapterSlug string) (*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE slug = ?;`
 
 	var chapter models.ChapterModel
 
-	row := db.connection.QueryRow(query, chapterSlug)
-	if err := row.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, chapterSlug)
+	if err := row.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -54,13 +55,13 @@ func (db *SQLiteDatabase) GetChapterBySlug(chapterSlug string) (*models.ChapterM
 	return &chapter, nil
 }
 
-func (db *SQLiteDatabase) GetChapterByFileKey(fileKey string) (*models.ChapterModel, error) {
-	query := `SELECT id, title, slug, chapter, content, course_id, file_checksum, file_key, created_at, updated_at FROM course_chapters WHERE file_key = ?;`
+func (db *SQLiteDatabase) GetChapterByFileKey(ctx context.Context, fileKey string) (*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE file_key = ?;`
 
 	var chapter models.ChapterModel
 
-	row := db.connection.QueryRow(query, fileKey)
-	if err := row.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, fileKey)
+	if err := row.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -72,12 +73,12 @@ func (db *SQLiteDatabase) GetChapterByFileKey(fileKey string) (*models.ChapterMo
 	return &chapter, nil
 }
 
-func (db *SQLiteDatabase) CountChapters(courseId string) (int, error) {
+func (db *SQLiteDatabase) CountChapters(ctx context.Context, courseId string) (int, error) {
 	query := `SELECT COUNT(id) FROM course_chapters WHERE course_id = ?;`
 
 	var chapters int
 
-	row := db.connection.QueryRow(query, courseId)
+	row := db.connection.QueryRowContext(ctx, query, courseId)
 	if err := row.Scan(&chapters); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -90,12 +91,12 @@ func (db *SQLiteDatabase) CountChapters(courseId string) (int, error) {
 	return chapters, nil
 }
 
-func (db *SQLiteDatabase) GetCourseChapters(courseId string) ([]*models.ChapterModel, error) {
-	query := `SELECT id, title, slug, chapter, content, course_id, file_checksum, file_key, created_at, updated_at FROM course_chapters WHERE course_id = ? ORDER BY chapter ASC;`
+func (db *SQLiteDatabase) GetCourseChapters(ctx context.Context, courseId string) ([]*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE course_id = ? ORDER BY chapter ASC;`
 
 	var chapters []*models.ChapterModel
 
-	rows, err := db.connection.Query(query, courseId)
+	rows, err := db.connection.QueryContext(ctx, query, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all chapters for course (\"%s\") from the database: %s\n", courseId, err)
 		return nil, err
@@ -104,7 +105,7 @@ func (db *SQLiteDatabase) GetCourseChapters(courseId string) ([]*models.ChapterM
 	for rows.Next() {
 		var chapter models.ChapterModel
 
-		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from chapters table: %s\n", err)
 			return nil, err
 		}
@@ -1,11 +1,13 @@
 package sqlite_database
 
-func (db *SQLiteDatabase) GetAllKeywordsForCourse(courseId string) ([]string, error) {
+import "context"
+
+func (db *SQLiteDatabase) GetAllKeywordsForCourse(ctx context.Context, courseId string) ([]string, error) {
 	query := `SELECT k.keyword FROM courses_keywords AS ck JOIN keywords AS k ON ck.keyword_id = k.id WHERE ck.course_id = ?;`
 
 	var keywords []string
 
-	rows, err := db.connection.Query(query, courseId)
+	rows, err := db.connection.QueryContext(ctx, query, courseId)
 	if err != nil {
 		return nil, err
 	}
@@ -45,3 +45,15 @@ func TestUnpublishCourse(t *testing.T) {
 func TestUpdateCourseAuthor(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestDeleteCourse(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestRestoreCourse(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestPurgeCourse(t *testing.T) {
+	// TODO: Implement.
+}
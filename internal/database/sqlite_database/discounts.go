@@ -1,13 +1,15 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) GetDiscountsPaginated(term string, active *bool, page, elements uint) ([]*models.DiscountModel, error) {
+func (db *SQLiteDatabase) GetDiscountsPaginated(ctx context.Context, term string, active *bool, cursor *database.Cursor, elements uint) ([]*models.DiscountModel, *database.Cursor, error) {
 	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts WHERE (LOWER(id) LIKE '%' || ? ||'%' OR LOWER(title) LIKE '%' || ? || '%' OR LOWER(description) LIKE '%' || ? || '%' OR LOWER(code) LIKE '%' || ? || '%')`
 
 	args := []any{term, term, term, term}
@@ -22,16 +24,19 @@ func (db *SQLiteDatabase) GetDiscountsPaginated(term string, active *bool, page,
 		}
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY created_at DESC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("discounts", "discounts", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY discounts.created_at DESC, discounts.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var discounts []*models.DiscountModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -40,7 +45,7 @@ func (db *SQLiteDatabase) GetDiscountsPaginated(term string, active *bool, page,
 
 		if err := rows.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from discounts table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		if active == 1 {
@@ -52,18 +57,22 @@ func (db *SQLiteDatabase) GetDiscountsPaginated(term string, active *bool, page,
 
 	if err := rows.Err(); err != nil {
 		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return discounts, nil
+	discounts, next := database.Paginate(discounts, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(discounts[i].CreatedAt, discounts[i].ID)
+	})
+
+	return discounts, next, nil
 }
 
-func (db *SQLiteDatabase) GetAllDiscounts() ([]*models.DiscountModel, error) {
+func (db *SQLiteDatabase) GetAllDiscounts(ctx context.Context) ([]*models.DiscountModel, error) {
 	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts;`
 
 	var discounts []*models.DiscountModel
 
-	rows, err := db.connection.Query(query)
+	rows, err := db.connection.QueryContext(ctx, query)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all discounts from the database: %s\n", err)
 		return nil, err
@@ -93,12 +102,12 @@ func (db *SQLiteDatabase) GetAllDiscounts() ([]*models.DiscountModel, error) {
 	return discounts, nil
 }
 
-func (db *SQLiteDatabase) CountDiscounts() (uint, error) {
+func (db *SQLiteDatabase) CountDiscounts(ctx context.Context) (uint, error) {
 	query := `SELECT COUNT(id) FROM discounts;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -111,9 +120,7 @@ func (db *SQLiteDatabase) CountDiscounts() (uint, error) {
 	return count, nil
 }
 
-func (db *SQLiteDatabase) AddDiscount(title, description string, discount, uses uint64) (string, error) {
-	query := `INSERT INTO discounts (id, title, description, code, discount, uses) VALUES (?, ?, ?, ?, ?, ?);`
-
+func (db *SQLiteDatabase) AddDiscount(ctx context.Context, title, description string, discount, uses uint64) (string, error) {
 	id, err := database.GenerateID()
 	if err != nil {
 		db.ErrorLog.Printf("Failed to generate ID for new discount: %s\n", err)
@@ -126,56 +133,32 @@ func (db *SQLiteDatabase) AddDiscount(title, description string, discount, uses
 		return "", err
 	}
 
-	result, err := db.connection.Exec(query, id, title, description, code, discount, uses)
-	if err != nil {
+	if err := internal.AddDiscount(ctx, db.connection, id, title, description, code, discount, uses); err != nil {
 		db.ErrorLog.Printf("Failed to add new discount \"%s\" to the database: %s\n", title, err)
 		return "", err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		db.ErrorLog.Printf("Failed to query the database for the number of rows affected after adding new discount: %s\n", err)
-		return "", err
-	}
-
-	if rowsAffected == 0 {
-		db.ErrorLog.Println("No rows were affected after adding new discount to the database")
-		return "", database.ErrNoRowsAffected
-	}
-
 	return id, nil
 }
 
-func (db *SQLiteDatabase) GetDiscountByID(discountId string) (*models.DiscountModel, error) {
-	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts WHERE id = ?;`
-
-	discount := new(models.DiscountModel)
-
-	var active int
-
-	row := db.connection.QueryRow(query, discountId)
-	if err := row.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-
+func (db *SQLiteDatabase) GetDiscountByID(ctx context.Context, discountId string) (*models.DiscountModel, error) {
+	discount, err := internal.GetDiscountByID(ctx, db.connection, discountId)
+	if err != nil {
 		db.ErrorLog.Printf("Failed to get discount \"%s\" from the database: %s\n", discountId, err)
 		return nil, err
 	}
 
-	discount.Active = active == 1
-
 	return discount, nil
 }
 
-func (db *SQLiteDatabase) GetDiscountByCode(discountCode string) (*models.DiscountModel, error) {
+func (db *SQLiteDatabase) GetDiscountByCode(ctx context.Context, discountCode string) (*models.DiscountModel, error) {
 	query := `SELECT id, title, description, code, discount, uses, active, created_at, updated_at FROM discounts WHERE code = ?;`
 
 	discount := new(models.DiscountModel)
 
 	var active int
 
-	row := db.connection.QueryRow(query, discountCode)
+	row := db.connection.QueryRowContext(ctx, query, discountCode)
 	if err := row.Scan(&discount.ID, &discount.Title, &discount.Description, &discount.Code, &discount.Discount, &discount.Uses, &active, &discount.CreatedAt, &discount.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -190,10 +173,8 @@ func (db *SQLiteDatabase) GetDiscountByCode(discountCode string) (*models.Discou
 	return discount, nil
 }
 
-func (db *SQLiteDatabase) ActivateDiscount(discountId string) error {
-	query := `UPDATE discounts SET active = 1, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`
-
-	if _, err := db.connection.Exec(query, discountId); err != nil {
+func (db *SQLiteDatabase) ActivateDiscount(ctx context.Context, discountId string) error {
+	if err := internal.ActivateDiscount(ctx, db.connection, discountId); err != nil {
 		db.ErrorLog.Printf("Failed to update discount \"%s\" active status: %s\n", discountId, err)
 		return err
 	}
@@ -201,10 +182,10 @@ func (db *SQLiteDatabase) ActivateDiscount(discountId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) DeactivateDiscount(discountId string) error {
+func (db *SQLiteDatabase) DeactivateDiscount(ctx context.Context, discountId string) error {
 	query := `UPDATE discounts SET active = 0, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`
 
-	if _, err := db.connection.Exec(query, discountId); err != nil {
+	if _, err := db.connection.ExecContext(ctx, query, discountId); err != nil {
 		db.ErrorLog.Printf("Failed to update discount \"%s\" active status: %s\n", discountId, err)
 		return err
 	}
@@ -1,11 +1,9 @@
 package sqlite_database
 
 import (
-	"fmt"
-
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 	_ "modernc.org/sqlite"
 )
 
@@ -16,8 +14,13 @@ func (db *SQLiteDatabase) SetupMigrations() (*migrate.Migrate, error) {
 		return nil, err
 	}
 
-	// Specify migrations directory.
-	m, err := migrate.NewWithDatabaseInstance(fmt.Sprintf("file:/%s", db.migrationsDir), "sqlite", driver)
+	// Read the migrations that were embedded into the binary.
+	src, err := iofs.New(db.migrations, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", src, "sqlite", driver)
 	if err != nil {
 		return nil, err
 	}
@@ -1,13 +1,17 @@
 package internal
 
-import "github.com/PsionicAlch/course-platform/internal/database"
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
 
 // RegisterAffiliatePointsChange adds a new row to the affiliate_points_history table in a way that works with a normal
 // database connection or a database transaction.
-func RegisterAffiliatePointsChange(dbFacade SqlDbFacade, id, userId, courseId string, pointsChange int, reason string) error {
+func RegisterAffiliatePointsChange(ctx context.Context, dbFacade SqlDbFacade, id, userId, courseId string, pointsChange int, reason string) error {
 	query := `INSERT INTO affiliate_points_history (id, user_id, course_id, points_change, reason) VALUES (?, ?, ?, ?, ?);`
 
-	result, err := dbFacade.Exec(query, id, userId, courseId, pointsChange, reason)
+	result, err := dbFacade.ExecContext(ctx, query, id, userId, courseId, pointsChange, reason)
 	if err != nil {
 		return err
 	}
@@ -9,3 +9,11 @@ func TestAddChapter(t *testing.T) {
 func TestUpdateChapter(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestDeleteChapter(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestParkChapter(t *testing.T) {
+	// TODO: Implement.
+}
@@ -9,3 +9,27 @@ func TestHasUserPurchasedCourse(t *testing.T) {
 func TestAddNewCoursePurchase(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestRegisterCoursePurchase(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetCoursePurchaseByPaymentKey(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetCoursePurchaseByCheckoutSession(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetCoursePurchasesByUserAndCourse(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestUpdateCoursePurchasePaymentStatus(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestTransitionCoursePurchasePaymentStatus(t *testing.T) {
+	// TODO: Implement.
+}
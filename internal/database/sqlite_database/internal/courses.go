@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -9,10 +10,10 @@ import (
 
 // AddCourse adds a new course row to the database. This function works with either a database connection or a database
 // transaction.
-func AddCourse(dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string) error {
-	query := `INSERT INTO courses (id, title, slug, description, thumbnail_url, banner_url, content, file_checksum, file_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+func AddCourse(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, publishAt, unpublishAt sql.NullTime) error {
+	query := `INSERT INTO courses (id, title, slug, description, thumbnail_url, banner_url, content, file_checksum, file_key, publish_at, unpublish_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
-	results, err := dbFacade.Exec(query, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey)
+	results, err := dbFacade.ExecContext(ctx, query, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, scheduleTimestamp(publishAt), scheduleTimestamp(unpublishAt))
 	if err != nil {
 		return err
 	}
@@ -31,9 +32,9 @@ func AddCourse(dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl,
 
 // UpdateCourse updates the course row based on the provided ID. This function works with either a database connection
 // or a database transaction.
-func UpdateCourse(dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string) error {
-	query := `UPDATE courses SET title = ?, slug = ?, description = ?, thumbnail_url = ?, banner_url = ?, content = ?, published = 0, file_checksum = ?, file_key = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`
-	results, err := dbFacade.Exec(query, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, id)
+func UpdateCourse(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, publishAt, unpublishAt sql.NullTime) error {
+	query := `UPDATE courses SET title = ?, slug = ?, description = ?, thumbnail_url = ?, banner_url = ?, content = ?, published = 0, file_checksum = ?, file_key = ?, publish_at = ?, unpublish_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`
+	results, err := dbFacade.ExecContext(ctx, query, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey, scheduleTimestamp(publishAt), scheduleTimestamp(unpublishAt), id)
 	if err != nil {
 		return err
 	}
@@ -52,14 +53,14 @@ func UpdateCourse(dbFacade SqlDbFacade, id, title, slug, description, thumbnailU
 
 // GetCourseByID retrieves a CourseModel based on the provided course ID. This function works with either a database
 // connection or a database transaction.
-func GetCourseByID(dbFacade SqlDbFacade, courseId string) (*models.CourseModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE id = ?;`
+func GetCourseByID(ctx context.Context, dbFacade SqlDbFacade, courseId string) (*models.CourseModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM courses WHERE id = ?;`
 
 	var course models.CourseModel
 	var published int
 
-	row := dbFacade.QueryRow(query, courseId)
-	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
+	row := dbFacade.QueryRowContext(ctx, query, courseId)
+	if err := row.Scan(&course.ID, &course.Title, &course.Slug, &course.Description, &course.ThumbnailURL, &course.BannerURL, &course.Content, &course.ReadingTime, &published, &course.AuthorID, &course.FileChecksum, &course.FileKey, &course.CreatedAt, &course.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -71,3 +72,13 @@ func GetCourseByID(dbFacade SqlDbFacade, courseId string) (*models.CourseModel,
 
 	return &course, nil
 }
+
+// UpdateCourseReadingTimes sets the reading time of every course to the total reading time of its chapters. This
+// function works with either a database connection or a database transaction.
+func UpdateCourseReadingTimes(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `UPDATE courses SET reading_time = (SELECT COALESCE(SUM(reading_time), 0) FROM course_chapters WHERE course_chapters.course_id = courses.id);`
+
+	_, err := dbFacade.ExecContext(ctx, query)
+
+	return err
+}
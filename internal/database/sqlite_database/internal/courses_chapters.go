@@ -1,17 +1,21 @@
 package internal
 
 import (
+	"context"
+	"database/sql"
+
 	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"modernc.org/sqlite"
 	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // AddChapter adds a new chapter row to the database. This function works with either a database connection or a
 // database transaction. This function will NOT throw an error upon a unique constraint violation.
-func AddChapter(dbFacade SqlDbFacade, id, title, slug string, chapter int, content, fileChecksum, fileKey, courseKey string) error {
-	query := `INSERT INTO course_chapters (id, title, slug, chapter, content, course_id, file_checksum, file_key) VALUES (?, ?, ?, ?, ?, (SELECT id FROM courses WHERE file_key = ?), ?, ?);`
+func AddChapter(ctx context.Context, dbFacade SqlDbFacade, id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime) error {
+	query := `INSERT INTO course_chapters (id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, release_set_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, (SELECT id FROM courses WHERE file_key = ?), ?, ?, ?, ?, CURRENT_TIMESTAMP);`
 
-	result, err := dbFacade.Exec(query, id, title, slug, chapter, content, courseKey, fileChecksum, fileKey)
+	result, err := dbFacade.ExecContext(ctx, query, id, title, slug, chapter, content, toc, wordCount, readingTime, courseKey, fileChecksum, fileKey, releaseAfterDays, scheduleTimestamp(releaseAt))
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return database.ErrChapterAlreadyExists
@@ -32,12 +36,66 @@ func AddChapter(dbFacade SqlDbFacade, id, title, slug string, chapter int, conte
 	return nil
 }
 
-// UpdateChapter updates a chapter in the database based off the provided ID. This function works with either a database
-// connection or a database transaction.
-func UpdateChapter(dbFacade SqlDbFacade, id, title, slug string, chapter int, content, fileChecksum, fileKey, courseKey string) error {
-	query := `UPDATE course_chapters SET title = ?, slug = ?, chapter = ?, content = ?, course_id = (SELECT id FROM courses WHERE file_key = ?), file_checksum = ?, file_key = ? WHERE id = ?;`
+// UpdateChapter updates a chapter in the database based off the provided ID. release_set_at only moves when the
+// chapter's release rule changes. This function works with either a database connection or a database transaction.
+func UpdateChapter(ctx context.Context, dbFacade SqlDbFacade, id, title, slug string, chapter int, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey, courseKey string, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime) error {
+	query := `UPDATE course_chapters SET title = ?, slug = ?, chapter = ?, content = ?, table_of_contents = ?, word_count = ?, reading_time = ?, course_id = (SELECT id FROM courses WHERE file_key = ?), file_checksum = ?, file_key = ?, release_after_days = ?, release_at = ?, release_set_at = CASE WHEN release_after_days IS ? AND release_at IS ? THEN release_set_at ELSE CURRENT_TIMESTAMP END WHERE id = ?;`
+
+	result, err := dbFacade.ExecContext(ctx, query, title, slug, chapter, content, toc, wordCount, readingTime, courseKey, fileChecksum, fileKey, releaseAfterDays, scheduleTimestamp(releaseAt), releaseAfterDays, scheduleTimestamp(releaseAt), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// DeleteChapter removes a chapter along with its quiz, every user's completion of that chapter and the record of who was
+// told about its release. This function works with either a database connection or a database transaction.
+func DeleteChapter(ctx context.Context, dbFacade SqlDbFacade, id string) error {
+	if err := DeleteChapterQuiz(ctx, dbFacade, id); err != nil {
+		return err
+	}
+
+	if _, err := dbFacade.ExecContext(ctx, `DELETE FROM user_course_chapter_completion WHERE chapter_id = ?;`, id); err != nil {
+		return err
+	}
+
+	if _, err := dbFacade.ExecContext(ctx, `DELETE FROM chapter_release_notifications WHERE chapter_id = ?;`, id); err != nil {
+		return err
+	}
+
+	result, err := dbFacade.ExecContext(ctx, `DELETE FROM course_chapters WHERE id = ?;`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
 
-	result, err := dbFacade.Exec(query, title, slug, chapter, content, courseKey, fileChecksum, fileKey, id)
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// ParkChapter temporarily gives a chapter a negative chapter number and uses its ID as its title and slug so that
+// other chapters in the same course can take over its number, title or slug before it gets updated. The chapter
+// numbers of a course are unique so the negative numbers are too. This function works with either a database
+// connection or a database transaction.
+func ParkChapter(ctx context.Context, dbFacade SqlDbFacade, id string) error {
+	result, err := dbFacade.ExecContext(ctx, `UPDATE course_chapters SET chapter = -chapter, title = id, slug = id WHERE id = ?;`, id)
 	if err != nil {
 		return err
 	}
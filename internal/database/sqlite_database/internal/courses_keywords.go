@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"context"
+
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"modernc.org/sqlite"
 	sqlite3 "modernc.org/sqlite/lib"
@@ -8,10 +10,10 @@ import (
 
 // AddKeywordToCourse associates a keyword row to a course row. This function works with either a database connection
 // or a database transaction. This function will NOT throw an error upon a unique constraint violation.
-func AddKeywordToCourse(dbFacade SqlDbFacade, id, keywordId, courseId string) error {
+func AddKeywordToCourse(ctx context.Context, dbFacade SqlDbFacade, id, keywordId, courseId string) error {
 	query := `INSERT INTO courses_keywords (id, course_id, keyword_id) VALUES (?, ?, ?);`
 
-	result, err := dbFacade.Exec(query, id, courseId, keywordId)
+	result, err := dbFacade.ExecContext(ctx, query, id, courseId, keywordId)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return nil
@@ -34,10 +36,10 @@ func AddKeywordToCourse(dbFacade SqlDbFacade, id, keywordId, courseId string) er
 
 // DeleteAllKeywordsFromCourses removes all database associations between courses and keywords. This function works
 // with either a database connection or a database transaction.
-func DeleteAllKeywordsFromCourses(dbFacade SqlDbFacade, courseId string) error {
+func DeleteAllKeywordsFromCourses(ctx context.Context, dbFacade SqlDbFacade, courseId string) error {
 	query := `DELETE FROM courses_keywords WHERE course_id = ?;`
 
-	_, err := dbFacade.Exec(query, courseId)
+	_, err := dbFacade.ExecContext(ctx, query, courseId)
 	if err != nil {
 		return err
 	}
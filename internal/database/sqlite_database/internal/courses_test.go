@@ -13,3 +13,7 @@ func TestUpdateCourse(t *testing.T) {
 func TestGetCourseByID(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestUpdateCourseReadingTimes(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,15 @@
+package internal
+
+import "testing"
+
+func TestAddDiscount(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetDiscountByID(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestActivateDiscount(t *testing.T) {
+	// TODO: Implement.
+}
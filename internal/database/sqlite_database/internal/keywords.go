@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -11,10 +12,10 @@ import (
 
 // AddKeywords adds a new keyword row to the database. This function works with either a database connection or a
 // database transaction. This function will NOT throw an error upon a unique constraint violation.
-func AddKeyword(dbFacade SqlDbFacade, id, keyword string) error {
+func AddKeyword(ctx context.Context, dbFacade SqlDbFacade, id, keyword string) error {
 	query := `INSERT INTO keywords (id, keyword) VALUES (?, ?);`
 
-	result, err := dbFacade.Exec(query, id, keyword)
+	result, err := dbFacade.ExecContext(ctx, query, id, keyword)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return database.ErrKeywordAlreadyExists
@@ -37,12 +38,12 @@ func AddKeyword(dbFacade SqlDbFacade, id, keyword string) error {
 
 // GetKeywordByKeyword will retrieve a KeywordModel from the database using a given keyword. This function works with
 // either a database connection or a database transaction.
-func GetKeywordByKeyword(dbFacade SqlDbFacade, keyword string) (*models.KeywordModel, error) {
+func GetKeywordByKeyword(ctx context.Context, dbFacade SqlDbFacade, keyword string) (*models.KeywordModel, error) {
 	query := `SELECT id, keyword FROM keywords WHERE keyword = ?;`
 
 	keywordModel := new(models.KeywordModel)
 
-	row := dbFacade.QueryRow(query, keyword)
+	row := dbFacade.QueryRowContext(ctx, query, keyword)
 	if err := row.Scan(&keywordModel.ID, &keywordModel.Keyword); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
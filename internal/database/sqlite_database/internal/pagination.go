@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+// cursorTimeFormat is the format SQLite's CURRENT_TIMESTAMP stores timestamps in.
+const cursorTimeFormat = "2006-01-02 15:04:05"
+
+// AfterCursor returns the condition that limits a listing, ordered by created_at DESC and id DESC, to the rows that
+// come after the cursor. The cursor's position is looked up from the row it points at so that timestamps get compared
+// in the format they were stored in. The cursor's own timestamp is only used if that row has since been deleted. The
+// alias is the name the table goes by in the query. An empty condition gets returned when there is no cursor.
+func AfterCursor(table, alias string, cursor *database.Cursor) (string, []any) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	condition := fmt.Sprintf(" AND (%[2]s.created_at, %[2]s.id) < (COALESCE((SELECT created_at FROM %[1]s WHERE id = ?), ?), ?)", table, alias)
+
+	return condition, []any{cursor.ID, cursor.CreatedAt.UTC().Format(cursorTimeFormat), cursor.ID}
+}
+
+// AfterRankedCursor returns the condition that limits full-text search results, ordered by the given rank expression
+// and then by id, to the results that come after the cursor. An empty condition gets returned when there is no cursor.
+func AfterRankedCursor(rank, alias string, cursor *database.Cursor) (string, []any) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf(" AND (%s, %s.id) > (?, ?)", rank, alias), []any{cursor.Rank, cursor.ID}
+}
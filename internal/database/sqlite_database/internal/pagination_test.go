@@ -0,0 +1,11 @@
+package internal
+
+import "testing"
+
+func TestAfterCursor(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestAfterRankedCursor(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,13 @@
+package internal
+
+import "database/sql"
+
+// scheduleTimestamp converts a publish schedule time to the format SQLite's CURRENT_TIMESTAMP stores timestamps in so
+// that the scheduler can compare the two. A missing time is stored as NULL.
+func scheduleTimestamp(t sql.NullTime) any {
+	if !t.Valid {
+		return nil
+	}
+
+	return t.Time.UTC().Format(cursorTimeFormat)
+}
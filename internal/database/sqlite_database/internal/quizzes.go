@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// AddQuiz adds a new quiz row to the database and returns its ID. If the chapter already has a quiz its pass mark gets
+// updated instead and its ID is returned. This function works with either a database connection or a database
+// transaction.
+func AddQuiz(ctx context.Context, dbFacade SqlDbFacade, id, chapterId string, passMark int) (string, error) {
+	query := `INSERT INTO quizzes (id, chapter_id, pass_mark) VALUES (?, ?, ?) ON CONFLICT (chapter_id) DO UPDATE SET pass_mark = excluded.pass_mark, updated_at = CURRENT_TIMESTAMP RETURNING id;`
+
+	var quizId string
+
+	if err := dbFacade.QueryRowContext(ctx, query, id, chapterId, passMark).Scan(&quizId); err != nil {
+		return "", err
+	}
+
+	return quizId, nil
+}
+
+// AddQuizQuestion adds a new question to a quiz. If the quiz already has a question at the same position that question
+// gets replaced but keeps its ID so that its statistics are kept. This function works with either a database
+// connection or a database transaction.
+func AddQuizQuestion(ctx context.Context, dbFacade SqlDbFacade, id, quizId string, position int, kind, question string, options, answers models.QuizAnswers) error {
+	query := `INSERT INTO quiz_questions (id, quiz_id, position, kind, question, options, answers) VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT (quiz_id, position) DO UPDATE SET kind = excluded.kind, question = excluded.question, options = excluded.options, answers = excluded.answers;`
+
+	_, err := dbFacade.ExecContext(ctx, query, id, quizId, position, kind, question, options, answers)
+
+	return err
+}
+
+// DeleteQuizQuestionsAfter removes the questions of a quiz that come after the given position along with their
+// answers. This function works with either a database connection or a database transaction.
+func DeleteQuizQuestionsAfter(ctx context.Context, dbFacade SqlDbFacade, quizId string, position int) error {
+	queries := []string{
+		`DELETE FROM quiz_attempt_answers WHERE question_id IN (SELECT id FROM quiz_questions WHERE quiz_id = ? AND position > ?);`,
+		`DELETE FROM quiz_questions WHERE quiz_id = ? AND position > ?;`,
+	}
+
+	for _, query := range queries {
+		if _, err := dbFacade.ExecContext(ctx, query, quizId, position); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteChapterQuiz removes the quiz of a chapter along with its questions and every attempt at it. This function works
+// with either a database connection or a database transaction.
+func DeleteChapterQuiz(ctx context.Context, dbFacade SqlDbFacade, chapterId string) error {
+	queries := []string{
+		`DELETE FROM quiz_attempt_answers WHERE attempt_id IN (SELECT id FROM quiz_attempts WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id = ?));`,
+		`DELETE FROM quiz_attempts WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id = ?);`,
+		`DELETE FROM quiz_questions WHERE quiz_id IN (SELECT id FROM quizzes WHERE chapter_id = ?);`,
+		`DELETE FROM quizzes WHERE chapter_id = ?;`,
+	}
+
+	for _, query := range queries {
+		if _, err := dbFacade.ExecContext(ctx, query, chapterId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddQuizAttempt adds a new quiz_attempts row to the database. This function works with either a database connection
+// or a database transaction.
+func AddQuizAttempt(ctx context.Context, dbFacade SqlDbFacade, id, quizId, userId string, score int, passed bool) error {
+	query := `INSERT INTO quiz_attempts (id, quiz_id, user_id, score, passed) VALUES (?, ?, ?, ?, ?);`
+
+	passedInt := 0
+	if passed {
+		passedInt = 1
+	}
+
+	_, err := dbFacade.ExecContext(ctx, query, id, quizId, userId, score, passedInt)
+
+	return err
+}
+
+// AddQuizAttemptAnswer adds the answer that was given to a question during a quiz attempt. This function works with
+// either a database connection or a database transaction.
+func AddQuizAttemptAnswer(ctx context.Context, dbFacade SqlDbFacade, id, attemptId, questionId string, answer models.QuizAnswers, correct bool) error {
+	query := `INSERT INTO quiz_attempt_answers (id, attempt_id, question_id, answer, correct) VALUES (?, ?, ?, ?, ?);`
+
+	correctInt := 0
+	if correct {
+		correctInt = 1
+	}
+
+	_, err := dbFacade.ExecContext(ctx, query, id, attemptId, questionId, answer, correctInt)
+
+	return err
+}
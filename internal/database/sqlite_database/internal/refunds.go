@@ -0,0 +1,68 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// RegisterRefund adds a new refund row to the database. This function works with either a database connection or a
+// database transaction. This function will NOT throw an error upon a unique constraint violation.
+func RegisterRefund(ctx context.Context, dbFacade SqlDbFacade, id, userId, coursePurchaseId string, status database.RefundStatus) error {
+	query := `INSERT INTO refunds (id, user_id, course_purchase_id, refund_status) VALUES (?, ?, ?, ?);`
+
+	result, err := dbFacade.ExecContext(ctx, query, id, userId, coursePurchaseId, status.String())
+	if err != nil {
+		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return nil
+		}
+
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// GetRefundWithCoursePurchaseID finds the refund row associated with the given course purchase. This function works
+// with either a database connection or a database transaction.
+func GetRefundWithCoursePurchaseID(ctx context.Context, dbFacade SqlDbFacade, coursePurchaseId string) (*models.RefundModel, error) {
+	query := `SELECT id, user_id, course_purchase_id, refund_status, created_at, updated_at FROM refunds WHERE course_purchase_id = ?;`
+
+	var refund models.RefundModel
+
+	row := dbFacade.QueryRowContext(ctx, query, coursePurchaseId)
+	if err := row.Scan(&refund.ID, &refund.UserID, &refund.CoursePurchaseID, &refund.RefundStatus, &refund.CreatedAt, &refund.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &refund, nil
+}
+
+// UpdateRefundStatus updates the status of the given refund row. This function works with either a database connection
+// or a database transaction.
+func UpdateRefundStatus(ctx context.Context, dbFacade SqlDbFacade, refundId string, status database.RefundStatus) error {
+	query := `UPDATE refunds SET refund_status = ? WHERE id = ?;`
+
+	if _, err := dbFacade.ExecContext(ctx, query, status.String(), refundId); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package internal
+
+import "testing"
+
+func TestRegisterRefund(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetRefundWithCoursePurchaseID(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestUpdateRefundStatus(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/searchmark"
+)
+
+// TutorialsRank is the bm25 ranking function for the tutorials_fts table. Matches in the title count the most and
+// matches in the content count the least. Lower values are more relevant.
+const TutorialsRank = "bm25(tutorials_fts, 0.0, 10.0, 5.0, 5.0, 1.0)"
+
+// CoursesRank is the bm25 ranking function for the courses_fts table. It uses the same weights as TutorialsRank.
+const CoursesRank = "bm25(courses_fts, 0.0, 10.0, 5.0, 5.0, 1.0)"
+
+// FullTextQuery turns a user's search term into an FTS5 query. Every word gets quoted so that FTS5 syntax in the
+// search term can't cause errors and gets turned into a prefix search so that results show up while the user is still
+// typing. An empty string gets returned if the search term doesn't contain any words.
+func FullTextQuery(term string) string {
+	words := strings.FieldsFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for i, word := range words {
+		words[i] = fmt.Sprintf("\"%s\"*", word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// RebuildTutorialsIndex replaces every row in the tutorials_fts table with the current tutorials and their keywords.
+// This function works with either a database connection or a database transaction.
+func RebuildTutorialsIndex(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `SELECT t.id, t.title, t.description, COALESCE((SELECT GROUP_CONCAT(k.keyword, ' ') FROM tutorials_keywords AS tk JOIN keywords AS k ON tk.keyword_id = k.id WHERE tk.tutorial_id = t.id), ''), t.content FROM tutorials AS t;`
+
+	return rebuildIndex(ctx, dbFacade, "tutorials_fts", query)
+}
+
+// RebuildCoursesIndex replaces every row in the courses_fts table with the current courses and their keywords. This
+// function works with either a database connection or a database transaction.
+func RebuildCoursesIndex(ctx context.Context, dbFacade SqlDbFacade) error {
+	query := `SELECT c.id, c.title, c.description, COALESCE((SELECT GROUP_CONCAT(k.keyword, ' ') FROM courses_keywords AS ck JOIN keywords AS k ON ck.keyword_id = k.id WHERE ck.course_id = c.id), ''), c.content FROM courses AS c;`
+
+	return rebuildIndex(ctx, dbFacade, "courses_fts", query)
+}
+
+// GetSearchSnippets returns a highlighted snippet of the content column for each of the given IDs that matches the
+// FTS5 query. Matched terms are wrapped in searchmark.Start and searchmark.End. IDs whose content didn't
+// match are left out of the map.
+func GetSearchSnippets(ctx context.Context, dbFacade SqlDbFacade, table, match string, ids []string) (map[string]string, error) {
+	snippets := make(map[string]string, len(ids))
+
+	if match == "" || len(ids) == 0 {
+		return snippets, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	query := fmt.Sprintf(`SELECT id, snippet(%s, 4, ?, ?, '…', 24) FROM %s WHERE %s MATCH ? AND id IN (%s);`, table, table, table, placeholders)
+
+	args := []any{searchmark.Start, searchmark.End, match}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	rows, err := dbFacade.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, snippet string
+
+		if err := rows.Scan(&id, &snippet); err != nil {
+			return nil, err
+		}
+
+		if strings.Contains(snippet, searchmark.Start) {
+			snippets[id] = snippet
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return snippets, nil
+}
+
+type indexRow struct {
+	id          string
+	title       string
+	description string
+	keywords    string
+	content     string
+}
+
+func rebuildIndex(ctx context.Context, dbFacade SqlDbFacade, table, query string) error {
+	var indexRows []indexRow
+
+	rows, err := dbFacade.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row indexRow
+
+		if err := rows.Scan(&row.id, &row.title, &row.description, &row.keywords, &row.content); err != nil {
+			return err
+		}
+
+		indexRows = append(indexRows, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if _, err := dbFacade.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s;`, table)); err != nil {
+		return err
+	}
+
+	insert := fmt.Sprintf(`INSERT INTO %s (id, title, description, keywords, content) VALUES (?, ?, ?, ?, ?);`, table)
+
+	for _, row := range indexRows {
+		if _, err := dbFacade.ExecContext(ctx, insert, row.id, row.title, row.description, row.keywords, database.HTMLToText(row.content)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package internal
+
+import "testing"
+
+func TestFullTextQuery(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestRebuildTutorialsIndex(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestRebuildCoursesIndex(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetSearchSnippets(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,31 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+// AddSlugHistory records the current slug of a tutorial, course or chapter when it's about to be replaced by newSlug.
+// Nothing gets recorded when the slug stays the same. If the old slug already belongs to another piece of content of
+// the same kind it gets handed over, since the most recent owner is the one that old links most likely point to. This
+// function works with either a database connection or a database transaction.
+func AddSlugHistory(ctx context.Context, dbFacade SqlDbFacade, id string, kind database.SlugKind, contentId, newSlug string) error {
+	var query string
+
+	switch kind {
+	case database.TutorialSlug:
+		query = `INSERT INTO slug_history (id, kind, content_id, slug) SELECT ?, ?, id, slug FROM tutorials WHERE id = ? AND slug != ? ON CONFLICT (kind, slug) DO UPDATE SET content_id = excluded.content_id, created_at = CURRENT_TIMESTAMP;`
+	case database.CourseSlug:
+		query = `INSERT INTO slug_history (id, kind, content_id, slug) SELECT ?, ?, id, slug FROM courses WHERE id = ? AND slug != ? ON CONFLICT (kind, slug) DO UPDATE SET content_id = excluded.content_id, created_at = CURRENT_TIMESTAMP;`
+	case database.ChapterSlug:
+		query = `INSERT INTO slug_history (id, kind, content_id, slug) SELECT ?, ?, id, slug FROM course_chapters WHERE id = ? AND slug != ? ON CONFLICT (kind, slug) DO UPDATE SET content_id = excluded.content_id, created_at = CURRENT_TIMESTAMP;`
+	default:
+		return fmt.Errorf("unknown slug kind %d", kind)
+	}
+
+	_, err := dbFacade.ExecContext(ctx, query, id, kind.String(), contentId, newSlug)
+
+	return err
+}
@@ -0,0 +1,7 @@
+package internal
+
+import "testing"
+
+func TestAddSlugHistory(t *testing.T) {
+	// TODO: Implement.
+}
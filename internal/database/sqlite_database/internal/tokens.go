@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -10,10 +11,10 @@ import (
 
 // AddToken adds a new token to the database. This function works with either a database connection or a database
 // transaction. This function WILL throw a ErrTokenAlreadyExists error upon a unique constraint violation.
-func AddToken(dbFacade SqlDbFacade, id, token, tokenType, userId string, validUntil time.Time) error {
+func AddToken(ctx context.Context, dbFacade SqlDbFacade, id, token, tokenType, userId string, validUntil time.Time) error {
 	query := `INSERT INTO tokens (id, token, token_type, valid_until, user_id) VALUES (?, ?, ?, ?, ?);`
 
-	result, err := dbFacade.Exec(query, id, token, tokenType, validUntil, userId)
+	result, err := dbFacade.ExecContext(ctx, query, id, token, tokenType, validUntil, userId)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return database.ErrTokenAlreadyExists
@@ -1,19 +1,21 @@
 package internal
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"modernc.org/sqlite"
 	sqlite3 "modernc.org/sqlite/lib"
 )
 
 // AddTutorial adds a new tutorial row to the database. This function works with either a database connection or a
 // database transaction. This function WILL throw a ErrTutorialAlreadyExist error upon a unique constraint violation.
-func AddTutorial(dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string) error {
-	query := `INSERT INTO tutorials (id, title, slug, description, thumbnail_url, banner_url, content, file_checksum, file_key) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
+func AddTutorial(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey string, publishAt, unpublishAt sql.NullTime) error {
+	query := `INSERT INTO tutorials (id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, file_checksum, file_key, publish_at, unpublish_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
-	result, err := dbFacade.Exec(query, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey)
+	result, err := dbFacade.ExecContext(ctx, query, id, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, fileChecksum, fileKey, scheduleTimestamp(publishAt), scheduleTimestamp(unpublishAt))
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return database.ErrTutorialAlreadyExists
@@ -36,10 +38,10 @@ func AddTutorial(dbFacade SqlDbFacade, id, title, slug, description, thumbnailUr
 
 // UpdateTutorial updates a tutorial database row based on the provided ID. This function works with either a database
 // connection or a database transaction.
-func UpdateTutorial(dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content, fileChecksum, fileKey string, authorId sql.NullString) error {
-	query := `UPDATE tutorials SET title = ?, slug = ?, description = ?, thumbnail_url = ?, banner_url = ?, content = ?, published = 0, author_id = ?, file_checksum = ?, file_key = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`
+func UpdateTutorial(ctx context.Context, dbFacade SqlDbFacade, id, title, slug, description, thumbnailUrl, bannerUrl, content string, toc models.TableOfContents, wordCount, readingTime int, fileChecksum, fileKey string, authorId sql.NullString, publishAt, unpublishAt sql.NullTime) error {
+	query := `UPDATE tutorials SET title = ?, slug = ?, description = ?, thumbnail_url = ?, banner_url = ?, content = ?, table_of_contents = ?, word_count = ?, reading_time = ?, published = 0, author_id = ?, file_checksum = ?, file_key = ?, publish_at = ?, unpublish_at = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`
 
-	result, err := dbFacade.Exec(query, title, slug, description, thumbnailUrl, bannerUrl, content, authorId, fileChecksum, fileKey, id)
+	result, err := dbFacade.ExecContext(ctx, query, title, slug, description, thumbnailUrl, bannerUrl, content, toc, wordCount, readingTime, authorId, fileChecksum, fileKey, scheduleTimestamp(publishAt), scheduleTimestamp(unpublishAt), id)
 	if err != nil {
 		return err
 	}
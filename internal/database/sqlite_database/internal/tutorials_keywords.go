@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"context"
+
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"modernc.org/sqlite"
 	sqlite3 "modernc.org/sqlite/lib"
@@ -9,10 +11,10 @@ import (
 // AddKeywordToTutorial adds a new keyword-tutorial association in the database. This function works with either a
 // database connection or a database transaction. This function wil NOT throw an error upon a unique constraint
 // violation.
-func AddKeywordToTutorial(dbFacade SqlDbFacade, id, keywordId, tutorialId string) error {
+func AddKeywordToTutorial(ctx context.Context, dbFacade SqlDbFacade, id, keywordId, tutorialId string) error {
 	query := `INSERT INTO tutorials_keywords (id, tutorial_id, keyword_id) VALUES (?, ?, ?);`
 
-	result, err := dbFacade.Exec(query, id, tutorialId, keywordId)
+	result, err := dbFacade.ExecContext(ctx, query, id, tutorialId, keywordId)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return nil
@@ -35,10 +37,10 @@ func AddKeywordToTutorial(dbFacade SqlDbFacade, id, keywordId, tutorialId string
 
 // DeleteAllKeywordsFromTutorial removes all keyword associations from the tutorial with the provided ID. This function
 // works with either a database connection or a database transaction.
-func DeleteAllKeywordsFromTutorial(dbFacade SqlDbFacade, tutorialId string) error {
+func DeleteAllKeywordsFromTutorial(ctx context.Context, dbFacade SqlDbFacade, tutorialId string) error {
 	query := `DELETE FROM tutorials_keywords WHERE tutorial_id = ?;`
 
-	_, err := dbFacade.Exec(query, tutorialId)
+	_, err := dbFacade.ExecContext(ctx, query, tutorialId)
 	if err != nil {
 		return err
 	}
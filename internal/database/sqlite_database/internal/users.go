@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -12,7 +13,7 @@ import (
 
 // AddUser adds a new user row to the database. This function will work with either a database connection or a database
 // transaction.
-func AddUser(dbFacade SqlDbFacade, id, name, surname, slug, email, password, affiliateCode string) (*models.UserModel, error) {
+func AddUser(ctx context.Context, dbFacade SqlDbFacade, id, name, surname, slug, email, password, affiliateCode string) (*models.UserModel, error) {
 	query := `INSERT INTO users (id, name, surname, slug, email, password, affiliate_code, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	user := new(models.UserModel)
@@ -29,7 +30,7 @@ func AddUser(dbFacade SqlDbFacade, id, name, surname, slug, email, password, aff
 	user.UpdatedAt = time.Now()
 	user.AffiliatePoints = 0
 
-	result, err := dbFacade.Exec(query, user.ID, user.Name, user.Surname, user.Slug, user.Email, user.Password, user.AffiliateCode, user.CreatedAt, user.UpdatedAt)
+	result, err := dbFacade.ExecContext(ctx, query, user.ID, user.Name, user.Surname, user.Slug, user.Email, user.Password, user.AffiliateCode, user.CreatedAt, user.UpdatedAt)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return nil, database.ErrUserAlreadyExists
@@ -51,24 +52,30 @@ func AddUser(dbFacade SqlDbFacade, id, name, surname, slug, email, password, aff
 }
 
 // GetUserByID retrieves a UserModel from the database depending on the provided ID and authorization level of the user.
-// This function will work with either a database connection or a database transaction.
-func GetUserByID(dbFacade SqlDbFacade, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+// Soft deleted accounts are only found when using Deleted or IncludeDeleted so that purchases and refunds can still be
+// traced back to them. This function will work with either a database connection or a database transaction.
+func GetUserByID(ctx context.Context, dbFacade SqlDbFacade, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
 	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE id = ?`
 
 	switch level {
 	case database.User:
-		query += ` AND is_admin = 0 AND is_author = 0`
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
 	case database.Admin:
-		query += ` AND is_admin = 1`
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
 	case database.Author:
-		query += ` AND is_author = 1`
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
 	}
 
 	var isAdmin int
 	var isAuthor int
 	var user models.UserModel
 
-	row := dbFacade.QueryRow(query, id)
+	row := dbFacade.QueryRowContext(ctx, query, id)
 	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -82,3 +89,41 @@ func GetUserByID(dbFacade SqlDbFacade, id string, level database.AuthorizationLe
 
 	return &user, nil
 }
+
+// GetUserByAffiliateCode finds a user row based on the given affiliate code. This function will work with either a
+// database connection or a database transaction.
+func GetUserByAffiliateCode(ctx context.Context, dbFacade SqlDbFacade, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE affiliate_code = ?`
+
+	switch level {
+	case database.User:
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
+	case database.Admin:
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
+	case database.Author:
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
+	}
+
+	user := new(models.UserModel)
+	isAdmin := 0
+	isAuthor := 0
+
+	row := dbFacade.QueryRowContext(ctx, query, affiliateCode)
+	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	user.IsAdmin = isAdmin == 1
+	user.IsAuthor = isAuthor == 1
+
+	return user, nil
+}
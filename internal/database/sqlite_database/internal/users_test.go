@@ -9,3 +9,7 @@ func TestAddUser(t *testing.T) {
 func TestGetUserByID(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestGetUserByAffiliateCode(t *testing.T) {
+	// TODO: Implement.
+}
@@ -1,11 +1,13 @@
 package sqlite_database
 
-func (db *SQLiteDatabase) GetKeywords() ([]string, error) {
+import "context"
+
+func (db *SQLiteDatabase) GetKeywords(ctx context.Context) ([]string, error) {
 	query := `SELECT keyword FROM keywords;`
 
 	var keywords []string
 
-	rows, err := db.connection.Query(query)
+	rows, err := db.connection.QueryContext(ctx, query)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all keywords from the database: %s\n", err)
 		return nil, err
@@ -30,10 +32,10 @@ func (db *SQLiteDatabase) GetKeywords() ([]string, error) {
 	return keywords, nil
 }
 
-func (db *SQLiteDatabase) DeleteAllKeywords() error {
+func (db *SQLiteDatabase) DeleteAllKeywords(ctx context.Context) error {
 	query := `DELETE FROM keywords;`
 
-	if _, err := db.connection.Exec(query); err != nil {
+	if _, err := db.connection.ExecContext(ctx, query); err != nil {
 		db.ErrorLog.Printf("Failed to delete all keywords: %s\n", err)
 		return err
 	}
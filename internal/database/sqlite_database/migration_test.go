@@ -13,3 +13,19 @@ func TestMigrateDown(t *testing.T) {
 func TestRollback(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestMigrateTo(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestForceMigrationVersion(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestMigrationVersion(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestMigrations(t *testing.T) {
+	// TODO: Implement.
+}
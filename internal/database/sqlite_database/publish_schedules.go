@@ -0,0 +1,124 @@
+package sqlite_database
+
+import (
+	"context"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// GetTutorialPublishSchedules gets every tutorial that is still waiting to be published or unpublished, ordered by
+// whichever of the two happens first.
+func (db *SQLiteDatabase) GetTutorialPublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error) {
+	query := `SELECT id, title, slug, published, publish_at, unpublish_at, updated_at FROM tutorials WHERE deleted_at IS NULL AND (publish_at IS NOT NULL OR unpublish_at IS NOT NULL) ORDER BY MIN(COALESCE(publish_at, unpublish_at), COALESCE(unpublish_at, publish_at)) ASC, id ASC;`
+
+	return db.getPublishSchedules(ctx, query, "tutorial")
+}
+
+// GetCoursePublishSchedules gets every course that is still waiting to be published or unpublished, ordered by
+// whichever of the two happens first.
+func (db *SQLiteDatabase) GetCoursePublishSchedules(ctx context.Context) ([]*models.PublishScheduleModel, error) {
+	query := `SELECT id, title, slug, published, publish_at, unpublish_at, updated_at FROM courses WHERE deleted_at IS NULL AND (publish_at IS NOT NULL OR unpublish_at IS NOT NULL) ORDER BY MIN(COALESCE(publish_at, unpublish_at), COALESCE(unpublish_at, publish_at)) ASC, id ASC;`
+
+	return db.getPublishSchedules(ctx, query, "course")
+}
+
+// RunPublishSchedules publishes and unpublishes every tutorial and course whose scheduled time has passed and returns
+// how many of them changed. A schedule gets cleared once it's due so that it only ever gets acted on once and changes
+// made from the admin panel afterwards are left alone. Content whose unpublish time has also passed doesn't get
+// published at all.
+func (db *SQLiteDatabase) RunPublishSchedules(ctx context.Context) (uint, error) {
+	changes := []string{
+		`UPDATE tutorials SET published = 1, publish_at = NULL WHERE deleted_at IS NULL AND published = 0 AND publish_at <= CURRENT_TIMESTAMP AND (unpublish_at IS NULL OR unpublish_at > CURRENT_TIMESTAMP);`,
+		`UPDATE tutorials SET published = 0, unpublish_at = NULL WHERE deleted_at IS NULL AND published = 1 AND unpublish_at <= CURRENT_TIMESTAMP;`,
+		`UPDATE courses SET published = 1, publish_at = NULL WHERE deleted_at IS NULL AND published = 0 AND publish_at <= CURRENT_TIMESTAMP AND (unpublish_at IS NULL OR unpublish_at > CURRENT_TIMESTAMP);`,
+		`UPDATE courses SET published = 0, unpublish_at = NULL WHERE deleted_at IS NULL AND published = 1 AND unpublish_at <= CURRENT_TIMESTAMP;`,
+	}
+
+	// Schedules that were due without changing anything, like publishing a tutorial that was already published by
+	// hand, still need to be cleared.
+	cleanups := []string{
+		`UPDATE tutorials SET publish_at = NULL WHERE publish_at <= CURRENT_TIMESTAMP;`,
+		`UPDATE tutorials SET unpublish_at = NULL WHERE unpublish_at <= CURRENT_TIMESTAMP;`,
+		`UPDATE courses SET publish_at = NULL WHERE publish_at <= CURRENT_TIMESTAMP;`,
+		`UPDATE courses SET unpublish_at = NULL WHERE unpublish_at <= CURRENT_TIMESTAMP;`,
+	}
+
+	var changed uint
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return 0, err
+	}
+
+	for i, query := range append(changes, cleanups...) {
+		result, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to run publish schedules: %s\n", err)
+			db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+			return 0, err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to get rows affected after running publish schedules: %s\n", err)
+			return 0, err
+		}
+
+		if i < len(changes) {
+			changed += uint(rowsAffected)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after running publish schedules: %s\n", err)
+		return 0, err
+	}
+
+	return changed, nil
+}
+
+func (db *SQLiteDatabase) getPublishSchedules(ctx context.Context, query, kind string) ([]*models.PublishScheduleModel, error) {
+	rows, err := db.connection.QueryContext(ctx, query)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get %s publish schedules: %s\n", kind, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*models.PublishScheduleModel
+
+	for rows.Next() {
+		var schedule models.PublishScheduleModel
+		var published int
+
+		if err := rows.Scan(&schedule.ID, &schedule.Title, &schedule.Slug, &published, &schedule.PublishAt, &schedule.UnpublishAt, &schedule.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to scan %s publish schedule row: %s\n", kind, err)
+			return nil, err
+		}
+
+		schedule.Published = published == 1
+
+		schedules = append(schedules, &schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Failed to read %s publish schedule rows: %s\n", kind, err)
+		return nil, err
+	}
+
+	return schedules, nil
+}
@@ -0,0 +1,182 @@
+package sqlite_database
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
+)
+
+func TestGetQuizByChapterID(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	_, quizId := addTestQuiz(t, sqliteDatabase)
+
+	quiz, err := sqliteDatabase.GetQuizByChapterID(ctx, "chapter")
+	if err != nil || quiz == nil {
+		t.Fatalf("Failed to get quiz by chapter ID: %v", err)
+	}
+
+	if quiz.ID != quizId || quiz.PassMark != 50 || len(quiz.Questions) != 2 {
+		t.Fatalf("Expected the quiz with its 2 questions. Got %+v", quiz)
+	}
+
+	if first := quiz.Questions[0]; first.ID != "first" || first.Kind != models.MultipleChoiceQuestion || !slices.Equal(first.Options, models.QuizAnswers{"a", "b"}) || !slices.Equal(first.Answers, models.QuizAnswers{"a"}) {
+		t.Errorf("Expected the first question to come first. Got %+v", first)
+	}
+
+	if second := quiz.Questions[1]; second.ID != "second" || second.Kind != models.ShortTextQuestion || len(second.Options) != 0 {
+		t.Errorf("Expected the second question to come second. Got %+v", second)
+	}
+
+	if quiz, err := sqliteDatabase.GetQuizByChapterID(ctx, "missing"); err != nil || quiz != nil {
+		t.Errorf("Expected no quiz for a chapter without one. Got %v (%v)", quiz, err)
+	}
+}
+
+func TestGetQuizAttemptAnswers(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	user, quizId := addTestQuiz(t, sqliteDatabase)
+
+	// The answers are given out of order to make sure that they come back in the order of the questions.
+	given := []*models.QuizAttemptAnswerModel{
+		{QuestionID: "second", Answer: models.QuizAnswers{"Go"}, Correct: true},
+		{QuestionID: "first", Answer: models.QuizAnswers{"b"}, Correct: false},
+	}
+
+	if err := sqliteDatabase.AddQuizAttempt(ctx, user.ID, quizId, 50, true, given); err != nil {
+		t.Fatalf("Failed to add quiz attempt: %s", err)
+	}
+
+	attempts, err := sqliteDatabase.GetQuizAttempts(ctx, user.ID, quizId)
+	if err != nil || len(attempts) != 1 {
+		t.Fatalf("Expected 1 quiz attempt. Got %d (%v)", len(attempts), err)
+	}
+
+	answers, err := sqliteDatabase.GetQuizAttemptAnswers(ctx, attempts[0].ID)
+	if err != nil {
+		t.Fatalf("Failed to get quiz attempt answers: %s", err)
+	}
+
+	if len(answers) != 2 {
+		t.Fatalf("Expected 2 answers. Got %d", len(answers))
+	}
+
+	if answers[0].QuestionID != "first" || answers[0].Correct || !slices.Equal(answers[0].Answer, models.QuizAnswers{"b"}) {
+		t.Errorf("Expected the answer to the first question to come first. Got %+v", answers[0])
+	}
+
+	if answers[1].QuestionID != "second" || !answers[1].Correct || !slices.Equal(answers[1].Answer, models.QuizAnswers{"Go"}) {
+		t.Errorf("Expected the answer to the second question to come second. Got %+v", answers[1])
+	}
+
+	if answers, err := sqliteDatabase.GetQuizAttemptAnswers(ctx, "missing"); err != nil || len(answers) != 0 {
+		t.Errorf("Expected no answers for a missing attempt. Got %d (%v)", len(answers), err)
+	}
+}
+
+func TestHasUserPassedQuiz(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	user, quizId := addTestQuiz(t, sqliteDatabase)
+
+	attempts := []bool{false, true, false}
+
+	for i, passed := range attempts {
+		if err := sqliteDatabase.AddQuizAttempt(ctx, user.ID, quizId, 50, passed, nil); err != nil {
+			t.Fatalf("Failed to add quiz attempt: %s", err)
+		}
+
+		// Failing the quiz again after passing it doesn't take the pass away.
+		want := slices.Contains(attempts[:i+1], true)
+
+		if got, err := sqliteDatabase.HasUserPassedQuiz(ctx, user.ID, quizId); err != nil || got != want {
+			t.Errorf("Expected passed to be %t after attempt %d. Got %t (%v)", want, i+1, got, err)
+		}
+	}
+
+	if passed, err := sqliteDatabase.HasUserPassedQuiz(ctx, "someone-else", quizId); err != nil || passed {
+		t.Errorf("Expected a user without attempts to not have passed. Got %t (%v)", passed, err)
+	}
+}
+
+func TestGetQuizQuestionStats(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	user, quizId := addTestQuiz(t, sqliteDatabase)
+
+	// The first question is answered twice and gotten right once. The second question is never answered.
+	for _, correct := range []bool{true, false} {
+		answers := []*models.QuizAttemptAnswerModel{{QuestionID: "first", Answer: models.QuizAnswers{"a"}, Correct: correct}}
+
+		if err := sqliteDatabase.AddQuizAttempt(ctx, user.ID, quizId, 0, false, answers); err != nil {
+			t.Fatalf("Failed to add quiz attempt: %s", err)
+		}
+	}
+
+	stats, err := sqliteDatabase.GetQuizQuestionStats(ctx, quizId)
+	if err != nil {
+		t.Fatalf("Failed to get quiz question stats: %s", err)
+	}
+
+	if len(stats) != 2 {
+		t.Fatalf("Expected stats for 2 questions. Got %d", len(stats))
+	}
+
+	if first := stats[0]; first.QuestionID != "first" || first.Position != 1 || first.Answers != 2 || first.Correct != 1 {
+		t.Errorf("Expected the first question to be answered twice and correctly once. Got %+v", first)
+	}
+
+	if second := stats[1]; second.QuestionID != "second" || second.Answers != 0 || second.Correct != 0 {
+		t.Errorf("Expected the second question to never be answered. Got %+v", second)
+	}
+}
+
+// addTestQuiz adds a user and a course with a chapter that has a quiz with a multiple choice question ("first") and a
+// short text question ("second"). The quiz is on the chapter with the ID "chapter".
+func addTestQuiz(t *testing.T, sqliteDatabase *SQLiteDatabase) (*models.UserModel, string) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	if err := internal.AddCourse(ctx, sqliteDatabase.connection, "course", "Course", "course", "", "", "", "", "", "course", sql.NullTime{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add course: %s", err)
+	}
+
+	if err := internal.AddChapter(ctx, sqliteDatabase.connection, "chapter", "Chapter", "chapter", 1, "", nil, 0, 0, "", "chapter", "course", sql.NullInt32{}, sql.NullTime{}); err != nil {
+		t.Fatalf("Failed to add chapter: %s", err)
+	}
+
+	quizId, err := internal.AddQuiz(ctx, sqliteDatabase.connection, "quiz", "chapter", 50)
+	if err != nil {
+		t.Fatalf("Failed to add quiz: %s", err)
+	}
+
+	if err := internal.AddQuizQuestion(ctx, sqliteDatabase.connection, "first", quizId, 1, models.MultipleChoiceQuestion, "First?", models.QuizAnswers{"a", "b"}, models.QuizAnswers{"a"}); err != nil {
+		t.Fatalf("Failed to add quiz question: %s", err)
+	}
+
+	if err := internal.AddQuizQuestion(ctx, sqliteDatabase.connection, "second", quizId, 2, models.ShortTextQuestion, "Second?", nil, models.QuizAnswers{"go"}); err != nil {
+		t.Fatalf("Failed to add quiz question: %s", err)
+	}
+
+	return user, quizId
+}
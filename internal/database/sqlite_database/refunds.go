@@ -1,15 +1,15 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
-	"modernc.org/sqlite"
-	sqlite3 "modernc.org/sqlite/lib"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) AdminGetRefunds(term string, status string, page, elements uint) ([]*models.RefundModel, error) {
+func (db *SQLiteDatabase) AdminGetRefunds(ctx context.Context, term string, status string, cursor *database.Cursor, elements uint) ([]*models.RefundModel, *database.Cursor, error) {
 	query := `SELECT r.id, r.user_id, r.course_purchase_id, r.refund_status, r.created_at, r.updated_at FROM refunds AS r LEFT JOIN users AS u ON r.user_id = u.id LEFT JOIN course_purchases AS cp ON r.course_purchase_id = cp.id LEFT JOIN courses AS c ON cp.course_id = c.id WHERE 1=1`
 	args := []any{}
 
@@ -23,16 +23,19 @@ func (db *SQLiteDatabase) AdminGetRefunds(term string, status string, page, elem
 		args = append(args, status)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY r.updated_at DESC, r.created_at DESC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("refunds", "r", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY r.created_at DESC, r.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var refunds []*models.RefundModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all refunds from the database: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -40,7 +43,7 @@ func (db *SQLiteDatabase) AdminGetRefunds(term string, status string, page, elem
 
 		if err := rows.Scan(&refund.ID, &refund.UserID, &refund.CoursePurchaseID, &refund.RefundStatus, &refund.CreatedAt, &refund.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from refunds table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		refunds = append(refunds, &refund)
@@ -48,68 +51,43 @@ func (db *SQLiteDatabase) AdminGetRefunds(term string, status string, page, elem
 
 	if err := rows.Err(); err != nil {
 		db.ErrorLog.Printf("Failed to get all refunds from the database: %s\n", err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return refunds, nil
-}
+	refunds, next := database.Paginate(refunds, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(refunds[i].CreatedAt, refunds[i].ID)
+	})
 
-func (db *SQLiteDatabase) RegisterRefund(userId, coursePurchaseId string, status database.RefundStatus) error {
-	query := `INSERT INTO refunds (id, user_id, course_purchase_id, refund_status) VALUES (?, ?, ?, ?);`
+	return refunds, next, nil
+}
 
+func (db *SQLiteDatabase) RegisterRefund(ctx context.Context, userId, coursePurchaseId string, status database.RefundStatus) error {
 	id, err := database.GenerateID()
 	if err != nil {
 		db.ErrorLog.Printf("Failed to generate ID for new refund: %s\n", err)
 		return err
 	}
 
-	result, err := db.connection.Exec(query, id, userId, coursePurchaseId, status.String())
-	if err != nil {
-		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
-			return nil
-		}
-
+	if err := internal.RegisterRefund(ctx, db.connection, id, userId, coursePurchaseId, status); err != nil {
 		db.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		db.ErrorLog.Printf("Failed to get rows affected after inserting new refund: %s\n", err)
-		return err
-	}
-
-	if rowsAffected == 0 {
-		db.ErrorLog.Println("No rows affected after inserting new refund")
-		return database.ErrNoRowsAffected
-	}
-
 	return nil
 }
 
-func (db *SQLiteDatabase) GetRefundWithCoursePurchaseID(coursePurchaseId string) (*models.RefundModel, error) {
-	query := `SELECT id, user_id, course_purchase_id, refund_status, created_at, updated_at FROM refunds WHERE course_purchase_id = ?;`
-
-	var refund models.RefundModel
-
-	row := db.connection.QueryRow(query, coursePurchaseId)
-	if err := row.Scan(&refund.ID, &refund.UserID, &refund.CoursePurchaseID, &refund.RefundStatus, &refund.CreatedAt, &refund.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-
+func (db *SQLiteDatabase) GetRefundWithCoursePurchaseID(ctx context.Context, coursePurchaseId string) (*models.RefundModel, error) {
+	refund, err := internal.GetRefundWithCoursePurchaseID(ctx, db.connection, coursePurchaseId)
+	if err != nil {
 		db.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchaseId, err)
 		return nil, err
 	}
 
-	return &refund, nil
+	return refund, nil
 }
 
-func (db *SQLiteDatabase) UpdateRefundStatus(refundId string, status database.RefundStatus) error {
-	query := `UPDATE refunds SET refund_status = ? WHERE id = ?;`
-
-	_, err := db.connection.Exec(query, status.String(), refundId)
-	if err != nil {
+func (db *SQLiteDatabase) UpdateRefundStatus(ctx context.Context, refundId string, status database.RefundStatus) error {
+	if err := internal.UpdateRefundStatus(ctx, db.connection, refundId, status); err != nil {
 		db.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundId, err)
 		return err
 	}
@@ -117,12 +95,12 @@ func (db *SQLiteDatabase) UpdateRefundStatus(refundId string, status database.Re
 	return nil
 }
 
-func (db *SQLiteDatabase) CountRefunds() (uint, error) {
+func (db *SQLiteDatabase) CountRefunds(ctx context.Context) (uint, error) {
 	query := `SELECT COUNT(id) FROM refunds;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -0,0 +1,11 @@
+package sqlite_database
+
+import "testing"
+
+func TestGetTutorialSearchSnippets(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestGetCourseSearchSnippets(t *testing.T) {
+	// TODO: Implement.
+}
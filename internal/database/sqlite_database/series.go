@@ -0,0 +1,112 @@
+package sqlite_database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// GetAllSeries gets every series that has at least one published tutorial, ordered by title.
+func (db *SQLiteDatabase) GetAllSeries(ctx context.Context) ([]*models.SeriesModel, error) {
+	query := `SELECT s.id, s.title, s.slug, s.created_at FROM series AS s WHERE EXISTS (SELECT 1 FROM tutorials AS t WHERE t.series_id = s.id AND t.published = 1 AND t.author_id IN (SELECT id FROM users WHERE deleted_at IS NULL) AND t.deleted_at IS NULL) ORDER BY s.title ASC;`
+
+	rows, err := db.connection.QueryContext(ctx, query)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for all series: %s\n", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var series []*models.SeriesModel
+
+	for rows.Next() {
+		var seriesModel models.SeriesModel
+
+		if err := rows.Scan(&seriesModel.ID, &seriesModel.Title, &seriesModel.Slug, &seriesModel.CreatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to scan series row from the database: %s\n", err)
+			return nil, err
+		}
+
+		series = append(series, &seriesModel)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all series rows: %s\n", err)
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetSeriesBySlug gets a series by its slug. Nil is returned if there is no such series.
+func (db *SQLiteDatabase) GetSeriesBySlug(ctx context.Context, slug string) (*models.SeriesModel, error) {
+	query := `SELECT id, title, slug, created_at FROM series WHERE slug = ?;`
+
+	series := new(models.SeriesModel)
+
+	if err := db.connection.QueryRowContext(ctx, query, slug).Scan(&series.ID, &series.Title, &series.Slug, &series.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get series by slug (\"%s\") from the database: %s\n", slug, err)
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetSeriesByTutorialID gets the series that a tutorial is part of. Nil is returned if the tutorial isn't part of a
+// series.
+func (db *SQLiteDatabase) GetSeriesByTutorialID(ctx context.Context, tutorialId string) (*models.SeriesModel, error) {
+	query := `SELECT s.id, s.title, s.slug, s.created_at FROM series AS s JOIN tutorials AS t ON t.series_id = s.id WHERE t.id = ?;`
+
+	series := new(models.SeriesModel)
+
+	if err := db.connection.QueryRowContext(ctx, query, tutorialId).Scan(&series.ID, &series.Title, &series.Slug, &series.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+
+		db.ErrorLog.Printf("Failed to get series of tutorial (\"%s\") from the database: %s\n", tutorialId, err)
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// GetSeriesTutorials gets the published tutorials of a series in the order that they should be read.
+func (db *SQLiteDatabase) GetSeriesTutorials(ctx context.Context, seriesId string) ([]*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE series_id = ? AND published = 1 AND author_id IN (SELECT id FROM users WHERE deleted_at IS NULL) AND deleted_at IS NULL ORDER BY series_order ASC, title ASC;`
+
+	rows, err := db.connection.QueryContext(ctx, query, seriesId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to query database for the tutorials of series (\"%s\"): %s\n", seriesId, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tutorials []*models.TutorialModel
+
+	for rows.Next() {
+		var tutorial models.TutorialModel
+		var published int
+
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+			db.ErrorLog.Printf("Failed to scan tutorials row from the database: %s\n", err)
+			return nil, err
+		}
+
+		tutorial.Published = published == 1
+
+		tutorials = append(tutorials, &tutorial)
+	}
+
+	if err := rows.Err(); err != nil {
+		db.ErrorLog.Printf("Found an error after scanning all tutorials rows: %s\n", err)
+		return nil, err
+	}
+
+	return tutorials, nil
+}
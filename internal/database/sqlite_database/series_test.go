@@ -0,0 +1,119 @@
+package sqlite_database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func TestSeries(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	author, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || author == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	sqliteDatabase.PrepareBulkTutorials()
+	sqliteDatabase.InsertTutorial("Part Two", "part-two", "", "", "", "", nil, 0, 0, "", "part-two", nil, "Go Basics", "go-basics", 2, sql.NullTime{}, sql.NullTime{})
+	sqliteDatabase.InsertTutorial("Part One", "part-one", "", "", "", "", nil, 0, 0, "", "part-one", nil, "Go Basics", "go-basics", 1, sql.NullTime{}, sql.NullTime{})
+	sqliteDatabase.InsertTutorial("Part Three", "part-three", "", "", "", "", nil, 0, 0, "", "part-three", nil, "Go Basics", "go-basics", 3, sql.NullTime{}, sql.NullTime{})
+	sqliteDatabase.InsertTutorial("Draft", "draft", "", "", "", "", nil, 0, 0, "", "draft", nil, "Drafts", "drafts", 1, sql.NullTime{}, sql.NullTime{})
+	sqliteDatabase.InsertTutorial("Standalone", "standalone", "", "", "", "", nil, 0, 0, "", "standalone", nil, "", "", 0, sql.NullTime{}, sql.NullTime{})
+
+	if err := sqliteDatabase.RunBulkTutorials(ctx); err != nil {
+		t.Fatalf("Failed to add tutorials: %s", err)
+	}
+
+	tutorials := make(map[string]*models.TutorialModel)
+
+	for _, slug := range []string{"part-one", "part-two", "part-three", "draft", "standalone"} {
+		tutorial, err := sqliteDatabase.GetTutorialBySlug(ctx, slug)
+		if err != nil || tutorial == nil {
+			t.Fatalf("Failed to get tutorial (\"%s\"): %v", slug, err)
+		}
+
+		if err := sqliteDatabase.UpdateTutorialAuthor(ctx, tutorial.ID, author.ID); err != nil {
+			t.Fatalf("Failed to update tutorial author: %s", err)
+		}
+
+		// Part three and the draft stay unpublished.
+		if slug != "part-three" && slug != "draft" {
+			if err := sqliteDatabase.PublishTutorial(ctx, tutorial.ID); err != nil {
+				t.Fatalf("Failed to publish tutorial: %s", err)
+			}
+		}
+
+		tutorials[slug] = tutorial
+	}
+
+	// A series only gets listed once it has a published tutorial.
+	allSeries, err := sqliteDatabase.GetAllSeries(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get all series: %s", err)
+	}
+
+	if len(allSeries) != 1 || allSeries[0].Title != "Go Basics" || allSeries[0].Slug != "go-basics" {
+		t.Fatalf("Expected only the Go Basics series. Got %v", allSeries)
+	}
+
+	series, err := sqliteDatabase.GetSeriesBySlug(ctx, "go-basics")
+	if err != nil || series == nil || series.ID != allSeries[0].ID {
+		t.Fatalf("Expected to find the series by slug. Got %v (%v)", series, err)
+	}
+
+	if missing, err := sqliteDatabase.GetSeriesBySlug(ctx, "missing"); err != nil || missing != nil {
+		t.Errorf("Expected no series for an unknown slug. Got %v (%v)", missing, err)
+	}
+
+	if found, err := sqliteDatabase.GetSeriesByTutorialID(ctx, tutorials["part-two"].ID); err != nil || found == nil || found.ID != series.ID {
+		t.Errorf("Expected part two to be in the series. Got %v (%v)", found, err)
+	}
+
+	if found, err := sqliteDatabase.GetSeriesByTutorialID(ctx, tutorials["standalone"].ID); err != nil || found != nil {
+		t.Errorf("Expected the standalone tutorial to not be in a series. Got %v (%v)", found, err)
+	}
+
+	// The tutorials of a series are read in series order and unpublished parts are left out.
+	if slugs := seriesSlugs(t, sqliteDatabase, series.ID); len(slugs) != 2 || slugs[0] != "part-one" || slugs[1] != "part-two" {
+		t.Errorf("Expected [part-one part-two]. Got %v", slugs)
+	}
+
+	// Taking the only tutorial out of a series deletes the empty series.
+	draft := tutorials["draft"]
+
+	sqliteDatabase.PrepareBulkTutorials()
+	sqliteDatabase.UpdateTutorial(draft.ID, draft.Title, draft.Slug, "", "", "", "", nil, 0, 0, "", draft.FileKey, nil, "", "", 0, draft.AuthorID, sql.NullTime{}, sql.NullTime{})
+
+	if err := sqliteDatabase.RunBulkTutorials(ctx); err != nil {
+		t.Fatalf("Failed to update tutorials: %s", err)
+	}
+
+	if drafts, err := sqliteDatabase.GetSeriesBySlug(ctx, "drafts"); err != nil || drafts != nil {
+		t.Errorf("Expected the empty series to be deleted. Got %v (%v)", drafts, err)
+	}
+}
+
+func seriesSlugs(t *testing.T, sqliteDatabase *SQLiteDatabase, seriesId string) []string {
+	t.Helper()
+
+	tutorials, err := sqliteDatabase.GetSeriesTutorials(context.Background(), seriesId)
+	if err != nil {
+		t.Fatalf("Failed to get series tutorials: %s", err)
+	}
+
+	var slugs []string
+	for _, tutorial := range tutorials {
+		slugs = append(slugs, tutorial.Slug)
+	}
+
+	return slugs
+}
@@ -0,0 +1,41 @@
+package sqlite_database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+)
+
+// GetCurrentSlug looks up the slug that a tutorial, course or chapter uses now based on a slug it used to have. An
+// empty string is returned when the old slug isn't in the slug history or its content has since been deleted.
+func (db *SQLiteDatabase) GetCurrentSlug(ctx context.Context, kind database.SlugKind, oldSlug string) (string, error) {
+	var query string
+
+	switch kind {
+	case database.TutorialSlug:
+		query = `SELECT t.slug FROM slug_history AS h JOIN tutorials AS t ON t.id = h.content_id WHERE h.kind = ? AND h.slug = ? AND t.deleted_at IS NULL;`
+	case database.CourseSlug:
+		query = `SELECT c.slug FROM slug_history AS h JOIN courses AS c ON c.id = h.content_id WHERE h.kind = ? AND h.slug = ? AND c.deleted_at IS NULL;`
+	case database.ChapterSlug:
+		query = `SELECT c.slug FROM slug_history AS h JOIN course_chapters AS c ON c.id = h.content_id WHERE h.kind = ? AND h.slug = ?;`
+	default:
+		err := fmt.Errorf("unknown slug kind %d", kind)
+		db.ErrorLog.Println(err)
+		return "", err
+	}
+
+	var slug string
+
+	if err := db.connection.QueryRowContext(ctx, query, kind.String(), oldSlug).Scan(&slug); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+
+		db.ErrorLog.Printf("Failed to get current %s slug for \"%s\": %s\n", kind, oldSlug, err)
+		return "", err
+	}
+
+	return slug, nil
+}
@@ -0,0 +1,7 @@
+package sqlite_database
+
+import "testing"
+
+func TestGetCurrentSlug(t *testing.T) {
+	// TODO: Implement.
+}
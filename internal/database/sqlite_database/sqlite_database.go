@@ -3,21 +3,20 @@ package sqlite_database
 import (
 	"database/sql"
 	"fmt"
+	"io/fs"
 
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	_ "modernc.org/sqlite"
 )
 
-// TODO: Add database cleanup functions that run in the background.
-
 type SQLiteDatabase struct {
 	utils.Loggers
-	fileName      string
-	migrationsDir string
-	connection    *sql.DB
+	fileName   string
+	migrations fs.FS
+	connection *sql.DB
 }
 
-func CreateSQLiteDatabase(fileName, migrationsDir string) (*SQLiteDatabase, error) {
+func CreateSQLiteDatabase(fileName string, migrations fs.FS) (*SQLiteDatabase, error) {
 	loggers := utils.CreateLoggers("SQLITE DATABASE")
 
 	// Open a connection to the database.
@@ -52,10 +51,10 @@ func CreateSQLiteDatabase(fileName, migrationsDir string) (*SQLiteDatabase, erro
 	}
 
 	sqliteDatabase := &SQLiteDatabase{
-		Loggers:       loggers,
-		fileName:      fileName,
-		migrationsDir: migrationsDir,
-		connection:    conn,
+		Loggers:    loggers,
+		fileName:   fileName,
+		migrations: migrations,
+		connection: conn,
 	}
 
 	return sqliteDatabase, nil
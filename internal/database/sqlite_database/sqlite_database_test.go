@@ -1,6 +1,12 @@
 package sqlite_database
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/db"
+)
 
 func TestCreateSQLiteDatabase(t *testing.T) {
 	// TODO: Implement.
@@ -9,3 +15,35 @@ func TestCreateSQLiteDatabase(t *testing.T) {
 func TestClose(t *testing.T) {
 	// TODO: Implement.
 }
+
+// newTestDatabase creates a fully migrated SQLite database in a temporary directory that gets removed once the test
+// is done. CreateSQLiteDatabase expects a path relative to the working directory so the temporary directory gets
+// turned into one.
+func newTestDatabase(t *testing.T) *SQLiteDatabase {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %s", err)
+	}
+
+	dir, err := filepath.Rel(wd, t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to get relative path to temporary directory: %s", err)
+	}
+
+	sqliteDatabase, err := CreateSQLiteDatabase("/"+filepath.ToSlash(filepath.Join(dir, "db.sqlite")), db.SQLiteMigrations())
+	if err != nil {
+		t.Fatalf("Failed to create SQLite database: %s", err)
+	}
+
+	t.Cleanup(func() {
+		sqliteDatabase.Close()
+	})
+
+	if err := sqliteDatabase.MigrateUp(); err != nil {
+		t.Fatalf("Failed to migrate SQLite database: %s", err)
+	}
+
+	return sqliteDatabase
+}
@@ -1,6 +1,7 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -9,14 +10,14 @@ import (
 	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) AddToken(token, tokenType, userId string, validUntil time.Time) error {
+func (db *SQLiteDatabase) AddToken(ctx context.Context, token, tokenType, userId string, validUntil time.Time) error {
 	id, err := database.GenerateID()
 	if err != nil {
 		db.ErrorLog.Printf("Failed to generate new ID for %s token: %s\n", tokenType, err)
 		return err
 	}
 
-	err = internal.AddToken(db.connection, id, token, tokenType, userId, validUntil)
+	err = internal.AddToken(ctx, db.connection, id, token, tokenType, userId, validUntil)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to save %s token to the database: %s\n", tokenType, err)
 		return err
@@ -25,12 +26,12 @@ func (db *SQLiteDatabase) AddToken(token, tokenType, userId string, validUntil t
 	return nil
 }
 
-func (db *SQLiteDatabase) GetToken(token, tokenType string) (*models.TokenModel, error) {
+func (db *SQLiteDatabase) GetToken(ctx context.Context, token, tokenType string) (*models.TokenModel, error) {
 	query := `SELECT id, token, token_type, valid_until, created_at, user_id FROM tokens WHERE token = ? AND token_type = ?;`
 
 	tokenStruct := new(models.TokenModel)
 
-	row := db.connection.QueryRow(query, token, tokenType)
+	row := db.connection.QueryRowContext(ctx, query, token, tokenType)
 
 	if err := row.Scan(&tokenStruct.ID, &tokenStruct.Token, &tokenStruct.TokenType, &tokenStruct.ValidUntil, &tokenStruct.CreatedAt, &tokenStruct.UserID); err != nil {
 		if err == sql.ErrNoRows {
@@ -44,10 +45,10 @@ func (db *SQLiteDatabase) GetToken(token, tokenType string) (*models.TokenModel,
 	return tokenStruct, nil
 }
 
-func (db *SQLiteDatabase) DeleteToken(token, tokenType string) error {
+func (db *SQLiteDatabase) DeleteToken(ctx context.Context, token, tokenType string) error {
 	query := `DELETE FROM tokens WHERE token = ? AND token_type = ?;`
 
-	_, err := db.connection.Exec(query, token, tokenType)
+	_, err := db.connection.ExecContext(ctx, query, token, tokenType)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to delete token from database: %s\n", err)
 		return err
@@ -56,10 +57,10 @@ func (db *SQLiteDatabase) DeleteToken(token, tokenType string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) DeleteAllTokens(email, tokenType string) error {
+func (db *SQLiteDatabase) DeleteAllTokens(ctx context.Context, email, tokenType string) error {
 	query := `DELETE FROM tokens WHERE token_type = ? AND user_id IN (SELECT id FROM users WHERE email = ?);`
 
-	_, err := db.connection.Exec(query, tokenType, email)
+	_, err := db.connection.ExecContext(ctx, query, tokenType, email)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to delete all user's (\"%s\") %s tokens from database: %s\n", email, tokenType, err)
 		return err
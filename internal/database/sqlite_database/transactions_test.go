@@ -0,0 +1,7 @@
+package sqlite_database
+
+import "testing"
+
+func TestWithTx(t *testing.T) {
+	// TODO: Implement.
+}
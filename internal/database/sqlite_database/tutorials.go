@@ -1,15 +1,33 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) AdminGetTutorials(term string, published *bool, authorId *string, likedByUser, bookmarkedByUser, keyword string, page, elements uint) ([]*models.TutorialModel, error) {
-	query := `SELECT DISTINCT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at FROM tutorials AS t LEFT JOIN tutorials_likes AS tl ON t.id = tl.tutorial_id LEFT JOIN tutorials_bookmarks AS tb ON t.id = tb.tutorial_id LEFT JOIN tutorials_keywords AS tk ON t.id = tk.tutorial_id LEFT JOIN keywords AS k ON tk.keyword_id = k.id WHERE (LOWER(t.id) LIKE '%' || ? || '%' OR LOWER(t.title) LIKE '%' || ? || '%' OR LOWER(t.slug) LIKE '%' || ? || '%' OR LOWER(t.description) LIKE '%' || ? || '%' OR LOWER(k.keyword) LIKE '%' || ? || '%')`
+func (db *SQLiteDatabase) AdminGetTutorials(ctx context.Context, term string, published *bool, authorId *string, likedByUser, bookmarkedByUser, keyword string, deleted bool, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	query := `SELECT DISTINCT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at FROM tutorials AS t LEFT JOIN tutorials_likes AS tl ON t.id = tl.tutorial_id LEFT JOIN tutorials_bookmarks AS tb ON t.id = tb.tutorial_id LEFT JOIN tutorials_keywords AS tk ON t.id = tk.tutorial_id LEFT JOIN keywords AS k ON tk.keyword_id = k.id WHERE 1=1`
+	args := []any{}
+
+	if deleted {
+		query += " AND t.deleted_at IS NOT NULL"
+	} else {
+		query += " AND t.deleted_at IS NULL"
+	}
 
-	args := []any{term, term, term, term, term}
+	if term != "" {
+		if match := internal.FullTextQuery(term); match != "" {
+			query += " AND (t.id = ? OR t.id IN (SELECT id FROM tutorials_fts WHERE tutorials_fts MATCH ?))"
+			args = append(args, term, match)
+		} else {
+			query += " AND t.id = ?"
+			args = append(args, term)
+		}
+	}
 
 	if published != nil {
 		query += " AND t.published = ?"
@@ -48,27 +66,30 @@ func (db *SQLiteDatabase) AdminGetTutorials(term string, published *bool, author
 		args = append(args, keyword)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY t.created_at DESC, t.updated_at DESC, t.title ASC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("tutorials", "t", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY t.created_at DESC, t.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var tutorials []*models.TutorialModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all tutorials from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
 		var tutorial models.TutorialModel
 		var published int
 
-		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from tutorials table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		tutorial.Published = published == 1
@@ -80,14 +101,18 @@ func (db *SQLiteDatabase) AdminGetTutorials(term string, published *bool, author
 		db.ErrorLog.Printf("Failed to get all tutorials from the database: %s\n", err)
 		db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
 
-		return nil, err
+		return nil, nil, err
 	}
 
-	return tutorials, nil
+	tutorials, next := database.Paginate(tutorials, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(tutorials[i].CreatedAt, tutorials[i].ID)
+	})
+
+	return tutorials, next, nil
 }
 
-func (db *SQLiteDatabase) GetAllTutorials(authorId string, published *bool) ([]*models.TutorialModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE 1=1`
+func (db *SQLiteDatabase) GetAllTutorials(ctx context.Context, authorId string, published *bool) ([]*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE 1=1`
 	args := []any{}
 
 	if authorId != "" {
@@ -95,11 +120,13 @@ func (db *SQLiteDatabase) GetAllTutorials(authorId string, published *bool) ([]*
 		args = append(args, authorId)
 	}
 
+	// Soft deleted tutorials are only left out when filtering on their publish status because the content command
+	// still needs to find them by file key.
 	if published != nil {
-		query += " AND published = ?"
+		query += " AND deleted_at IS NULL AND published = ?"
 
 		if *published {
-			query += " AND author_id IS NOT NULL"
+			query += " AND author_id IN (SELECT id FROM users WHERE deleted_at IS NULL)"
 			args = append(args, 1)
 		} else {
 			args = append(args, 0)
@@ -110,7 +137,7 @@ func (db *SQLiteDatabase) GetAllTutorials(authorId string, published *bool) ([]*
 
 	var tutorials []*models.TutorialModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to query database for all tutorials: %s\n", err)
 		return nil, err
@@ -122,7 +149,7 @@ func (db *SQLiteDatabase) GetAllTutorials(authorId string, published *bool) ([]*
 
 		published := false
 
-		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to scan tutorials row from the database: %s\n", err)
 			return nil, err
 		}
@@ -144,34 +171,56 @@ func (db *SQLiteDatabase) GetAllTutorials(authorId string, published *bool) ([]*
 	return tutorials, err
 }
 
-func (db *SQLiteDatabase) GetTutorials(term string, authorId string, page, elements int) ([]*models.TutorialModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE published = 1 AND author_id IS NOT NULL AND (LOWER(title) LIKE '%' || ? || '%' OR LOWER(description) LIKE '%' || ? || '%')`
-	args := []any{term, term}
+func (db *SQLiteDatabase) GetTutorials(ctx context.Context, term string, authorId string, cursor *database.Cursor, elements int) ([]*models.TutorialModel, *database.Cursor, error) {
+	query := `SELECT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at`
+	args := []any{}
+
+	match := internal.FullTextQuery(term)
+	if match != "" {
+		query += ", " + internal.TutorialsRank + " FROM tutorials AS t JOIN tutorials_fts ON tutorials_fts.id = t.id WHERE tutorials_fts MATCH ? AND"
+		args = append(args, match)
+	} else {
+		query += ", 0.0 FROM tutorials AS t WHERE"
+	}
+
+	query += " t.published = 1 AND t.author_id IN (SELECT id FROM users WHERE deleted_at IS NULL) AND t.deleted_at IS NULL"
 
 	if authorId != "" {
-		query += " AND author_id = ?"
+		query += " AND t.author_id = ?"
 		args = append(args, authorId)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY updated_at DESC, title ASC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	// Search results are ordered by relevance so the cursor needs to keep track of the rank of the last result.
+	if match != "" {
+		condition, cursorArgs := internal.AfterRankedCursor(internal.TutorialsRank, "t", cursor)
+		query += condition + " ORDER BY " + internal.TutorialsRank + " ASC, t.id ASC"
+		args = append(args, cursorArgs...)
+	} else {
+		condition, cursorArgs := internal.AfterCursor("tutorials", "t", cursor)
+		query += condition + " ORDER BY t.created_at DESC, t.id DESC"
+		args = append(args, cursorArgs...)
+	}
+
+	query += " LIMIT ?;"
+	args = append(args, elements+1)
 
 	var tutorials []*models.TutorialModel
+	var ranks []float64
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
-		db.ErrorLog.Printf("Failed to get all tutorials (page %d), that match the search term \"%s\", from the database: %s\n", page, term, err)
-		return nil, err
+		db.ErrorLog.Printf("Failed to get the next page of tutorials, that match the search term \"%s\", from the database: %s\n", term, err)
+		return nil, nil, err
 	}
 
 	for rows.Next() {
 		var tutorial models.TutorialModel
 		var published int
+		var rank float64
 
-		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
-			db.ErrorLog.Printf("Failed to read row from tutorials (page %d) table, that match the search term \"%s\": %s\n", page, term, err)
-			return nil, err
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt, &rank); err != nil {
+			db.ErrorLog.Printf("Failed to read row from tutorials table, that match the search term \"%s\": %s\n", term, err)
+			return nil, nil, err
 		}
 
 		if published == 1 {
@@ -179,24 +228,33 @@ func (db *SQLiteDatabase) GetTutorials(term string, authorId string, page, eleme
 		}
 
 		tutorials = append(tutorials, &tutorial)
+		ranks = append(ranks, rank)
 	}
 
 	if err := rows.Err(); err != nil {
-		db.ErrorLog.Printf("Failed to get all tutorials (page %d), that match the search term \"%s\", from the database: %s\n", page, term, err)
-		return nil, err
+		db.ErrorLog.Printf("Failed to get the next page of tutorials, that match the search term \"%s\", from the database: %s\n", term, err)
+		return nil, nil, err
 	}
 
-	return tutorials, nil
+	tutorials, next := database.Paginate(tutorials, elements, func(i int) *database.Cursor {
+		if match != "" {
+			return database.NewRankedCursor(ranks[i], tutorials[i].CreatedAt, tutorials[i].ID)
+		}
+
+		return database.NewCursor(tutorials[i].CreatedAt, tutorials[i].ID)
+	})
+
+	return tutorials, next, nil
 }
 
-func (db *SQLiteDatabase) GetTutorialByID(id string) (*models.TutorialModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE id = ?;`
+func (db *SQLiteDatabase) GetTutorialByID(ctx context.Context, id string) (*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE id = ?;`
 
 	var tutorial models.TutorialModel
 	var publishedInt int
 
-	row := db.connection.QueryRow(query, id)
-	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, id)
+	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -212,14 +270,14 @@ func (db *SQLiteDatabase) GetTutorialByID(id string) (*models.TutorialModel, err
 	return &tutorial, nil
 }
 
-func (db *SQLiteDatabase) GetTutorialBySlug(slug string) (*models.TutorialModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE slug = ?;`
+func (db *SQLiteDatabase) GetTutorialBySlug(ctx context.Context, slug string) (*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE slug = ? AND deleted_at IS NULL;`
 
 	var tutorial models.TutorialModel
 	var publishedInt int
 
-	row := db.connection.QueryRow(query, slug)
-	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, slug)
+	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -235,14 +293,14 @@ func (db *SQLiteDatabase) GetTutorialBySlug(slug string) (*models.TutorialModel,
 	return &tutorial, nil
 }
 
-func (db *SQLiteDatabase) GetTutorialByFileKey(fileKey string) (*models.TutorialModel, error) {
-	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE file_key = ?;`
+func (db *SQLiteDatabase) GetTutorialByFileKey(ctx context.Context, fileKey string) (*models.TutorialModel, error) {
+	query := `SELECT id, title, slug, description, thumbnail_url, banner_url, content, table_of_contents, word_count, reading_time, published, author_id, file_checksum, file_key, created_at, updated_at FROM tutorials WHERE file_key = ?;`
 
 	var tutorial models.TutorialModel
 	var publishedInt int
 
-	row := db.connection.QueryRow(query, fileKey)
-	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+	row := db.connection.QueryRowContext(ctx, query, fileKey)
+	if err := row.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &publishedInt, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -258,12 +316,12 @@ func (db *SQLiteDatabase) GetTutorialByFileKey(fileKey string) (*models.Tutorial
 	return &tutorial, nil
 }
 
-func (db *SQLiteDatabase) CountTutorials() (uint, error) {
-	query := `SELECT COUNT(id) FROM tutorials;`
+func (db *SQLiteDatabase) CountTutorials(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM tutorials WHERE deleted_at IS NULL;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		db.ErrorLog.Printf("Failed to count the number of tutorials in the database: %s\n", err)
 		return 0, err
@@ -272,12 +330,12 @@ func (db *SQLiteDatabase) CountTutorials() (uint, error) {
 	return count, nil
 }
 
-func (db *SQLiteDatabase) CountTutorialsWrittenBy(authorId string) (uint, error) {
-	query := `SELECT COUNT(id) FROM tutorials where author_id = ?;`
+func (db *SQLiteDatabase) CountTutorialsWrittenBy(ctx context.Context, authorId string) (uint, error) {
+	query := `SELECT COUNT(id) FROM tutorials WHERE author_id = ? AND deleted_at IS NULL;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, authorId)
+	row := db.connection.QueryRowContext(ctx, query, authorId)
 	if err := row.Scan(&count); err != nil {
 		db.ErrorLog.Printf("Failed to count the number of tutorials written by \"%s\": %s\n", authorId, err)
 		return 0, err
@@ -286,10 +344,10 @@ func (db *SQLiteDatabase) CountTutorialsWrittenBy(authorId string) (uint, error)
 	return count, nil
 }
 
-func (db *SQLiteDatabase) PublishTutorial(tutorialId string) error {
+func (db *SQLiteDatabase) PublishTutorial(ctx context.Context, tutorialId string) error {
 	query := `UPDATE tutorials SET published = 1 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, tutorialId)
+	_, err := db.connection.ExecContext(ctx, query, tutorialId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to publish tutorial \"%s\": %s\n", tutorialId, err)
 		return err
@@ -298,10 +356,10 @@ func (db *SQLiteDatabase) PublishTutorial(tutorialId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UnpublishTutorial(tutorialId string) error {
+func (db *SQLiteDatabase) UnpublishTutorial(ctx context.Context, tutorialId string) error {
 	query := `UPDATE tutorials SET published = 0 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, tutorialId)
+	_, err := db.connection.ExecContext(ctx, query, tutorialId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to unpublish tutorial \"%s\": %s\n", tutorialId, err)
 		return err
@@ -310,14 +368,14 @@ func (db *SQLiteDatabase) UnpublishTutorial(tutorialId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UpdateTutorialAuthor(tutorialId, authorId string) error {
+func (db *SQLiteDatabase) UpdateTutorialAuthor(ctx context.Context, tutorialId, authorId string) error {
 	query := `UPDATE tutorials SET author_id = ? WHERE id = ?;`
 
 	if authorId == "" {
 		authorId = "NULL"
 	}
 
-	_, err := db.connection.Exec(query, authorId, tutorialId)
+	_, err := db.connection.ExecContext(ctx, query, authorId, tutorialId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update tutorial's (\"%s\") author (\"%s\"): %s\n", tutorialId, authorId, err)
 		return err
@@ -325,3 +383,120 @@ func (db *SQLiteDatabase) UpdateTutorialAuthor(tutorialId, authorId string) erro
 
 	return nil
 }
+
+// DeleteTutorial soft deletes the tutorial so that it no longer shows up anywhere on the site.
+func (db *SQLiteDatabase) DeleteTutorial(ctx context.Context, tutorialId string) error {
+	query := `UPDATE tutorials SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to delete tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after deleting tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after deleting tutorial \"%s\"\n", tutorialId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// RestoreTutorial brings back a soft deleted tutorial.
+func (db *SQLiteDatabase) RestoreTutorial(ctx context.Context, tutorialId string) error {
+	query := `UPDATE tutorials SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL;`
+
+	result, err := db.connection.ExecContext(ctx, query, tutorialId)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to restore tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		db.ErrorLog.Printf("Failed to get rows affected after restoring tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		db.ErrorLog.Printf("0 rows were affected after restoring tutorial \"%s\"\n", tutorialId)
+		return database.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+// PurgeTutorial permanently removes the tutorial along with its keywords, likes, bookmarks and comments.
+func (db *SQLiteDatabase) PurgeTutorial(ctx context.Context, tutorialId string) error {
+	queries := []string{
+		`DELETE FROM tutorials_keywords WHERE tutorial_id = ?;`,
+		`DELETE FROM tutorials_likes WHERE tutorial_id = ?;`,
+		`DELETE FROM tutorials_bookmarks WHERE tutorial_id = ?;`,
+		`DELETE FROM comments WHERE tutorial_id = ?;`,
+	}
+
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, tutorialId); err != nil {
+			if err := tx.Rollback(); err != nil {
+				db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+			}
+
+			db.ErrorLog.Printf("Failed to purge tutorial \"%s\": %s\n", tutorialId, err)
+			db.ErrorLog.Printf("\nSQL Query Used:\n%s\n", query)
+
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM tutorials WHERE id = ?;`, tutorialId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to purge tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after purging tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after purging tutorial \"%s\"\n", tutorialId)
+		return database.ErrNoRowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after purging tutorial \"%s\": %s\n", tutorialId, err)
+		return err
+	}
+
+	return nil
+}
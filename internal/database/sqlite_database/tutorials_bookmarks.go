@@ -1,14 +1,16 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) GetTutorialsBookmarkedByUser(term, userId string, page, elements uint) ([]*models.TutorialModel, error) {
-	query := `SELECT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at FROM tutorials_bookmarks AS tb JOIN tutorials AS t ON tb.tutorial_id = t.id WHERE tb.user_id = ? AND t.published = 1`
+func (db *SQLiteDatabase) GetTutorialsBookmarkedByUser(ctx context.Context, term, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error) {
+	query := `SELECT t.id, t.title, t.slug, t.description, t.thumbnail_url, t.banner_url, t.content, t.table_of_contents, t.word_count, t.reading_time, t.published, t.author_id, t.file_checksum, t.file_key, t.created_at, t.updated_at, tb.created_at, tb.id FROM tutorials_bookmarks AS tb JOIN tutorials AS t ON tb.tutorial_id = t.id WHERE tb.user_id = ? AND t.published = 1 AND t.deleted_at IS NULL`
 	args := []any{userId}
 
 	if term != "" {
@@ -16,46 +18,57 @@ func (db *SQLiteDatabase) GetTutorialsBookmarkedByUser(term, userId string, page
 		args = append(args, term, term, term)
 	}
 
-	offset := (page - 1) * elements
-	query += " ORDER BY tb.created_at DESC LIMIT ? OFFSET ?;"
-	args = append(args, elements, offset)
+	// The tutorials are ordered by when they were bookmarked so the cursor points at the bookmark rather than the tutorial.
+	condition, cursorArgs := internal.AfterCursor("tutorials_bookmarks", "tb", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += " ORDER BY tb.created_at DESC, tb.id DESC LIMIT ?;"
+	args = append(args, elements+1)
 
 	var tutorials []*models.TutorialModel
+	var relations []*database.Cursor
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all tutorials bookmarked by user (\"%s\"): %s\n", userId, err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	for rows.Next() {
 		var tutorial models.TutorialModel
 		var published int
+		var relation database.Cursor
 
-		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt); err != nil {
+		if err := rows.Scan(&tutorial.ID, &tutorial.Title, &tutorial.Slug, &tutorial.Description, &tutorial.ThumbnailURL, &tutorial.BannerURL, &tutorial.Content, &tutorial.TableOfContents, &tutorial.WordCount, &tutorial.ReadingTime, &published, &tutorial.AuthorID, &tutorial.FileChecksum, &tutorial.FileKey, &tutorial.CreatedAt, &tutorial.UpdatedAt, &relation.CreatedAt, &relation.ID); err != nil {
 			db.ErrorLog.Printf("Failed to read tutorial from the database: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		tutorial.Published = published == 1
 
 		tutorials = append(tutorials, &tutorial)
+		relations = append(relations, &relation)
 	}
 
 	if err := rows.Err(); err != nil {
 		db.ErrorLog.Printf("Failed to get all tutorials bookmarked by user (\"%s\"): %s\n", userId, err)
-		return nil, err
+		return nil, nil, err
 	}
 
-	return tutorials, nil
+	tutorials, next := database.Paginate(tutorials, int(elements), func(i int) *database.Cursor {
+		return relations[i]
+	})
+
+	return tutorials, next, nil
 }
 
-func (db *SQLiteDatabase) UserBookmarkedTutorial(userId, slug string) (bool, error) {
+func (db *SQLiteDatabase) UserBookmarkedTutorial(ctx context.Context, userId, slug string) (bool, error) {
 	query := `SELECT t.id FROM tutorials_bookmarks AS tb JOIN tutorials AS t ON tb.tutorial_id = t.id WHERE tb.user_id = ? AND t.slug = ?;`
 
 	var id string
 
-	row := db.connection.QueryRow(query, userId, slug)
+	row := db.connection.QueryRowContext(ctx, query, userId, slug)
 	if err := row.Scan(&id); err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -68,7 +81,7 @@ func (db *SQLiteDatabase) UserBookmarkedTutorial(userId, slug string) (bool, err
 	return id != "", nil
 }
 
-func (db *SQLiteDatabase) UserBookmarkTutorial(userId, slug string) error {
+func (db *SQLiteDatabase) UserBookmarkTutorial(ctx context.Context, userId, slug string) error {
 	query := `INSERT INTO tutorials_bookmarks (id, user_id, tutorial_id) VALUES (?, ?, (SELECT id FROM tutorials WHERE slug = ?));`
 
 	id, err := database.GenerateID()
@@ -77,7 +90,7 @@ func (db *SQLiteDatabase) UserBookmarkTutorial(userId, slug string) error {
 		return err
 	}
 
-	result, err := db.connection.Exec(query, id, userId, slug)
+	result, err := db.connection.ExecContext(ctx, query, id, userId, slug)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to add new row to tutorials_bookmarks table in the database: %s\n", err)
 		return err
@@ -97,10 +110,10 @@ func (db *SQLiteDatabase) UserBookmarkTutorial(userId, slug string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UserUnbookmarkTutorial(userId, slug string) error {
+func (db *SQLiteDatabase) UserUnbookmarkTutorial(ctx context.Context, userId, slug string) error {
 	query := `DELETE FROM tutorials_bookmarks WHERE user_id = ? AND tutorial_id = (SELECT id FROM tutorials WHERE slug = ?);`
 
-	result, err := db.connection.Exec(query, userId, slug)
+	result, err := db.connection.ExecContext(ctx, query, userId, slug)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to delete row from tutorials_bookmarks table in the database: %s\n", err)
 		return err
@@ -120,12 +133,12 @@ func (db *SQLiteDatabase) UserUnbookmarkTutorial(userId, slug string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) CountTutorialBookmarks(tutorialId string) (uint, error) {
+func (db *SQLiteDatabase) CountTutorialBookmarks(ctx context.Context, tutorialId string) (uint, error) {
 	query := `SELECT COUNT(id) FROM tutorials_bookmarks WHERE tutorial_id = ?;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, tutorialId)
+	row := db.connection.QueryRowContext(ctx, query, tutorialId)
 	if err := row.Scan(&count); err != nil {
 		db.ErrorLog.Printf("Failed to count the amount of times the tutorial \"%s\" has been bookmarked: %s\n", tutorialId, err)
 		return 0, err
@@ -134,12 +147,12 @@ func (db *SQLiteDatabase) CountTutorialBookmarks(tutorialId string) (uint, error
 	return count, nil
 }
 
-func (db *SQLiteDatabase) CountTutorialsBookmarkedByUser(userId string) (uint, error) {
+func (db *SQLiteDatabase) CountTutorialsBookmarkedByUser(ctx context.Context, userId string) (uint, error) {
 	query := `SELECT COUNT(id) FROM tutorials_bookmarks WHERE user_id = ?;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query, userId)
+	row := db.connection.QueryRowContext(ctx, query, userId)
 	if err := row.Scan(&count); err != nil {
 		db.ErrorLog.Printf("Failed to count the amount of tutorials bookmarked by user \"%s\": %s\n", userId, err)
 		return 0, err
@@ -1,11 +1,13 @@
 package sqlite_database
 
-func (db *SQLiteDatabase) GetAllKeywordsForTutorial(tutorialId string) ([]string, error) {
+import "context"
+
+func (db *SQLiteDatabase) GetAllKeywordsForTutorial(ctx context.Context, tutorialId string) ([]string, error) {
 	query := `SELECT k.keyword FROM tutorials_keywords AS tk JOIN keywords AS k ON tk.keyword_id = k.id WHERE tk.tutorial_id = ?;`
 
 	var keywords []string
 
-	rows, err := db.connection.Query(query, tutorialId)
+	rows, err := db.connection.QueryContext(ctx, query, tutorialId)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -9,12 +10,12 @@ import (
 	sqlite3 "modernc.org/sqlite/lib"
 )
 
-func (db *SQLiteDatabase) HasUserCompletedChapter(userId, courseId, chapterId string) (bool, error) {
+func (db *SQLiteDatabase) HasUserCompletedChapter(ctx context.Context, userId, courseId, chapterId string) (bool, error) {
 	query := `SELECT id FROM user_course_chapter_completion WHERE user_id = ? AND course_id = ? AND chapter_id = ?;`
 
 	var id string
 
-	row := db.connection.QueryRow(query, userId, courseId, chapterId)
+	row := db.connection.QueryRowContext(ctx, query, userId, courseId, chapterId)
 	if err := row.Scan(&id); err != nil {
 		if err == sql.ErrNoRows {
 			return false, nil
@@ -27,12 +28,12 @@ func (db *SQLiteDatabase) HasUserCompletedChapter(userId, courseId, chapterId st
 	return id != "", nil
 }
 
-func (db *SQLiteDatabase) GetAllChaptersCompleted(userId, courseId string) ([]*models.ChapterModel, error) {
-	query := `SELECT cc.id, cc.title, cc.slug, cc.chapter, cc.content, cc.course_id, cc.file_checksum, cc.file_key, cc.created_at, cc.updated_at FROM user_course_chapter_completion AS uccc LEFT JOIN course_chapters AS cc ON uccc.chapter_id = cc.id WHERE uccc.user_id = ? AND uccc.course_id = ? ORDER BY cc.chapter ASC;`
+func (db *SQLiteDatabase) GetAllChaptersCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	query := `SELECT cc.id, cc.title, cc.slug, cc.chapter, cc.content, cc.table_of_contents, cc.word_count, cc.reading_time, cc.course_id, cc.file_checksum, cc.file_key, cc.release_after_days, cc.release_at, cc.created_at, cc.updated_at FROM user_course_chapter_completion AS uccc LEFT JOIN course_chapters AS cc ON uccc.chapter_id = cc.id WHERE uccc.user_id = ? AND uccc.course_id = ? ORDER BY cc.chapter ASC;`
 
 	var chapters []*models.ChapterModel
 
-	rows, err := db.connection.Query(query, userId, courseId)
+	rows, err := db.connection.QueryContext(ctx, query, userId, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get course (\"%s\") chapters completed by user (\"%s\"): %s\n", courseId, userId, err)
 		return nil, err
@@ -41,7 +42,7 @@ func (db *SQLiteDatabase) GetAllChaptersCompleted(userId, courseId string) ([]*m
 	for rows.Next() {
 		var chapter models.ChapterModel
 
-		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read chapter from database: %s\n", err)
 			return nil, err
 		}
@@ -57,12 +58,12 @@ func (db *SQLiteDatabase) GetAllChaptersCompleted(userId, courseId string) ([]*m
 	return chapters, nil
 }
 
-func (db *SQLiteDatabase) GetAllChaptersNotCompleted(userId, courseId string) ([]*models.ChapterModel, error) {
-	query := `SELECT id, title, slug, chapter, content, course_id, file_checksum, file_key, created_at, updated_at FROM course_chapters WHERE course_id = ? EXCEPT SELECT cc.id, cc.title, cc.slug, cc.chapter, cc.content, cc.course_id, cc.file_checksum, cc.file_key, cc.created_at, cc.updated_at FROM course_chapters AS cc LEFT JOIN user_course_chapter_completion AS uccc ON cc.id = uccc.chapter_id WHERE uccc.user_id = ? AND cc.course_id = ? ORDER BY chapter ASC;`
+func (db *SQLiteDatabase) GetAllChaptersNotCompleted(ctx context.Context, userId, courseId string) ([]*models.ChapterModel, error) {
+	query := `SELECT id, title, slug, chapter, content, table_of_contents, word_count, reading_time, course_id, file_checksum, file_key, release_after_days, release_at, created_at, updated_at FROM course_chapters WHERE course_id = ? EXCEPT SELECT cc.id, cc.title, cc.slug, cc.chapter, cc.content, cc.table_of_contents, cc.word_count, cc.reading_time, cc.course_id, cc.file_checksum, cc.file_key, cc.release_after_days, cc.release_at, cc.created_at, cc.updated_at FROM course_chapters AS cc LEFT JOIN user_course_chapter_completion AS uccc ON cc.id = uccc.chapter_id WHERE uccc.user_id = ? AND cc.course_id = ? ORDER BY chapter ASC;`
 
 	var chapters []*models.ChapterModel
 
-	rows, err := db.connection.Query(query, courseId, userId, courseId)
+	rows, err := db.connection.QueryContext(ctx, query, courseId, userId, courseId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get course (\"%s\") chapters not completed by user (\"%s\"): %s\n", courseId, userId, err)
 		return nil, err
@@ -71,7 +72,7 @@ func (db *SQLiteDatabase) GetAllChaptersNotCompleted(userId, courseId string) ([
 	for rows.Next() {
 		var chapter models.ChapterModel
 
-		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
+		if err := rows.Scan(&chapter.ID, &chapter.Title, &chapter.Slug, &chapter.Chapter, &chapter.Content, &chapter.TableOfContents, &chapter.WordCount, &chapter.ReadingTime, &chapter.CourseID, &chapter.FileChecksum, &chapter.FileKey, &chapter.ReleaseAfterDays, &chapter.ReleaseAt, &chapter.CreatedAt, &chapter.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read chapter from database: %s\n", err)
 			return nil, err
 		}
@@ -87,8 +88,10 @@ func (db *SQLiteDatabase) GetAllChaptersNotCompleted(userId, courseId string) ([
 	return chapters, nil
 }
 
-func (db *SQLiteDatabase) FinishChapter(userId, chapterId, courseId string) error {
-	query := `INSERT INTO user_course_chapter_completion (id, user_id, course_id, chapter_id) VALUES (?, ?, ?, ?);`
+// FinishChapter marks a chapter as completed by the user. If the chapter has a quiz the user needs to have passed it
+// first, otherwise database.ErrQuizNotPassed gets returned. Finishing a chapter twice isn't treated as an error.
+func (db *SQLiteDatabase) FinishChapter(ctx context.Context, userId, chapterId, courseId string) error {
+	query := `INSERT INTO user_course_chapter_completion (id, user_id, course_id, chapter_id) SELECT ?, ?, ?, ? WHERE NOT EXISTS (SELECT 1 FROM quizzes WHERE chapter_id = ?) OR EXISTS (SELECT 1 FROM quiz_attempts AS a JOIN quizzes AS q ON q.id = a.quiz_id WHERE q.chapter_id = ? AND a.user_id = ? AND a.passed = 1) OR EXISTS (SELECT 1 FROM user_course_chapter_completion WHERE user_id = ? AND course_id = ? AND chapter_id = ?);`
 
 	id, err := database.GenerateID()
 	if err != nil {
@@ -96,7 +99,7 @@ func (db *SQLiteDatabase) FinishChapter(userId, chapterId, courseId string) erro
 		return err
 	}
 
-	result, err := db.connection.Exec(query, id, userId, courseId, chapterId)
+	result, err := db.connection.ExecContext(ctx, query, id, userId, courseId, chapterId, chapterId, chapterId, userId, userId, courseId, chapterId)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return nil
@@ -112,9 +115,9 @@ func (db *SQLiteDatabase) FinishChapter(userId, chapterId, courseId string) erro
 		return err
 	}
 
+	// Nothing gets inserted when the chapter has a quiz that the user hasn't passed yet.
 	if rowsAffected == 0 {
-		db.ErrorLog.Println("No rows were affected after inserting new row to user_course_chapter_completion table")
-		return database.ErrNoRowsAffected
+		return database.ErrQuizNotPassed
 	}
 
 	return nil
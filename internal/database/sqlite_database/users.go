@@ -1,6 +1,7 @@
 package sqlite_database
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -11,18 +12,23 @@ import (
 	sqlite3 "modernc.org/sqlite/lib"
 )
 
-func (db *SQLiteDatabase) GetUsers(term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error) {
+func (db *SQLiteDatabase) GetUsers(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string) ([]*models.UserModel, error) {
 	query := `SELECT DISTINCT u.id, u.name, u.surname, u.slug, u.email, u.password, u.affiliate_code, u.affiliate_points, u.is_admin, u.is_author, u.created_at, u.updated_at FROM users AS u LEFT JOIN tutorials_likes AS tl ON u.id = tl.user_id LEFT JOIN tutorials_bookmarks AS tb ON u.id = tb.user_id WHERE (LOWER(u.id) LIKE '%' || ? || '%' OR LOWER(u.name) LIKE '%' || ? || '%' OR LOWER(u.surname) LIKE '%' || ? || '%' OR LOWER(u.email) LIKE '%' || ? || '%' OR LOWER(u.affiliate_code) LIKE '%' || ? || '%')`
 
 	args := []any{term, term, term, term, term}
 
 	switch level {
 	case database.User:
-		query += ` AND is_admin = 0 AND is_author = 0`
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
 	case database.Admin:
-		query += ` AND is_admin = 1`
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
 	case database.Author:
-		query += ` AND is_author = 1`
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
 	}
 
 	if likedTutorialID != "" {
@@ -37,7 +43,7 @@ func (db *SQLiteDatabase) GetUsers(term string, level database.AuthorizationLeve
 
 	var users []*models.UserModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to query database for all users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
 		return nil, err
@@ -67,20 +73,23 @@ func (db *SQLiteDatabase) GetUsers(term string, level database.AuthorizationLeve
 	return users, nil
 }
 
-func (db *SQLiteDatabase) GetUsersPaginated(term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, page, elements uint) ([]*models.UserModel, error) {
+func (db *SQLiteDatabase) GetUsersPaginated(ctx context.Context, term string, level database.AuthorizationLevel, likedTutorialID, bookmarkedTutorialID string, cursor *database.Cursor, elements uint) ([]*models.UserModel, *database.Cursor, error) {
 	query := `SELECT DISTINCT u.id, u.name, u.surname, u.slug, u.email, u.password, u.affiliate_code, u.affiliate_points, u.is_admin, u.is_author, u.created_at, u.updated_at FROM users AS u LEFT JOIN tutorials_likes AS tl ON u.id = tl.user_id LEFT JOIN tutorials_bookmarks AS tb ON u.id = tb.user_id WHERE (LOWER(u.id) LIKE '%' || ? || '%' OR LOWER(u.name) LIKE '%' || ? || '%' OR LOWER(u.surname) LIKE '%' || ? || '%' OR LOWER(u.email) LIKE '%' || ? || '%' OR LOWER(u.affiliate_code) LIKE '%' || ? || '%')`
 
-	offset := (page - 1) * elements
-
 	args := []any{term, term, term, term, term}
 
 	switch level {
 	case database.User:
-		query += ` AND is_admin = 0 AND is_author = 0`
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
 	case database.Admin:
-		query += ` AND is_admin = 1`
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
 	case database.Author:
-		query += ` AND is_author = 1`
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
 	}
 
 	if likedTutorialID != "" {
@@ -93,15 +102,19 @@ func (db *SQLiteDatabase) GetUsersPaginated(term string, level database.Authoriz
 		args = append(args, bookmarkedTutorialID)
 	}
 
-	query += ` ORDER BY u.created_at DESC LIMIT ? OFFSET ?;`
-	args = append(args, elements, offset)
+	condition, cursorArgs := internal.AfterCursor("users", "u", cursor)
+	query += condition
+	args = append(args, cursorArgs...)
+
+	query += ` ORDER BY u.created_at DESC, u.id DESC LIMIT ?;`
+	args = append(args, elements+1)
 
 	var users []*models.UserModel
 
-	rows, err := db.connection.Query(query, args...)
+	rows, err := db.connection.QueryContext(ctx, query, args...)
 	if err != nil {
-		db.ErrorLog.Printf("Failed to query database for all users (page %d) according to the search term \"%s\" and authorization level \"%s\": %s\n", page, term, level, err)
-		return nil, err
+		db.ErrorLog.Printf("Failed to query database for the next page of users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
+		return nil, nil, err
 	}
 
 	for rows.Next() {
@@ -111,7 +124,7 @@ func (db *SQLiteDatabase) GetUsersPaginated(term string, level database.Authoriz
 
 		if err := rows.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &user.AffiliateCode, &user.AffiliatePoints, &isAdmin, &isAuthor, &user.CreatedAt, &user.UpdatedAt); err != nil {
 			db.ErrorLog.Printf("Failed to read row from users table: %s\n", err)
-			return nil, err
+			return nil, nil, err
 		}
 
 		user.IsAdmin = isAdmin == 1
@@ -121,19 +134,23 @@ func (db *SQLiteDatabase) GetUsersPaginated(term string, level database.Authoriz
 	}
 
 	if err := rows.Err(); err != nil {
-		db.ErrorLog.Printf("Failed to query database for all users (page %d) according to the search term \"%s\" and authorization level \"%s\": %s\n", page, term, level, err)
-		return nil, err
+		db.ErrorLog.Printf("Failed to query database for the next page of users according to the search term \"%s\" and authorization level \"%s\": %s\n", term, level, err)
+		return nil, nil, err
 	}
 
-	return users, nil
+	users, next := database.Paginate(users, int(elements), func(i int) *database.Cursor {
+		return database.NewCursor(users[i].CreatedAt, users[i].ID)
+	})
+
+	return users, next, nil
 }
 
-func (db *SQLiteDatabase) GetAllUsers() ([]*models.UserModel, error) {
-	query := `SELECT id, name, surname, slug, email, password, affiliate_code, affiliate_points, is_admin, is_author, created_at, updated_at FROM users`
+func (db *SQLiteDatabase) GetAllUsers(ctx context.Context) ([]*models.UserModel, error) {
+	query := `SELECT id, name, surname, slug, email, password, affiliate_code, affiliate_points, is_admin, is_author, created_at, updated_at FROM users WHERE deleted_at IS NULL`
 
 	var users []*models.UserModel
 
-	rows, err := db.connection.Query(query)
+	rows, err := db.connection.QueryContext(ctx, query)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get all users from the database: %s\n", err)
 		return nil, err
@@ -164,7 +181,7 @@ func (db *SQLiteDatabase) GetAllUsers() ([]*models.UserModel, error) {
 }
 
 // The only function that I believe has a viable reason to do transactions.
-func (db *SQLiteDatabase) AddNewUser(name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error) {
+func (db *SQLiteDatabase) AddNewUser(ctx context.Context, name, surname, email, password, token, tokenType, ipAddr string, validUntil time.Time) (*models.UserModel, error) {
 	// Generate all the IDs required for the database transaction first. I don't want the transaction to fail
 	// because I couldn't generate an ID.
 	userId, err := database.GenerateID()
@@ -196,13 +213,13 @@ func (db *SQLiteDatabase) AddNewUser(name, surname, email, password, token, toke
 	// With all the setup out the way, start a new transaction. The only thing that can fail now is database calls.
 	// The reason for the transaction is that I don't want the user saved to the database if the token or the IP
 	// address couldn't have been saved. I'd rather the user start again than have my database contain fractured data.
-	tx, err := db.connection.Begin()
+	tx, err := db.connection.BeginTx(ctx, nil)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to begin transaction to add user (\"%s\") to the database: %s\n", email, err)
 		return nil, err
 	}
 
-	user, err := internal.AddUser(tx, userId, name, surname, userSlug, email, password, affiliateCode)
+	user, err := internal.AddUser(ctx, tx, userId, name, surname, userSlug, email, password, affiliateCode)
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
@@ -212,7 +229,7 @@ func (db *SQLiteDatabase) AddNewUser(name, surname, email, password, token, toke
 		return nil, err
 	}
 
-	err = internal.AddToken(tx, tokenId, token, tokenType, user.ID, validUntil)
+	err = internal.AddToken(ctx, tx, tokenId, token, tokenType, user.ID, validUntil)
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
@@ -222,7 +239,7 @@ func (db *SQLiteDatabase) AddNewUser(name, surname, email, password, token, toke
 		return nil, err
 	}
 
-	err = internal.AddIPAddress(tx, addressId, user.ID, ipAddr)
+	err = internal.AddIPAddress(ctx, tx, addressId, user.ID, ipAddr)
 	if err != nil {
 		if err := tx.Rollback(); err != nil {
 			db.ErrorLog.Printf("Failed to rollback database changes: %s\n", err)
@@ -246,7 +263,7 @@ func (db *SQLiteDatabase) AddNewUser(name, surname, email, password, token, toke
 	return user, nil
 }
 
-func (db *SQLiteDatabase) NewUser(name, surname, email, password string) error {
+func (db *SQLiteDatabase) NewUser(ctx context.Context, name, surname, email, password string) error {
 	query := `INSERT INTO users (id, name, surname, slug, email, password, affiliate_code) VALUES (?, ?, ?, ?, ?, ?, ?);`
 
 	userId, err := database.GenerateID()
@@ -263,7 +280,7 @@ func (db *SQLiteDatabase) NewUser(name, surname, email, password string) error {
 
 	userSlug := database.NameSurnameToSlug(name, surname)
 
-	result, err := db.connection.Exec(query, userId, name, surname, userSlug, email, password, affiliateCode)
+	result, err := db.connection.ExecContext(ctx, query, userId, name, surname, userSlug, email, password, affiliateCode)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return database.ErrUserAlreadyExists
@@ -289,7 +306,7 @@ func (db *SQLiteDatabase) NewUser(name, surname, email, password string) error {
 
 // AddAdminUser adds a new admin user to the database. This function should not be made available to the application because
 // there should never be a reason for the actual application to use this function.
-func (db *SQLiteDatabase) NewAdminUser(name, surname, email, password string) error {
+func (db *SQLiteDatabase) NewAdminUser(ctx context.Context, name, surname, email, password string) error {
 	query := `INSERT INTO users (id, name, surname, slug, email, password, affiliate_code, is_admin) VALUES (?, ?, ?, ?, ?, ?, ?, 1);`
 
 	userId, err := database.GenerateID()
@@ -306,7 +323,7 @@ func (db *SQLiteDatabase) NewAdminUser(name, surname, email, password string) er
 
 	userSlug := database.NameSurnameToSlug(name, surname)
 
-	result, err := db.connection.Exec(query, userId, name, surname, userSlug, email, password, affiliateCode)
+	result, err := db.connection.ExecContext(ctx, query, userId, name, surname, userSlug, email, password, affiliateCode)
 	if err != nil {
 		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
 			return database.ErrUserAlreadyExists
@@ -330,16 +347,21 @@ func (db *SQLiteDatabase) NewAdminUser(name, surname, email, password string) er
 	return nil
 }
 
-func (db *SQLiteDatabase) GetUserByEmail(email string, level database.AuthorizationLevel) (*models.UserModel, error) {
+func (db *SQLiteDatabase) GetUserByEmail(ctx context.Context, email string, level database.AuthorizationLevel) (*models.UserModel, error) {
 	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE email = ?`
 
 	switch level {
 	case database.User:
-		query += ` AND is_admin = 0 AND is_author = 0`
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
 	case database.Admin:
-		query += ` AND is_admin = 1`
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
 	case database.Author:
-		query += ` AND is_author = 1`
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
 	}
 
 	var isAdminInt int
@@ -348,7 +370,7 @@ func (db *SQLiteDatabase) GetUserByEmail(email string, level database.Authorizat
 	isAdmin := false
 	isAuthor := false
 
-	row := db.connection.QueryRow(query, email)
+	row := db.connection.QueryRowContext(ctx, query, email)
 	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdminInt, &isAuthorInt, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			// Nothing was found so we can just send back nothing and handle it at the caller
@@ -374,8 +396,8 @@ func (db *SQLiteDatabase) GetUserByEmail(email string, level database.Authorizat
 	return user, nil
 }
 
-func (db *SQLiteDatabase) GetUserByID(id string, level database.AuthorizationLevel) (*models.UserModel, error) {
-	user, err := internal.GetUserByID(db.connection, id, level)
+func (db *SQLiteDatabase) GetUserByID(ctx context.Context, id string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	user, err := internal.GetUserByID(ctx, db.connection, id, level)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to get user by ID (\"%s\") from the database: %s\n", id, err)
 		return nil, err
@@ -384,16 +406,21 @@ func (db *SQLiteDatabase) GetUserByID(id string, level database.AuthorizationLev
 	return user, nil
 }
 
-func (db *SQLiteDatabase) GetUserByToken(token, tokenType string, level database.AuthorizationLevel) (*models.UserModel, error) {
+func (db *SQLiteDatabase) GetUserByToken(ctx context.Context, token, tokenType string, level database.AuthorizationLevel) (*models.UserModel, error) {
 	query := `SELECT users.id, users.name, users.surname, users.slug, users.email, users.password, users.is_admin, users.is_author, users.affiliate_code, users.affiliate_points, users.created_at, users.updated_at FROM tokens JOIN users ON tokens.user_id = users.id WHERE tokens.token = ? AND tokens.token_type = ? AND tokens.valid_until > CURRENT_TIMESTAMP`
 
 	switch level {
 	case database.User:
-		query += ` AND users.is_admin = 0 AND users.is_author = 0`
+		query += ` AND users.is_admin = 0 AND users.is_author = 0 AND users.deleted_at IS NULL`
 	case database.Admin:
-		query += ` AND users.is_admin = 1`
+		query += ` AND users.is_admin = 1 AND users.deleted_at IS NULL`
 	case database.Author:
-		query += ` AND users.is_author = 1`
+		query += ` AND users.is_author = 1 AND users.deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND users.deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND users.deleted_at IS NULL`
 	}
 
 	var isAdminInt int
@@ -402,7 +429,7 @@ func (db *SQLiteDatabase) GetUserByToken(token, tokenType string, level database
 	isAdmin := false
 	isAuthor := false
 
-	row := db.connection.QueryRow(query, token, tokenType)
+	row := db.connection.QueryRowContext(ctx, query, token, tokenType)
 	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdminInt, &isAuthorInt, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			// Nothing was found so we can just send back nothing and handle it at the caller
@@ -428,55 +455,38 @@ func (db *SQLiteDatabase) GetUserByToken(token, tokenType string, level database
 	return user, nil
 }
 
-func (db *SQLiteDatabase) GetUserByAffiliateCode(affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
-	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE affiliate_code = ?`
-
-	switch level {
-	case database.User:
-		query += ` AND is_admin = 0 AND is_author = 0`
-	case database.Admin:
-		query += ` AND is_admin = 1`
-	case database.Author:
-		query += ` AND is_author = 1`
-	}
-
-	user := new(models.UserModel)
-	isAdmin := 0
-	isAuthor := 0
-
-	row := db.connection.QueryRow(query, affiliateCode)
-	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-
+func (db *SQLiteDatabase) GetUserByAffiliateCode(ctx context.Context, affiliateCode string, level database.AuthorizationLevel) (*models.UserModel, error) {
+	user, err := internal.GetUserByAffiliateCode(ctx, db.connection, affiliateCode, level)
+	if err != nil {
 		db.ErrorLog.Printf("Failed to query the database for user with affiliate code (\"%s\"): %s\n", affiliateCode, err)
 		return nil, err
 	}
 
-	user.IsAdmin = isAdmin == 1
-	user.IsAuthor = isAuthor == 1
-
 	return user, nil
 }
 
-func (db *SQLiteDatabase) GetUserBySlug(userSlug string, level database.AuthorizationLevel) (*models.UserModel, error) {
+func (db *SQLiteDatabase) GetUserBySlug(ctx context.Context, userSlug string, level database.AuthorizationLevel) (*models.UserModel, error) {
 	query := `SELECT id, name, surname, slug, email, password, is_admin, is_author, affiliate_code, affiliate_points, created_at, updated_at FROM users WHERE slug = ?`
 
 	switch level {
 	case database.User:
-		query += ` AND is_admin = 0 AND is_author = 0`
+		query += ` AND is_admin = 0 AND is_author = 0 AND deleted_at IS NULL`
 	case database.Admin:
-		query += ` AND is_admin = 1`
+		query += ` AND is_admin = 1 AND deleted_at IS NULL`
 	case database.Author:
-		query += ` AND is_author = 1`
+		query += ` AND is_author = 1 AND deleted_at IS NULL`
+	case database.Deleted:
+		query += ` AND deleted_at IS NOT NULL`
+	case database.IncludeDeleted:
+	default:
+		query += ` AND deleted_at IS NULL`
 	}
 
 	user := new(models.UserModel)
 	isAdmin := 0
 	isAuthor := 0
 
-	row := db.connection.QueryRow(query, userSlug)
+	row := db.connection.QueryRowContext(ctx, query, userSlug)
 	if err := row.Scan(&user.ID, &user.Name, &user.Surname, &user.Slug, &user.Email, &user.Password, &isAdmin, &isAuthor, &user.AffiliateCode, &user.AffiliatePoints, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -492,12 +502,12 @@ func (db *SQLiteDatabase) GetUserBySlug(userSlug string, level database.Authoriz
 	return user, nil
 }
 
-func (db *SQLiteDatabase) UpdateUserName(userId, name, surname string) error {
+func (db *SQLiteDatabase) UpdateUserName(ctx context.Context, userId, name, surname string) error {
 	query := `UPDATE users SET name = ?, surname = ?, slug = ? WHERE id = ?;`
 
 	slug := database.NameSurnameToSlug(name, surname)
 
-	result, err := db.connection.Exec(query, name, surname, slug, userId)
+	result, err := db.connection.ExecContext(ctx, query, name, surname, slug, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") name: %s\n", userId, err)
 		return err
@@ -517,10 +527,10 @@ func (db *SQLiteDatabase) UpdateUserName(userId, name, surname string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UpdateUserEmail(userId, email string) error {
+func (db *SQLiteDatabase) UpdateUserEmail(ctx context.Context, userId, email string) error {
 	query := `UPDATE users SET email = ? WHERE id = ?;`
 
-	result, err := db.connection.Exec(query, email, userId)
+	result, err := db.connection.ExecContext(ctx, query, email, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") email: %s\n", userId, err)
 		return err
@@ -540,10 +550,10 @@ func (db *SQLiteDatabase) UpdateUserEmail(userId, email string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) UpdateUserPassword(userId, password string) error {
+func (db *SQLiteDatabase) UpdateUserPassword(ctx context.Context, userId, password string) error {
 	query := `UPDATE users SET password = ? WHERE id = ?;`
 
-	result, err := db.connection.Exec(query, password, userId)
+	result, err := db.connection.ExecContext(ctx, query, password, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") password: %s\n", userId, err)
 		return err
@@ -563,12 +573,12 @@ func (db *SQLiteDatabase) UpdateUserPassword(userId, password string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) CountUsers() (uint, error) {
-	query := `SELECT COUNT(id) FROM users;`
+func (db *SQLiteDatabase) CountUsers(ctx context.Context) (uint, error) {
+	query := `SELECT COUNT(id) FROM users WHERE deleted_at IS NULL;`
 
 	var count uint
 
-	row := db.connection.QueryRow(query)
+	row := db.connection.QueryRowContext(ctx, query)
 	if err := row.Scan(&count); err != nil {
 		if err == sql.ErrNoRows {
 			return 0, nil
@@ -581,10 +591,10 @@ func (db *SQLiteDatabase) CountUsers() (uint, error) {
 	return count, nil
 }
 
-func (db *SQLiteDatabase) AddAuthorStatus(userId string) error {
+func (db *SQLiteDatabase) AddAuthorStatus(ctx context.Context, userId string) error {
 	query := `UPDATE users SET is_author = 1 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, userId)
+	_, err := db.connection.ExecContext(ctx, query, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") author status: %s\n", userId, err)
 		return err
@@ -593,10 +603,10 @@ func (db *SQLiteDatabase) AddAuthorStatus(userId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) RemoveAuthorStatus(userId string) error {
+func (db *SQLiteDatabase) RemoveAuthorStatus(ctx context.Context, userId string) error {
 	query := `UPDATE users SET is_author = 0 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, userId)
+	_, err := db.connection.ExecContext(ctx, query, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") author status: %s\n", userId, err)
 		return err
@@ -605,10 +615,10 @@ func (db *SQLiteDatabase) RemoveAuthorStatus(userId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) AddAdminStatus(userId string) error {
+func (db *SQLiteDatabase) AddAdminStatus(ctx context.Context, userId string) error {
 	query := `UPDATE users SET is_admin = 1 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, userId)
+	_, err := db.connection.ExecContext(ctx, query, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") admin status: %s\n", userId, err)
 		return err
@@ -617,10 +627,10 @@ func (db *SQLiteDatabase) AddAdminStatus(userId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) RemoveAdminStatus(userId string) error {
+func (db *SQLiteDatabase) RemoveAdminStatus(ctx context.Context, userId string) error {
 	query := `UPDATE users SET is_admin = 0 WHERE id = ?;`
 
-	_, err := db.connection.Exec(query, userId)
+	_, err := db.connection.ExecContext(ctx, query, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to update user's (\"%s\") admin status: %s\n", userId, err)
 		return err
@@ -629,14 +639,136 @@ func (db *SQLiteDatabase) RemoveAdminStatus(userId string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) DeleteUser(userId string) error {
-	query := `DELETE FROM users WHERE id = ?;`
+// DeleteUser soft deletes the user's account along with their comments and logs them out everywhere. Their purchases,
+// refunds and certificates are left untouched until the account gets purged.
+func (db *SQLiteDatabase) DeleteUser(ctx context.Context, userId string) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
 
-	_, err := db.connection.Exec(query, userId)
+	result, err := tx.ExecContext(ctx, `UPDATE users SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL;`, userId)
 	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
 		db.ErrorLog.Printf("Failed to delete user's (\"%s\") account: %s\n", userId, err)
 		return err
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after deleting user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after deleting user's (\"%s\") account\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	// The comments get the same timestamp as the account so that restoring the account only brings back the comments
+	// that were removed along with it.
+	if _, err := tx.ExecContext(ctx, `UPDATE comments SET deleted_at = (SELECT deleted_at FROM users WHERE id = ?) WHERE user_id = ? AND deleted_at IS NULL;`, userId, userId); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to delete user's (\"%s\") comments: %s\n", userId, err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tokens WHERE user_id = ?;`, userId); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to delete user's (\"%s\") tokens: %s\n", userId, err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after deleting user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	return nil
+}
+
+// RestoreUser brings back a soft deleted account along with the comments that were deleted with it. Accounts that have
+// already been anonymized can't be restored.
+func (db *SQLiteDatabase) RestoreUser(ctx context.Context, userId string) error {
+	tx, err := db.connection.BeginTx(ctx, nil)
+	if err != nil {
+		db.ErrorLog.Printf("Failed to start new database transaction: %s\n", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE comments SET deleted_at = NULL WHERE user_id = ? AND deleted_at = (SELECT deleted_at FROM users WHERE id = ?);`, userId, userId); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to restore user's (\"%s\") comments: %s\n", userId, err)
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL AND anonymized_at IS NULL;`, userId)
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		// Someone signed up with the account's email address after it was deleted.
+		if sqliteErr, ok := err.(*sqlite.Error); ok && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return database.ErrUserAlreadyExists
+		}
+
+		db.ErrorLog.Printf("Failed to restore user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to get rows affected after restoring user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("0 rows were affected after restoring user's (\"%s\") account\n", userId)
+		return database.ErrNoRowsAffected
+	}
+
+	if err := tx.Commit(); err != nil {
+		if err := tx.Rollback(); err != nil {
+			db.ErrorLog.Printf("Failed to rollback changes after error occurred: %s\n", err)
+		}
+
+		db.ErrorLog.Printf("Failed to commit changes after restoring user's (\"%s\") account: %s\n", userId, err)
+		return err
+	}
+
 	return nil
 }
@@ -1,6 +1,13 @@
 package sqlite_database
 
-import "testing"
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
 
 func TestGetUsers(t *testing.T) {
 	// TODO: Implement.
@@ -79,5 +86,109 @@ func TestRemoveAdminStatus(t *testing.T) {
 }
 
 func TestDeleteUser(t *testing.T) {
-	// TODO: Implement.
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	if err := sqliteDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	// The deleted account mustn't stop the person from signing up again.
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to sign up again with the email of a deleted account: %s", err)
+	}
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); !errors.Is(err, database.ErrUserAlreadyExists) {
+		t.Errorf("Expected a second active account with the same email to be rejected. Got %v", err)
+	}
+}
+
+func TestRestoreUser(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	if err := sqliteDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	if err := sqliteDatabase.RestoreUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to restore user: %s", err)
+	}
+
+	if err := sqliteDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to sign up again with the email of a deleted account: %s", err)
+	}
+
+	if err := sqliteDatabase.RestoreUser(ctx, user.ID); !errors.Is(err, database.ErrUserAlreadyExists) {
+		t.Errorf("Expected restoring an account whose email was taken to fail. Got %v", err)
+	}
+}
+
+func TestDeletedUserLevels(t *testing.T) {
+	ctx := context.Background()
+	sqliteDatabase := newTestDatabase(t)
+
+	if err := sqliteDatabase.NewUser(ctx, "Jane", "Doe", "jane@example.com", "password"); err != nil {
+		t.Fatalf("Failed to create user: %s", err)
+	}
+
+	user, err := sqliteDatabase.GetUserByEmail(ctx, "jane@example.com", database.All)
+	if err != nil || user == nil {
+		t.Fatalf("Failed to get user by email: %v", err)
+	}
+
+	if err := sqliteDatabase.DeleteUser(ctx, user.ID); err != nil {
+		t.Fatalf("Failed to delete user: %s", err)
+	}
+
+	lookups := map[string]func(level database.AuthorizationLevel) (*models.UserModel, error){
+		"ID": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return sqliteDatabase.GetUserByID(ctx, user.ID, level)
+		},
+		"email": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return sqliteDatabase.GetUserByEmail(ctx, user.Email, level)
+		},
+		"slug": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return sqliteDatabase.GetUserBySlug(ctx, user.Slug, level)
+		},
+		"affiliate code": func(level database.AuthorizationLevel) (*models.UserModel, error) {
+			return sqliteDatabase.GetUserByAffiliateCode(ctx, user.AffiliateCode, level)
+		},
+	}
+
+	// Only Deleted and IncludeDeleted may hand back a deleted account, no matter how it is looked up.
+	for name, lookup := range lookups {
+		for _, level := range database.AuthorizationLevelValues() {
+			found, err := lookup(level)
+			if err != nil {
+				t.Fatalf("Failed to get user by %s: %s", name, err)
+			}
+
+			if want := level == database.Deleted || level == database.IncludeDeleted; (found != nil) != want {
+				t.Errorf("Expected looking up a deleted user by %s with level %s to find it: %t. Got %v", name, level, want, found)
+			}
+		}
+	}
 }
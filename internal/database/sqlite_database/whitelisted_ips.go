@@ -1,19 +1,21 @@
 package sqlite_database
 
 import (
+	"context"
+
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database/internal"
 )
 
-func (db *SQLiteDatabase) AddIPAddress(userId, ipAddr string) error {
+func (db *SQLiteDatabase) AddIPAddress(ctx context.Context, userId, ipAddr string) error {
 	id, err := database.GenerateID()
 	if err != nil {
 		db.ErrorLog.Printf("Failed to generate new ID for IP address: %s\n", err)
 		return err
 	}
 
-	err = internal.AddIPAddress(db.connection, id, userId, ipAddr)
+	err = internal.AddIPAddress(ctx, db.connection, id, userId, ipAddr)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to save IP address %s to the database: %s\n", ipAddr, err)
 		return err
@@ -22,12 +24,12 @@ func (db *SQLiteDatabase) AddIPAddress(userId, ipAddr string) error {
 	return nil
 }
 
-func (db *SQLiteDatabase) GetUserIpAddresses(userId string) ([]*models.WhitelistedIPModel, error) {
+func (db *SQLiteDatabase) GetUserIpAddresses(ctx context.Context, userId string) ([]*models.WhitelistedIPModel, error) {
 	query := `SELECT id, user_id, ip_address, created_at FROM whitelisted_ips WHERE user_id = ? ORDER BY created_at DESC;`
 
 	var ipAddresses []*models.WhitelistedIPModel
 
-	rows, err := db.connection.Query(query, userId)
+	rows, err := db.connection.QueryContext(ctx, query, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to query database for all user's (\"%s\") whitelisted IP addresses: %s\n", userId, err)
 		return nil, err
@@ -53,10 +55,10 @@ func (db *SQLiteDatabase) GetUserIpAddresses(userId string) ([]*models.Whitelist
 	return ipAddresses, nil
 }
 
-func (db *SQLiteDatabase) DeleteIPAddress(ipAddrId, userId string) error {
+func (db *SQLiteDatabase) DeleteIPAddress(ctx context.Context, ipAddrId, userId string) error {
 	query := `DELETE FROM whitelisted_ips WHERE id = ? AND user_id = ?;`
 
-	_, err := db.connection.Exec(query, ipAddrId, userId)
+	_, err := db.connection.ExecContext(ctx, query, ipAddrId, userId)
 	if err != nil {
 		db.ErrorLog.Printf("Failed to delete IP address (\"%s\") from database (user id: \"%s\"): %s\n", ipAddrId, userId, err)
 		return err
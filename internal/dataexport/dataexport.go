@@ -0,0 +1,604 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+)
+
+// pageSize is how many rows get requested at a time from the paginated database functions.
+const pageSize = 100
+
+// DataExport gathers everything the platform holds about a user and packs it into a ZIP archive that the user can
+// download.
+type DataExport struct {
+	utils.Loggers
+	Database database.Database
+}
+
+// SetupDataExport creates a new instance of DataExport.
+func SetupDataExport(db database.Database) *DataExport {
+	loggers := utils.CreateLoggers("DATA EXPORT")
+
+	return &DataExport{
+		Loggers:  loggers,
+		Database: db,
+	}
+}
+
+// Profile is the user's account details. The password hash is left out on purpose.
+type Profile struct {
+	ID              string    `json:"id"`
+	Name            string    `json:"name"`
+	Surname         string    `json:"surname"`
+	Slug            string    `json:"slug"`
+	Email           string    `json:"email"`
+	AffiliateCode   string    `json:"affiliate_code"`
+	AffiliatePoints int       `json:"affiliate_points"`
+	IsAdmin         bool      `json:"is_admin"`
+	IsAuthor        bool      `json:"is_author"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+type WhitelistedIP struct {
+	ID        string    `json:"id"`
+	IPAddress string    `json:"ip_address"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Purchase struct {
+	ID                  string    `json:"id"`
+	CourseID            string    `json:"course_id"`
+	CourseTitle         string    `json:"course_title"`
+	AffiliateCode       *string   `json:"affiliate_code"`
+	DiscountCode        *string   `json:"discount_code"`
+	AffiliatePointsUsed uint      `json:"affiliate_points_used"`
+	AmountPaid          float64   `json:"amount_paid"`
+	PaymentStatus       string    `json:"payment_status"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type Refund struct {
+	ID               string    `json:"id"`
+	CoursePurchaseID string    `json:"course_purchase_id"`
+	RefundStatus     string    `json:"refund_status"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type AffiliatePointsChange struct {
+	ID           string    `json:"id"`
+	CourseID     string    `json:"course_id"`
+	PointsChange int       `json:"points_change"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Comment struct {
+	ID            string    `json:"id"`
+	TutorialID    string    `json:"tutorial_id"`
+	TutorialTitle string    `json:"tutorial_title"`
+	Content       string    `json:"content"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Tutorial is a tutorial that the user either liked or bookmarked.
+type Tutorial struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+type ChapterCompletion struct {
+	CourseID     string `json:"course_id"`
+	CourseTitle  string `json:"course_title"`
+	ChapterID    string `json:"chapter_id"`
+	ChapterTitle string `json:"chapter_title"`
+	Chapter      int    `json:"chapter"`
+}
+
+type Certificate struct {
+	ID          string    `json:"id"`
+	CourseID    string    `json:"course_id"`
+	CourseTitle string    `json:"course_title"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// QuizAttempt is an attempt at a chapter's quiz along with the answers that were given.
+type QuizAttempt struct {
+	ID           string       `json:"id"`
+	CourseID     string       `json:"course_id"`
+	CourseTitle  string       `json:"course_title"`
+	ChapterID    string       `json:"chapter_id"`
+	ChapterTitle string       `json:"chapter_title"`
+	Score        int          `json:"score"`
+	Passed       bool         `json:"passed"`
+	Answers      []QuizAnswer `json:"answers"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type QuizAnswer struct {
+	QuestionID string   `json:"question_id"`
+	Question   string   `json:"question"`
+	Answer     []string `json:"answer"`
+	Correct    bool     `json:"correct"`
+}
+
+// ChapterReleaseNotification is an email that told the user that a chapter unlocked for them.
+type ChapterReleaseNotification struct {
+	ID           string    `json:"id"`
+	CourseID     string    `json:"course_id"`
+	CourseTitle  string    `json:"course_title"`
+	ChapterID    string    `json:"chapter_id"`
+	ChapterTitle string    `json:"chapter_title"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AuditLogEntry is a privileged action that the user took.
+type AuditLogEntry struct {
+	ID          string    `json:"id"`
+	Action      string    `json:"action"`
+	TargetID    string    `json:"target_id"`
+	BeforeValue string    `json:"before_value"`
+	AfterValue  string    `json:"after_value"`
+	IPAddress   string    `json:"ip_address"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Export is everything the platform holds about a single user.
+type Export struct {
+	Profile                Profile
+	WhitelistedIPs         []WhitelistedIP
+	Purchases              []Purchase
+	Refunds                []Refund
+	AffiliatePointsHistory []AffiliatePointsChange
+	Comments               []Comment
+	Likes                  []Tutorial
+	Bookmarks              []Tutorial
+	ChapterCompletions     []ChapterCompletion
+	QuizAttempts           []QuizAttempt
+	ChapterReleases        []ChapterReleaseNotification
+	Certificates           []Certificate
+	AuditLog               []AuditLogEntry
+}
+
+// Collect gathers everything the platform holds about the given user.
+func (export *DataExport) Collect(ctx context.Context, user *models.UserModel) (*Export, error) {
+	data := &Export{
+		Profile: Profile{
+			ID:              user.ID,
+			Name:            user.Name,
+			Surname:         user.Surname,
+			Slug:            user.Slug,
+			Email:           user.Email,
+			AffiliateCode:   user.AffiliateCode,
+			AffiliatePoints: user.AffiliatePoints,
+			IsAdmin:         user.IsAdmin,
+			IsAuthor:        user.IsAuthor,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		WhitelistedIPs:         []WhitelistedIP{},
+		Purchases:              []Purchase{},
+		Refunds:                []Refund{},
+		AffiliatePointsHistory: []AffiliatePointsChange{},
+		Comments:               []Comment{},
+		Likes:                  []Tutorial{},
+		Bookmarks:              []Tutorial{},
+		ChapterCompletions:     []ChapterCompletion{},
+		QuizAttempts:           []QuizAttempt{},
+		ChapterReleases:        []ChapterReleaseNotification{},
+		Certificates:           []Certificate{},
+		AuditLog:               []AuditLogEntry{},
+	}
+
+	ipAddresses, err := export.Database.GetUserIpAddresses(ctx, user.ID)
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get user's (\"%s\") whitelisted IP addresses: %s\n", user.ID, err)
+		return nil, err
+	}
+
+	for _, ipAddress := range ipAddresses {
+		data.WhitelistedIPs = append(data.WhitelistedIPs, WhitelistedIP{
+			ID:        ipAddress.ID,
+			IPAddress: ipAddress.IPAddress,
+			CreatedAt: ipAddress.CreatedAt,
+		})
+	}
+
+	courses, err := export.Database.GetAllCourses(ctx, "", nil)
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get all courses: %s\n", err)
+		return nil, err
+	}
+
+	// Chapter release notifications only hold the chapter's ID so the titles get looked up while going through the
+	// courses.
+	type chapterInfo struct {
+		course  *models.CourseModel
+		chapter *models.ChapterModel
+	}
+
+	chapterInfos := make(map[string]chapterInfo)
+
+	for _, course := range courses {
+		purchases, err := export.Database.GetCoursePurchasesByUserAndCourse(ctx, user.ID, course.ID)
+		if err != nil {
+			export.ErrorLog.Printf("Failed to get user's (\"%s\") purchases of course (\"%s\"): %s\n", user.ID, course.ID, err)
+			return nil, err
+		}
+
+		if len(purchases) == 0 {
+			continue
+		}
+
+		for _, purchase := range purchases {
+			data.Purchases = append(data.Purchases, Purchase{
+				ID:                  purchase.ID,
+				CourseID:            course.ID,
+				CourseTitle:         course.Title,
+				AffiliateCode:       nullString(purchase.AffiliateCode),
+				DiscountCode:        nullString(purchase.DiscountCode),
+				AffiliatePointsUsed: purchase.AffiliatePointsUsed,
+				AmountPaid:          purchase.AmountPaid,
+				PaymentStatus:       purchase.PaymentStatus,
+				CreatedAt:           purchase.CreatedAt,
+				UpdatedAt:           purchase.UpdatedAt,
+			})
+
+			refund, err := export.Database.GetRefundWithCoursePurchaseID(ctx, purchase.ID)
+			if err != nil {
+				export.ErrorLog.Printf("Failed to get refund for course purchase (\"%s\"): %s\n", purchase.ID, err)
+				return nil, err
+			}
+
+			if refund != nil {
+				data.Refunds = append(data.Refunds, Refund{
+					ID:               refund.ID,
+					CoursePurchaseID: refund.CoursePurchaseID,
+					RefundStatus:     refund.RefundStatus,
+					CreatedAt:        refund.CreatedAt,
+					UpdatedAt:        refund.UpdatedAt,
+				})
+			}
+		}
+
+		chapters, err := export.Database.GetAllChaptersCompleted(ctx, user.ID, course.ID)
+		if err != nil {
+			export.ErrorLog.Printf("Failed to get chapters of course (\"%s\") completed by user (\"%s\"): %s\n", course.ID, user.ID, err)
+			return nil, err
+		}
+
+		for _, chapter := range chapters {
+			data.ChapterCompletions = append(data.ChapterCompletions, ChapterCompletion{
+				CourseID:     course.ID,
+				CourseTitle:  course.Title,
+				ChapterID:    chapter.ID,
+				ChapterTitle: chapter.Title,
+				Chapter:      chapter.Chapter,
+			})
+		}
+
+		courseChapters, err := export.Database.GetCourseChapters(ctx, course.ID)
+		if err != nil {
+			export.ErrorLog.Printf("Failed to get chapters of course (\"%s\"): %s\n", course.ID, err)
+			return nil, err
+		}
+
+		for _, chapter := range courseChapters {
+			chapterInfos[chapter.ID] = chapterInfo{course: course, chapter: chapter}
+
+			attempts, err := export.quizAttempts(ctx, user, course, chapter)
+			if err != nil {
+				return nil, err
+			}
+
+			data.QuizAttempts = append(data.QuizAttempts, attempts...)
+		}
+
+		certificate, err := export.Database.GetCertificateFromUserAndCourse(ctx, user.ID, course.ID)
+		if err != nil {
+			export.ErrorLog.Printf("Failed to get user's (\"%s\") certificate for course (\"%s\"): %s\n", user.ID, course.ID, err)
+			return nil, err
+		}
+
+		if certificate != nil {
+			data.Certificates = append(data.Certificates, Certificate{
+				ID:          certificate.ID,
+				CourseID:    course.ID,
+				CourseTitle: course.Title,
+				CreatedAt:   certificate.CreatedAt,
+			})
+		}
+	}
+
+	notifications, err := export.Database.GetUserChapterReleaseNotifications(ctx, user.ID)
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get user's (\"%s\") chapter release notifications: %s\n", user.ID, err)
+		return nil, err
+	}
+
+	for _, notification := range notifications {
+		release := ChapterReleaseNotification{
+			ID:        notification.ID,
+			ChapterID: notification.ChapterID,
+			CreatedAt: notification.CreatedAt,
+		}
+
+		if info, has := chapterInfos[notification.ChapterID]; has {
+			release.CourseID = info.course.ID
+			release.CourseTitle = info.course.Title
+			release.ChapterTitle = info.chapter.Title
+		}
+
+		data.ChapterReleases = append(data.ChapterReleases, release)
+	}
+
+	history, err := collect(func(cursor *database.Cursor) ([]*models.AffiliatePointsHistoryModel, *database.Cursor, error) {
+		return export.Database.GetUserAffiliatePointsHistory(ctx, user.ID, cursor, pageSize)
+	})
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get user's (\"%s\") affiliate points history: %s\n", user.ID, err)
+		return nil, err
+	}
+
+	for _, change := range history {
+		data.AffiliatePointsHistory = append(data.AffiliatePointsHistory, AffiliatePointsChange{
+			ID:           change.ID,
+			CourseID:     change.CourseID,
+			PointsChange: change.PointsChange,
+			Reason:       change.Reason,
+			CreatedAt:    change.CreatedAt,
+		})
+	}
+
+	comments, err := collect(func(cursor *database.Cursor) ([]*models.CommentModel, *database.Cursor, error) {
+		return export.Database.AdminGetComments(ctx, "", "", user.ID, false, cursor, pageSize)
+	})
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get user's (\"%s\") comments: %s\n", user.ID, err)
+		return nil, err
+	}
+
+	tutorialTitles := make(map[string]string)
+
+	for _, comment := range comments {
+		title, has := tutorialTitles[comment.TutorialID]
+		if !has {
+			tutorial, err := export.Database.GetTutorialByID(ctx, comment.TutorialID)
+			if err != nil {
+				export.ErrorLog.Printf("Failed to get tutorial (\"%s\") by ID: %s\n", comment.TutorialID, err)
+				return nil, err
+			}
+
+			if tutorial != nil {
+				title = tutorial.Title
+			}
+
+			tutorialTitles[comment.TutorialID] = title
+		}
+
+		data.Comments = append(data.Comments, Comment{
+			ID:            comment.ID,
+			TutorialID:    comment.TutorialID,
+			TutorialTitle: title,
+			Content:       comment.Content,
+			CreatedAt:     comment.CreatedAt,
+		})
+	}
+
+	likes, err := collect(func(cursor *database.Cursor) ([]*models.TutorialModel, *database.Cursor, error) {
+		return export.Database.GetTutorialsLikedByUser(ctx, "", user.ID, cursor, pageSize)
+	})
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get tutorials liked by user (\"%s\"): %s\n", user.ID, err)
+		return nil, err
+	}
+
+	data.Likes = append(data.Likes, tutorials(likes)...)
+
+	bookmarks, err := collect(func(cursor *database.Cursor) ([]*models.TutorialModel, *database.Cursor, error) {
+		return export.Database.GetTutorialsBookmarkedByUser(ctx, "", user.ID, cursor, pageSize)
+	})
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get tutorials bookmarked by user (\"%s\"): %s\n", user.ID, err)
+		return nil, err
+	}
+
+	data.Bookmarks = append(data.Bookmarks, tutorials(bookmarks)...)
+
+	auditLogs, err := collect(func(cursor *database.Cursor) ([]*models.AuditLogModel, *database.Cursor, error) {
+		return export.Database.AdminGetAuditLogs(ctx, "", "", user.ID, cursor, pageSize)
+	})
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get user's (\"%s\") audit log entries: %s\n", user.ID, err)
+		return nil, err
+	}
+
+	for _, entry := range auditLogs {
+		data.AuditLog = append(data.AuditLog, AuditLogEntry{
+			ID:          entry.ID,
+			Action:      entry.Action,
+			TargetID:    entry.TargetID,
+			BeforeValue: entry.BeforeValue,
+			AfterValue:  entry.AfterValue,
+			IPAddress:   entry.IPAddress,
+			CreatedAt:   entry.CreatedAt,
+		})
+	}
+
+	return data, nil
+}
+
+// quizAttempts gets the user's attempts at the chapter's quiz along with the answers that were given in each attempt.
+func (export *DataExport) quizAttempts(ctx context.Context, user *models.UserModel, course *models.CourseModel, chapter *models.ChapterModel) ([]QuizAttempt, error) {
+	quiz, err := export.Database.GetQuizByChapterID(ctx, chapter.ID)
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get quiz of chapter (\"%s\"): %s\n", chapter.ID, err)
+		return nil, err
+	}
+
+	if quiz == nil {
+		return nil, nil
+	}
+
+	questions := make(map[string]string)
+	for _, question := range quiz.Questions {
+		questions[question.ID] = question.Question
+	}
+
+	attempts, err := export.Database.GetQuizAttempts(ctx, user.ID, quiz.ID)
+	if err != nil {
+		export.ErrorLog.Printf("Failed to get user's (\"%s\") attempts at quiz (\"%s\"): %s\n", user.ID, quiz.ID, err)
+		return nil, err
+	}
+
+	var exported []QuizAttempt
+
+	for _, attempt := range attempts {
+		answers, err := export.Database.GetQuizAttemptAnswers(ctx, attempt.ID)
+		if err != nil {
+			export.ErrorLog.Printf("Failed to get answers of quiz attempt (\"%s\"): %s\n", attempt.ID, err)
+			return nil, err
+		}
+
+		exportedAttempt := QuizAttempt{
+			ID:           attempt.ID,
+			CourseID:     course.ID,
+			CourseTitle:  course.Title,
+			ChapterID:    chapter.ID,
+			ChapterTitle: chapter.Title,
+			Score:        attempt.Score,
+			Passed:       attempt.Passed,
+			Answers:      []QuizAnswer{},
+			CreatedAt:    attempt.CreatedAt,
+		}
+
+		for _, answer := range answers {
+			exportedAttempt.Answers = append(exportedAttempt.Answers, QuizAnswer{
+				QuestionID: answer.QuestionID,
+				Question:   questions[answer.QuestionID],
+				Answer:     append([]string{}, answer.Answer...),
+				Correct:    answer.Correct,
+			})
+		}
+
+		exported = append(exported, exportedAttempt)
+	}
+
+	return exported, nil
+}
+
+// WriteArchive collects everything the platform holds about the given user and writes it to w as a ZIP archive with
+// one JSON file per kind of data.
+func (export *DataExport) WriteArchive(ctx context.Context, w io.Writer, user *models.UserModel) error {
+	data, err := export.Collect(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", data.Profile},
+		{"whitelisted_ips.json", data.WhitelistedIPs},
+		{"purchases.json", data.Purchases},
+		{"refunds.json", data.Refunds},
+		{"affiliate_points_history.json", data.AffiliatePointsHistory},
+		{"comments.json", data.Comments},
+		{"likes.json", data.Likes},
+		{"bookmarks.json", data.Bookmarks},
+		{"chapter_completions.json", data.ChapterCompletions},
+		{"quiz_attempts.json", data.QuizAttempts},
+		{"chapter_release_notifications.json", data.ChapterReleases},
+		{"certificates.json", data.Certificates},
+		{"audit_log.json", data.AuditLog},
+	}
+
+	archive := zip.NewWriter(w)
+
+	for _, file := range files {
+		contents, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			export.ErrorLog.Printf("Failed to marshal %s: %s\n", file.name, err)
+			return err
+		}
+
+		fileWriter, err := archive.Create(file.name)
+		if err != nil {
+			export.ErrorLog.Printf("Failed to add %s to the archive: %s\n", file.name, err)
+			return err
+		}
+
+		if _, err := fileWriter.Write(contents); err != nil {
+			export.ErrorLog.Printf("Failed to write %s to the archive: %s\n", file.name, err)
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		export.ErrorLog.Printf("Failed to finish the archive: %s\n", err)
+		return err
+	}
+
+	return nil
+}
+
+// FileName returns the name that the archive should be downloaded as.
+func FileName(date time.Time) string {
+	return fmt.Sprintf("psionicalch-data-%s.zip", date.Format("2006-01-02"))
+}
+
+// collect keeps requesting pages until there aren't any left and returns every row.
+func collect[T any](fetch func(cursor *database.Cursor) ([]T, *database.Cursor, error)) ([]T, error) {
+	var all []T
+	var cursor *database.Cursor
+
+	for {
+		rows, next, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		all = append(all, rows...)
+
+		if next == nil {
+			return all, nil
+		}
+
+		cursor = next
+	}
+}
+
+func tutorials(rows []*models.TutorialModel) []Tutorial {
+	var exported []Tutorial
+
+	for _, tutorial := range rows {
+		exported = append(exported, Tutorial{
+			ID:    tutorial.ID,
+			Title: tutorial.Title,
+			Slug:  tutorial.Slug,
+		})
+	}
+
+	return exported
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+
+	return &s.String
+}
@@ -0,0 +1,107 @@
+package dataexport
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func TestSetupDataExport(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.NewDatabase()
+
+	user := databasetest.NewUserBuilder().AsAdmin().Build()
+	other := databasetest.NewUserBuilder().Build()
+	course := databasetest.NewCourseBuilder().Published().Build()
+
+	db.SeedUsers(user, other)
+	db.SeedCourses(course)
+	db.SeedCoursePurchases(
+		databasetest.NewCoursePurchaseBuilder(user.ID, course.ID).Build(),
+		databasetest.NewCoursePurchaseBuilder(other.ID, course.ID).Build(),
+	)
+	db.SeedChapters(&models.ChapterModel{
+		ID:       "chapter",
+		Title:    "Chapter",
+		Slug:     "chapter",
+		Chapter:  1,
+		CourseID: course.ID,
+	})
+	db.SeedQuizzes(&models.QuizModel{
+		ID:        "quiz",
+		ChapterID: "chapter",
+		PassMark:  50,
+		Questions: []*models.QuizQuestionModel{
+			{ID: "question", QuizID: "quiz", Position: 1, Kind: models.ShortTextQuestion, Question: "Question?", Answers: models.QuizAnswers{"go"}},
+		},
+	})
+
+	for _, userId := range []string{user.ID, other.ID} {
+		if err := db.AddQuizAttempt(ctx, userId, "quiz", 100, true, []*models.QuizAttemptAnswerModel{{QuestionID: "question", Answer: models.QuizAnswers{"Go"}, Correct: true}}); err != nil {
+			t.Fatalf("Failed to add quiz attempt: %s", err)
+		}
+
+		if err := db.AddChapterReleaseNotification(ctx, userId, "chapter"); err != nil {
+			t.Fatalf("Failed to add chapter release notification: %s", err)
+		}
+	}
+
+	if err := db.AddAuditLog(ctx, user.ID, database.AuditAddAdminStatus, other.ID, "false", "true", "127.0.0.1"); err != nil {
+		t.Fatalf("Failed to add audit log entry: %s", err)
+	}
+
+	// Entries where someone else acted on the user belong to that person.
+	if err := db.AddAuditLog(ctx, other.ID, database.AuditAddAdminStatus, user.ID, "false", "true", "10.0.0.1"); err != nil {
+		t.Fatalf("Failed to add audit log entry: %s", err)
+	}
+
+	data, err := SetupDataExport(db).Collect(ctx, user)
+	if err != nil {
+		t.Fatalf("Failed to collect user's data: %s", err)
+	}
+
+	if len(data.QuizAttempts) != 1 {
+		t.Fatalf("Expected 1 quiz attempt. Got %d", len(data.QuizAttempts))
+	}
+
+	attempt := data.QuizAttempts[0]
+	if attempt.CourseID != course.ID || attempt.ChapterTitle != "Chapter" || attempt.Score != 100 || !attempt.Passed {
+		t.Errorf("Expected the attempt at the chapter's quiz. Got %+v", attempt)
+	}
+
+	if len(attempt.Answers) != 1 || attempt.Answers[0].Question != "Question?" || !slices.Equal(attempt.Answers[0].Answer, []string{"Go"}) || !attempt.Answers[0].Correct {
+		t.Errorf("Expected the answer that was given to be exported. Got %+v", attempt.Answers)
+	}
+
+	if len(data.ChapterReleases) != 1 {
+		t.Fatalf("Expected 1 chapter release notification. Got %d", len(data.ChapterReleases))
+	}
+
+	if release := data.ChapterReleases[0]; release.ChapterID != "chapter" || release.CourseTitle != course.Title || release.ChapterTitle != "Chapter" {
+		t.Errorf("Expected the notification about the chapter. Got %+v", release)
+	}
+
+	if len(data.AuditLog) != 1 {
+		t.Fatalf("Expected 1 audit log entry. Got %d", len(data.AuditLog))
+	}
+
+	if entry := data.AuditLog[0]; entry.TargetID != other.ID || entry.IPAddress != "127.0.0.1" || entry.Action != database.AuditAddAdminStatus.String() {
+		t.Errorf("Expected the entry for the action that the user took. Got %+v", entry)
+	}
+}
+
+func TestWriteArchive(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestFileName(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,124 @@
+package maintenance
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+)
+
+// RunTimeout is the maximum amount of time a single maintenance run is allowed to take.
+const RunTimeout = 10 * time.Minute
+
+// Maintenance periodically cleans up the database while the web server is running.
+type Maintenance struct {
+	utils.Loggers
+	Database        database.Database
+	Interval        time.Duration
+	PurchaseWindow  time.Duration
+	RetentionPeriod time.Duration
+}
+
+// Summary describes what happened during a single maintenance run.
+type Summary struct {
+	TokensPurged       uint
+	PurchasesCancelled uint
+	DeletedPurged      uint
+	Optimized          bool
+	Duration           time.Duration
+	Errors             []error
+}
+
+// SetupMaintenance creates a new instance of Maintenance. The interval controls how often the maintenance runs, the
+// purchase window controls how long a course purchase may stay "Pending" before it gets cancelled and the retention
+// period controls how long soft deleted content is kept around before it gets purged. A retention period of 0 keeps
+// soft deleted content forever.
+func SetupMaintenance(db database.Database, interval, purchaseWindow, retentionPeriod time.Duration) *Maintenance {
+	loggers := utils.CreateLoggers("MAINTENANCE")
+
+	return &Maintenance{
+		Loggers:         loggers,
+		Database:        db,
+		Interval:        interval,
+		PurchaseWindow:  purchaseWindow,
+		RetentionPeriod: retentionPeriod,
+	}
+}
+
+// Start runs the maintenance once straight away and then once every interval in a background goroutine. The
+// goroutine stops when the given context gets cancelled and is tracked by the wait group so that the caller can wait
+// for a run that is still busy to finish. Nothing gets started when the interval isn't greater than 0.
+func (m *Maintenance) Start(ctx context.Context, wg *sync.WaitGroup) {
+	if m.Interval <= 0 {
+		m.ErrorLog.Printf("Not starting maintenance because the interval (%s) has to be greater than 0\n", m.Interval)
+		return
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(m.Interval)
+		defer ticker.Stop()
+
+		for {
+			m.Run(ctx)
+
+			select {
+			case <-ctx.Done():
+				m.InfoLog.Println("Stopping maintenance")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Run runs every maintenance task once and logs a summary of what was done. A task failing doesn't stop the tasks
+// after it from running.
+func (m *Maintenance) Run(ctx context.Context) *Summary {
+	ctx, cancel := context.WithTimeout(ctx, RunTimeout)
+	defer cancel()
+
+	start := time.Now()
+	summary := new(Summary)
+
+	tokensPurged, err := m.Database.DeleteExpiredTokens(ctx)
+	if err != nil {
+		m.ErrorLog.Printf("Failed to purge expired tokens: %s\n", err)
+		summary.Errors = append(summary.Errors, err)
+	}
+	summary.TokensPurged = tokensPurged
+
+	purchasesCancelled, err := m.Database.CancelStalePurchases(ctx, m.PurchaseWindow)
+	if err != nil {
+		m.ErrorLog.Printf("Failed to cancel stale course purchases: %s\n", err)
+		summary.Errors = append(summary.Errors, err)
+	}
+	summary.PurchasesCancelled = purchasesCancelled
+
+	if m.RetentionPeriod > 0 {
+		deletedPurged, err := m.Database.PurgeDeleted(ctx, m.RetentionPeriod)
+		if err != nil {
+			m.ErrorLog.Printf("Failed to purge soft deleted content: %s\n", err)
+			summary.Errors = append(summary.Errors, err)
+		}
+		summary.DeletedPurged = deletedPurged
+	}
+
+	if err := m.Database.Optimize(ctx); err != nil {
+		m.ErrorLog.Printf("Failed to optimize the database: %s\n", err)
+		summary.Errors = append(summary.Errors, err)
+	} else {
+		summary.Optimized = true
+	}
+
+	summary.Duration = time.Since(start)
+
+	m.InfoLog.Printf("Maintenance finished in %s: %d expired tokens purged, %d stale purchases cancelled, %d deleted rows purged, optimized: %t, errors: %d\n", summary.Duration, summary.TokensPurged, summary.PurchasesCancelled, summary.DeletedPurged, summary.Optimized, len(summary.Errors))
+
+	return summary
+}
@@ -0,0 +1,149 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func TestSetupMaintenance(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestStart(t *testing.T) {
+	db, user, purchase := setupStalePurchase(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	SetupMaintenance(db, 0, 24*time.Hour, 0).Start(ctx, &wg)
+
+	// An interval that isn't greater than 0 would make the ticker panic so nothing should have been started.
+	assertPurchase(t, db, user, purchase, database.Pending, 0)
+
+	SetupMaintenance(db, time.Hour, 24*time.Hour, 0).Start(ctx, &wg)
+
+	// The first run happens straight away in the background.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		coursePurchase, err := db.GetCoursePurchaseByID(ctx, purchase.ID)
+		if err != nil {
+			t.Fatalf("Failed to get course purchase: %s", err)
+		}
+
+		if coursePurchase.PaymentStatus != database.Pending.String() {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the maintenance to run once straight away")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assertPurchase(t, db, user, purchase, database.Cancelled, 50)
+
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the maintenance to stop once the context was cancelled")
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	db, user, purchase := setupStalePurchase(t)
+
+	// A task failing shouldn't stop the tasks after it from running.
+	db.FailOn("DeleteExpiredTokens", errors.New("database is locked"))
+
+	maintenance := SetupMaintenance(db, time.Hour, 24*time.Hour, 0)
+
+	summary := maintenance.Run(ctx)
+	if summary.PurchasesCancelled != 1 {
+		t.Errorf("Expected 1 stale purchase to be cancelled. Got %d", summary.PurchasesCancelled)
+	}
+
+	if len(summary.Errors) != 1 {
+		t.Errorf("Expected 1 error to be reported. Got %d", len(summary.Errors))
+	}
+
+	db.ClearErrors()
+
+	summary = maintenance.Run(ctx)
+	if summary.PurchasesCancelled != 0 || len(summary.Errors) != 0 {
+		t.Errorf("Expected a second run to cancel nothing. Got %d cancelled purchases and %d errors", summary.PurchasesCancelled, len(summary.Errors))
+	}
+
+	// The affiliate points should only have been given back once.
+	assertPurchase(t, db, user, purchase, database.Cancelled, 50)
+}
+
+// setupStalePurchase creates a database with a "Pending" purchase that is two days old and used 50 affiliate points
+// along with a purchase that was only just made.
+func setupStalePurchase(t *testing.T) (*databasetest.Database, *models.UserModel, *models.CoursePurchaseModel) {
+	t.Helper()
+
+	db := databasetest.NewDatabase()
+	user := databasetest.NewUserBuilder().Build()
+	course := databasetest.NewCourseBuilder().Published().Build()
+	otherCourse := databasetest.NewCourseBuilder().Published().Build()
+
+	stale := databasetest.NewCoursePurchaseBuilder(user.ID, course.ID).
+		WithStatus(database.Pending).
+		WithAffiliatePointsUsed(50).
+		CreatedAt(time.Now().Add(-48 * time.Hour)).
+		Build()
+
+	fresh := databasetest.NewCoursePurchaseBuilder(user.ID, otherCourse.ID).
+		WithStatus(database.Pending).
+		Build()
+
+	db.SeedUsers(user)
+	db.SeedCourses(course, otherCourse)
+	db.SeedCoursePurchases(stale, fresh)
+
+	return db, user, stale
+}
+
+func assertPurchase(t *testing.T, db *databasetest.Database, user *models.UserModel, purchase *models.CoursePurchaseModel, status database.PaymentStatus, refunded int) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	coursePurchase, err := db.GetCoursePurchaseByID(ctx, purchase.ID)
+	if err != nil {
+		t.Fatalf("Failed to get course purchase: %s", err)
+	}
+
+	if coursePurchase.PaymentStatus != status.String() {
+		t.Errorf("Expected payment status to be %q. Got %q", status, coursePurchase.PaymentStatus)
+	}
+
+	user, err = db.GetUserByID(ctx, user.ID, database.All)
+	if err != nil {
+		t.Fatalf("Failed to get user: %s", err)
+	}
+
+	if user.AffiliatePoints != refunded {
+		t.Errorf("Expected %d affiliate points to be given back. Got %d", refunded, user.AffiliatePoints)
+	}
+}
@@ -1,6 +1,7 @@
 package payments
 
 import (
+	"context"
 	"time"
 
 	"math/rand"
@@ -19,20 +20,20 @@ const AffiliateCodeDiscount float64 = 10.0 / 100.0
 const AffiliatePointDiscount float64 = 1.0 / 100.0
 
 // CalculatePrice will determine the cost of the course in cents. It takes into account the discounts when determining the price.
-func (payment *Payments) CalculatePrice(userId, affiliateCode, discountCode string, affiliatePointsUsed uint) (int64, error) {
-	affiliateCodeDiscount, err := payment.ValidateAffiliateCode(userId, affiliateCode)
+func (payment *Payments) CalculatePrice(ctx context.Context, userId, affiliateCode, discountCode string, affiliatePointsUsed uint) (int64, error) {
+	affiliateCodeDiscount, err := payment.ValidateAffiliateCode(ctx, userId, affiliateCode)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to validate affiliate code: %s\n", err)
 		return 0, ErrInvalidAffiliateCode
 	}
 
-	discountCodeDiscount, err := payment.ValidateDiscountCode(discountCode)
+	discountCodeDiscount, err := payment.ValidateDiscountCode(ctx, discountCode)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to validate discount code: %s\n", err)
 		return 0, ErrInvalidDiscountCode
 	}
 
-	affiliatePointsDiscount, err := payment.ValidateAffiliatePointsUsed(userId, affiliatePointsUsed)
+	affiliatePointsDiscount, err := payment.ValidateAffiliatePointsUsed(ctx, userId, affiliatePointsUsed)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to validate affiliate points: %s\n", err)
 		return 0, ErrInsufficientAffiliatePoints
@@ -53,12 +54,12 @@ func (payment *Payments) CalculatePrice(userId, affiliateCode, discountCode stri
 }
 
 // ValidateAffiliateCode ensure that the provided affiliate code is allowed to be used.
-func (payment *Payments) ValidateAffiliateCode(userId, affiliateCode string) (float64, error) {
+func (payment *Payments) ValidateAffiliateCode(ctx context.Context, userId, affiliateCode string) (float64, error) {
 	if affiliateCode == "" {
 		return 0, nil
 	}
 
-	user, err := payment.Database.GetUserByID(userId, database.All)
+	user, err := payment.Database.GetUserByID(ctx, userId, database.All)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get user by ID (\"%s\"): %s\n", userId, err)
 		return 0, err
@@ -72,7 +73,7 @@ func (payment *Payments) ValidateAffiliateCode(userId, affiliateCode string) (fl
 		return 0, ErrCantUseOwnAffiliateCode
 	}
 
-	affiliateUser, err := payment.Database.GetUserByAffiliateCode(affiliateCode, database.All)
+	affiliateUser, err := payment.Database.GetUserByAffiliateCode(ctx, affiliateCode, database.All)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get user by affiliate code (\"%s\"): %s\n", affiliateCode, err)
 		return 0, err
@@ -86,12 +87,12 @@ func (payment *Payments) ValidateAffiliateCode(userId, affiliateCode string) (fl
 }
 
 // ValidateDiscountCode ensures the provided discount code is allowed to be used.
-func (payment *Payments) ValidateDiscountCode(discountCode string) (float64, error) {
+func (payment *Payments) ValidateDiscountCode(ctx context.Context, discountCode string) (float64, error) {
 	if discountCode == "" {
 		return 0, nil
 	}
 
-	discount, err := payment.Database.GetDiscountByCode(discountCode)
+	discount, err := payment.Database.GetDiscountByCode(ctx, discountCode)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get discount from the database: %s\n", err)
 		return 0, err
@@ -105,7 +106,7 @@ func (payment *Payments) ValidateDiscountCode(discountCode string) (float64, err
 		return 0, ErrInvalidDiscountCode
 	}
 
-	discountUsed, err := payment.Database.CountCoursesWhereDiscountWasUsed(discountCode)
+	discountUsed, err := payment.Database.CountCoursesWhereDiscountWasUsed(ctx, discountCode)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to count the number of courses bought with discount code (\"%s\"): %s\n", discountCode, err)
 		return 0, err
@@ -119,13 +120,17 @@ func (payment *Payments) ValidateDiscountCode(discountCode string) (float64, err
 }
 
 // ValidateAffiliatePointsUsed ensures that the user is allowed to use the provided amount of affiliate points.
-func (payment *Payments) ValidateAffiliatePointsUsed(userId string, affiliatePointsUsed uint) (float64, error) {
-	user, err := payment.Database.GetUserByID(userId, database.All)
+func (payment *Payments) ValidateAffiliatePointsUsed(ctx context.Context, userId string, affiliatePointsUsed uint) (float64, error) {
+	user, err := payment.Database.GetUserByID(ctx, userId, database.All)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get user by ID (\"%s\"): %s\n", userId, err)
 		return 0, err
 	}
 
+	if user == nil {
+		return 0, ErrUserDoesNotExist
+	}
+
 	if user.AffiliatePoints < int(affiliatePointsUsed) {
 		return 0, ErrInsufficientAffiliatePoints
 	}
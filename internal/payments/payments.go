@@ -1,6 +1,7 @@
 package payments
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -34,19 +35,40 @@ func SetupPayments(privateKey, webhookSecret string, db database.Database, maile
 }
 
 // CreateDiscount adds a new discount to the database and returns an instance of the newly created discount.
-func (payment *Payments) CreateDiscount(title, description string, discountAmount, uses uint64) (*models.DiscountModel, error) {
-	discountId, err := payment.Database.AddDiscount(title, description, discountAmount, uses)
+func (payment *Payments) CreateDiscount(ctx context.Context, title, description string, discountAmount, uses uint64) (*models.DiscountModel, error) {
+	var discount *models.DiscountModel
+
+	err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+		d, err := payment.createDiscount(ctx, tx, title, description, discountAmount, uses)
+		if err != nil {
+			return err
+		}
+
+		discount = d
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return discount, nil
+}
+
+// createDiscount adds and activates a new discount as part of the given unit of work.
+func (payment *Payments) createDiscount(ctx context.Context, tx database.Tx, title, description string, discountAmount, uses uint64) (*models.DiscountModel, error) {
+	discountId, err := tx.AddDiscount(ctx, title, description, discountAmount, uses)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to create new discount: %s\n", err)
 		return nil, err
 	}
 
-	if err := payment.Database.ActivateDiscount(discountId); err != nil {
+	if err := tx.ActivateDiscount(ctx, discountId); err != nil {
 		payment.ErrorLog.Printf("Failed to activate new discount: %s\n", err)
 		return nil, err
 	}
 
-	discount, err := payment.Database.GetDiscountByID(discountId)
+	discount, err := tx.GetDiscountByID(ctx, discountId)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get discount by ID: %s\n", err)
 		return nil, err
@@ -56,7 +78,7 @@ func (payment *Payments) CreateDiscount(title, description string, discountAmoun
 }
 
 // BuyCourse registers a course purchase in the database and constructs a Stripe Checkout Session to handle the receiving the funds.
-func (payment *Payments) BuyCourse(user *models.UserModel, course *models.CourseModel, successUrl, cancelUrl, affiliateCode, discountCode string, affiliatePointsUsed uint, amountPaid int64) (string, error) {
+func (payment *Payments) BuyCourse(ctx context.Context, user *models.UserModel, course *models.CourseModel, successUrl, cancelUrl, affiliateCode, discountCode string, affiliatePointsUsed uint, amountPaid int64) (string, error) {
 	paymentKey, err := GeneratePaymentKey()
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to generate payment key: %s\n", err)
@@ -73,46 +95,58 @@ func (payment *Payments) BuyCourse(user *models.UserModel, course *models.Course
 		ac := database.NewNullString(affiliateCode)
 		dc := database.NewNullString(discountCode)
 
-		if err := payment.Database.RegisterCoursePurchase(user.ID, course.ID, paymentKey, "", ac, dc, affiliatePointsUsed, float64(amountPaid)/100.0, "", PaymentToken, time.Now().Add(time.Hour)); err != nil {
-			payment.ErrorLog.Printf("Failed register course purchase in the database: %s\n", err)
-			return "", err
-		}
+		var discount *models.DiscountModel
 
-		coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
-			return "", err
-		}
+		// A free purchase never goes through Stripe so everything that the webhook would normally do has to happen
+		// here. All of it happens as one unit of work so that a failure halfway through doesn't leave the user with a
+		// purchase that never got marked as succeeded.
+		err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+			if err := tx.RegisterCoursePurchase(ctx, user.ID, course.ID, paymentKey, "", ac, dc, affiliatePointsUsed, float64(amountPaid)/100.0, "", PaymentToken, time.Now().Add(time.Hour)); err != nil {
+				payment.ErrorLog.Printf("Failed register course purchase in the database: %s\n", err)
+				return err
+			}
 
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Succeeded); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
-			return "", err
-		}
+			coursePurchase, err := tx.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+				return err
+			}
 
-		redirectURL := fmt.Sprintf("/profile/courses/%s", course.Slug)
+			if err := tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.Succeeded); err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
+				return err
+			}
 
-		if ac.Valid {
-			affiliateUser, err := payment.Database.GetUserByAffiliateCode(coursePurchase.AffiliateCode.String, database.All)
-			if err != nil {
-				payment.ErrorLog.Printf("Failed to get user by affiliate code (\"%s\"): %s\n", coursePurchase.AffiliateCode.String, err)
-				return redirectURL, nil
+			if ac.Valid {
+				affiliateUser, err := tx.GetUserByAffiliateCode(ctx, coursePurchase.AffiliateCode.String, database.All)
+				if err != nil {
+					payment.ErrorLog.Printf("Failed to get user by affiliate code (\"%s\"): %s\n", coursePurchase.AffiliateCode.String, err)
+					return err
+				}
+
+				if affiliateUser != nil {
+					if err := tx.RegisterAffiliatePointsChange(ctx, affiliateUser.ID, coursePurchase.CourseID, AffiliateReward, "Affiliate reward received"); err != nil {
+						payment.ErrorLog.Printf("Failed to reward user (\"%s\") with affiliate points: %s\n", affiliateUser.ID, err)
+						return err
+					}
+				}
 			}
 
-			if err := payment.Database.RegisterAffiliatePointsChange(affiliateUser.ID, coursePurchase.CourseID, AffiliateReward, "Affiliate reward received"); err != nil {
-				payment.ErrorLog.Printf("Failed to reward user (\"%s\") with affiliate points: %s\n", affiliateUser.ID, err)
-				return redirectURL, nil
+			discount, err = payment.createDiscount(ctx, tx, fmt.Sprintf("Thank You Gift To %s %s", user.Name, user.Surname), "A gift to thank the user for buying a course from us", 20, 1)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to create new discount: %s\n", err)
+				return err
 			}
-		}
 
-		discount, err := payment.CreateDiscount(fmt.Sprintf("Thank You Gift To %s %s", user.Name, user.Surname), "A gift to thank the user for buying a course from us", 20, 1)
+			return nil
+		})
 		if err != nil {
-			payment.ErrorLog.Printf("Failed to create new discount: %s\n", err)
-			return redirectURL, nil
+			return "", err
 		}
 
 		go payment.Mailer.SendThankYouForPurchaseEmail(user.Email, user.Name, user.AffiliateCode, course, discount)
 
-		return redirectURL, nil
+		return fmt.Sprintf("/profile/courses/%s", course.Slug), nil
 	}
 
 	metaData := map[string]string{
@@ -157,7 +191,7 @@ func (payment *Payments) BuyCourse(user *models.UserModel, course *models.Course
 	ac := database.NewNullString(affiliateCode)
 	dc := database.NewNullString(discountCode)
 
-	if err := payment.Database.RegisterCoursePurchase(user.ID, course.ID, paymentKey, s.ID, ac, dc, affiliatePointsUsed, float64(amountPaid)/100.0, paymentToken, PaymentToken, time.Now().Add(time.Hour)); err != nil {
+	if err := payment.Database.RegisterCoursePurchase(ctx, user.ID, course.ID, paymentKey, s.ID, ac, dc, affiliatePointsUsed, float64(amountPaid)/100.0, paymentToken, PaymentToken, time.Now().Add(time.Hour)); err != nil {
 		payment.ErrorLog.Printf("Failed to save stripe checkout information to the database: %s\n", err)
 		return "", err
 	}
@@ -166,8 +200,8 @@ func (payment *Payments) BuyCourse(user *models.UserModel, course *models.Course
 }
 
 // RequestRefund registers a refund in the database and initializes a refund on Stripe's side.
-func (payment *Payments) RequestRefund(user *models.UserModel, course *models.CourseModel) error {
-	coursePurchases, err := payment.Database.GetCoursePurchasesByUserAndCourse(user.ID, course.ID)
+func (payment *Payments) RequestRefund(ctx context.Context, user *models.UserModel, course *models.CourseModel) error {
+	coursePurchases, err := payment.Database.GetCoursePurchasesByUserAndCourse(ctx, user.ID, course.ID)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get course purchase for user (\"%s\") and course (\"%s\"): %s\n", user.ID, course.ID, err)
 		return err
@@ -184,13 +218,7 @@ func (payment *Payments) RequestRefund(user *models.UserModel, course *models.Co
 	coursePurchase := coursePurchases[index]
 
 	if coursePurchase.AmountPaid == 0.0 {
-		if err := payment.Database.RegisterRefund(coursePurchase.UserID, coursePurchase.ID, database.RefundSucceeded); err != nil {
-			payment.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
-			return err
-		}
-
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Refunded); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") status to refunded: %s\n", coursePurchase.ID, err)
+		if err := payment.registerRefund(ctx, coursePurchase, database.RefundSucceeded); err != nil {
 			return err
 		}
 
@@ -222,14 +250,26 @@ func (payment *Payments) RequestRefund(user *models.UserModel, course *models.Co
 		return err
 	}
 
-	if err := payment.Database.RegisterRefund(coursePurchase.UserID, coursePurchase.ID, database.RefundPending); err != nil {
-		payment.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
+	if err := payment.registerRefund(ctx, coursePurchase, database.RefundPending); err != nil {
 		return err
 	}
 
-	if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Refunded); err != nil {
-		payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") status to refunded: %s\n", coursePurchase.ID, err)
-	}
-
 	return nil
 }
+
+// registerRefund saves the refund and marks the course purchase as refunded as one unit of work.
+func (payment *Payments) registerRefund(ctx context.Context, coursePurchase *models.CoursePurchaseModel, status database.RefundStatus) error {
+	return payment.Database.WithTx(ctx, func(tx database.Tx) error {
+		if err := tx.RegisterRefund(ctx, coursePurchase.UserID, coursePurchase.ID, status); err != nil {
+			payment.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
+			return err
+		}
+
+		if err := tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.Refunded); err != nil {
+			payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") status to refunded: %s\n", coursePurchase.ID, err)
+			return err
+		}
+
+		return nil
+	})
+}
@@ -1,6 +1,7 @@
 package payments
 
 import (
+	"context"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -10,8 +11,8 @@ import (
 const PaymentToken = "payment"
 
 // ValidatePaymentToken ensures that the provided payment token is valid.
-func (payment *Payments) ValidatePaymentToken(token string) bool {
-	paymentToken, err := payment.Database.GetToken(token, PaymentToken)
+func (payment *Payments) ValidatePaymentToken(ctx context.Context, token string) bool {
+	paymentToken, err := payment.Database.GetToken(ctx, token, PaymentToken)
 	if err != nil {
 		payment.ErrorLog.Printf("Failed to get payment token from the database: %s\n", err)
 		return false
@@ -33,11 +34,11 @@ func (payment *Payments) ValidatePaymentToken(token string) bool {
 }
 
 // GetUserFromPaymentToken gets the user from the provided payment token.
-func (payment *Payments) GetUserFromPaymentToken(token string) (*models.UserModel, error) {
-	return payment.Database.GetUserByToken(token, PaymentToken, database.All)
+func (payment *Payments) GetUserFromPaymentToken(ctx context.Context, token string) (*models.UserModel, error) {
+	return payment.Database.GetUserByToken(ctx, token, PaymentToken, database.All)
 }
 
 // DeletePaymentToken deletes the provided payment token from the database.
-func (payment *Payments) DeletePaymentToken(token string) error {
-	return payment.Database.DeleteToken(token, PaymentToken)
+func (payment *Payments) DeletePaymentToken(ctx context.Context, token string) error {
+	return payment.Database.DeleteToken(ctx, token, PaymentToken)
 }
@@ -1,6 +1,7 @@
 package payments
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -32,7 +33,7 @@ func (payment *Payments) Webhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	handlers := map[string]func(event *stripe.Event) error{
+	handlers := map[string]func(ctx context.Context, event *stripe.Event) error{
 		"payment_intent.requires_action": payment.HandlePaymentIntent(database.RequiresAction),
 		"payment_intent.processing":      payment.HandlePaymentIntent(database.Processing),
 		"payment_intent.succeeded":       payment.HandlePaymentSuccess,
@@ -47,7 +48,7 @@ func (payment *Payments) Webhook(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if handler, has := handlers[string(event.Type)]; has {
-		if err := handler(&event); err != nil {
+		if err := handler(r.Context(), &event); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
@@ -58,8 +59,8 @@ func (payment *Payments) Webhook(w http.ResponseWriter, r *http.Request) {
 
 // HandlePaymentIntent handles updating course purchases for "payment_intent.requires_action" and
 // "payment_intent.processing" events.
-func (payment *Payments) HandlePaymentIntent(status database.PaymentStatus) func(event *stripe.Event) error {
-	return func(event *stripe.Event) error {
+func (payment *Payments) HandlePaymentIntent(status database.PaymentStatus) func(ctx context.Context, event *stripe.Event) error {
+	return func(ctx context.Context, event *stripe.Event) error {
 		var intent stripe.PaymentIntent
 		if err := json.Unmarshal(event.Data.Raw, &intent); err != nil {
 			payment.ErrorLog.Printf("Failed to unmarshal payment intent: %s\n", err)
@@ -67,16 +68,28 @@ func (payment *Payments) HandlePaymentIntent(status database.PaymentStatus) func
 		}
 
 		if paymentKey, exists := intent.Metadata["payment_key"]; exists {
-			coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
+			err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+				coursePurchase, err := tx.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+				if err != nil {
+					payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+					return err
+				}
+
+				payment.InfoLog.Println("Found course purchase by payment key")
+
+				changed, err := tx.TransitionCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.OpenPaymentStatuses, status)
+				if err != nil {
+					payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
+					return err
+				}
+
+				if !changed {
+					payment.WarningLog.Printf("Course purchase (\"%s\") was already settled as \"%s\".\n", coursePurchase.ID, coursePurchase.PaymentStatus)
+				}
+
+				return nil
+			})
 			if err != nil {
-				payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
-				return errors.New("unexpected internal server error")
-			}
-
-			payment.InfoLog.Println("Found course purchase by payment key")
-
-			if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, status); err != nil {
-				payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
 				return errors.New("unexpected internal server error")
 			}
 
@@ -90,7 +103,7 @@ func (payment *Payments) HandlePaymentIntent(status database.PaymentStatus) func
 }
 
 // HandlePaymentSuccess handles updating course purchases when payment is successful.
-func (payment *Payments) HandlePaymentSuccess(event *stripe.Event) error {
+func (payment *Payments) HandlePaymentSuccess(ctx context.Context, event *stripe.Event) error {
 	var intent stripe.PaymentIntent
 	if err := json.Unmarshal(event.Data.Raw, &intent); err != nil {
 		payment.ErrorLog.Printf("Failed to unmarshal payment intent: %s\n", err)
@@ -98,45 +111,85 @@ func (payment *Payments) HandlePaymentSuccess(event *stripe.Event) error {
 	}
 
 	if paymentKey, exists := intent.Metadata["payment_key"]; exists {
-		coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
-			return errors.New("unexpected internal server error")
-		}
+		var user *models.UserModel
+		var course *models.CourseModel
+		var discount *models.DiscountModel
+		var alreadySettled bool
 
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Succeeded); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
-		}
+		err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+			coursePurchase, err := tx.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+				return err
+			}
 
-		if coursePurchase.AffiliateCode.Valid {
-			affiliateUser, err := payment.Database.GetUserByAffiliateCode(coursePurchase.AffiliateCode.String, database.All)
+			// Stripe can still take the payment after the purchase was closed as "Failed" or "Cancelled" (for example
+			// when the maintenance job expired it a moment too early). The learner paid so the purchase still goes
+			// through, but the affiliate points that were given back when it was closed need to be used again. The
+			// learner might have spent those points in the meantime, in which case they aren't taken a second time.
+			previousStatus := database.PaymentStatusFromString(coursePurchase.PaymentStatus)
+
+			changed, err := tx.TransitionCoursePurchasePaymentStatus(ctx, coursePurchase.ID, append(slices.Clone(database.OpenPaymentStatuses), database.Failed, database.Cancelled), database.Succeeded)
 			if err != nil {
-				payment.ErrorLog.Printf("Failed to get user by affiliate code (\"%s\"): %s\n", coursePurchase.AffiliateCode.String, err)
-				return errors.New("unexpected internal server error")
+				payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
+				return err
 			}
 
-			if err := payment.Database.RegisterAffiliatePointsChange(affiliateUser.ID, coursePurchase.CourseID, AffiliateReward, "Affiliate reward received"); err != nil {
-				payment.ErrorLog.Printf("Failed to reward user (\"%s\") with affiliate points: %s\n", affiliateUser.ID, err)
-				return errors.New("unexpected internal server error")
+			if !changed {
+				payment.WarningLog.Printf("Course purchase (\"%s\") was already settled as \"%s\".\n", coursePurchase.ID, coursePurchase.PaymentStatus)
+				alreadySettled = true
+				return nil
 			}
-		}
 
-		user, err := payment.Database.GetUserByID(coursePurchase.UserID, database.All)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to get user (\"%s\") from the database: %s\n", coursePurchase.UserID, err)
-			return nil
-		}
+			user, err = tx.GetUserByID(ctx, coursePurchase.UserID, database.IncludeDeleted)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to get user (\"%s\") from the database: %s\n", coursePurchase.UserID, err)
+				return err
+			}
+
+			if (previousStatus == database.Failed || previousStatus == database.Cancelled) && coursePurchase.AffiliatePointsUsed > 0 {
+				if user.AffiliatePoints < int(coursePurchase.AffiliatePointsUsed) {
+					payment.WarningLog.Printf("Course purchase (\"%s\") succeeded after it was closed but user (\"%s\") only has %d of the %d affiliate points it used. Settling it without using them again. Please review.\n", coursePurchase.ID, coursePurchase.UserID, user.AffiliatePoints, coursePurchase.AffiliatePointsUsed)
+				} else if err := tx.RegisterAffiliatePointsChange(ctx, coursePurchase.UserID, coursePurchase.CourseID, -int(coursePurchase.AffiliatePointsUsed), "Payment succeeded after it was closed"); err != nil {
+					payment.ErrorLog.Printf("Failed to use affiliate points again after payment succeeded: %s\n", err)
+					return err
+				}
+			}
+
+			if coursePurchase.AffiliateCode.Valid {
+				affiliateUser, err := tx.GetUserByAffiliateCode(ctx, coursePurchase.AffiliateCode.String, database.All)
+				if err != nil {
+					payment.ErrorLog.Printf("Failed to get user by affiliate code (\"%s\"): %s\n", coursePurchase.AffiliateCode.String, err)
+					return err
+				}
+
+				if affiliateUser != nil {
+					if err := tx.RegisterAffiliatePointsChange(ctx, affiliateUser.ID, coursePurchase.CourseID, AffiliateReward, "Affiliate reward received"); err != nil {
+						payment.ErrorLog.Printf("Failed to reward user (\"%s\") with affiliate points: %s\n", affiliateUser.ID, err)
+						return err
+					}
+				}
+			}
+
+			course, err = tx.GetCourseByID(ctx, coursePurchase.CourseID)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to get course (\"%s\") from the database: %s\n", coursePurchase.CourseID, err)
+				return err
+			}
+
+			discount, err = payment.createDiscount(ctx, tx, fmt.Sprintf("Thank You Gift To %s %s", user.Name, user.Surname), "A gift to thank the user for buying a course from us", 20, 1)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to create new discount: %s\n", err)
+				return err
+			}
 
-		course, err := payment.Database.GetCourseByID(coursePurchase.CourseID)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to get course (\"%s\") from the database: %s\n", coursePurchase.CourseID, err)
 			return nil
+		})
+		if err != nil {
+			return errors.New("unexpected internal server error")
 		}
 
-		discount, err := payment.CreateDiscount(fmt.Sprintf("Thank You Gift To %s %s", user.Name, user.Surname), "A gift to thank the user for buying a course from us", 20, 1)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to create new discount: %s\n", err)
+		if alreadySettled {
 			return nil
 		}
 
@@ -147,7 +200,7 @@ func (payment *Payments) HandlePaymentSuccess(event *stripe.Event) error {
 }
 
 // HandlePaymentCancel updates course purchase when payment is canceled.
-func (payment *Payments) HandlePaymentCancel(event *stripe.Event) error {
+func (payment *Payments) HandlePaymentCancel(ctx context.Context, event *stripe.Event) error {
 	var intent stripe.PaymentIntent
 	if err := json.Unmarshal(event.Data.Raw, &intent); err != nil {
 		payment.ErrorLog.Printf("Failed to unmarshal payment intent: %s\n", err)
@@ -155,22 +208,37 @@ func (payment *Payments) HandlePaymentCancel(event *stripe.Event) error {
 	}
 
 	if paymentKey, exists := intent.Metadata["payment_key"]; exists {
-		coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
-			return errors.New("unexpected internal server error")
-		}
+		err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+			coursePurchase, err := tx.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+				return err
+			}
 
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Cancelled); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
-		}
+			// The maintenance job might have expired the purchase already, in which case the affiliate points were
+			// given back at that point.
+			changed, err := tx.TransitionCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.OpenPaymentStatuses, database.Cancelled)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
+				return err
+			}
 
-		if coursePurchase.AffiliatePointsUsed > 0 {
-			if err := payment.Database.RegisterAffiliatePointsChange(coursePurchase.UserID, coursePurchase.CourseID, int(coursePurchase.AffiliatePointsUsed), "Payment cancelled"); err != nil {
-				payment.ErrorLog.Printf("Failed to refund affiliate points after payment was cancelled: %s\n", err)
-				return errors.New("unexpected internal server error")
+			if !changed {
+				payment.WarningLog.Printf("Course purchase (\"%s\") was already settled as \"%s\".\n", coursePurchase.ID, coursePurchase.PaymentStatus)
+				return nil
 			}
+
+			if coursePurchase.AffiliatePointsUsed > 0 {
+				if err := tx.RegisterAffiliatePointsChange(ctx, coursePurchase.UserID, coursePurchase.CourseID, int(coursePurchase.AffiliatePointsUsed), "Payment cancelled"); err != nil {
+					payment.ErrorLog.Printf("Failed to refund affiliate points after payment was cancelled: %s\n", err)
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return errors.New("unexpected internal server error")
 		}
 	}
 
@@ -178,7 +246,7 @@ func (payment *Payments) HandlePaymentCancel(event *stripe.Event) error {
 }
 
 // HandlePaymentFailed updates course purchase when payment fails.
-func (payment *Payments) HandlePaymentFailed(event *stripe.Event) error {
+func (payment *Payments) HandlePaymentFailed(ctx context.Context, event *stripe.Event) error {
 	var intent stripe.PaymentIntent
 	if err := json.Unmarshal(event.Data.Raw, &intent); err != nil {
 		payment.ErrorLog.Printf("Failed to unmarshal payment intent: %s\n", err)
@@ -186,22 +254,35 @@ func (payment *Payments) HandlePaymentFailed(event *stripe.Event) error {
 	}
 
 	if paymentKey, exists := intent.Metadata["payment_key"]; exists {
-		coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
-		if err != nil {
-			payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
-			return errors.New("unexpected internal server error")
-		}
+		err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+			coursePurchase, err := tx.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to get course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
+				return err
+			}
 
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Failed); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
-		}
+			changed, err := tx.TransitionCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.OpenPaymentStatuses, database.Failed)
+			if err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase's (\"%s\") payment status: %s\n", coursePurchase.ID, err)
+				return err
+			}
 
-		if coursePurchase.AffiliatePointsUsed > 0 {
-			if err := payment.Database.RegisterAffiliatePointsChange(coursePurchase.UserID, coursePurchase.CourseID, int(coursePurchase.AffiliatePointsUsed), "Payment failed"); err != nil {
-				payment.ErrorLog.Printf("Failed to refund affiliate points after payment failed: %s\n", err)
-				return errors.New("unexpected internal server error")
+			if !changed {
+				payment.WarningLog.Printf("Course purchase (\"%s\") was already settled as \"%s\".\n", coursePurchase.ID, coursePurchase.PaymentStatus)
+				return nil
+			}
+
+			if coursePurchase.AffiliatePointsUsed > 0 {
+				if err := tx.RegisterAffiliatePointsChange(ctx, coursePurchase.UserID, coursePurchase.CourseID, int(coursePurchase.AffiliatePointsUsed), "Payment failed"); err != nil {
+					payment.ErrorLog.Printf("Failed to refund affiliate points after payment failed: %s\n", err)
+					return err
+				}
 			}
+
+			return nil
+		})
+		if err != nil {
+			return errors.New("unexpected internal server error")
 		}
 	}
 
@@ -209,7 +290,7 @@ func (payment *Payments) HandlePaymentFailed(event *stripe.Event) error {
 }
 
 // HandleRefund updates the course purchase and refund based on the provided Stripe events.
-func (payment *Payments) HandleRefund(event *stripe.Event) error {
+func (payment *Payments) HandleRefund(ctx context.Context, event *stripe.Event) error {
 	var refund stripe.Refund
 	if err := json.Unmarshal(event.Data.Raw, &refund); err != nil {
 		payment.ErrorLog.Printf("Failed to unmarshal refund: %s\n", err)
@@ -219,7 +300,7 @@ func (payment *Payments) HandleRefund(event *stripe.Event) error {
 	var coursePurchase *models.CoursePurchaseModel
 
 	if paymentKey, hasPaymentKey := refund.Metadata["payment_key"]; hasPaymentKey {
-		cp, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
+		cp, err := payment.Database.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
 		if err != nil {
 			payment.ErrorLog.Printf("Failed to find course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
 			return errors.New("unexpected internal server error")
@@ -249,7 +330,7 @@ func (payment *Payments) HandleRefund(event *stripe.Event) error {
 				continue
 			}
 
-			cp, err := payment.Database.GetCoursePurchaseByCheckoutSession(s.ID)
+			cp, err := payment.Database.GetCoursePurchaseByCheckoutSession(ctx, s.ID)
 			if err != nil {
 				payment.ErrorLog.Printf("Failed to get course purchase by stripe checkout session ID (\"%s\"): %s\n", s.ID, err)
 				return errors.New("unexpected internal server error")
@@ -285,46 +366,53 @@ func (payment *Payments) HandleRefund(event *stripe.Event) error {
 		return nil
 	}
 
-	refundModel, err := payment.Database.GetRefundWithCoursePurchaseID(coursePurchase.ID)
-	if err != nil {
-		payment.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchase.ID, err)
-		return errors.New("unexpected internal server error")
-	}
-
-	if refundModel == nil {
-		if err := payment.Database.RegisterRefund(coursePurchase.UserID, coursePurchase.ID, status); err != nil {
-			payment.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
-			return errors.New("unexpected internal server error")
+	err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+		refundModel, err := tx.GetRefundWithCoursePurchaseID(ctx, coursePurchase.ID)
+		if err != nil {
+			payment.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchase.ID, err)
+			return err
 		}
-	} else {
-		if database.RefundStatusFromString(refundModel.RefundStatus) < status {
-			if err := payment.Database.UpdateRefundStatus(coursePurchase.ID, status); err != nil {
-				payment.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundModel.ID, err)
-				return errors.New("unexpected internal server error")
+
+		if refundModel == nil {
+			if err := tx.RegisterRefund(ctx, coursePurchase.UserID, coursePurchase.ID, status); err != nil {
+				payment.ErrorLog.Printf("Failed to insert new refund: %s\n", err)
+				return err
+			}
+		} else {
+			if database.RefundStatusFromString(refundModel.RefundStatus) < status {
+				if err := tx.UpdateRefundStatus(ctx, refundModel.ID, status); err != nil {
+					payment.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundModel.ID, err)
+					return err
+				}
 			}
 		}
-	}
 
-	if status == database.RefundFailed || status == database.RefundCancelled {
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Succeeded); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to succeeded: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
-		}
-	} else {
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Refunded); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to refunded: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
+		if status == database.RefundFailed || status == database.RefundCancelled {
+			if err := tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.Succeeded); err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to succeeded: %s\n", coursePurchase.ID, err)
+				return err
+			}
+		} else {
+			if err := tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.Refunded); err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to refunded: %s\n", coursePurchase.ID, err)
+				return err
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New("unexpected internal server error")
 	}
 
 	if slices.Contains([]database.RefundStatus{database.RefundFailed, database.RefundCancelled, database.RefundSucceeded}, status) {
-		user, err := payment.Database.GetUserByID(coursePurchase.UserID, database.All)
+		user, err := payment.Database.GetUserByID(ctx, coursePurchase.UserID, database.IncludeDeleted)
 		if err != nil {
 			payment.ErrorLog.Printf("Failed to get user by ID (\"%s\"): %s\n", coursePurchase.UserID, err)
 			return errors.New("unexpected internal server error")
 		}
 
-		course, err := payment.Database.GetCourseByID(coursePurchase.CourseID)
+		course, err := payment.Database.GetCourseByID(ctx, coursePurchase.CourseID)
 		if err != nil {
 			payment.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", coursePurchase.CourseID, err)
 			return errors.New("unexpected internal server error")
@@ -360,7 +448,7 @@ func (payment *Payments) HandleRefund(event *stripe.Event) error {
 }
 
 // HandleChargeRefunded updates the course purchase and refund based on the provided Stripe events.
-func (payment *Payments) HandleChargeRefunded(event *stripe.Event) error {
+func (payment *Payments) HandleChargeRefunded(ctx context.Context, event *stripe.Event) error {
 	var charge stripe.Charge
 	if err := json.Unmarshal(event.Data.Raw, &charge); err != nil {
 		payment.ErrorLog.Printf("Failed to unmarshal charge: %s\n", err)
@@ -368,20 +456,20 @@ func (payment *Payments) HandleChargeRefunded(event *stripe.Event) error {
 	}
 
 	if paymentKey, hasPaymentKey := charge.Metadata["payment_key"]; hasPaymentKey {
-		coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
+		coursePurchase, err := payment.Database.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
 		if err != nil || coursePurchase == nil {
 			payment.ErrorLog.Printf("Failed to find course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
 			return errors.New("unexpected internal server error")
 		}
 
-		refundModel, err := payment.Database.GetRefundWithCoursePurchaseID(coursePurchase.ID)
+		refundModel, err := payment.Database.GetRefundWithCoursePurchaseID(ctx, coursePurchase.ID)
 		if err != nil {
 			payment.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchase.ID, err)
 			return errors.New("unexpected internal server error")
 		}
 
 		if refundModel != nil {
-			if err := payment.Database.UpdateRefundStatus(refundModel.ID, database.RefundSucceeded); err != nil {
+			if err := payment.Database.UpdateRefundStatus(ctx, refundModel.ID, database.RefundSucceeded); err != nil {
 				payment.ErrorLog.Printf("Failed to update refund (\"%s\") status to succeeded: %s\n", refundModel.ID, err)
 				return errors.New("unexpected internal server error")
 			}
@@ -396,7 +484,7 @@ func (payment *Payments) HandleChargeRefunded(event *stripe.Event) error {
 	}
 }
 
-func (payment *Payments) HandleChargeDispute(event *stripe.Event) error {
+func (payment *Payments) HandleChargeDispute(ctx context.Context, event *stripe.Event) error {
 	var dispute stripe.Dispute
 	if err := json.Unmarshal(event.Data.Raw, &dispute); err != nil {
 		payment.ErrorLog.Printf("Failed to unmarshal dispute: %s\n", err)
@@ -406,7 +494,7 @@ func (payment *Payments) HandleChargeDispute(event *stripe.Event) error {
 	var coursePurchase *models.CoursePurchaseModel
 
 	if paymentKey, hasPaymentKey := dispute.Metadata["payment_key"]; hasPaymentKey {
-		cp, err := payment.Database.GetCoursePurchaseByPaymentKey(paymentKey)
+		cp, err := payment.Database.GetCoursePurchaseByPaymentKey(ctx, paymentKey)
 		if err != nil {
 			payment.ErrorLog.Printf("Failed to find course purchase by payment key (\"%s\"): %s\n", paymentKey, err)
 			return errors.New("unexpected internal server error")
@@ -436,7 +524,7 @@ func (payment *Payments) HandleChargeDispute(event *stripe.Event) error {
 				continue
 			}
 
-			cp, err := payment.Database.GetCoursePurchaseByCheckoutSession(s.ID)
+			cp, err := payment.Database.GetCoursePurchaseByCheckoutSession(ctx, s.ID)
 			if err != nil {
 				payment.ErrorLog.Printf("Failed to get course purchase by stripe checkout session ID (\"%s\"): %s\n", s.ID, err)
 				return errors.New("unexpected internal server error")
@@ -476,36 +564,43 @@ func (payment *Payments) HandleChargeDispute(event *stripe.Event) error {
 		return nil
 	}
 
-	refundModel, err := payment.Database.GetRefundWithCoursePurchaseID(coursePurchase.ID)
-	if err != nil {
-		payment.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchase.ID, err)
-		return errors.New("unexpected internal server error")
-	}
-
-	if refundModel == nil {
-		if err := payment.Database.RegisterRefund(coursePurchase.UserID, coursePurchase.ID, status); err != nil {
-			payment.ErrorLog.Printf("Failed to insert new dispute: %s\n", err)
-			return errors.New("unexpected internal server error")
+	err := payment.Database.WithTx(ctx, func(tx database.Tx) error {
+		refundModel, err := tx.GetRefundWithCoursePurchaseID(ctx, coursePurchase.ID)
+		if err != nil {
+			payment.ErrorLog.Printf("Failed to get refund from course purchase ID (\"%s\"): %s\n", coursePurchase.ID, err)
+			return err
 		}
-	} else {
-		if database.RefundStatusFromString(refundModel.RefundStatus) < status {
-			if err := payment.Database.UpdateRefundStatus(coursePurchase.ID, status); err != nil {
-				payment.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundModel.ID, err)
-				return errors.New("unexpected internal server error")
+
+		if refundModel == nil {
+			if err := tx.RegisterRefund(ctx, coursePurchase.UserID, coursePurchase.ID, status); err != nil {
+				payment.ErrorLog.Printf("Failed to insert new dispute: %s\n", err)
+				return err
+			}
+		} else {
+			if database.RefundStatusFromString(refundModel.RefundStatus) < status {
+				if err := tx.UpdateRefundStatus(ctx, refundModel.ID, status); err != nil {
+					payment.ErrorLog.Printf("Failed to update refund (\"%s\") status: %s\n", refundModel.ID, err)
+					return err
+				}
 			}
 		}
-	}
 
-	if status == database.DisputeWon || status == database.DisputeWarningClosed {
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Succeeded); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to succeeded: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
-		}
-	} else {
-		if err := payment.Database.UpdateCoursePurchasePaymentStatus(coursePurchase.ID, database.Disputed); err != nil {
-			payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to disputed: %s\n", coursePurchase.ID, err)
-			return errors.New("unexpected internal server error")
+		if status == database.DisputeWon || status == database.DisputeWarningClosed {
+			if err := tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.Succeeded); err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to succeeded: %s\n", coursePurchase.ID, err)
+				return err
+			}
+		} else {
+			if err := tx.UpdateCoursePurchasePaymentStatus(ctx, coursePurchase.ID, database.Disputed); err != nil {
+				payment.ErrorLog.Printf("Failed to update course purchase (\"%s\") payment status to disputed: %s\n", coursePurchase.ID, err)
+				return err
+			}
 		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.New("unexpected internal server error")
 	}
 
 	return nil
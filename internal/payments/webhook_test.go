@@ -1,6 +1,17 @@
 package payments
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/stripe/stripe-go/v81"
+)
 
 func TestWebhook(t *testing.T) {
 	// TODO: Implement.
@@ -11,15 +22,60 @@ func TestHandlePaymentIntent(t *testing.T) {
 }
 
 func TestHandlePaymentSuccess(t *testing.T) {
-	// TODO: Implement.
+	ctx := context.Background()
+	payment, db, purchase := setupExpiredPurchase(t)
+
+	if err := payment.HandlePaymentSuccess(ctx, paymentIntentEvent(t, purchase.PaymentKey)); err != nil {
+		t.Fatalf("Failed to handle payment success: %s", err)
+	}
+
+	assertPurchase(t, db, purchase, database.Succeeded, 0)
+
+	// Stripe retries webhooks so the same event can arrive twice.
+	if err := payment.HandlePaymentSuccess(ctx, paymentIntentEvent(t, purchase.PaymentKey)); err != nil {
+		t.Fatalf("Failed to handle payment success a second time: %s", err)
+	}
+
+	assertPurchase(t, db, purchase, database.Succeeded, 0)
+}
+
+func TestHandlePaymentSuccessAfterPointsWereSpent(t *testing.T) {
+	ctx := context.Background()
+	payment, db, purchase := setupExpiredPurchase(t)
+
+	// The learner spends some of the points they got back before Stripe's webhook arrives.
+	if err := db.RegisterAffiliatePointsChange(ctx, purchase.UserID, purchase.CourseID, -30, "Spent"); err != nil {
+		t.Fatalf("Failed to spend affiliate points: %s", err)
+	}
+
+	if err := payment.HandlePaymentSuccess(ctx, paymentIntentEvent(t, purchase.PaymentKey)); err != nil {
+		t.Fatalf("Failed to handle payment success: %s", err)
+	}
+
+	// The purchase goes through but the balance must never go below zero.
+	assertPurchase(t, db, purchase, database.Succeeded, purchase.AffiliatePointsUsed-30)
 }
 
 func TestHandlePaymentCancel(t *testing.T) {
-	// TODO: Implement.
+	ctx := context.Background()
+	payment, db, purchase := setupExpiredPurchase(t)
+
+	if err := payment.HandlePaymentCancel(ctx, paymentIntentEvent(t, purchase.PaymentKey)); err != nil {
+		t.Fatalf("Failed to handle payment cancel: %s", err)
+	}
+
+	assertPurchase(t, db, purchase, database.Cancelled, purchase.AffiliatePointsUsed)
 }
 
 func TestHandlePaymentFailed(t *testing.T) {
-	// TODO: Implement.
+	ctx := context.Background()
+	payment, db, purchase := setupExpiredPurchase(t)
+
+	if err := payment.HandlePaymentFailed(ctx, paymentIntentEvent(t, purchase.PaymentKey)); err != nil {
+		t.Fatalf("Failed to handle payment failure: %s", err)
+	}
+
+	assertPurchase(t, db, purchase, database.Cancelled, purchase.AffiliatePointsUsed)
 }
 
 func TestHandleRefund(t *testing.T) {
@@ -33,3 +89,90 @@ func TestHandleChargeRefunded(t *testing.T) {
 func TestHandleChargeDispute(t *testing.T) {
 	// TODO: Implement.
 }
+
+// mailer is an Emailer that doesn't send anything.
+type mailer struct{}
+
+func (mailer) SendThankYouForPurchaseEmail(email, firstName, affiliateCode string, course *models.CourseModel, discount *models.DiscountModel) {
+}
+
+func (mailer) SendRefundRequestFailedEmail(email, firstName, courseName, failureReason string) {}
+
+func (mailer) SendRefundRequestCancelledEmail(email, firstName, courseName string) {}
+
+func (mailer) SendRefundRequestSuccessfulEmail(email, firstName, courseName string, refundAmount float64) {
+}
+
+// setupExpiredPurchase creates a purchase that used affiliate points and lets the maintenance job expire it, the same
+// way it would happen when Stripe takes longer than PENDING_PURCHASE_WINDOW to send its webhook.
+func setupExpiredPurchase(t *testing.T) (*Payments, *databasetest.Database, *models.CoursePurchaseModel) {
+	t.Helper()
+
+	db := databasetest.NewDatabase()
+	user := databasetest.NewUserBuilder().Build()
+	course := databasetest.NewCourseBuilder().Published().Build()
+	purchase := databasetest.NewCoursePurchaseBuilder(user.ID, course.ID).
+		WithStatus(database.Pending).
+		WithAffiliatePointsUsed(50).
+		CreatedAt(time.Now().Add(-48 * time.Hour)).
+		Build()
+
+	db.SeedUsers(user)
+	db.SeedCourses(course)
+	db.SeedCoursePurchases(purchase)
+
+	cancelled, err := db.CancelStalePurchases(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to cancel stale purchases: %s", err)
+	}
+
+	if cancelled != 1 {
+		t.Fatalf("Expected the purchase to be cancelled. Got %d cancelled purchases", cancelled)
+	}
+
+	payment := &Payments{
+		Loggers:  utils.CreateLoggers("PAYMENTS"),
+		Database: db,
+		Mailer:   mailer{},
+	}
+
+	return payment, db, purchase
+}
+
+// paymentIntentEvent creates a Stripe event for the payment intent of the purchase with the given payment key.
+func paymentIntentEvent(t *testing.T, paymentKey string) *stripe.Event {
+	t.Helper()
+
+	raw, err := json.Marshal(stripe.PaymentIntent{Metadata: map[string]string{"payment_key": paymentKey}})
+	if err != nil {
+		t.Fatalf("Failed to marshal payment intent: %s", err)
+	}
+
+	return &stripe.Event{Data: &stripe.EventData{Raw: raw}}
+}
+
+// assertPurchase checks the purchase's payment status and how many of its affiliate points the user has in total
+// been given back.
+func assertPurchase(t *testing.T, db *databasetest.Database, purchase *models.CoursePurchaseModel, status database.PaymentStatus, refunded uint) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	coursePurchase, err := db.GetCoursePurchaseByID(ctx, purchase.ID)
+	if err != nil {
+		t.Fatalf("Failed to get course purchase: %s", err)
+	}
+
+	if coursePurchase.PaymentStatus != status.String() {
+		t.Errorf("Expected payment status to be %q. Got %q", status, coursePurchase.PaymentStatus)
+	}
+
+	user, err := db.GetUserByID(ctx, purchase.UserID, database.All)
+	if err != nil {
+		t.Fatalf("Failed to get user: %s", err)
+	}
+
+	if user.AffiliatePoints != int(refunded) {
+		t.Errorf("Expected %d affiliate points to be given back. Got %d", refunded, user.AffiliatePoints)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/PsionicAlch/course-platform/internal/searchmark"
 	"github.com/xeonx/timeago"
 )
 
@@ -22,6 +23,7 @@ func CreateFuncMap(cdnURL string) template.FuncMap {
 		"url_escape":              URLEscape,
 		"assets":                  Assets(cdnURL),
 		"time_ago":                TimeAgo,
+		"highlight":               Highlight,
 	}
 
 	return funcMap
@@ -118,3 +120,12 @@ func Assets(cdnURL string) func(path string) string {
 func TimeAgo(t time.Time) string {
 	return timeago.English.Format(t)
 }
+
+// Highlight escapes a search snippet and wraps the matched search terms in <mark> tags.
+func Highlight(snippet string) template.HTML {
+	escaped := template.HTMLEscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, searchmark.Start, "<mark>")
+	escaped = strings.ReplaceAll(escaped, searchmark.End, "</mark>")
+
+	return template.HTML(escaped)
+}
@@ -37,3 +37,7 @@ func TestURLEscape(t *testing.T) {
 func TestAssets(t *testing.T) {
 	// TODO: Implement.
 }
+
+func TestHighlight(t *testing.T) {
+	// TODO: Implement.
+}
@@ -0,0 +1,6 @@
+package scheduler
+
+// Emailer represents the expected email functions.
+type Emailer interface {
+	SendChapterReleasedEmail(email, firstName, courseTitle, courseSlug, chapterTitle, chapterSlug string)
+}
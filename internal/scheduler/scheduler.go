@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/cache"
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+)
+
+// RunTimeout is the maximum amount of time a single scheduler run is allowed to take.
+const RunTimeout = time.Minute
+
+// Scheduler publishes and unpublishes tutorials and courses at the times set in their frontmatter while the web
+// server is running. It also emails learners when a chapter of a course they bought unlocks for them.
+type Scheduler struct {
+	utils.Loggers
+	Database database.Database
+	Cache    cache.Cache
+	Mailer   Emailer
+	Interval time.Duration
+}
+
+// SetupScheduler creates a new instance of Scheduler. The interval controls how often the scheduler checks for
+// tutorials, courses and chapters that are due, so content can go live up to one interval after its scheduled time.
+// The cache gets invalidated whenever something changes so that the RSS feeds pick up the change.
+func SetupScheduler(db database.Database, c cache.Cache, mailer Emailer, interval time.Duration) *Scheduler {
+	loggers := utils.CreateLoggers("SCHEDULER")
+
+	return &Scheduler{
+		Loggers:  loggers,
+		Database: db,
+		Cache:    c,
+		Mailer:   mailer,
+		Interval: interval,
+	}
+}
+
+// Start runs the scheduler once straight away and then once every interval in a background goroutine. The goroutine
+// stops when the given context gets cancelled and is tracked by the wait group so that the caller can wait for a run
+// that is still busy to finish. Nothing gets started when the interval isn't greater than 0.
+func (s *Scheduler) Start(ctx context.Context, wg *sync.WaitGroup) {
+	if s.Interval <= 0 {
+		s.ErrorLog.Printf("Not starting scheduler because the interval (%s) has to be greater than 0\n", s.Interval)
+		return
+	}
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+
+		for {
+			s.Run(ctx)
+			s.NotifyChapterReleases(ctx)
+
+			select {
+			case <-ctx.Done():
+				s.InfoLog.Println("Stopping scheduler")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Run publishes and unpublishes every tutorial and course that is due and returns how many of them changed.
+func (s *Scheduler) Run(ctx context.Context) (uint, error) {
+	ctx, cancel := context.WithTimeout(ctx, RunTimeout)
+	defer cancel()
+
+	changed, err := s.Database.RunPublishSchedules(ctx)
+	if err != nil {
+		s.ErrorLog.Printf("Failed to run publish schedules: %s\n", err)
+		return 0, err
+	}
+
+	if changed > 0 {
+		s.Cache.InvalidateCache()
+		s.InfoLog.Printf("Changed the publish status of %d tutorial(s) and course(s)\n", changed)
+	}
+
+	return changed, nil
+}
+
+// NotifyChapterReleases emails every learner who has a chapter that unlocked since the last run and returns how many
+// emails were sent. Each release gets recorded before its email is sent so that a learner is never emailed twice about
+// the same chapter, even when more than one scheduler is running.
+func (s *Scheduler) NotifyChapterReleases(ctx context.Context) (uint, error) {
+	ctx, cancel := context.WithTimeout(ctx, RunTimeout)
+	defer cancel()
+
+	releases, err := s.Database.GetDueChapterReleases(ctx)
+	if err != nil {
+		s.ErrorLog.Printf("Failed to get due chapter releases: %s\n", err)
+		return 0, err
+	}
+
+	var notified uint
+
+	for _, release := range releases {
+		if err := s.Database.AddChapterReleaseNotification(ctx, release.UserID, release.ChapterID); err != nil {
+			if errors.Is(err, database.ErrChapterReleaseAlreadyNotified) {
+				continue
+			}
+
+			s.ErrorLog.Printf("Failed to record the release of chapter (\"%s\") for user (\"%s\"): %s\n", release.ChapterID, release.UserID, err)
+			return notified, err
+		}
+
+		s.Mailer.SendChapterReleasedEmail(release.Email, release.Name, release.CourseTitle, release.CourseSlug, release.ChapterTitle, release.ChapterSlug)
+		notified++
+	}
+
+	if notified > 0 {
+		s.InfoLog.Printf("Sent %d chapter release email(s)\n", notified)
+	}
+
+	return notified, nil
+}
@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func TestStart(t *testing.T) {
+	db := setupReleasedChapter(t)
+	mailer := new(mailer)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	SetupScheduler(db, new(feedCache), mailer, 0).Start(ctx, &wg)
+
+	// An interval that isn't greater than 0 would make the ticker panic so nothing should have been started.
+	if sent := mailer.Sent(); sent != 0 {
+		t.Errorf("Expected the scheduler to not run. Got %d emails", sent)
+	}
+
+	SetupScheduler(db, new(feedCache), mailer, time.Hour).Start(ctx, &wg)
+
+	// The first run happens straight away in the background.
+	deadline := time.Now().Add(5 * time.Second)
+	for mailer.Sent() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the scheduler to run once straight away")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the scheduler to stop once the context was cancelled")
+	}
+}
+
+func TestRun(t *testing.T) {
+	db := databasetest.NewDatabase()
+	c := new(feedCache)
+
+	scheduler := SetupScheduler(db, c, new(mailer), time.Minute)
+
+	if _, err := scheduler.Run(context.Background()); err != nil {
+		t.Fatalf("Failed to run scheduler: %s", err)
+	}
+
+	if c.invalidated != 0 {
+		t.Errorf("Expected the cache to be left alone when nothing changed. Got %d invalidations", c.invalidated)
+	}
+
+	db.FailOn("RunPublishSchedules", errors.New("database is locked"))
+
+	if _, err := scheduler.Run(context.Background()); err == nil {
+		t.Error("Expected the database error to be returned")
+	}
+}
+
+func TestNotifyChapterReleases(t *testing.T) {
+	ctx := context.Background()
+	db := setupReleasedChapter(t)
+	mailer := new(mailer)
+
+	scheduler := SetupScheduler(db, new(feedCache), mailer, time.Minute)
+
+	notified, err := scheduler.NotifyChapterReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to notify chapter releases: %s", err)
+	}
+
+	if notified != 1 || mailer.Sent() != 1 {
+		t.Errorf("Expected 1 chapter release email. Got %d notified and %d sent", notified, mailer.Sent())
+	}
+
+	notified, err = scheduler.NotifyChapterReleases(ctx)
+	if err != nil {
+		t.Fatalf("Failed to notify chapter releases: %s", err)
+	}
+
+	if notified != 0 || mailer.Sent() != 1 {
+		t.Errorf("Expected the learner to only be emailed once. Got %d notified and %d sent", notified, mailer.Sent())
+	}
+}
+
+// setupReleasedChapter creates a database with a learner who bought a course two days ago and a chapter of that
+// course that unlocked a day after the purchase.
+func setupReleasedChapter(t *testing.T) *databasetest.Database {
+	t.Helper()
+
+	db := databasetest.NewDatabase()
+	user := databasetest.NewUserBuilder().Build()
+	course := databasetest.NewCourseBuilder().Published().Build()
+	purchase := databasetest.NewCoursePurchaseBuilder(user.ID, course.ID).
+		CreatedAt(time.Now().Add(-48 * time.Hour)).
+		Build()
+
+	db.SeedUsers(user)
+	db.SeedCourses(course)
+	db.SeedCoursePurchases(purchase)
+	db.SeedChapters(&models.ChapterModel{
+		ID:               "chapter",
+		Title:            "Chapter",
+		Slug:             "chapter",
+		Chapter:          1,
+		CourseID:         course.ID,
+		ReleaseAfterDays: sql.NullInt32{Int32: 1, Valid: true},
+	})
+
+	return db
+}
+
+type feedCache struct {
+	invalidated int
+}
+
+func (c *feedCache) InvalidateCache()                                   { c.invalidated++ }
+func (c *feedCache) GetGeneralRSSFeed() string                          { return "" }
+func (c *feedCache) GetTutorialsRSSFeed() string                        { return "" }
+func (c *feedCache) GetTutorialRSSFeed(tutorialSlug string) string      { return "" }
+func (c *feedCache) GetCoursesRSSFeed() string                          { return "" }
+func (c *feedCache) GetAuthorTutorialsRSSFeed(authorSlug string) string { return "" }
+func (c *feedCache) GetAuthorCoursesRSSFeed(authorSlug string) string   { return "" }
+func (c *feedCache) GetSeriesRSSFeed(seriesSlug string) string          { return "" }
+
+type mailer struct {
+	mu   sync.Mutex
+	sent int
+}
+
+func (m *mailer) SendChapterReleasedEmail(email, firstName, courseTitle, courseSlug, chapterTitle, chapterSlug string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent++
+}
+
+func (m *mailer) Sent() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sent
+}
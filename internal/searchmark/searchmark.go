@@ -0,0 +1,12 @@
+// Package searchmark holds the markers that the databases wrap matched search terms in when they build search
+// snippets. They are control characters so that they can't show up in the content and survive HTML escaping, which
+// lets the renderer swap them for <mark> tags after escaping the snippet.
+package searchmark
+
+const (
+	// Start marks the start of a matched search term inside a search snippet.
+	Start = "\x02"
+
+	// End marks the end of a matched search term inside a search snippet.
+	End = "\x03"
+)
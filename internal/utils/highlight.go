@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/gomarkdown/markdown/ast"
+)
+
+// DefaultCodeTheme is the chroma style that code blocks get highlighted with unless another one has been set.
+const DefaultCodeTheme = "github"
+
+// codeStyle is the chroma style used by HighlightCode.
+var codeStyle = styles.Get(DefaultCodeTheme)
+
+// codeInfoRegex splits the info string of a fenced code block into key="value" attributes, {line ranges} and words.
+var codeInfoRegex = regexp.MustCompile(`(\w+)="([^"]*)"|\{([^}]*)\}|(\S+)`)
+
+// codeInfo holds the options that were set on the first line of a fenced code block, for example:
+// ```go {3-5,8} title="main.go" showLineNumbers
+type codeInfo struct {
+	language    string
+	title       string
+	lines       [][2]int
+	lineNumbers bool
+}
+
+// SetCodeTheme changes the chroma style that code blocks get highlighted with. The list of themes can be found at
+// https://xyproto.github.io/splash/docs/.
+func SetCodeTheme(theme string) error {
+	style, has := styles.Registry[strings.ToLower(theme)]
+	if !has {
+		return fmt.Errorf("unknown code theme \"%s\"", theme)
+	}
+
+	codeStyle = style
+
+	return nil
+}
+
+// HighlightCode is a markdown render hook that turns code blocks into syntax highlighted HTML. The colours are written
+// as inline styles so the page doesn't need any extra CSS or JavaScript to show them.
+func HighlightCode(w io.Writer, node ast.Node, entering bool) (ast.WalkStatus, bool) {
+	block, ok := node.(*ast.CodeBlock)
+	if !ok || !entering {
+		return ast.GoToNext, false
+	}
+
+	info := parseCodeInfo(string(block.Info))
+
+	lexer := lexers.Get(info.language)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, string(block.Literal))
+	if err != nil {
+		return ast.GoToNext, false
+	}
+
+	formatter := chromahtml.New(
+		chromahtml.TabWidth(4),
+		chromahtml.WithLineNumbers(info.lineNumbers),
+		chromahtml.HighlightLines(info.lines),
+	)
+
+	var code bytes.Buffer
+
+	if err := formatter.Format(&code, codeStyle, iterator); err != nil {
+		return ast.GoToNext, false
+	}
+
+	if info.title != "" {
+		fmt.Fprintf(w, "<figure class=\"code-block\"><figcaption>%s</figcaption>", html.EscapeString(info.title))
+	}
+
+	w.Write(code.Bytes())
+
+	if info.title != "" {
+		io.WriteString(w, "</figure>")
+	}
+
+	io.WriteString(w, "\n")
+
+	return ast.GoToNext, true
+}
+
+// parseCodeInfo reads the language and the options from the info string of a fenced code block. Options that can't
+// be understood are ignored.
+func parseCodeInfo(info string) codeInfo {
+	var parsed codeInfo
+
+	for i, match := range codeInfoRegex.FindAllStringSubmatch(info, -1) {
+		switch {
+		case match[1] != "":
+			if match[1] == "title" {
+				parsed.title = match[2]
+			}
+
+		case match[0][0] == '{':
+			parsed.lines = append(parsed.lines, parseLineRanges(match[3])...)
+
+		case match[4] == "showLineNumbers":
+			parsed.lineNumbers = true
+
+		case i == 0:
+			parsed.language = match[4]
+		}
+	}
+
+	return parsed
+}
+
+// parseLineRanges converts a list of lines such as "3-5,8" into the ranges that chroma highlights.
+func parseLineRanges(lines string) [][2]int {
+	var ranges [][2]int
+
+	for _, part := range strings.Split(lines, ",") {
+		start, end, isRange := strings.Cut(strings.TrimSpace(part), "-")
+
+		from, err := strconv.Atoi(start)
+		if err != nil {
+			continue
+		}
+
+		to := from
+
+		if isRange {
+			to, err = strconv.Atoi(end)
+			if err != nil || to < from {
+				continue
+			}
+		}
+
+		ranges = append(ranges, [2]int{from, to})
+	}
+
+	return ranges
+}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gomarkdown/markdown/ast"
+)
+
+func TestSetCodeTheme(t *testing.T) {
+	t.Cleanup(func() { SetCodeTheme(DefaultCodeTheme) })
+
+	if err := SetCodeTheme("Monokai"); err != nil {
+		t.Errorf("Expected themes to be looked up regardless of case. Got %s", err)
+	}
+
+	if err := SetCodeTheme("not-a-theme"); err == nil {
+		t.Error("Expected an unknown theme to be rejected")
+	}
+}
+
+func TestHighlightCode(t *testing.T) {
+	code := "a := 1\nb := 2\nc := 3\n"
+
+	tests := []struct {
+		name        string
+		info        string
+		highlighted []int
+		contains    []string
+	}{
+		{
+			name:        "Single line",
+			info:        "go {2}",
+			highlighted: []int{2},
+		},
+		{
+			name:        "Reversed range",
+			info:        "go {3-1}",
+			highlighted: nil,
+		},
+		{
+			name:        "Overlapping ranges",
+			info:        "go {1-2,2-3}",
+			highlighted: []int{1, 2, 3},
+		},
+		{
+			name:        "Range past the last line",
+			info:        "go {2-10}",
+			highlighted: []int{2, 3},
+		},
+		{
+			name:        "Line past the last line",
+			info:        "go {10}",
+			highlighted: nil,
+		},
+		{
+			name:        "Malformed range",
+			info:        "go {1-}",
+			highlighted: nil,
+		},
+		{
+			name:        "Escaped title",
+			info:        `go title="<script>"`,
+			highlighted: nil,
+			contains:    []string{`<figure class="code-block"><figcaption>&lt;script&gt;</figcaption>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var w bytes.Buffer
+
+			block := &ast.CodeBlock{Info: []byte(tt.info), Leaf: ast.Leaf{Literal: []byte(code)}}
+
+			if _, handled := HighlightCode(&w, block, true); !handled {
+				t.Fatal("Expected the code block to be rendered")
+			}
+
+			if highlighted := highlightedLines(w.String()); !reflect.DeepEqual(highlighted, tt.highlighted) {
+				t.Errorf("Expected lines %v to be highlighted. Got %v", tt.highlighted, highlighted)
+			}
+
+			for _, text := range tt.contains {
+				if !strings.Contains(w.String(), text) {
+					t.Errorf("Expected the HTML to contain %q. Got %q", text, w.String())
+				}
+			}
+		})
+	}
+
+	if _, handled := HighlightCode(&bytes.Buffer{}, &ast.Paragraph{}, true); handled {
+		t.Error("Expected nodes that aren't code blocks to be left to the default renderer")
+	}
+}
+
+func TestParseCodeInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		info string
+		want codeInfo
+	}{
+		{
+			name: "Language only",
+			info: "go",
+			want: codeInfo{language: "go"},
+		},
+		{
+			name: "Every option",
+			info: `go {3-5,8} title="main.go" showLineNumbers`,
+			want: codeInfo{language: "go", title: "main.go", lines: [][2]int{{3, 5}, {8, 8}}, lineNumbers: true},
+		},
+		{
+			name: "Several line ranges",
+			info: "go {1} {4-5}",
+			want: codeInfo{language: "go", lines: [][2]int{{1, 1}, {4, 5}}},
+		},
+		{
+			name: "Language has to come first",
+			info: `title="main.go" go`,
+			want: codeInfo{title: "main.go"},
+		},
+		{
+			name: "Braces inside a title",
+			info: `go title="Lines {1-3}"`,
+			want: codeInfo{language: "go", title: "Lines {1-3}"},
+		},
+		{
+			name: "Unknown options",
+			info: `go theme="dark" wrap`,
+			want: codeInfo{language: "go"},
+		},
+		{
+			name: "Malformed range",
+			info: "go {1-}",
+			want: codeInfo{language: "go"},
+		},
+		{
+			name: "Empty",
+			info: "",
+			want: codeInfo{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCodeInfo(tt.info); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %+v. Got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseLineRanges(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines string
+		want  [][2]int
+	}{
+		{name: "Single line", lines: "3", want: [][2]int{{3, 3}}},
+		{name: "Range", lines: "3-5", want: [][2]int{{3, 5}}},
+		{name: "List", lines: "3-5, 8", want: [][2]int{{3, 5}, {8, 8}}},
+		{name: "Overlapping", lines: "1-3,2-4", want: [][2]int{{1, 3}, {2, 4}}},
+		{name: "Reversed", lines: "5-3", want: nil},
+		{name: "Reversed among valid ranges", lines: "1,5-3,7", want: [][2]int{{1, 1}, {7, 7}}},
+		{name: "Open ended", lines: "1-", want: nil},
+		{name: "Missing start", lines: "-3", want: nil},
+		{name: "Not a number", lines: "a-b,x", want: nil},
+		{name: "Empty", lines: "", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLineRanges(tt.lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected %v. Got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// highlightedLines returns the numbers of the lines that chroma gave the highlight background to. Every line of the
+// code block is wrapped in a flex span and highlighted lines get a background colour on top of that.
+func highlightedLines(html string) []int {
+	var highlighted []int
+
+	for i, line := range strings.Split(html, `<span style="display:flex`)[1:] {
+		if strings.HasPrefix(line, "; background-color:") {
+			highlighted = append(highlighted, i+1)
+		}
+	}
+
+	return highlighted
+}
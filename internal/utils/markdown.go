@@ -15,7 +15,7 @@ func MarkdownToHTML(md []byte) []byte {
 
 	// create HTML renderer with extensions
 	htmlFlags := html.CommonFlags | html.HrefTargetBlank
-	opts := html.RendererOptions{Flags: htmlFlags}
+	opts := html.RendererOptions{Flags: htmlFlags, RenderNodeHook: HighlightCode}
 	renderer := html.NewRenderer(opts)
 
 	return markdown.Render(doc, renderer)
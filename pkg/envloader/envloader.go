@@ -34,6 +34,29 @@ func LoadEnvironment(settings map[string]validators.ValidationFunc, filenames ..
 	return nil
 }
 
+// SetDefaults uses github.com/joho/godotenv to load the specified env files and afterwards sets every variable in
+// defaults that is still unset or empty to its default value. Call it before LoadEnvironment so that the optional
+// variables pass validation.
+func SetDefaults(defaults map[string]string, filenames ...string) error {
+	err := godotenv.Load(filenames...)
+	if err != nil {
+		return err
+	}
+
+	for name, value := range defaults {
+		if os.Getenv(name) != "" {
+			continue
+		}
+
+		err = os.Setenv(name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // GetVariable is a generic function that allows you to read an environment variable and automatically convert
 // it to one of the following types: string, int, int8, int16, int32, int64, uint8, uint16, uint32, uint64,
 // float32, float64, bool, complex64, complex128.
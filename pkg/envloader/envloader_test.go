@@ -1,11 +1,56 @@
 package envloader
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestLoadEnvironment(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestSetDefaults(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(filename, []byte("ENVLOADER_FROM_FILE=file\n"), 0644); err != nil {
+		t.Fatalf("Failed to write env file: %s", err)
+	}
+
+	t.Setenv("ENVLOADER_SET", "set")
+	t.Setenv("ENVLOADER_EMPTY", "")
+	t.Setenv("ENVLOADER_UNSET", "")
+	os.Unsetenv("ENVLOADER_UNSET")
+
+	// godotenv sets ENVLOADER_FROM_FILE without going through t.Setenv so it needs to be cleaned up manually.
+	t.Cleanup(func() {
+		os.Unsetenv("ENVLOADER_FROM_FILE")
+	})
+
+	defaults := map[string]string{
+		"ENVLOADER_SET":       "default",
+		"ENVLOADER_EMPTY":     "default",
+		"ENVLOADER_UNSET":     "default",
+		"ENVLOADER_FROM_FILE": "default",
+	}
+
+	if err := SetDefaults(defaults, filename); err != nil {
+		t.Fatalf("Failed to set defaults: %s", err)
+	}
+
+	expected := map[string]string{
+		"ENVLOADER_SET":       "set",
+		"ENVLOADER_EMPTY":     "default",
+		"ENVLOADER_UNSET":     "default",
+		"ENVLOADER_FROM_FILE": "file",
+	}
+
+	for name, value := range expected {
+		if variable := os.Getenv(name); variable != value {
+			t.Errorf("Expected %s to be %q. Got %q", name, value, variable)
+		}
+	}
+}
+
 func TestGetVariable(t *testing.T) {
 	// TODO: Implement.
 }
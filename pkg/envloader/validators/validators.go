@@ -37,6 +37,36 @@ func Int(name, data string) error {
 	return Integer(name, data, 0)
 }
 
+// PositiveInt makes sure that the variable is a whole number greater than 0. Use it for values like intervals that
+// break when they are 0 or negative.
+func PositiveInt(name, data string) error {
+	value, err := strconv.Atoi(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to int: %s", name, err)
+	}
+
+	if value <= 0 {
+		return fmt.Errorf("%s has to be greater than 0", name)
+	}
+
+	return nil
+}
+
+// NonNegativeInt makes sure that the variable is a whole number that is 0 or greater. Use it for values like periods of
+// time where 0 makes sense but a negative number doesn't.
+func NonNegativeInt(name, data string) error {
+	value, err := strconv.Atoi(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert %s to int: %s", name, err)
+	}
+
+	if value < 0 {
+		return fmt.Errorf("%s can't be negative", name)
+	}
+
+	return nil
+}
+
 func Int8(name, data string) error {
 	return Integer(name, data, 8)
 }
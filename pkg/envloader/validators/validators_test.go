@@ -18,6 +18,39 @@ func TestInt(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestPositiveInt(t *testing.T) {
+	tests := map[string]bool{
+		"1":   true,
+		"60":  true,
+		"0":   false,
+		"-5":  false,
+		"1.5": false,
+		"":    false,
+	}
+
+	for data, valid := range tests {
+		if err := PositiveInt("INTERVAL", data); (err == nil) != valid {
+			t.Errorf("Expected %q to be valid: %t. Got %v", data, valid, err)
+		}
+	}
+}
+
+func TestNonNegativeInt(t *testing.T) {
+	tests := map[string]bool{
+		"0":   true,
+		"30":  true,
+		"-1":  false,
+		"1.5": false,
+		"":    false,
+	}
+
+	for data, valid := range tests {
+		if err := NonNegativeInt("PERIOD", data); (err == nil) != valid {
+			t.Errorf("Expected %q to be valid: %t. Got %v", data, valid, err)
+		}
+	}
+}
+
 func TestInt8(t *testing.T) {
 	// TODO: Implement.
 }
@@ -11,6 +11,16 @@ const (
 	production  = "production"
 )
 
+const (
+	sqliteDriver   = "sqlite"
+	postgresDriver = "postgres"
+)
+
+// defaults holds the values of the optional environment variables that get used when they aren't set.
+var defaults = map[string]string{
+	"DATABASE_DRIVER": sqliteDriver,
+}
+
 func SetupConfig() error {
 	variables := map[string]validators.ValidationFunc{
 		"PORT":                     validators.NotEmpty,
@@ -36,6 +46,78 @@ func SetupConfig() error {
 		"STRIPE_SECRET_KEY":          validators.NotEmpty,
 		"STRIPE_WEBHOOK_SECRET":      validators.NotEmpty,
 		"CLOUDFRONT_URL":             validators.NotEmpty,
+		"DATABASE_DRIVER":            validators.InSlice([]string{sqliteDriver, postgresDriver}),
+		"DATABASE_URL":               validators.Empty,
+		"MAINTENANCE_INTERVAL": validators.Chain(
+			validators.NotEmpty,
+			validators.PositiveInt,
+		),
+		"PENDING_PURCHASE_WINDOW": validators.Chain(
+			validators.NotEmpty,
+			validators.PositiveInt,
+		),
+		"SOFT_DELETE_RETENTION_PERIOD": validators.Chain(
+			validators.NotEmpty,
+			validators.NonNegativeInt,
+		),
+		"PUBLISH_SCHEDULE_INTERVAL": validators.Chain(
+			validators.NotEmpty,
+			validators.PositiveInt,
+		),
+		"BACKUP_INTERVAL": validators.Chain(
+			validators.NotEmpty,
+			validators.Int,
+		),
+		"BACKUP_KEEP": validators.Chain(
+			validators.NotEmpty,
+			validators.Int,
+		),
+		"BACKUP_DIRECTORY":         validators.NotEmpty,
+		"BACKUP_UPLOAD":            validators.Bool,
+		"DATABASE_INSTRUMENTATION": validators.Bool,
+		"SLOW_QUERY_THRESHOLD": validators.Chain(
+			validators.NotEmpty,
+			validators.Int,
+		),
+	}
+
+	if err := envloader.SetDefaults(defaults); err != nil {
+		return err
+	}
+
+	return envloader.LoadEnvironment(variables)
+}
+
+// SetupDatabaseConfig only loads the environment variables needed to open a database connection. This is used
+// by the command line tools that don't need the rest of the web configuration.
+func SetupDatabaseConfig() error {
+	variables := map[string]validators.ValidationFunc{
+		"DATABASE_DRIVER": validators.InSlice([]string{sqliteDriver, postgresDriver}),
+		"DATABASE_URL":    validators.Empty,
+	}
+
+	if err := envloader.SetDefaults(defaults); err != nil {
+		return err
+	}
+
+	return envloader.LoadEnvironment(variables)
+}
+
+// SetupBackupConfig only loads the environment variables needed to make and restore database backups. This is used
+// by the backup command line tool.
+func SetupBackupConfig() error {
+	variables := map[string]validators.ValidationFunc{
+		"DATABASE_DRIVER": validators.InSlice([]string{sqliteDriver, postgresDriver}),
+		"BACKUP_KEEP": validators.Chain(
+			validators.NotEmpty,
+			validators.Int,
+		),
+		"BACKUP_DIRECTORY": validators.NotEmpty,
+		"BACKUP_UPLOAD":    validators.Bool,
+	}
+
+	if err := envloader.SetDefaults(defaults); err != nil {
+		return err
 	}
 
 	return envloader.LoadEnvironment(variables)
@@ -61,3 +143,11 @@ func InTesting() bool {
 func InProduction() bool {
 	return GetWithoutError[string]("ENVIRONMENT") == production
 }
+
+func UsingSQLite() bool {
+	return GetWithoutError[string]("DATABASE_DRIVER") == sqliteDriver
+}
+
+func UsingPostgres() bool {
+	return GetWithoutError[string]("DATABASE_DRIVER") == postgresDriver
+}
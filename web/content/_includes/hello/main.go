@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	name := "World"
+	if len(os.Args) > 1 {
+		name = os.Args[1]
+	}
+
+	fmt.Printf("Hello, %s!\n", name)
+}
@@ -1,24 +1,39 @@
 package content
 
 import (
+	"context"
+
 	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 )
 
 type Content struct {
 	utils.Loggers
+	Policy OrphanPolicy
+	DryRun bool
+	Report *Report
 }
 
-func RegisterContent(db database.Database) {
+// RegisterContent loads all tutorials and courses into the database and returns a report of what changed. Content
+// whose markdown file has been removed is handled according to the given orphan policy. A dry run only reads from the
+// database and returns a report of what would change.
+func RegisterContent(ctx context.Context, db database.Database, policy OrphanPolicy, dryRun bool) *Report {
 	loggers := utils.CreateLoggers("CONTENT")
 	content := &Content{
 		Loggers: loggers,
+		Policy:  policy,
+		DryRun:  dryRun,
+		Report:  &Report{DryRun: dryRun},
 	}
 
-	if err := db.DeleteAllKeywords(); err != nil {
-		loggers.ErrorLog.Fatalf("Failed to delete all keywords: %s\n", err)
+	if !dryRun {
+		if err := db.DeleteAllKeywords(ctx); err != nil {
+			loggers.ErrorLog.Fatalf("Failed to delete all keywords: %s\n", err)
+		}
 	}
 
-	content.RegisterTutorialsContent(db)
-	content.RegisterCourseContent(db)
+	content.RegisterTutorialsContent(ctx, db)
+	content.RegisterCourseContent(ctx, db)
+
+	return content.Report
 }
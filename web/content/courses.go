@@ -1,9 +1,12 @@
 package content
 
 import (
+	"context"
 	"crypto/sha256"
+	"database/sql"
 	"embed"
 	"encoding/hex"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,13 +20,16 @@ import (
 var coursesFS embed.FS
 
 type CourseMatter struct {
-	Title        string   `yaml:"title"`
-	Description  string   `yaml:"description"`
-	ThumbnailURL string   `yaml:"thumbnail_url"`
-	BannerURL    string   `yaml:"banner_url"`
-	Keywords     []string `yaml:"keywords"`
-	Directory    string   `yaml:"directory"`
-	Key          string   `yaml:"key"`
+	Title        string     `yaml:"title"`
+	Description  string     `yaml:"description"`
+	ThumbnailURL string     `yaml:"thumbnail_url"`
+	BannerURL    string     `yaml:"banner_url"`
+	Keywords     []string   `yaml:"keywords"`
+	Directory    string     `yaml:"directory"`
+	Slug         string     `yaml:"slug"`
+	Key          string     `yaml:"key"`
+	PublishAt    *time.Time `yaml:"publish_at"`
+	UnpublishAt  *time.Time `yaml:"unpublish_at"`
 }
 
 type CourseData struct {
@@ -32,10 +38,29 @@ type CourseData struct {
 }
 
 type ChapterMatter struct {
-	Title     string `yaml:"title"`
-	Chapter   int    `yaml:"chapter"`
-	CourseKey string `yaml:"course_key"`
-	Key       string `yaml:"key"`
+	Title            string      `yaml:"title"`
+	Chapter          int         `yaml:"chapter"`
+	CourseKey        string      `yaml:"course_key"`
+	Slug             string      `yaml:"slug"`
+	Key              string      `yaml:"key"`
+	ReleaseAfterDays *int        `yaml:"release_after_days"`
+	ReleaseAt        *time.Time  `yaml:"release_at"`
+	Quiz             *QuizMatter `yaml:"quiz"`
+}
+
+// QuizMatter is a quiz that has to be passed before a chapter can be finished.
+type QuizMatter struct {
+	PassMark  int                   `yaml:"pass_mark"`
+	Questions []*QuizQuestionMatter `yaml:"questions"`
+}
+
+// QuizQuestionMatter is a single question of a quiz. Type is one of "multiple_choice", "multiple_answers" or
+// "short_text". Short text questions don't have options and accept any of their answers.
+type QuizQuestionMatter struct {
+	Type     string   `yaml:"type"`
+	Question string   `yaml:"question"`
+	Options  []string `yaml:"options"`
+	Answers  []string `yaml:"answers"`
 }
 
 type ChapterData struct {
@@ -43,17 +68,19 @@ type ChapterData struct {
 	Content string
 }
 
-func (content *Content) RegisterCourseContent(db database.Database) {
-	courses, err := db.GetAllCourses("", nil)
+func (content *Content) RegisterCourseContent(ctx context.Context, db database.Database) {
+	courses, err := db.GetAllCourses(ctx, "", nil)
 	if err != nil {
 		content.ErrorLog.Fatalf("Failed to get all courses: %s\n", err)
 	}
 
-	chapters, err := db.GetAllChapters()
+	chapters, err := db.GetAllChapters(ctx)
 	if err != nil {
 		content.ErrorLog.Fatalf("Failed to get all chapters: %s\n", err)
 	}
 
+	schedules := content.publishSchedules(ctx, "course", db.GetCoursePublishSchedules)
+
 	files, err := coursesFS.ReadDir("courses")
 	if err != nil {
 		content.ErrorLog.Fatalf("Failed to read courses embedded file system: %s\n", err)
@@ -82,6 +109,10 @@ func (content *Content) RegisterCourseContent(db database.Database) {
 
 	timerStart := time.Now()
 
+	// Keep track of the file keys that still have a markdown file so that orphaned courses and chapters can be found.
+	courseKeys := make(map[string]bool, coursesFileCount)
+	chapterKeys := make(map[string]bool, chaptersFileCount)
+
 	for _, file := range files {
 		if file.IsDir() {
 			chapterFiles, err := coursesFS.ReadDir("courses/" + file.Name())
@@ -91,16 +122,50 @@ func (content *Content) RegisterCourseContent(db database.Database) {
 
 			for _, chapterFile := range chapterFiles {
 				filePath := "courses/" + file.Name() + "/" + chapterFile.Name()
-				content.ParseChapterFile(filePath, db, chapters)
+				chapterKeys[content.ParseChapterFile(ctx, filePath, db, chapters)] = true
 			}
 		} else {
 			filePath := "courses/" + file.Name()
-			content.ParseCourseFile(filePath, db, courses)
+			courseKeys[content.ParseCourseFile(ctx, filePath, db, courses, schedules)] = true
+		}
+	}
+
+	// Chapters that were removed from a course that still exists are always deleted. This happens in the same
+	// transaction as the other chapter changes so that the remaining chapters can be renumbered. Chapters of
+	// orphaned courses are left to the orphan policy.
+	for _, chapter := range chapters {
+		if chapterKeys[chapter.FileKey] {
+			continue
+		}
+
+		courseIndex, courseFound := utils.InSliceFunc(chapter.CourseID, courses, func(courseId string, course *models.CourseModel) bool {
+			return courseId == course.ID
+		})
+
+		if courseFound && !courseKeys[courses[courseIndex].FileKey] {
+			continue
 		}
+
+		db.DeleteChapter(chapter.ID)
+		content.Report.add("chapter", chapter.Title, "Deleted")
 	}
 
-	if err := db.RunBulkCourses(); err != nil {
-		content.ErrorLog.Fatalf("Failed to bulk parse courses: %s\n", err)
+	// The staged changes are thrown away during a dry run. They get cleared the next time the bulk functions are prepared.
+	if !content.DryRun {
+		if err := db.RunBulkCourses(ctx); err != nil {
+			content.ErrorLog.Fatalf("Failed to bulk parse courses: %s\n", err)
+		}
+	}
+
+	archived, err := archivedCourses(ctx, db)
+	if err != nil {
+		content.ErrorLog.Fatalf("Failed to read archived courses from the database: %s\n", err)
+	}
+
+	for _, course := range courses {
+		if !courseKeys[course.FileKey] {
+			content.handleOrphanedCourse(ctx, db, course, archived[course.ID])
+		}
 	}
 
 	timerEnd := time.Since(timerStart)
@@ -108,7 +173,8 @@ func (content *Content) RegisterCourseContent(db database.Database) {
 	content.InfoLog.Printf("Parsed %d courses and %d chapters in %s\n", coursesFileCount, chaptersFileCount, timerEnd)
 }
 
-func (content *Content) ParseChapterFile(filePath string, db database.Database, chapters []*models.ChapterModel) {
+// ParseChapterFile stages the chapter for insertion or update if it's new or has changed and returns its file key.
+func (content *Content) ParseChapterFile(ctx context.Context, filePath string, db database.Database, chapters []*models.ChapterModel) string {
 	output, err := coursesFS.ReadFile(filePath)
 	if err != nil {
 		content.ErrorLog.Printf("Failed to read chapter file (\"%s\") in courses embedded file system: %s\n", filePath, err)
@@ -127,8 +193,23 @@ func (content *Content) ParseChapterFile(filePath string, db database.Database,
 	hasher.Write(output)
 	fileChecksum := hex.EncodeToString(hasher.Sum(nil))
 
+	rendered, err := RenderMarkdown(data)
+	if err != nil {
+		content.ErrorLog.Fatalf("Failed to render markdown from \"%s\": %s\n", filePath, err)
+	}
+
 	chapterData.ChapterMatter = *chapterMatter
-	chapterData.Content = string(MarkdownToHTML(data))
+	chapterData.Content = rendered.HTML
+
+	releaseAfterDays, releaseAt, err := ChapterRelease(chapterMatter.ReleaseAfterDays, chapterMatter.ReleaseAt)
+	if err != nil {
+		content.ErrorLog.Fatalf("Invalid release in \"%s\": %s\n", filePath, err)
+	}
+
+	quiz, err := ChapterQuiz(chapterMatter.Quiz)
+	if err != nil {
+		content.ErrorLog.Fatalf("Invalid quiz in \"%s\": %s\n", filePath, err)
+	}
 
 	fileKeyIndex, fileKeyFound := utils.InSliceFunc(chapterMatter.Key, chapters, func(fileKey string, chapter *models.ChapterModel) bool {
 		return fileKey == chapter.FileKey
@@ -141,24 +222,32 @@ func (content *Content) ParseChapterFile(filePath string, db database.Database,
 
 	// The chapter already exists and hasn't been updated.
 	if fileKeyFound && checksumMatch {
-		return
+		return chapterMatter.Key
 	}
 
+	slug := ContentSlug(chapterData.Slug, chapterData.Title)
+
 	// The chapter does not yet exist.
 	if !fileKeyFound {
-		db.InsertChapter(chapterData.Title, TitleToSlug(chapterData.Title), chapterData.Chapter, chapterData.Content, fileChecksum, chapterData.Key, chapterData.CourseKey)
-		return
+		db.InsertChapter(chapterData.Title, slug, chapterData.Chapter, chapterData.Content, rendered.TableOfContents, rendered.WordCount, rendered.ReadingTime, fileChecksum, chapterData.Key, chapterData.CourseKey, releaseAfterDays, releaseAt, quiz)
+		content.Report.addChange(content.describe("chapter", chapterData.Title, "Added", filePath, nil, nil, "", chapterDocument(chapterData.Title, slug, chapterData.Chapter, releaseAfterDays, releaseAt, chapterData.Content)))
+		return chapterMatter.Key
 	}
 
 	// The chapter has been updated.
 	if !checksumMatch {
-		content.InfoLog.Printf("%s's file checksum didn't match.\nOld file checksum: %s\t New file checksum: %s\n", chapters[fileKeyIndex].FileKey, chapters[fileKeyIndex].FileChecksum, fileChecksum)
-		db.UpdateChapter(chapters[fileKeyIndex].ID, chapterData.Title, TitleToSlug(chapterData.Title), chapterData.Chapter, chapterData.Content, fileChecksum, chapterData.Key, chapterData.CourseKey)
-		return
+		chapter := chapters[fileKeyIndex]
+
+		content.InfoLog.Printf("%s's file checksum didn't match.\nOld file checksum: %s\t New file checksum: %s\n", chapter.FileKey, chapter.FileChecksum, fileChecksum)
+		db.UpdateChapter(chapter.ID, chapterData.Title, slug, chapterData.Chapter, chapterData.Content, rendered.TableOfContents, rendered.WordCount, rendered.ReadingTime, fileChecksum, chapterData.Key, chapterData.CourseKey, releaseAfterDays, releaseAt, quiz)
+		content.Report.addChange(content.describe("chapter", chapterData.Title, "Updated", filePath, nil, nil, chapterDocument(chapter.Title, chapter.Slug, chapter.Chapter, chapter.ReleaseAfterDays, chapter.ReleaseAt, chapter.Content), chapterDocument(chapterData.Title, slug, chapterData.Chapter, releaseAfterDays, releaseAt, chapterData.Content)))
 	}
+
+	return chapterMatter.Key
 }
 
-func (content *Content) ParseCourseFile(filePath string, db database.Database, courses []*models.CourseModel) {
+// ParseCourseFile stages the course for insertion or update if it's new or has changed and returns its file key.
+func (content *Content) ParseCourseFile(ctx context.Context, filePath string, db database.Database, courses []*models.CourseModel, schedules map[string]*models.PublishScheduleModel) string {
 	output, err := coursesFS.ReadFile(filePath)
 	if err != nil {
 		content.ErrorLog.Printf("Failed to read course file (\"%s\") in courses embedded file system: %s\n", filePath, err)
@@ -177,8 +266,13 @@ func (content *Content) ParseCourseFile(filePath string, db database.Database, c
 	hasher.Write(output)
 	fileChecksum := hex.EncodeToString(hasher.Sum(nil))
 
+	courseHTML, err := MarkdownToHTML(data)
+	if err != nil {
+		content.ErrorLog.Fatalf("Failed to render markdown from \"%s\": %s\n", filePath, err)
+	}
+
 	courseData.CourseMatter = *courseMatter
-	courseData.Content = string(MarkdownToHTML(data))
+	courseData.Content = string(courseHTML)
 
 	fileKeyIndex, fileKeyFound := utils.InSliceFunc(courseMatter.Key, courses, func(fileKey string, course *models.CourseModel) bool {
 		return fileKey == course.FileKey
@@ -191,18 +285,67 @@ func (content *Content) ParseCourseFile(filePath string, db database.Database, c
 
 	// The chapter already exists and hasn't been updated.
 	if fileKeyFound && checksumMatch {
-		return
+		return courseMatter.Key
 	}
 
+	slug := ContentSlug(courseData.Slug, courseData.Title)
+	publishAt := ScheduleTime(courseData.PublishAt)
+	unpublishAt := ScheduleTime(courseData.UnpublishAt)
+
 	// The chapter does not yet exist.
 	if !fileKeyFound {
-		db.InsertCourse(courseData.Title, TitleToSlug(courseData.Title), courseData.Description, courseData.ThumbnailURL, courseData.BannerURL, courseData.Content, fileChecksum, courseData.Key, courseData.Keywords)
-		return
+		db.InsertCourse(courseData.Title, slug, courseData.Description, courseData.ThumbnailURL, courseData.BannerURL, courseData.Content, fileChecksum, courseData.Key, courseData.Keywords, publishAt, unpublishAt)
+		content.Report.addChange(content.describe("course", courseData.Title, "Added", filePath, nil, courseData.Keywords, "", courseDocument(courseData.Title, slug, courseData.Description, courseData.ThumbnailURL, courseData.BannerURL, publishAt, unpublishAt, courseData.Content)))
+		return courseMatter.Key
 	}
 
 	// The chapter has been updated.
 	if !checksumMatch {
-		db.UpdateCourse(courses[fileKeyIndex].ID, courseData.Title, TitleToSlug(courseData.Title), courseData.Description, courseData.ThumbnailURL, courseData.BannerURL, courseData.Content, fileChecksum, courseData.Key, courseData.Keywords, courses[fileKeyIndex].AuthorID)
-		return
+		course := courses[fileKeyIndex]
+
+		db.UpdateCourse(course.ID, courseData.Title, slug, courseData.Description, courseData.ThumbnailURL, courseData.BannerURL, courseData.Content, fileChecksum, courseData.Key, courseData.Keywords, course.AuthorID, publishAt, unpublishAt)
+
+		var keywords []string
+		if content.DryRun {
+			keywords, err = db.GetAllKeywordsForCourse(ctx, course.ID)
+			if err != nil {
+				content.ErrorLog.Fatalf("Failed to get keywords for course \"%s\": %s\n", course.Title, err)
+			}
+		}
+
+		oldPublishAt, oldUnpublishAt := scheduleOf(schedules[course.ID])
+
+		content.Report.addChange(content.describe("course", courseData.Title, "Updated", filePath, keywords, courseData.Keywords, courseDocument(course.Title, course.Slug, course.Description, course.ThumbnailURL, course.BannerURL, oldPublishAt, oldUnpublishAt, course.Content), courseDocument(courseData.Title, slug, courseData.Description, courseData.ThumbnailURL, courseData.BannerURL, publishAt, unpublishAt, courseData.Content)))
 	}
+
+	return courseMatter.Key
+}
+
+// courseDocument lays out a course as text so that the version in the database can be diffed with the file.
+func courseDocument(title, slug, description, thumbnailUrl, bannerUrl string, publishAt, unpublishAt sql.NullTime, html string) string {
+	return document([]string{
+		"title: " + title,
+		"slug: " + slug,
+		"description: " + description,
+		"thumbnail_url: " + thumbnailUrl,
+		"banner_url: " + bannerUrl,
+		"publish_at: " + scheduleString(publishAt),
+		"unpublish_at: " + scheduleString(unpublishAt),
+	}, html)
+}
+
+// chapterDocument lays out a chapter as text so that the version in the database can be diffed with the file.
+func chapterDocument(title, slug string, chapter int, releaseAfterDays sql.NullInt32, releaseAt sql.NullTime, html string) string {
+	days := ""
+	if releaseAfterDays.Valid {
+		days = strconv.Itoa(int(releaseAfterDays.Int32))
+	}
+
+	return document([]string{
+		"title: " + title,
+		"slug: " + slug,
+		"chapter: " + strconv.Itoa(chapter),
+		"release_after_days: " + days,
+		"release_at: " + scheduleString(releaseAt),
+	}, html)
 }
@@ -0,0 +1,391 @@
+package content
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/gomarkdown/markdown/ast"
+)
+
+// includesDir is the directory in the content tree that ::include directives read their source files from.
+const includesDir = "_includes"
+
+// The directory starts with an underscore so that the go tool doesn't try to build the source files in it.
+//
+//go:embed _includes
+var includesFS embed.FS
+
+// containerDirectiveRegex matches the line that opens a container directive, for example ":::note Heads up". The
+// number of colons is captured so that the matching closing line can be found.
+var containerDirectiveRegex = regexp.MustCompile(`^(:{3,})([a-z]+)(?:[ \t]+(.*?))?[ \t]*$`)
+
+// includeDirectiveRegex matches an include directive and captures its attributes, for example
+// ::include{file="hello/main.go" lines="3-5"}.
+var includeDirectiveRegex = regexp.MustCompile(`^::include\{(.*)\}[ \t]*$`)
+
+// directiveAttributeRegex matches a single key="value" attribute of an include directive.
+var directiveAttributeRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// calloutTitles are the titles that callouts get when the directive doesn't set one.
+var calloutTitles = map[string]string{
+	"note":    "Note",
+	"tip":     "Tip",
+	"warning": "Warning",
+}
+
+// DirectiveError describes a directive in a markdown file that couldn't be rendered.
+type DirectiveError struct {
+	Directive string
+	Message   string
+}
+
+func (err *DirectiveError) Error() string {
+	return fmt.Sprintf("\"%s\" %s", err.Directive, err.Message)
+}
+
+// directive holds the line that opened a container directive so that errors can point back at it.
+type directive struct {
+	ast.Container
+	line string
+}
+
+// callout is a note, tip or warning that stands out from the rest of the content.
+type callout struct {
+	directive
+	kind  string
+	title string
+}
+
+// tabs groups tab directives so that only one of them is shown at a time.
+type tabs struct {
+	directive
+	id int
+}
+
+// tab is a single panel of a tabs directive.
+type tab struct {
+	directive
+	label string
+}
+
+// solution is a block that stays collapsed until the reader chooses to open it.
+type solution struct {
+	directive
+	summary string
+}
+
+// directiveParser is a markdown parser hook that turns directives into nodes. The first error it comes across is kept
+// because the hook itself has no way of returning one.
+type directiveParser struct {
+	files fs.FS
+	err   error
+}
+
+// parse is called at the start of every block. It returns the number of bytes that the directive used up, or 0 if the
+// block isn't a directive. The body of a container directive is returned so that it gets parsed as markdown.
+func (parser *directiveParser) parse(data []byte) (ast.Node, []byte, int) {
+	if parser.err != nil || !bytes.HasPrefix(data, []byte("::")) {
+		return nil, nil, 0
+	}
+
+	line, _, _ := bytes.Cut(data, []byte("\n"))
+	line = bytes.TrimRight(line, "\r")
+
+	if match := includeDirectiveRegex.FindSubmatch(line); match != nil {
+		block, err := parser.include(string(match[1]))
+		if err != nil {
+			parser.err = &DirectiveError{Directive: string(line), Message: err.Error()}
+			return nil, nil, 0
+		}
+
+		return block, nil, lineLength(data)
+	}
+
+	match := containerDirectiveRegex.FindSubmatch(line)
+	if match == nil {
+		return nil, nil, 0
+	}
+
+	node := newDirective(string(match[2]), strings.TrimSpace(string(match[3])), string(line))
+	if node == nil {
+		parser.err = &DirectiveError{Directive: string(line), Message: "is not a known directive"}
+		return nil, nil, 0
+	}
+
+	body, consumed, closed := containerBody(data, string(match[1]))
+	if !closed {
+		parser.err = &DirectiveError{Directive: string(line), Message: fmt.Sprintf("is never closed with \"%s\"", match[1])}
+		return nil, nil, 0
+	}
+
+	return node, body, consumed
+}
+
+// include reads the source file that an include directive points at and turns it into a code block.
+func (parser *directiveParser) include(attributes string) (*ast.CodeBlock, error) {
+	attrs := make(map[string]string)
+	for _, match := range directiveAttributeRegex.FindAllStringSubmatch(attributes, -1) {
+		attrs[match[1]] = match[2]
+	}
+
+	// Anything left over, like a stray double quote in a title, would otherwise be dropped without a word and could
+	// end the title early in the info string of the code block.
+	if leftover := strings.TrimSpace(directiveAttributeRegex.ReplaceAllString(attributes, "")); leftover != "" {
+		return nil, fmt.Errorf("has attributes that can't be read: \"%s\"", leftover)
+	}
+
+	file := attrs["file"]
+	if file == "" {
+		return nil, fmt.Errorf("needs a file attribute")
+	}
+
+	if !fs.ValidPath(file) || file == "." {
+		return nil, fmt.Errorf("has to point at a file inside of the %s directory", includesDir)
+	}
+
+	source, err := fs.ReadFile(parser.files, path.Join(includesDir, file))
+	if err != nil {
+		return nil, fmt.Errorf("could not read \"%s\" from the %s directory", file, includesDir)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(source), "\n"), "\n")
+
+	if attrs["lines"] != "" {
+		from, to, err := parseLineRange(attrs["lines"], len(lines))
+		if err != nil {
+			return nil, err
+		}
+
+		lines = lines[from-1 : to]
+	}
+
+	language := attrs["lang"]
+	if language == "" {
+		language = strings.TrimPrefix(path.Ext(file), ".")
+	}
+
+	title := attrs["title"]
+	if title == "" {
+		title = file
+	}
+
+	// The language ends up in the info string of the code block as a single word.
+	if strings.ContainsAny(language, " \t{}") {
+		return nil, fmt.Errorf("has an invalid language \"%s\"", language)
+	}
+
+	return &ast.CodeBlock{
+		IsFenced: true,
+		Info:     []byte(fmt.Sprintf("%s title=\"%s\"", language, title)),
+		Leaf:     ast.Leaf{Literal: []byte(strings.Join(lines, "\n") + "\n")},
+	}, nil
+}
+
+// newDirective creates the node for a container directive or returns nil if the name isn't known.
+func newDirective(name, argument, line string) ast.Node {
+	base := directive{line: line}
+
+	switch name {
+	case "note", "tip", "warning":
+		if argument == "" {
+			argument = calloutTitles[name]
+		}
+
+		return &callout{directive: base, kind: name, title: argument}
+
+	case "tabs":
+		return &tabs{directive: base}
+
+	case "tab":
+		if argument == "" {
+			argument = "Tab"
+		}
+
+		return &tab{directive: base, label: argument}
+
+	case "solution":
+		if argument == "" {
+			argument = "Solution"
+		}
+
+		return &solution{directive: base, summary: argument}
+	}
+
+	return nil
+}
+
+// containerBody finds the line that closes a container directive. It returns the body between the opening and closing
+// lines, the number of bytes up to and including the closing line and whether the closing line was found. Lines inside
+// fenced code blocks are skipped so that code can contain colons.
+func containerBody(data []byte, fence string) ([]byte, int, bool) {
+	start := lineLength(data)
+	inCodeBlock := false
+
+	for offset := start; offset < len(data); {
+		length := lineLength(data[offset:])
+		line := strings.TrimSpace(string(data[offset : offset+length]))
+
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inCodeBlock = !inCodeBlock
+		} else if !inCodeBlock && line == fence {
+			return data[start:offset], offset + length, true
+		}
+
+		offset += length
+	}
+
+	return nil, 0, false
+}
+
+// lineLength returns the length of the first line of data, including its line ending.
+func lineLength(data []byte) int {
+	if end := bytes.IndexByte(data, '\n'); end >= 0 {
+		return end + 1
+	}
+
+	return len(data)
+}
+
+// parseLineRange reads a range of lines such as "3-10", "3-" or "3" and checks that it fits in a file with the given
+// number of lines.
+func parseLineRange(lines string, count int) (int, int, error) {
+	start, end, isRange := strings.Cut(strings.TrimSpace(lines), "-")
+
+	from, err := strconv.Atoi(start)
+	if err != nil {
+		return 0, 0, fmt.Errorf("has an invalid line range \"%s\"", lines)
+	}
+
+	to := from
+
+	if isRange {
+		to = count
+
+		if end != "" {
+			to, err = strconv.Atoi(end)
+			if err != nil {
+				return 0, 0, fmt.Errorf("has an invalid line range \"%s\"", lines)
+			}
+		}
+	}
+
+	if from < 1 || to < from || to > count {
+		return 0, 0, fmt.Errorf("line range \"%s\" doesn't fit in a file with %d lines", lines, count)
+	}
+
+	return from, to, nil
+}
+
+// validateDirectives checks the directives that depend on each other. Tabs can only contain tab directives and a tab
+// directive has to be inside of tabs.
+func validateDirectives(doc ast.Node) error {
+	var err error
+
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+
+		switch node := node.(type) {
+		case *tabs:
+			if len(node.Children) == 0 {
+				err = &DirectiveError{Directive: node.line, Message: "needs at least one tab"}
+				return ast.Terminate
+			}
+
+			for _, child := range node.Children {
+				if _, ok := child.(*tab); !ok {
+					err = &DirectiveError{Directive: node.line, Message: "can only contain tab directives"}
+					return ast.Terminate
+				}
+			}
+
+		case *tab:
+			if _, ok := node.Parent.(*tabs); !ok {
+				err = &DirectiveError{Directive: node.line, Message: "has to be inside of a tabs directive"}
+				return ast.Terminate
+			}
+		}
+
+		return ast.GoToNext
+	})
+
+	return err
+}
+
+// directiveRenderer renders directives to HTML and hands everything else over to the code highlighter. Every document
+// needs its own renderer so that the IDs of its tab groups are unique.
+type directiveRenderer struct {
+	tabGroups int
+}
+
+func (renderer *directiveRenderer) renderNode(w io.Writer, node ast.Node, entering bool) (ast.WalkStatus, bool) {
+	switch node := node.(type) {
+	case *callout:
+		if entering {
+			fmt.Fprintf(w, "<aside class=\"callout callout-%s\" role=\"note\">\n<p class=\"callout-title\">%s</p>\n", node.kind, html.EscapeString(node.title))
+		} else {
+			io.WriteString(w, "</aside>\n")
+		}
+
+	case *tabs:
+		if entering {
+			renderer.tabGroups++
+			node.id = renderer.tabGroups
+
+			io.WriteString(w, "<div class=\"tabs\">\n")
+		} else {
+			io.WriteString(w, "</div>\n")
+		}
+
+	case *tab:
+		if entering {
+			group := node.Parent.(*tabs)
+			index := tabIndex(group, node)
+			id := fmt.Sprintf("tabs-%d-%d", group.id, index)
+
+			checked := ""
+			if index == 0 {
+				checked = " checked"
+			}
+
+			fmt.Fprintf(w, "<input type=\"radio\" class=\"tab-input\" name=\"tabs-%d\" id=\"%s\" aria-controls=\"%s-panel\"%s>\n", group.id, id, id, checked)
+			fmt.Fprintf(w, "<label class=\"tab-label\" for=\"%s\" id=\"%s-label\">%s</label>\n", id, id, html.EscapeString(node.label))
+			fmt.Fprintf(w, "<div class=\"tab-panel\" id=\"%s-panel\" role=\"region\" aria-labelledby=\"%s-label\">\n", id, id)
+		} else {
+			io.WriteString(w, "</div>\n")
+		}
+
+	case *solution:
+		if entering {
+			fmt.Fprintf(w, "<details class=\"solution\">\n<summary>%s</summary>\n<div class=\"solution-body\">\n", html.EscapeString(node.summary))
+		} else {
+			io.WriteString(w, "</div>\n</details>\n")
+		}
+
+	default:
+		return utils.HighlightCode(w, node, entering)
+	}
+
+	return ast.GoToNext, true
+}
+
+// tabIndex returns the position of a tab within its group.
+func tabIndex(group *tabs, node *tab) int {
+	for i, child := range group.Children {
+		if child == ast.Node(node) {
+			return i
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,164 @@
+package content
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gomarkdown/markdown"
+)
+
+func TestDirectives(t *testing.T) {
+	files := fstest.MapFS{
+		"_includes/hello/main.go": {Data: []byte("package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"Hello\")\n}\n")},
+		"secret.go":               {Data: []byte("package secret\n")},
+	}
+
+	tests := []struct {
+		name     string
+		markdown string
+		contains []string
+		excludes []string
+		err      string
+	}{
+		{
+			name:     "Callout with default title",
+			markdown: ":::note\nRemember this.\n:::\n",
+			contains: []string{`<aside class="callout callout-note" role="note">`, `<p class="callout-title">Note</p>`, "<p>Remember this.</p>"},
+		},
+		{
+			name:     "Callout with escaped title",
+			markdown: ":::warning Don't <script>\nCareful.\n:::\n",
+			contains: []string{`<aside class="callout callout-warning" role="note">`, "Don&#39;t &lt;script&gt;"},
+			excludes: []string{"<script>"},
+		},
+		{
+			name:     "Unknown directive",
+			markdown: ":::danger\nBoom.\n:::\n",
+			err:      "is not a known directive",
+		},
+		{
+			name:     "Unclosed directive",
+			markdown: ":::tip\nNever closed.\n",
+			err:      "is never closed with \":::\"",
+		},
+		{
+			name:     "Closing fence inside code block",
+			markdown: ":::tip\n```\n:::\n```\n:::\n",
+			contains: []string{`<aside class="callout callout-tip" role="note">`},
+		},
+		{
+			name:     "Tabs",
+			markdown: "::::tabs\n:::tab Go\nGo code.\n:::\n:::tab Python\nPython code.\n:::\n::::\n",
+			contains: []string{
+				`<div class="tabs">`,
+				`<input type="radio" class="tab-input" name="tabs-1" id="tabs-1-0" aria-controls="tabs-1-0-panel" checked>`,
+				`<input type="radio" class="tab-input" name="tabs-1" id="tabs-1-1" aria-controls="tabs-1-1-panel">`,
+				`<label class="tab-label" for="tabs-1-0" id="tabs-1-0-label">Go</label>`,
+				`<label class="tab-label" for="tabs-1-1" id="tabs-1-1-label">Python</label>`,
+			},
+		},
+		{
+			name:     "Tabs without tabs",
+			markdown: "::::tabs\n::::\n",
+			err:      "needs at least one tab",
+		},
+		{
+			name:     "Tabs with other content",
+			markdown: "::::tabs\nNot a tab.\n::::\n",
+			err:      "can only contain tab directives",
+		},
+		{
+			name:     "Tab outside of tabs",
+			markdown: ":::tab Go\nGo code.\n:::\n",
+			err:      "has to be inside of a tabs directive",
+		},
+		{
+			name:     "Solution",
+			markdown: ":::solution\nThe answer.\n:::\n",
+			contains: []string{`<details class="solution">`, "<summary>Solution</summary>", "<p>The answer.</p>"},
+		},
+		{
+			name:     "Solution with summary",
+			markdown: ":::solution Show answer\nThe answer.\n:::\n",
+			contains: []string{"<summary>Show answer</summary>"},
+		},
+		{
+			name:     "Include",
+			markdown: "::include{file=\"hello/main.go\"}\n",
+			contains: []string{"<figcaption>hello/main.go</figcaption>", "Println"},
+		},
+		{
+			name:     "Include with lines and title",
+			markdown: "::include{file=\"hello/main.go\" lines=\"1\" title=\"Package\"}\n",
+			contains: []string{"<figcaption>Package</figcaption>", "main"},
+			excludes: []string{"Println"},
+		},
+		{
+			name:     "Include without file",
+			markdown: "::include{lines=\"1\"}\n",
+			err:      "needs a file attribute",
+		},
+		{
+			name:     "Include of missing file",
+			markdown: "::include{file=\"hello/missing.go\"}\n",
+			err:      "could not read \"hello/missing.go\"",
+		},
+		{
+			name:     "Include outside of the includes directory",
+			markdown: "::include{file=\"../secret.go\"}\n",
+			err:      "has to point at a file inside of the _includes directory",
+		},
+		{
+			name:     "Include with absolute path",
+			markdown: "::include{file=\"/secret.go\"}\n",
+			err:      "has to point at a file inside of the _includes directory",
+		},
+		{
+			name:     "Include with invalid line range",
+			markdown: "::include{file=\"hello/main.go\" lines=\"5-100\"}\n",
+			err:      "doesn't fit in a file with 7 lines",
+		},
+		{
+			name:     "Include with double quote in title",
+			markdown: "::include{file=\"hello/main.go\" title=\"Say \"Hello\"\"}\n",
+			err:      "has attributes that can't be read",
+		},
+		{
+			name:     "Include with invalid language",
+			markdown: "::include{file=\"hello/main.go\" lang=\"go {1-3}\"}\n",
+			err:      "has an invalid language",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := parseMarkdown([]byte(tt.markdown), files)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Expected an error containing \"%s\". Got %v", tt.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Failed to parse markdown: %s", err)
+			}
+
+			rendered := string(markdown.Render(doc, newHTMLRenderer()))
+
+			for _, text := range tt.contains {
+				if !strings.Contains(rendered, text) {
+					t.Errorf("Expected the HTML to contain %q. Got %q", text, rendered)
+				}
+			}
+
+			for _, text := range tt.excludes {
+				if strings.Contains(rendered, text) {
+					t.Errorf("Expected the HTML not to contain %q. Got %q", text, rendered)
+				}
+			}
+		})
+	}
+}
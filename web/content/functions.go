@@ -2,28 +2,140 @@ package content
 
 import (
 	"crypto/rand"
+	"database/sql"
 	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 	"unicode"
 
+	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
 	"github.com/gomarkdown/markdown/html"
 	"github.com/gomarkdown/markdown/parser"
 )
 
-func MarkdownToHTML(md []byte) []byte {
-	// create markdown parser with extensions
-	extensions := parser.CommonExtensions | parser.AutoHeadingIDs | parser.NoEmptyLineBeforeBlock
+// wordsPerMinute is the reading speed that the reading time of tutorials and chapters is based on.
+const wordsPerMinute = 200
+
+// RenderedMarkdown is the HTML of a markdown file along with the information that was gathered from it.
+type RenderedMarkdown struct {
+	HTML            string
+	TableOfContents models.TableOfContents
+	WordCount       int
+	ReadingTime     int
+}
+
+// MarkdownToHTML converts markdown, including any directives, into HTML.
+func MarkdownToHTML(md []byte) ([]byte, error) {
+	doc, err := parseMarkdown(md, includesFS)
+	if err != nil {
+		return nil, err
+	}
+
+	return markdown.Render(doc, newHTMLRenderer()), nil
+}
+
+// RenderMarkdown converts markdown into HTML and builds a table of contents from its headings. The words in code
+// blocks aren't counted towards the reading time because code gets skimmed rather than read.
+func RenderMarkdown(md []byte) (*RenderedMarkdown, error) {
+	doc, err := parseMarkdown(md, includesFS)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := &RenderedMarkdown{
+		HTML:            string(markdown.Render(doc, newHTMLRenderer())),
+		TableOfContents: models.TableOfContents{},
+	}
+
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		if !entering {
+			return ast.GoToNext
+		}
+
+		switch node := node.(type) {
+		case *ast.Heading:
+			if node.HeadingID != "" {
+				rendered.TableOfContents = append(rendered.TableOfContents, models.Heading{
+					ID:    node.HeadingID,
+					Title: nodeText(node),
+					Level: node.Level,
+				})
+			}
+
+		case *ast.Text:
+			rendered.WordCount += len(strings.Fields(string(node.Literal)))
+
+		case *ast.Code:
+			rendered.WordCount += len(strings.Fields(string(node.Literal)))
+		}
+
+		return ast.GoToNext
+	})
+
+	rendered.ReadingTime = ReadingTime(rendered.WordCount)
+
+	return rendered, nil
+}
+
+// ReadingTime estimates how many minutes it takes to read the given number of words, rounded up.
+func ReadingTime(words int) int {
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// parseMarkdown parses markdown along with its directives. The files of include directives are read from the given
+// file system.
+func parseMarkdown(md []byte, files fs.FS) (ast.Node, error) {
+	directives := &directiveParser{files: files}
+
+	// create markdown parser with extensions. Definition lists are turned off because their ": " syntax would swallow
+	// the directive that follows a paragraph.
+	extensions := (parser.CommonExtensions | parser.AutoHeadingIDs | parser.NoEmptyLineBeforeBlock) &^ parser.DefinitionLists
 	p := parser.NewWithExtensions(extensions)
+	p.Opts.ParserHook = directives.parse
+
 	doc := p.Parse(md)
+	if directives.err != nil {
+		return nil, directives.err
+	}
 
+	if err := validateDirectives(doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func newHTMLRenderer() *html.Renderer {
 	// create HTML renderer with extensions
 	htmlFlags := html.CommonFlags | html.HrefTargetBlank
-	opts := html.RendererOptions{Flags: htmlFlags}
-	renderer := html.NewRenderer(opts)
+	directives := &directiveRenderer{}
+	opts := html.RendererOptions{Flags: htmlFlags, RenderNodeHook: directives.renderNode}
+
+	return html.NewRenderer(opts)
+}
+
+// nodeText returns the plain text of a node and its children, leaving out any formatting.
+func nodeText(node ast.Node) string {
+	var text strings.Builder
+
+	ast.WalkFunc(node, func(child ast.Node, entering bool) ast.WalkStatus {
+		if entering {
+			if leaf := child.AsLeaf(); leaf != nil {
+				text.Write(leaf.Literal)
+			}
+		}
+
+		return ast.GoToNext
+	})
 
-	return markdown.Render(doc, renderer)
+	return strings.TrimSpace(text.String())
 }
 
 func TitleToSlug(title string) string {
@@ -51,6 +163,109 @@ func TitleToSlug(title string) string {
 	return slug
 }
 
+// ContentSlug returns the slug for a tutorial, course or chapter. The slug set in the frontmatter wins so that a title
+// can be changed without changing the URL. It still gets cleaned up the same way as a title would be.
+func ContentSlug(slug, title string) string {
+	if strings.TrimSpace(slug) != "" {
+		return TitleToSlug(slug)
+	}
+
+	return TitleToSlug(title)
+}
+
+// ScheduleTime converts an optional publish_at or unpublish_at time from the frontmatter to a value that can be stored
+// in the database. The time is converted to UTC because the databases compare it against their own UTC clock and a
+// time with an offset (eg "2025-03-01T09:00:00+02:00") would otherwise fire hours early or late.
+func ScheduleTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// ChapterRelease checks the release_after_days and release_at fields from a chapter's frontmatter and converts them to
+// values that can be stored in the database. A chapter can only be released one way so at most one of them can be set.
+// release_at goes through ScheduleTime so that it gets compared against the database's clock in UTC.
+func ChapterRelease(releaseAfterDays *int, releaseAt *time.Time) (sql.NullInt32, sql.NullTime, error) {
+	if releaseAfterDays != nil && releaseAt != nil {
+		return sql.NullInt32{}, sql.NullTime{}, errors.New("\"release_after_days\" and \"release_at\" can't both be set")
+	}
+
+	if releaseAfterDays == nil {
+		return sql.NullInt32{}, ScheduleTime(releaseAt), nil
+	}
+
+	if *releaseAfterDays < 0 {
+		return sql.NullInt32{}, sql.NullTime{}, errors.New("\"release_after_days\" needs to be 0 or more")
+	}
+
+	return sql.NullInt32{Int32: int32(*releaseAfterDays), Valid: true}, sql.NullTime{}, nil
+}
+
+// ChapterQuiz checks the quiz from a chapter's frontmatter and converts it to a model that can be stored in the
+// database. Nil is returned if the chapter doesn't have a quiz.
+func ChapterQuiz(quiz *QuizMatter) (*models.QuizModel, error) {
+	if quiz == nil {
+		return nil, nil
+	}
+
+	if quiz.PassMark < 1 || quiz.PassMark > 100 {
+		return nil, errors.New("needs a \"pass_mark\" between 1 and 100")
+	}
+
+	if len(quiz.Questions) == 0 {
+		return nil, errors.New("needs at least one question")
+	}
+
+	model := &models.QuizModel{PassMark: quiz.PassMark}
+
+	for i, question := range quiz.Questions {
+		if question == nil || strings.TrimSpace(question.Question) == "" {
+			return nil, fmt.Errorf("question %d needs a \"question\"", i+1)
+		}
+
+		if len(question.Answers) == 0 {
+			return nil, fmt.Errorf("question %d needs at least one answer", i+1)
+		}
+
+		switch question.Type {
+		case models.MultipleChoiceQuestion, models.MultipleAnswersQuestion:
+			if len(question.Options) < 2 {
+				return nil, fmt.Errorf("question %d needs at least two options", i+1)
+			}
+
+			if question.Type == models.MultipleChoiceQuestion && len(question.Answers) != 1 {
+				return nil, fmt.Errorf("question %d is multiple choice so it needs exactly one answer", i+1)
+			}
+
+			for _, answer := range question.Answers {
+				if !slices.Contains(question.Options, answer) {
+					return nil, fmt.Errorf("question %d has an answer (\"%s\") that isn't one of its options", i+1, answer)
+				}
+			}
+
+		case models.ShortTextQuestion:
+			if len(question.Options) != 0 {
+				return nil, fmt.Errorf("question %d is short text so it can't have options", i+1)
+			}
+
+		default:
+			return nil, fmt.Errorf("question %d has an unknown type \"%s\". Expected \"%s\", \"%s\" or \"%s\"", i+1, question.Type, models.MultipleChoiceQuestion, models.MultipleAnswersQuestion, models.ShortTextQuestion)
+		}
+
+		model.Questions = append(model.Questions, &models.QuizQuestionModel{
+			Position: i + 1,
+			Kind:     question.Type,
+			Question: question.Question,
+			Options:  question.Options,
+			Answers:  question.Answers,
+		})
+	}
+
+	return model, nil
+}
+
 func RemoveAccents(s string) string {
 	t := ""
 	for _, c := range s {
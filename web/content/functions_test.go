@@ -1,15 +1,168 @@
 package content
 
-import "testing"
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
 
 func TestMarkdownToHTML(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestRenderMarkdown(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestReadingTime(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestParseMarkdown(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestNewHTMLRenderer(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestNodeText(t *testing.T) {
+	// TODO: Implement.
+}
+
 func TestTitleToSlug(t *testing.T) {
 	// TODO: Implement.
 }
 
+func TestContentSlug(t *testing.T) {
+	// TODO: Implement.
+}
+
+func TestScheduleTime(t *testing.T) {
+	if scheduled := ScheduleTime(nil); scheduled.Valid {
+		t.Errorf("Expected a missing time to be stored as NULL. Got %s", scheduled.Time)
+	}
+
+	publishAt := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.FixedZone("", 2*60*60))
+
+	scheduled := ScheduleTime(&publishAt)
+	if !scheduled.Valid {
+		t.Fatal("Expected the time to be stored")
+	}
+
+	if scheduled.Time.Location() != time.UTC {
+		t.Errorf("Expected the time to be converted to UTC. Got %s", scheduled.Time.Location())
+	}
+
+	if !scheduled.Time.Equal(time.Date(2025, time.March, 1, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the time to stay the same instant. Got %s", scheduled.Time)
+	}
+}
+
+func TestChapterRelease(t *testing.T) {
+	days := 7
+	negative := -1
+	releaseAt := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.FixedZone("", -5*60*60))
+
+	if _, _, err := ChapterRelease(&days, &releaseAt); err == nil {
+		t.Error("Expected an error when both release_after_days and release_at are set")
+	}
+
+	if _, _, err := ChapterRelease(&negative, nil); err == nil {
+		t.Error("Expected an error when release_after_days is negative")
+	}
+
+	releaseAfterDays, _, err := ChapterRelease(&days, nil)
+	if err != nil {
+		t.Fatalf("Failed to convert release_after_days: %s", err)
+	}
+
+	if !releaseAfterDays.Valid || releaseAfterDays.Int32 != 7 {
+		t.Errorf("Expected release_after_days to be 7. Got %v", releaseAfterDays)
+	}
+
+	_, released, err := ChapterRelease(nil, &releaseAt)
+	if err != nil {
+		t.Fatalf("Failed to convert release_at: %s", err)
+	}
+
+	if !released.Valid || released.Time.Location() != time.UTC || !released.Time.Equal(releaseAt) {
+		t.Errorf("Expected release_at to be converted to UTC. Got %v", released)
+	}
+}
+
+func TestChapterQuiz(t *testing.T) {
+	if quiz, err := ChapterQuiz(nil); quiz != nil || err != nil {
+		t.Errorf("Expected a chapter without a quiz to have no quiz. Got %v (%v)", quiz, err)
+	}
+
+	choice := func() *QuizQuestionMatter {
+		return &QuizQuestionMatter{Type: models.MultipleChoiceQuestion, Question: "Which?", Options: []string{"a", "b"}, Answers: []string{"a"}}
+	}
+
+	tests := []struct {
+		name     string
+		quiz     *QuizMatter
+		err      string
+		question func(question *QuizQuestionMatter)
+	}{
+		{name: "Pass mark too low", quiz: &QuizMatter{PassMark: 0, Questions: []*QuizQuestionMatter{choice()}}, err: "needs a \"pass_mark\" between 1 and 100"},
+		{name: "Pass mark too high", quiz: &QuizMatter{PassMark: 101, Questions: []*QuizQuestionMatter{choice()}}, err: "needs a \"pass_mark\" between 1 and 100"},
+		{name: "No questions", quiz: &QuizMatter{PassMark: 50}, err: "needs at least one question"},
+		{name: "Blank question", question: func(q *QuizQuestionMatter) { q.Question = "  " }, err: "question 1 needs a \"question\""},
+		{name: "No answers", question: func(q *QuizQuestionMatter) { q.Answers = nil }, err: "question 1 needs at least one answer"},
+		{name: "One option", question: func(q *QuizQuestionMatter) { q.Options = []string{"a"} }, err: "question 1 needs at least two options"},
+		{name: "Multiple choice with two answers", question: func(q *QuizQuestionMatter) { q.Answers = []string{"a", "b"} }, err: "question 1 is multiple choice so it needs exactly one answer"},
+		{name: "Answer that isn't an option", question: func(q *QuizQuestionMatter) { q.Answers = []string{"c"} }, err: "question 1 has an answer (\"c\") that isn't one of its options"},
+		{name: "Short text with options", question: func(q *QuizQuestionMatter) { q.Type = models.ShortTextQuestion }, err: "question 1 is short text so it can't have options"},
+		{name: "Unknown type", question: func(q *QuizQuestionMatter) { q.Type = "essay" }, err: "question 1 has an unknown type \"essay\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := tt.quiz
+			if quiz == nil {
+				question := choice()
+				tt.question(question)
+				quiz = &QuizMatter{PassMark: 50, Questions: []*QuizQuestionMatter{question}}
+			}
+
+			if _, err := ChapterQuiz(quiz); err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("Expected an error containing \"%s\". Got %v", tt.err, err)
+			}
+		})
+	}
+
+	quiz, err := ChapterQuiz(&QuizMatter{
+		PassMark: 75,
+		Questions: []*QuizQuestionMatter{
+			choice(),
+			{Type: models.MultipleAnswersQuestion, Question: "Which ones?", Options: []string{"a", "b", "c"}, Answers: []string{"a", "c"}},
+			{Type: models.ShortTextQuestion, Question: "What?", Answers: []string{"go", "golang"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to convert quiz: %s", err)
+	}
+
+	if quiz.PassMark != 75 || len(quiz.Questions) != 3 {
+		t.Fatalf("Expected a quiz with a pass mark of 75 and 3 questions. Got %+v", quiz)
+	}
+
+	for i, question := range quiz.Questions {
+		if question.Position != i+1 {
+			t.Errorf("Expected question %d to be at position %d. Got %d", i+1, i+1, question.Position)
+		}
+	}
+
+	if last := quiz.Questions[2]; last.Kind != models.ShortTextQuestion || !slices.Equal(last.Answers, models.QuizAnswers{"go", "golang"}) {
+		t.Errorf("Expected the short text question to keep its answers. Got %+v", last)
+	}
+}
+
 func TestRemoveAccents(t *testing.T) {
 	// TODO: Implement.
 }
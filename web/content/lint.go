@@ -0,0 +1,486 @@
+package content
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrg/frontmatter"
+)
+
+// imageLinkRegex matches markdown image links and captures everything between the brackets.
+var imageLinkRegex = regexp.MustCompile(`!\[[^\]]*\]\(([^)]*)\)`)
+
+// imageTitleRegex matches the optional title at the end of a markdown image link.
+var imageTitleRegex = regexp.MustCompile(`\s+"[^"]*"$`)
+
+// Problem is a single issue that the linter found in a content file.
+type Problem struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (problem Problem) String() string {
+	if problem.Line == 0 {
+		return fmt.Sprintf("%s: %s", problem.File, problem.Message)
+	}
+
+	return fmt.Sprintf("%s:%d: %s", problem.File, problem.Line, problem.Message)
+}
+
+// Linter checks the tutorials and courses in a content directory for the mistakes that would otherwise only show up
+// halfway through loading the content into the database.
+type Linter struct {
+	fsys     fs.FS
+	client   *http.Client
+	problems []Problem
+	checked  map[string]string
+}
+
+// lintFile is a parsed content file along with the information needed to point at the lines of its fields.
+type lintFile struct {
+	path  string
+	lines []string
+	body  int
+}
+
+// NewLinter creates a linter for a content directory that contains the tutorials and courses folders. Remote image
+// links are only requested when checkRemote is true.
+func NewLinter(fsys fs.FS, checkRemote bool) *Linter {
+	linter := &Linter{
+		fsys:    fsys,
+		checked: make(map[string]string),
+	}
+
+	if checkRemote {
+		linter.client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return linter
+}
+
+// Lint checks every tutorial, course and chapter file and returns the problems it found, sorted by file and line.
+func (linter *Linter) Lint() ([]Problem, error) {
+	linter.problems = nil
+
+	if err := linter.lintTutorials(); err != nil {
+		return nil, err
+	}
+
+	if err := linter.lintCourses(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(linter.problems, func(i, j int) bool {
+		if linter.problems[i].File != linter.problems[j].File {
+			return linter.problems[i].File < linter.problems[j].File
+		}
+
+		return linter.problems[i].Line < linter.problems[j].Line
+	})
+
+	return linter.problems, nil
+}
+
+func (linter *Linter) lintTutorials() error {
+	entries, err := fs.ReadDir(linter.fsys, "tutorials")
+	if err != nil {
+		return fmt.Errorf("failed to read tutorials directory: %w", err)
+	}
+
+	keys := make(map[string]string)
+	slugs := make(map[string]string)
+	seriesOrders := make(map[string]string)
+
+	for _, entry := range entries {
+		filePath := path.Join("tutorials", entry.Name())
+
+		if entry.IsDir() {
+			linter.report(filePath, 0, "directories are not supported in the tutorials directory")
+			continue
+		}
+
+		if !isMarkdown(entry.Name()) {
+			continue
+		}
+
+		matter := new(TutorialMatter)
+
+		file, ok, err := linter.parse(filePath, matter)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		linter.required(file, "title", matter.Title)
+		linter.required(file, "description", matter.Description)
+		linter.required(file, "key", matter.Key)
+		linter.image(file, "thumbnail_url", matter.ThumbnailURL)
+		linter.image(file, "banner_url", matter.BannerURL)
+		linter.unique(file, "key", matter.Key, "tutorial key", keys)
+		linter.unique(file, "title", ContentSlug(matter.Slug, matter.Title), "tutorial slug", slugs)
+		linter.schedule(file, matter.PublishAt, matter.UnpublishAt)
+		linter.series(file, matter.Series, matter.SeriesOrder, seriesOrders)
+		linter.bodyImages(file)
+		linter.directives(file)
+	}
+
+	return nil
+}
+
+func (linter *Linter) lintCourses() error {
+	entries, err := fs.ReadDir(linter.fsys, "courses")
+	if err != nil {
+		return fmt.Errorf("failed to read courses directory: %w", err)
+	}
+
+	keys := make(map[string]string)
+	slugs := make(map[string]string)
+
+	// Chapters get checked once all the courses are known so that their course keys can be resolved.
+	var chapterDirs []string
+
+	for _, entry := range entries {
+		filePath := path.Join("courses", entry.Name())
+
+		if entry.IsDir() {
+			chapterDirs = append(chapterDirs, filePath)
+			continue
+		}
+
+		if !isMarkdown(entry.Name()) {
+			continue
+		}
+
+		matter := new(CourseMatter)
+
+		file, ok, err := linter.parse(filePath, matter)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		linter.required(file, "title", matter.Title)
+		linter.required(file, "description", matter.Description)
+		linter.required(file, "key", matter.Key)
+		linter.image(file, "thumbnail_url", matter.ThumbnailURL)
+		linter.image(file, "banner_url", matter.BannerURL)
+		linter.unique(file, "key", matter.Key, "course key", keys)
+		linter.unique(file, "title", ContentSlug(matter.Slug, matter.Title), "course slug", slugs)
+		linter.schedule(file, matter.PublishAt, matter.UnpublishAt)
+		linter.bodyImages(file)
+		linter.directives(file)
+	}
+
+	chapterKeys := make(map[string]string)
+	chapterNumbers := make(map[string]string)
+	chapterSlugs := make(map[string]string)
+
+	for _, dir := range chapterDirs {
+		chapterEntries, err := fs.ReadDir(linter.fsys, dir)
+		if err != nil {
+			return fmt.Errorf("failed to read chapters directory \"%s\": %w", dir, err)
+		}
+
+		for _, entry := range chapterEntries {
+			filePath := path.Join(dir, entry.Name())
+
+			if entry.IsDir() {
+				linter.report(filePath, 0, "chapter directories can't contain other directories")
+				continue
+			}
+
+			if !isMarkdown(entry.Name()) {
+				continue
+			}
+
+			matter := new(ChapterMatter)
+
+			file, ok, err := linter.parse(filePath, matter)
+			if err != nil {
+				return err
+			}
+
+			if !ok {
+				continue
+			}
+
+			linter.required(file, "title", matter.Title)
+			linter.required(file, "key", matter.Key)
+			linter.unique(file, "key", matter.Key, "chapter key", chapterKeys)
+
+			if matter.Chapter < 1 {
+				linter.report(file.path, file.line("chapter"), "\"chapter\" needs to be a number of 1 or more")
+			}
+
+			if linter.required(file, "course_key", matter.CourseKey) {
+				if _, has := keys[matter.CourseKey]; !has {
+					linter.report(file.path, file.line("course_key"), "\"course_key\" doesn't match the key of any course")
+				}
+
+				if matter.Chapter >= 1 {
+					linter.unique(file, "chapter", fmt.Sprintf("%s/%d", matter.CourseKey, matter.Chapter), fmt.Sprintf("chapter number %d", matter.Chapter), chapterNumbers)
+				}
+
+				linter.unique(file, "title", matter.CourseKey+"/"+ContentSlug(matter.Slug, matter.Title), "chapter slug", chapterSlugs)
+			}
+
+			linter.release(file, matter.ReleaseAfterDays, matter.ReleaseAt)
+			linter.quiz(file, matter.Quiz)
+			linter.bodyImages(file)
+			linter.directives(file)
+		}
+	}
+
+	return nil
+}
+
+// parse reads a content file and decodes its frontmatter into matter. It returns false when the file couldn't be
+// parsed, in which case the problem has already been reported.
+func (linter *Linter) parse(filePath string, matter any) (*lintFile, bool, error) {
+	output, err := fs.ReadFile(linter.fsys, filePath)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read \"%s\": %w", filePath, err)
+	}
+
+	file := &lintFile{
+		path:  filePath,
+		lines: strings.Split(string(output), "\n"),
+	}
+
+	if !bytes.HasPrefix(output, []byte("---")) {
+		linter.report(filePath, 1, "file needs to start with a frontmatter block")
+		return file, false, nil
+	}
+
+	if _, err := frontmatter.MustParse(bytes.NewReader(output), matter); err != nil {
+		linter.report(filePath, 1, fmt.Sprintf("failed to parse frontmatter: %s", err))
+		return file, false, nil
+	}
+
+	// The body starts after the line that closes the frontmatter block.
+	for i := 1; i < len(file.lines); i++ {
+		if strings.TrimSpace(file.lines[i]) == "---" {
+			file.body = i + 1
+			break
+		}
+	}
+
+	return file, true, nil
+}
+
+// required reports a problem if a frontmatter field is blank and returns whether it had a value.
+func (linter *Linter) required(file *lintFile, field, value string) bool {
+	if strings.TrimSpace(value) == "" {
+		linter.report(file.path, file.line(field), fmt.Sprintf("\"%s\" is required", field))
+		return false
+	}
+
+	return true
+}
+
+// unique reports a problem if another file already used the same value. Blank values are left to required.
+func (linter *Linter) unique(file *lintFile, field, value, name string, seen map[string]string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+
+	if other, has := seen[value]; has {
+		linter.report(file.path, file.line(field), fmt.Sprintf("%s is already used by \"%s\"", name, other))
+		return
+	}
+
+	seen[value] = file.path
+}
+
+// image reports a problem if an image field is blank or doesn't resolve.
+func (linter *Linter) image(file *lintFile, field, link string) {
+	if linter.required(file, field, link) {
+		if problem := linter.resolve(link); problem != "" {
+			linter.report(file.path, file.line(field), fmt.Sprintf("\"%s\" %s", field, problem))
+		}
+	}
+}
+
+// schedule reports a problem if the content would be unpublished before it gets published.
+func (linter *Linter) schedule(file *lintFile, publishAt, unpublishAt *time.Time) {
+	if publishAt != nil && unpublishAt != nil && !unpublishAt.After(*publishAt) {
+		linter.report(file.path, file.line("unpublish_at"), "\"unpublish_at\" needs to be after \"publish_at\"")
+	}
+}
+
+// release reports a problem if a chapter sets both ways of releasing it or has a negative release_after_days.
+func (linter *Linter) release(file *lintFile, releaseAfterDays *int, releaseAt *time.Time) {
+	if _, _, err := ChapterRelease(releaseAfterDays, releaseAt); err != nil {
+		linter.report(file.path, file.line("release_after_days"), err.Error())
+	}
+}
+
+// series reports a problem if a tutorial has a series order without a series, or if its position in the series is
+// invalid or already taken by another tutorial.
+func (linter *Linter) series(file *lintFile, series string, order int, seen map[string]string) {
+	if strings.TrimSpace(series) == "" {
+		if order != 0 {
+			linter.report(file.path, file.line("series_order"), "\"series_order\" needs a \"series\"")
+		}
+
+		return
+	}
+
+	if order < 1 {
+		linter.report(file.path, file.line("series_order"), "\"series_order\" needs to be 1 or more")
+		return
+	}
+
+	linter.unique(file, "series_order", fmt.Sprintf("%s/%d", TitleToSlug(series), order), "series order", seen)
+}
+
+// quiz reports a problem if a chapter's quiz can't be loaded.
+func (linter *Linter) quiz(file *lintFile, quiz *QuizMatter) {
+	if _, err := ChapterQuiz(quiz); err != nil {
+		linter.report(file.path, file.line("quiz"), fmt.Sprintf("\"quiz\" %s", err))
+	}
+}
+
+// bodyImages checks every image link in the markdown body. Code blocks are skipped because their contents don't get
+// rendered as markdown.
+func (linter *Linter) bodyImages(file *lintFile) {
+	inCodeBlock := false
+
+	for i := file.body; i < len(file.lines); i++ {
+		line := file.lines[i]
+
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+
+		if inCodeBlock {
+			continue
+		}
+
+		for _, match := range imageLinkRegex.FindAllStringSubmatch(line, -1) {
+			link := imageTitleRegex.ReplaceAllString(strings.TrimSpace(match[1]), "")
+			link = strings.TrimSuffix(strings.TrimPrefix(link, "<"), ">")
+
+			if link == "" {
+				linter.report(file.path, i+1, "image link is empty")
+				continue
+			}
+
+			if problem := linter.resolve(link); problem != "" {
+				linter.report(file.path, i+1, fmt.Sprintf("image \"%s\" %s", link, problem))
+			}
+		}
+	}
+}
+
+// directives reports the first directive in the markdown body that can't be rendered, such as a callout that is never
+// closed or an include directive that points at a file that doesn't exist.
+func (linter *Linter) directives(file *lintFile) {
+	body := strings.Join(file.lines[file.body:], "\n")
+
+	_, err := parseMarkdown([]byte(body), linter.fsys)
+
+	var directiveErr *DirectiveError
+	if errors.As(err, &directiveErr) {
+		linter.report(file.path, file.find(directiveErr.Directive), directiveErr.Error())
+	}
+}
+
+// resolve checks that an image link is an absolute URL and, when remote checks are turned on, that it can be
+// downloaded. It returns a description of the problem or an empty string if the link is fine. Results are cached
+// because the same image often gets used in more than one place.
+func (linter *Linter) resolve(link string) string {
+	if problem, has := linter.checked[link]; has {
+		return problem
+	}
+
+	problem := ""
+
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		problem = "needs to be an absolute http or https URL"
+	} else if linter.client != nil {
+		problem = linter.fetch(link)
+	}
+
+	linter.checked[link] = problem
+
+	return problem
+}
+
+// fetch requests the image and describes why it couldn't be downloaded. Some servers don't support HEAD requests so
+// a GET request is used as a fallback.
+func (linter *Linter) fetch(link string) string {
+	response, err := linter.client.Head(link)
+	if err == nil && (response.StatusCode == http.StatusMethodNotAllowed || response.StatusCode == http.StatusNotImplemented) {
+		response.Body.Close()
+		response, err = linter.client.Get(link)
+	}
+
+	if err != nil {
+		return fmt.Sprintf("could not be requested: %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Sprintf("returned status %d", response.StatusCode)
+	}
+
+	return ""
+}
+
+func (linter *Linter) report(file string, line int, message string) {
+	linter.problems = append(linter.problems, Problem{
+		File:    file,
+		Line:    line,
+		Message: message,
+	})
+}
+
+// line returns the line number of a frontmatter field, or the first line of the file if the field is missing.
+func (file *lintFile) line(field string) int {
+	end := file.body
+	if end == 0 {
+		end = len(file.lines)
+	}
+
+	for i := 1; i < end; i++ {
+		if strings.HasPrefix(file.lines[i], field+":") {
+			return i + 1
+		}
+	}
+
+	return 1
+}
+
+// find returns the line number of the first line in the body that matches text, or 0 if there is no such line.
+func (file *lintFile) find(text string) int {
+	for i := file.body; i < len(file.lines); i++ {
+		if strings.TrimSpace(file.lines[i]) == text {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+func isMarkdown(name string) bool {
+	return strings.HasSuffix(name, ".md")
+}
@@ -0,0 +1,105 @@
+package content
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name string
+		dir  string
+		want []Problem
+	}{
+		{
+			name: "Valid content",
+			dir:  "testdata/lint/valid",
+			want: nil,
+		},
+		{
+			name: "Invalid content",
+			dir:  "testdata/lint/invalid",
+			want: []Problem{
+				{File: "courses/course/duplicate-number.md", Line: 3, Message: "chapter number 1 is already used by \"courses/course/chapter-1.md\""},
+				{File: "courses/course/include.md", Line: 8, Message: "\"::include{file=\"missing.go\"}\" could not read \"missing.go\" from the _includes directory"},
+				{File: "courses/course/nested", Line: 0, Message: "chapter directories can't contain other directories"},
+				{File: "courses/course/quiz.md", Line: 6, Message: "\"quiz\" needs a \"pass_mark\" between 1 and 100"},
+				{File: "courses/course/release.md", Line: 6, Message: "\"release_after_days\" and \"release_at\" can't both be set"},
+				{File: "courses/course/unknown-course.md", Line: 4, Message: "\"course_key\" doesn't match the key of any course"},
+				{File: "courses/course/zero.md", Line: 3, Message: "\"chapter\" needs to be a number of 1 or more"},
+				{File: "tutorials/broken-frontmatter.md", Line: 1, Message: "failed to parse frontmatter: yaml: line 1: did not find expected node content"},
+				{File: "tutorials/directive.md", Line: 11, Message: "\":::note\" is never closed with \":::\""},
+				{File: "tutorials/duplicate.md", Line: 2, Message: "tutorial slug is already used by \"tutorials/a-original.md\""},
+				{File: "tutorials/duplicate.md", Line: 6, Message: "tutorial key is already used by \"tutorials/a-original.md\""},
+				{File: "tutorials/images.md", Line: 4, Message: "\"thumbnail_url\" needs to be an absolute http or https URL"},
+				{File: "tutorials/images.md", Line: 9, Message: "image \"images/relative.png\" needs to be an absolute http or https URL"},
+				{File: "tutorials/images.md", Line: 10, Message: "image link is empty"},
+				{File: "tutorials/missing-fields.md", Line: 1, Message: "\"description\" is required"},
+				{File: "tutorials/missing-fields.md", Line: 1, Message: "\"key\" is required"},
+				{File: "tutorials/missing-fields.md", Line: 1, Message: "\"thumbnail_url\" is required"},
+				{File: "tutorials/missing-fields.md", Line: 1, Message: "\"banner_url\" is required"},
+				{File: "tutorials/nested", Line: 0, Message: "directories are not supported in the tutorials directory"},
+				{File: "tutorials/no-frontmatter.md", Line: 1, Message: "file needs to start with a frontmatter block"},
+				{File: "tutorials/schedule.md", Line: 8, Message: "\"unpublish_at\" needs to be after \"publish_at\""},
+				{File: "tutorials/series-order.md", Line: 8, Message: "series order is already used by \"tutorials/a-original.md\""},
+				{File: "tutorials/series-without-name.md", Line: 7, Message: "\"series_order\" needs a \"series\""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems, err := NewLinter(os.DirFS(tt.dir), false).Lint()
+			if err != nil {
+				t.Fatalf("Failed to lint content: %s", err)
+			}
+
+			if !reflect.DeepEqual(problems, tt.want) {
+				t.Errorf("Expected %d problems. Got %d", len(tt.want), len(problems))
+
+				for _, problem := range problems {
+					t.Log(problem)
+				}
+			}
+		})
+	}
+}
+
+func TestLintRemoteImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+		case "/head-not-allowed.png":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	files := fstest.MapFS{
+		"tutorials/remote.md": {Data: []byte("---\ntitle: \"Remote\"\ndescription: \"A tutorial\"\nthumbnail_url: \"" + server.URL + "/image.png\"\nbanner_url: \"" + server.URL + "/head-not-allowed.png\"\nkey: \"remote\"\n---\n\n![Missing](" + server.URL + "/missing.png)\n")},
+		"courses":             {Mode: os.ModeDir},
+	}
+
+	problems, err := NewLinter(files, true).Lint()
+	if err != nil {
+		t.Fatalf("Failed to lint content: %s", err)
+	}
+
+	want := []Problem{{File: "tutorials/remote.md", Line: 9, Message: "image \"" + server.URL + "/missing.png\" returned status 404"}}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("Expected only the missing image to be reported. Got %v", problems)
+	}
+
+	// Images are only requested when remote checks are turned on.
+	if problems, err := NewLinter(files, false).Lint(); err != nil || len(problems) != 0 {
+		t.Errorf("Expected no problems without remote checks. Got %v (%v)", problems, err)
+	}
+}
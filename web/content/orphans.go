@@ -0,0 +1,205 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+// OrphanPolicy decides what happens to tutorials and courses whose markdown file has been removed.
+type OrphanPolicy string
+
+const (
+	// UnpublishOrphans hides orphaned content from the site but keeps it in the admin panel.
+	UnpublishOrphans OrphanPolicy = "unpublish"
+
+	// ArchiveOrphans soft deletes orphaned content so that it can be restored until the retention period runs out.
+	ArchiveOrphans OrphanPolicy = "archive"
+
+	// DeleteOrphans permanently deletes orphaned content. Courses that have been bought are archived instead
+	// because their purchase history has to be kept.
+	DeleteOrphans OrphanPolicy = "delete"
+)
+
+// orphanPageSize is the number of soft deleted rows that get read at a time when looking for archived content.
+const orphanPageSize = 100
+
+// ParseOrphanPolicy converts the given string to an OrphanPolicy.
+func ParseOrphanPolicy(policy string) (OrphanPolicy, error) {
+	switch OrphanPolicy(policy) {
+	case UnpublishOrphans, ArchiveOrphans, DeleteOrphans:
+		return OrphanPolicy(policy), nil
+	}
+
+	return "", fmt.Errorf("unknown orphan policy \"%s\". Expected \"unpublish\", \"archive\" or \"delete\"", policy)
+}
+
+// handleOrphanedTutorial applies the orphan policy to a tutorial whose markdown file no longer exists. During a dry
+// run the change only gets reported.
+func (content *Content) handleOrphanedTutorial(ctx context.Context, db database.Database, tutorial *models.TutorialModel, archived bool) {
+	switch content.Policy {
+	case DeleteOrphans:
+		if !content.DryRun {
+			if err := db.PurgeTutorial(ctx, tutorial.ID); err != nil {
+				content.ErrorLog.Fatalf("Failed to delete orphaned tutorial \"%s\": %s\n", tutorial.Title, err)
+			}
+		}
+
+		content.Report.add("tutorial", tutorial.Title, "Deleted")
+
+	case ArchiveOrphans:
+		if archived {
+			return
+		}
+
+		if !content.DryRun {
+			if err := db.DeleteTutorial(ctx, tutorial.ID); err != nil {
+				content.ErrorLog.Fatalf("Failed to archive orphaned tutorial \"%s\": %s\n", tutorial.Title, err)
+			}
+		}
+
+		content.Report.add("tutorial", tutorial.Title, "Archived")
+
+	default:
+		if !tutorial.Published {
+			return
+		}
+
+		if !content.DryRun {
+			if err := db.UnpublishTutorial(ctx, tutorial.ID); err != nil {
+				content.ErrorLog.Fatalf("Failed to unpublish orphaned tutorial \"%s\": %s\n", tutorial.Title, err)
+			}
+		}
+
+		content.Report.add("tutorial", tutorial.Title, "Unpublished")
+	}
+}
+
+// handleOrphanedCourse applies the orphan policy to a course whose markdown file no longer exists. The course's
+// chapters go along with it. During a dry run the change only gets reported.
+func (content *Content) handleOrphanedCourse(ctx context.Context, db database.Database, course *models.CourseModel, archived bool) {
+	switch content.Policy {
+	case DeleteOrphans:
+		purged, err := content.purgeCourse(ctx, db, course)
+		if err != nil {
+			content.ErrorLog.Fatalf("Failed to delete orphaned course \"%s\": %s\n", course.Title, err)
+		}
+
+		if purged {
+			content.Report.add("course", course.Title, "Deleted")
+			return
+		}
+
+		if archived {
+			content.WarningLog.Printf("Orphaned course \"%s\" has been bought so it was left archived instead of being deleted.\n", course.Title)
+			return
+		}
+
+		if !content.DryRun {
+			if err := db.DeleteCourse(ctx, course.ID); err != nil {
+				content.ErrorLog.Fatalf("Failed to archive orphaned course \"%s\": %s\n", course.Title, err)
+			}
+		}
+
+		content.Report.add("course", course.Title, "Archived (has purchases)")
+
+	case ArchiveOrphans:
+		if archived {
+			return
+		}
+
+		if !content.DryRun {
+			if err := db.DeleteCourse(ctx, course.ID); err != nil {
+				content.ErrorLog.Fatalf("Failed to archive orphaned course \"%s\": %s\n", course.Title, err)
+			}
+		}
+
+		content.Report.add("course", course.Title, "Archived")
+
+	default:
+		if !course.Published {
+			return
+		}
+
+		if !content.DryRun {
+			if err := db.UnpublishCourse(ctx, course.ID); err != nil {
+				content.ErrorLog.Fatalf("Failed to unpublish orphaned course \"%s\": %s\n", course.Title, err)
+			}
+		}
+
+		content.Report.add("course", course.Title, "Unpublished")
+	}
+}
+
+// purgeCourse permanently deletes a course and returns false if it couldn't be deleted because it has been bought.
+// During a dry run nothing gets deleted and the purchases are checked instead.
+func (content *Content) purgeCourse(ctx context.Context, db database.Database, course *models.CourseModel) (bool, error) {
+	if content.DryRun {
+		buyers, err := db.CountUsersWhoBoughtCourse(ctx, course.ID)
+		return buyers == 0, err
+	}
+
+	err := db.PurgeCourse(ctx, course.ID)
+	if errors.Is(err, database.ErrCourseHasPurchases) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// archivedTutorials returns the IDs of all soft deleted tutorials.
+func archivedTutorials(ctx context.Context, db database.Database) (map[string]bool, error) {
+	archived := make(map[string]bool)
+
+	// A nil author only matches tutorials without an author.
+	anyAuthor := ""
+
+	var cursor *database.Cursor
+
+	for {
+		tutorials, next, err := db.AdminGetTutorials(ctx, "", nil, &anyAuthor, "", "", "", true, cursor, orphanPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tutorial := range tutorials {
+			archived[tutorial.ID] = true
+		}
+
+		if next == nil {
+			return archived, nil
+		}
+
+		cursor = next
+	}
+}
+
+// archivedCourses returns the IDs of all soft deleted courses.
+func archivedCourses(ctx context.Context, db database.Database) (map[string]bool, error) {
+	archived := make(map[string]bool)
+
+	// A nil author only matches courses without an author.
+	anyAuthor := ""
+
+	var cursor *database.Cursor
+
+	for {
+		courses, next, err := db.AdminGetCourses(ctx, "", nil, &anyAuthor, "", "", true, cursor, orphanPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, course := range courses {
+			archived[course.ID] = true
+		}
+
+		if next == nil {
+			return archived, nil
+		}
+
+		cursor = next
+	}
+}
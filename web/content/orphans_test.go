@@ -0,0 +1,258 @@
+package content
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
+
+func TestParseOrphanPolicy(t *testing.T) {
+	for _, policy := range []OrphanPolicy{UnpublishOrphans, ArchiveOrphans, DeleteOrphans} {
+		if parsed, err := ParseOrphanPolicy(string(policy)); err != nil || parsed != policy {
+			t.Errorf("Expected \"%s\" to be parsed. Got \"%s\" (%v)", policy, parsed, err)
+		}
+	}
+
+	if _, err := ParseOrphanPolicy("keep"); err == nil {
+		t.Error("Expected an unknown policy to be rejected")
+	}
+}
+
+func TestRegisterContentDryRun(t *testing.T) {
+	ctx := context.Background()
+	db := databasetest.NewDatabase()
+
+	before := contentState(t, db)
+
+	report := RegisterContent(ctx, db, UnpublishOrphans, true)
+	if len(report.Changes) == 0 {
+		t.Fatal("Expected the dry run to plan adding the content")
+	}
+
+	for _, change := range report.Changes {
+		if change.Action != "Added" || change.Diff == "" {
+			t.Errorf("Expected only additions with a diff to be planned. Got %s", change)
+		}
+	}
+
+	if after := contentState(t, db); !reflect.DeepEqual(before, after) {
+		t.Error("Expected the dry run not to write anything to the database")
+	}
+
+	applied := RegisterContent(ctx, db, UnpublishOrphans, false)
+	if !reflect.DeepEqual(changeList(applied), changeList(report)) {
+		t.Errorf("Expected the dry run to plan the changes that were made.\nPlanned: %v\nMade: %v", changeList(report), changeList(applied))
+	}
+}
+
+func TestOrphanPolicies(t *testing.T) {
+	tests := []struct {
+		policy  OrphanPolicy
+		want    map[string]string
+		actions []string
+	}{
+		{
+			policy:  UnpublishOrphans,
+			want:    map[string]string{"tutorial": "unpublished", "course": "unpublished", "bought": "unpublished"},
+			actions: []string{"Unpublished tutorial \"Orphaned Tutorial\"", "Unpublished course \"Bought Course\"", "Unpublished course \"Orphaned Course\""},
+		},
+		{
+			policy:  ArchiveOrphans,
+			want:    map[string]string{"tutorial": "archived", "course": "archived", "bought": "archived"},
+			actions: []string{"Archived tutorial \"Orphaned Tutorial\"", "Archived course \"Bought Course\"", "Archived course \"Orphaned Course\""},
+		},
+		{
+			policy:  DeleteOrphans,
+			want:    map[string]string{"tutorial": "deleted", "course": "deleted", "bought": "archived"},
+			actions: []string{"Deleted tutorial \"Orphaned Tutorial\"", "Archived (has purchases) course \"Bought Course\"", "Deleted course \"Orphaned Course\""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			ctx := context.Background()
+			db, orphans := setupOrphans(t)
+
+			before := contentState(t, db)
+
+			plan := RegisterContent(ctx, db, tt.policy, true)
+
+			if after := contentState(t, db); !reflect.DeepEqual(before, after) {
+				t.Error("Expected the dry run not to write anything to the database")
+			}
+
+			report := RegisterContent(ctx, db, tt.policy, false)
+
+			if !reflect.DeepEqual(changeList(report), tt.actions) {
+				t.Errorf("Expected %v. Got %v", tt.actions, changeList(report))
+			}
+
+			if !reflect.DeepEqual(changeList(plan), changeList(report)) {
+				t.Errorf("Expected the dry run to plan the changes that were made. Planned %v", changeList(plan))
+			}
+
+			for name, id := range orphans {
+				if status := contentStatus(t, db, id); status != tt.want[name] {
+					t.Errorf("Expected the %s to be %s. Got %s", name, tt.want[name], status)
+				}
+			}
+
+			// Content that still has a markdown file is left alone.
+			state := contentState(t, db)
+			for _, tutorial := range before.tutorials {
+				if _, orphaned := orphanIDs(orphans)[tutorial.ID]; !orphaned && contentStatus(t, db, tutorial.ID) != "published" {
+					t.Errorf("Expected tutorial \"%s\" to stay published", tutorial.Title)
+				}
+			}
+
+			if len(state.chapters) != len(before.chapters) {
+				t.Errorf("Expected the chapters of the remaining courses to stay. Got %d of %d", len(state.chapters), len(before.chapters))
+			}
+		})
+	}
+}
+
+// state is everything that the content loader can change in the database.
+type state struct {
+	tutorials         []*models.TutorialModel
+	courses           []*models.CourseModel
+	chapters          []*models.ChapterModel
+	archivedTutorials map[string]bool
+	archivedCourses   map[string]bool
+}
+
+func contentState(t *testing.T, db *databasetest.Database) state {
+	t.Helper()
+
+	ctx := context.Background()
+
+	tutorials, err := db.GetAllTutorials(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to get tutorials: %s", err)
+	}
+
+	courses, err := db.GetAllCourses(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to get courses: %s", err)
+	}
+
+	chapters, err := db.GetAllChapters(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get chapters: %s", err)
+	}
+
+	tutorialsArchived, err := archivedTutorials(ctx, db)
+	if err != nil {
+		t.Fatalf("Failed to get archived tutorials: %s", err)
+	}
+
+	coursesArchived, err := archivedCourses(ctx, db)
+	if err != nil {
+		t.Fatalf("Failed to get archived courses: %s", err)
+	}
+
+	return state{
+		tutorials:         tutorials,
+		courses:           courses,
+		chapters:          chapters,
+		archivedTutorials: tutorialsArchived,
+		archivedCourses:   coursesArchived,
+	}
+}
+
+// contentStatus describes what happened to the tutorial or course with the given ID.
+func contentStatus(t *testing.T, db *databasetest.Database, id string) string {
+	t.Helper()
+
+	state := contentState(t, db)
+
+	if state.archivedTutorials[id] || state.archivedCourses[id] {
+		return "archived"
+	}
+
+	for _, tutorial := range state.tutorials {
+		if tutorial.ID == id {
+			return publishedStatus(tutorial.Published)
+		}
+	}
+
+	for _, course := range state.courses {
+		if course.ID == id {
+			return publishedStatus(course.Published)
+		}
+	}
+
+	return "deleted"
+}
+
+func publishedStatus(published bool) string {
+	if published {
+		return "published"
+	}
+
+	return "unpublished"
+}
+
+// setupOrphans loads the content into the database, publishes it and adds a tutorial, a course and a bought course
+// that don't have a markdown file. The IDs of the orphans are returned by name.
+func setupOrphans(t *testing.T) (*databasetest.Database, map[string]string) {
+	t.Helper()
+
+	ctx := context.Background()
+	db := databasetest.NewDatabase()
+
+	RegisterContent(ctx, db, UnpublishOrphans, false)
+
+	author := databasetest.NewUserBuilder().AsAuthor().Build()
+	db.SeedUsers(author)
+
+	tutorials, err := db.GetAllTutorials(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("Failed to get tutorials: %s", err)
+	}
+
+	for _, tutorial := range tutorials {
+		if err := db.PublishTutorial(ctx, tutorial.ID); err != nil {
+			t.Fatalf("Failed to publish tutorial: %s", err)
+		}
+	}
+
+	orphanedTutorial := &models.TutorialModel{ID: "orphaned-tutorial", Title: "Orphaned Tutorial", Slug: "orphaned-tutorial", FileKey: "orphaned-tutorial", Published: true}
+	db.SeedTutorials(orphanedTutorial)
+
+	orphanedCourse := databasetest.NewCourseBuilder().WithTitle("Orphaned Course").WithFileKey("orphaned-course").WithAuthor(author.ID).Published().Build()
+	boughtCourse := databasetest.NewCourseBuilder().WithTitle("Bought Course").WithFileKey("bought-course").WithAuthor(author.ID).Published().Build()
+	db.SeedCourses(orphanedCourse, boughtCourse)
+
+	buyer := databasetest.NewUserBuilder().Build()
+	db.SeedUsers(buyer)
+	db.SeedCoursePurchases(databasetest.NewCoursePurchaseBuilder(buyer.ID, boughtCourse.ID).Build())
+
+	return db, map[string]string{
+		"tutorial": orphanedTutorial.ID,
+		"course":   orphanedCourse.ID,
+		"bought":   boughtCourse.ID,
+	}
+}
+
+func orphanIDs(orphans map[string]string) map[string]bool {
+	ids := make(map[string]bool, len(orphans))
+	for _, id := range orphans {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+// changeList returns the one line summaries of the changes in a report.
+func changeList(report *Report) []string {
+	var changes []string
+	for _, change := range report.Changes {
+		changes = append(changes, change.String())
+	}
+
+	return changes
+}
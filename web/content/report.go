@@ -0,0 +1,177 @@
+package content
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Change describes a single change that the content loader made to the database, or would make during a dry run.
+// Keywords and Diff are only filled in during a dry run.
+type Change struct {
+	Kind            string
+	Title           string
+	Action          string
+	AddedKeywords   []string
+	RemovedKeywords []string
+	Diff            string
+}
+
+func (change Change) String() string {
+	return fmt.Sprintf("%s %s \"%s\"", change.Action, change.Kind, change.Title)
+}
+
+// Report keeps track of every change that the content loader made to the database. During a dry run it's the plan
+// of what would change instead.
+type Report struct {
+	DryRun  bool
+	Changes []Change
+}
+
+func (report *Report) add(kind, title, action string) {
+	report.addChange(Change{
+		Kind:   kind,
+		Title:  title,
+		Action: action,
+	})
+}
+
+func (report *Report) addChange(change Change) {
+	report.Changes = append(report.Changes, change)
+}
+
+// WritePlan writes every change along with its keyword changes and content diff to w.
+func (report *Report) WritePlan(w io.Writer) error {
+	for _, change := range report.Changes {
+		if _, err := fmt.Fprintln(w, change); err != nil {
+			return err
+		}
+
+		var keywords []string
+
+		for _, keyword := range change.AddedKeywords {
+			keywords = append(keywords, "+"+keyword)
+		}
+
+		for _, keyword := range change.RemovedKeywords {
+			keywords = append(keywords, "-"+keyword)
+		}
+
+		if len(keywords) > 0 {
+			if _, err := fmt.Fprintf(w, "keywords: %s\n", strings.Join(keywords, ", ")); err != nil {
+				return err
+			}
+		}
+
+		if change.Diff != "" {
+			if _, err := fmt.Fprintln(w, change.Diff); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// describe creates the change for a tutorial, course or chapter that is being added or updated. During a dry run the
+// change also gets the keyword changes and a unified diff between the database and the file. An empty oldDocument
+// means that the content is new.
+func (content *Content) describe(kind, title, action, file string, oldKeywords, newKeywords []string, oldDocument, newDocument string) Change {
+	change := Change{
+		Kind:   kind,
+		Title:  title,
+		Action: action,
+	}
+
+	if !content.DryRun {
+		return change
+	}
+
+	for _, keyword := range newKeywords {
+		if !slices.Contains(oldKeywords, keyword) {
+			change.AddedKeywords = append(change.AddedKeywords, keyword)
+		}
+	}
+
+	for _, keyword := range oldKeywords {
+		if !slices.Contains(newKeywords, keyword) {
+			change.RemovedKeywords = append(change.RemovedKeywords, keyword)
+		}
+	}
+
+	// SplitLines turns an empty string into a single blank line so new content has to start from no lines at all.
+	var oldLines []string
+	from := "/dev/null"
+
+	if oldDocument != "" {
+		oldLines = difflib.SplitLines(oldDocument)
+		from = "a/" + file
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        oldLines,
+		B:        difflib.SplitLines(newDocument),
+		FromFile: from,
+		ToFile:   "b/" + file,
+		Context:  3,
+	})
+	if err != nil {
+		content.ErrorLog.Printf("Failed to diff \"%s\": %s\n", file, err)
+	}
+
+	change.Diff = strings.TrimSuffix(diff, "\n")
+
+	return change
+}
+
+// document lays out the fields and HTML of a tutorial, course or chapter as text so that two versions can be diffed.
+func document(fields []string, html string) string {
+	return strings.Join(fields, "\n") + "\n\n" + html
+}
+
+// publishSchedules reads the pending publish schedules of every tutorial or course, keyed by ID. They're only needed to
+// diff the old publish times during a dry run so nothing gets read otherwise.
+func (content *Content) publishSchedules(ctx context.Context, kind string, get func(ctx context.Context) ([]*models.PublishScheduleModel, error)) map[string]*models.PublishScheduleModel {
+	schedules := make(map[string]*models.PublishScheduleModel)
+
+	if !content.DryRun {
+		return schedules
+	}
+
+	list, err := get(ctx)
+	if err != nil {
+		content.ErrorLog.Fatalf("Failed to read %s publish schedules from the database: %s\n", kind, err)
+	}
+
+	for _, schedule := range list {
+		schedules[schedule.ID] = schedule
+	}
+
+	return schedules
+}
+
+// scheduleOf returns the publish and unpublish times of a schedule. A missing schedule has neither.
+func scheduleOf(schedule *models.PublishScheduleModel) (sql.NullTime, sql.NullTime) {
+	if schedule == nil {
+		return sql.NullTime{}, sql.NullTime{}
+	}
+
+	return schedule.PublishAt, schedule.UnpublishAt
+}
+
+// scheduleString formats a publish or unpublish time for a diff. Times are shown in UTC because that is how the
+// database hands them back.
+func scheduleString(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+
+	return t.Time.UTC().Format(time.RFC3339)
+}
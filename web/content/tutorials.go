@@ -1,7 +1,9 @@
 package content
 
 import (
+	"context"
 	"crypto/sha256"
+	"database/sql"
 	"embed"
 	"encoding/hex"
 	"strings"
@@ -18,12 +20,17 @@ var tutorialsFS embed.FS
 
 // TutorialMatter is a struct representation of the metadata found in each tutorial markdown file.
 type TutorialMatter struct {
-	Title        string   `yaml:"title"`
-	Description  string   `yaml:"description"`
-	ThumbnailURL string   `yaml:"thumbnail_url"`
-	BannerURL    string   `yaml:"banner_url"`
-	Keywords     []string `yaml:"keywords"`
-	Key          string   `yaml:"key"`
+	Title        string     `yaml:"title"`
+	Description  string     `yaml:"description"`
+	ThumbnailURL string     `yaml:"thumbnail_url"`
+	BannerURL    string     `yaml:"banner_url"`
+	Keywords     []string   `yaml:"keywords"`
+	Slug         string     `yaml:"slug"`
+	Key          string     `yaml:"key"`
+	Series       string     `yaml:"series"`
+	SeriesOrder  int        `yaml:"series_order"`
+	PublishAt    *time.Time `yaml:"publish_at"`
+	UnpublishAt  *time.Time `yaml:"unpublish_at"`
 }
 
 // TutorialData is a struct representation for the information contained in a tutorial.
@@ -32,16 +39,18 @@ type TutorialData struct {
 	Content string
 }
 
-func (content *Content) RegisterTutorialsContent(db database.Database) {
+func (content *Content) RegisterTutorialsContent(ctx context.Context, db database.Database) {
 	db.PrepareBulkTutorials()
 
 	timerStart := time.Now()
 
-	tutorials, err := db.GetAllTutorials("", nil)
+	tutorials, err := db.GetAllTutorials(ctx, "", nil)
 	if err != nil {
 		content.ErrorLog.Fatalf("Failed to read all tutorials from the database: %s\n", err)
 	}
 
+	schedules := content.publishSchedules(ctx, "tutorial", db.GetTutorialPublishSchedules)
+
 	files, err := tutorialsFS.ReadDir("tutorials")
 	if err != nil {
 		content.ErrorLog.Printf("Failed to read tutorials embedded file system: %s\n", err)
@@ -49,6 +58,9 @@ func (content *Content) RegisterTutorialsContent(db database.Database) {
 
 	content.InfoLog.Printf("Parsing %d tutorials!\n", len(files))
 
+	// Keep track of the file keys that still have a markdown file so that orphaned tutorials can be found.
+	fileKeys := make(map[string]bool, len(files))
+
 	for _, file := range files {
 		// Skip over any directories.
 		if file.IsDir() {
@@ -71,6 +83,8 @@ func (content *Content) RegisterTutorialsContent(db database.Database) {
 			content.ErrorLog.Fatalf("Failed to parse markdown from \"%s\": %s", "tutorials/"+file.Name(), err)
 		}
 
+		fileKeys[matter.Key] = true
+
 		// Create the file checksum to be able to see if the file data has changed at all.
 		hasher := sha256.New()
 		hasher.Write(output)
@@ -91,29 +105,82 @@ func (content *Content) RegisterTutorialsContent(db database.Database) {
 			continue
 		}
 
+		rendered, err := RenderMarkdown(data)
+		if err != nil {
+			content.ErrorLog.Fatalf("Failed to render markdown from \"%s\": %s\n", "tutorials/"+file.Name(), err)
+		}
+
 		tutData.TutorialMatter = *matter
-		tutData.Content = string(MarkdownToHTML(data))
+		tutData.Content = rendered.HTML
+
+		slug := ContentSlug(tutData.Slug, tutData.Title)
+		filePath := "tutorials/" + file.Name()
+		publishAt := ScheduleTime(tutData.PublishAt)
+		unpublishAt := ScheduleTime(tutData.UnpublishAt)
+		seriesSlug := TitleToSlug(tutData.Series)
 
 		// This tutorial is new.
 		if !fileKeyFound {
-			db.InsertTutorial(tutData.Title, TitleToSlug(tutData.Title), tutData.Description, tutData.ThumbnailURL, tutData.BannerURL, tutData.Content, fileChecksum, tutData.Key, tutData.Keywords)
+			db.InsertTutorial(tutData.Title, slug, tutData.Description, tutData.ThumbnailURL, tutData.BannerURL, tutData.Content, rendered.TableOfContents, rendered.WordCount, rendered.ReadingTime, fileChecksum, tutData.Key, tutData.Keywords, tutData.Series, seriesSlug, tutData.SeriesOrder, publishAt, unpublishAt)
+			content.Report.addChange(content.describe("tutorial", tutData.Title, "Added", filePath, nil, tutData.Keywords, "", tutorialDocument(tutData.Title, slug, tutData.Description, tutData.ThumbnailURL, tutData.BannerURL, publishAt, unpublishAt, tutData.Content)))
 
 			continue
 		}
 
 		// This tutorial has been updated.
 		if !checksumMatch {
-			db.UpdateTutorial(tutorials[fileKeyIndex].ID, tutData.Title, TitleToSlug(tutData.Title), tutData.Description, tutData.ThumbnailURL, tutData.BannerURL, tutData.Content, string(fileChecksum), tutData.Key, tutData.Keywords, tutorials[fileKeyIndex].AuthorID)
+			tutorial := tutorials[fileKeyIndex]
+
+			db.UpdateTutorial(tutorial.ID, tutData.Title, slug, tutData.Description, tutData.ThumbnailURL, tutData.BannerURL, tutData.Content, rendered.TableOfContents, rendered.WordCount, rendered.ReadingTime, string(fileChecksum), tutData.Key, tutData.Keywords, tutData.Series, seriesSlug, tutData.SeriesOrder, tutorial.AuthorID, publishAt, unpublishAt)
+
+			var keywords []string
+			if content.DryRun {
+				keywords, err = db.GetAllKeywordsForTutorial(ctx, tutorial.ID)
+				if err != nil {
+					content.ErrorLog.Fatalf("Failed to get keywords for tutorial \"%s\": %s\n", tutorial.Title, err)
+				}
+			}
+
+			oldPublishAt, oldUnpublishAt := scheduleOf(schedules[tutorial.ID])
+
+			content.Report.addChange(content.describe("tutorial", tutData.Title, "Updated", filePath, keywords, tutData.Keywords, tutorialDocument(tutorial.Title, tutorial.Slug, tutorial.Description, tutorial.ThumbnailURL, tutorial.BannerURL, oldPublishAt, oldUnpublishAt, tutorial.Content), tutorialDocument(tutData.Title, slug, tutData.Description, tutData.ThumbnailURL, tutData.BannerURL, publishAt, unpublishAt, tutData.Content)))
 
 			continue
 		}
 	}
 
-	if err := db.RunBulkTutorials(); err != nil {
-		content.ErrorLog.Fatalln(err)
+	// The staged changes are thrown away during a dry run. They get cleared the next time the bulk functions are prepared.
+	if !content.DryRun {
+		if err := db.RunBulkTutorials(ctx); err != nil {
+			content.ErrorLog.Fatalln(err)
+		}
+	}
+
+	archived, err := archivedTutorials(ctx, db)
+	if err != nil {
+		content.ErrorLog.Fatalf("Failed to read archived tutorials from the database: %s\n", err)
+	}
+
+	for _, tutorial := range tutorials {
+		if !fileKeys[tutorial.FileKey] {
+			content.handleOrphanedTutorial(ctx, db, tutorial, archived[tutorial.ID])
+		}
 	}
 
 	timerEnd := time.Since(timerStart)
 
 	content.InfoLog.Printf("Parsed %d tutorials in %s\n", len(files), timerEnd)
 }
+
+// tutorialDocument lays out a tutorial as text so that the version in the database can be diffed with the file.
+func tutorialDocument(title, slug, description, thumbnailUrl, bannerUrl string, publishAt, unpublishAt sql.NullTime, html string) string {
+	return document([]string{
+		"title: " + title,
+		"slug: " + slug,
+		"description: " + description,
+		"thumbnail_url: " + thumbnailUrl,
+		"banner_url: " + bannerUrl,
+		"publish_at: " + scheduleString(publishAt),
+		"unpublish_at: " + scheduleString(unpublishAt),
+	}, html)
+}
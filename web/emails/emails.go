@@ -84,6 +84,11 @@ func (e *Emails) SendAccountDeletionEmail(email, firstName string) {
 	e.SendEmail(email, emailData.Title, "account-deletion", emailData)
 }
 
+func (e *Emails) SendDataExportEmail(email, firstName, dataExportToken string, validUntil time.Time) {
+	emailData := html.NewDataExportEmail(firstName, dataExportToken, validUntil)
+	e.SendEmail(email, emailData.Title, "data-export", emailData)
+}
+
 func (e *Emails) SendRefundRequestAcknowledgementEmail(email, firstName string) {
 	emailData := html.NewRefundRequestAcknowledgementEmail(firstName)
 	e.SendEmail(email, emailData.Title, "refund-request-acknowledgement", emailData)
@@ -108,3 +113,8 @@ func (e *Emails) SendRefundRequestSuccessfulEmail(email, firstName, courseName s
 	emailData := html.NewRefundRequestSuccessfulEmail(firstName, courseName, refundAmount)
 	e.SendEmail(email, emailData.Title, "refund-request-successful", emailData)
 }
+
+func (e *Emails) SendChapterReleasedEmail(email, firstName, courseTitle, courseSlug, chapterTitle, chapterSlug string) {
+	emailData := html.NewChapterReleasedEmail(firstName, courseTitle, courseSlug, chapterTitle, chapterSlug)
+	e.SendEmail(email, emailData.Title, "chapter-released", emailData)
+}
@@ -1,6 +1,7 @@
 package forms
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,10 +14,10 @@ import (
 	"github.com/PsionicAlch/course-platform/web/html"
 )
 
-func ValidateAffiliateCode(user *models.UserModel, payment *payments.Payments) validators.ValidationFunc {
+func ValidateAffiliateCode(ctx context.Context, user *models.UserModel, payment *payments.Payments) validators.ValidationFunc {
 	return func(data string, values url.Values) error {
 		if data != "" {
-			_, err := payment.ValidateAffiliateCode(user.ID, data)
+			_, err := payment.ValidateAffiliateCode(ctx, user.ID, data)
 			if err != nil {
 				switch err {
 				case payments.ErrCantUseOwnAffiliateCode:
@@ -33,7 +34,7 @@ func ValidateAffiliateCode(user *models.UserModel, payment *payments.Payments) v
 	}
 }
 
-func ValidateAffiliatePoints(user *models.UserModel, payment *payments.Payments) validators.ValidationFunc {
+func ValidateAffiliatePoints(ctx context.Context, user *models.UserModel, payment *payments.Payments) validators.ValidationFunc {
 	return func(data string, values url.Values) error {
 		if data != "" {
 			pointsUsed, err := strconv.ParseUint(data, 10, 64)
@@ -41,7 +42,7 @@ func ValidateAffiliatePoints(user *models.UserModel, payment *payments.Payments)
 				return errors.New("only numbers are allowed")
 			}
 
-			_, err = payment.ValidateAffiliatePointsUsed(user.ID, uint(pointsUsed))
+			_, err = payment.ValidateAffiliatePointsUsed(ctx, user.ID, uint(pointsUsed))
 			if err != nil {
 				switch err {
 				case payments.ErrInsufficientAffiliatePoints:
@@ -56,10 +57,10 @@ func ValidateAffiliatePoints(user *models.UserModel, payment *payments.Payments)
 	}
 }
 
-func ValidateDiscountCode(payment *payments.Payments) validators.ValidationFunc {
+func ValidateDiscountCode(ctx context.Context, payment *payments.Payments) validators.ValidationFunc {
 	return func(data string, values url.Values) error {
 		if data != "" {
-			_, err := payment.ValidateDiscountCode(data)
+			_, err := payment.ValidateDiscountCode(ctx, data)
 			if err != nil {
 				switch err {
 				case payments.ErrInvalidDiscountCode:
@@ -76,9 +77,9 @@ func ValidateDiscountCode(payment *payments.Payments) validators.ValidationFunc
 
 func NewCoursePurchaseForm(r *http.Request, user *models.UserModel, payment *payments.Payments) *GenericForm {
 	return NewForm(r, map[FieldName]validators.ValidationFunc{
-		AffiliateCodeName:   ValidateAffiliateCode(user, payment),
-		AffiliatePointsName: ValidateAffiliatePoints(user, payment),
-		DiscountCodeName:    ValidateDiscountCode(payment),
+		AffiliateCodeName:   ValidateAffiliateCode(r.Context(), user, payment),
+		AffiliatePointsName: ValidateAffiliatePoints(r.Context(), user, payment),
+		DiscountCodeName:    ValidateDiscountCode(r.Context(), payment),
 	})
 }
 
@@ -113,7 +114,7 @@ func EmptyCoursePurchaseFormComponent(courseSlug string, user *models.UserModel)
 	return coursePurchaseForm
 }
 
-func NewCoursePurchaseFormComponent(form *GenericForm, courseSlug string, user *models.UserModel, payment *payments.Payments) *html.CoursePurchaseFormComponent {
+func NewCoursePurchaseFormComponent(ctx context.Context, form *GenericForm, courseSlug string, user *models.UserModel, payment *payments.Payments) *html.CoursePurchaseFormComponent {
 	coursePurchaseForm := EmptyCoursePurchaseFormComponent(courseSlug, user)
 
 	affiliateCode := form.GetValue(AffiliateCodeName)
@@ -124,7 +125,7 @@ func NewCoursePurchaseFormComponent(form *GenericForm, courseSlug string, user *
 
 	if len(affiliateCodeErrors) == 0 {
 		if affiliateCode != "" {
-			if affiliateCodeDiscount, err := payment.ValidateAffiliateCode(user.ID, affiliateCode); err == nil {
+			if affiliateCodeDiscount, err := payment.ValidateAffiliateCode(ctx, user.ID, affiliateCode); err == nil {
 				coursePurchaseForm.AffiliateCodeDiscount = uint(affiliateCodeDiscount * 100)
 			}
 		}
@@ -139,7 +140,7 @@ func NewCoursePurchaseFormComponent(form *GenericForm, courseSlug string, user *
 	if len(affiliatePointsErrors) == 0 {
 		if affiliatePoints != "" {
 			if aps, err := strconv.ParseUint(affiliatePoints, 10, 64); err == nil {
-				if affiliatePointsDiscount, err := payment.ValidateAffiliatePointsUsed(user.ID, uint(aps)); err == nil {
+				if affiliatePointsDiscount, err := payment.ValidateAffiliatePointsUsed(ctx, user.ID, uint(aps)); err == nil {
 					coursePurchaseForm.AffiliatePointsDiscount = uint(affiliatePointsDiscount * 100)
 				}
 			}
@@ -154,18 +155,18 @@ func NewCoursePurchaseFormComponent(form *GenericForm, courseSlug string, user *
 
 	if len(discountCodeErrors) == 0 {
 		if discountCode != "" {
-			if discountCodeDiscount, err := payment.ValidateDiscountCode(discountCode); err == nil {
+			if discountCodeDiscount, err := payment.ValidateDiscountCode(ctx, discountCode); err == nil {
 				coursePurchaseForm.DiscountCodeDiscount = uint(discountCodeDiscount * 100)
 			}
 		}
 	}
 
 	if aps, err := strconv.ParseUint(affiliatePoints, 10, 64); err == nil {
-		if total, err := payment.CalculatePrice(user.ID, affiliateCode, discountCode, uint(aps)); err == nil {
+		if total, err := payment.CalculatePrice(ctx, user.ID, affiliateCode, discountCode, uint(aps)); err == nil {
 			coursePurchaseForm.Total = float64(total) / 100.0
 		}
 	} else {
-		if total, err := payment.CalculatePrice(user.ID, affiliateCode, discountCode, 0); err == nil {
+		if total, err := payment.CalculatePrice(ctx, user.ID, affiliateCode, discountCode, 0); err == nil {
 			coursePurchaseForm.Total = float64(total) / 100.0
 		}
 	}
@@ -2,6 +2,7 @@ package generators
 
 import (
 	"bytes"
+	"context"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -11,8 +12,15 @@ import (
 	"github.com/PsionicAlch/course-platform/web/html"
 )
 
+// GeneratorTimeout is how long a generator is allowed to query the database for. Generated feeds are cached and
+// shared between requests so they don't use the context of the request that triggered them.
+const GeneratorTimeout = 30 * time.Second
+
 func RSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func() (string, error) {
 	return func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
 		rssData := &html.GeneralRSS{
 			LastBuildTime: time.Now(),
 		}
@@ -20,7 +28,7 @@ func RSSFeed(loggers utils.Loggers, db database.Database, renderer render.Render
 		published := true
 		feed := new(bytes.Buffer)
 
-		tutorials, err := db.GetAllTutorials("", &published)
+		tutorials, err := db.GetAllTutorials(ctx, "", &published)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get all tutorials: %s\n", err)
 
@@ -33,7 +41,7 @@ func RSSFeed(loggers utils.Loggers, db database.Database, renderer render.Render
 
 		rssData.Tutorials = tutorials
 
-		courses, err := db.GetAllCourses("", &published)
+		courses, err := db.GetAllCourses(ctx, "", &published)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get all courses: %s\n", err)
 
@@ -52,7 +60,7 @@ func RSSFeed(loggers utils.Loggers, db database.Database, renderer render.Render
 		for _, tutorial := range tutorials {
 			author, contains := authorsCache[tutorial.AuthorID.String]
 			if !contains {
-				author, err = db.GetUserByID(tutorial.AuthorID.String, database.Author)
+				author, err = db.GetUserByID(ctx, tutorial.AuthorID.String, database.Author)
 				if err != nil {
 					loggers.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", tutorial.AuthorID.String, err)
 
@@ -74,7 +82,7 @@ func RSSFeed(loggers utils.Loggers, db database.Database, renderer render.Render
 			if !contains {
 				loggers.InfoLog.Printf("Adding author to cache: %s\n", course.AuthorID.String)
 
-				author, err = db.GetUserByID(course.AuthorID.String, database.Author)
+				author, err = db.GetUserByID(ctx, course.AuthorID.String, database.Author)
 				if err != nil {
 					loggers.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", course.AuthorID.String, err)
 
@@ -103,6 +111,9 @@ func RSSFeed(loggers utils.Loggers, db database.Database, renderer render.Render
 
 func TutorialsRSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func() (string, error) {
 	return func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
 		rssData := &html.TutorialsRSS{
 			LastBuildTime: time.Now(),
 		}
@@ -111,7 +122,7 @@ func TutorialsRSSFeed(loggers utils.Loggers, db database.Database, renderer rend
 
 		feed := new(bytes.Buffer)
 
-		tutorials, err := db.GetAllTutorials("", &published)
+		tutorials, err := db.GetAllTutorials(ctx, "", &published)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get all tutorials: %s\n", err)
 
@@ -130,7 +141,7 @@ func TutorialsRSSFeed(loggers utils.Loggers, db database.Database, renderer rend
 		for _, tutorial := range tutorials {
 			author, contains := authorsCache[tutorial.AuthorID.String]
 			if !contains {
-				author, err = db.GetUserByID(tutorial.AuthorID.String, database.Author)
+				author, err = db.GetUserByID(ctx, tutorial.AuthorID.String, database.Author)
 				if err != nil {
 					loggers.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", tutorial.AuthorID.String, err)
 
@@ -159,6 +170,9 @@ func TutorialsRSSFeed(loggers utils.Loggers, db database.Database, renderer rend
 
 func CoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func() (string, error) {
 	return func() (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
 		rssData := &html.CoursesRSS{
 			LastBuildTime: time.Now(),
 		}
@@ -167,7 +181,7 @@ func CoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer render
 
 		feed := new(bytes.Buffer)
 
-		courses, err := db.GetAllCourses("", &published)
+		courses, err := db.GetAllCourses(ctx, "", &published)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get all courses: %s\n", err)
 
@@ -186,7 +200,7 @@ func CoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer render
 		for _, course := range courses {
 			author, contains := authorsCache[course.AuthorID.String]
 			if !contains {
-				author, err = db.GetUserByID(course.AuthorID.String, database.Author)
+				author, err = db.GetUserByID(ctx, course.AuthorID.String, database.Author)
 				if err != nil {
 					loggers.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", course.AuthorID.String, err)
 
@@ -215,6 +229,9 @@ func CoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer render
 
 func AuthorTutorialsRSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func(authorSlug string) (string, error) {
 	return func(authorSlug string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
 		rssData := &html.TutorialAuthorRSS{
 			LastBuildTime: time.Now(),
 		}
@@ -223,7 +240,7 @@ func AuthorTutorialsRSSFeed(loggers utils.Loggers, db database.Database, rendere
 
 		feed := new(bytes.Buffer)
 
-		author, err := db.GetUserBySlug(authorSlug, database.Author)
+		author, err := db.GetUserBySlug(ctx, authorSlug, database.Author)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get author by slug (\"%s\"): %s\n", authorSlug, err)
 
@@ -244,7 +261,7 @@ func AuthorTutorialsRSSFeed(loggers utils.Loggers, db database.Database, rendere
 
 		rssData.Author = author
 
-		tutorials, err := db.GetAllTutorials(author.ID, &published)
+		tutorials, err := db.GetAllTutorials(ctx, author.ID, &published)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get all tutorials: %s\n", err)
 
@@ -275,6 +292,9 @@ func AuthorTutorialsRSSFeed(loggers utils.Loggers, db database.Database, rendere
 
 func AuthorCoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func(authorSlug string) (string, error) {
 	return func(authorSlug string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
 		rssData := &html.CourseAuthorRSS{
 			LastBuildTime: time.Now(),
 		}
@@ -283,7 +303,7 @@ func AuthorCoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer
 
 		feed := new(bytes.Buffer)
 
-		author, err := db.GetUserBySlug(authorSlug, database.Author)
+		author, err := db.GetUserBySlug(ctx, authorSlug, database.Author)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get author by slug (\"%s\"): %s\n", authorSlug, err)
 
@@ -304,7 +324,7 @@ func AuthorCoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer
 
 		rssData.Author = author
 
-		courses, err := db.GetAllCourses(author.ID, &published)
+		courses, err := db.GetAllCourses(ctx, author.ID, &published)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get all courses: %s\n", err)
 
@@ -335,13 +355,16 @@ func AuthorCoursesRSSFeed(loggers utils.Loggers, db database.Database, renderer
 
 func TutorialRSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func(tutorialSlug string) (string, error) {
 	return func(tutorialSlug string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
 		rssData := &html.TutorialRSS{
 			LastBuildTime: time.Now(),
 		}
 
 		feed := new(bytes.Buffer)
 
-		tutorial, err := db.GetTutorialBySlug(tutorialSlug)
+		tutorial, err := db.GetTutorialBySlug(ctx, tutorialSlug)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get tutorial by slug (\"%s\"): %s\n", tutorialSlug, err)
 
@@ -362,7 +385,7 @@ func TutorialRSSFeed(loggers utils.Loggers, db database.Database, renderer rende
 
 		rssData.Tutorial = tutorial
 
-		author, err := db.GetUserByID(tutorial.AuthorID.String, database.Author)
+		author, err := db.GetUserByID(ctx, tutorial.AuthorID.String, database.Author)
 		if err != nil {
 			loggers.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", tutorial.AuthorID.String, err)
 
@@ -382,3 +405,89 @@ func TutorialRSSFeed(loggers utils.Loggers, db database.Database, renderer rende
 		return feed.String(), err
 	}
 }
+
+func SeriesRSSFeed(loggers utils.Loggers, db database.Database, renderer render.Renderer) func(seriesSlug string) (string, error) {
+	return func(seriesSlug string) (string, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), GeneratorTimeout)
+		defer cancel()
+
+		rssData := &html.SeriesRSS{
+			LastBuildTime: time.Now(),
+		}
+
+		feed := new(bytes.Buffer)
+
+		series, err := db.GetSeriesBySlug(ctx, seriesSlug)
+		if err != nil {
+			loggers.ErrorLog.Printf("Failed to get series by slug (\"%s\"): %s\n", seriesSlug, err)
+
+			if err := renderer.Render(feed, nil, "errors-500-rss", nil); err != nil {
+				loggers.ErrorLog.Println(err)
+			}
+
+			return feed.String(), err
+		}
+
+		if series == nil {
+			if err := renderer.Render(feed, nil, "errors-404-rss", nil); err != nil {
+				loggers.ErrorLog.Println(err)
+			}
+
+			return feed.String(), err
+		}
+
+		rssData.Series = series
+
+		tutorials, err := db.GetSeriesTutorials(ctx, series.ID)
+		if err != nil {
+			loggers.ErrorLog.Printf("Failed to get tutorials for series (\"%s\"): %s\n", series.Title, err)
+
+			if err := renderer.Render(feed, nil, "errors-500-rss", nil); err != nil {
+				loggers.ErrorLog.Println(err)
+			}
+
+			return feed.String(), err
+		}
+
+		if len(tutorials) == 0 {
+			if err := renderer.Render(feed, nil, "errors-404-rss", nil); err != nil {
+				loggers.ErrorLog.Println(err)
+			}
+
+			return feed.String(), err
+		}
+
+		rssData.Tutorials = tutorials
+
+		authors := make(map[string]*models.UserModel, len(tutorials))
+		authorsCache := make(map[string]*models.UserModel)
+
+		for _, tutorial := range tutorials {
+			author, contains := authorsCache[tutorial.AuthorID.String]
+			if !contains {
+				author, err = db.GetUserByID(ctx, tutorial.AuthorID.String, database.Author)
+				if err != nil {
+					loggers.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", tutorial.AuthorID.String, err)
+
+					if err := renderer.Render(feed, nil, "errors-500-rss", nil); err != nil {
+						loggers.ErrorLog.Println(err)
+					}
+
+					return feed.String(), err
+				}
+
+				authorsCache[tutorial.AuthorID.String] = author
+			}
+
+			authors[tutorial.ID] = author
+		}
+
+		rssData.Authors = authors
+
+		if err := renderer.Render(feed, nil, "series-rss", rssData); err != nil {
+			loggers.ErrorLog.Println(err)
+		}
+
+		return feed.String(), err
+	}
+}
@@ -130,6 +130,7 @@ type ChangePasswordFormComponent struct {
 type TutorialsListComponent struct {
 	Tutorials    []*models.TutorialModel
 	LastTutorial *models.TutorialModel
+	Snippets     map[string]string
 	QueryURL     string
 	ErrorMessage string
 }
@@ -145,6 +146,7 @@ type CommentsListComponent struct {
 type CoursesListComponent struct {
 	Courses      []*models.CourseModel
 	LastCourse   *models.CourseModel
+	Snippets     map[string]string
 	QueryURL     string
 	ErrorMessage string
 }
@@ -159,6 +161,7 @@ type AdminUsersListComponent struct {
 	CoursesWritten      map[string]uint
 	BaseURL             string
 	URLQuery            string
+	Deleted             bool
 	ErrorMessage        string
 }
 
@@ -180,6 +183,7 @@ type AdminTutorialsListComponent struct {
 	Bookmarks    map[string]uint
 	BaseURL      string
 	URLQuery     string
+	Deleted      bool
 	ErrorMessage string
 }
 
@@ -200,6 +204,7 @@ type AdminCoursesListComponent struct {
 	Purchases    map[string]uint
 	BaseURL      string
 	URLQuery     string
+	Deleted      bool
 	ErrorMessage string
 }
 
@@ -210,6 +215,16 @@ type AdminCommentsListComponent struct {
 	Tutorials    map[string]*models.TutorialModel
 	BaseURL      string
 	URLQuery     string
+	Deleted      bool
+	ErrorMessage string
+}
+
+type AdminAuditListComponent struct {
+	AuditLogs    []*models.AuditLogModel
+	LastAuditLog *models.AuditLogModel
+	Actors       map[string]*models.UserModel
+	BaseURL      string
+	URLQuery     string
 	ErrorMessage string
 }
 
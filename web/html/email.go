@@ -109,6 +109,22 @@ func NewAccountDeletionEmail(firstName string) *AccountDeletionEmail {
 	}
 }
 
+type DataExportEmail struct {
+	BaseEmail
+	FirstName       string
+	DataExportToken string
+	ValidUntil      time.Time
+}
+
+func NewDataExportEmail(firstName, dataExportToken string, validUntil time.Time) *DataExportEmail {
+	return &DataExportEmail{
+		BaseEmail:       NewBaseEmail("Your Data Is Ready To Download"),
+		FirstName:       firstName,
+		DataExportToken: dataExportToken,
+		ValidUntil:      validUntil,
+	}
+}
+
 type RefundRequestAcknowledgementEmail struct {
 	BaseEmail
 	FirstName string
@@ -184,3 +200,23 @@ func NewRefundRequestSuccessfulEmail(firstName, courseName string, refundAmount
 		RefundAmount: refundAmount,
 	}
 }
+
+type ChapterReleasedEmail struct {
+	BaseEmail
+	FirstName    string
+	CourseTitle  string
+	CourseSlug   string
+	ChapterTitle string
+	ChapterSlug  string
+}
+
+func NewChapterReleasedEmail(firstName, courseTitle, courseSlug, chapterTitle, chapterSlug string) *ChapterReleasedEmail {
+	return &ChapterReleasedEmail{
+		BaseEmail:    NewBaseEmail("A New Chapter Has Been Unlocked"),
+		FirstName:    firstName,
+		CourseTitle:  courseTitle,
+		CourseSlug:   courseSlug,
+		ChapterTitle: chapterTitle,
+		ChapterSlug:  chapterSlug,
+	}
+}
@@ -1,6 +1,11 @@
 package html
 
-import "github.com/PsionicAlch/course-platform/internal/database/models"
+import (
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database/instrumented_database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+)
 
 type BasePage struct {
 	Navbar    *NavbarComponent
@@ -34,6 +39,22 @@ type AccountsResetPasswordPage struct {
 	ResetPasswordForm *ResetPasswordFormComponent
 }
 
+type AdminAuditPage struct {
+	BasePage
+	NumAuditLogs uint
+	URLQuery     string
+	Actions      []string
+	Admins       []*models.UserModel
+	AuditLogs    *AdminAuditListComponent
+}
+
+type AdminMetricsPage struct {
+	BasePage
+	Enabled bool
+	Buckets []string
+	Methods []instrumented_database.MethodMetrics
+}
+
 type AdminCommentsPage struct {
 	BasePage
 	NumComments uint
@@ -50,6 +71,7 @@ type AdminCoursesPage struct {
 	PublishStatus []string
 	Authors       []*models.UserModel
 	Keywords      []string
+	Schedules     []*models.PublishScheduleModel
 	Courses       *AdminCoursesListComponent
 }
 
@@ -89,6 +111,7 @@ type AdminTutorialsPage struct {
 	PublishStatus []string
 	Authors       []*models.UserModel
 	Keywords      []string
+	Schedules     []*models.PublishScheduleModel
 	Tutorials     *AdminTutorialsListComponent
 }
 
@@ -215,6 +238,24 @@ type ProfileCourse struct {
 	LastChapter        bool
 	Completed          map[string]bool
 	HasCompletedCourse bool
+	Locked             map[string]bool
+	ReleasesAt         time.Time
+	Quiz               *models.QuizModel
+	QuizPassed         bool
+	LastAttempt        *models.QuizAttemptModel
+}
+
+type ProfileQuizzesPage struct {
+	BasePage
+	Quizzes []*ChapterQuizStats
+}
+
+// ChapterQuizStats holds how often each question of a chapter's quiz has been answered correctly.
+type ChapterQuizStats struct {
+	Course    *models.CourseModel
+	Chapter   *models.ChapterModel
+	Quiz      *models.QuizModel
+	Questions []*models.QuizQuestionStatsModel
 }
 
 type ProfileTutorialsBookmarksPage struct {
@@ -231,6 +272,18 @@ type RedirectScreenPage struct {
 	RedirectURL string
 }
 
+type SeriesPage struct {
+	BasePage
+	Series    []*models.SeriesModel
+	Tutorials map[string][]*models.TutorialModel
+}
+
+type SeriesTutorialsPage struct {
+	BasePage
+	Series    *models.SeriesModel
+	Tutorials *TutorialsListComponent
+}
+
 type SettingsPage struct {
 	BasePage
 	ChangeFirstNameForm *ChangeFirstNameFormComponent
@@ -256,4 +309,9 @@ type TutorialsTutorialPage struct {
 	TutorialLiked      bool
 	TutorialBookmarked bool
 	Comments           *CommentsListComponent
+	Series             *models.SeriesModel
+	SeriesTutorials    []*models.TutorialModel
+	SeriesPart         int
+	PreviousTutorial   *models.TutorialModel
+	NextTutorial       *models.TutorialModel
 }
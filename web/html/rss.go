@@ -42,3 +42,10 @@ type TutorialRSS struct {
 	Tutorial      *models.TutorialModel
 	Author        *models.UserModel
 }
+
+type SeriesRSS struct {
+	LastBuildTime time.Time
+	Series        *models.SeriesModel
+	Tutorials     []*models.TutorialModel
+	Authors       map[string]*models.UserModel
+}
@@ -69,7 +69,7 @@ func (h *Handlers) LoginPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, cookie, err := h.Authentication.LogUserIn(email, password)
+	user, cookie, err := h.Authentication.LogUserIn(r.Context(), email, password)
 	if err != nil {
 		if err == authentication.ErrInvalidCredentials {
 			loginForm.SetEmailError("invalid email or password")
@@ -92,7 +92,7 @@ func (h *Handlers) LoginPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	userIpAddresses, err := h.Database.GetUserIpAddresses(user.ID)
+	userIpAddresses, err := h.Database.GetUserIpAddresses(r.Context(), user.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user's (\"%s\") whitelisted IP addresses: %s\n", user.Email, err)
 	} else {
@@ -154,7 +154,7 @@ func (h *Handlers) SignupPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, cookie, err := h.Authentication.SignUserUp(firstName, lastName, email, password, ipAddr)
+	user, cookie, err := h.Authentication.SignUserUp(r.Context(), firstName, lastName, email, password, ipAddr)
 	if err != nil {
 		if err == authentication.ErrUserExists {
 			signupForm.SetEmailError("this email has already been registered")
@@ -177,11 +177,11 @@ func (h *Handlers) SignupPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	discount, err := h.Payment.CreateDiscount(fmt.Sprintf("Welcome discount for %s %s", user.Name, user.Surname), fmt.Sprintf("A discount that encourages %s %s to buy their first course after creating a new account", user.Name, user.Surname), 50, 1)
+	discount, err := h.Payment.CreateDiscount(r.Context(), fmt.Sprintf("Welcome discount for %s %s", user.Name, user.Surname), fmt.Sprintf("A discount that encourages %s %s to buy their first course after creating a new account", user.Name, user.Surname), 50, 1)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to create a welcome discount for %s %s: %s\n", user.Name, user.Surname, err)
 	} else {
-		latestCourses, err := h.Database.GetCourses("", "", 1, 5)
+		latestCourses, _, err := h.Database.GetCourses(r.Context(), "", "", nil, 5)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get the latest courses: %s\n", err)
 		} else {
@@ -200,7 +200,7 @@ func (h *Handlers) SignupPost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handlers) LogoutDelete(w http.ResponseWriter, r *http.Request) {
-	cookie, err := h.Authentication.LogUserOut(r.Cookies())
+	cookie, err := h.Authentication.LogUserOut(r.Context(), r.Cookies())
 	if err != nil {
 		h.ErrorLog.Printf("An error occurred whilst logging user out: %s\n", err)
 	}
@@ -238,7 +238,7 @@ func (h *Handlers) ForgotPasswordPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	email := forms.GetForgotPasswordFormValues(forgotPasswordForm)
-	user, resetToken, err := h.Authentication.GeneratePasswordResetToken(email)
+	user, resetToken, err := h.Authentication.GeneratePasswordResetToken(r.Context(), email)
 	if err != nil && err != authentication.ErrUnregisteredEmail {
 		h.ErrorLog.Printf("Failed to generate new password reset token: %s\n", err)
 
@@ -270,7 +270,7 @@ func (h *Handlers) ResetPasswordGet(w http.ResponseWriter, r *http.Request) {
 		ResetPasswordForm: forms.EmptyResetPasswordFormComponent(emailToken),
 	}
 
-	valid, err := h.Authentication.ValidateEmailToken(emailToken)
+	valid, err := h.Authentication.ValidateEmailToken(r.Context(), emailToken)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to validate password reset token: %s\n", err)
 
@@ -308,7 +308,7 @@ func (h *Handlers) ResetPasswordPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	valid, err := h.Authentication.ValidateEmailToken(emailToken)
+	valid, err := h.Authentication.ValidateEmailToken(r.Context(), emailToken)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to validate password reset token: %s\n", err)
 
@@ -328,7 +328,7 @@ func (h *Handlers) ResetPasswordPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.Authentication.GetUserFromEmailToken(emailToken)
+	user, err := h.Authentication.GetUserFromEmailToken(r.Context(), emailToken)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user from password reset token: %s\n", err)
 
@@ -343,7 +343,7 @@ func (h *Handlers) ResetPasswordPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	password, _ := forms.GetResetPasswordFormValues(resetPasswordForm)
-	err = h.Authentication.ChangeUserPassword(user, password)
+	err = h.Authentication.ChangeUserPassword(r.Context(), user, password)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user from password reset token: %s\n", err)
 
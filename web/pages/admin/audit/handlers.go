@@ -0,0 +1,198 @@
+package audit
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/html"
+	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/justinas/nosurf"
+)
+
+const AuditLogsPerPagination = 25
+
+type Handlers struct {
+	utils.Loggers
+	*pages.HandlerContext
+}
+
+func SetupHandlers(handlerContext *pages.HandlerContext) *Handlers {
+	loggers := utils.CreateLoggers("ADMIN AUDIT HANDLERS")
+
+	return &Handlers{
+		Loggers:        loggers,
+		HandlerContext: handlerContext,
+	}
+}
+
+func (h *Handlers) AuditGet(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	pageData := html.AdminAuditPage{
+		BasePage: html.NewBasePage(user, nosurf.Token(r)),
+	}
+
+	auditList, urlQuery, err := h.CreateAuditList(r)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to create audit log list: %s\n", err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	pageData.AuditLogs = auditList
+
+	urlQuery.Del("cursor")
+	pageData.URLQuery = urlQuery.Encode()
+
+	actions := make([]string, 0, len(database.AuditActions))
+	for _, action := range database.AuditActions {
+		actions = append(actions, action.String())
+	}
+
+	pageData.Actions = actions
+
+	admins, err := h.Database.GetUsers(r.Context(), "", database.Admin, "", "")
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get all admins: %s\n", err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	pageData.Admins = admins
+
+	numAuditLogs, err := h.Database.CountAuditLogs(r.Context())
+	if err != nil {
+		h.ErrorLog.Printf("Failed to count the audit log entries: %s\n", err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	pageData.NumAuditLogs = numAuditLogs
+
+	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-audit", pageData); err != nil {
+		h.ErrorLog.Println(err)
+	}
+}
+
+func (h *Handlers) AuditPaginationGet(w http.ResponseWriter, r *http.Request) {
+	auditList, _, err := h.CreateAuditList(r)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to create audit log list: %s\n", err)
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "admin-audit", html.AdminAuditListComponent{ErrorMessage: "Failed to get audit log. Please try again."}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := h.Renderers.Htmx.RenderHTML(w, nil, "admin-audit", auditList); err != nil {
+		h.ErrorLog.Println(err)
+	}
+}
+
+// Possible URL queries:
+// - cursor
+// - query
+// - action
+// - actor
+func (h *Handlers) CreateAuditList(r *http.Request) (*html.AdminAuditListComponent, url.Values, error) {
+	urlQuery := CreateUrlQuery(r)
+
+	query := urlQuery.Get("query")
+	action := urlQuery.Get("action")
+	actorId := urlQuery.Get("actor")
+
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
+
+	auditLogs, next, err := h.Database.AdminGetAuditLogs(r.Context(), query, action, actorId, cursor, AuditLogsPerPagination)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get audit log entries from the database: %s\n", err)
+		return nil, urlQuery, err
+	}
+
+	var auditLogsSlice []*models.AuditLogModel
+	var lastAuditLog *models.AuditLogModel
+
+	if next == nil {
+		auditLogsSlice = auditLogs
+	} else {
+		auditLogsSlice = auditLogs[:len(auditLogs)-1]
+		lastAuditLog = auditLogs[len(auditLogs)-1]
+
+		urlQuery.Add("cursor", next.String())
+	}
+
+	actors := make(map[string]*models.UserModel, len(auditLogs))
+
+	for _, auditLog := range auditLogs {
+		actor, err := h.Database.GetUserByID(r.Context(), auditLog.ActorID, database.All)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get user (\"%s\") by ID: %s\n", auditLog.ActorID, err)
+			return nil, urlQuery, err
+		}
+
+		actors[auditLog.ID] = actor
+	}
+
+	auditList := &html.AdminAuditListComponent{
+		AuditLogs:    auditLogsSlice,
+		LastAuditLog: lastAuditLog,
+		Actors:       actors,
+		BaseURL:      "/admin/audit/htmx",
+		URLQuery:     urlQuery.Encode(),
+	}
+
+	return auditList, urlQuery, nil
+}
+
+func CreateUrlQuery(r *http.Request) url.Values {
+	urlQuery := make(url.Values)
+
+	if q := r.URL.Query().Get("query"); q != "" {
+		urlQuery.Add("query", q)
+	}
+
+	if a := r.URL.Query().Get("action"); a != "" {
+		urlQuery.Add("action", a)
+	}
+
+	if a := r.URL.Query().Get("actor"); a != "" {
+		urlQuery.Add("actor", a)
+	}
+
+	return urlQuery
+}
+
+// Record adds an entry to the audit log for an action that the currently logged in admin
+// took. The IP address is taken from the request so that handlers only need to describe
+// what changed.
+func Record(r *http.Request, db database.Database, action database.AuditAction, targetId, before, after string) error {
+	var actorId string
+	if user := authentication.GetUserFromRequest(r); user != nil {
+		actorId = user.ID
+	}
+
+	ipAddr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ipAddr = r.RemoteAddr
+	}
+
+	return db.AddAuditLog(r.Context(), actorId, action, targetId, before, after, ipAddr)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -12,12 +11,16 @@ import (
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
 	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/audit"
 	"github.com/go-chi/chi/v5"
 	"github.com/justinas/nosurf"
 )
 
 const CommentsPerPagination = 25
 
+// DeletedStatus is the value of the status filter that lists the comments that have been deleted.
+const DeletedStatus = "Deleted"
+
 type Handlers struct {
 	utils.Loggers
 	*pages.HandlerContext
@@ -51,10 +54,10 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Comments = commentsList
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
-	tutorials, err := h.Database.GetAllTutorials("", nil)
+	tutorials, err := h.Database.GetAllTutorials(r.Context(), "", nil)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all tutorials: %s\n", err)
 
@@ -67,7 +70,7 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Tutorials = tutorials
 
-	users, err := h.Database.GetAllUsers()
+	users, err := h.Database.GetAllUsers(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all users: %s\n", err)
 
@@ -80,7 +83,7 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Users = users
 
-	numComments, err := h.Database.CountComments()
+	numComments, err := h.Database.CountComments(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count the comments: %s\n", err)
 
@@ -120,7 +123,12 @@ func (h *Handlers) CommentDelete(w http.ResponseWriter, r *http.Request) {
 
 	commentId := chi.URLParam(r, "comment-id")
 
-	if err := h.Database.DeleteComment(commentId); err != nil {
+	comment, err := h.Database.GetCommentByID(r.Context(), commentId)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get comment by ID (\"%s\"): %s\n", commentId, err)
+	}
+
+	if err := h.Database.DeleteComment(r.Context(), commentId); err != nil {
 		h.ErrorLog.Printf("Failed to delete comment (\"%s\"): %s\n", commentId, err)
 
 		resp := fmt.Sprintf(`<button class="btn btn-red shadow-sm" hx-delete="/admin/comments/%s">Delete Comment</button>`, commentId)
@@ -136,51 +144,93 @@ func (h *Handlers) CommentDelete(w http.ResponseWriter, r *http.Request) {
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
 			h.ErrorLog.Println(err)
 		}
+
+		return
 	}
 
-	urlQuery.Set("page", "1")
+	var content string
+	if comment != nil {
+		content = comment.Content
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditDeleteComment, commentId, content, ""); err != nil {
+		h.ErrorLog.Printf("Failed to record comment (\"%s\") deletion in the audit log: %s\n", commentId, err)
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/admin/comments?%s", urlQuery.Encode()))
+}
+
+func (h *Handlers) CommentRestore(w http.ResponseWriter, r *http.Request) {
+	urlQuery := CreateUrlQuery(r)
+
+	commentId := chi.URLParam(r, "comment-id")
+
+	if err := h.Database.RestoreComment(r.Context(), commentId); err != nil {
+		h.ErrorLog.Printf("Failed to restore comment (\"%s\"): %s\n", commentId, err)
+
+		resp := fmt.Sprintf(`<button class="btn btn-blue shadow-sm" hx-post="/admin/comments/%s/restore">Restore Comment</button>`, commentId)
+		resp += `
+		<script>
+		notyf.open({
+			type: 'flash-error',
+			message: 'Failed to restore comment. Please try again.'
+		});
+		</script>
+		`
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditRestoreComment, commentId, "Deleted", "Active"); err != nil {
+		h.ErrorLog.Printf("Failed to record comment (\"%s\") restoration in the audit log: %s\n", commentId, err)
+	}
 
 	w.Header().Set("HX-Redirect", fmt.Sprintf("/admin/comments?%s", urlQuery.Encode()))
 }
 
 // Possible URL queries:
-// - page
+// - cursor
 // - query
 // - tutorial
 // - user
+// - status
 func (h *Handlers) CreateCommentsList(r *http.Request) (*html.AdminCommentsListComponent, url.Values, error) {
 	urlQuery := CreateUrlQuery(r)
 
 	query := urlQuery.Get("query")
 	tutorialId := urlQuery.Get("tutorial")
 	userId := urlQuery.Get("user")
-	page := 1
+	deleted := urlQuery.Get("status") == DeletedStatus
 
-	if p, err := strconv.Atoi(urlQuery.Get("page")); err == nil {
-		page = p - 1
-	}
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
-	comments, err := h.Database.AdminGetComments(query, tutorialId, userId, uint(page), CommentsPerPagination)
+	comments, next, err := h.Database.AdminGetComments(r.Context(), query, tutorialId, userId, deleted, cursor, CommentsPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get all comments (page %d) from the database: %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get all comments from the database: %s\n", err)
 		return nil, urlQuery, err
 	}
 
 	var commentsSlice []*models.CommentModel
 	var lastComment *models.CommentModel
 
-	if len(comments) < CommentsPerPagination {
+	if next == nil {
 		commentsSlice = comments
 	} else {
 		commentsSlice = comments[:len(comments)-1]
 		lastComment = comments[len(comments)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	users := make(map[string]*models.UserModel, len(comments))
 	tutorials := make(map[string]*models.TutorialModel, len(comments))
 
 	for _, comment := range comments {
-		user, err := h.Database.GetUserByID(comment.UserID, database.All)
+		user, err := h.Database.GetUserByID(r.Context(), comment.UserID, database.All)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get user (\"%s\") by ID: %s\n", comment.UserID, err)
 			return nil, urlQuery, err
@@ -188,7 +238,7 @@ func (h *Handlers) CreateCommentsList(r *http.Request) (*html.AdminCommentsListC
 
 		users[comment.ID] = user
 
-		tutorial, err := h.Database.GetTutorialByID(comment.TutorialID)
+		tutorial, err := h.Database.GetTutorialByID(r.Context(), comment.TutorialID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get tutorial (\"%s\") by ID: %s\n", comment.TutorialID, err)
 			return nil, urlQuery, err
@@ -204,6 +254,7 @@ func (h *Handlers) CreateCommentsList(r *http.Request) (*html.AdminCommentsListC
 		Users:       users,
 		BaseURL:     "/admin/comments/htmx",
 		URLQuery:    urlQuery.Encode(),
+		Deleted:     deleted,
 	}
 
 	return commentsList, urlQuery, nil
@@ -212,12 +263,6 @@ func (h *Handlers) CreateCommentsList(r *http.Request) (*html.AdminCommentsListC
 func CreateUrlQuery(r *http.Request) url.Values {
 	urlQuery := make(url.Values)
 
-	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		urlQuery.Add("page", fmt.Sprintf("%d", p+1))
-	} else {
-		urlQuery.Add("page", "2")
-	}
-
 	if q := r.URL.Query().Get("query"); q != "" {
 		urlQuery.Add("query", q)
 	}
@@ -230,5 +275,9 @@ func CreateUrlQuery(r *http.Request) url.Values {
 		urlQuery.Add("user", u)
 	}
 
+	if r.URL.Query().Get("status") == DeletedStatus {
+		urlQuery.Add("status", DeletedStatus)
+	}
+
 	return urlQuery
 }
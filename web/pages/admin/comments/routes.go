@@ -15,6 +15,7 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 	router.Get("/", handlers.CommentsGet)
 	router.Get("/htmx", handlers.CommentsPaginationGet)
 	router.Delete("/{comment-id}", handlers.CommentDelete)
+	router.Post("/{comment-id}/restore", handlers.CommentRestore)
 
 	return router
 }
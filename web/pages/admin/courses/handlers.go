@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -13,6 +12,7 @@ import (
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
 	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/audit"
 	"github.com/go-chi/chi/v5"
 	"github.com/justinas/nosurf"
 )
@@ -21,6 +21,9 @@ const CoursesPerPagination = 25
 
 var PublishStatuses = []string{"Published", "Unpublished"}
 
+// DeletedStatus lists the courses that have been deleted when it's used as the status filter.
+const DeletedStatus = "Deleted"
+
 type Handlers struct {
 	utils.Loggers
 	*pages.HandlerContext
@@ -54,12 +57,12 @@ func (h *Handlers) CoursesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Courses = coursesList
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
 	pageData.PublishStatus = PublishStatuses
 
-	numCourses, err := h.Database.CountCourses()
+	numCourses, err := h.Database.CountCourses(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count the number of courses in the database: %s\n", err)
 
@@ -72,7 +75,7 @@ func (h *Handlers) CoursesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.NumCourses = numCourses
 
-	authors, err := h.Database.GetUsers("", database.Author, "", "")
+	authors, err := h.Database.GetUsers(r.Context(), "", database.Author, "", "")
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all the authors from the database: %s\n", err)
 
@@ -85,7 +88,7 @@ func (h *Handlers) CoursesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Authors = authors
 
-	keywords, err := h.Database.GetKeywords()
+	keywords, err := h.Database.GetKeywords(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all the keywords from the database: %s\n", err)
 
@@ -98,6 +101,21 @@ func (h *Handlers) CoursesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Keywords = keywords
 
+	schedules, err := h.Database.GetCoursePublishSchedules(r.Context())
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get the scheduled courses from the database: %s\n", err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{
+			BasePage: html.NewBasePage(user, nosurf.Token(r)),
+		}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	pageData.Schedules = schedules
+
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-courses", pageData); err != nil {
 		h.ErrorLog.Println(err)
 	}
@@ -123,7 +141,7 @@ func (h *Handlers) CoursesPaginationGet(w http.ResponseWriter, r *http.Request)
 func (h *Handlers) PublishedEditGet(w http.ResponseWriter, r *http.Request) {
 	courseId := chi.URLParam(r, "course-id")
 
-	course, err := h.Database.GetCourseByID(courseId)
+	course, err := h.Database.GetCourseByID(r.Context(), courseId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by ID \"%s\": %s\n", courseId, err)
 
@@ -194,7 +212,7 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	publishStatus := r.Form.Get("publish-status")
 
-	course, err := h.Database.GetCourseByID(courseId)
+	course, err := h.Database.GetCourseByID(r.Context(), courseId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by ID \"%s\": %s\n", courseId, err)
 
@@ -256,7 +274,7 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if publishStatus == "Published" {
-		if err := h.Database.PublishCourse(course.ID); err != nil {
+		if err := h.Database.PublishCourse(r.Context(), course.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update tutorial's (\"%s\") publish status: %s\n", course.Title, err)
 
 			resp := "Unpublished"
@@ -276,11 +294,15 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditPublishCourse, course.ID, publishStatusName(course), "Published"); err != nil {
+			h.ErrorLog.Printf("Failed to record publish status change for course (\"%s\") in the audit log: %s\n", course.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "Published"); err != nil {
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.UnpublishCourse(course.ID); err != nil {
+		if err := h.Database.UnpublishCourse(r.Context(), course.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update course's (\"%s\") publish status: %s\n", course.Title, err)
 
 			resp := "Published"
@@ -300,6 +322,10 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditUnpublishCourse, course.ID, publishStatusName(course), "Unpublished"); err != nil {
+			h.ErrorLog.Printf("Failed to record publish status change for course (\"%s\") in the audit log: %s\n", course.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "Unpublished"); err != nil {
 			h.ErrorLog.Println(err)
 		}
@@ -309,7 +335,7 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) AuthorEditGet(w http.ResponseWriter, r *http.Request) {
 	courseId := chi.URLParam(r, "course-id")
 
-	course, err := h.Database.GetCourseByID(courseId)
+	course, err := h.Database.GetCourseByID(r.Context(), courseId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by ID \"%s\": %s\n", courseId, err)
 
@@ -330,7 +356,7 @@ func (h *Handlers) AuthorEditGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authors, err := h.Database.GetUsers("", database.Author, "", "")
+	authors, err := h.Database.GetUsers(r.Context(), "", database.Author, "", "")
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get authors: %s\n", err)
 
@@ -383,7 +409,7 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	authorId := r.Form.Get("author")
 
-	course, err := h.Database.GetCourseByID(courseId)
+	course, err := h.Database.GetCourseByID(r.Context(), courseId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by ID \"%s\": %s\n", courseId, err)
 
@@ -404,7 +430,7 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Database.UpdateCourseAuthor(course.ID, authorId); err != nil {
+	if err := h.Database.UpdateCourseAuthor(r.Context(), course.ID, authorId); err != nil {
 		h.ErrorLog.Printf("Failed to update course's \"%s\" author \"%s\": %s\n", course.ID, authorId, err)
 
 		resp := "No Author"
@@ -424,12 +450,16 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := audit.Record(r, h.Database, database.AuditUpdateCourseAuthor, course.ID, course.AuthorID.String, authorId); err != nil {
+		h.ErrorLog.Printf("Failed to record author change for course (\"%s\") in the audit log: %s\n", course.ID, err)
+	}
+
 	var resp string
 
 	if authorId == "" {
 		resp = "No Author"
 	} else {
-		author, err := h.Database.GetUserByID(authorId, database.Author)
+		author, err := h.Database.GetUserByID(r.Context(), authorId, database.Author)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to update course's \"%s\" author \"%s\": %s\n", course.ID, authorId, err)
 
@@ -458,16 +488,76 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handlers) CourseDelete(w http.ResponseWriter, r *http.Request) {
+	courseId := chi.URLParam(r, "course-id")
+
+	if err := h.Database.DeleteCourse(r.Context(), courseId); err != nil {
+		h.ErrorLog.Printf("Failed to delete course (\"%s\"): %s\n", courseId, err)
+
+		resp := fmt.Sprintf(`<button class="btn btn-red shadow-sm" hx-delete="/admin/courses/%s">Delete Course</button>`, courseId)
+		resp += `
+		<script>
+		notyf.open({
+			type: 'flash-error',
+			message: 'Failed to delete course. Please try again.'
+		});
+		</script>
+		`
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditDeleteCourse, courseId, "Active", "Deleted"); err != nil {
+		h.ErrorLog.Printf("Failed to record course (\"%s\") deletion in the audit log: %s\n", courseId, err)
+	}
+
+	w.Header().Set("HX-Redirect", "/admin/courses")
+}
+
+func (h *Handlers) CourseRestore(w http.ResponseWriter, r *http.Request) {
+	courseId := chi.URLParam(r, "course-id")
+
+	if err := h.Database.RestoreCourse(r.Context(), courseId); err != nil {
+		h.ErrorLog.Printf("Failed to restore course (\"%s\"): %s\n", courseId, err)
+
+		resp := fmt.Sprintf(`<button class="btn btn-blue shadow-sm" hx-post="/admin/courses/%s/restore">Restore Course</button>`, courseId)
+		resp += `
+		<script>
+		notyf.open({
+			type: 'flash-error',
+			message: 'Failed to restore course. Please try again.'
+		});
+		</script>
+		`
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditRestoreCourse, courseId, "Deleted", "Active"); err != nil {
+		h.ErrorLog.Printf("Failed to record course (\"%s\") restoration in the audit log: %s\n", courseId, err)
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/admin/courses?status=%s", DeletedStatus))
+}
+
 // Possible URL queries:
-// -page
+// -cursor
 // -query
-// -status
+// -status (Published, Unpublished or Deleted)
 // -author
 // -keyword
 // -bought_by
 func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListComponent, url.Values, error) {
 	var published *bool
-	var page int
+	var deleted bool
 	var query string
 	var author *string
 	var boughtBy string
@@ -475,7 +565,11 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 
 	urlQuery := make(url.Values)
 
-	if !slices.Contains(PublishStatuses, r.URL.Query().Get("status")) {
+	if r.URL.Query().Get("status") == DeletedStatus {
+		deleted = true
+
+		urlQuery.Add("status", DeletedStatus)
+	} else if !slices.Contains(PublishStatuses, r.URL.Query().Get("status")) {
 		published = nil
 	} else {
 		if r.URL.Query().Get("status") == "Published" {
@@ -489,14 +583,6 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 		urlQuery.Add("status", r.URL.Query().Get("status"))
 	}
 
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && pageNum > 0 {
-		page = pageNum
-	} else {
-		page = 1
-	}
-
-	urlQuery.Add("page", strconv.Itoa(page+1))
-
 	if r.URL.Query().Get("query") != "" {
 		query = r.URL.Query().Get("query")
 		urlQuery.Add("query", query)
@@ -527,20 +613,24 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 		urlQuery.Add("keyword", key)
 	}
 
-	courses, err := h.Database.AdminGetCourses(query, published, author, boughtBy, keyword, uint(page), CoursesPerPagination)
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
+
+	courses, next, err := h.Database.AdminGetCourses(r.Context(), query, published, author, boughtBy, keyword, deleted, cursor, CoursesPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get courses (page %d) from the database: %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get courses from the database: %s\n", err)
 		return nil, urlQuery, err
 	}
 
 	var coursesSlice []*models.CourseModel
 	var lastCourse *models.CourseModel
 
-	if len(courses) < CoursesPerPagination {
+	if next == nil {
 		coursesSlice = courses
 	} else {
 		coursesSlice = courses[:len(courses)-1]
 		lastCourse = courses[len(courses)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	authors := make(map[string]*models.UserModel, len(courses))
@@ -549,7 +639,7 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 
 	for _, course := range courses {
 		if course.AuthorID.Valid {
-			author, err := h.Database.GetUserByID(course.AuthorID.String, database.Author)
+			author, err := h.Database.GetUserByID(r.Context(), course.AuthorID.String, database.Author)
 			if err != nil {
 				h.ErrorLog.Printf("Failed to get author \"%s\" from the database: %s\n", course.AuthorID.String, err)
 				return nil, urlQuery, err
@@ -558,7 +648,7 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 			authors[course.ID] = author
 		}
 
-		keys, err := h.Database.GetAllKeywordsForCourse(course.ID)
+		keys, err := h.Database.GetAllKeywordsForCourse(r.Context(), course.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get all keywords for course \"%s\": %s\n", course.Title, err)
 			return nil, urlQuery, err
@@ -566,7 +656,7 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 
 		keywords[course.ID] = keys
 
-		purchase, err := h.Database.CountUsersWhoBoughtCourse(course.ID)
+		purchase, err := h.Database.CountUsersWhoBoughtCourse(r.Context(), course.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to count the number of times the course \"%s\" was bought: %s\n", course.Title, err)
 			return nil, urlQuery, err
@@ -583,7 +673,16 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.AdminCoursesListCom
 		Purchases:  purchases,
 		BaseURL:    "/admin/courses/htmx",
 		URLQuery:   urlQuery.Encode(),
+		Deleted:    deleted,
 	}
 
 	return coursesList, urlQuery, nil
 }
+
+func publishStatusName(course *models.CourseModel) string {
+	if course.Published {
+		return "Published"
+	}
+
+	return "Unpublished"
+}
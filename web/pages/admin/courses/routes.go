@@ -21,5 +21,8 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 	router.Get("/htmx/change-author/{course-id}", handlers.AuthorEditGet)
 	router.Post("/htmx/change-author/{course-id}", handlers.AuthorEditPost)
 
+	router.Delete("/{course-id}", handlers.CourseDelete)
+	router.Post("/{course-id}/restore", handlers.CourseRestore)
+
 	return router
 }
@@ -5,14 +5,15 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/forms"
 	"github.com/PsionicAlch/course-platform/web/html"
 	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/audit"
 	"github.com/go-chi/chi/v5"
 	"github.com/justinas/nosurf"
 )
@@ -41,7 +42,7 @@ func (h *Handlers) DiscountsGet(w http.ResponseWriter, r *http.Request) {
 		BasePage: html.NewBasePage(user, nosurf.Token(r)),
 	}
 
-	numDiscounts, err := h.Database.CountDiscounts()
+	numDiscounts, err := h.Database.CountDiscounts(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count the number of discounts in the database: %s\n", err)
 
@@ -72,7 +73,7 @@ func (h *Handlers) DiscountsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Discounts = discounts
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
 	pageData.NewDiscountForm = forms.EmptyNewDiscountFormComponent()
@@ -113,7 +114,8 @@ func (h *Handlers) NewDiscountPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	title, description, uses, amount := forms.GetNewDiscountFormValues(form)
-	if _, err := h.Database.AddDiscount(title, description, amount, uses); err != nil {
+	discountId, err := h.Database.AddDiscount(r.Context(), title, description, amount, uses)
+	if err != nil {
 		formComponent := forms.NewDiscountFormComponent(form)
 		formComponent.ErrorMessage = "Unexpected server error. Failed to create new discount."
 
@@ -124,6 +126,10 @@ func (h *Handlers) NewDiscountPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := audit.Record(r, h.Database, database.AuditAddDiscount, discountId, "", fmt.Sprintf("%s (%d%% off, %d uses)", title, amount, uses)); err != nil {
+		h.ErrorLog.Printf("Failed to record new discount (\"%s\") in the audit log: %s\n", discountId, err)
+	}
+
 	w.Header().Set("HX-Redirect", "/admin/discounts")
 
 	if err := h.Renderers.Htmx.RenderHTML(w, nil, "new-discount-form", forms.EmptyNewDiscountFormComponent()); err != nil {
@@ -151,7 +157,7 @@ func (h *Handlers) EmptyNewDiscountGet(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) StatusEditGet(w http.ResponseWriter, r *http.Request) {
 	discountId := chi.URLParam(r, "discount-id")
 
-	discount, err := h.Database.GetDiscountByID(discountId)
+	discount, err := h.Database.GetDiscountByID(r.Context(), discountId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get discount \"%s\" from the database: %s\n", discountId, err)
 
@@ -222,7 +228,7 @@ func (h *Handlers) StatusEditPost(w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	discountStatus := r.Form.Get("discount-status")
 
-	discount, err := h.Database.GetDiscountByID(discountId)
+	discount, err := h.Database.GetDiscountByID(r.Context(), discountId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get discount \"%s\" from the database: %s\n", discountId, err)
 
@@ -283,7 +289,7 @@ func (h *Handlers) StatusEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if discountStatus == "Active" {
-		if err := h.Database.ActivateDiscount(discountId); err != nil {
+		if err := h.Database.ActivateDiscount(r.Context(), discountId); err != nil {
 			h.ErrorLog.Printf("Failed to update discount active status: %s\n", err)
 
 			discountStatuses := make(map[string]string, len(DiscountStatuses))
@@ -305,11 +311,15 @@ func (h *Handlers) StatusEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditActivateDiscount, discount.ID, discountStatusName(discount), "Active"); err != nil {
+			h.ErrorLog.Printf("Failed to record discount (\"%s\") status change in the audit log: %s\n", discount.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "Active"); err != nil {
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.DeactivateDiscount(discountId); err != nil {
+		if err := h.Database.DeactivateDiscount(r.Context(), discountId); err != nil {
 			h.ErrorLog.Printf("Failed to update discount active status: %s\n", err)
 
 			discountStatuses := make(map[string]string, len(DiscountStatuses))
@@ -331,6 +341,10 @@ func (h *Handlers) StatusEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditDeactivateDiscount, discount.ID, discountStatusName(discount), "Inactive"); err != nil {
+			h.ErrorLog.Printf("Failed to record discount (\"%s\") status change in the audit log: %s\n", discount.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "Inactive"); err != nil {
 			h.ErrorLog.Println(err)
 		}
@@ -338,13 +352,12 @@ func (h *Handlers) StatusEditPost(w http.ResponseWriter, r *http.Request) {
 }
 
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 // -status
 func (h *Handlers) CreateDiscountsList(r *http.Request) (*html.AdminDiscountsListComponent, url.Values, error) {
 	var query string
 	var status *bool
-	var page int
 
 	urlQuery := make(url.Values)
 
@@ -368,15 +381,9 @@ func (h *Handlers) CreateDiscountsList(r *http.Request) (*html.AdminDiscountsLis
 		status = nil
 	}
 
-	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = p
-	} else {
-		page = 1
-	}
-
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
-	discounts, err := h.Database.GetDiscountsPaginated(query, status, uint(page), DiscountPerPagination)
+	discounts, next, err := h.Database.GetDiscountsPaginated(r.Context(), query, status, cursor, DiscountPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get discounts from the database: %s\n", err)
 		return nil, urlQuery, err
@@ -385,16 +392,18 @@ func (h *Handlers) CreateDiscountsList(r *http.Request) (*html.AdminDiscountsLis
 	var discountsSlice []*models.DiscountModel
 	var lastDiscount *models.DiscountModel
 
-	if len(discounts) < DiscountPerPagination {
+	if next == nil {
 		discountsSlice = discounts
 	} else {
 		discountsSlice = discounts[:len(discounts)-1]
 		lastDiscount = discounts[len(discounts)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	discountUsed := make(map[string]uint, len(discounts))
 	for _, discount := range discounts {
-		uses, err := h.Database.CountCoursesWhereDiscountWasUsed(discount.Code)
+		uses, err := h.Database.CountCoursesWhereDiscountWasUsed(r.Context(), discount.Code)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to count the amount of times discount code (\"%s\") was used: %s\n", discount.Code, err)
 			return nil, urlQuery, err
@@ -413,3 +422,11 @@ func (h *Handlers) CreateDiscountsList(r *http.Request) (*html.AdminDiscountsLis
 
 	return discountsList, urlQuery, nil
 }
+
+func discountStatusName(discount *models.DiscountModel) string {
+	if discount.Active {
+		return "Active"
+	}
+
+	return "Inactive"
+}
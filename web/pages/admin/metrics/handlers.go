@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database/instrumented_database"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/html"
+	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/justinas/nosurf"
+)
+
+type Handlers struct {
+	utils.Loggers
+	*pages.HandlerContext
+}
+
+func SetupHandlers(handlerContext *pages.HandlerContext) *Handlers {
+	loggers := utils.CreateLoggers("ADMIN METRICS HANDLERS")
+
+	return &Handlers{
+		Loggers:        loggers,
+		HandlerContext: handlerContext,
+	}
+}
+
+// MetricsGet shows how often each database method was called since the server started, how many of those calls
+// failed and how long they took. The numbers are only recorded when DATABASE_INSTRUMENTATION is turned on.
+func (h *Handlers) MetricsGet(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	pageData := html.AdminMetricsPage{
+		BasePage: html.NewBasePage(user, nosurf.Token(r)),
+	}
+
+	if metrics := instrumented_database.GetMetrics(h.Database); metrics != nil {
+		pageData.Enabled = true
+		pageData.Methods = metrics.Snapshot()
+	}
+
+	buckets := make([]string, 0, len(instrumented_database.LatencyBuckets)+1)
+	for _, bound := range instrumented_database.LatencyBuckets {
+		buckets = append(buckets, "≤ "+bound.String())
+	}
+
+	buckets = append(buckets, "> "+instrumented_database.LatencyBuckets[len(instrumented_database.LatencyBuckets)-1].String())
+	pageData.Buckets = buckets
+
+	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-metrics", pageData); err != nil {
+		h.ErrorLog.Println(err)
+	}
+}
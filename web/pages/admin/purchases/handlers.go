@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -49,7 +48,7 @@ func (h *Handlers) PurchasesGet(w http.ResponseWriter, r *http.Request) {
 		PaymentStatuses: PurchaseStatuses,
 	}
 
-	numPurchases, err := h.Database.CountAllPurchases()
+	numPurchases, err := h.Database.CountAllPurchases(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count all course purchases: %s\n", err)
 
@@ -62,7 +61,7 @@ func (h *Handlers) PurchasesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.NumPurchases = numPurchases
 
-	courses, err := h.Database.GetAllCourses("", nil)
+	courses, err := h.Database.GetAllCourses(r.Context(), "", nil)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all courses: %s\n", err)
 
@@ -75,7 +74,7 @@ func (h *Handlers) PurchasesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Courses = courses
 
-	authors, err := h.Database.GetUsers("", database.Author, "", "")
+	authors, err := h.Database.GetUsers(r.Context(), "", database.Author, "", "")
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all authors: %s\n", err)
 
@@ -88,7 +87,7 @@ func (h *Handlers) PurchasesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Authors = authors
 
-	users, err := h.Database.GetAllUsers()
+	users, err := h.Database.GetAllUsers(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all users: %s\n", err)
 
@@ -101,7 +100,7 @@ func (h *Handlers) PurchasesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Users = users
 
-	discounts, err := h.Database.GetAllDiscounts()
+	discounts, err := h.Database.GetAllDiscounts(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all discounts: %s\n", err)
 
@@ -127,7 +126,7 @@ func (h *Handlers) PurchasesGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Purchases = coursePurchaseList
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-purchases", pageData); err != nil {
@@ -153,13 +152,12 @@ func (h *Handlers) PurchasesPaginationGet(w http.ResponseWriter, r *http.Request
 }
 
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 // -course
 // -author
 // -status
 func (h *Handlers) CreateCoursePurchasesList(r *http.Request) (*html.AdminCoursePurchaseListComponent, url.Values, error) {
-	var page int
 	var query string
 	var course string
 	var author string
@@ -167,14 +165,6 @@ func (h *Handlers) CreateCoursePurchasesList(r *http.Request) (*html.AdminCourse
 
 	urlQuery := make(url.Values)
 
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && page > 0 {
-		page = pageNum
-	} else {
-		page = 1
-	}
-
-	urlQuery.Add("page", strconv.Itoa(page+1))
-
 	if r.URL.Query().Get("query") != "" {
 		query = r.URL.Query().Get("query")
 		urlQuery.Add("query", query)
@@ -195,20 +185,24 @@ func (h *Handlers) CreateCoursePurchasesList(r *http.Request) (*html.AdminCourse
 		urlQuery.Add("status", s)
 	}
 
-	coursePurchases, err := h.Database.AdminGetCoursePurchases(query, course, author, status, uint(page), PurchasesPerPagination)
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
+
+	coursePurchases, next, err := h.Database.AdminGetCoursePurchases(r.Context(), query, course, author, status, cursor, PurchasesPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get course purchases (page %d): %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get course purchases: %s\n", err)
 		return nil, urlQuery, err
 	}
 
 	var coursePurchasesSlice []*models.CoursePurchaseModel
 	var lastCoursePurchase *models.CoursePurchaseModel
 
-	if len(coursePurchases) < PurchasesPerPagination {
+	if next == nil {
 		coursePurchasesSlice = coursePurchases
 	} else {
 		coursePurchasesSlice = coursePurchases[:len(coursePurchases)-1]
 		lastCoursePurchase = coursePurchases[len(coursePurchases)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	users := make(map[string]*models.UserModel)
@@ -216,7 +210,7 @@ func (h *Handlers) CreateCoursePurchasesList(r *http.Request) (*html.AdminCourse
 
 	for _, purchase := range coursePurchases {
 		if _, has := users[purchase.UserID]; !has {
-			user, err := h.Database.GetUserByID(purchase.UserID, database.All)
+			user, err := h.Database.GetUserByID(r.Context(), purchase.UserID, database.IncludeDeleted)
 			if err != nil {
 				h.ErrorLog.Printf("Failed to get user by ID (\"%s\"): %s\n", purchase.UserID, err)
 				return nil, urlQuery, err
@@ -226,7 +220,7 @@ func (h *Handlers) CreateCoursePurchasesList(r *http.Request) (*html.AdminCourse
 		}
 
 		if _, has := courses[purchase.CourseID]; !has {
-			course, err := h.Database.GetCourseByID(purchase.CourseID)
+			course, err := h.Database.GetCourseByID(r.Context(), purchase.CourseID)
 			if err != nil {
 				h.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", purchase.CourseID, err)
 				return nil, urlQuery, err
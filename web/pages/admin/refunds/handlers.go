@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -52,7 +51,7 @@ func (h *Handlers) RefundsGet(w http.ResponseWriter, r *http.Request) {
 		RefundStatuses: RefundStatuses,
 	}
 
-	numRefunds, err := h.Database.CountRefunds()
+	numRefunds, err := h.Database.CountRefunds(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count the number of refunds in the database: %s\n", err)
 
@@ -78,7 +77,7 @@ func (h *Handlers) RefundsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Refunds = refunds
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-refunds", pageData); err != nil {
@@ -104,24 +103,15 @@ func (h *Handlers) RefundsPaginationGet(w http.ResponseWriter, r *http.Request)
 }
 
 // Possible URL Queries:
-// - page
+// - cursor
 // - query
 // - status
 func (h *Handlers) CreateRefundsList(r *http.Request) (*html.AdminRefundsListComponent, url.Values, error) {
-	var page uint
 	var query string
 	var status string
 
 	urlQuery := make(url.Values)
 
-	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = uint(p)
-	} else {
-		page = 1
-	}
-
-	urlQuery.Add("page", strconv.Itoa(int(page+1)))
-
 	if q := r.URL.Query().Get("query"); q != "" {
 		query = q
 		urlQuery.Add("query", q)
@@ -132,7 +122,9 @@ func (h *Handlers) CreateRefundsList(r *http.Request) (*html.AdminRefundsListCom
 		urlQuery.Add("status", s)
 	}
 
-	refunds, err := h.Database.AdminGetRefunds(query, status, uint(page), RefundsPerPagination)
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
+
+	refunds, next, err := h.Database.AdminGetRefunds(r.Context(), query, status, cursor, RefundsPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all refunds from the database: %s\n", err)
 		return nil, urlQuery, err
@@ -141,11 +133,13 @@ func (h *Handlers) CreateRefundsList(r *http.Request) (*html.AdminRefundsListCom
 	var refundsSlice []*models.RefundModel
 	var lastRefund *models.RefundModel
 
-	if len(refunds) < RefundsPerPagination {
+	if next == nil {
 		refundsSlice = refunds
 	} else {
 		refundsSlice = refunds[:len(refunds)-1]
 		lastRefund = refunds[len(refunds)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	users := make(map[string]*models.UserModel)
@@ -153,7 +147,7 @@ func (h *Handlers) CreateRefundsList(r *http.Request) (*html.AdminRefundsListCom
 
 	for _, refund := range refunds {
 		if _, has := users[refund.UserID]; !has {
-			user, err := h.Database.GetUserByID(refund.UserID, database.All)
+			user, err := h.Database.GetUserByID(r.Context(), refund.UserID, database.IncludeDeleted)
 			if err != nil {
 				h.ErrorLog.Printf("Failed to get user by ID (\"%s\"): %s\n", refund.UserID, err)
 				return nil, urlQuery, err
@@ -163,7 +157,7 @@ func (h *Handlers) CreateRefundsList(r *http.Request) (*html.AdminRefundsListCom
 		}
 
 		if _, has := courses[refund.CoursePurchaseID]; !has {
-			course, err := h.Database.GetCourseByCoursePurchaseID(refund.CoursePurchaseID)
+			course, err := h.Database.GetCourseByCoursePurchaseID(r.Context(), refund.CoursePurchaseID)
 			if err != nil {
 				h.ErrorLog.Printf("Failed to get course by course purchase ID (\"%s\"): %s\n", refund.CoursePurchaseID, err)
 				return nil, urlQuery, err
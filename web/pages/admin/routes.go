@@ -4,9 +4,11 @@ import (
 	"net/http"
 
 	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/audit"
 	"github.com/PsionicAlch/course-platform/web/pages/admin/comments"
 	"github.com/PsionicAlch/course-platform/web/pages/admin/courses"
 	"github.com/PsionicAlch/course-platform/web/pages/admin/discounts"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/metrics"
 	"github.com/PsionicAlch/course-platform/web/pages/admin/purchases"
 	"github.com/PsionicAlch/course-platform/web/pages/admin/refunds"
 	"github.com/PsionicAlch/course-platform/web/pages/admin/tutorials"
@@ -25,9 +27,11 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 
 	router.Get("/", handlers.AdminGet)
 
+	router.Mount("/audit", audit.RegisterRoutes(handlerContext))
 	router.Mount("/comments", comments.RegisterRoutes(handlerContext))
 	router.Mount("/courses", courses.RegisterRoutes(handlerContext))
 	router.Mount("/discounts", discounts.RegisterRoutes(handlerContext))
+	router.Mount("/metrics", metrics.RegisterRoutes(handlerContext))
 	router.Mount("/purchases", purchases.RegisterRoutes(handlerContext))
 	router.Mount("/refunds", refunds.RegisterRoutes(handlerContext))
 	router.Mount("/tutorials", tutorials.RegisterRoutes(handlerContext))
@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -13,6 +12,7 @@ import (
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
 	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/audit"
 	"github.com/go-chi/chi/v5"
 	"github.com/justinas/nosurf"
 )
@@ -21,6 +21,10 @@ const TutorialsPerPagination = 25
 
 var PublishStatuses = []string{"Published", "Unpublished"}
 
+// DeletedStatus is the status filter value that lists the deleted tutorials instead of the published or unpublished
+// ones.
+const DeletedStatus = "Deleted"
+
 type Handlers struct {
 	utils.Loggers
 	*pages.HandlerContext
@@ -56,12 +60,12 @@ func (h *Handlers) TutorialsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Tutorials = tutorialList
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
 	pageData.PublishStatus = PublishStatuses
 
-	numTutorials, err := h.Database.CountTutorials()
+	numTutorials, err := h.Database.CountTutorials(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count the number of tutorials in the database: %s\n", err)
 
@@ -76,7 +80,7 @@ func (h *Handlers) TutorialsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.NumTutorials = numTutorials
 
-	authors, err := h.Database.GetUsers("", database.Author, "", "")
+	authors, err := h.Database.GetUsers(r.Context(), "", database.Author, "", "")
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all the authors from the database: %s\n", err)
 
@@ -91,7 +95,7 @@ func (h *Handlers) TutorialsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Authors = authors
 
-	keywords, err := h.Database.GetKeywords()
+	keywords, err := h.Database.GetKeywords(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all the keywords from the database: %s\n", err)
 
@@ -106,6 +110,21 @@ func (h *Handlers) TutorialsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Keywords = keywords
 
+	schedules, err := h.Database.GetTutorialPublishSchedules(r.Context())
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get the scheduled tutorials from the database: %s\n", err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{
+			BasePage: html.NewBasePage(user, nosurf.Token(r)),
+		}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	pageData.Schedules = schedules
+
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-tutorials", pageData); err != nil {
 		h.ErrorLog.Println(err)
 	}
@@ -131,7 +150,7 @@ func (h *Handlers) TutorialsPaginationGet(w http.ResponseWriter, r *http.Request
 func (h *Handlers) PublishedEditGet(w http.ResponseWriter, r *http.Request) {
 	tutorialId := chi.URLParam(r, "tutorial-id")
 
-	tutorial, err := h.Database.GetTutorialByID(tutorialId)
+	tutorial, err := h.Database.GetTutorialByID(r.Context(), tutorialId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorial by ID \"%s\": %s\n", tutorialId, err)
 
@@ -202,7 +221,7 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 	r.ParseForm()
 	publishStatus := r.Form.Get("publish-status")
 
-	tutorial, err := h.Database.GetTutorialByID(tutorialId)
+	tutorial, err := h.Database.GetTutorialByID(r.Context(), tutorialId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorial by ID \"%s\": %s\n", tutorialId, err)
 
@@ -264,7 +283,7 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if publishStatus == "Published" {
-		if err := h.Database.PublishTutorial(tutorial.ID); err != nil {
+		if err := h.Database.PublishTutorial(r.Context(), tutorial.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update tutorial's (\"%s\") publish status: %s\n", tutorial.Title, err)
 
 			resp := "Unpublished"
@@ -284,11 +303,15 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditPublishTutorial, tutorial.ID, publishStatusName(tutorial), "Published"); err != nil {
+			h.ErrorLog.Printf("Failed to record publish status change for tutorial (\"%s\") in the audit log: %s\n", tutorial.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "Published"); err != nil {
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.UnpublishTutorial(tutorial.ID); err != nil {
+		if err := h.Database.UnpublishTutorial(r.Context(), tutorial.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update tutorial's (\"%s\") publish status: %s\n", tutorial.Title, err)
 
 			resp := "Published"
@@ -308,6 +331,10 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditUnpublishTutorial, tutorial.ID, publishStatusName(tutorial), "Unpublished"); err != nil {
+			h.ErrorLog.Printf("Failed to record publish status change for tutorial (\"%s\") in the audit log: %s\n", tutorial.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "Unpublished"); err != nil {
 			h.ErrorLog.Println(err)
 		}
@@ -317,7 +344,7 @@ func (h *Handlers) PublishedEditPost(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) AuthorEditGet(w http.ResponseWriter, r *http.Request) {
 	tutorialId := chi.URLParam(r, "tutorial-id")
 
-	tutorial, err := h.Database.GetTutorialByID(tutorialId)
+	tutorial, err := h.Database.GetTutorialByID(r.Context(), tutorialId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorial by ID \"%s\": %s\n", tutorialId, err)
 
@@ -338,7 +365,7 @@ func (h *Handlers) AuthorEditGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authors, err := h.Database.GetUsers("", database.Author, "", "")
+	authors, err := h.Database.GetUsers(r.Context(), "", database.Author, "", "")
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get authors: %s\n", err)
 
@@ -392,7 +419,7 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 
 	authorId := r.Form.Get("author")
 
-	tutorial, err := h.Database.GetTutorialByID(tutorialId)
+	tutorial, err := h.Database.GetTutorialByID(r.Context(), tutorialId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorial by ID \"%s\": %s\n", tutorialId, err)
 
@@ -413,7 +440,7 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Database.UpdateTutorialAuthor(tutorial.ID, authorId); err != nil {
+	if err := h.Database.UpdateTutorialAuthor(r.Context(), tutorial.ID, authorId); err != nil {
 		h.ErrorLog.Printf("Failed to update tutorial \"%s\" author \"%s\": %s\n", tutorial.ID, authorId, err)
 
 		resp := "No Author"
@@ -433,12 +460,16 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := audit.Record(r, h.Database, database.AuditUpdateTutorialAuthor, tutorial.ID, tutorial.AuthorID.String, authorId); err != nil {
+		h.ErrorLog.Printf("Failed to record author change for tutorial (\"%s\") in the audit log: %s\n", tutorial.ID, err)
+	}
+
 	var resp string
 
 	if authorId == "" {
 		resp = "No Author"
 	} else {
-		author, err := h.Database.GetUserByID(authorId, database.Author)
+		author, err := h.Database.GetUserByID(r.Context(), authorId, database.Author)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to update tutorial \"%s\" author \"%s\": %s\n", tutorial.ID, authorId, err)
 
@@ -467,17 +498,77 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *Handlers) TutorialDelete(w http.ResponseWriter, r *http.Request) {
+	tutorialId := chi.URLParam(r, "tutorial-id")
+
+	if err := h.Database.DeleteTutorial(r.Context(), tutorialId); err != nil {
+		h.ErrorLog.Printf("Failed to delete tutorial (\"%s\"): %s\n", tutorialId, err)
+
+		resp := fmt.Sprintf(`<button class="btn btn-red shadow-sm" hx-delete="/admin/tutorials/%s">Delete Tutorial</button>`, tutorialId)
+		resp += `
+		<script>
+		notyf.open({
+			type: 'flash-error',
+			message: 'Failed to delete tutorial. Please try again.'
+		});
+		</script>
+		`
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditDeleteTutorial, tutorialId, "Active", "Deleted"); err != nil {
+		h.ErrorLog.Printf("Failed to record tutorial (\"%s\") deletion in the audit log: %s\n", tutorialId, err)
+	}
+
+	w.Header().Set("HX-Redirect", "/admin/tutorials")
+}
+
+func (h *Handlers) TutorialRestore(w http.ResponseWriter, r *http.Request) {
+	tutorialId := chi.URLParam(r, "tutorial-id")
+
+	if err := h.Database.RestoreTutorial(r.Context(), tutorialId); err != nil {
+		h.ErrorLog.Printf("Failed to restore tutorial (\"%s\"): %s\n", tutorialId, err)
+
+		resp := fmt.Sprintf(`<button class="btn btn-blue shadow-sm" hx-post="/admin/tutorials/%s/restore">Restore Tutorial</button>`, tutorialId)
+		resp += `
+		<script>
+		notyf.open({
+			type: 'flash-error',
+			message: 'Failed to restore tutorial. Please try again.'
+		});
+		</script>
+		`
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditRestoreTutorial, tutorialId, "Deleted", "Active"); err != nil {
+		h.ErrorLog.Printf("Failed to record tutorial (\"%s\") restoration in the audit log: %s\n", tutorialId, err)
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/admin/tutorials?status=%s", DeletedStatus))
+}
+
 // Possible URL queries:
-// -page
+// -cursor
 // -query
-// -status
+// -status (Published, Unpublished or Deleted)
 // -author
 // -liked_by
 // -bookmarked_by
 // -keyword
 func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsListComponent, url.Values, error) {
 	var published *bool
-	var page int
+	var deleted bool
 	var query string
 	var author *string
 	var likedBy string
@@ -486,7 +577,11 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 
 	urlQuery := make(url.Values)
 
-	if !slices.Contains(PublishStatuses, r.URL.Query().Get("status")) {
+	if r.URL.Query().Get("status") == DeletedStatus {
+		deleted = true
+
+		urlQuery.Add("status", DeletedStatus)
+	} else if !slices.Contains(PublishStatuses, r.URL.Query().Get("status")) {
 		published = nil
 	} else {
 		if r.URL.Query().Get("status") == "Published" {
@@ -500,14 +595,6 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 		urlQuery.Add("status", r.URL.Query().Get("status"))
 	}
 
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && pageNum > 0 {
-		page = pageNum
-	} else {
-		page = 1
-	}
-
-	urlQuery.Add("page", strconv.Itoa(page+1))
-
 	if r.URL.Query().Get("query") != "" {
 		query = r.URL.Query().Get("query")
 		urlQuery.Add("query", query)
@@ -544,20 +631,24 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 		urlQuery.Add("keyword", key)
 	}
 
-	tutorials, err := h.Database.AdminGetTutorials(query, published, author, likedBy, bookmarkedBy, keyword, uint(page), TutorialsPerPagination)
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
+
+	tutorials, next, err := h.Database.AdminGetTutorials(r.Context(), query, published, author, likedBy, bookmarkedBy, keyword, deleted, cursor, TutorialsPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get tutorials (page %d) from the database: %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get tutorials from the database: %s\n", err)
 		return nil, urlQuery, err
 	}
 
 	var tutorialsSlice []*models.TutorialModel
 	var lastTutorial *models.TutorialModel
 
-	if len(tutorials) < TutorialsPerPagination {
+	if next == nil {
 		tutorialsSlice = tutorials
 	} else {
 		tutorialsSlice = tutorials[:len(tutorials)-1]
 		lastTutorial = tutorials[len(tutorials)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	authors := make(map[string]*models.UserModel, len(tutorials))
@@ -568,7 +659,7 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 
 	for _, tutorial := range tutorials {
 		if tutorial.AuthorID.Valid {
-			author, err := h.Database.GetUserByID(tutorial.AuthorID.String, database.Author)
+			author, err := h.Database.GetUserByID(r.Context(), tutorial.AuthorID.String, database.Author)
 			if err != nil {
 				h.ErrorLog.Printf("Failed to get author \"%s\" from the database: %s\n", tutorial.AuthorID.String, err)
 				return nil, urlQuery, err
@@ -577,7 +668,7 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 			authors[tutorial.ID] = author
 		}
 
-		keys, err := h.Database.GetAllKeywordsForTutorial(tutorial.ID)
+		keys, err := h.Database.GetAllKeywordsForTutorial(r.Context(), tutorial.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get all keywords for tutorial \"%s\": %s\n", tutorial.Title, err)
 			return nil, urlQuery, err
@@ -585,7 +676,7 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 
 		keywords[tutorial.ID] = keys
 
-		commentCount, err := h.Database.CountCommentsForTutorial(tutorial.ID)
+		commentCount, err := h.Database.CountCommentsForTutorial(r.Context(), tutorial.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to count all comments related to tutorial \"%s\": %s\n", tutorial.Title, err)
 			return nil, urlQuery, err
@@ -593,7 +684,7 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 
 		comments[tutorial.ID] = commentCount
 
-		likesCount, err := h.Database.CountTutorialLikes(tutorial.ID)
+		likesCount, err := h.Database.CountTutorialLikes(r.Context(), tutorial.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to count the number of likes the tutorial \"%s\" has: %s\n", tutorial.Title, err)
 			return nil, urlQuery, err
@@ -601,7 +692,7 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 
 		likes[tutorial.ID] = likesCount
 
-		bookmarksCount, err := h.Database.CountTutorialBookmarks(tutorial.ID)
+		bookmarksCount, err := h.Database.CountTutorialBookmarks(r.Context(), tutorial.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to count the number of bookmarks the tutorial \"%s\" has: %s\n", tutorial.Title, err)
 			return nil, urlQuery, err
@@ -620,7 +711,16 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.AdminTutorialsLis
 		Bookmarks:    bookmarks,
 		BaseURL:      "/admin/tutorials/htmx",
 		URLQuery:     urlQuery.Encode(),
+		Deleted:      deleted,
 	}
 
 	return usersList, urlQuery, nil
 }
+
+func publishStatusName(tutorial *models.TutorialModel) string {
+	if tutorial.Published {
+		return "Published"
+	}
+
+	return "Unpublished"
+}
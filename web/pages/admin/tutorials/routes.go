@@ -21,5 +21,8 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 	router.Get("/htmx/change-author/{tutorial-id}", handlers.AuthorEditGet)
 	router.Post("/htmx/change-author/{tutorial-id}", handlers.AuthorEditPost)
 
+	router.Delete("/{tutorial-id}", handlers.TutorialDelete)
+	router.Post("/{tutorial-id}/restore", handlers.TutorialRestore)
+
 	return router
 }
@@ -1,11 +1,11 @@
 package users
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -13,6 +13,7 @@ import (
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
 	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/PsionicAlch/course-platform/web/pages/admin/audit"
 	"github.com/go-chi/chi/v5"
 	"github.com/justinas/nosurf"
 )
@@ -39,7 +40,7 @@ func (h *Handlers) UsersGet(w http.ResponseWriter, r *http.Request) {
 		BasePage: html.NewBasePage(user, nosurf.Token(r)),
 	}
 
-	usersCount, err := h.Database.CountUsers()
+	usersCount, err := h.Database.CountUsers(r.Context())
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get the number of users from the database: %s\n", err)
 
@@ -54,7 +55,10 @@ func (h *Handlers) UsersGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.NumUsers = usersCount
 
-	pageData.AuthorizationLevels = database.AuthorizationLevelStrings()
+	// IncludeDeleted would mix deleted accounts in with the live ones so it isn't offered as a filter.
+	pageData.AuthorizationLevels = slices.DeleteFunc(database.AuthorizationLevelStrings(), func(level string) bool {
+		return level == database.IncludeDeleted.String()
+	})
 
 	usersList, urlQuery, err := h.CreateUsersList(r)
 	if err != nil {
@@ -71,7 +75,7 @@ func (h *Handlers) UsersGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Users = usersList
 
-	urlQuery.Set("page", "1")
+	urlQuery.Del("cursor")
 	pageData.URLQuery = urlQuery.Encode()
 
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "admin-users", pageData); err != nil {
@@ -99,9 +103,9 @@ func (h *Handlers) UsersPaginationGet(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) AuthorEditGet(w http.ResponseWriter, r *http.Request) {
 	userId := chi.URLParam(r, "user-id")
 
-	user, err := h.Database.GetUserByID(userId, database.All)
-	if err != nil {
-		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %s\n", userId, err)
+	user, err := h.Database.GetUserByID(r.Context(), userId, database.All)
+	if err != nil || user == nil {
+		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %v\n", userId, err)
 
 		resp := "<p style=\"color: var(--primary-red-color);\">&#10008;</p>"
 		resp += `
@@ -150,9 +154,9 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 		"User":   "User",
 	}
 
-	user, err := h.Database.GetUserByID(userId, database.All)
-	if err != nil {
-		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %s\n", userId, err)
+	user, err := h.Database.GetUserByID(r.Context(), userId, database.All)
+	if err != nil || user == nil {
+		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %v\n", userId, err)
 
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "select", html.SelectComponent{
 			Name:         "author-status",
@@ -184,7 +188,7 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if authorStatus == "Author" {
-		if err := h.Database.AddAuthorStatus(user.ID); err != nil {
+		if err := h.Database.AddAuthorStatus(r.Context(), user.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update user's (\"%s\") author status: %s\n", user.ID, err)
 
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "select", html.SelectComponent{
@@ -198,11 +202,15 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditAddAuthorStatus, user.ID, authorStatusName(user), "Author"); err != nil {
+			h.ErrorLog.Printf("Failed to record author status change for user (\"%s\") in the audit log: %s\n", user.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "<p style=\"color: var(--primary-green-color);\">&#10004;</p>"); err != nil {
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.RemoveAuthorStatus(user.ID); err != nil {
+		if err := h.Database.RemoveAuthorStatus(r.Context(), user.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update user's (\"%s\") author status: %s\n", user.ID, err)
 
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "select", html.SelectComponent{
@@ -216,6 +224,10 @@ func (h *Handlers) AuthorEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditRemoveAuthorStatus, user.ID, authorStatusName(user), "User"); err != nil {
+			h.ErrorLog.Printf("Failed to record author status change for user (\"%s\") in the audit log: %s\n", user.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "<p style=\"color: var(--primary-red-color);\">&#10008;</p>"); err != nil {
 			h.ErrorLog.Println(err)
 		}
@@ -226,9 +238,9 @@ func (h *Handlers) AdminEditGet(w http.ResponseWriter, r *http.Request) {
 	user := authentication.GetUserFromRequest(r)
 
 	userId := chi.URLParam(r, "user-id")
-	targetUser, err := h.Database.GetUserByID(userId, database.All)
-	if err != nil {
-		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %s\n", userId, err)
+	targetUser, err := h.Database.GetUserByID(r.Context(), userId, database.All)
+	if err != nil || targetUser == nil {
+		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %v\n", userId, err)
 
 		resp := "<p style=\"color: var(--primary-red-color);\">&#10008;</p>"
 		resp += `
@@ -301,9 +313,9 @@ func (h *Handlers) AdminEditPost(w http.ResponseWriter, r *http.Request) {
 		"User":  "User",
 	}
 
-	user, err := h.Database.GetUserByID(userId, database.All)
-	if err != nil {
-		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %s\n", userId, err)
+	user, err := h.Database.GetUserByID(r.Context(), userId, database.All)
+	if err != nil || user == nil {
+		h.ErrorLog.Printf("Failed to get user by ID \"%s\": %v\n", userId, err)
 
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "select", html.SelectComponent{
 			Name:         "admin-status",
@@ -335,7 +347,7 @@ func (h *Handlers) AdminEditPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if adminStatus == "Admin" {
-		if err := h.Database.AddAdminStatus(user.ID); err != nil {
+		if err := h.Database.AddAdminStatus(r.Context(), user.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update user's (\"%s\") admin status: %s\n", user.ID, err)
 
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "select", html.SelectComponent{
@@ -349,11 +361,15 @@ func (h *Handlers) AdminEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditAddAdminStatus, user.ID, adminStatusName(user), "Admin"); err != nil {
+			h.ErrorLog.Printf("Failed to record admin status change for user (\"%s\") in the audit log: %s\n", user.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "<p style=\"color: var(--primary-green-color);\">&#10004;</p>"); err != nil {
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.RemoveAdminStatus(user.ID); err != nil {
+		if err := h.Database.RemoveAdminStatus(r.Context(), user.ID); err != nil {
 			h.ErrorLog.Printf("Failed to update user's (\"%s\") admin status: %s\n", user.ID, err)
 
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "select", html.SelectComponent{
@@ -367,14 +383,53 @@ func (h *Handlers) AdminEditPost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if err := audit.Record(r, h.Database, database.AuditRemoveAdminStatus, user.ID, adminStatusName(user), "User"); err != nil {
+			h.ErrorLog.Printf("Failed to record admin status change for user (\"%s\") in the audit log: %s\n", user.ID, err)
+		}
+
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", "<p style=\"color: var(--primary-red-color);\">&#10008;</p>"); err != nil {
 			h.ErrorLog.Println(err)
 		}
 	}
 }
 
+func (h *Handlers) UserRestore(w http.ResponseWriter, r *http.Request) {
+	userId := chi.URLParam(r, "user-id")
+
+	if err := h.Database.RestoreUser(r.Context(), userId); err != nil {
+		h.ErrorLog.Printf("Failed to restore user (\"%s\"): %s\n", userId, err)
+
+		message := "Failed to restore user. Please try again."
+		if errors.Is(err, database.ErrUserAlreadyExists) {
+			message = "Failed to restore user. Another account is using their email address."
+		}
+
+		resp := fmt.Sprintf(`<button class="btn btn-blue shadow-sm" hx-post="/admin/users/%s/restore">Restore User</button>`, userId)
+		resp += fmt.Sprintf(`
+		<script>
+		notyf.open({
+			type: 'flash-error',
+			message: '%s'
+		});
+		</script>
+		`, message)
+
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "empty", resp, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if err := audit.Record(r, h.Database, database.AuditRestoreUser, userId, "Deleted", "Active"); err != nil {
+		h.ErrorLog.Printf("Failed to record user (\"%s\") restoration in the audit log: %s\n", userId, err)
+	}
+
+	w.Header().Set("HX-Redirect", fmt.Sprintf("/admin/users?level=%s", database.Deleted))
+}
+
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 // -level
 // -liked
@@ -382,19 +437,12 @@ func (h *Handlers) AdminEditPost(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListComponent, url.Values, error) {
 	query := r.URL.Query().Get("query")
 	level := database.All
-	page := 1
 	liked := ""
 	bookmarked := ""
 
 	urlQuery := make(url.Values)
 
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = pageNum
-	}
-
-	urlQuery.Add("page", strconv.Itoa(page+1))
-
-	if authLevel, err := database.AuthorizationLevelString(r.URL.Query().Get("level")); err == nil {
+	if authLevel, err := database.AuthorizationLevelString(r.URL.Query().Get("level")); err == nil && authLevel != database.IncludeDeleted {
 		level = authLevel
 
 		urlQuery.Add("level", authLevel.String())
@@ -412,9 +460,11 @@ func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListCompone
 		urlQuery.Add("bookmarked", bookmarkedTutorial)
 	}
 
-	users, err := h.Database.GetUsersPaginated(query, level, liked, bookmarked, uint(page), UsersPerPagination)
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
+
+	users, next, err := h.Database.GetUsersPaginated(r.Context(), query, level, liked, bookmarked, cursor, UsersPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get users (page %d) from the database: %s\n", 1, err)
+		h.ErrorLog.Printf("Failed to get users from the database: %s\n", err)
 
 		return nil, urlQuery, err
 	}
@@ -426,7 +476,7 @@ func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListCompone
 	coursesWritten := make(map[string]uint, len(users))
 
 	for _, user := range users {
-		tutsLiked, err := h.Database.CountTutorialsLikedByUser(user.ID)
+		tutsLiked, err := h.Database.CountTutorialsLikedByUser(r.Context(), user.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get the number of tutorials liked by user \"%s\": %s\n", user.ID, err)
 
@@ -435,7 +485,7 @@ func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListCompone
 
 		tutorialsLiked[user.ID] = tutsLiked
 
-		tutsBookmarked, err := h.Database.CountTutorialsBookmarkedByUser(user.ID)
+		tutsBookmarked, err := h.Database.CountTutorialsBookmarkedByUser(r.Context(), user.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get the number of tutorials bookmarked by user \"%s\": %s\n", user.ID, err)
 
@@ -446,7 +496,7 @@ func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListCompone
 
 		coursesBought[user.ID] = 0
 
-		tutsWritten, err := h.Database.CountTutorialsWrittenBy(user.ID)
+		tutsWritten, err := h.Database.CountTutorialsWrittenBy(r.Context(), user.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get the number of tutorials written by user \"%s\": %s\n", user.ID, err)
 
@@ -461,11 +511,13 @@ func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListCompone
 	var usersSlice []*models.UserModel
 	var lastUser *models.UserModel
 
-	if len(users) < UsersPerPagination {
+	if next == nil {
 		usersSlice = users
 	} else {
 		usersSlice = users[:len(users)-1]
 		lastUser = users[len(users)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	usersList := &html.AdminUsersListComponent{
@@ -478,7 +530,24 @@ func (h *Handlers) CreateUsersList(r *http.Request) (*html.AdminUsersListCompone
 		CoursesWritten:      coursesWritten,
 		BaseURL:             "/admin/users/htmx",
 		URLQuery:            urlQuery.Encode(),
+		Deleted:             level == database.Deleted,
 	}
 
 	return usersList, urlQuery, nil
 }
+
+func authorStatusName(user *models.UserModel) string {
+	if user.IsAuthor {
+		return "Author"
+	}
+
+	return "User"
+}
+
+func adminStatusName(user *models.UserModel) string {
+	if user.IsAdmin {
+		return "Admin"
+	}
+
+	return "User"
+}
@@ -21,5 +21,7 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 	router.Get("/htmx/change-admin/{user-id}", handlers.AdminEditGet)
 	router.Post("/htmx/change-admin/{user-id}", handlers.AdminEditPost)
 
+	router.Post("/{user-id}/restore", handlers.UserRestore)
+
 	return router
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -58,7 +57,7 @@ func (h *Handlers) CoursesGet(w http.ResponseWriter, r *http.Request) {
 	pageData.Author = author
 	pageData.Courses = coursesList
 
-	lenCourses, err := h.Database.CountCoursesWrittenBy(author.ID)
+	lenCourses, err := h.Database.CountCoursesWrittenBy(r.Context(), author.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count all courses written by \"%s\": %s\n", author.ID, err)
 
@@ -94,24 +93,18 @@ func (h *Handlers) CoursesPaginationGet(w http.ResponseWriter, r *http.Request)
 }
 
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 func (h *Handlers) CreateCoursesList(r *http.Request) (*html.CoursesListComponent, *models.UserModel, error) {
 	authorSlug := chi.URLParam(r, "author-slug")
 
 	query := r.URL.Query().Get("query")
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
 	urlQuery := make(url.Values)
-
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = pageNum
-	}
-
 	urlQuery.Add("query", query)
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
 
-	author, err := h.Database.GetUserBySlug(authorSlug, database.Author)
+	author, err := h.Database.GetUserBySlug(r.Context(), authorSlug, database.Author)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to find author by slug (\"%s\"): %s\n", authorSlug, err)
 		return nil, nil, err
@@ -121,9 +114,9 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.CoursesListComponen
 		return nil, nil, ErrAuthorNotFound
 	}
 
-	courses, err := h.Database.GetCourses(query, author.ID, page, CoursesPerPagination)
+	courses, next, err := h.Database.GetCourses(r.Context(), query, author.ID, cursor, CoursesPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get all courses (page %d): %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get all courses: %s\n", err)
 		return nil, nil, err
 	}
 
@@ -134,11 +127,13 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.CoursesListComponen
 	var coursesSlice []*models.CourseModel
 	var lastCourse *models.CourseModel
 
-	if len(courses) < CoursesPerPagination {
+	if next == nil {
 		coursesSlice = courses
 	} else {
 		coursesSlice = courses[:len(courses)-1]
 		lastCourse = courses[len(courses)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	tutorialList := &html.CoursesListComponent{
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -58,7 +57,7 @@ func (h *Handlers) TutorialsGet(w http.ResponseWriter, r *http.Request) {
 	pageData.Author = author
 	pageData.Tutorials = tutorialsList
 
-	lenTutorials, err := h.Database.CountTutorialsWrittenBy(author.ID)
+	lenTutorials, err := h.Database.CountTutorialsWrittenBy(r.Context(), author.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count all tutorials written by \"%s\": %s\n", author.ID, err)
 
@@ -94,24 +93,18 @@ func (h *Handlers) TutorialsPaginationGet(w http.ResponseWriter, r *http.Request
 }
 
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.TutorialsListComponent, *models.UserModel, error) {
 	authorSlug := chi.URLParam(r, "author-slug")
 
 	query := r.URL.Query().Get("query")
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
 	urlQuery := make(url.Values)
-
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = pageNum
-	}
-
 	urlQuery.Add("query", query)
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
 
-	author, err := h.Database.GetUserBySlug(authorSlug, database.Author)
+	author, err := h.Database.GetUserBySlug(r.Context(), authorSlug, database.Author)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to find author by slug (\"%s\"): %s\n", authorSlug, err)
 		return nil, nil, err
@@ -121,9 +114,9 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.TutorialsListComp
 		return nil, nil, ErrAuthorNotFound
 	}
 
-	tutorials, err := h.Database.GetTutorials(query, author.ID, page, TutorialsPerPagination)
+	tutorials, next, err := h.Database.GetTutorials(r.Context(), query, author.ID, cursor, TutorialsPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get all tutorials (page %d): %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get all tutorials: %s\n", err)
 		return nil, nil, err
 	}
 
@@ -134,11 +127,13 @@ func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.TutorialsListComp
 	var tutorialsSlice []*models.TutorialModel
 	var lastTutorial *models.TutorialModel
 
-	if len(tutorials) < TutorialsPerPagination {
+	if next == nil {
 		tutorialsSlice = tutorials
 	} else {
 		tutorialsSlice = tutorials[:len(tutorials)-1]
 		lastTutorial = tutorials[len(tutorials)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	tutorialList := &html.TutorialsListComponent{
@@ -32,7 +32,7 @@ func (h *Handlers) CertificateGet(w http.ResponseWriter, r *http.Request) {
 
 	certificateId := chi.URLParam(r, "certificate-id")
 
-	certificate, err := h.Database.GetCertificateFromID(certificateId)
+	certificate, err := h.Database.GetCertificateFromID(r.Context(), certificateId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get certificate (\"%s\"): %s\n", certificateId, err)
 
@@ -53,7 +53,7 @@ func (h *Handlers) CertificateGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Certificate = certificate
 
-	certificateUser, err := h.Database.GetUserByID(certificate.UserID, database.All)
+	certificateUser, err := h.Database.GetUserByID(r.Context(), certificate.UserID, database.All)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user from certificate (\"%s\"): %s\n", certificate.UserID, err)
 
@@ -74,7 +74,7 @@ func (h *Handlers) CertificateGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.User = certificateUser
 
-	course, err := h.Database.GetCourseByID(certificate.CourseID)
+	course, err := h.Database.GetCourseByID(r.Context(), certificate.CourseID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course from certificate (\"%s\"): %s\n", certificate.CourseID, err)
 
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -85,7 +84,7 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 
 	courseSlug := chi.URLParam(r, "course-slug")
 
-	course, err := h.Database.GetCourseBySlug(courseSlug)
+	course, err := h.Database.GetCourseBySlug(r.Context(), courseSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course from the database with slug \"%s\": %s\n", courseSlug, err)
 
@@ -99,6 +98,25 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if course == nil {
+		// The course might have been renamed so links to its old slug get sent to the current one.
+		currentSlug, err := h.Database.GetCurrentSlug(r.Context(), database.CourseSlug, courseSlug)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to look up the current slug of course (\"%s\"): %s\n", courseSlug, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{
+				BasePage: html.NewBasePage(user, nosurf.Token(r)),
+			}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		if currentSlug != "" {
+			utils.Redirect(w, r, fmt.Sprintf("/courses/%s", currentSlug), http.StatusMovedPermanently)
+			return
+		}
+
 		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-404", html.Errors500Page{
 			BasePage: html.NewBasePage(user, nosurf.Token(r)),
 		}, http.StatusNotFound); err != nil {
@@ -110,7 +128,7 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Course = course
 
-	chapters, err := h.Database.CountChapters(course.ID)
+	chapters, err := h.Database.CountChapters(r.Context(), course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to count all the chapters, connected to course \"%s\", in the database: %s\n", course.Title, err)
 
@@ -125,7 +143,7 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Chapters = chapters
 
-	keywords, err := h.Database.GetAllKeywordsForCourse(course.ID)
+	keywords, err := h.Database.GetAllKeywordsForCourse(r.Context(), course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all keywords for course \"%s\": %s\n", course.Title, err)
 
@@ -147,7 +165,7 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 		authorID = ""
 	}
 
-	author, err := h.Database.GetUserByID(authorID, database.Author)
+	author, err := h.Database.GetUserByID(r.Context(), authorID, database.Author)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get author by ID \"%s\", in the database: %s\n", authorID, err)
 
@@ -175,7 +193,7 @@ func (h *Handlers) PurchaseCourseGet(w http.ResponseWriter, r *http.Request) {
 
 	courseSlug := chi.URLParam(r, "course-slug")
 
-	course, err := h.Database.GetCourseBySlug(courseSlug)
+	course, err := h.Database.GetCourseBySlug(r.Context(), courseSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by slug: %s\n", err)
 
@@ -208,7 +226,7 @@ func (h *Handlers) PurchaseCourseGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	author, err := h.Database.GetUserByID(course.AuthorID.String, database.Author)
+	author, err := h.Database.GetUserByID(r.Context(), course.AuthorID.String, database.Author)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by slug: %s\n", err)
 
@@ -231,7 +249,7 @@ func (h *Handlers) PurchaseCourseGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Author = author
 
-	hasPurchasedCourse, err := h.Database.HasUserPurchasedCourse(user.ID, course.ID)
+	hasPurchasedCourse, err := h.Database.HasUserPurchasedCourse(r.Context(), user.ID, course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to check if user (\"%s\") has purchased course (\"%s\"): %s\n", user.ID, course.ID, err)
 
@@ -261,7 +279,7 @@ func (h *Handlers) PurchaseCoursePost(w http.ResponseWriter, r *http.Request) {
 	coursePurchaseForm := forms.NewCoursePurchaseForm(r, user, h.Payment)
 
 	if !coursePurchaseForm.Validate() {
-		if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", forms.NewCoursePurchaseFormComponent(coursePurchaseForm, courseSlug, user, h.Payment)); err != nil {
+		if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", forms.NewCoursePurchaseFormComponent(r.Context(), coursePurchaseForm, courseSlug, user, h.Payment)); err != nil {
 			h.ErrorLog.Println(err)
 		}
 
@@ -270,11 +288,11 @@ func (h *Handlers) PurchaseCoursePost(w http.ResponseWriter, r *http.Request) {
 
 	affiliateCode, discountCode, affiliatePointsUsed := forms.GetCoursePurchaseFormValues(coursePurchaseForm)
 
-	totalPrice, err := h.Payment.CalculatePrice(user.ID, affiliateCode, discountCode, affiliatePointsUsed)
+	totalPrice, err := h.Payment.CalculatePrice(r.Context(), user.ID, affiliateCode, discountCode, affiliatePointsUsed)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to calculate course price: %s\n", err)
 
-		coursePurchaseFormComponent := forms.NewCoursePurchaseFormComponent(coursePurchaseForm, courseSlug, user, h.Payment)
+		coursePurchaseFormComponent := forms.NewCoursePurchaseFormComponent(r.Context(), coursePurchaseForm, courseSlug, user, h.Payment)
 		coursePurchaseFormComponent.ErrorMessage = "Failed to calculate price. Please try again."
 
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", coursePurchaseFormComponent, http.StatusInternalServerError); err != nil {
@@ -284,11 +302,11 @@ func (h *Handlers) PurchaseCoursePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	course, err := h.Database.GetCourseBySlug(courseSlug)
+	course, err := h.Database.GetCourseBySlug(r.Context(), courseSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course from slug: %s\n", err)
 
-		coursePurchaseFormComponent := forms.NewCoursePurchaseFormComponent(coursePurchaseForm, courseSlug, user, h.Payment)
+		coursePurchaseFormComponent := forms.NewCoursePurchaseFormComponent(r.Context(), coursePurchaseForm, courseSlug, user, h.Payment)
 		coursePurchaseFormComponent.ErrorMessage = "Unexpected server error. Please try again."
 
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", coursePurchaseFormComponent, http.StatusInternalServerError); err != nil {
@@ -308,11 +326,11 @@ func (h *Handlers) PurchaseCoursePost(w http.ResponseWriter, r *http.Request) {
 		domainName = fmt.Sprintf("https://%s", config.GetWithoutError[string]("DOMAIN_NAME"))
 	}
 
-	redirectURL, err := h.Payment.BuyCourse(user, course, fmt.Sprintf("%s/courses/%s/purchase/success", domainName, course.Slug), fmt.Sprintf("%s/courses/%s/purchase/cancel", domainName, course.Slug), affiliateCode, discountCode, affiliatePointsUsed, totalPrice)
+	redirectURL, err := h.Payment.BuyCourse(r.Context(), user, course, fmt.Sprintf("%s/courses/%s/purchase/success", domainName, course.Slug), fmt.Sprintf("%s/courses/%s/purchase/cancel", domainName, course.Slug), affiliateCode, discountCode, affiliatePointsUsed, totalPrice)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course from slug: %s\n", err)
 
-		coursePurchaseFormComponent := forms.NewCoursePurchaseFormComponent(coursePurchaseForm, courseSlug, user, h.Payment)
+		coursePurchaseFormComponent := forms.NewCoursePurchaseFormComponent(r.Context(), coursePurchaseForm, courseSlug, user, h.Payment)
 		coursePurchaseFormComponent.ErrorMessage = "Unexpected server error. Please try again."
 
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", coursePurchaseFormComponent, http.StatusInternalServerError); err != nil {
@@ -328,7 +346,7 @@ func (h *Handlers) PurchaseCoursePost(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) PurchaseCourseSuccessGet(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 
-	if !h.Payment.ValidatePaymentToken(token) {
+	if !h.Payment.ValidatePaymentToken(r.Context(), token) {
 		utils.Redirect(w, r, "/courses")
 		return
 	}
@@ -348,12 +366,12 @@ func (h *Handlers) PurchaseCourseSuccessGet(w http.ResponseWriter, r *http.Reque
 func (h *Handlers) PurchaseCourseCancelGet(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 
-	if !h.Payment.ValidatePaymentToken(token) {
+	if !h.Payment.ValidatePaymentToken(r.Context(), token) {
 		utils.Redirect(w, r, "/courses")
 		return
 	}
 
-	if err := h.Payment.DeletePaymentToken(token); err != nil {
+	if err := h.Payment.DeletePaymentToken(r.Context(), token); err != nil {
 		h.ErrorLog.Printf("Failed to delete payment token: %s\n", err)
 	}
 
@@ -373,12 +391,12 @@ func (h *Handlers) PurchaseCourseCancelGet(w http.ResponseWriter, r *http.Reques
 func (h *Handlers) PurchaseCourseCheckGet(w http.ResponseWriter, r *http.Request) {
 	token := r.URL.Query().Get("token")
 
-	if !h.Payment.ValidatePaymentToken(token) {
+	if !h.Payment.ValidatePaymentToken(r.Context(), token) {
 		utils.Redirect(w, r, "/courses")
 		return
 	}
 
-	user, err := h.Payment.GetUserFromPaymentToken(token)
+	user, err := h.Payment.GetUserFromPaymentToken(r.Context(), token)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user from payment token: %s\n", err)
 		return
@@ -386,7 +404,7 @@ func (h *Handlers) PurchaseCourseCheckGet(w http.ResponseWriter, r *http.Request
 
 	courseSlug := chi.URLParam(r, "course-slug")
 
-	course, err := h.Database.GetCourseBySlug(courseSlug)
+	course, err := h.Database.GetCourseBySlug(r.Context(), courseSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by slug (\"%s\"): %s\n", courseSlug, err)
 		return
@@ -397,14 +415,14 @@ func (h *Handlers) PurchaseCourseCheckGet(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	bought, err := h.Database.HasUserPurchasedCourse(user.ID, course.ID)
+	bought, err := h.Database.HasUserPurchasedCourse(r.Context(), user.ID, course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to check if the user (\"%s\") has bought this course (\"%s\"): %s\n", user.ID, course.ID, err)
 		return
 	}
 
 	if bought {
-		h.Payment.DeletePaymentToken(token)
+		h.Payment.DeletePaymentToken(r.Context(), token)
 		h.Session.SetInfoMessage(r.Context(), "Thank you for your purchase! We hope you enjoy the course.")
 		utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s", course.Slug))
 	}
@@ -416,46 +434,58 @@ func (h *Handlers) ValidatePurchasePost(w http.ResponseWriter, r *http.Request)
 	coursePurchaseForm := forms.NewCoursePurchaseForm(r, user, h.Payment)
 	coursePurchaseForm.Validate()
 
-	if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", forms.NewCoursePurchaseFormComponent(coursePurchaseForm, courseSlug, user, h.Payment)); err != nil {
+	if err := h.Renderers.Htmx.RenderHTML(w, nil, "course-purchase-form", forms.NewCoursePurchaseFormComponent(r.Context(), coursePurchaseForm, courseSlug, user, h.Payment)); err != nil {
 		h.ErrorLog.Println(err)
 	}
 }
 
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 func (h *Handlers) CreateCoursesList(r *http.Request) (*html.CoursesListComponent, error) {
 	query := r.URL.Query().Get("query")
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
 	urlQuery := make(url.Values)
-
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = pageNum
-	}
-
 	urlQuery.Add("query", query)
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
 
-	courses, err := h.Database.GetCourses(query, "", page, CoursesPerPagination)
+	courses, next, err := h.Database.GetCourses(r.Context(), query, "", cursor, CoursesPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get all courses (page %d) from the database: %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get all courses from the database: %s\n", err)
 		return nil, err
 	}
 
 	var coursesSlice []*models.CourseModel
 	var lastCourse *models.CourseModel
 
-	if len(courses) < CoursesPerPagination {
+	if next == nil {
 		coursesSlice = courses
 	} else {
 		coursesSlice = courses[:len(courses)-1]
 		lastCourse = courses[len(courses)-1]
+
+		urlQuery.Add("cursor", next.String())
+	}
+
+	var snippets map[string]string
+
+	if query != "" {
+		ids := make([]string, 0, len(courses))
+		for _, course := range courses {
+			ids = append(ids, course.ID)
+		}
+
+		snippets, err = h.Database.GetCourseSearchSnippets(r.Context(), query, ids)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get search snippets for courses that match \"%s\": %s\n", query, err)
+			return nil, err
+		}
 	}
 
 	coursesList := &html.CoursesListComponent{
 		Courses:    coursesSlice,
 		LastCourse: lastCourse,
+		Snippets:   snippets,
 		QueryURL:   fmt.Sprintf("/courses/htmx?%s", urlQuery.Encode()),
 	}
 
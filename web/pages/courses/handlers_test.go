@@ -0,0 +1,91 @@
+package courses
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/web/pages/pagestest"
+)
+
+func TestCourseGet(t *testing.T) {
+	author := databasetest.NewUserBuilder().WithName("Ada", "Lovelace").AsAuthor().Build()
+	course := databasetest.NewCourseBuilder().WithTitle("Go Concurrency").WithSlug("go-concurrency").WithAuthor(author.ID).Published().Build()
+	renamed := databasetest.NewCourseBuilder().WithTitle("Testing in Go").WithSlug("go-testing").WithAuthor(author.ID).Published().Build()
+
+	tests := []struct {
+		name     string
+		path     string
+		failOn   string
+		status   int
+		location string
+		contains []string
+	}{
+		{
+			name:     "Existing course",
+			path:     "/go-concurrency",
+			status:   http.StatusOK,
+			contains: []string{"Go Concurrency", "Ada"},
+		},
+		{
+			name:     "Old slug",
+			path:     "/go-testing",
+			status:   http.StatusMovedPermanently,
+			location: "/courses/testing-in-go",
+		},
+		{
+			name:   "Missing course",
+			path:   "/missing",
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "Database error",
+			path:   "/go-concurrency",
+			failOn: "GetCourseBySlug",
+			status: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := databasetest.NewDatabase()
+			db.SeedUsers(author)
+			db.SeedCourses(course, renamed)
+
+			// Renaming the course through the content loader records its old slug.
+			db.PrepareBulkCourses()
+			db.UpdateCourse(renamed.ID, renamed.Title, "testing-in-go", renamed.Description, renamed.ThumbnailURL, renamed.BannerURL, renamed.Content, renamed.FileChecksum, renamed.FileKey, nil, renamed.AuthorID, sql.NullTime{}, sql.NullTime{})
+			if err := db.RunBulkCourses(context.Background()); err != nil {
+				t.Fatalf("Failed to rename course: %s", err)
+			}
+
+			if tt.failOn != "" {
+				db.FailOn(tt.failOn, errors.New("database is down"))
+			}
+
+			handlerContext, _ := pagestest.NewHandlerContext(t, db)
+
+			w := httptest.NewRecorder()
+			RegisterRoutes(handlerContext).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d. Got %d", tt.status, w.Code)
+			}
+
+			if location := w.Header().Get("Location"); location != tt.location {
+				t.Errorf("Expected to be redirected to \"%s\". Got \"%s\"", tt.location, location)
+			}
+
+			for _, text := range tt.contains {
+				if !strings.Contains(w.Body.String(), text) {
+					t.Errorf("Expected the page to contain \"%s\"", text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package pagestest assembles a pages.HandlerContext that can be used to test handlers with net/http/httptest without
+// a real database, email provider or environment variables.
+package pagestest
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/payments"
+	"github.com/PsionicAlch/course-platform/internal/session"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/emails"
+	"github.com/PsionicAlch/course-platform/web/pages"
+)
+
+const (
+	// AuthCookieName is the name of the cookie used to authenticate users in tests.
+	AuthCookieName = "auth"
+
+	// NotificationCookieName is the name of the cookie used for session notifications in tests.
+	NotificationCookieName = "notification"
+
+	domainName = "localhost"
+)
+
+// Email is an email that was sent through a Mailbox.
+type Email struct {
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// Mailbox is an email.EmailClient that keeps every email it is asked to send instead of sending it.
+type Mailbox struct {
+	mu     sync.Mutex
+	emails []Email
+}
+
+func (mailbox *Mailbox) SendEmail(recipient, subject, body string) {
+	mailbox.mu.Lock()
+	defer mailbox.mu.Unlock()
+
+	mailbox.emails = append(mailbox.emails, Email{
+		Recipient: recipient,
+		Subject:   subject,
+		Body:      body,
+	})
+}
+
+// Emails returns a copy of every email that was sent so far.
+func (mailbox *Mailbox) Emails() []Email {
+	mailbox.mu.Lock()
+	defer mailbox.mu.Unlock()
+
+	return append([]Email(nil), mailbox.emails...)
+}
+
+// EmailsTo returns every email that was sent to the given recipient.
+func (mailbox *Mailbox) EmailsTo(recipient string) []Email {
+	mailbox.mu.Lock()
+	defer mailbox.mu.Unlock()
+
+	var emails []Email
+
+	for _, email := range mailbox.emails {
+		if email.Recipient == recipient {
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+// NewHandlerContext creates a pages.HandlerContext that uses the given database along with the real renderers,
+// authentication, session, cache and sitemapper. Emails get delivered to the returned Mailbox and payments use
+// placeholder Stripe keys so only the parts that don't talk to Stripe can be tested. It fails the test if any of the
+// pieces can't be set up.
+func NewHandlerContext(t testing.TB, db database.Database) (*pages.HandlerContext, *Mailbox) {
+	t.Helper()
+
+	sessions := session.SetupSession(NotificationCookieName, domainName)
+
+	renderers, err := pages.SetupRenderers(sessions)
+	if err != nil {
+		t.Fatalf("Failed to set up renderers: %s", err)
+	}
+
+	key, err := authentication.GenerateKeyString()
+	if err != nil {
+		t.Fatalf("Failed to generate secure cookie key: %s", err)
+	}
+
+	auth, err := authentication.SetupAuthentication(db, sessions, time.Hour, 30*time.Minute, AuthCookieName, domainName, key, "")
+	if err != nil {
+		t.Fatalf("Failed to set up authentication: %s", err)
+	}
+
+	emailRenderer, err := pages.SetupEmailRenderer()
+	if err != nil {
+		t.Fatalf("Failed to set up email renderer: %s", err)
+	}
+
+	mailbox := &Mailbox{}
+
+	emailer := &emails.Emails{
+		Loggers: utils.CreateLoggers("TEST EMAIL"),
+		Client:  mailbox,
+		Render:  emailRenderer,
+	}
+
+	handlerContext := &pages.HandlerContext{
+		Renderers:      renderers,
+		Database:       db,
+		Authentication: auth,
+		Session:        sessions,
+		Payment:        payments.SetupPayments("sk_test_placeholder", "whsec_placeholder", db, emailer),
+		Emailer:        emailer,
+		Cache:          pages.SetupCache(db, renderers.RSS),
+		Mapper:         pages.SetupSiteMapper(),
+	}
+
+	return handlerContext, mailbox
+}
+
+// AuthCookie creates an authentication token for the user and returns the cookie that a logged in browser would send
+// along with its requests. The user needs to exist in the handler context's database.
+func AuthCookie(t testing.TB, handlerContext *pages.HandlerContext, userId string) *http.Cookie {
+	t.Helper()
+
+	token, err := database.GenerateToken()
+	if err != nil {
+		t.Fatalf("Failed to generate authentication token: %s", err)
+	}
+
+	validUntil := time.Now().Add(handlerContext.Authentication.AuthenticationLifetime)
+
+	if err := handlerContext.Database.AddToken(context.Background(), token, authentication.AuthenticationToken, userId, validUntil); err != nil {
+		t.Fatalf("Failed to add authentication token: %s", err)
+	}
+
+	cookie, err := handlerContext.Authentication.CookiesManager.Encode(token)
+	if err != nil {
+		t.Fatalf("Failed to encode authentication cookie: %s", err)
+	}
+
+	return cookie
+}
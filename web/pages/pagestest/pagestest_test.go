@@ -0,0 +1,52 @@
+package pagestest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+)
+
+func TestMailbox(t *testing.T) {
+	mailbox := &Mailbox{}
+
+	mailbox.SendEmail("jane@example.com", "Hello", "<p>Hello Jane</p>")
+	mailbox.SendEmail("john@example.com", "Hello", "<p>Hello John</p>")
+	mailbox.SendEmail("jane@example.com", "Goodbye", "<p>Goodbye Jane</p>")
+
+	if emails := mailbox.Emails(); len(emails) != 3 {
+		t.Errorf("Expected 3 emails. Got %d", len(emails))
+	}
+
+	emails := mailbox.EmailsTo("jane@example.com")
+	if len(emails) != 2 || emails[0].Subject != "Hello" || emails[1].Subject != "Goodbye" {
+		t.Errorf("Expected both of Jane's emails in the order they were sent. Got %+v", emails)
+	}
+
+	if emails := mailbox.EmailsTo("nobody@example.com"); len(emails) != 0 {
+		t.Errorf("Expected no emails for an unknown recipient. Got %d", len(emails))
+	}
+}
+
+func TestAuthCookie(t *testing.T) {
+	db := databasetest.NewDatabase()
+	user := databasetest.NewUserBuilder().Build()
+	db.SeedUsers(user)
+
+	handlerContext, _ := NewHandlerContext(t, db)
+
+	cookie := AuthCookie(t, handlerContext, user.ID)
+	if cookie.Name != AuthCookieName {
+		t.Errorf("Expected the cookie to be called \"%s\". Got \"%s\"", AuthCookieName, cookie.Name)
+	}
+
+	loggedIn, err := handlerContext.Authentication.GetUserFromAuthCookie(context.Background(), []*http.Cookie{cookie})
+	if err != nil {
+		t.Fatalf("Failed to get user from the cookie: %s", err)
+	}
+
+	if loggedIn == nil || loggedIn.ID != user.ID {
+		t.Errorf("Expected the cookie to log in the user")
+	}
+}
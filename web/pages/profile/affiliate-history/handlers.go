@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
@@ -73,19 +73,14 @@ func (h *Handlers) AffiliateHistoryPaginationGet(w http.ResponseWriter, r *http.
 }
 
 // Possible URL Query:
-// - page
+// - cursor
 func (h *Handlers) CreateAffiliateHistoryList(r *http.Request) (*html.AffiliateHistoryListComponent, error) {
 	user := authentication.GetUserFromRequest(r)
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
 	urlQuery := make(url.Values)
 
-	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = p
-		urlQuery.Add("page", fmt.Sprintf("%d", page+1))
-	}
-
-	affiliateHistory, err := h.Database.GetUserAffiliatePointsHistory(user.ID, uint(page), AffiliateHistoryElementsPerPagination)
+	affiliateHistory, next, err := h.Database.GetUserAffiliatePointsHistory(r.Context(), user.ID, cursor, AffiliateHistoryElementsPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user's (\"%s\") affiliate points history: %s\n", user.ID, err)
 		return nil, err
@@ -94,11 +89,13 @@ func (h *Handlers) CreateAffiliateHistoryList(r *http.Request) (*html.AffiliateH
 	var affiliateHistorySlice []*models.AffiliatePointsHistoryModel
 	var lastAffiliateHistory *models.AffiliatePointsHistoryModel
 
-	if len(affiliateHistory) < AffiliateHistoryElementsPerPagination {
+	if next == nil {
 		affiliateHistorySlice = affiliateHistory
 	} else {
 		affiliateHistorySlice = affiliateHistory[:len(affiliateHistory)-1]
 		lastAffiliateHistory = affiliateHistory[len(affiliateHistory)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	affiliateHistoryList := &html.AffiliateHistoryListComponent{
@@ -3,6 +3,7 @@ package courses
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 )
@@ -11,6 +12,8 @@ type ContextKey string
 
 const CourseContextKey ContextKey = "course-context-key"
 
+const PurchaseDateContextKey ContextKey = "purchase-date-context-key"
+
 func NewContextWithCourseModel(course *models.CourseModel, ctx context.Context) context.Context {
 	return context.WithValue(ctx, CourseContextKey, course)
 }
@@ -23,3 +26,13 @@ func GetCourseFromRequest(r *http.Request) *models.CourseModel {
 
 	return course
 }
+
+func NewContextWithPurchaseDate(purchasedAt time.Time, ctx context.Context) context.Context {
+	return context.WithValue(ctx, PurchaseDateContextKey, purchasedAt)
+}
+
+func GetPurchaseDateFromRequest(r *http.Request) (time.Time, bool) {
+	purchasedAt, ok := r.Context().Value(PurchaseDateContextKey).(time.Time)
+
+	return purchasedAt, ok
+}
@@ -1,11 +1,13 @@
 package courses
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"net/http"
 	"net/url"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -75,29 +77,23 @@ func (h *Handlers) CoursesPaginationGet(w http.ResponseWriter, r *http.Request)
 }
 
 // Possible URL Queries:
-// - page
+// - cursor
 // - query
 func (h *Handlers) CreateCoursesList(r *http.Request) (*html.CoursesListComponent, error) {
 	user := authentication.GetUserFromRequest(r)
 
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 	query := ""
 
 	urlQuery := make(url.Values)
 
-	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = p
-	}
-
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
-
 	if q := r.URL.Query().Get("query"); q != "" {
 		query = q
 
 		urlQuery.Add("query", q)
 	}
 
-	courses, err := h.Database.GetCoursesBoughtByUser(query, user.ID, uint(page), CoursesPerPagination)
+	courses, next, err := h.Database.GetCoursesBoughtByUser(r.Context(), query, user.ID, cursor, CoursesPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get courses bought by user (\"%s\"): %s\n", user.ID, err)
 		return nil, err
@@ -106,11 +102,13 @@ func (h *Handlers) CreateCoursesList(r *http.Request) (*html.CoursesListComponen
 	var coursesSlice []*models.CourseModel
 	var lastCourse *models.CourseModel
 
-	if len(courses) < CoursesPerPagination {
+	if next == nil {
 		coursesSlice = courses
 	} else {
 		coursesSlice = courses[:len(courses)-1]
 		lastCourse = courses[len(courses)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	coursesList := &html.CoursesListComponent{
@@ -146,7 +144,7 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	chapters, err := h.Database.GetCourseChapters(course.ID)
+	chapters, err := h.Database.GetCourseChapters(r.Context(), course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all course (\"%s\") chapters: %s\n", course.Title, err)
 
@@ -158,7 +156,7 @@ func (h *Handlers) CourseGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, chapter := range chapters {
-		hasCompleted, err := h.Database.HasUserCompletedChapter(user.ID, course.ID, chapter.ID)
+		hasCompleted, err := h.Database.HasUserCompletedChapter(r.Context(), user.ID, course.ID, chapter.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Could not check if user has completed course chapter: %s\n", err)
 
@@ -209,7 +207,7 @@ func (h *Handlers) CourseCertificateGet(w http.ResponseWriter, r *http.Request)
 	pageData.Course = course
 	pageData.User = user
 
-	incompleteChapters, err := h.Database.GetAllChaptersNotCompleted(user.ID, course.ID)
+	incompleteChapters, err := h.Database.GetAllChaptersNotCompleted(r.Context(), user.ID, course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all chapters of course (\"%s\") that the user (\"%s\") hasn't completed yet: %s\n", course.ID, user.ID, err)
 
@@ -225,7 +223,7 @@ func (h *Handlers) CourseCertificateGet(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	certificate, err := h.Database.GetCertificateFromUserAndCourse(user.ID, course.ID)
+	certificate, err := h.Database.GetCertificateFromUserAndCourse(r.Context(), user.ID, course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get certificate from the database: %s\n", err)
 
@@ -256,7 +254,7 @@ func (h *Handlers) CourseCertificateGet(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	author, err := h.Database.GetUserByID(course.AuthorID.String, database.Author)
+	author, err := h.Database.GetUserByID(r.Context(), course.AuthorID.String, database.Author)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get author by ID (\"%s\"): %s\n", course.AuthorID.String, err)
 
@@ -279,7 +277,7 @@ func (h *Handlers) CourseCertificateGet(w http.ResponseWriter, r *http.Request)
 
 	pageData.Author = author
 
-	chapters, err := h.Database.GetCourseChapters(course.ID)
+	chapters, err := h.Database.GetCourseChapters(r.Context(), course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get chapters associates with course (\"%s\"): %s\n", course.ID, err)
 
@@ -329,6 +327,17 @@ func (h *Handlers) CourseChapterGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	purchasedAt, ok := GetPurchaseDateFromRequest(r)
+	if !ok {
+		h.ErrorLog.Println("Failed to get purchase date from context")
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
 	pageData := html.ProfileCourse{
 		BasePage: html.NewBasePage(user, nosurf.Token(r)),
 		Course:   course,
@@ -336,7 +345,7 @@ func (h *Handlers) CourseChapterGet(w http.ResponseWriter, r *http.Request) {
 
 	chapterSlug := chi.URLParam(r, "chapter-slug")
 
-	chapter, err := h.Database.GetChapterBySlug(chapterSlug)
+	chapter, err := h.Database.GetChapterBySlug(r.Context(), chapterSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get chapter by slug (\"%s\"): %s\n", chapterSlug, err)
 
@@ -348,6 +357,32 @@ func (h *Handlers) CourseChapterGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if chapter == nil {
+		// The chapter might have been renamed so links to its old slug get sent to the current one.
+		currentSlug, err := h.Database.GetCurrentSlug(r.Context(), database.ChapterSlug, chapterSlug)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to look up the current slug of chapter (\"%s\"): %s\n", chapterSlug, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		if currentSlug != "" {
+			utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s", course.Slug, currentSlug), http.StatusMovedPermanently)
+			return
+		}
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-404", html.Errors404Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusNotFound); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	// Chapters are looked up by slug alone so a chapter from a course the user didn't buy could end up here.
+	if chapter.CourseID != course.ID {
 		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-404", html.Errors404Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusNotFound); err != nil {
 			h.ErrorLog.Println(err)
 		}
@@ -357,7 +392,7 @@ func (h *Handlers) CourseChapterGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Chapter = chapter
 
-	chapters, err := h.Database.GetCourseChapters(course.ID)
+	chapters, err := h.Database.GetCourseChapters(r.Context(), course.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get chapters associates with course (\"%s\"): %s\n", course.ID, err)
 
@@ -387,7 +422,7 @@ func (h *Handlers) CourseChapterGet(w http.ResponseWriter, r *http.Request) {
 	completed := make(map[string]bool, len(chapters))
 
 	for _, c := range chapters {
-		hasCompleted, err := h.Database.HasUserCompletedChapter(user.ID, course.ID, c.ID)
+		hasCompleted, err := h.Database.HasUserCompletedChapter(r.Context(), user.ID, course.ID, c.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to check if user (\"%s\") has completed course (\"%s\") chapter (\"%s\"): %s\n", user.ID, course.ID, c.ID, err)
 
@@ -405,6 +440,63 @@ func (h *Handlers) CourseChapterGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.HasCompletedCourse = !utils.InSeq(false, maps.Values(completed))
 
+	now := time.Now()
+	locked := make(map[string]bool, len(chapters))
+
+	for _, c := range chapters {
+		locked[c.ID] = !c.IsReleased(purchasedAt, now)
+	}
+
+	pageData.Locked = locked
+
+	// Locked chapters only show when they will be released so their content and quiz aren't needed.
+	if !chapter.IsReleased(purchasedAt, now) {
+		pageData.ReleasesAt = chapter.ReleaseTime(purchasedAt)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "profile-course", pageData); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	quiz, err := h.Database.GetQuizByChapterID(r.Context(), chapter.ID)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get quiz of chapter (\"%s\"): %s\n", chapter.ID, err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if quiz != nil {
+		attempts, err := h.Database.GetQuizAttempts(r.Context(), user.ID, quiz.ID)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get user's (\"%s\") attempts at quiz (\"%s\"): %s\n", user.ID, quiz.ID, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		pageData.Quiz = quiz
+
+		if len(attempts) > 0 {
+			pageData.LastAttempt = attempts[0]
+		}
+
+		for _, attempt := range attempts {
+			if attempt.Passed {
+				pageData.QuizPassed = true
+				break
+			}
+		}
+	}
+
 	// Render the current chapter based off the course slug and chapter slug.
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "profile-course", pageData); err != nil {
 		h.ErrorLog.Println(err)
@@ -422,10 +514,25 @@ func (h *Handlers) CourseChapterFinishPost(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if course == nil {
+		h.ErrorLog.Println("Failed to get course from request context")
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	purchasedAt, ok := GetPurchaseDateFromRequest(r)
+	if !ok {
+		h.ErrorLog.Println("Failed to get purchase date from request context")
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
 	courseSlug := chi.URLParam(r, "course-slug")
 	chapterSlug := chi.URLParam(r, "chapter-slug")
 
-	chapter, err := h.Database.GetChapterBySlug(chapterSlug)
+	chapter, err := h.Database.GetChapterBySlug(r.Context(), chapterSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get chapter by slug (\"%s\"): %s\n", chapterSlug, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
@@ -440,7 +547,26 @@ func (h *Handlers) CourseChapterFinishPost(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if err := h.Database.FinishChapter(user.ID, chapter.ID, chapter.CourseID); err != nil {
+	if chapter.CourseID != course.ID {
+		h.ErrorLog.Printf("Chapter (\"%s\") is not part of course (\"%s\")\n", chapter.ID, course.ID)
+		h.Session.SetErrorMessage(r.Context(), "That chapter isn't part of this course.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	if !chapter.IsReleased(purchasedAt, time.Now()) {
+		h.Session.SetWarningMessage(r.Context(), "This chapter hasn't been released yet.")
+		utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s", courseSlug, chapterSlug))
+		return
+	}
+
+	if err := h.Database.FinishChapter(r.Context(), user.ID, chapter.ID, chapter.CourseID); err != nil {
+		if errors.Is(err, database.ErrQuizNotPassed) {
+			h.Session.SetWarningMessage(r.Context(), "Pass the quiz to finish this chapter.")
+			utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s#quiz", courseSlug, chapterSlug))
+			return
+		}
+
 		h.ErrorLog.Printf("Failed to mark the chapter (\"%s\") as completed for user (\"%s\"): %s\n", chapter.ID, user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Failed to mark chapter as completed. Please try again.")
 		w.Header().Set("HX-Refresh", "true")
@@ -448,7 +574,7 @@ func (h *Handlers) CourseChapterFinishPost(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	incompleteChapters, err := h.Database.GetAllChaptersNotCompleted(user.ID, chapter.CourseID)
+	incompleteChapters, err := h.Database.GetAllChaptersNotCompleted(r.Context(), user.ID, chapter.CourseID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all chapters of course (\"%s\") that the user (\"%s\") hasn't completed yet: %s\n", chapter.CourseID, user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
@@ -458,14 +584,7 @@ func (h *Handlers) CourseChapterFinishPost(w http.ResponseWriter, r *http.Reques
 	}
 
 	if len(incompleteChapters) == 0 {
-		if course == nil {
-			h.ErrorLog.Println("Failed to get course from context")
-			h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
-			utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s#next-chapter-btn", courseSlug, chapterSlug))
-			return
-		}
-
-		if err := h.Database.AddCertificate(user.ID, course.ID); err != nil {
+		if err := h.Database.AddCertificate(r.Context(), user.ID, course.ID); err != nil {
 			h.ErrorLog.Printf("Failed to create new certificate of completion: %s\n", err)
 			h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
 			utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s#next-chapter-btn", courseSlug, chapterSlug))
@@ -477,3 +596,110 @@ func (h *Handlers) CourseChapterFinishPost(w http.ResponseWriter, r *http.Reques
 		utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s", courseSlug, incompleteChapters[0].Slug))
 	}
 }
+
+func (h *Handlers) CourseChapterQuizPost(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	course := GetCourseFromRequest(r)
+
+	if user == nil {
+		h.ErrorLog.Println("Failed to get user from request context")
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	if course == nil {
+		h.ErrorLog.Println("Failed to get course from request context")
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	purchasedAt, ok := GetPurchaseDateFromRequest(r)
+	if !ok {
+		h.ErrorLog.Println("Failed to get purchase date from request context")
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	courseSlug := chi.URLParam(r, "course-slug")
+	chapterSlug := chi.URLParam(r, "chapter-slug")
+	chapterURL := fmt.Sprintf("/profile/courses/%s/%s#quiz", courseSlug, chapterSlug)
+
+	chapter, err := h.Database.GetChapterBySlug(r.Context(), chapterSlug)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get chapter by slug (\"%s\"): %s\n", chapterSlug, err)
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	if chapter == nil {
+		h.ErrorLog.Printf("Failed to get chapter from slug (\"%s\")\n", chapterSlug)
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	if chapter.CourseID != course.ID {
+		h.ErrorLog.Printf("Chapter (\"%s\") is not part of course (\"%s\")\n", chapter.ID, course.ID)
+		h.Session.SetErrorMessage(r.Context(), "That chapter isn't part of this course.")
+		utils.Redirect(w, r, "/profile")
+		return
+	}
+
+	if !chapter.IsReleased(purchasedAt, time.Now()) {
+		h.Session.SetWarningMessage(r.Context(), "This chapter hasn't been released yet.")
+		utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s", courseSlug, chapterSlug))
+		return
+	}
+
+	quiz, err := h.Database.GetQuizByChapterID(r.Context(), chapter.ID)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get quiz of chapter (\"%s\"): %s\n", chapter.ID, err)
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
+		utils.Redirect(w, r, chapterURL)
+		return
+	}
+
+	if quiz == nil {
+		h.Session.SetErrorMessage(r.Context(), "This chapter doesn't have a quiz.")
+		utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s/%s", courseSlug, chapterSlug))
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		h.ErrorLog.Printf("Failed to parse quiz form: %s\n", err)
+		h.Session.SetErrorMessage(r.Context(), "Failed to read your answers. Please try again.")
+		utils.Redirect(w, r, chapterURL)
+		return
+	}
+
+	answers := make(map[string][]string, len(quiz.Questions))
+
+	for _, question := range quiz.Questions {
+		for _, answer := range r.PostForm["question-"+question.ID] {
+			if strings.TrimSpace(answer) != "" {
+				answers[question.ID] = append(answers[question.ID], answer)
+			}
+		}
+	}
+
+	score, passed, graded := quiz.Grade(answers)
+
+	if err := h.Database.AddQuizAttempt(r.Context(), user.ID, quiz.ID, score, passed, graded); err != nil {
+		h.ErrorLog.Printf("Failed to save user's (\"%s\") attempt at quiz (\"%s\"): %s\n", user.ID, quiz.ID, err)
+		h.Session.SetErrorMessage(r.Context(), "Failed to save your answers. Please try again.")
+		utils.Redirect(w, r, chapterURL)
+		return
+	}
+
+	if passed {
+		h.Session.SetInfoMessage(r.Context(), fmt.Sprintf("You passed the quiz with %d%%.", score))
+	} else {
+		h.Session.SetWarningMessage(r.Context(), fmt.Sprintf("You scored %d%% but need %d%% to pass. Please try again.", score, quiz.PassMark))
+	}
+
+	utils.Redirect(w, r, chapterURL)
+}
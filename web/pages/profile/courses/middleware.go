@@ -3,8 +3,10 @@ package courses
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
 	"github.com/go-chi/chi/v5"
@@ -17,7 +19,7 @@ func (h *Handlers) UserBoughtCourse(next http.Handler) http.Handler {
 
 		courseSlug := chi.URLParam(r, "course-slug")
 
-		course, err := h.Database.GetCourseBySlug(courseSlug)
+		course, err := h.Database.GetCourseBySlug(r.Context(), courseSlug)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get course by slug (\"%s\"): %s\n", courseSlug, err)
 
@@ -29,6 +31,24 @@ func (h *Handlers) UserBoughtCourse(next http.Handler) http.Handler {
 		}
 
 		if course == nil {
+			// The course might have been renamed so links to its old slug get sent to the same page under the current one.
+			currentSlug, err := h.Database.GetCurrentSlug(r.Context(), database.CourseSlug, courseSlug)
+			if err != nil {
+				h.ErrorLog.Printf("Failed to look up the current slug of course (\"%s\"): %s\n", courseSlug, err)
+
+				if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+					h.ErrorLog.Println(err)
+				}
+
+				return
+			}
+
+			if currentSlug != "" {
+				rest := strings.TrimPrefix(r.URL.Path, fmt.Sprintf("/profile/courses/%s", courseSlug))
+				utils.Redirect(w, r, fmt.Sprintf("/profile/courses/%s%s", currentSlug, rest), http.StatusMovedPermanently)
+				return
+			}
+
 			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-404", html.Errors404Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusNotFound); err != nil {
 				h.ErrorLog.Println(err)
 			}
@@ -44,7 +64,7 @@ func (h *Handlers) UserBoughtCourse(next http.Handler) http.Handler {
 			return
 		}
 
-		hasPurchased, err := h.Database.HasUserPurchasedCourse(user.ID, course.ID)
+		purchasedAt, err := h.Database.GetCoursePurchaseDate(r.Context(), user.ID, course.ID)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to check if user (\"%s\") has purchased the course (\"%s\"): %s\n", user.ID, course.Title, err)
 
@@ -55,12 +75,13 @@ func (h *Handlers) UserBoughtCourse(next http.Handler) http.Handler {
 			return
 		}
 
-		if !hasPurchased {
+		if !purchasedAt.Valid {
 			utils.Redirect(w, r, fmt.Sprintf("/courses/%s/purchase", course.Slug))
 			return
 		}
 
 		ctx := NewContextWithCourseModel(course, r.Context())
+		ctx = NewContextWithPurchaseDate(purchasedAt.Time, ctx)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
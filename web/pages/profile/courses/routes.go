@@ -23,6 +23,7 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 		r.Get("/certificate", handlers.CourseCertificateGet)
 		r.Get("/{chapter-slug}", handlers.CourseChapterGet)
 		r.Post("/{chapter-slug}/finish", handlers.CourseChapterFinishPost)
+		r.Post("/{chapter-slug}/quiz", handlers.CourseChapterQuizPost)
 	})
 
 	return router
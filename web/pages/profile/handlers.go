@@ -36,7 +36,7 @@ func (h *Handlers) ProfileGet(w http.ResponseWriter, r *http.Request) {
 		User:     user,
 	}
 
-	affiliateHistory, err := h.Database.CountUserAffiliateHistory(user.ID)
+	affiliateHistory, err := h.Database.CountUserAffiliateHistory(r.Context(), user.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get affiliate points history associated with user (\"%s\"): %s\n", user.ID, err)
 
@@ -49,7 +49,7 @@ func (h *Handlers) ProfileGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.HasAffiliateHistory = affiliateHistory != 0
 
-	courses, err := h.Database.GetCoursesBoughtByUser("", user.ID, 1, Elements+2)
+	courses, _, err := h.Database.GetCoursesBoughtByUser(r.Context(), "", user.ID, nil, Elements+2)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get courses bought by user (\"%s\"): %s\n", user.ID, err)
 
@@ -74,7 +74,7 @@ func (h *Handlers) ProfileGet(w http.ResponseWriter, r *http.Request) {
 	pageData.Courses = coursesSlice
 	pageData.HasMoreCourses = hasMoreCourses
 
-	tutorialsBookmarked, err := h.Database.GetTutorialsBookmarkedByUser("", user.ID, 1, Elements+2)
+	tutorialsBookmarked, _, err := h.Database.GetTutorialsBookmarkedByUser(r.Context(), "", user.ID, nil, Elements+2)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorials bookmarked by user (\"%s\"): %s\n", user.ID, err)
 
@@ -100,7 +100,7 @@ func (h *Handlers) ProfileGet(w http.ResponseWriter, r *http.Request) {
 	pageData.TutorialsBookmarked = tutorialsBookmarkedSlice
 	pageData.HasMoreTutorialsBookmarked = hasMoreTutorialsBookmarked
 
-	tutorialsLiked, err := h.Database.GetTutorialsLikedByUser("", user.ID, 1, Elements+2)
+	tutorialsLiked, _, err := h.Database.GetTutorialsLikedByUser(r.Context(), "", user.ID, nil, Elements+2)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorials liked by user (\"%s\"): %s\n", user.ID, err)
 
@@ -0,0 +1,100 @@
+package quizzes
+
+import (
+	"net/http"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/html"
+	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/justinas/nosurf"
+)
+
+type Handlers struct {
+	utils.Loggers
+	*pages.HandlerContext
+}
+
+func SetupHandlers(handlerContext *pages.HandlerContext) *Handlers {
+	loggers := utils.CreateLoggers("PROFILE QUIZZES HANDLERS")
+
+	return &Handlers{
+		Loggers:        loggers,
+		HandlerContext: handlerContext,
+	}
+}
+
+func (h *Handlers) QuizzesGet(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	pageData := html.ProfileQuizzesPage{
+		BasePage: html.NewBasePage(user, nosurf.Token(r)),
+	}
+
+	authorId := user.ID
+	if user.IsAdmin {
+		authorId = ""
+	}
+
+	courses, err := h.Database.GetAllCourses(r.Context(), authorId, nil)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get courses written by author (\"%s\"): %s\n", user.ID, err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	for _, course := range courses {
+		chapters, err := h.Database.GetCourseChapters(r.Context(), course.ID)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get chapters associated with course (\"%s\"): %s\n", course.ID, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		for _, chapter := range chapters {
+			quiz, err := h.Database.GetQuizByChapterID(r.Context(), chapter.ID)
+			if err != nil {
+				h.ErrorLog.Printf("Failed to get quiz of chapter (\"%s\"): %s\n", chapter.ID, err)
+
+				if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+					h.ErrorLog.Println(err)
+				}
+
+				return
+			}
+
+			if quiz == nil {
+				continue
+			}
+
+			stats, err := h.Database.GetQuizQuestionStats(r.Context(), quiz.ID)
+			if err != nil {
+				h.ErrorLog.Printf("Failed to get question statistics of quiz (\"%s\"): %s\n", quiz.ID, err)
+
+				if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+					h.ErrorLog.Println(err)
+				}
+
+				return
+			}
+
+			pageData.Quizzes = append(pageData.Quizzes, &html.ChapterQuizStats{
+				Course:    course,
+				Chapter:   chapter,
+				Quiz:      quiz,
+				Questions: stats,
+			})
+		}
+	}
+
+	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "profile-quizzes", pageData); err != nil {
+		h.ErrorLog.Println(err)
+	}
+}
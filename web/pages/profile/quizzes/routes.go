@@ -0,0 +1,21 @@
+package quizzes
+
+import (
+	"net/http"
+
+	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/go-chi/chi/v5"
+)
+
+// Base URL: /profile/quizzes
+func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
+	handlers := SetupHandlers(handlerContext)
+
+	router := chi.NewRouter()
+
+	router.Use(handlerContext.Authentication.AllowAuthor("/profile"))
+
+	router.Get("/", handlers.QuizzesGet)
+
+	return router
+}
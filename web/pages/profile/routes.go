@@ -6,6 +6,7 @@ import (
 	"github.com/PsionicAlch/course-platform/web/pages"
 	affiliatehistory "github.com/PsionicAlch/course-platform/web/pages/profile/affiliate-history"
 	"github.com/PsionicAlch/course-platform/web/pages/profile/courses"
+	"github.com/PsionicAlch/course-platform/web/pages/profile/quizzes"
 	"github.com/PsionicAlch/course-platform/web/pages/profile/tutorials"
 	"github.com/go-chi/chi/v5"
 )
@@ -23,6 +24,7 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 
 	router.Mount("/affiliate-history", affiliatehistory.RegisterRoutes(handlerContext))
 	router.Mount("/courses", courses.RegisterRoutes(handlerContext))
+	router.Mount("/quizzes", quizzes.RegisterRoutes(handlerContext))
 	router.Mount("/tutorials", tutorials.RegisterRoutes(handlerContext))
 
 	return router
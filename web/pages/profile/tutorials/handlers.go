@@ -1,12 +1,13 @@
 package tutorials
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/html"
@@ -112,27 +113,21 @@ func (h *Handlers) TutorialsLikesPaginationGet(w http.ResponseWriter, r *http.Re
 	}
 }
 
-func (h *Handlers) CreateTutorialsList(r *http.Request, baseURL string, dbFunc func(term string, userId string, page uint, elements uint) ([]*models.TutorialModel, error)) (*html.TutorialsListComponent, error) {
+func (h *Handlers) CreateTutorialsList(r *http.Request, baseURL string, dbFunc func(ctx context.Context, term string, userId string, cursor *database.Cursor, elements uint) ([]*models.TutorialModel, *database.Cursor, error)) (*html.TutorialsListComponent, error) {
 	user := authentication.GetUserFromRequest(r)
 
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 	query := ""
 
 	urlQuery := make(url.Values)
 
-	if p, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = p
-	}
-
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
-
 	if q := r.URL.Query().Get("query"); q != "" {
 		query = q
 
 		urlQuery.Add("query", q)
 	}
 
-	tutorials, err := dbFunc(query, user.ID, uint(page), TutorialsPerPagination)
+	tutorials, next, err := dbFunc(r.Context(), query, user.ID, cursor, TutorialsPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all tutorials liked/bookmarked by user (\"%s\"): %s\n", user.ID, err)
 		return nil, err
@@ -141,11 +136,13 @@ func (h *Handlers) CreateTutorialsList(r *http.Request, baseURL string, dbFunc f
 	var tutorialsSlice []*models.TutorialModel
 	var lastTutorial *models.TutorialModel
 
-	if len(tutorials) < TutorialsPerPagination {
+	if next == nil {
 		tutorialsSlice = tutorials
 	} else {
 		tutorialsSlice = tutorials[:len(tutorials)-1]
 		lastTutorial = tutorials[len(tutorials)-1]
+
+		urlQuery.Add("cursor", next.String())
 	}
 
 	tutorialsList := &html.TutorialsListComponent{
@@ -61,3 +61,11 @@ func (h *Handlers) RSSCourseAuthorGet(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
 	w.Write([]byte(feed))
 }
+
+func (h *Handlers) RSSSeriesGet(w http.ResponseWriter, r *http.Request) {
+	seriesSlug := chi.URLParam(r, "series-slug")
+	feed := h.Cache.GetSeriesRSSFeed(seriesSlug)
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write([]byte(feed))
+}
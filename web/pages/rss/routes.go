@@ -22,5 +22,7 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 	router.Get("/author/{author-slug}/tutorials", handlers.RSSTutorialAuthorGet)
 	router.Get("/author/{author-slug}/courses", handlers.RSSCourseAuthorGet)
 
+	router.Get("/series/{series-slug}", handlers.RSSSeriesGet)
+
 	return router
 }
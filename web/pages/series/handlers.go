@@ -0,0 +1,120 @@
+package series
+
+import (
+	"net/http"
+
+	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/utils"
+	"github.com/PsionicAlch/course-platform/web/html"
+	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/go-chi/chi/v5"
+	"github.com/justinas/nosurf"
+)
+
+type Handlers struct {
+	utils.Loggers
+	*pages.HandlerContext
+}
+
+func SetupHandlers(handlerContext *pages.HandlerContext) *Handlers {
+	loggers := utils.CreateLoggers("SERIES HANDLERS")
+
+	return &Handlers{
+		Loggers:        loggers,
+		HandlerContext: handlerContext,
+	}
+}
+
+func (h *Handlers) SeriesGet(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	pageData := html.SeriesPage{
+		BasePage:  html.NewBasePage(user, nosurf.Token(r)),
+		Tutorials: make(map[string][]*models.TutorialModel),
+	}
+
+	series, err := h.Database.GetAllSeries(r.Context())
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get all series from the database: %s\n", err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	for _, s := range series {
+		tutorials, err := h.Database.GetSeriesTutorials(r.Context(), s.ID)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get tutorials for series (\"%s\") from the database: %s\n", s.Title, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		pageData.Tutorials[s.ID] = tutorials
+	}
+
+	pageData.Series = series
+
+	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "series", pageData); err != nil {
+		h.ErrorLog.Println(err)
+	}
+}
+
+func (h *Handlers) SeriesTutorialsGet(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	pageData := html.SeriesTutorialsPage{
+		BasePage: html.NewBasePage(user, nosurf.Token(r)),
+	}
+
+	seriesSlug := chi.URLParam(r, "series-slug")
+
+	series, err := h.Database.GetSeriesBySlug(r.Context(), seriesSlug)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get series by slug (\"%s\") from the database: %s\n", seriesSlug, err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	var tutorials []*models.TutorialModel
+
+	if series != nil {
+		tutorials, err = h.Database.GetSeriesTutorials(r.Context(), series.ID)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get tutorials for series (\"%s\") from the database: %s\n", series.Title, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+	}
+
+	// A series without any published tutorials is treated as if it doesn't exist.
+	if len(tutorials) == 0 {
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-404", html.Errors404Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusNotFound); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	pageData.Series = series
+	pageData.Tutorials = &html.TutorialsListComponent{
+		Tutorials: tutorials,
+	}
+
+	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "series-tutorials", pageData); err != nil {
+		h.ErrorLog.Println(err)
+	}
+}
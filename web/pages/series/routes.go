@@ -0,0 +1,22 @@
+package series
+
+import (
+	"net/http"
+
+	"github.com/PsionicAlch/course-platform/web/pages"
+	"github.com/go-chi/chi/v5"
+)
+
+func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
+	handlers := SetupHandlers(handlerContext)
+
+	router := chi.NewRouter()
+
+	router.Use(handlerContext.Authentication.SetUser)
+	router.Use(handlerContext.Session.SessionMiddleware)
+
+	router.Get("/", handlers.SeriesGet)
+	router.Get("/{series-slug}", handlers.SeriesTutorialsGet)
+
+	return router
+}
@@ -1,13 +1,17 @@
 package settings
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"strconv"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database/models"
+	"github.com/PsionicAlch/course-platform/internal/dataexport"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/forms"
 	"github.com/PsionicAlch/course-platform/web/html"
@@ -19,6 +23,7 @@ import (
 type Handlers struct {
 	utils.Loggers
 	*pages.HandlerContext
+	DataExport *dataexport.DataExport
 }
 
 func SetupHandlers(handlerContext *pages.HandlerContext) *Handlers {
@@ -27,6 +32,7 @@ func SetupHandlers(handlerContext *pages.HandlerContext) *Handlers {
 	return &Handlers{
 		Loggers:        loggers,
 		HandlerContext: handlerContext,
+		DataExport:     dataexport.SetupDataExport(handlerContext.Database),
 	}
 }
 
@@ -40,7 +46,7 @@ func (h *Handlers) SettingsGet(w http.ResponseWriter, r *http.Request) {
 		ChangePasswordForm:  forms.EmptyChangePasswordFormComponent(),
 	}
 
-	whitelistedIPAddresses, err := h.Database.GetUserIpAddresses(user.ID)
+	whitelistedIPAddresses, err := h.Database.GetUserIpAddresses(r.Context(), user.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get user's (\"%s\") whitelisted IP addresses: %s\n", user.ID, err)
 
@@ -53,7 +59,7 @@ func (h *Handlers) SettingsGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.IPAddresses = whitelistedIPAddresses
 
-	courses, err := h.Database.GetAllCoursesBoughtByUser(user.ID)
+	courses, err := h.Database.GetAllCoursesBoughtByUser(r.Context(), user.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get all courses bought by user (\"%s\"): %s\n", user.ID, err)
 
@@ -90,7 +96,7 @@ func (h *Handlers) WhitelistIPAddressPost(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if err := h.Database.AddIPAddress(user.ID, ipAddr); err != nil {
+	if err := h.Database.AddIPAddress(r.Context(), user.ID, ipAddr); err != nil {
 		h.ErrorLog.Printf("Failed to add IP address to user's (\"%s\") whitelist: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Failed to add IP address to whitelist.")
 	}
@@ -111,7 +117,7 @@ func (h *Handlers) ChangeFirstNamePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Database.UpdateUserName(user.ID, forms.GetChangeFirstNameFormValues(form), user.Surname); err != nil {
+	if err := h.Database.UpdateUserName(r.Context(), user.ID, forms.GetChangeFirstNameFormValues(form), user.Surname); err != nil {
 		h.ErrorLog.Printf("Failed to update user's (\"%s\") first name: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
 
@@ -139,7 +145,7 @@ func (h *Handlers) ChangeLastNamePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Database.UpdateUserName(user.ID, user.Name, forms.GetChangeLastNameFormValues(form)); err != nil {
+	if err := h.Database.UpdateUserName(r.Context(), user.ID, user.Name, forms.GetChangeLastNameFormValues(form)); err != nil {
 		h.ErrorLog.Printf("Failed to update user's (\"%s\") last name: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
 
@@ -167,7 +173,7 @@ func (h *Handlers) ChangeEmailPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Database.UpdateUserEmail(user.ID, forms.GetChangeEmailFormValues(form)); err != nil {
+	if err := h.Database.UpdateUserEmail(r.Context(), user.ID, forms.GetChangeEmailFormValues(form)); err != nil {
 		h.ErrorLog.Printf("Failed to update user's (\"%s\") email: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
 
@@ -217,7 +223,7 @@ func (h *Handlers) ChangePasswordPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Authentication.ChangeUserPassword(user, newPassword); err != nil {
+	if err := h.Authentication.ChangeUserPassword(r.Context(), user, newPassword); err != nil {
 		h.ErrorLog.Printf("Failed to update user's (\"%s\") password: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
 
@@ -229,7 +235,7 @@ func (h *Handlers) ChangePasswordPost(w http.ResponseWriter, r *http.Request) {
 
 	go h.Emailer.SendPasswordResetConfirmationEmail(user.Email, user.Name)
 
-	_, authCookie, err := h.Authentication.LogUserIn(user.Email, newPassword)
+	_, authCookie, err := h.Authentication.LogUserIn(r.Context(), user.Email, newPassword)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to log user (\"%s\") in after updating password: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
@@ -251,7 +257,7 @@ func (h *Handlers) IPAddressDelete(w http.ResponseWriter, r *http.Request) {
 	user := authentication.GetUserFromRequest(r)
 	ipAddrID := chi.URLParam(r, "ip-address-id")
 
-	if err := h.Database.DeleteIPAddress(ipAddrID, user.ID); err != nil {
+	if err := h.Database.DeleteIPAddress(r.Context(), ipAddrID, user.ID); err != nil {
 		h.ErrorLog.Printf("Failed to delete IP address (\"%s\") from the database: %s\n", ipAddrID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Failed to delete IP address.")
 	} else {
@@ -266,7 +272,7 @@ func (h *Handlers) RequestRefundPost(w http.ResponseWriter, r *http.Request) {
 	user := authentication.GetUserFromRequest(r)
 
 	courseId := chi.URLParam(r, "course-id")
-	course, err := h.Database.GetCourseByID(courseId)
+	course, err := h.Database.GetCourseByID(r.Context(), courseId)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get course by ID (\"%s\"): %s\n", courseId, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
@@ -274,7 +280,7 @@ func (h *Handlers) RequestRefundPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.Payment.RequestRefund(user, course); err != nil {
+	if err := h.Payment.RequestRefund(r.Context(), user, course); err != nil {
 		h.ErrorLog.Printf("Failed to request course (\"%s\") refund for user (\"%s\"): %s\n", course.ID, user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -289,7 +295,7 @@ func (h *Handlers) RequestRefundPost(w http.ResponseWriter, r *http.Request) {
 func (h *Handlers) AccountDelete(w http.ResponseWriter, r *http.Request) {
 	user := authentication.GetUserFromRequest(r)
 
-	if err := h.Database.DeleteUser(user.ID); err != nil {
+	if err := h.Database.DeleteUser(r.Context(), user.ID); err != nil {
 		h.ErrorLog.Printf("Failed to delete user's (\"%s\") account: %s\n", user.ID, err)
 		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Failed to delete your account.")
 		w.Header().Set("HX-Refresh", "true")
@@ -302,6 +308,71 @@ func (h *Handlers) AccountDelete(w http.ResponseWriter, r *http.Request) {
 	utils.Redirect(w, r, "/")
 }
 
+func (h *Handlers) DownloadMyDataPost(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+
+	token, validUntil, err := h.Authentication.GenerateDataExportToken(r.Context(), user)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to generate data export token for user (\"%s\"): %s\n", user.ID, err)
+		h.Session.SetErrorMessage(r.Context(), "Unexpected server error. Please try again.")
+
+		w.Header().Set("HX-Refresh", "true")
+		utils.Redirect(w, r, "/settings#download-my-data")
+
+		return
+	}
+
+	go h.Emailer.SendDataExportEmail(user.Email, user.Name, token, validUntil)
+
+	h.Session.SetInfoMessage(r.Context(), "We've emailed you a link to download your data.")
+
+	w.Header().Set("HX-Refresh", "true")
+	utils.Redirect(w, r, "/settings#download-my-data")
+}
+
+func (h *Handlers) DownloadMyDataGet(w http.ResponseWriter, r *http.Request) {
+	user := authentication.GetUserFromRequest(r)
+	token := chi.URLParam(r, "token")
+
+	valid, err := h.Authentication.ValidateDataExportToken(r.Context(), token, user)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to validate user's (\"%s\") data export token: %s\n", user.ID, err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if !valid {
+		h.Session.SetErrorMessage(r.Context(), "This download link is invalid or has expired. Please request a new one.")
+		utils.Redirect(w, r, "/settings#download-my-data")
+		return
+	}
+
+	// Build the whole archive before writing anything so that a failure can still be reported as a 500 page.
+	archive := new(bytes.Buffer)
+
+	if err := h.DataExport.WriteArchive(r.Context(), archive, user); err != nil {
+		h.ErrorLog.Printf("Failed to export user's (\"%s\") data: %s\n", user.ID, err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", dataexport.FileName(time.Now())))
+	w.Header().Set("Content-Length", strconv.Itoa(archive.Len()))
+
+	if _, err := archive.WriteTo(w); err != nil {
+		h.ErrorLog.Printf("Failed to send user's (\"%s\") data export: %s\n", user.ID, err)
+	}
+}
+
 func (h *Handlers) ValidateChangePassword(w http.ResponseWriter, r *http.Request) {
 	form := forms.ChangePasswordFormPartialValidation(r)
 	form.Validate()
@@ -0,0 +1,134 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/databasetest"
+	"github.com/PsionicAlch/course-platform/web/forms"
+	"github.com/PsionicAlch/course-platform/web/pages/pagestest"
+)
+
+func TestChangeFirstNamePost(t *testing.T) {
+	tests := []struct {
+		name       string
+		firstName  string
+		loggedOut  bool
+		remoteAddr string
+		failOn     string
+		status     int
+		location   string
+		wantName   string
+		wantEmails int
+	}{
+		{
+			name:      "Valid name",
+			firstName: "Grace",
+			status:    http.StatusFound,
+			location:  "/settings#change-first-name",
+			wantName:  "Grace",
+		},
+		{
+			name:      "Invalid name",
+			firstName: "Grace1",
+			status:    http.StatusOK,
+			wantName:  "Test",
+		},
+		{
+			name:      "Logged out",
+			firstName: "Grace",
+			loggedOut: true,
+			status:    http.StatusTemporaryRedirect,
+			location:  "/accounts/login",
+			wantName:  "Test",
+		},
+		{
+			name:      "Database error",
+			firstName: "Grace",
+			failOn:    "UpdateUserName",
+			status:    http.StatusFound,
+			location:  "/settings#change-first-name",
+			wantName:  "Test",
+		},
+		{
+			name:       "Unknown IP address",
+			firstName:  "Grace",
+			remoteAddr: "203.0.113.7:1234",
+			status:     http.StatusFound,
+			location:   "/settings#change-first-name",
+			wantName:   "Grace",
+			wantEmails: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := databasetest.NewDatabase()
+			user := databasetest.NewUserBuilder().Build()
+			db.SeedUsers(user)
+
+			// httptest sends every request from 192.0.2.1.
+			if err := db.AddIPAddress(ctx, user.ID, "192.0.2.1"); err != nil {
+				t.Fatalf("Failed to whitelist IP address: %s", err)
+			}
+
+			if tt.failOn != "" {
+				db.FailOn(tt.failOn, errors.New("database is down"))
+			}
+
+			handlerContext, mailbox := pagestest.NewHandlerContext(t, db)
+
+			form := url.Values{forms.FirstName: {tt.firstName}}
+			r := httptest.NewRequest(http.MethodPost, "/change-first-name", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			if tt.remoteAddr != "" {
+				r.RemoteAddr = tt.remoteAddr
+			}
+
+			if !tt.loggedOut {
+				r.AddCookie(pagestest.AuthCookie(t, handlerContext, user.ID))
+			}
+
+			w := httptest.NewRecorder()
+			RegisterRoutes(handlerContext).ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d. Got %d", tt.status, w.Code)
+			}
+
+			if location := w.Header().Get("Location"); location != tt.location {
+				t.Errorf("Expected to be redirected to \"%s\". Got \"%s\"", tt.location, location)
+			}
+
+			db.ClearErrors()
+
+			updated, err := db.GetUserByID(ctx, user.ID, database.All)
+			if err != nil || updated == nil {
+				t.Fatalf("Failed to get user: %v", err)
+			}
+
+			if updated.Name != tt.wantName {
+				t.Errorf("Expected the user's first name to be \"%s\". Got \"%s\"", tt.wantName, updated.Name)
+			}
+
+			// The suspicious activity email gets sent in the background.
+			deadline := time.Now().Add(5 * time.Second)
+			for len(mailbox.EmailsTo(user.Email)) < tt.wantEmails && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			if emails := mailbox.EmailsTo(user.Email); len(emails) != tt.wantEmails {
+				t.Errorf("Expected %d emails. Got %d", tt.wantEmails, len(emails))
+			}
+		})
+	}
+}
@@ -32,6 +32,10 @@ func RegisterRoutes(handlerContext *pages.HandlerContext) http.Handler {
 
 	router.Post("/request-refund/{course-id}", handlers.RequestRefundPost)
 
+	router.Post("/download-my-data", handlers.DownloadMyDataPost)
+
+	router.Get("/download-my-data/{token}", handlers.DownloadMyDataGet)
+
 	router.Delete("/delete-account", handlers.AccountDelete)
 
 	router.Post("/validate/change-password", handlers.ValidateChangePassword)
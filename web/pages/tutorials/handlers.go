@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strconv"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
 	"github.com/PsionicAlch/course-platform/internal/database"
@@ -83,7 +82,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 
 	tutorialSlug := chi.URLParam(r, "slug")
 
-	tutorial, err := h.Database.GetTutorialBySlug(tutorialSlug)
+	tutorial, err := h.Database.GetTutorialBySlug(r.Context(), tutorialSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get tutorial (\"%s\") in the database: %s\n", tutorialSlug, err)
 
@@ -95,6 +94,23 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if tutorial == nil {
+		// The tutorial might have been renamed so links to its old slug get sent to the current one.
+		currentSlug, err := h.Database.GetCurrentSlug(r.Context(), database.TutorialSlug, tutorialSlug)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to look up the current slug of tutorial (\"%s\"): %s\n", tutorialSlug, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		if currentSlug != "" {
+			utils.Redirect(w, r, fmt.Sprintf("/tutorials/%s", currentSlug), http.StatusMovedPermanently)
+			return
+		}
+
 		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-404", html.Errors404Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusNotFound); err != nil {
 			h.ErrorLog.Println(err)
 		}
@@ -104,7 +120,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Tutorial = tutorial
 
-	keywords, err := h.Database.GetAllKeywordsForTutorial(tutorial.ID)
+	keywords, err := h.Database.GetAllKeywordsForTutorial(r.Context(), tutorial.ID)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get keywords for tutorial (\"%s\") in the database: %s\n", tutorial.Title, err)
 
@@ -117,8 +133,53 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Keywords = keywords
 
+	series, err := h.Database.GetSeriesByTutorialID(r.Context(), tutorial.ID)
+	if err != nil {
+		h.ErrorLog.Printf("Failed to get series for tutorial (\"%s\") in the database: %s\n", tutorial.Title, err)
+
+		if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+			h.ErrorLog.Println(err)
+		}
+
+		return
+	}
+
+	if series != nil {
+		seriesTutorials, err := h.Database.GetSeriesTutorials(r.Context(), series.ID)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get tutorials for series (\"%s\") in the database: %s\n", series.Title, err)
+
+			if err := h.Renderers.Page.RenderHTML(w, r.Context(), "errors-500", html.Errors500Page{BasePage: html.NewBasePage(user, nosurf.Token(r))}, http.StatusInternalServerError); err != nil {
+				h.ErrorLog.Println(err)
+			}
+
+			return
+		}
+
+		// The previous and next tutorials are the neighbours of this tutorial in the reading order of the series.
+		for i, seriesTutorial := range seriesTutorials {
+			if seriesTutorial.ID != tutorial.ID {
+				continue
+			}
+
+			pageData.Series = series
+			pageData.SeriesTutorials = seriesTutorials
+			pageData.SeriesPart = i + 1
+
+			if i > 0 {
+				pageData.PreviousTutorial = seriesTutorials[i-1]
+			}
+
+			if i < len(seriesTutorials)-1 {
+				pageData.NextTutorial = seriesTutorials[i+1]
+			}
+
+			break
+		}
+	}
+
 	if user != nil {
-		userLikedTutorial, err := h.Database.UserLikedTutorial(user.ID, tutorialSlug)
+		userLikedTutorial, err := h.Database.UserLikedTutorial(r.Context(), user.ID, tutorialSlug)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to find out if user liked tutorial (\"%s\") from the database: %s\n", tutorialSlug, err)
 
@@ -129,7 +190,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		userBookmarkedTutorial, err := h.Database.UserBookmarkedTutorial(user.ID, tutorialSlug)
+		userBookmarkedTutorial, err := h.Database.UserBookmarkedTutorial(r.Context(), user.ID, tutorialSlug)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to find out if user bookmarked tutorial (\"%s\") from the database: %s\n", tutorialSlug, err)
 
@@ -149,7 +210,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 		authorId = tutorial.AuthorID.String
 	}
 
-	author, err := h.Database.GetUserByID(authorId, database.Author)
+	author, err := h.Database.GetUserByID(r.Context(), authorId, database.Author)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get author by ID (\"%s\") from the database: %s\n", authorId, err)
 
@@ -164,7 +225,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 
 	var course *models.CourseModel
 
-	courses, err := h.Database.GetCourses("", "", 1, 1)
+	courses, _, err := h.Database.GetCourses(r.Context(), "", "", nil, 1)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get author by ID (\"%s\") from the database: %s\n", authorId, err)
 	}
@@ -175,7 +236,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 
 	pageData.Course = course
 
-	comments, err := h.Database.GetAllCommentsPaginated(tutorial.ID, 1, CommentsPerPagination)
+	comments, next, err := h.Database.GetAllCommentsPaginated(r.Context(), tutorial.ID, nil, CommentsPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get comments for tutorial (\"%s\"): %s\n", tutorial.Title, err)
 		h.Session.SetErrorMessage(r.Context(), "Failed to get comments for tutorial.")
@@ -184,7 +245,7 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 	users := make(map[string]*models.UserModel)
 
 	for _, comment := range comments {
-		user, err := h.Database.GetUserByID(comment.UserID, database.All)
+		user, err := h.Database.GetUserByID(r.Context(), comment.UserID, database.All)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get user (\"%s\") for comment (\"%s\"): %s\n", comment.ID, comment.UserID, err)
 			h.Session.SetErrorMessage(r.Context(), "Failed to get comments for tutorial.")
@@ -195,19 +256,21 @@ func (h *Handlers) TutorialGet(w http.ResponseWriter, r *http.Request) {
 
 	var commentsSlice []*models.CommentModel
 	var lastComment *models.CommentModel
+	var queryURL string
 
-	if len(comments) < CommentsPerPagination {
+	if next == nil {
 		commentsSlice = comments
 	} else {
 		commentsSlice = comments[:len(comments)-1]
 		lastComment = comments[len(comments)-1]
+		queryURL = fmt.Sprintf("/tutorials/%s/comments?cursor=%s", tutorial.Slug, next.String())
 	}
 
 	pageData.Comments = &html.CommentsListComponent{
 		Comments:    commentsSlice,
 		LastComment: lastComment,
 		Users:       users,
-		QueryURL:    fmt.Sprintf("/tutorials/%s/comments?page=2", tutorial.Slug),
+		QueryURL:    queryURL,
 	}
 
 	if err := h.Renderers.Page.RenderHTML(w, r.Context(), "tutorials-tutorial", pageData); err != nil {
@@ -227,7 +290,7 @@ func (h *Handlers) LikeTutorialPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	likedTutorial, err := h.Database.UserLikedTutorial(user.ID, tutorialSlug)
+	likedTutorial, err := h.Database.UserLikedTutorial(r.Context(), user.ID, tutorialSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to see if user liked tutorial %s in the database: %s\n", tutorialSlug, err)
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "heart-empty", nil, http.StatusInternalServerError); err != nil {
@@ -238,7 +301,7 @@ func (h *Handlers) LikeTutorialPost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if likedTutorial {
-		if err := h.Database.UserDislikeTutorial(user.ID, tutorialSlug); err != nil {
+		if err := h.Database.UserDislikeTutorial(r.Context(), user.ID, tutorialSlug); err != nil {
 			h.ErrorLog.Printf("Failed to dislike tutorial %s in the database: %s\n", tutorialSlug, err)
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "heart-empty", nil, http.StatusInternalServerError); err != nil {
 				h.ErrorLog.Println(err)
@@ -251,7 +314,7 @@ func (h *Handlers) LikeTutorialPost(w http.ResponseWriter, r *http.Request) {
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.UserLikeTutorial(user.ID, tutorialSlug); err != nil {
+		if err := h.Database.UserLikeTutorial(r.Context(), user.ID, tutorialSlug); err != nil {
 			h.ErrorLog.Printf("Failed to like tutorial %s in the database: %s\n", tutorialSlug, err)
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "heart-filled", nil, http.StatusInternalServerError); err != nil {
 				h.ErrorLog.Println(err)
@@ -278,7 +341,7 @@ func (h *Handlers) BookmarkTutorialPost(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	bookmarkedTutorial, err := h.Database.UserBookmarkedTutorial(user.ID, tutorialSlug)
+	bookmarkedTutorial, err := h.Database.UserBookmarkedTutorial(r.Context(), user.ID, tutorialSlug)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to see if user bookmarked tutorial %s in the database: %s\n", tutorialSlug, err)
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "bookmark-empty", nil, http.StatusInternalServerError); err != nil {
@@ -289,7 +352,7 @@ func (h *Handlers) BookmarkTutorialPost(w http.ResponseWriter, r *http.Request)
 	}
 
 	if bookmarkedTutorial {
-		if err := h.Database.UserUnbookmarkTutorial(user.ID, tutorialSlug); err != nil {
+		if err := h.Database.UserUnbookmarkTutorial(r.Context(), user.ID, tutorialSlug); err != nil {
 			h.ErrorLog.Printf("Failed to unbookmark tutorial %s in the database: %s\n", tutorialSlug, err)
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "bookmark-empty", nil, http.StatusInternalServerError); err != nil {
 				h.ErrorLog.Println(err)
@@ -302,7 +365,7 @@ func (h *Handlers) BookmarkTutorialPost(w http.ResponseWriter, r *http.Request)
 			h.ErrorLog.Println(err)
 		}
 	} else {
-		if err := h.Database.UserBookmarkTutorial(user.ID, tutorialSlug); err != nil {
+		if err := h.Database.UserBookmarkTutorial(r.Context(), user.ID, tutorialSlug); err != nil {
 			h.ErrorLog.Printf("Failed to bookmark tutorial %s in the database: %s\n", tutorialSlug, err)
 			if err := h.Renderers.Htmx.RenderHTML(w, nil, "bookmark-filled", nil, http.StatusInternalServerError); err != nil {
 				h.ErrorLog.Println(err)
@@ -321,9 +384,9 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 	commentsList := &html.CommentsListComponent{}
 
 	tutorialSlug := chi.URLParam(r, "slug")
-	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	cursor, err := database.ParseCursor(r.URL.Query().Get("cursor"))
 	if err != nil {
-		h.ErrorLog.Printf("Failed to convert page URL query to int: %s\n", err)
+		h.ErrorLog.Printf("Failed to parse cursor URL query: %s\n", err)
 		commentsList.ErrorMessage = "Failed to load comments."
 
 		if err := h.Renderers.Htmx.RenderHTML(w, nil, "comments", commentsList, http.StatusBadRequest); err != nil {
@@ -333,7 +396,7 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comments, err := h.Database.GetAllCommentsBySlugPaginated(tutorialSlug, page, CommentsPerPagination)
+	comments, next, err := h.Database.GetAllCommentsBySlugPaginated(r.Context(), tutorialSlug, cursor, CommentsPerPagination)
 	if err != nil {
 		h.ErrorLog.Printf("Failed to get comments for tutorial (\"%s\"): %s\n", tutorialSlug, err)
 		commentsList.ErrorMessage = "Failed to load comments."
@@ -348,7 +411,7 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 	users := make(map[string]*models.UserModel)
 
 	for _, comment := range comments {
-		user, err := h.Database.GetUserByID(comment.UserID, database.All)
+		user, err := h.Database.GetUserByID(r.Context(), comment.UserID, database.All)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to get user (\"%s\") for comment (\"%s\"): %s\n", comment.ID, comment.UserID, err)
 			commentsList.ErrorMessage = "Failed to load comments."
@@ -366,17 +429,17 @@ func (h *Handlers) CommentsGet(w http.ResponseWriter, r *http.Request) {
 	var commentsSlice []*models.CommentModel
 	var lastComment *models.CommentModel
 
-	if len(comments) < CommentsPerPagination {
+	if next == nil {
 		commentsSlice = comments
 	} else {
 		commentsSlice = comments[:len(comments)-1]
 		lastComment = comments[len(comments)-1]
+		commentsList.QueryURL = fmt.Sprintf("/tutorials/%s/comments?cursor=%s", tutorialSlug, next.String())
 	}
 
 	commentsList.Comments = commentsSlice
 	commentsList.LastComment = lastComment
 	commentsList.Users = users
-	commentsList.QueryURL = fmt.Sprintf("/tutorials/%s/comments?page=%d", tutorialSlug, page+1)
 
 	if err := h.Renderers.Htmx.RenderHTML(w, nil, "comments", commentsList); err != nil {
 		h.ErrorLog.Println(err)
@@ -397,7 +460,7 @@ func (h *Handlers) CommentsPost(w http.ResponseWriter, r *http.Request) {
 
 		comment = goaway.Censor(comment)
 
-		commentModel, err := h.Database.AddCommentBySlug(comment, user.ID, tutorialSlug)
+		commentModel, err := h.Database.AddCommentBySlug(r.Context(), comment, user.ID, tutorialSlug)
 		if err != nil {
 			h.ErrorLog.Printf("Failed to add user's (\"%s\") comment on \"%s\" to the database: %s\n", user.ID, tutorialSlug, err)
 			return
@@ -414,40 +477,52 @@ func (h *Handlers) CommentsPost(w http.ResponseWriter, r *http.Request) {
 }
 
 // Possible URL queries:
-// -page
+// -cursor
 // -query
 func (h *Handlers) CreateTutorialsList(r *http.Request) (*html.TutorialsListComponent, error) {
 	query := r.URL.Query().Get("query")
-	page := 1
+	cursor, _ := database.ParseCursor(r.URL.Query().Get("cursor"))
 
 	urlQuery := make(url.Values)
-
-	if pageNum, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil {
-		page = pageNum
-	}
-
 	urlQuery.Add("query", query)
-	urlQuery.Add("page", fmt.Sprintf("%d", page+1))
 
-	tutorials, err := h.Database.GetTutorials(query, "", page, TutorialsPerPagination)
+	tutorials, next, err := h.Database.GetTutorials(r.Context(), query, "", cursor, TutorialsPerPagination)
 	if err != nil {
-		h.ErrorLog.Printf("Failed to get all tutorials (page %d): %s\n", page, err)
+		h.ErrorLog.Printf("Failed to get all tutorials: %s\n", err)
 		return nil, err
 	}
 
 	var tutorialsSlice []*models.TutorialModel
 	var lastTutorial *models.TutorialModel
 
-	if len(tutorials) < TutorialsPerPagination {
+	if next == nil {
 		tutorialsSlice = tutorials
 	} else {
 		tutorialsSlice = tutorials[:len(tutorials)-1]
 		lastTutorial = tutorials[len(tutorials)-1]
+
+		urlQuery.Add("cursor", next.String())
+	}
+
+	var snippets map[string]string
+
+	if query != "" {
+		ids := make([]string, 0, len(tutorials))
+		for _, tutorial := range tutorials {
+			ids = append(ids, tutorial.ID)
+		}
+
+		snippets, err = h.Database.GetTutorialSearchSnippets(r.Context(), query, ids)
+		if err != nil {
+			h.ErrorLog.Printf("Failed to get search snippets for tutorials that match \"%s\": %s\n", query, err)
+			return nil, err
+		}
 	}
 
 	tutorialList := &html.TutorialsListComponent{
 		Tutorials:    tutorialsSlice,
 		LastTutorial: lastTutorial,
+		Snippets:     snippets,
 		QueryURL:     fmt.Sprintf("/tutorials/htmx?%s", urlQuery.Encode()),
 	}
 
@@ -1,18 +1,27 @@
 package pages
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
+	migrations "github.com/PsionicAlch/course-platform/db"
 	"github.com/PsionicAlch/course-platform/internal/authentication"
+	"github.com/PsionicAlch/course-platform/internal/backup"
+	"github.com/PsionicAlch/course-platform/internal/bucket"
+	awss3 "github.com/PsionicAlch/course-platform/internal/bucket/aws_s3"
 	"github.com/PsionicAlch/course-platform/internal/cache"
 	gocache "github.com/PsionicAlch/course-platform/internal/cache/go-cache"
 	"github.com/PsionicAlch/course-platform/internal/database"
+	"github.com/PsionicAlch/course-platform/internal/database/instrumented_database"
+	"github.com/PsionicAlch/course-platform/internal/database/postgres_database"
 	"github.com/PsionicAlch/course-platform/internal/database/sqlite_database"
+	"github.com/PsionicAlch/course-platform/internal/maintenance"
 	"github.com/PsionicAlch/course-platform/internal/payments"
 	"github.com/PsionicAlch/course-platform/internal/render"
 	vanillahtml "github.com/PsionicAlch/course-platform/internal/render/renderers/vanilla_html"
 	vanillatext "github.com/PsionicAlch/course-platform/internal/render/renderers/vanilla_text"
+	"github.com/PsionicAlch/course-platform/internal/scheduler"
 	"github.com/PsionicAlch/course-platform/internal/session"
 	"github.com/PsionicAlch/course-platform/internal/utils"
 	"github.com/PsionicAlch/course-platform/web/config"
@@ -22,6 +31,9 @@ import (
 	"github.com/PsionicAlch/sitemapper"
 )
 
+// sqliteFileName is where the SQLite database lives, relative to the root of the project.
+const sqliteFileName = "/db/db.sqlite"
+
 type Renderers struct {
 	Page render.Renderer
 	Htmx render.Renderer
@@ -63,6 +75,10 @@ func CreateHandlerContext() (*HandlerContext, error) {
 		return nil, err
 	}
 
+	if err := VerifySchemaVersion(db); err != nil {
+		return nil, err
+	}
+
 	// Set up authentication system.
 	auth, err := SetupAuthentication(db, sessions)
 	if err != nil {
@@ -125,14 +141,63 @@ func SetupRenderers(sessions *session.Session) (*Renderers, error) {
 }
 
 func SetupDatabase() (database.Database, error) {
-	db, err := sqlite_database.CreateSQLiteDatabase("/db/db.sqlite", "/db/migrations")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create database connection: %w", err)
+	var db database.Database
+
+	if config.UsingPostgres() {
+		dataSourceName := config.GetWithoutError[string]("DATABASE_URL")
+		if dataSourceName == "" {
+			return nil, errors.New("DATABASE_URL needs to be set when using the postgres database driver")
+		}
+
+		postgresDB, err := postgres_database.CreatePostgresDatabase(dataSourceName, migrations.PostgresMigrations())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create database connection: %w", err)
+		}
+
+		db = postgresDB
+	} else {
+		sqliteDB, err := sqlite_database.CreateSQLiteDatabase(sqliteFileName, migrations.SQLiteMigrations())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create database connection: %w", err)
+		}
+
+		db = sqliteDB
+	}
+
+	// Wrap the database so that we can see how long each call takes. The numbers are shown on the admin metrics
+	// page.
+	if config.GetWithoutError[bool]("DATABASE_INSTRUMENTATION") {
+		threshold := time.Duration(config.GetWithoutError[int]("SLOW_QUERY_THRESHOLD")) * time.Millisecond
+		db = instrumented_database.CreateInstrumentedDatabase(db, threshold)
 	}
 
 	return db, nil
 }
 
+// VerifySchemaVersion makes sure that the database schema is at the version of the newest migration that was embedded
+// into this build. Running against an older or newer schema would only surface as broken queries later on.
+func VerifySchemaVersion(db database.Database) error {
+	version, dirty, err := db.MigrationVersion()
+	if err != nil {
+		return fmt.Errorf("failed to get database schema version: %w", err)
+	}
+
+	if dirty {
+		return fmt.Errorf("database schema is dirty at version %d, fix the failed migration and run \"go run ./cmd/migrate force <version>\"", version)
+	}
+
+	available, err := db.Migrations()
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	if expected := database.LatestMigration(available); version != expected {
+		return fmt.Errorf("database schema is at version %d but this build expects version %d, run \"make migrate-up\" first", version, expected)
+	}
+
+	return nil
+}
+
 func SetupAuthentication(db database.Database, sessions *session.Session) (*authentication.Authentication, error) {
 	authLifetime := time.Duration(config.GetWithoutError[int]("AUTH_TOKEN_LIFETIME")) * time.Minute
 	pwdResetLifetime := time.Minute * 30
@@ -156,13 +221,22 @@ func SetupPayments(db database.Database, emailer *emails.Emails) *payments.Payme
 }
 
 func SetupEmailer() (*emails.Emails, error) {
+	emailRenderer, err := SetupEmailRenderer()
+	if err != nil {
+		return nil, err
+	}
+
+	return emails.SetupEmails(emailRenderer), nil
+}
+
+func SetupEmailRenderer() (render.Renderer, error) {
 	cloudfrontURL := config.GetWithoutError[string]("CLOUDFRONT_URL")
 	emailRenderer, err := vanillahtml.SetupVanillaHTMLRenderer(cloudfrontURL, nil, html.HTMLFiles, ".email.tmpl", "emails", "layouts/email.layout.tmpl")
 	if err != nil {
 		return nil, fmt.Errorf("failed to set up email renderer: %w", err)
 	}
 
-	return emails.SetupEmails(emailRenderer), nil
+	return emailRenderer, nil
 }
 
 func SetupCache(db database.Database, xmlRenderer render.Renderer) cache.Cache {
@@ -173,10 +247,69 @@ func SetupCache(db database.Database, xmlRenderer render.Renderer) cache.Cache {
 		CoursesRSSFeed:         generators.CoursesRSSFeed(utils.CreateLoggers("COURSES RSS FEED GENERATOR"), db, xmlRenderer),
 		AuthorTutorialsRSSFeed: generators.AuthorTutorialsRSSFeed(utils.CreateLoggers("AUTHOR TUTORIALS RSS FEED GENERATOR"), db, xmlRenderer),
 		AuthorCoursesRSSFeed:   generators.AuthorCoursesRSSFeed(utils.CreateLoggers("AUTHOR COURSES RSS FEED GENERATOR"), db, xmlRenderer),
+		SeriesRSSFeed:          generators.SeriesRSSFeed(utils.CreateLoggers("SERIES RSS FEED GENERATOR"), db, xmlRenderer),
 	}
 	return gocache.SetupGoCache(gens)
 }
 
+func SetupMaintenance(db database.Database) *maintenance.Maintenance {
+	interval := time.Duration(config.GetWithoutError[int]("MAINTENANCE_INTERVAL")) * time.Minute
+	purchaseWindow := time.Duration(config.GetWithoutError[int]("PENDING_PURCHASE_WINDOW")) * time.Minute
+	retentionPeriod := time.Duration(config.GetWithoutError[int]("SOFT_DELETE_RETENTION_PERIOD")) * 24 * time.Hour
+
+	return maintenance.SetupMaintenance(db, interval, purchaseWindow, retentionPeriod)
+}
+
+func SetupScheduler(db database.Database, c cache.Cache, emailer *emails.Emails) *scheduler.Scheduler {
+	interval := time.Duration(config.GetWithoutError[int]("PUBLISH_SCHEDULE_INTERVAL")) * time.Minute
+
+	return scheduler.SetupScheduler(db, c, emailer, interval)
+}
+
+// SetupBackup creates the database backup tool. Backups rely on SQLite's "VACUUM INTO" so they are only available
+// when using the sqlite database driver.
+func SetupBackup() (*backup.Backup, error) {
+	if !config.UsingSQLite() {
+		return nil, errors.New("database backups are only supported when using the sqlite database driver")
+	}
+
+	directory := config.GetWithoutError[string]("BACKUP_DIRECTORY")
+	keep := config.GetWithoutError[int]("BACKUP_KEEP")
+
+	var b bucket.Bucket
+
+	if config.GetWithoutError[bool]("BACKUP_UPLOAD") {
+		s3Bucket, err := SetupBucket()
+		if err != nil {
+			return nil, err
+		}
+
+		b = s3Bucket
+	}
+
+	return backup.SetupBackup("."+sqliteFileName, directory, keep, b), nil
+}
+
+func SetupBucket() (*awss3.S3Bucket, error) {
+	variables := make(map[string]string)
+
+	for _, name := range []string{"REGION", "ACCESS_KEY_ID", "SECRET_ACCESS_KEY", "BUCKET_NAME"} {
+		variable, err := config.Get[string](name)
+		if err != nil || variable == "" {
+			return nil, fmt.Errorf("%s needs to be set to upload files to the bucket", name)
+		}
+
+		variables[name] = variable
+	}
+
+	s3Bucket, err := awss3.SetupS3Bucket(variables["REGION"], variables["ACCESS_KEY_ID"], variables["SECRET_ACCESS_KEY"], variables["BUCKET_NAME"])
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up bucket: %w", err)
+	}
+
+	return s3Bucket, nil
+}
+
 func SetupSiteMapper() *sitemapper.SiteMapper {
 	loggers := utils.CreateLoggers("SITEMAPPER")
 
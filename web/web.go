@@ -1,9 +1,14 @@
 package web
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/PsionicAlch/course-platform/internal/authentication"
@@ -20,6 +25,7 @@ import (
 	"github.com/PsionicAlch/course-platform/web/pages/general"
 	"github.com/PsionicAlch/course-platform/web/pages/profile"
 	"github.com/PsionicAlch/course-platform/web/pages/rss"
+	"github.com/PsionicAlch/course-platform/web/pages/series"
 	"github.com/PsionicAlch/course-platform/web/pages/settings"
 	"github.com/PsionicAlch/course-platform/web/pages/sitemap"
 	"github.com/PsionicAlch/course-platform/web/pages/tutorials"
@@ -28,6 +34,22 @@ import (
 	"github.com/justinas/nosurf"
 )
 
+const (
+	// defaultTimeout is the maximum amount of time a regular page request is allowed to take.
+	defaultTimeout = 15 * time.Second
+
+	// adminTimeout is the maximum amount of time an admin page request is allowed to take. Admin pages run heavier
+	// queries across whole tables so they get more time than the regular pages.
+	adminTimeout = 30 * time.Second
+
+	// webhookTimeout is the maximum amount of time the payments webhook is allowed to take.
+	webhookTimeout = 30 * time.Second
+
+	// shutdownTimeout is the maximum amount of time the server waits for requests that are still in flight to finish
+	// once it has been asked to stop.
+	shutdownTimeout = 30 * time.Second
+)
+
 func StartWeb() {
 	// Set up config.
 	if err := config.SetupConfig(); err != nil {
@@ -42,6 +64,28 @@ func StartWeb() {
 		loggers.ErrorLog.Fatalln(err)
 	}
 
+	// The background workers and the server get stopped when the process receives SIGINT or SIGTERM.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var workers sync.WaitGroup
+
+	// Start background database maintenance.
+	pages.SetupMaintenance(handlerContext.Database).Start(ctx, &workers)
+
+	// Start publishing and unpublishing scheduled tutorials and courses, and emailing learners about released chapters.
+	pages.SetupScheduler(handlerContext.Database, handlerContext.Cache, handlerContext.Emailer).Start(ctx, &workers)
+
+	// Start scheduled database backups.
+	if backupInterval := config.GetWithoutError[int]("BACKUP_INTERVAL"); backupInterval > 0 {
+		backups, err := pages.SetupBackup()
+		if err != nil {
+			loggers.ErrorLog.Fatalln(err)
+		}
+
+		backups.Start(ctx, &workers, time.Duration(backupInterval)*time.Minute)
+	}
+
 	// Create new router.
 	router := chi.NewRouter()
 
@@ -53,20 +97,22 @@ func StartWeb() {
 	router.Use(pm.RateLimiter(25, time.Minute, handlerContext.Renderers.Page))
 
 	// Register payments webhook.
-	router.Post("/payments/webhook", handlerContext.Payment.Webhook)
-
-	// Set up routes.
-	router.Mount("/rss", rss.RegisterRoutes(handlerContext))
-	router.Mount("/sitemap", sitemap.RegisterRoutes(handlerContext))
-	router.Mount("/", general.RegisterRoutes(handlerContext))
-	router.Mount("/accounts", accounts.RegisterRoutes(handlerContext))
-	router.Mount("/profile", profile.RegisterRoutes(handlerContext))
-	router.Mount("/tutorials", tutorials.RegisterRoutes(handlerContext))
-	router.Mount("/courses", courses.RegisterRoutes(handlerContext))
-	router.Mount("/settings", settings.RegisterRoutes(handlerContext))
-	router.Mount("/admin", admin.RegisterRoutes(handlerContext))
-	router.Mount("/authors", authors.RegisterRoutes(handlerContext))
-	router.Mount("/certificates", certificates.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(webhookTimeout)).Post("/payments/webhook", handlerContext.Payment.Webhook)
+
+	// Set up routes. Each group of routes gets a request timeout so that slow database queries get cancelled once the
+	// client is no longer waiting on them.
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/rss", rss.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/sitemap", sitemap.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/", general.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/accounts", accounts.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/profile", profile.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/tutorials", tutorials.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/courses", courses.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/settings", settings.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(adminTimeout)).Mount("/admin", admin.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/authors", authors.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/series", series.RegisterRoutes(handlerContext))
+	router.With(middleware.Timeout(defaultTimeout)).Mount("/certificates", certificates.RegisterRoutes(handlerContext))
 
 	// Set up 404 handler.
 	router.With(handlerContext.Authentication.SetUser, handlerContext.Session.SessionMiddleware).NotFound(func(w http.ResponseWriter, r *http.Request) {
@@ -79,6 +125,48 @@ func StartWeb() {
 
 	// Start server.
 	port := config.GetWithoutError[string]("PORT")
-	loggers.InfoLog.Println("Starting server on port:", port)
-	loggers.ErrorLog.Fatal(http.ListenAndServe(fmt.Sprintf(":%s", port), router))
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%s", port),
+		Handler: router,
+	}
+
+	serverErr := make(chan error, 1)
+
+	go func() {
+		loggers.InfoLog.Println("Starting server on port:", port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	var failed bool
+
+	select {
+	case err := <-serverErr:
+		loggers.ErrorLog.Printf("Server stopped unexpectedly: %s\n", err)
+		failed = true
+	case <-ctx.Done():
+		loggers.InfoLog.Println("Shutting down server")
+	}
+
+	// Stop listening for signals so that a second SIGINT kills the process straight away. This also stops the
+	// background workers when the server stopped on its own.
+	stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		loggers.ErrorLog.Printf("Failed to wait for in flight requests to finish: %s\n", err)
+	}
+
+	cancel()
+
+	// The background workers might be busy with the database so they need to finish before it gets closed.
+	workers.Wait()
+
+	if err := handlerContext.Database.Close(); err != nil {
+		loggers.ErrorLog.Printf("Failed to close database: %s\n", err)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
 }